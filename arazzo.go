@@ -0,0 +1,122 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	gocontext "context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	higharazzo "github.com/pb33f/libopenapi/datamodel/high/arazzo"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowarazzo "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ErrInvalidArazzo is returned when the bytes supplied to NewArazzoDocument are not an Arazzo document.
+var ErrInvalidArazzo = errors.New("invalid arazzo document, the 'arazzo' version is missing")
+
+// NewArazzoDocument creates a new Arazzo workflows document from the provided bytes. Like the OpenAPI models,
+// every object of the Arazzo model can 'GoLow()' to reach the original yaml nodes, with their line and column
+// numbers. The OpenAPI documents the workflows describe can be opened with Workspace.OpenArazzoSources.
+func NewArazzoDocument(arazzoBytes []byte) (*higharazzo.Arazzo, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(arazzoBytes, &node); err != nil {
+		return nil, err
+	}
+	if len(node.Content) == 0 || !utils.IsNodeMap(node.Content[0]) {
+		return nil, ErrInvalidArazzo
+	}
+	if _, version := utils.FindKeyNodeTop(lowarazzo.ArazzoLabel, node.Content[0].Content); version == nil {
+		return nil, ErrInvalidArazzo
+	}
+
+	var lowArazzo lowarazzo.Arazzo
+	if err := low.BuildModel(node.Content[0], &lowArazzo); err != nil {
+		return nil, err
+	}
+	if err := lowArazzo.Build(gocontext.Background(), nil, node.Content[0], nil); err != nil {
+		return nil, err
+	}
+	return higharazzo.NewArazzo(&lowArazzo), nil
+}
+
+// OpenArazzoSources opens (and builds) every OpenAPI source description of an Arazzo document in the workspace,
+// and returns them keyed by the name of the source description. Because they are opened by the workspace, the
+// source documents share its file systems (and every file they reference) with each other, and with any other
+// document the workspace opens.
+//
+// The arazzoPath is the location of the Arazzo document (relative paths are relative to the BasePath of the
+// workspace), relative source description URLs are resolved from it. Remote URLs are only opened if the workspace
+// allows remote references. Source descriptions of the 'arazzo' type are skipped.
+//
+// Every source description that could be opened is returned, along with the errors for those that could not (or
+// that had errors building their model).
+func (w *Workspace) OpenArazzoSources(arazzo *higharazzo.Arazzo, arazzoPath string) (map[string]Document, error) {
+	if arazzo == nil {
+		return nil, errors.New("unable to open arazzo sources, no arazzo document supplied")
+	}
+	arazzoDir := filepath.Dir(w.absolutePath(arazzoPath))
+	documents := make(map[string]Document)
+	var errs []error
+	for _, source := range arazzo.SourceDescriptions {
+		if source.Type == lowarazzo.SourceDescriptionTypeArazzo {
+			continue
+		}
+		var doc Document
+		var err error
+		if u, parseErr := url.Parse(source.URL); parseErr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+			doc, err = w.openRemoteDocument(u)
+		} else {
+			location := strings.TrimPrefix(source.URL, "file://")
+			if !filepath.IsAbs(location) {
+				location = filepath.Join(arazzoDir, filepath.FromSlash(location))
+			}
+			doc, err = w.OpenDocument(location)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to open source description '%s': %w", source.Name, err))
+		}
+		if doc != nil {
+			documents[source.Name] = doc
+		}
+	}
+	return documents, errors.Join(errs...)
+}
+
+// openRemoteDocument opens (and builds) a document from a remote location, using the remote file system of the
+// workspace. If the document has already been opened, the same Document is returned.
+func (w *Workspace) openRemoteDocument(location *url.URL) (Document, error) {
+	if w.remoteFS == nil {
+		return nil, fmt.Errorf("remote references are not allowed, unable to open '%s'", location)
+	}
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	key := location.String()
+	if doc, ok := w.documents[key]; ok {
+		return doc, nil
+	}
+	file, err := w.remoteFS.Open(key)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	base := *location
+	base.Path = path.Dir(location.Path)
+	config := w.documentConfiguration()
+	config.BaseURL = &base
+	config.SpecFilePath = path.Base(location.Path)
+	return w.buildDocument(key, spec, config)
+}
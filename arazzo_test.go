@@ -0,0 +1,140 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const arazzoPets = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: findPets
+      responses:
+        '200':
+          description: pets`
+
+func TestNewArazzoDocument(t *testing.T) {
+	arazzo, err := NewArazzoDocument([]byte(`arazzo: 1.0.1
+info:
+  title: Adopt a pet
+  version: 1.0.0
+sourceDescriptions:
+  - name: petStore
+    url: ./pets.yaml
+    type: openapi
+workflows:
+  - workflowId: findPets
+    steps:
+      - stepId: find
+        operationId: $sourceDescriptions.petStore.findPets
+        successCriteria:
+          - condition: $statusCode == 200`))
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", arazzo.Arazzo)
+	assert.Equal(t, "petStore", arazzo.SourceDescriptions[0].Name)
+
+	step := arazzo.Workflows[0].Steps[0]
+	assert.Equal(t, "$statusCode == 200", step.SuccessCriteria[0].Condition)
+	assert.Equal(t, 12, step.GoLow().RootNode.Line)
+}
+
+func TestNewArazzoDocument_Invalid(t *testing.T) {
+	_, err := NewArazzoDocument([]byte(`openapi: 3.1.0`))
+	assert.ErrorIs(t, err, ErrInvalidArazzo)
+	_, err = NewArazzoDocument([]byte(`- arazzo`))
+	assert.ErrorIs(t, err, ErrInvalidArazzo)
+	_, err = NewArazzoDocument([]byte(`arazzo: [`))
+	assert.Error(t, err)
+}
+
+func TestWorkspace_OpenArazzoSources(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"specs/pets.yaml":             arazzoPets,
+		"workflows/adopt.arazzo.yaml": "",
+	})
+	arazzo, err := NewArazzoDocument([]byte(`arazzo: 1.0.1
+info:
+  title: Adopt a pet
+  version: 1.0.0
+sourceDescriptions:
+  - name: petStore
+    url: ../specs/pets.yaml
+    type: openapi
+  - name: shared
+    url: ./shared.arazzo.yaml
+    type: arazzo
+  - name: missing
+    url: ../specs/missing.yaml`))
+	require.NoError(t, err)
+
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: dir})
+	require.NoError(t, err)
+	docs, err := ws.OpenArazzoSources(arazzo, "workflows/adopt.arazzo.yaml")
+	assert.ErrorContains(t, err, "unable to open source description 'missing'")
+	require.Len(t, docs, 1)
+
+	model, errs := docs["petStore"].BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, "findPets", model.Model.Paths.PathItems.GetOrZero("/pets").Get.OperationId)
+
+	// the source was opened by the workspace, so opening it again returns the same document.
+	pets, err := ws.OpenDocument(filepath.Join("specs", "pets.yaml"))
+	require.NoError(t, err)
+	assert.Same(t, docs["petStore"], pets)
+
+	_, err = ws.OpenArazzoSources(nil, "")
+	assert.Error(t, err)
+}
+
+func TestWorkspace_OpenArazzoSources_Remote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(arazzoPets))
+	}))
+	defer server.Close()
+
+	arazzo, err := NewArazzoDocument([]byte(`arazzo: 1.0.1
+info:
+  title: Adopt a pet
+  version: 1.0.0
+sourceDescriptions:
+  - name: petStore
+    url: ` + server.URL + `/specs/pets.yaml`))
+	require.NoError(t, err)
+
+	local, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: t.TempDir()})
+	require.NoError(t, err)
+	docs, err := local.OpenArazzoSources(arazzo, "adopt.arazzo.yaml")
+	assert.ErrorContains(t, err, "remote references are not allowed")
+	assert.Empty(t, docs)
+
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{
+		BasePath:              t.TempDir(),
+		AllowRemoteReferences: true,
+	})
+	require.NoError(t, err)
+	docs, err = ws.OpenArazzoSources(arazzo, "adopt.arazzo.yaml")
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	model, errs := docs["petStore"].BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, "Pets", model.Model.Info.Title)
+	assert.Contains(t, ws.GetDocuments(), server.URL+"/specs/pets.yaml")
+
+	again, err := ws.OpenArazzoSources(arazzo, "adopt.arazzo.yaml")
+	require.NoError(t, err)
+	assert.Same(t, docs["petStore"], again["petStore"])
+}
@@ -0,0 +1,84 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// BuildStats contains timings and reference counts captured while a Document was parsed and a model was built
+// from it. It allows the cost of each document to be reported (and regressed) without wrapping every call with
+// timers.
+//
+// Timings are wall-clock durations. ModelBuildDuration covers everything in the build that is not indexing or
+// resolving, which is mostly the creation of the low and high level models.
+type BuildStats struct {
+	ParseDuration      time.Duration // time taken to parse the specification bytes into a yaml.Node tree.
+	IndexDuration      time.Duration // time taken to index the rolodex (root document and all referenced files).
+	ResolveDuration    time.Duration // time taken to check for circular references.
+	ModelBuildDuration time.Duration // time taken to build the low and high level models.
+	TotalDuration      time.Duration // total time taken to build the model, including ParseDuration.
+
+	TotalReferences     int // every $ref found across all indexes.
+	LocalReferences     int // references that point inside the same file (e.g. '#/components/schemas/Pet').
+	FileReferences      int // references that point to another local file.
+	RemoteReferences    int // references that point to a remote (http/https) location.
+	ExtensionReferences int // references found under an extension (x-*) path.
+	CircularReferences  int // circular references found, including safe and ignored ones.
+	IndexCount          int // number of indexes held by the rolodex, including the root index.
+}
+
+// newBuildStats creates a BuildStats from the supplied timings and the state of the rolodex after a build.
+func newBuildStats(parse, total time.Duration, rolodex *index.Rolodex) *BuildStats {
+	stats := &BuildStats{
+		ParseDuration: parse,
+		TotalDuration: total + parse,
+	}
+	if rolodex == nil {
+		stats.ModelBuildDuration = total
+		return stats
+	}
+	stats.IndexDuration = rolodex.GetIndexingDuration()
+	stats.ResolveDuration = rolodex.GetCircularCheckDuration()
+	stats.ModelBuildDuration = total - stats.IndexDuration - stats.ResolveDuration
+	if stats.ModelBuildDuration < 0 {
+		stats.ModelBuildDuration = 0
+	}
+	indexes := rolodex.GetIndexes()
+	if root := rolodex.GetRootIndex(); root != nil {
+		indexes = append([]*index.SpecIndex{root}, indexes...)
+	}
+	seen := make(map[*index.SpecIndex]struct{}, len(indexes))
+	for _, idx := range indexes {
+		if idx == nil {
+			continue
+		}
+		if _, ok := seen[idx]; ok {
+			continue
+		}
+		seen[idx] = struct{}{}
+		stats.IndexCount++
+		stats.CircularReferences += len(idx.GetCircularReferences()) +
+			len(idx.GetIgnoredPolymorphicCircularReferences()) +
+			len(idx.GetIgnoredArrayCircularReferences())
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			stats.TotalReferences++
+			if ref.IsExtensionRef {
+				stats.ExtensionReferences++
+			}
+			switch {
+			case strings.HasPrefix(ref.Definition, "#"):
+				stats.LocalReferences++
+			case strings.HasPrefix(ref.Definition, "http://"), strings.HasPrefix(ref.Definition, "https://"):
+				stats.RemoteReferences++
+			default:
+				stats.FileReferences++
+			}
+		}
+	}
+	return stats
+}
@@ -0,0 +1,109 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_BuildV3Model_Stats(t *testing.T) {
+	spec := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      x-pet:
+        $ref: '#/components/schemas/Pet'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [parent]
+      properties:
+        parent:
+          $ref: '#/components/schemas/Pet'`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	m, _ := doc.BuildV3Model()
+	require.NotNil(t, m)
+	require.NotNil(t, m.Stats)
+
+	stats := m.Stats
+	assert.Equal(t, 3, stats.TotalReferences)
+	assert.Equal(t, 3, stats.LocalReferences)
+	assert.Equal(t, 0, stats.FileReferences)
+	assert.Equal(t, 0, stats.RemoteReferences)
+	assert.Equal(t, 1, stats.ExtensionReferences)
+	assert.Equal(t, 1, stats.CircularReferences)
+	assert.Equal(t, 1, stats.IndexCount)
+
+	assert.Greater(t, stats.ParseDuration, time.Duration(0))
+	assert.Greater(t, stats.IndexDuration, time.Duration(0))
+	assert.Greater(t, stats.TotalDuration, time.Duration(0))
+	assert.GreaterOrEqual(t, stats.TotalDuration,
+		stats.ParseDuration+stats.IndexDuration+stats.ResolveDuration+stats.ModelBuildDuration)
+}
+
+func TestDocument_BuildV3Model_Stats_FileReferences(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte(`type: object`), 0o644))
+	spec := []byte(`openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'`)
+
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		AllowFileReferences: true,
+		BasePath:            dir,
+	})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, 2, m.Stats.TotalReferences)
+	assert.Equal(t, 1, m.Stats.LocalReferences)
+	assert.Equal(t, 1, m.Stats.FileReferences)
+	assert.Equal(t, 2, m.Stats.IndexCount)
+}
+
+func TestDocument_BuildV2Model_Stats(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV2Model()
+	require.NoError(t, errs)
+	require.NotNil(t, m.Stats)
+	assert.Greater(t, m.Stats.TotalReferences, 0)
+	assert.Equal(t, m.Stats.TotalReferences, m.Stats.LocalReferences)
+	assert.Greater(t, m.Stats.TotalDuration, time.Duration(0))
+}
+
+func TestNewBuildStats_NoRolodex(t *testing.T) {
+	stats := newBuildStats(10, 20, nil)
+	assert.Equal(t, time.Duration(10), stats.ParseDuration)
+	assert.Equal(t, time.Duration(20), stats.ModelBuildDuration)
+	assert.Equal(t, time.Duration(30), stats.TotalDuration)
+	assert.Zero(t, stats.TotalReferences)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel"
 	highbase "github.com/pb33f/libopenapi/datamodel/high/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
@@ -27,6 +28,9 @@ import (
 // ErrInvalidModel is returned when the model is not usable.
 var ErrInvalidModel = errors.New("invalid model")
 
+// ErrNameCollision is returned when the NameCollisionHandler of the composition config fails a name collision.
+var ErrNameCollision = errors.New("component name collision")
+
 // BundleBytes will take a byte slice of an OpenAPI specification and return a bundled version of it.
 // This is useful for when you want to take a specification with external references, and you want to bundle it
 // into a single document.
@@ -35,12 +39,18 @@ var ErrInvalidModel = errors.New("invalid model")
 // document will be a valid OpenAPI specification, containing no references.
 //
 // Circular references will not be resolved and will be skipped.
+//
+// Swagger (version 2) specifications are bundled with BundleSwaggerDocument.
 func BundleBytes(bytes []byte, configuration *datamodel.DocumentConfiguration) ([]byte, error) {
 	doc, err := libopenapi.NewDocumentWithConfiguration(bytes, configuration)
 	if err != nil {
 		return nil, err
 	}
 
+	if isSwagger(doc) {
+		return bundleSwaggerBytes(doc, false, nil, nil)
+	}
+
 	v3Doc, err := doc.BuildV3Model()
 	if v3Doc == nil {
 		return nil, errors.Join(ErrInvalidModel, err)
@@ -55,12 +65,22 @@ func BundleBytes(bytes []byte, configuration *datamodel.DocumentConfiguration) (
 //
 // Composed means that every external file will have references lifted out and added to the `components` section of the document.
 // Names will be preserved where possible, conflicts will dealt with by using a delimiter and appending a number.
+//
+// Swagger (version 2) specifications are composed with BundleSwaggerDocumentComposed.
 func BundleBytesComposed(bytes []byte, configuration *datamodel.DocumentConfiguration, compositionConfig *BundleCompositionConfig) ([]byte, error) {
 	doc, err := libopenapi.NewDocumentWithConfiguration(bytes, configuration)
 	if err != nil {
 		return nil, err
 	}
 
+	if isSwagger(doc) {
+		compositionConfig, err = checkCompositionConfig(compositionConfig)
+		if err != nil {
+			return nil, err
+		}
+		return bundleSwaggerBytes(doc, true, compositionConfig, nil)
+	}
+
 	v3Doc, err := doc.BuildV3Model()
 	if v3Doc == nil || err != nil {
 		return nil, errors.Join(ErrInvalidModel, err)
@@ -94,6 +114,10 @@ func BundleBytesWithConfig(bytes []byte, configuration *datamodel.DocumentConfig
 		return nil, err
 	}
 
+	if isSwagger(doc) {
+		return bundleSwaggerBytes(doc, false, nil, bundleConfig)
+	}
+
 	v3Doc, err := doc.BuildV3Model()
 	if v3Doc == nil {
 		return nil, errors.Join(ErrInvalidModel, err)
@@ -114,8 +138,94 @@ func BundleDocumentWithConfig(model *v3.Document, bundleConfig *BundleInlineConf
 
 // BundleCompositionConfig is used to configure the composition of OpenAPI documents when using BundleDocumentComposed.
 type BundleCompositionConfig struct {
-	Delimiter           string // Delimiter is used to separate clashing names. Defaults to `__`.
-	StrictValidation    bool   // StrictValidation will cause bundling to fail on invalid OpenAPI specs (e.g. $ref with siblings)
+	Delimiter        string // Delimiter is used to separate clashing names. Defaults to `__`.
+	StrictValidation bool   // StrictValidation will cause bundling to fail on invalid OpenAPI specs (e.g. $ref with siblings)
+
+	// NamingStrategy decides how a component is renamed when its name clashes with a component that has already
+	// been composed. Defaults to NamingStrategyDefault.
+	NamingStrategy NamingStrategy
+
+	// NameCollisionHandler is called when the name of a component clashes with a component that has already been
+	// composed, or is in the root document, before the NamingStrategy is used. It is given the content of both
+	// components, and decides what happens to the incoming one:
+	//   - a different name renames it. If the name is also taken, the NamingStrategy is used instead.
+	//   - the name of the collision merges it with the existing component, every reference to it points to the
+	//     existing one.
+	//   - an empty name leaves it to the NamingStrategy.
+	//   - an error fails the composition, with an ErrNameCollision.
+	NameCollisionHandler func(collision *NameCollision) (string, error)
+
+	// Provenance when true, annotates every composed (or inlined) component with an `x-bundled-from` extension that
+	// holds the file (relative to the root document) and JSON pointer it was lifted from. This is useful for audits,
+	// and for debugging name collisions.
+	Provenance bool
+
+	// ComponentOrder decides the order of the components lifted into the root document. Sorting them makes
+	// repeated bundling of the same inputs byte-for-byte reproducible. Defaults to ComponentOrderComposed.
+	ComponentOrder ComponentOrder
+
+	// DeduplicateComponents when true, merges components with clashing names that are structurally identical (the
+	// same content, in any key order, with references that point to the same place) into one component, instead of
+	// renaming one of them. Every reference points to the one component.
+	DeduplicateComponents bool
+
+	// Workers is the number of indexes (files) that are scanned for references at the same time when composing.
+	// Large specifications with many external files compose faster with more workers. The result is the same
+	// whatever the number of workers. Defaults to 0 (indexes are scanned one at a time).
+	Workers int
+
+	// Output controls the format (YAML or JSON), indentation and key quoting of the composed bundle.
+	// Defaults to nil (YAML, rendered by the model).
+	Output *BundleOutputConfig
+
+	// EventHandler is called with events as references are discovered, composed or inlined, and as files are
+	// processed, so long composition runs can drive progress bars and structured logs. Defaults to nil.
+	EventHandler BundleEventHandler
+
+	// PreserveComments when true, keeps the comments (head, line and foot) of the root document and of every
+	// component lifted from another file, including the comment written above the name of the component, so
+	// licensing and doc comments survive bundling. Defaults to false (comments are dropped when rendering).
+	PreserveComments bool
+
+	// InlineExternalExamples when true, embeds the content of example payload files as the `value` of the examples
+	// that point to them, so the bundle has no loose sidecar files. The `externalValue` of example objects, and
+	// `$ref`s to whole files used as an example `value` (or `example`), are replaced. JSON and YAML files are
+	// embedded as data, anything else as a string. Files are located relative to the file the example is written
+	// in. Defaults to false.
+	InlineExternalExamples bool
+}
+
+// NamingStrategy decides how components with clashing names are renamed when composing a bundle.
+type NamingStrategy int
+
+const (
+	// NamingStrategyDefault appends the name of the file the component comes from, then the names of the
+	// directories the file is in (one at a time), and then a counter, until the name is unique.
+	NamingStrategyDefault NamingStrategy = iota
+
+	// NamingStrategyFileStem prefixes the name with the name of the file the component comes from (without the
+	// extension), for example 'Pet' from 'pets.yaml' becomes 'pets__Pet'.
+	NamingStrategyFileStem
+
+	// NamingStrategyDirectoryPath prefixes the name with the path of the directory the component comes from,
+	// relative to the root document, for example 'Pet' from 'models/v2/pets.yaml' becomes 'models__v2__Pet'.
+	// Components from files in the same directory as the root document are prefixed with the file name instead.
+	NamingStrategyDirectoryPath
+
+	// NamingStrategyContentHash appends a short hash of the content of the component, for example 'Pet' becomes
+	// 'Pet__3f2a9c1b'. The same component is always given the same name.
+	NamingStrategyContentHash
+)
+
+// NameCollision describes a component that has to be renamed when composing a bundle, because its name clashes
+// with a component that has already been composed.
+type NameCollision struct {
+	Name          string     // Name is the name of the component that clashed.
+	ComponentType string     // ComponentType is the components section, for example 'schemas' or 'responses'.
+	Definition    string     // Definition is the full definition of the reference, the file path (or URL) and JSON pointer.
+	Node          *yaml.Node // Node is the content of the component.
+	Existing      *yaml.Node // Existing is the content of the component that has the name.
+	Delimiter     string     // Delimiter is the configured delimiter.
 }
 
 // BundleInlineConfig provides configuration options for inline bundling.
@@ -126,11 +236,61 @@ type BundleInlineConfig struct {
 	// in external files reference other schemas in those external files.
 	// Default: false (preserves existing behavior of keeping external refs as-is)
 	ResolveDiscriminatorExternalRefs bool
+
+	// Filter when set, bundles only the operations selected by tag, path or operationId, and prunes everything
+	// that is not reachable from them (paths, webhooks, tags and components). This is useful for publishing
+	// slices of a large specification. The document model is not changed.
+	// Default: nil (the whole document is bundled)
+	Filter *BundleFilter
+
+	// PreserveExternalRefs are patterns of external references that are kept as references, rather than inlined.
+	// This is useful for canonical shared schemas (for example 'https://schemas.example.com/*') that should not be
+	// copied into every bundle. Patterns use path.Match syntax, and are matched against the reference as written,
+	// the full location of the referenced file and its location relative to the root document (with and without
+	// the fragment), and the file name. Kept file references are rewritten relative to the root document.
+	// Default: nil (every external reference is inlined)
+	PreserveExternalRefs []string
+
+	// CircularReferenceMode controls what happens to circular references into other files, which cannot be
+	// inlined. They can be extracted into the components of the bundle, kept as external references that resolve
+	// from the root document, or fail the bundle.
+	// Default: CircularReferenceModeSkip (circular references are left as they are written)
+	CircularReferenceMode CircularReferenceMode
+
+	// Provenance when true, annotates every inlined external reference with an `x-bundled-from` extension that
+	// holds the file (relative to the root document) and JSON pointer it was inlined from, so tooling can trace
+	// where each part of the bundle came from.
+	// Default: false
+	Provenance bool
+
+	// Output controls the format (YAML or JSON), indentation and key quoting of the bundle.
+	// Default: nil (YAML, rendered by the model)
+	Output *BundleOutputConfig
+
+	// EventHandler is called with events as references are discovered and inlined, circular references are
+	// skipped, and files are processed, so long bundling runs can drive progress bars and structured logs.
+	// Default: nil
+	EventHandler BundleEventHandler
+
+	// PreserveComments when true, keeps the comments (head, line and foot) of the root document and of the content
+	// of every inlined reference, including the comment written above the referenced key, so licensing and doc
+	// comments survive bundling.
+	// Default: false (comments are dropped when rendering)
+	PreserveComments bool
+
+	// InlineExternalExamples when true, embeds the content of example payload files as the `value` of the examples
+	// that point to them, so the bundle has no loose sidecar files. The `externalValue` of example objects, and
+	// `$ref`s to whole files used as an example `value` (or `example`), are replaced. JSON and YAML files are
+	// embedded as data, anything else as a string. Files are located relative to the file the example is written
+	// in. OpenAPI 3 documents only.
+	// Default: false
+	InlineExternalExamples bool
 }
 
 // BundleDocumentComposed will take a v3.Document and return a composed bundled version of it. Composed means
 // that every external file will have references lifted out and added to the `components` section of the document.
-// Names will be preserved where possible, conflicts will be appended with a number. If the type of the reference cannot
+// Names will be preserved where possible, conflicts are renamed using the NamingStrategy (or NameCollisionHandler)
+// of the composition config. If the type of the reference cannot
 // be determined, it will be added to the `components` section as a `Schema` type, a warning will be logged.
 // The document model will be mutated permanently.
 //
@@ -139,22 +299,29 @@ func BundleDocumentComposed(model *v3.Document, compositionConfig *BundleComposi
 	return compose(model, compositionConfig)
 }
 
-func compose(model *v3.Document, compositionConfig *BundleCompositionConfig) ([]byte, error) {
+// checkCompositionConfig returns the composition config with a default delimiter (a default config if none is
+// supplied), or an error if the delimiter cannot be used in a reference.
+func checkCompositionConfig(compositionConfig *BundleCompositionConfig) (*BundleCompositionConfig, error) {
 	if compositionConfig == nil {
-		compositionConfig = &BundleCompositionConfig{
-			Delimiter: "__",
-		}
-	} else {
-		if compositionConfig.Delimiter == "" {
-			compositionConfig.Delimiter = "__"
-		}
-		if strings.Contains(compositionConfig.Delimiter, "#") ||
-			strings.Contains(compositionConfig.Delimiter, "/") {
-			return nil, errors.New("composition delimiter cannot contain '#' or '/' characters")
-		}
-		if strings.Contains(compositionConfig.Delimiter, " ") {
-			return nil, errors.New("composition delimiter cannot contain spaces")
-		}
+		return &BundleCompositionConfig{Delimiter: "__"}, nil
+	}
+	if compositionConfig.Delimiter == "" {
+		compositionConfig.Delimiter = "__"
+	}
+	if strings.Contains(compositionConfig.Delimiter, "#") ||
+		strings.Contains(compositionConfig.Delimiter, "/") {
+		return nil, errors.New("composition delimiter cannot contain '#' or '/' characters")
+	}
+	if strings.Contains(compositionConfig.Delimiter, " ") {
+		return nil, errors.New("composition delimiter cannot contain spaces")
+	}
+	return compositionConfig, nil
+}
+
+func compose(model *v3.Document, compositionConfig *BundleCompositionConfig) ([]byte, error) {
+	compositionConfig, err := checkCompositionConfig(compositionConfig)
+	if err != nil {
+		return nil, err
 	}
 
 	if model == nil || model.Rolodex == nil {
@@ -174,24 +341,40 @@ func compose(model *v3.Document, compositionConfig *BundleCompositionConfig) ([]
 		refMap:                orderedmap.New[string, *processRef](),
 		compositionConfig:     compositionConfig,
 		discriminatorMappings: discriminatorMappings,
+		namer:                 newComponentNamer(compositionConfig, rolodex.GetRootIndex()),
+		events:                newBundleEvents(compositionConfig.EventHandler, rolodex),
 	}
 	if err := handleIndex(cf); err != nil {
 		return nil, err
 	}
 
 	processedNodes := orderedmap.New[string, *processRef]()
+	lifted := make(liftedComponents)
+	var composed []*processRef
 	var errs []error
 	for _, ref := range cf.refMap.FromOldest() {
+		inlined := len(cf.inlineRequired)
 		err := processReference(model, ref, cf)
+		if errors.Is(err, ErrNameCollision) {
+			return nil, err
+		}
 		errs = append(errs, err)
 		processedNodes.Set(ref.ref.FullDefinition, ref)
+		if len(cf.inlineRequired) == inlined && len(ref.location) > 2 && ref.location[0] == v3low.ComponentsLabel {
+			lifted.add(ref.location[1], ref.name, ref.ref.FullDefinition)
+			composed = append(composed, ref)
+			cf.events.referenceEvent(BundleEventReferenceComposed, referenceFile(ref.seqRef),
+				refValueNode(ref.seqRef), ref.ref.FullDefinition,
+				"#/"+v3low.ComponentsLabel+"/"+ref.location[1]+"/"+encodeJSONPointerSegment(ref.name))
+		} else if len(cf.inlineRequired) > inlined {
+			cf.events.referenceEvent(BundleEventReferenceInlined, referenceFile(ref.seqRef),
+				refValueNode(ref.seqRef), ref.ref.FullDefinition, "")
+		}
 	}
+	orderComponents(model.Components, lifted, compositionConfig.ComponentOrder)
 
 	slices.SortFunc(indexes, func(i, j *index.SpecIndex) int {
-		if i.GetSpecAbsolutePath() < j.GetSpecAbsolutePath() {
-			return 1
-		}
-		return 0
+		return strings.Compare(j.GetSpecAbsolutePath(), i.GetSpecAbsolutePath())
 	})
 
 	rootIndex := rolodex.GetRootIndex()
@@ -217,19 +400,21 @@ func compose(model *v3.Document, compositionConfig *BundleCompositionConfig) ([]
 						uri[0] = filepath.Join(filepath.Dir(pr.idx.GetSpecAbsolutePath()), uri[0])
 					}
 					pointerRef := pr.idx.FindComponent(context.Background(), strings.Join(uri, "#/"))
-					pr.seqRef.Node.Content = pointerRef.Node.Content
+					inlined := withProvenance(pointerRef.Node, pr.provenance)
+					pr.seqRef.Node.Content = inlined.Content
 					// Track this inlined content for reuse
 					if pr.ref != nil {
-						inlinedPaths[pr.ref.FullDefinition] = pointerRef.Node
+						inlinedPaths[pr.ref.FullDefinition] = inlined
 					}
 					continue
 				}
 			}
 		}
-		pr.seqRef.Node.Content = pr.ref.Node.Content
+		inlined := withProvenance(pr.ref.Node, pr.provenance)
+		pr.seqRef.Node.Content = inlined.Content
 		// Track this inlined content for reuse
 		if pr.ref != nil {
-			inlinedPaths[pr.ref.FullDefinition] = pr.ref.Node
+			inlinedPaths[pr.ref.FullDefinition] = inlined
 		}
 	}
 
@@ -254,7 +439,25 @@ func compose(model *v3.Document, compositionConfig *BundleCompositionConfig) ([]
 		}
 	}
 
-	b, err := model.Render()
+	var b []byte
+	if compositionConfig.Output != nil || compositionConfig.PreserveComments || compositionConfig.InlineExternalExamples {
+		rendered, _ := model.MarshalYAML()
+		if node, ok := rendered.(*yaml.Node); ok && compositionConfig.PreserveComments {
+			copyComposedComments(node, rolodex, composed)
+		}
+		if node, ok := rendered.(*yaml.Node); ok && compositionConfig.InlineExternalExamples {
+			examples := newExampleFiles(rolodex)
+			examples.embedComposed(node, composed)
+			errs = append(errs, examples.err())
+		}
+		if compositionConfig.Output != nil {
+			b, err = compositionConfig.Output.render(rendered, 4)
+		} else {
+			b, err = yaml.Marshal(rendered)
+		}
+	} else {
+		b, err = model.Render()
+	}
 	errs = append(errs, err)
 
 	return b, errors.Join(errs...)
@@ -266,7 +469,40 @@ func bundleWithConfig(model *v3.Document, config *BundleInlineConfig) ([]byte, e
 	highbase.SetBundlingMode(true)
 	defer highbase.SetBundlingMode(false)
 
+	var circular *circularReferences
+	var examples *exampleFiles
 	if model.Rolodex != nil {
+		// Keep the external references that match the preserved patterns, and handle circular references,
+		// while rendering.
+		if config != nil {
+			events := newBundleEvents(config.EventHandler, model.Rolodex)
+			events.discover(model.Rolodex)
+			circular = newCircularReferences(model, config.CircularReferenceMode, events)
+			preserver := referencePreserver(model.Rolodex, config.PreserveExternalRefs)
+			if circular != nil {
+				preserver = chainPreservers(preserver, circular.preserve)
+			}
+			if preserver != nil {
+				model.Rolodex.SetReferencePreserver(preserver)
+				defer model.Rolodex.SetReferencePreserver(nil)
+			}
+			var annotator index.ReferenceAnnotator
+			if config.Provenance {
+				annotator = provenanceAnnotator(model.Rolodex)
+			}
+			if config.PreserveComments {
+				annotator = chainAnnotators(annotator, commentAnnotator())
+			}
+			if config.InlineExternalExamples {
+				examples = newExampleFiles(model.Rolodex)
+				annotator = chainAnnotators(annotator, examples.annotator())
+			}
+			if annotator = chainAnnotators(annotator, events.annotator()); annotator != nil {
+				model.Rolodex.SetReferenceAnnotator(annotator)
+				defer model.Rolodex.SetReferenceAnnotator(nil)
+			}
+		}
+
 		// Handle discriminator external refs if enabled.
 		// This copies external schemas referenced by discriminator mappings to the root
 		// document's components section, ensuring the bundled output is valid.
@@ -284,7 +520,46 @@ func bundleWithConfig(model *v3.Document, config *BundleInlineConfig) ([]byte, e
 	// Discriminator mappings are preserved via Schema.MarshalYAMLInline() which
 	// marks oneOf/anyOf SchemaProxy items to preserve their references.
 	// Circular references are handled in SchemaProxy.MarshalYAMLInline().
-	return model.RenderInline()
+	if config == nil || (config.Filter.isEmpty() && config.Output == nil && !config.PreserveComments &&
+		!config.InlineExternalExamples) {
+		bundled, err := model.RenderInline()
+		if err != nil {
+			return nil, err
+		}
+		if err = circular.err(); err != nil {
+			return nil, err
+		}
+		return bundled, nil
+	}
+
+	// Filtering is applied to the rendered nodes, so the model is not pruned.
+	rendered, _ := model.MarshalYAMLInline()
+	if err := circular.err(); err != nil {
+		return nil, err
+	}
+	if node, ok := rendered.(*yaml.Node); ok {
+		if config.PreserveComments && model.Rolodex != nil {
+			copyComments(model.Rolodex.GetRootNode(), node)
+		}
+		if examples != nil {
+			examples.embed(model.Rolodex.GetRootIndex().GetSpecAbsolutePath(), node)
+		}
+		filterBundle(node, config.Filter)
+	}
+	var bundled []byte
+	var err error
+	if config.Output != nil {
+		bundled, err = config.Output.render(rendered, 4)
+	} else {
+		bundled, err = yaml.Marshal(rendered)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if examples != nil {
+		return bundled, examples.err()
+	}
+	return bundled, nil
 }
 
 // externalSchemaRef represents an external schema that needs to be copied to the root document's components.
@@ -7,9 +7,11 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
@@ -24,6 +26,41 @@ type processRef struct {
 	refPointer string
 	name       string
 	location   []string
+	provenance string // where the reference came from, when provenance is enabled.
+	merged     bool   // the component was merged with the component that has its name, by the NameCollisionHandler.
+}
+
+// sourceIndex returns the index of the file the referenced component is written in.
+func (pr *processRef) sourceIndex() *index.SpecIndex {
+	if pr.ref != nil && pr.ref.Index != nil {
+		return pr.ref.Index
+	}
+	return pr.idx
+}
+
+// composedComponent returns the key and value nodes of a component lifted into the components of a rendered
+// bundle, or nil if it cannot be found.
+func composedComponent(rendered *yaml.Node, pr *processRef) (*yaml.Node, *yaml.Node) {
+	if rendered.Kind == yaml.DocumentNode && len(rendered.Content) > 0 {
+		rendered = rendered.Content[0]
+	}
+	if pr.ref == nil || len(pr.location) < 2 {
+		return nil, nil
+	}
+	components := mappingValue(rendered, v3low.ComponentsLabel)
+	if components == nil {
+		return nil, nil
+	}
+	section := mappingValue(components, pr.location[1])
+	if section == nil {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(section.Content); i += 2 {
+		if section.Content[i].Value == pr.name {
+			return section.Content[i], section.Content[i+1]
+		}
+	}
+	return nil, nil
 }
 
 type handleIndexConfig struct {
@@ -31,33 +68,155 @@ type handleIndexConfig struct {
 	model                 *v3.Document
 	indexes               []*index.SpecIndex
 	refMap                *orderedmap.Map[string, *processRef]
+	namer                 *componentNamer
 	seen                  sync.Map
 	inlineRequired        []*processRef
 	compositionConfig     *BundleCompositionConfig
 	discriminatorMappings []*yaml.Node
+	contexts              referenceContexts
+	indexesByPath         map[string][]*index.SpecIndex // the indexes of the rolodex, by the path of their file.
+	scans                 map[*index.SpecIndex]*indexScan
+	events                *bundleEvents
+}
+
+// referenceKind returns the components section of a reference written where a path item or a callback is expected.
+func (c *handleIndexConfig) referenceKind(ref *index.Reference) string {
+	if ref == nil {
+		return ""
+	}
+	if c.contexts == nil {
+		indexes := slices.Clone(c.indexes)
+		if c.model != nil && c.model.Rolodex != nil {
+			indexes = append(indexes, c.model.Rolodex.GetRootIndex())
+		}
+		c.contexts = newReferenceContexts(indexes...)
+	}
+	return c.contexts[ref.Node]
 }
 
 // handleIndex will recursively explore the indexes and their references, building a map of references
 // to be processed later. It will also check for circular references and avoid infinite loops.
 // everything is stored in the handleIndexConfig, which is passed around to avoid passing too many parameters.
 func handleIndex(c *handleIndexConfig) error {
-	mappedReferences := c.idx.GetMappedReferences()
-	sequencedReferences := c.idx.GetRawReferencesSequenced()
+	scan := c.scan(c.idx)
 	var indexesToExplore []*index.SpecIndex
 
-	for _, sequenced := range sequencedReferences {
+	for _, r := range scan.refs {
+		// check if we have seen this index before, if so - skip it, otherwise we will be going around forever.
+		if _, ok := c.seen.Load(r.sequenced.FullDefinition); ok {
+			continue
+		}
+		if r.foundIndex != nil && r.mapped != nil {
+			// store the reference to be composed in the root.
+			if kk := c.refMap.GetOrZero(r.mapped.FullDefinition); kk == nil {
+				c.refMap.Set(r.mapped.FullDefinition, &processRef{
+					idx:    r.foundIndex,
+					ref:    r.mapped,
+					seqRef: r.sequenced,
+					name:   r.mapped.Name,
+				})
+				c.events.referenceEvent(BundleEventReferenceDiscovered, c.idx.GetSpecAbsolutePath(),
+					refValueNode(r.sequenced), r.mapped.FullDefinition, "")
+			}
+			if _, ok := c.seen.Load(r.foundIndex.GetSpecAbsolutePath()); !ok {
+				c.seen.Store(r.foundIndex.GetSpecAbsolutePath(), r.mapped)
+				indexesToExplore = append(indexesToExplore, r.foundIndex)
+			}
+		}
+	}
+	if scan.err != nil {
+		return scan.err
+	}
+	c.events.fileProcessed(c.idx.GetSpecAbsolutePath())
+
+	for _, idx := range indexesToExplore {
+		c.idx = idx
+		if err := handleIndex(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexScan is what is found for the references of an index, in order. Scanning an index does not depend on any
+// other index, so indexes can be scanned concurrently.
+type indexScan struct {
+	refs []*scannedRef
+	err  error // the references after an invalid reference are not scanned.
+}
+
+type scannedRef struct {
+	sequenced  *index.Reference
+	mapped     *index.Reference
+	foundIndex *index.SpecIndex
+}
+
+// scan returns the scan of an index. With more than one worker, every index is scanned (concurrently) the first time.
+func (c *handleIndexConfig) scan(idx *index.SpecIndex) *indexScan {
+	if c.indexesByPath == nil {
+		c.indexesByPath = make(map[string][]*index.SpecIndex)
+		for _, i := range c.indexes {
+			c.indexesByPath[i.GetSpecAbsolutePath()] = append(c.indexesByPath[i.GetSpecAbsolutePath()], i)
+		}
+		if c.compositionConfig.Workers > 1 {
+			c.scans = scanIndexes(append([]*index.SpecIndex{idx}, c.indexes...), c.compositionConfig.Workers,
+				func(i *index.SpecIndex) *indexScan {
+					return scanIndex(i, c.indexesByPath, c.compositionConfig.StrictValidation)
+				})
+		}
+	}
+	if s, ok := c.scans[idx]; ok {
+		return s
+	}
+	return scanIndex(idx, c.indexesByPath, c.compositionConfig.StrictValidation)
+}
+
+// scanIndexes scans indexes with a pool of workers.
+func scanIndexes(indexes []*index.SpecIndex, workers int, scan func(*index.SpecIndex) *indexScan) map[*index.SpecIndex]*indexScan {
+	scans := make(map[*index.SpecIndex]*indexScan, len(indexes))
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	jobs := make(chan *index.SpecIndex)
+	for range min(workers, len(indexes)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				s := scan(idx)
+				lock.Lock()
+				scans[idx] = s
+				lock.Unlock()
+			}
+		}()
+	}
+	for _, idx := range indexes {
+		if idx != nil {
+			jobs <- idx
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return scans
+}
+
+// scanIndex finds the component (and the index it is in) of every reference of an index.
+func scanIndex(idx *index.SpecIndex, indexesByPath map[string][]*index.SpecIndex, strict bool) *indexScan {
+	scan := &indexScan{}
+	mappedReferences := idx.GetMappedReferences()
+	for _, sequenced := range idx.GetRawReferencesSequenced() {
 		mappedReference := mappedReferences[sequenced.FullDefinition]
 
 		// Check for invalid sibling properties if strict validation is enabled
-		if c.compositionConfig.StrictValidation &&
-				c.idx.GetConfig().SpecInfo.VersionNumeric == 3.0 &&
-				sequenced.HasSiblingProperties {
+		if strict &&
+			idx.GetConfig().SpecInfo.VersionNumeric == 3.0 &&
+			sequenced.HasSiblingProperties {
 			siblingKeys := make([]string, 0, len(sequenced.SiblingProperties))
 			for key := range sequenced.SiblingProperties {
 				siblingKeys = append(siblingKeys, key)
 			}
-			return fmt.Errorf("invalid OpenAPI 3.0 specification: $ref cannot have sibling properties. Found $ref '%s' with siblings %v at line %d, column %d",
+			scan.err = fmt.Errorf("invalid OpenAPI 3.0 specification: $ref cannot have sibling properties. Found $ref '%s' with siblings %v at line %d, column %d",
 				sequenced.FullDefinition, siblingKeys, sequenced.Node.Line, sequenced.Node.Column)
+			return scan
 		}
 
 		// if we're in the root document, don't bundle anything.
@@ -66,49 +225,25 @@ func handleIndex(c *handleIndexConfig) error {
 
 		// make sure to use the correct index.
 		// https://github.com/pb33f/libopenapi/issues/397
-		for _, i := range c.indexes {
-			if i.GetSpecAbsolutePath() == refExp[0] {
-				foundIndex = i
-				if mappedReference != nil && !mappedReference.Circular {
-
-					lookup := sequenced.FullDefinition
-					mr := i.FindComponent(context.Background(), lookup)
-					if mr != nil {
-						// found the component; this is the one we want to use.
-						mappedReference = mr
-						break
-					}
+		for _, i := range indexesByPath[refExp[0]] {
+			foundIndex = i
+			if mappedReference != nil && !mappedReference.Circular {
+				lookup := sequenced.FullDefinition
+				mr := i.FindComponent(context.Background(), lookup)
+				if mr != nil {
+					// found the component; this is the one we want to use.
+					mappedReference = mr
+					break
 				}
 			}
 		}
-		// check if we have seen this index before, if so - skip it, otherwise we will be going around forever.
-		if _, ok := c.seen.Load(sequenced.FullDefinition); ok {
-			continue
-		}
-		if foundIndex != nil && mappedReference != nil {
-			// store the reference to be composed in the root.
-			if kk := c.refMap.GetOrZero(mappedReference.FullDefinition); kk == nil {
-				c.refMap.Set(mappedReference.FullDefinition, &processRef{
-					idx:    foundIndex,
-					ref:    mappedReference,
-					seqRef: sequenced,
-					name:   mappedReference.Name,
-				})
-			}
-			if _, ok := c.seen.Load(foundIndex.GetSpecAbsolutePath()); !ok {
-				c.seen.Store(foundIndex.GetSpecAbsolutePath(), mappedReference) // TODO: replace with map.
-				indexesToExplore = append(indexesToExplore, foundIndex)
-			}
-		}
-	}
-
-	for _, idx := range indexesToExplore {
-		c.idx = idx
-		if err := handleIndex(c); err != nil {
-			return err
-		}
+		scan.refs = append(scan.refs, &scannedRef{
+			sequenced:  sequenced,
+			mapped:     mappedReference,
+			foundIndex: foundIndex,
+		})
 	}
-	return nil
+	return scan
 }
 
 // openAPIRootKeys contains known OpenAPI root-level keys that should NOT be
@@ -142,8 +277,6 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 	var components *v3.Components
 	var err error
 
-	delim := cf.compositionConfig.Delimiter
-
 	if model.Components != nil {
 		components = model.Components
 	} else {
@@ -154,6 +287,13 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 		model.Components = components
 	}
 
+	// path items can only be composed into OpenAPI 3.1+ documents, they are inlined into older ones.
+	kind := cf.referenceKind(pr.seqRef)
+	if kind == v3low.PathItemsLabel && !supportsPathItems(model) {
+		cf.inlineRequired = append(cf.inlineRequired, pr)
+		return nil
+	}
+
 	var location []string
 	if strings.Contains(pr.ref.FullDefinition, "#/") {
 
@@ -164,28 +304,39 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 		// make sure the sequence ref and pr ref have the same full definition.
 		pr.ref.FullDefinition = pr.seqRef.FullDefinition
 		// this is a root document reference, there is no way to get the location from the fragment.
-		// first, lets try to determine the type of the import, if we can.
-		if importType, ok := DetectOpenAPIComponentType(pr.ref.Node); ok {
+		// first, lets try to determine the type of the import, if we can. Path items and callbacks are known from
+		// where they are referenced.
+		importType, ok := DetectOpenAPIComponentType(pr.ref.Node)
+		if kind != "" {
+			importType, ok = kind, true
+		}
+		if importType == v3low.PathItemsLabel && !supportsPathItems(model) {
+			ok = false
+		}
+		if ok {
 			// cool, using the filename as the reference name, check if we have any collisions.
 			switch importType {
 			case v3low.SchemasLabel:
-				location = handleFileImport(pr, v3low.SchemasLabel, delim, components.Schemas)
+				location, err = handleFileImport(pr, v3low.SchemasLabel, cf.namer, components.Schemas)
 			case v3low.ResponsesLabel:
-				location = handleFileImport(pr, v3low.ResponsesLabel, delim, components.Responses)
+				location, err = handleFileImport(pr, v3low.ResponsesLabel, cf.namer, components.Responses)
 			case v3low.ParametersLabel:
-				location = handleFileImport(pr, v3low.ParametersLabel, delim, components.Parameters)
+				location, err = handleFileImport(pr, v3low.ParametersLabel, cf.namer, components.Parameters)
 			case v3low.HeadersLabel:
-				location = handleFileImport(pr, v3low.HeadersLabel, delim, components.Headers)
+				location, err = handleFileImport(pr, v3low.HeadersLabel, cf.namer, components.Headers)
 			case v3low.RequestBodiesLabel:
-				location = handleFileImport(pr, v3low.RequestBodiesLabel, delim, components.RequestBodies)
+				location, err = handleFileImport(pr, v3low.RequestBodiesLabel, cf.namer, components.RequestBodies)
 			case v3low.ExamplesLabel:
-				location = handleFileImport(pr, v3low.ExamplesLabel, delim, components.Examples)
+				location, err = handleFileImport(pr, v3low.ExamplesLabel, cf.namer, components.Examples)
 			case v3low.LinksLabel:
-				location = handleFileImport(pr, v3low.LinksLabel, delim, components.Links)
+				location, err = handleFileImport(pr, v3low.LinksLabel, cf.namer, components.Links)
 			case v3low.CallbacksLabel:
-				location = handleFileImport(pr, v3low.CallbacksLabel, delim, components.Callbacks)
+				location, err = handleFileImport(pr, v3low.CallbacksLabel, cf.namer, components.Callbacks)
 			case v3low.PathItemsLabel:
-				location = handleFileImport(pr, v3low.PathItemsLabel, delim, components.PathItems)
+				location, err = handleFileImport(pr, v3low.PathItemsLabel, cf.namer, components.PathItems)
+			}
+			if err != nil {
+				return err
 			}
 		} else {
 			// the only choice we can make here to be accurate is to inline instead of recompose.
@@ -193,10 +344,13 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 		}
 	}
 
+	if cf.compositionConfig.Provenance {
+		pr.provenance = provenanceSource(cf.namer.rootPath, pr.ref.FullDefinition)
+	}
+
 	unknown := func(procRef *processRef, config *handleIndexConfig) {
-		if l := config.idx.GetLogger(); l != nil {
-			l.Warn("[bundler] unable to compose reference, not sure where it goes.", "$ref", procRef.ref.FullDefinition)
-		}
+		config.idx.GetSubsystemLogger(datamodel.LogSubsystemBundler).Warn(
+			"[bundler] unable to compose reference, not sure where it goes.", procRef.ref.LogAttributes()...)
 		// no idea what do with this, so we will inline it.
 		config.inlineRequired = append(cf.inlineRequired, procRef)
 	}
@@ -210,7 +364,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						schemaName := location[2]
 						if components.Schemas != nil {
-							return checkReferenceAndBubbleUp(schemaName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(schemaName, cf.namer,
 								pr, idx, components.Schemas, buildSchema)
 						}
 					}
@@ -219,7 +373,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						responseCode := location[2]
 						if components.Responses != nil {
-							return checkReferenceAndBubbleUp(responseCode, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(responseCode, cf.namer,
 								pr, idx, components.Responses, buildResponse)
 						}
 					}
@@ -228,7 +382,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						paramName := location[2]
 						if components.Parameters != nil {
-							return checkReferenceAndBubbleUp(paramName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(paramName, cf.namer,
 								pr, idx, components.Parameters, buildParameter)
 						}
 					}
@@ -237,7 +391,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						headerName := location[2]
 						if components.Headers != nil {
-							return checkReferenceAndBubbleUp(headerName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(headerName, cf.namer,
 								pr, idx, components.Headers, buildHeader)
 						}
 					}
@@ -246,7 +400,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						requestBodyName := location[2]
 						if components.RequestBodies != nil {
-							return checkReferenceAndBubbleUp(requestBodyName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(requestBodyName, cf.namer,
 								pr, idx, components.RequestBodies, buildRequestBody)
 						}
 					}
@@ -254,7 +408,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						exampleName := location[2]
 						if components.Examples != nil {
-							return checkReferenceAndBubbleUp(exampleName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(exampleName, cf.namer,
 								pr, idx, components.Examples, buildExample)
 						}
 					}
@@ -263,7 +417,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						linksName := location[2]
 						if components.Links != nil {
-							return checkReferenceAndBubbleUp(linksName, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(linksName, cf.namer,
 								pr, idx, components.Links, buildLink)
 						}
 					}
@@ -272,7 +426,7 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						callbacks := location[2]
 						if components.Callbacks != nil {
-							return checkReferenceAndBubbleUp(callbacks, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(callbacks, cf.namer,
 								pr, idx, components.Callbacks, buildCallback)
 						}
 					}
@@ -281,12 +435,36 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					if len(location) > 2 {
 						pathItem := location[2]
 						if components.PathItems != nil {
-							return checkReferenceAndBubbleUp(pathItem, cf.compositionConfig.Delimiter,
+							return checkReferenceAndBubbleUp(pathItem, cf.namer,
 								pr, idx, components.PathItems, buildPathItem)
 						}
 					}
 				}
 			}
+		} else if kind != "" && location[len(location)-1] != "" {
+			// path items and callbacks that are not components (for example '#/paths/~1pets'), are named after the
+			// last segment of the pointer.
+			name := componentName(location[len(location)-1])
+			switch kind {
+			case v3low.PathItemsLabel:
+				if components.PathItems == nil {
+					components.PathItems = orderedmap.New[string, *v3.PathItem]()
+				}
+				if pr.name, err = checkForCollision(name, cf.namer, pr, components.PathItems); err != nil {
+					return err
+				}
+				pr.location = []string{v3low.ComponentsLabel, v3low.PathItemsLabel, pr.name}
+				return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.PathItems, buildPathItem)
+			case v3low.CallbacksLabel:
+				if components.Callbacks == nil {
+					components.Callbacks = orderedmap.New[string, *v3.Callback]()
+				}
+				if pr.name, err = checkForCollision(name, cf.namer, pr, components.Callbacks); err != nil {
+					return err
+				}
+				pr.location = []string{v3low.ComponentsLabel, v3low.CallbacksLabel, pr.name}
+				return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Callbacks, buildCallback)
+			}
 		} else {
 			// handle single-segment JSON pointers (e.g., #/NonRequired)
 			if len(location) == 1 && location[0] != "" {
@@ -312,57 +490,75 @@ func processReference(model *v3.Document, pr *processRef, cf *handleIndexConfig)
 					switch importType {
 					case v3low.SchemasLabel:
 						if components.Schemas != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Schemas)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Schemas); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.SchemasLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Schemas, buildSchema)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Schemas, buildSchema)
 						}
 					case v3low.ResponsesLabel:
 						if components.Responses != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Responses)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Responses); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.ResponsesLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Responses, buildResponse)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Responses, buildResponse)
 						}
 					case v3low.ParametersLabel:
 						if components.Parameters != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Parameters)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Parameters); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.ParametersLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Parameters, buildParameter)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Parameters, buildParameter)
 						}
 					case v3low.HeadersLabel:
 						if components.Headers != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Headers)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Headers); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.HeadersLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Headers, buildHeader)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Headers, buildHeader)
 						}
 					case v3low.RequestBodiesLabel:
 						if components.RequestBodies != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.RequestBodies)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.RequestBodies); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.RequestBodiesLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.RequestBodies, buildRequestBody)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.RequestBodies, buildRequestBody)
 						}
 					case v3low.ExamplesLabel:
 						if components.Examples != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Examples)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Examples); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.ExamplesLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Examples, buildExample)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Examples, buildExample)
 						}
 					case v3low.LinksLabel:
 						if components.Links != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Links)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Links); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.LinksLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Links, buildLink)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Links, buildLink)
 						}
 					case v3low.CallbacksLabel:
 						if components.Callbacks != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.Callbacks)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.Callbacks); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.CallbacksLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.Callbacks, buildCallback)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.Callbacks, buildCallback)
 						}
 					case v3low.PathItemsLabel:
 						if components.PathItems != nil {
-							pr.name = checkForCollision(componentName, delim, pr, components.PathItems)
+							if pr.name, err = checkForCollision(componentName, cf.namer, pr, components.PathItems); err != nil {
+								return err
+							}
 							pr.location = []string{v3low.ComponentsLabel, v3low.PathItemsLabel, pr.name}
-							return checkReferenceAndBubbleUp(pr.name, delim, pr, idx, components.PathItems, buildPathItem)
+							return checkReferenceAndBubbleUp(pr.name, cf.namer, pr, idx, components.PathItems, buildPathItem)
 						}
 					}
 				}
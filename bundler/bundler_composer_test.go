@@ -102,7 +102,7 @@ func TestBundleDocumentComposed(t *testing.T) {
 }
 
 func TestCheckReferenceAndBubbleUp(t *testing.T) {
-	err := checkReferenceAndBubbleUp[any]("test", "__",
+	err := checkReferenceAndBubbleUp[any]("test", newComponentNamer(&BundleCompositionConfig{Delimiter: "__"}, nil),
 		&processRef{ref: &index.Reference{Node: &yaml.Node{}}},
 		nil, nil,
 		func(node *yaml.Node, idx *index.SpecIndex) (any, error) {
@@ -2092,3 +2092,304 @@ paths:
 	}
 	assert.True(t, foundTestPath, "TestPath should be added to components")
 }
+
+// namingModel builds a model with three schemas named 'Pet', one in the root document and two in other files.
+func namingModel(t *testing.T) *v3.Document {
+	dir := t.TempDir()
+	files := map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /v1/pets:
+    get:
+      responses:
+        '200':
+          description: v1 pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models/v1/pets.yaml#/components/schemas/Pet'
+  /v2/pets:
+    get:
+      responses:
+        '200':
+          description: v2 pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models/v2/pets.yaml#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: string`,
+		"models/v1/pets.yaml": `components:
+  schemas:
+    Pet:
+      type: integer`,
+		"models/v2/pets.yaml": `components:
+  schemas:
+    Pet:
+      type: object`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	spec, _ := os.ReadFile(filepath.Join(dir, "root.yaml"))
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:                dir,
+		SpecFilePath:            "root.yaml",
+		ExtractRefsSequentially: true,
+	})
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	return &m.Model
+}
+
+func composeWithNaming(t *testing.T, config *BundleCompositionConfig) *yaml.Node {
+	bundled, err := BundleDocumentComposed(namingModel(t), config)
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	return node.Content[0]
+}
+
+// composedSchemaNames returns the composed schema names, and the schema reference of each path.
+func composedSchemaNames(t *testing.T, root *yaml.Node) ([]string, map[string]string) {
+	var names []string
+	_, schemas := findYAMLPath(root, "components", "schemas")
+	require.NotNil(t, schemas)
+	for i := 0; i < len(schemas.Content); i += 2 {
+		names = append(names, schemas.Content[i].Value)
+	}
+	refs := make(map[string]string)
+	for _, p := range []string{"/v1/pets", "/v2/pets"} {
+		_, ref := findYAMLPath(root, "paths", p, "get", "responses", "200", "content", "application/json",
+			"schema", "$ref")
+		require.NotNil(t, ref)
+		refs[p] = ref.Value
+	}
+	return names, refs
+}
+
+func findYAMLPath(node *yaml.Node, keys ...string) (*yaml.Node, *yaml.Node) {
+	var key *yaml.Node
+	for _, k := range keys {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, nil
+		}
+		var next *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == k {
+				key, next = node.Content[i], node.Content[i+1]
+			}
+		}
+		node = next
+	}
+	return key, node
+}
+
+func TestBundleDocumentComposed_NamingStrategy(t *testing.T) {
+	names, refs := composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyFileStem,
+	}))
+	assert.Equal(t, []string{"Pet", "pets__Pet", "pets__Pet__2"}, names)
+	assert.Equal(t, "#/components/schemas/pets__Pet", refs["/v1/pets"])
+	assert.Equal(t, "#/components/schemas/pets__Pet__2", refs["/v2/pets"])
+
+	names, refs = composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		Delimiter:      "_",
+		NamingStrategy: NamingStrategyDirectoryPath,
+	}))
+	assert.Equal(t, []string{"Pet", "models_v1_Pet", "models_v2_Pet"}, names)
+	assert.Equal(t, "#/components/schemas/models_v1_Pet", refs["/v1/pets"])
+	assert.Equal(t, "#/components/schemas/models_v2_Pet", refs["/v2/pets"])
+
+	names, refs = composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyContentHash,
+	}))
+	require.Len(t, names, 3)
+	assert.Regexp(t, "^Pet__[0-9a-f]{8}$", names[1])
+	assert.Regexp(t, "^Pet__[0-9a-f]{8}$", names[2])
+	assert.NotEqual(t, names[1], names[2])
+	assert.Equal(t, "#/components/schemas/"+names[1], refs["/v1/pets"])
+
+	// the hash is the same between runs.
+	again, _ := composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyContentHash,
+	}))
+	assert.Equal(t, names, again)
+}
+
+func TestBundleDocumentComposed_NameCollisionHandler(t *testing.T) {
+	var collisions []*NameCollision
+	names, refs := composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyFileStem,
+		NameCollisionHandler: func(collision *NameCollision) (string, error) {
+			collisions = append(collisions, collision)
+			return "LegacyPet", nil // taken the second time, so the naming strategy is used.
+		},
+	}))
+	assert.Equal(t, []string{"Pet", "LegacyPet", "pets__Pet"}, names)
+	assert.Equal(t, "#/components/schemas/LegacyPet", refs["/v1/pets"])
+	assert.Equal(t, "#/components/schemas/pets__Pet", refs["/v2/pets"])
+
+	require.Len(t, collisions, 2)
+	assert.Equal(t, "Pet", collisions[0].Name)
+	assert.Equal(t, "schemas", collisions[0].ComponentType)
+	assert.Equal(t, "__", collisions[0].Delimiter)
+	assert.True(t, strings.HasSuffix(collisions[0].Definition, "pets.yaml#/components/schemas/Pet"))
+	assert.Equal(t, "integer", collisions[0].Node.Content[1].Value)
+	assert.Equal(t, "string", collisions[0].Existing.Content[1].Value)
+}
+
+func TestBundleDocumentComposed_NameCollisionHandler_Merge(t *testing.T) {
+	var candidates, existing []string
+	names, refs := composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NameCollisionHandler: func(collision *NameCollision) (string, error) {
+			candidates = append(candidates, collision.Name)
+			existing = append(existing, collision.Existing.Content[1].Value)
+			if collision.Node.Content[1].Value == "integer" {
+				return "IntegerPet", nil
+			}
+			return collision.Name, nil // merged with the existing 'Pet'.
+		},
+	}))
+	assert.Equal(t, []string{"Pet", "IntegerPet"}, names)
+	assert.Equal(t, "#/components/schemas/IntegerPet", refs["/v1/pets"])
+	assert.Equal(t, "#/components/schemas/Pet", refs["/v2/pets"])
+	assert.Equal(t, []string{"Pet", "Pet"}, candidates)
+	assert.Equal(t, []string{"string", "string"}, existing)
+
+	// an empty name leaves the collision to the naming strategy.
+	names, _ = composedSchemaNames(t, composeWithNaming(t, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyFileStem,
+		NameCollisionHandler: func(*NameCollision) (string, error) {
+			return "", nil
+		},
+	}))
+	assert.Equal(t, []string{"Pet", "pets__Pet", "pets__Pet__2"}, names)
+}
+
+func TestBundleDocumentComposed_NameCollisionHandler_Error(t *testing.T) {
+	refused := errors.New("pets must be renamed by hand")
+	bundled, err := BundleDocumentComposed(namingModel(t), &BundleCompositionConfig{
+		NameCollisionHandler: func(*NameCollision) (string, error) {
+			return "", refused
+		},
+	})
+	assert.Nil(t, bundled)
+	assert.ErrorIs(t, err, ErrNameCollision)
+	assert.ErrorIs(t, err, refused)
+}
+
+func composePathItems(t *testing.T, version string) *yaml.Node {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: ` + version + `
+info:
+  title: Hooks
+  version: 1.0.0
+paths:
+  /pets:
+    $ref: 'paths/pets.yaml'
+  /owners:
+    $ref: 'paths/all.yaml#/paths/~1owners'
+  /hooks:
+    post:
+      callbacks:
+        onPet:
+          $ref: 'callbacks.yaml#/components/callbacks/OnPet'
+        onFile:
+          $ref: 'callback.yaml'
+      responses:
+        '200':
+          description: ok`,
+		"paths/pets.yaml": `summary: pets
+servers:
+  - url: https://pets.example.com`,
+		"paths/all.yaml": `paths:
+  /owners:
+    get:
+      responses:
+        '200':
+          description: owners`,
+		"callbacks.yaml": `components:
+  callbacks:
+    OnPet:
+      '{$request.body#/url}':
+        $ref: 'paths/pets.yaml'`,
+		"callback.yaml": `'{$request.body#/url}':
+  post:
+    responses:
+      '200':
+        description: ok`,
+	}, "root.yaml")
+	bundled, err := BundleBytesComposed(spec, config, nil)
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	return node.Content[0]
+}
+
+func TestBundleBytesComposed_ExternalPathItemsAndCallbacks(t *testing.T) {
+	root := composePathItems(t, "3.1.0")
+
+	// path items are known from where they are referenced, not from what is in them.
+	assert.Equal(t, []string{"pets", "owners"}, mappingKeys(t, root, "components", "pathItems"))
+	_, ref := findYAMLPath(root, "paths", "/pets", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/pathItems/pets", ref.Value)
+	_, ref = findYAMLPath(root, "paths", "/owners", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/pathItems/owners", ref.Value)
+
+	assert.Equal(t, []string{"OnPet", "callback"}, mappingKeys(t, root, "components", "callbacks"))
+	_, ref = findYAMLPath(root, "components", "callbacks", "OnPet", "{$request.body#/url}", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/pathItems/pets", ref.Value)
+	_, schemas := findYAMLPath(root, "components", "schemas")
+	assert.Nil(t, schemas)
+}
+
+func TestBundleBytesComposed_ExternalPathItems_OpenAPI30(t *testing.T) {
+	root := composePathItems(t, "3.0.3")
+
+	// 3.0 documents have no components.pathItems, so path items are inlined.
+	_, pathItems := findYAMLPath(root, "components", "pathItems")
+	assert.Nil(t, pathItems)
+	_, summary := findYAMLPath(root, "paths", "/pets", "summary")
+	require.NotNil(t, summary)
+	assert.Equal(t, "pets", summary.Value)
+	_, summary = findYAMLPath(root, "components", "callbacks", "OnPet", "{$request.body#/url}", "summary")
+	require.NotNil(t, summary)
+	assert.Equal(t, "pets", summary.Value)
+}
+
+func TestComponentName(t *testing.T) {
+	assert.Equal(t, "pets", componentName("~1pets"))
+	assert.Equal(t, "pets__id", componentName("/pets/{id}"))
+	assert.Equal(t, "Pet.v1", componentName("Pet.v1"))
+}
+
+func TestBundleBytesComposed_Workers(t *testing.T) {
+	specBytes, err := os.ReadFile("test/specs/main.yaml")
+	require.NoError(t, err)
+	config := &datamodel.DocumentConfiguration{
+		BasePath:                "test/specs",
+		ExtractRefsSequentially: true,
+	}
+
+	serial, err := BundleBytesComposed(specBytes, config, nil)
+	require.NoError(t, err)
+
+	// scanning the indexes concurrently composes the same bundle.
+	for _, workers := range []int{2, 8} {
+		parallel, err := BundleBytesComposed(specBytes, config, &BundleCompositionConfig{Workers: workers})
+		require.NoError(t, err)
+		assert.Equal(t, string(serial), string(parallel))
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"slices"
+	"strings"
+
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// BundleFilter selects the operations to keep in a bundled document. An operation is kept if it matches any of the
+// tags, paths or operationIds of the filter. Everything that is not reachable from the kept operations is pruned;
+// paths and webhooks with no operations left, tags that are no longer used, and components (including security
+// schemes) that are no longer referenced.
+type BundleFilter struct {
+	Tags         []string // Tags keeps every operation that has at least one of these tags.
+	Paths        []string // Paths keeps every operation of these paths (exact path keys, for example `/pets/{id}`).
+	OperationIDs []string // OperationIDs keeps the operations with these operationIds.
+}
+
+func (f *BundleFilter) isEmpty() bool {
+	return f == nil || (len(f.Tags) == 0 && len(f.Paths) == 0 && len(f.OperationIDs) == 0)
+}
+
+var filterOperationLabels = []string{
+	v3low.GetLabel, v3low.PutLabel, v3low.PostLabel, v3low.DeleteLabel, v3low.OptionsLabel,
+	v3low.HeadLabel, v3low.PatchLabel, v3low.TraceLabel, v3low.QueryLabel,
+}
+
+// filterBundle prunes a rendered (bundled) document down to the operations selected by the filter.
+func filterBundle(root *yaml.Node, filter *BundleFilter) {
+	if root == nil || filter.isEmpty() {
+		return
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return
+	}
+
+	usedTags := make(map[string]bool)
+	for _, label := range []string{v3low.PathsLabel, v3low.WebhooksLabel} {
+		_, pathItems := utils.FindKeyNodeTop(label, root.Content)
+		if pathItems == nil || pathItems.Kind != yaml.MappingNode {
+			continue
+		}
+		var kept []*yaml.Node
+		for i := 0; i+1 < len(pathItems.Content); i += 2 {
+			path, pathItem := pathItems.Content[i].Value, pathItems.Content[i+1]
+			keepAll := label == v3low.PathsLabel && slices.Contains(filter.Paths, path)
+			if filterPathItem(pathItem, filter, keepAll, usedTags) {
+				kept = append(kept, pathItems.Content[i], pathItem)
+			}
+		}
+		pathItems.Content = kept
+	}
+	pruneTags(root, usedTags)
+	pruneComponents(root)
+}
+
+// filterPathItem removes the operations of a path item that are not selected, records the tags of the operations
+// that are kept, and returns true if any operations are left.
+func filterPathItem(pathItem *yaml.Node, filter *BundleFilter, keepAll bool, usedTags map[string]bool) bool {
+	if pathItem.Kind != yaml.MappingNode {
+		return keepAll
+	}
+	keep := func(op *yaml.Node) bool {
+		if keepAll || filterOperation(op, filter) {
+			for _, tag := range operationTags(op) {
+				usedTags[tag] = true
+			}
+			return true
+		}
+		return false
+	}
+
+	var content []*yaml.Node
+	operations := 0
+	for i := 0; i+1 < len(pathItem.Content); i += 2 {
+		key, value := pathItem.Content[i], pathItem.Content[i+1]
+		switch {
+		case slices.Contains(filterOperationLabels, key.Value):
+			if !keep(value) {
+				continue
+			}
+			operations++
+		case key.Value == v3low.AdditionalOperationsLabel && value.Kind == yaml.MappingNode:
+			var additional []*yaml.Node
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				if keep(value.Content[j+1]) {
+					additional = append(additional, value.Content[j], value.Content[j+1])
+				}
+			}
+			if len(additional) == 0 {
+				continue
+			}
+			value.Content = additional
+			operations += len(additional) / 2
+		}
+		content = append(content, key, value)
+	}
+	pathItem.Content = content
+	return operations > 0
+}
+
+func filterOperation(op *yaml.Node, filter *BundleFilter) bool {
+	if _, id := utils.FindKeyNodeTop("operationId", op.Content); id != nil &&
+		slices.Contains(filter.OperationIDs, id.Value) {
+		return true
+	}
+	for _, tag := range operationTags(op) {
+		if slices.Contains(filter.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func operationTags(op *yaml.Node) []string {
+	_, tags := utils.FindKeyNodeTop(v3low.TagsLabel, op.Content)
+	if tags == nil {
+		return nil
+	}
+	names := make([]string, 0, len(tags.Content))
+	for _, tag := range tags.Content {
+		names = append(names, tag.Value)
+	}
+	return names
+}
+
+// pruneTags removes the tags of the document that are no longer used by an operation, tags that are the parent
+// of a used tag (OpenAPI 3.2+) are kept.
+func pruneTags(root *yaml.Node, used map[string]bool) {
+	_, tags := utils.FindKeyNodeTop(v3low.TagsLabel, root.Content)
+	if tags == nil || tags.Kind != yaml.SequenceNode {
+		return
+	}
+	parents := make(map[string]string)
+	for _, tag := range tags.Content {
+		_, name := utils.FindKeyNodeTop(v3low.NameLabel, tag.Content)
+		_, parent := utils.FindKeyNodeTop("parent", tag.Content)
+		if name != nil && parent != nil {
+			parents[name.Value] = parent.Value
+		}
+	}
+	for name := range used {
+		for p, ok := parents[name]; ok && !used[p]; p, ok = parents[p] {
+			used[p] = true
+		}
+	}
+	var kept []*yaml.Node
+	for _, tag := range tags.Content {
+		if _, name := utils.FindKeyNodeTop(v3low.NameLabel, tag.Content); name != nil && used[name.Value] {
+			kept = append(kept, tag)
+		}
+	}
+	tags.Content = kept
+}
+
+// pruneComponents removes every component that cannot be reached from the rest of the document. References are
+// followed from everything outside the components (and through every component that is reached), security schemes
+// are reached by name from security requirements.
+func pruneComponents(root *yaml.Node) {
+	_, components := utils.FindKeyNodeTop(v3low.ComponentsLabel, root.Content)
+	if components == nil || components.Kind != yaml.MappingNode {
+		return
+	}
+	reached := make(map[string]bool)
+	var queue []*yaml.Node
+	var visit func(n *yaml.Node, securityRequirement bool)
+	visit = func(n *yaml.Node, securityRequirement bool) {
+		switch n.Kind {
+		case yaml.ScalarNode:
+			if !strings.HasPrefix(n.Value, "#/"+v3low.ComponentsLabel+"/") {
+				return
+			}
+			segments := strings.SplitN(strings.TrimPrefix(n.Value, "#/"+v3low.ComponentsLabel+"/"), "/", 3)
+			if len(segments) < 2 {
+				return
+			}
+			pointer := segments[0] + "/" + decodeJSONPointerSegment(segments[1])
+			if !reached[pointer] {
+				reached[pointer] = true
+				if c := findComponent(components, segments[0], decodeJSONPointerSegment(segments[1])); c != nil {
+					queue = append(queue, c)
+				}
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if securityRequirement {
+					reached[v3low.SecuritySchemesLabel+"/"+n.Content[i].Value] = true
+					continue
+				}
+				visit(n.Content[i+1], n.Content[i].Value == v3low.SecurityLabel)
+			}
+		case yaml.SequenceNode:
+			for _, item := range n.Content {
+				visit(item, securityRequirement)
+			}
+		}
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value != v3low.ComponentsLabel {
+			visit(root.Content[i+1], root.Content[i].Value == v3low.SecurityLabel)
+		}
+	}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		visit(n, false)
+	}
+
+	var sections []*yaml.Node
+	for i := 0; i+1 < len(components.Content); i += 2 {
+		section, entries := components.Content[i], components.Content[i+1]
+		if entries.Kind != yaml.MappingNode || strings.HasPrefix(section.Value, "x-") {
+			sections = append(sections, section, entries)
+			continue
+		}
+		var kept []*yaml.Node
+		for j := 0; j+1 < len(entries.Content); j += 2 {
+			if reached[section.Value+"/"+entries.Content[j].Value] {
+				kept = append(kept, entries.Content[j], entries.Content[j+1])
+			}
+		}
+		if len(kept) > 0 {
+			entries.Content = kept
+			sections = append(sections, section, entries)
+		}
+	}
+	components.Content = sections
+}
+
+func findComponent(components *yaml.Node, section, name string) *yaml.Node {
+	entries := mappingValue(components, section)
+	if entries == nil {
+		return nil
+	}
+	return mappingValue(entries, name)
+}
+
+// mappingValue returns the value of a key of a mapping node, keys are matched exactly (component names are case
+// sensitive).
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+var filterSpec = `openapi: 3.1.0
+info:
+  title: Store
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: store
+  - name: users
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      security:
+        - petAuth: []
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models.yaml#/components/schemas/Pets'
+    post:
+      operationId: createPet
+      tags: [pets, store]
+      requestBody:
+        $ref: '#/components/requestBodies/NewPet'
+      responses:
+        '201':
+          description: created
+  /orders:
+    get:
+      operationId: listOrders
+      tags: [store]
+      parameters:
+        - $ref: '#/components/parameters/Limit'
+      responses:
+        '200':
+          description: orders
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+  /users:
+    get:
+      operationId: listUsers
+      tags: [users]
+      security:
+        - userAuth: []
+      responses:
+        '200':
+          description: users
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/User'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        owner:
+          $ref: '#/components/schemas/Owner'
+    Owner:
+      type: object
+    Order:
+      type: object
+    User:
+      type: object
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+  requestBodies:
+    NewPet:
+      content:
+        application/json:
+          schema:
+            $ref: '#/components/schemas/Pet'
+  securitySchemes:
+    petAuth:
+      type: apiKey
+      in: header
+      name: X-Pet
+    userAuth:
+      type: apiKey
+      in: header
+      name: X-User`
+
+func bundleFiltered(t *testing.T, filter *BundleFilter) *yaml.Node {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.yaml"), []byte(`components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: 'root.yaml#/components/schemas/Pet'`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "root.yaml"), []byte(filterSpec), 0o644))
+
+	bundled, err := BundleBytesWithConfig([]byte(filterSpec), &datamodel.DocumentConfiguration{
+		BasePath:                dir,
+		SpecFilePath:            "root.yaml",
+		ExtractRefsSequentially: true,
+	}, &BundleInlineConfig{Filter: filter})
+	require.NoError(t, err)
+
+	// the filtered bundle is still a valid document.
+	doc, err := libopenapi.NewDocument(bundled)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	return node.Content[0]
+}
+
+func mappingKeys(t *testing.T, root *yaml.Node, keys ...string) []string {
+	_, n := findYAMLPath(root, keys...)
+	require.NotNil(t, n)
+	var names []string
+	for i := 0; i < len(n.Content); i += 2 {
+		names = append(names, n.Content[i].Value)
+	}
+	return names
+}
+
+func tagNames(root *yaml.Node) []string {
+	_, tags := findYAMLPath(root, "tags")
+	var names []string
+	for _, tag := range tags.Content {
+		_, name := findYAMLPath(tag, "name")
+		names = append(names, name.Value)
+	}
+	return names
+}
+
+func TestBundleBytesWithConfig_FilterTags(t *testing.T) {
+	root := bundleFiltered(t, &BundleFilter{Tags: []string{"pets"}})
+
+	assert.Equal(t, []string{"/pets"}, mappingKeys(t, root, "paths"))
+	assert.Equal(t, []string{"get", "post"}, mappingKeys(t, root, "paths", "/pets"))
+	assert.Equal(t, []string{"pets", "store"}, tagNames(root))
+	assert.Equal(t, []string{"Pet", "Owner"}, mappingKeys(t, root, "components", "schemas"))
+	assert.Equal(t, []string{"petAuth"}, mappingKeys(t, root, "components", "securitySchemes"))
+
+	// request bodies and parameters are inlined by the bundler, so they are no longer referenced.
+	_, bodies := findYAMLPath(root, "components", "requestBodies")
+	assert.Nil(t, bodies)
+	_, params := findYAMLPath(root, "components", "parameters")
+	assert.Nil(t, params)
+}
+
+func TestBundleBytesWithConfig_FilterOperationIDsAndPaths(t *testing.T) {
+	root := bundleFiltered(t, &BundleFilter{OperationIDs: []string{"listOrders"}, Paths: []string{"/users"}})
+
+	assert.Equal(t, []string{"/orders", "/users"}, mappingKeys(t, root, "paths"))
+	assert.Equal(t, []string{"store", "users"}, tagNames(root))
+	assert.Equal(t, []string{"Order", "User"}, mappingKeys(t, root, "components", "schemas"))
+	assert.Equal(t, []string{"userAuth"}, mappingKeys(t, root, "components", "securitySchemes"))
+
+	root = bundleFiltered(t, &BundleFilter{OperationIDs: []string{"createPet"}})
+	assert.Equal(t, []string{"post"}, mappingKeys(t, root, "paths", "/pets"))
+	assert.Equal(t, []string{"pets", "store"}, tagNames(root))
+}
+
+func TestBundleBytesWithConfig_FilterNoMatch(t *testing.T) {
+	root := bundleFiltered(t, &BundleFilter{Tags: []string{"nope"}})
+	assert.Empty(t, mappingKeys(t, root, "paths"))
+	assert.Empty(t, tagNames(root))
+	assert.Empty(t, mappingKeys(t, root, "components"))
+}
+
+func TestFilterBundle_ParentTags(t *testing.T) {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`openapi: 3.2.0
+tags:
+  - name: animals
+  - name: pets
+    parent: animals
+  - name: other
+paths:
+  /pets:
+    get:
+      tags: [pets]
+  /other:
+    get:
+      tags: [other]`), &node))
+	filterBundle(&node, &BundleFilter{Tags: []string{"pets"}})
+	assert.Equal(t, []string{"animals", "pets"}, tagNames(node.Content[0]))
+}
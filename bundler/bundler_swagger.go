@@ -0,0 +1,332 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// BundleSwaggerDocument will take a Swagger (version 2) document and return a bundled version of it, every external
+// reference is replaced by the content it references. The resulting document contains no external references.
+//
+// Circular references cannot be inlined, so they are composed into the `definitions`, `parameters` or `responses`
+// of the document instead (see BundleSwaggerDocumentComposed). The document model is not changed.
+func BundleSwaggerDocument(model *v2.Swagger) ([]byte, error) {
+	return bundleSwagger(model, false, nil, nil)
+}
+
+// BundleSwaggerDocumentComposed will take a Swagger (version 2) document and return a composed bundled version of
+// it. Every external reference is lifted out and added to the `definitions`, `parameters` or `responses` of the
+// document (depending on what is referenced), and the reference is changed to point at it. Names are preserved where
+// possible, conflicts are renamed using the NamingStrategy (or NameCollisionHandler) of the composition config.
+// The document model is not changed.
+func BundleSwaggerDocumentComposed(model *v2.Swagger, compositionConfig *BundleCompositionConfig) ([]byte, error) {
+	compositionConfig, err := checkCompositionConfig(compositionConfig)
+	if err != nil {
+		return nil, err
+	}
+	return bundleSwagger(model, true, compositionConfig, nil)
+}
+
+func isSwagger(doc libopenapi.Document) bool {
+	info := doc.GetSpecInfo()
+	return info != nil && info.SpecFormat == datamodel.OAS2
+}
+
+func bundleSwaggerBytes(doc libopenapi.Document, compose bool, compositionConfig *BundleCompositionConfig,
+	inlineConfig *BundleInlineConfig,
+) ([]byte, error) {
+	v2Doc, err := doc.BuildV2Model()
+	if v2Doc == nil {
+		return nil, errors.Join(ErrInvalidModel, err)
+	}
+	bundledBytes, e := bundleSwagger(&v2Doc.Model, compose, compositionConfig, inlineConfig)
+	return bundledBytes, errors.Join(err, e)
+}
+
+func bundleSwagger(model *v2.Swagger, compose bool, compositionConfig *BundleCompositionConfig,
+	inlineConfig *BundleInlineConfig,
+) ([]byte, error) {
+	b, err := newSwaggerBundler(model, compose, compositionConfig, inlineConfig)
+	if err != nil {
+		return nil, err
+	}
+	b.walk(b.root, b.rootIdx, v2low.DefinitionsLabel)
+	if err := errors.Join(b.errs...); errors.Is(err, ErrNameCollision) {
+		return nil, err
+	}
+	if compose && compositionConfig != nil && compositionConfig.ComponentOrder != ComponentOrderComposed {
+		for _, section := range []string{v2low.DefinitionsLabel, v2low.ParametersLabel, v2low.ResponsesLabel} {
+			if _, n := utils.FindKeyNodeTop(section, b.root.Content); n != nil {
+				orderMapping(n, b.lifted.sorted(section, compositionConfig.ComponentOrder))
+			}
+		}
+	}
+
+	var output *BundleOutputConfig
+	if compose && compositionConfig != nil {
+		output = compositionConfig.Output
+	} else if !compose && inlineConfig != nil {
+		output = inlineConfig.Output
+	}
+	if output != nil {
+		rendered, err := output.render(b.root, 2)
+		if err != nil {
+			return nil, err
+		}
+		return rendered, errors.Join(b.errs...)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(b.root); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), errors.Join(b.errs...)
+}
+
+// bundleSwaggerReport composes a copy of a Swagger document, to report what composing it would do.
+func bundleSwaggerReport(model *v2.Swagger, compositionConfig *BundleCompositionConfig) (*CompositionReport, error) {
+	b, err := newSwaggerBundler(model, true, compositionConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+	b.report = newCompositionReport(b.rootIdx)
+	b.namer.renamed = b.report.renamed
+	b.walk(b.root, b.rootIdx, v2low.DefinitionsLabel)
+	return b.report, errors.Join(b.errs...)
+}
+
+func newSwaggerBundler(model *v2.Swagger, compose bool, compositionConfig *BundleCompositionConfig,
+	inlineConfig *BundleInlineConfig,
+) (*swaggerBundler, error) {
+	if model == nil || model.GoLow() == nil || model.GoLow().Rolodex == nil || model.GoLow().SpecInfo == nil {
+		return nil, errors.New("model or rolodex is nil")
+	}
+	lowDoc := model.GoLow()
+	if lowDoc.SpecInfo.RootNode == nil || len(lowDoc.SpecInfo.RootNode.Content) == 0 {
+		return nil, errors.New("unable to bundle, the document is empty")
+	}
+	if compositionConfig == nil {
+		compositionConfig = &BundleCompositionConfig{Delimiter: "__"}
+	}
+	rootIdx := lowDoc.Rolodex.GetRootIndex()
+	b := &swaggerBundler{
+		rootIdx:    rootIdx,
+		root:       utils.CloneYAMLNode(lowDoc.SpecInfo.RootNode.Content[0]),
+		compose:    compose,
+		namer:      newComponentNamer(compositionConfig, rootIdx),
+		composed:   make(map[string]string),
+		inlining:   make(map[string]bool),
+		lifted:     make(liftedComponents),
+		provenance: compositionConfig.Provenance,
+		comments:   compositionConfig.PreserveComments,
+		events:     newBundleEvents(compositionConfig.EventHandler, lowDoc.Rolodex),
+	}
+	b.namer.componentsPointer = "#/"
+	if inlineConfig != nil {
+		b.preserve = referencePreserver(lowDoc.Rolodex, inlineConfig.PreserveExternalRefs)
+		b.provenance = inlineConfig.Provenance
+		b.comments = inlineConfig.PreserveComments
+		b.events = newBundleEvents(inlineConfig.EventHandler, lowDoc.Rolodex)
+	}
+	return b, nil
+}
+
+// swaggerBundler bundles a copy of the yaml nodes of a Swagger document, references are located using the index
+// of the file they are found in, so relative references are resolved from the right place.
+type swaggerBundler struct {
+	rootIdx    *index.SpecIndex
+	root       *yaml.Node
+	compose    bool
+	namer      *componentNamer
+	composed   map[string]string // the full definition of every composed reference, and its new local reference.
+	inlining   map[string]bool   // the full definitions currently being inlined, used to find circular references.
+	preserve   index.ReferencePreserver
+	provenance bool               // annotate every inlined or composed reference with where it came from.
+	comments   bool               // keep the comment written above the key of every inlined or composed reference.
+	report     *CompositionReport // records every composed reference, when reporting.
+	events     *bundleEvents
+	lifted     liftedComponents
+	errs       []error
+}
+
+// walk searches a node for references. The section is where a reference is composed to if the reference itself
+// does not say (for example, a whole file), and depends on where in the document the reference is found.
+func (b *swaggerBundler) walk(node *yaml.Node, idx *index.SpecIndex, section string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			b.walk(n, idx, section)
+		}
+	case yaml.MappingNode:
+		if _, ref := utils.FindKeyNodeTop("$ref", node.Content); ref != nil && ref.Kind == yaml.ScalarNode {
+			b.bundleReference(node, ref, idx, section)
+			return
+		}
+		// components added while walking are walked when they are added, so only the original content is walked.
+		content := node.Content
+		for i := 0; i+1 < len(content); i += 2 {
+			key := content[i].Value
+			if strings.HasPrefix(key, "x-") || key == "example" || key == "examples" {
+				continue
+			}
+			b.walk(content[i+1], idx, swaggerSection(key, section))
+		}
+	}
+}
+
+// swaggerSection returns the section that a reference found under a key would be composed to.
+func swaggerSection(key, section string) string {
+	switch key {
+	case v2low.ParametersLabel:
+		return v2low.ParametersLabel
+	case v2low.ResponsesLabel:
+		return v2low.ResponsesLabel
+	case "schema", "items", "properties", "additionalProperties", "allOf", v2low.DefinitionsLabel:
+		return v2low.DefinitionsLabel
+	}
+	return section
+}
+
+func (b *swaggerBundler) bundleReference(node, ref *yaml.Node, idx *index.SpecIndex, section string) {
+	if idx == b.rootIdx && strings.HasPrefix(ref.Value, "#") {
+		return
+	}
+	found, foundIdx := idx.SearchIndexForReference(ref.Value)
+	if found == nil || found.Node == nil {
+		b.errs = append(b.errs, fmt.Errorf("unable to bundle reference '%s' (line %d, column %d), it cannot be found",
+			ref.Value, ref.Line, ref.Column))
+		return
+	}
+	if b.preserve != nil {
+		if preserved, ok := b.preserve(foundIdx, ref.Value); ok {
+			ref.Value = preserved
+			return
+		}
+	}
+	// the full definition is not always absolute, so the definition is keyed by the file it was found in.
+	_, fragment, hasFragment := strings.Cut(found.FullDefinition, "#")
+	definition := foundIdx.GetSpecAbsolutePath()
+	if hasFragment {
+		definition += "#" + fragment
+	}
+	if foundIdx == b.rootIdx && hasFragment {
+		ref.Value = "#" + fragment // references back into the root document become local.
+		return
+	}
+	file := idx.GetSpecAbsolutePath()
+	if _, seen := b.composed[definition]; !seen && !b.inlining[definition] {
+		b.events.referenceEvent(BundleEventReferenceDiscovered, file, ref, definition, "")
+	}
+
+	if !b.compose && !b.inlining[definition] {
+		b.events.referenceEvent(BundleEventReferenceInlined, file, ref, definition, "")
+		content := b.clone(found.Node, definition)
+		if b.comments {
+			addHeadComment(content, keyComment(foundIdx.GetRootNode(), found.Node))
+		}
+		b.inlining[definition] = true
+		b.walk(content, foundIdx, section)
+		delete(b.inlining, definition)
+		*node = *content
+		return
+	}
+
+	if local, ok := b.composed[definition]; ok {
+		ref.Value = local
+		return
+	}
+	section, name := b.composeLocation(definition, fragment, section)
+	components := b.section(section)
+	taken := func(name string) bool {
+		return mappingValue(components, name) != nil
+	}
+	pr := &processRef{ref: &index.Reference{FullDefinition: definition, Node: found.Node}}
+	originalName, duplicate := name, false
+	if taken(name) {
+		var duplicateName string
+		if duplicateName, duplicate = b.namer.duplicateName(section, name, found.Node, foundIdx); duplicate {
+			name = duplicateName
+		} else {
+			var err error
+			if name, duplicate, err = b.namer.resolveName(name, section, pr, taken); err != nil {
+				b.errs = append(b.errs, err)
+				return
+			}
+		}
+	}
+	local := "#/" + section + "/" + encodeJSONPointerSegment(name)
+	b.composed[definition] = local
+	b.events.referenceEvent(BundleEventReferenceComposed, file, ref, definition, local)
+	ref.Value = local
+	if b.report != nil {
+		b.report.composed(definition, section, name, pr)
+	}
+	if duplicate {
+		return // an identical (or merged) component with the same name has already been composed.
+	}
+	b.lifted.add(section, name, definition)
+	b.namer.record(section, originalName, name, found.Node, foundIdx)
+
+	content := b.clone(found.Node, definition)
+	key := utils.CreateStringNode(name)
+	if b.comments {
+		key.HeadComment = keyComment(foundIdx.GetRootNode(), found.Node)
+	}
+	components.Content = append(components.Content, key, content)
+	b.walk(content, foundIdx, section)
+}
+
+// clone copies the content of a reference, annotated with where it came from if provenance is enabled.
+func (b *swaggerBundler) clone(node *yaml.Node, definition string) *yaml.Node {
+	content := utils.CloneYAMLNode(node)
+	if b.provenance {
+		addProvenance(content, provenanceSource(b.rootIdx.GetSpecAbsolutePath(), definition))
+	}
+	return content
+}
+
+// composeLocation returns the section and the name a reference is composed to. References to definitions,
+// parameters or responses keep their section and name, anything else is named after the last segment of the
+// reference (or the file name) and composed to the section it was found in.
+func (b *swaggerBundler) composeLocation(definition, fragment, section string) (string, string) {
+	segments := strings.Split(strings.TrimPrefix(fragment, "/"), "/")
+	if len(segments) == 2 {
+		switch segments[0] {
+		case v2low.DefinitionsLabel, v2low.ParametersLabel, v2low.ResponsesLabel:
+			section = segments[0]
+		}
+	}
+	name := segments[len(segments)-1]
+	if name == "" {
+		file, _, _ := strings.Cut(definition, "#")
+		name = strings.TrimSuffix(path.Base(file), path.Ext(file))
+	}
+	return section, decodeJSONPointerSegment(name)
+}
+
+// section returns the mapping node of a section of the root document, adding it if it does not exist.
+func (b *swaggerBundler) section(name string) *yaml.Node {
+	if _, n := utils.FindKeyNodeTop(name, b.root.Content); n != nil && n.Kind == yaml.MappingNode {
+		return n
+	}
+	n := utils.CreateEmptyMapNode()
+	b.root.Content = append(b.root.Content, utils.CreateStringNode(name), n)
+	return n
+}
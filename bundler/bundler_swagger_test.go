@@ -0,0 +1,252 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// swaggerTree writes a Swagger root document that references definitions, parameters and responses in other
+// files (including a definition that clashes with one in the root, and a circular definition), and returns the
+// directory and the root specification.
+func swaggerTree(t *testing.T) (string, []byte) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'common/parameters.yaml#/parameters/Limit'
+      responses:
+        '200':
+          description: pets
+          schema:
+            type: array
+            items:
+              $ref: 'models/pets.yaml#/definitions/Pet'
+        '404':
+          $ref: 'common/responses.yaml#/responses/NotFound'
+  /owners:
+    get:
+      responses:
+        '200':
+          description: owners
+          schema:
+            $ref: 'models/owner.yaml'
+definitions:
+  Pet:
+    type: string`,
+		"models/pets.yaml": `definitions:
+  Pet:
+    type: object
+    properties:
+      owner:
+        $ref: 'owner.yaml'
+  Node:
+    type: object
+    properties:
+      next:
+        $ref: '#/definitions/Node'`,
+		"models/owner.yaml": `type: object
+properties:
+  pets:
+    type: array
+    items:
+      $ref: 'pets.yaml#/definitions/Node'`,
+		"common/parameters.yaml": `parameters:
+  Limit:
+    name: limit
+    in: query
+    type: integer`,
+		"common/responses.yaml": `responses:
+  NotFound:
+    description: not found
+    schema:
+      $ref: '../models/pets.yaml#/definitions/Pet'`,
+	}
+	for name, content := range files {
+		p := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+	}
+	spec, _ := os.ReadFile(filepath.Join(dir, "root.yaml"))
+	return dir, spec
+}
+
+func swaggerConfig(dir string) *datamodel.DocumentConfiguration {
+	return &datamodel.DocumentConfiguration{
+		BasePath:                dir,
+		SpecFilePath:            "root.yaml",
+		ExtractRefsSequentially: true,
+	}
+}
+
+func TestBundleBytes_Swagger(t *testing.T) {
+	dir, spec := swaggerTree(t)
+	bundled, err := BundleBytes(spec, swaggerConfig(dir))
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	_, param := findYAMLPath(root, "paths", "/pets", "get", "parameters")
+	require.NotNil(t, param)
+	_, name := findYAMLPath(param.Content[0], "name")
+	assert.Equal(t, "limit", name.Value)
+
+	_, typ := findYAMLPath(root, "paths", "/pets", "get", "responses", "200", "schema", "items", "type")
+	assert.Equal(t, "object", typ.Value)
+	_, desc := findYAMLPath(root, "paths", "/pets", "get", "responses", "404", "description")
+	assert.Equal(t, "not found", desc.Value)
+
+	// the circular definition cannot be inlined, so it is composed into the definitions of the document.
+	_, next := findYAMLPath(root, "paths", "/owners", "get", "responses", "200", "schema", "properties", "pets",
+		"items", "properties", "next", "$ref")
+	assert.Equal(t, "#/definitions/Node", next.Value)
+	_, next = findYAMLPath(root, "definitions", "Node", "properties", "next", "$ref")
+	assert.Equal(t, "#/definitions/Node", next.Value)
+	_, pet := findYAMLPath(root, "definitions", "Pet", "type")
+	assert.Equal(t, "string", pet.Value)
+
+	// the bundled document has no external references left.
+	doc, err := libopenapi.NewDocument(bundled)
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+	assert.Equal(t, "limit", m.Model.Paths.PathItems.GetOrZero("/pets").Get.Parameters[0].Name)
+}
+
+func TestBundleBytesComposed_Swagger(t *testing.T) {
+	dir, spec := swaggerTree(t)
+	bundled, err := BundleBytesComposed(spec, swaggerConfig(dir), nil)
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	_, param := findYAMLPath(root, "paths", "/pets", "get", "parameters")
+	_, ref := findYAMLPath(param.Content[0], "$ref")
+	assert.Equal(t, "#/parameters/Limit", ref.Value)
+	_, ref = findYAMLPath(root, "paths", "/pets", "get", "responses", "200", "schema", "items", "$ref")
+	assert.Equal(t, "#/definitions/Pet__pets", ref.Value)
+	_, ref = findYAMLPath(root, "paths", "/pets", "get", "responses", "404", "$ref")
+	assert.Equal(t, "#/responses/NotFound", ref.Value)
+	_, ref = findYAMLPath(root, "paths", "/owners", "get", "responses", "200", "schema", "$ref")
+	assert.Equal(t, "#/definitions/owner", ref.Value)
+
+	// the same definition is only composed once.
+	_, ref = findYAMLPath(root, "responses", "NotFound", "schema", "$ref")
+	assert.Equal(t, "#/definitions/Pet__pets", ref.Value)
+	_, ref = findYAMLPath(root, "definitions", "Pet__pets", "properties", "owner", "$ref")
+	assert.Equal(t, "#/definitions/owner", ref.Value)
+	_, ref = findYAMLPath(root, "definitions", "Node", "properties", "next", "$ref")
+	assert.Equal(t, "#/definitions/Node", ref.Value)
+
+	var names []string
+	_, definitions := findYAMLPath(root, "definitions")
+	for i := 0; i < len(definitions.Content); i += 2 {
+		names = append(names, definitions.Content[i].Value)
+	}
+	assert.Equal(t, []string{"Pet", "Pet__pets", "owner", "Node"}, names)
+
+	doc, err := libopenapi.NewDocument(bundled)
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+	assert.Equal(t, 4, m.Model.Definitions.Definitions.Len())
+	assert.Equal(t, "limit", m.Model.Parameters.Definitions.GetOrZero("Limit").Name)
+}
+
+func TestBundleSwaggerDocumentComposed_NamingStrategy(t *testing.T) {
+	dir, spec := swaggerTree(t)
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, swaggerConfig(dir))
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+
+	bundled, err := BundleSwaggerDocumentComposed(&m.Model, &BundleCompositionConfig{
+		NamingStrategy: NamingStrategyFileStem,
+	})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	_, ref := findYAMLPath(node.Content[0], "paths", "/pets", "get", "responses", "200", "schema", "items", "$ref")
+	assert.Equal(t, "#/definitions/pets__Pet", ref.Value)
+
+	_, err = BundleSwaggerDocumentComposed(&m.Model, &BundleCompositionConfig{Delimiter: "#"})
+	assert.Error(t, err)
+}
+
+func TestBundleSwaggerDocumentComposed_NameCollisionHandler(t *testing.T) {
+	dir, spec := swaggerTree(t)
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, swaggerConfig(dir))
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+
+	bundled, err := BundleSwaggerDocumentComposed(&m.Model, &BundleCompositionConfig{
+		NameCollisionHandler: func(collision *NameCollision) (string, error) {
+			return collision.Name, nil
+		},
+	})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	_, ref := findYAMLPath(node.Content[0], "paths", "/pets", "get", "responses", "200", "schema", "items", "$ref")
+	assert.Equal(t, "#/definitions/Pet", ref.Value)
+	_, pet := findYAMLPath(node.Content[0], "definitions", "Pet", "type")
+	assert.Equal(t, "string", pet.Value)
+
+	bundled, err = BundleSwaggerDocumentComposed(&m.Model, &BundleCompositionConfig{
+		NameCollisionHandler: func(*NameCollision) (string, error) {
+			return "", errors.New("no")
+		},
+	})
+	assert.Nil(t, bundled)
+	assert.ErrorIs(t, err, ErrNameCollision)
+}
+
+func TestBundleSwaggerDocument_MissingReference(t *testing.T) {
+	dir := t.TempDir()
+	spec := []byte(`swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/definitions/Missing'`)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models.yaml"), []byte("definitions: {}"), 0o644))
+
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, swaggerConfig(dir))
+	require.NoError(t, err)
+	m, _ := doc.BuildV2Model()
+	require.NotNil(t, m)
+
+	bundled, err := BundleSwaggerDocument(&m.Model)
+	assert.ErrorContains(t, err, "models.yaml#/definitions/Missing")
+	assert.NotEmpty(t, bundled)
+
+	_, err = BundleSwaggerDocument(nil)
+	assert.Error(t, err)
+}
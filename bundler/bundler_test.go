@@ -1790,20 +1790,17 @@ paths:
 	runtime.GC()
 }
 
-func TestBundleBytesWithConfig_InvalidModel(t *testing.T) {
-	// Test that BundleBytesWithConfig returns ErrInvalidModel when BuildV3Model fails
-	// Using Swagger 2.0 spec triggers "wrong version" error from BuildV3Model
-
+func TestBundleBytesWithConfig_Swagger(t *testing.T) {
+	// Swagger 2.0 specifications are bundled by the Swagger bundler, rather than rejected by BuildV3Model.
 	swagger2Spec := []byte(`swagger: "2.0"
 info:
   title: Test API
   version: 1.0.0
 paths: {}`)
 
-	_, err := BundleBytesWithConfig(swagger2Spec, nil, nil)
-	require.Error(t, err)
-	assert.ErrorIs(t, err, ErrInvalidModel)
-	assert.Contains(t, err.Error(), "different version")
+	bundled, err := BundleBytesWithConfig(swagger2Spec, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "swagger: \"2.0\"")
 }
 
 // TestBundleBytesWithConfig_BackwardCompatibility tests that existing behavior is preserved
@@ -2415,3 +2412,58 @@ paths:
 	require.NoError(t, err)
 	require.NotNil(t, bundledBytes)
 }
+
+func TestBundleBytes_RemoteCacheDir(t *testing.T) {
+	var downloads, revalidations int
+	var lock sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if r.Header.Get("If-None-Match") == `"pet"` {
+			revalidations++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads++
+		w.Header().Set("ETag", `"pet"`)
+		_, _ = w.Write([]byte(`components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`))
+	}))
+	defer server.Close()
+
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '` + server.URL + `/pet.yaml#/components/schemas/Pet'`)
+	config := &datamodel.DocumentConfiguration{
+		AllowRemoteReferences:   true,
+		RemoteCacheDir:          t.TempDir(),
+		ExtractRefsSequentially: true,
+	}
+
+	first, err := BundleBytes(spec, config)
+	require.NoError(t, err)
+	assert.Contains(t, string(first), "name:")
+
+	// the second bundle revalidates the cached document, rather than downloading it again.
+	second, err := BundleBytes(spec, config)
+	require.NoError(t, err)
+	assert.Equal(t, string(first), string(second))
+	assert.Equal(t, 1, downloads)
+	assert.Positive(t, revalidations)
+}
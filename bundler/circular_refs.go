@@ -0,0 +1,217 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// ErrCircularReference is returned when CircularReferenceModeError is used, and the bundle contains circular
+// references to other files.
+var ErrCircularReference = errors.New("circular reference cannot be bundled")
+
+// CircularReferenceMode controls what the inline bundler does with circular references into other files, which
+// cannot be inlined.
+type CircularReferenceMode int
+
+const (
+	// CircularReferenceModeSkip leaves circular references as they are written (the default). References written
+	// in other files may not resolve from the bundled document.
+	CircularReferenceModeSkip CircularReferenceMode = iota
+
+	// CircularReferenceModeExtract copies the targets of circular references into the `components` of the bundled
+	// document (as schemas), and points every reference to them at the copy. The bundle is self-contained.
+	CircularReferenceModeExtract
+
+	// CircularReferenceModePreserve keeps circular references as external references, rewritten so they resolve
+	// from the root document.
+	CircularReferenceModePreserve
+
+	// CircularReferenceModeError fails the bundle (with ErrCircularReference) if it contains a circular reference
+	// into another file.
+	CircularReferenceModeError
+)
+
+// circularReferences handles the circular references of a rolodex for a CircularReferenceMode, while rendering.
+type circularReferences struct {
+	mode    CircularReferenceMode
+	targets map[string]*index.Reference // the loop points (in other files) of circular references, by full definition.
+	refs    map[string]string           // the reference rendered in place of each target (extract and preserve).
+	found   []string                    // the targets reached while rendering (error and skip).
+	events  *bundleEvents
+	lock    sync.Mutex
+}
+
+// newCircularReferences collects the loop points of the circular references of the rolodex that are not in the
+// root document. With CircularReferenceModeExtract, the targets are copied into the components of the model.
+// With CircularReferenceModeSkip, circular references are only collected to report the ones that are skipped.
+func newCircularReferences(model *v3.Document, mode CircularReferenceMode, events *bundleEvents) *circularReferences {
+	if (mode == CircularReferenceModeSkip && events == nil) || model == nil || model.Rolodex == nil {
+		return nil
+	}
+	rolodex := model.Rolodex
+	rootIdx := rolodex.GetRootIndex()
+	if rootIdx == nil {
+		return nil
+	}
+	rootPath := rootIdx.GetSpecAbsolutePath()
+
+	c := &circularReferences{
+		mode:    mode,
+		targets: make(map[string]*index.Reference),
+		refs:    make(map[string]string),
+		events:  events,
+	}
+	results := slices.Concat(rolodex.GetSafeCircularReferences(), rolodex.GetIgnoredCircularReferences(),
+		rootIdx.GetCircularReferences())
+	for _, idx := range rolodex.GetIndexes() {
+		results = append(results, idx.GetCircularReferences()...)
+	}
+	var ordered []string
+	for _, result := range results {
+		if result == nil || result.LoopPoint == nil {
+			continue
+		}
+		def := result.LoopPoint.FullDefinition
+		file, _, _ := strings.Cut(def, "#")
+		if file == "" || file == rootPath || c.targets[def] != nil {
+			continue
+		}
+		c.targets[def] = result.LoopPoint
+		ordered = append(ordered, def)
+	}
+
+	switch mode {
+	case CircularReferenceModePreserve:
+		for _, def := range ordered {
+			file, fragment, _ := strings.Cut(def, "#")
+			c.refs[def] = rootRelativeReference(rootPath, file, fragment)
+		}
+	case CircularReferenceModeExtract:
+		c.extract(model, ordered)
+	}
+	return c
+}
+
+// extract copies every target into the schemas of the model's components, with a unique name.
+func (c *circularReferences) extract(model *v3.Document, definitions []string) {
+	if len(definitions) == 0 {
+		return
+	}
+	rootIdx := model.Rolodex.GetRootIndex()
+	if model.Components == nil {
+		model.Components, _ = buildComponents(rootIdx)
+	}
+	if model.Components.Schemas == nil {
+		model.Components.Schemas = orderedmap.New[string, *base.SchemaProxy]()
+	}
+	existingNames := make(map[string]bool)
+	for name := range model.Components.Schemas.KeysFromOldest() {
+		existingNames[name] = true
+	}
+	for _, def := range definitions {
+		found := rootIdx.FindComponent(context.Background(), def)
+		if found == nil || found.Node == nil {
+			continue
+		}
+		// the schema is built with the index of the file it is written in, so its references resolve from there.
+		file, fragment, _ := strings.Cut(def, "#")
+		idx := found.Index
+		for _, i := range model.Rolodex.GetIndexes() {
+			if i.GetSpecAbsolutePath() == file {
+				idx = i
+				break
+			}
+		}
+		name := fragment[strings.LastIndex(fragment, "/")+1:]
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		}
+		c.refs[def] = copySchemaToComponents(model, &externalSchemaRef{
+			idx:        idx,
+			ref:        found,
+			schemaName: decodeJSONPointerSegment(name),
+			fullDef:    def,
+		}, existingNames)
+	}
+}
+
+// preserve is an index.ReferencePreserver for the circular references.
+func (c *circularReferences) preserve(idx *index.SpecIndex, ref string) (string, bool) {
+	if c == nil || idx == nil {
+		return "", false
+	}
+	def := idx.GetSpecAbsolutePath()
+	if _, fragment, ok := strings.Cut(ref, "#"); ok && fragment != "" {
+		def += "#" + fragment
+	}
+	if _, ok := c.targets[def]; !ok {
+		return "", false
+	}
+	switch c.mode {
+	case CircularReferenceModeError, CircularReferenceModeSkip:
+		c.lock.Lock()
+		reached := slices.Contains(c.found, def)
+		if !reached {
+			c.found = append(c.found, def)
+		}
+		c.lock.Unlock()
+		if c.mode == CircularReferenceModeSkip && !reached {
+			c.events.emit(&BundleEvent{
+				Type:       BundleEventCircularReferenceSkipped,
+				Message:    fmt.Sprintf("skipped circular reference to '%s'", def),
+				Ref:        ref,
+				Definition: def,
+			})
+		}
+		return "", false
+	}
+	r, ok := c.refs[def]
+	return r, ok
+}
+
+// err returns an ErrCircularReference for every target reached while rendering (CircularReferenceModeError).
+func (c *circularReferences) err() error {
+	if c == nil || c.mode != CircularReferenceModeError || len(c.found) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(c.found))
+	for _, def := range c.found {
+		errs = append(errs, fmt.Errorf("%w: '%s'", ErrCircularReference, def))
+	}
+	return errors.Join(errs...)
+}
+
+// chainPreservers returns an index.ReferencePreserver that tries each preserver in turn.
+func chainPreservers(preservers ...index.ReferencePreserver) index.ReferencePreserver {
+	var chain []index.ReferencePreserver
+	for _, p := range preservers {
+		if p != nil {
+			chain = append(chain, p)
+		}
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+	return func(idx *index.SpecIndex, ref string) (string, bool) {
+		for _, p := range chain {
+			if r, ok := p(idx, ref); ok {
+				return r, true
+			}
+		}
+		return "", false
+	}
+}
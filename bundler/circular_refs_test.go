@@ -0,0 +1,123 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func bundleCircular(t *testing.T, config *BundleInlineConfig) ([]byte, error) {
+	spec, docConfig := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Tree
+  version: 1.0.0
+paths:
+  /nodes:
+    get:
+      responses:
+        '200':
+          description: nodes
+          content:
+            application/json:
+              schema:
+                $ref: 'models/forest.yaml#/components/schemas/Forest'
+  /owners:
+    get:
+      responses:
+        '200':
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: 'models/owner.yaml'`,
+		"models/forest.yaml": `components:
+  schemas:
+    Forest:
+      type: array
+      items:
+        $ref: 'tree/node.yaml#/components/schemas/Node'`,
+		"models/tree/node.yaml": `components:
+  schemas:
+    Node:
+      type: object
+      properties:
+        children:
+          type: array
+          items:
+            $ref: '#/components/schemas/Node'`,
+		"models/owner.yaml": `type: object
+properties:
+  pet:
+    $ref: 'pet.yaml'`,
+		"models/pet.yaml": `type: object
+properties:
+  owner:
+    $ref: 'owner.yaml'`,
+	}, "root.yaml")
+	return BundleBytesWithConfig(spec, docConfig, config)
+}
+
+func TestBundleBytesWithConfig_CircularReferenceModeExtract(t *testing.T) {
+	bundled, err := bundleCircular(t, &BundleInlineConfig{CircularReferenceMode: CircularReferenceModeExtract})
+	require.NoError(t, err)
+
+	// the bundle is self-contained.
+	doc, err := libopenapi.NewDocument(bundled)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	_, ref := findYAMLPath(root, "paths", "/nodes", "get", "responses", "200", "content", "application/json",
+		"schema", "items", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/schemas/Node", ref.Value)
+	_, ref = findYAMLPath(root, "components", "schemas", "Node", "properties", "children", "items", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/schemas/Node", ref.Value)
+
+	// whole files are extracted with the name of the file.
+	_, ref = findYAMLPath(root, "paths", "/owners", "get", "responses", "200", "content", "application/json",
+		"schema", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/schemas/owner", ref.Value)
+	_, ref = findYAMLPath(root, "components", "schemas", "owner", "properties", "pet", "properties", "owner", "$ref")
+	require.NotNil(t, ref)
+	assert.Equal(t, "#/components/schemas/owner", ref.Value)
+
+	assert.NotContains(t, string(bundled), ".yaml")
+}
+
+func TestBundleBytesWithConfig_CircularReferenceModePreserve(t *testing.T) {
+	bundled, err := bundleCircular(t, &BundleInlineConfig{CircularReferenceMode: CircularReferenceModePreserve})
+	require.NoError(t, err)
+
+	// the reference written in models/forest.yaml is rewritten to resolve from the root document.
+	assert.Contains(t, string(bundled), "$ref: 'models/tree/node.yaml#/components/schemas/Node'")
+	assert.NotContains(t, string(bundled), "components:")
+}
+
+func TestBundleBytesWithConfig_CircularReferenceModeError(t *testing.T) {
+	_, err := bundleCircular(t, &BundleInlineConfig{CircularReferenceMode: CircularReferenceModeError})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrCircularReference)
+	assert.Contains(t, err.Error(), "tree/node.yaml#/components/schemas/Node")
+}
+
+func TestBundleBytesWithConfig_CircularReferenceModeSkip(t *testing.T) {
+	bundled, err := bundleCircular(t, &BundleInlineConfig{CircularReferenceMode: CircularReferenceModeSkip})
+	require.NoError(t, err)
+
+	// the reference is left as it is written in models/forest.yaml.
+	assert.Contains(t, string(bundled), "$ref: 'tree/node.yaml#/components/schemas/Node'")
+}
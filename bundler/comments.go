@@ -0,0 +1,139 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// copyComments copies the comments of a source node (and of everything below it) to a rendered node, wherever the
+// rendered node has none. Mappings are matched by key and sequences by position, so content that was changed when
+// rendering keeps the comments of whatever still matches.
+func copyComments(source, rendered *yaml.Node) {
+	if source == nil || rendered == nil {
+		return
+	}
+	if source.Kind == yaml.DocumentNode && rendered.Kind != yaml.DocumentNode {
+		if len(source.Content) == 0 {
+			return
+		}
+		copyComments(source.Content[0], rendered)
+		return
+	}
+	if rendered.Kind == yaml.DocumentNode && source.Kind != yaml.DocumentNode {
+		if len(rendered.Content) > 0 {
+			copyComments(source, rendered.Content[0])
+		}
+		return
+	}
+	if source.Kind != rendered.Kind {
+		return
+	}
+	copyNodeComments(source, rendered)
+
+	switch rendered.Kind {
+	case yaml.DocumentNode:
+		if len(source.Content) > 0 && len(rendered.Content) > 0 {
+			copyComments(source.Content[0], rendered.Content[0])
+		}
+	case yaml.MappingNode:
+		keys := make(map[string]int, len(source.Content)/2)
+		for i := 0; i+1 < len(source.Content); i += 2 {
+			keys[source.Content[i].Value] = i
+		}
+		for i := 0; i+1 < len(rendered.Content); i += 2 {
+			if j, ok := keys[rendered.Content[i].Value]; ok {
+				copyNodeComments(source.Content[j], rendered.Content[i])
+				copyComments(source.Content[j+1], rendered.Content[i+1])
+			}
+		}
+	case yaml.SequenceNode:
+		for i := 0; i < len(rendered.Content) && i < len(source.Content); i++ {
+			copyComments(source.Content[i], rendered.Content[i])
+		}
+	}
+}
+
+func copyNodeComments(source, rendered *yaml.Node) {
+	if rendered.HeadComment == "" {
+		rendered.HeadComment = source.HeadComment
+	}
+	if rendered.LineComment == "" {
+		rendered.LineComment = source.LineComment
+	}
+	if rendered.FootComment == "" {
+		rendered.FootComment = source.FootComment
+	}
+}
+
+// keyComment returns the head comment of the key that holds a node in the tree of a file, for example a license or
+// doc comment written above the name of a component. The comment is lost when only the node is copied.
+func keyComment(root, node *yaml.Node) string {
+	if root == nil || node == nil {
+		return ""
+	}
+	for i, n := range root.Content {
+		if root.Kind == yaml.MappingNode && i%2 == 1 && n == node {
+			return root.Content[i-1].HeadComment
+		}
+		if c := keyComment(n, node); c != "" {
+			return c
+		}
+	}
+	return ""
+}
+
+// addHeadComment adds a comment above the first key of a mapping node, ahead of any comment it already has.
+func addHeadComment(node *yaml.Node, comment string) {
+	if comment == "" || node == nil || node.Kind != yaml.MappingNode || len(node.Content) == 0 {
+		return
+	}
+	key := node.Content[0]
+	if key.HeadComment == "" {
+		key.HeadComment = comment
+	} else if !strings.Contains(key.HeadComment, comment) {
+		key.HeadComment = comment + "\n" + key.HeadComment
+	}
+}
+
+// commentAnnotator returns an index.ReferenceAnnotator that copies the comments of the content of every inlined
+// reference (from the file it was inlined from) to its rendered node.
+func commentAnnotator() index.ReferenceAnnotator {
+	return func(idx *index.SpecIndex, ref string, node *yaml.Node) {
+		if idx == nil || idx.GetRootNode() == nil || node == nil {
+			return
+		}
+		source := idx.GetRootNode()
+		if _, fragment, ok := strings.Cut(ref, "#"); ok && fragment != "" {
+			found := idx.FindComponentInRoot(context.Background(), "#"+fragment)
+			if found == nil || found.Node == nil {
+				return
+			}
+			source = found.Node
+		}
+		copyComments(source, node)
+		addHeadComment(node, keyComment(idx.GetRootNode(), source))
+	}
+}
+
+// copyComposedComments copies the comments of the root document, and of every component lifted into it, to a
+// rendered composed bundle.
+func copyComposedComments(rendered *yaml.Node, rolodex *index.Rolodex, composed []*processRef) {
+	copyComments(rolodex.GetRootNode(), rendered)
+	for _, pr := range composed {
+		key, value := composedComponent(rendered, pr)
+		if key == nil {
+			continue
+		}
+		copyComments(pr.ref.Node, value)
+		if idx := pr.sourceIndex(); key.HeadComment == "" && idx != nil {
+			key.HeadComment = keyComment(idx.GetRootNode(), pr.ref.Node)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// commentFiles are OpenAPI (root.yaml) and Swagger (swagger.yaml) specifications, with a commented schema in
+// another file.
+var commentFiles = map[string]string{
+	"root.yaml": `# root document
+openapi: 3.1.0
+info:
+  title: Pets # the title
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models.yaml#/components/schemas/Pet'`,
+	"swagger.yaml": `swagger: '2.0'
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/components/schemas/Pet'`,
+	"models.yaml": `components:
+  schemas:
+    # Licensed under MIT
+    Pet:
+      # a pet
+      type: object # always an object
+      properties:
+        tags:
+          allOf:
+            - type: array # a list of tags
+        name: # the name
+          type: string
+`,
+}
+
+func TestBundleBytesWithConfig_PreserveComments(t *testing.T) {
+	spec, config := specFixture(t, commentFiles, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{PreserveComments: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "# root document\nopenapi: 3.1.0")
+	assert.Contains(t, string(bundled), "title: Pets # the title")
+	assert.Contains(t, string(bundled), `schema:
+                                # Licensed under MIT
+                                # a pet
+                                type: object # always an object`)
+	assert.Contains(t, string(bundled), "name: # the name")
+	assert.Contains(t, string(bundled), "- type: array # a list of tags")
+
+	// comments are dropped by default.
+	bundled, err = BundleBytesWithConfig(spec, config, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundled), "#")
+}
+
+func TestBundleBytesComposed_PreserveComments(t *testing.T) {
+	spec, config := specFixture(t, commentFiles, "root.yaml")
+
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{PreserveComments: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "# root document\nopenapi: 3.1.0")
+	assert.Contains(t, string(bundled), `schemas:
+        # Licensed under MIT
+        Pet:
+            # a pet
+            type: object # always an object`)
+	assert.Contains(t, string(bundled), "name: # the name")
+
+	// the output options apply to the same rendered document.
+	spec, config = specFixture(t, commentFiles, "root.yaml")
+	bundled, err = BundleBytesComposed(spec, config, &BundleCompositionConfig{
+		PreserveComments: true,
+		Output:           &BundleOutputConfig{Indent: 2},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), `schemas:
+    # Licensed under MIT
+    Pet:`)
+}
+
+func TestBundleSwagger_PreserveComments(t *testing.T) {
+	spec, config := specFixture(t, commentFiles, "swagger.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{PreserveComments: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), `schema:
+            # Licensed under MIT
+            # a pet
+            type: object # always an object`)
+
+	bundled, err = BundleBytesComposed(spec, config, &BundleCompositionConfig{PreserveComments: true})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), `definitions:
+  # Licensed under MIT
+  Pet:
+    # a pet`)
+}
+
+func TestCopyComments(t *testing.T) {
+	var source, rendered yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`# head
+a: 1 # one
+b:
+  - x # first
+  - y # second
+c: 3 # three`), &source))
+	require.NoError(t, yaml.Unmarshal([]byte(`a: 1
+b:
+  - x
+c: 3 # kept
+d: 4`), &rendered))
+
+	copyComments(&source, &rendered)
+	out, err := yaml.Marshal(&rendered)
+	require.NoError(t, err)
+	assert.Equal(t, `# head
+a: 1 # one
+b:
+    - x # first
+c: 3 # kept
+d: 4
+`, string(out))
+
+	copyComments(nil, &rendered)
+	copyComments(&source, nil)
+}
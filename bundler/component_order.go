@@ -0,0 +1,102 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// ComponentOrder decides the order of the components that are lifted into the root document when composing a
+// bundle. Components that are already in the root document keep their order, and come first.
+type ComponentOrder int
+
+const (
+	// ComponentOrderComposed keeps lifted components in the order they are composed (the default).
+	ComponentOrderComposed ComponentOrder = iota
+
+	// ComponentOrderAlphabetical sorts lifted components by name.
+	ComponentOrderAlphabetical
+
+	// ComponentOrderSourceFile sorts lifted components by the file (or URL) they come from, and then by name.
+	ComponentOrderSourceFile
+)
+
+// liftedComponents are the names of the components lifted into each section of the components, and the full
+// definition each one was lifted from.
+type liftedComponents map[string]map[string]string
+
+func (l liftedComponents) add(section, name, definition string) {
+	if l[section] == nil {
+		l[section] = make(map[string]string)
+	}
+	l[section][name] = definition
+}
+
+// sorted returns the names of the components lifted into a section, in order.
+func (l liftedComponents) sorted(section string, order ComponentOrder) []string {
+	names := make([]string, 0, len(l[section]))
+	for name := range l[section] {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		if order == ComponentOrderSourceFile {
+			fileA, _, _ := strings.Cut(l[section][a], "#")
+			fileB, _, _ := strings.Cut(l[section][b], "#")
+			if c := cmp.Compare(fileA, fileB); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a, b)
+	})
+	return names
+}
+
+// orderComponents moves the lifted components of a composed document to the end of each section, in order.
+func orderComponents(components *v3.Components, lifted liftedComponents, order ComponentOrder) {
+	if components == nil || order == ComponentOrderComposed {
+		return
+	}
+	orderMap(components.Schemas, lifted.sorted(v3low.SchemasLabel, order))
+	orderMap(components.Responses, lifted.sorted(v3low.ResponsesLabel, order))
+	orderMap(components.Parameters, lifted.sorted(v3low.ParametersLabel, order))
+	orderMap(components.Examples, lifted.sorted(v3low.ExamplesLabel, order))
+	orderMap(components.RequestBodies, lifted.sorted(v3low.RequestBodiesLabel, order))
+	orderMap(components.Headers, lifted.sorted(v3low.HeadersLabel, order))
+	orderMap(components.Links, lifted.sorted(v3low.LinksLabel, order))
+	orderMap(components.Callbacks, lifted.sorted(v3low.CallbacksLabel, order))
+	orderMap(components.PathItems, lifted.sorted(v3low.PathItemsLabel, order))
+}
+
+func orderMap[T any](m *orderedmap.Map[string, T], names []string) {
+	if m == nil {
+		return
+	}
+	for _, name := range names {
+		_ = m.MoveToBack(name)
+	}
+}
+
+// orderMapping moves the named entries of a mapping node to the end, in order.
+func orderMapping(mapping *yaml.Node, names []string) {
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return
+	}
+	for _, name := range names {
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			if mapping.Content[i].Value == name {
+				key, value := mapping.Content[i], mapping.Content[i+1]
+				mapping.Content = append(slices.Delete(mapping.Content, i, i+2), key, value)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func bundleOrdered(t *testing.T, files map[string]string, order ComponentOrder) ([]byte, *yaml.Node) {
+	spec, config := specFixture(t, files, "root.yaml")
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{ComponentOrder: order})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	return bundled, node.Content[0]
+}
+
+var orderedSpecFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Zoo
+  version: 1.0.0
+paths:
+  /zebras:
+    get:
+      responses:
+        '200':
+          description: zebras
+          content:
+            application/json:
+              schema:
+                $ref: 'b.yaml#/components/schemas/Zebra'
+  /mangos:
+    get:
+      responses:
+        '200':
+          description: mangos
+          content:
+            application/json:
+              schema:
+                $ref: 'a.yaml#/components/schemas/Mango'
+  /apples:
+    get:
+      responses:
+        '200':
+          description: apples
+          content:
+            application/json:
+              schema:
+                $ref: 'b.yaml#/components/schemas/Apple'
+components:
+  schemas:
+    Root:
+      type: object`,
+	"a.yaml": `components:
+  schemas:
+    Mango:
+      type: object`,
+	"b.yaml": `components:
+  schemas:
+    Zebra:
+      type: object
+    Apple:
+      type: object`,
+}
+
+func TestBundleBytesComposed_ComponentOrder(t *testing.T) {
+	_, root := bundleOrdered(t, orderedSpecFiles, ComponentOrderComposed)
+	assert.Equal(t, []string{"Root", "Zebra", "Mango", "Apple"}, mappingKeys(t, root, "components", "schemas"))
+
+	first, root := bundleOrdered(t, orderedSpecFiles, ComponentOrderAlphabetical)
+	assert.Equal(t, []string{"Root", "Apple", "Mango", "Zebra"}, mappingKeys(t, root, "components", "schemas"))
+
+	// the same inputs bundle to the same bytes.
+	second, _ := bundleOrdered(t, orderedSpecFiles, ComponentOrderAlphabetical)
+	assert.Equal(t, string(first), string(second))
+
+	_, root = bundleOrdered(t, orderedSpecFiles, ComponentOrderSourceFile)
+	assert.Equal(t, []string{"Root", "Mango", "Apple", "Zebra"}, mappingKeys(t, root, "components", "schemas"))
+}
+
+func TestBundleBytesComposed_ComponentOrder_Swagger(t *testing.T) {
+	_, root := bundleOrdered(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Zoo
+  version: 1.0.0
+paths:
+  /zebras:
+    get:
+      responses:
+        '200':
+          description: zebras
+          schema:
+            $ref: 'b.yaml#/definitions/Zebra'
+  /apples:
+    get:
+      responses:
+        '200':
+          description: apples
+          schema:
+            $ref: 'a.yaml#/definitions/Apple'`,
+		"a.yaml": `definitions:
+  Apple:
+    type: object`,
+		"b.yaml": `definitions:
+  Zebra:
+    type: object`,
+	}, ComponentOrderAlphabetical)
+	assert.Equal(t, []string{"Apple", "Zebra"}, mappingKeys(t, root, "definitions"))
+}
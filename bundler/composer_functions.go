@@ -5,8 +5,12 @@ package bundler
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/url"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -18,6 +22,7 @@ import (
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/json"
 	"github.com/pb33f/libopenapi/orderedmap"
 	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
@@ -77,26 +82,50 @@ func calculateCollisionName(name, pointer, delimiter string, iteration int) stri
 }
 
 func checkReferenceAndBubbleUp[T any](
-	name, delimiter string,
+	name string,
+	namer *componentNamer,
 	pr *processRef,
 	idx *index.SpecIndex,
 	componentMap *orderedmap.Map[string, T],
 	buildFunc func(node *yaml.Node, idx *index.SpecIndex) (T, error),
 ) error {
-	// Build the component
-	component, err := buildFunc(pr.ref.Node, idx)
+	// Build the component, from an annotated copy if provenance is enabled.
+	node := pr.ref.Node
+	if pr.provenance != "" {
+		node = withProvenance(node, pr.provenance)
+	}
+	component, err := buildFunc(node, idx)
 	if err != nil {
 		return err
 	}
 
-	// Handle potential collisions and add to the component map
-	if v := componentMap.GetOrZero(name); !isZeroOfType(v) {
-		uniqueName := handleCollision(name, delimiter, pr, componentMap)
-		componentMap.Set(uniqueName, component)
-	} else {
-		componentMap.Set(name, component)
+	// an identical component with the same name has already been composed (or the component was merged with it),
+	// references point to that one.
+	componentType := componentTypeLabel(componentMap)
+	exists := !isZeroOfType(componentMap.GetOrZero(name))
+	if exists {
+		if pr.merged {
+			pr.name = name
+			return nil
+		}
+		if duplicate, ok := namer.duplicateName(componentType, name, pr.ref.Node, idx); ok {
+			pr.name = duplicate
+			return nil
+		}
 	}
 
+	// Handle potential collisions and add to the component map
+	composedName := name
+	if exists {
+		if composedName, err = handleCollision(name, namer, pr, componentMap); err != nil {
+			return err
+		}
+		if pr.merged {
+			return nil
+		}
+	}
+	componentMap.Set(composedName, component)
+	namer.record(componentType, name, composedName, pr.ref.Node, idx)
 	return nil
 }
 
@@ -105,35 +134,207 @@ func isZeroOfType[T any](v T) bool {
 	return isZero
 }
 
-func handleCollision[T any](schemaName, delimiter string, pr *processRef, componentsItem *orderedmap.Map[string, T]) string {
-	foundUnique := false
-	uniqueName := schemaName
-	iterations := 0
-	for !foundUnique {
-		iterations++
-		uniqueName = calculateCollisionName(uniqueName, pr.ref.FullDefinition, delimiter, iterations)
-		if v := componentsItem.GetOrZero(uniqueName); isZeroOfType(v) {
-			foundUnique = true
-		}
-
+func handleCollision[T any](schemaName string, namer *componentNamer, pr *processRef, componentsItem *orderedmap.Map[string, T]) (string, error) {
+	taken := func(name string) bool {
+		return !isZeroOfType(componentsItem.GetOrZero(name))
+	}
+	uniqueName, merged, err := namer.resolveName(schemaName, componentTypeLabel(componentsItem), pr, taken)
+	if err != nil {
+		return "", err
 	}
 	pr.name = uniqueName
-	return uniqueName
+	pr.merged = merged
+	return uniqueName, nil
 }
 
-func handleFileImport[T any](pr *processRef, importType, delimiter string, components *orderedmap.Map[string, T]) []string {
-	name := checkForCollision(filepath.Base(strings.Replace(pr.ref.Name, filepath.Ext(pr.ref.Name), "", 1)), delimiter, pr, components)
+func handleFileImport[T any](pr *processRef, importType string, namer *componentNamer, components *orderedmap.Map[string, T]) ([]string, error) {
+	name, err := checkForCollision(filepath.Base(strings.Replace(pr.ref.Name, filepath.Ext(pr.ref.Name), "", 1)), namer, pr, components)
+	if err != nil {
+		return nil, err
+	}
 	pr.name = name
 	pr.ref.Name = name
 	pr.seqRef.Name = name
-	return []string{v3low.ComponentsLabel, importType, name}
+	return []string{v3low.ComponentsLabel, importType, name}, nil
 }
 
-func checkForCollision[T any](schemaName, delimiter string, pr *processRef, componentsItem *orderedmap.Map[string, T]) string {
+func checkForCollision[T any](schemaName string, namer *componentNamer, pr *processRef, componentsItem *orderedmap.Map[string, T]) (string, error) {
 	if v := componentsItem.GetOrZero(schemaName); !isZeroOfType(v) {
-		return handleCollision(schemaName, delimiter, pr, componentsItem)
+		if duplicate, ok := namer.duplicateName(componentTypeLabel(componentsItem), schemaName, pr.ref.Node,
+			pr.idx); ok {
+			return duplicate, nil
+		}
+		return handleCollision(schemaName, namer, pr, componentsItem)
 	}
-	return schemaName
+	return schemaName, nil
+}
+
+// componentNamer renames components with clashing names when composing, using the NameCollisionHandler and
+// NamingStrategy of the composition config.
+type componentNamer struct {
+	config            *BundleCompositionConfig
+	rootPath          string
+	rootIdx           *index.SpecIndex
+	componentsPointer string                // where the sections of components are in the root document.
+	hashes            map[string]string     // the structural hash of every composed component, when deduplicating.
+	duplicates        map[string]string     // the composed name of every original name and structural hash.
+	nodes             map[string]*yaml.Node // the content of every composed component, by type and name.
+
+	// renamed is called with every component that is renamed (used to report renames without composing).
+	renamed func(name, componentType, uniqueName string, pr *processRef)
+}
+
+func newComponentNamer(config *BundleCompositionConfig, rootIdx *index.SpecIndex) *componentNamer {
+	n := &componentNamer{config: config, rootIdx: rootIdx, componentsPointer: "#/" + v3low.ComponentsLabel + "/"}
+	if rootIdx != nil {
+		n.rootPath = rootIdx.GetSpecAbsolutePath()
+	}
+	return n
+}
+
+// resolveName returns the name a component is composed as when its name is taken. The NameCollisionHandler decides
+// first; the component is renamed, merged with the component that has the name (true is returned), or composition
+// fails. Otherwise the component is given a unique name by the NamingStrategy.
+func (n *componentNamer) resolveName(name, componentType string, pr *processRef, taken func(name string) bool) (string, bool, error) {
+	uniqueName := ""
+	if n.config.NameCollisionHandler != nil {
+		resolved, err := n.config.NameCollisionHandler(&NameCollision{
+			Name:          name,
+			ComponentType: componentType,
+			Definition:    pr.ref.FullDefinition,
+			Node:          pr.ref.Node,
+			Existing:      n.existingNode(componentType, name),
+			Delimiter:     n.config.Delimiter,
+		})
+		switch {
+		case err != nil:
+			return "", false, fmt.Errorf("%w: unable to compose '%s' as '%s': %w", ErrNameCollision,
+				pr.ref.FullDefinition, name, err)
+		case resolved == name:
+			return name, true, nil
+		case resolved != "" && !taken(resolved):
+			uniqueName = resolved
+		}
+	}
+	if uniqueName == "" {
+		uniqueName = n.pickName(name, pr, taken)
+	}
+	if n.renamed != nil {
+		n.renamed(name, componentType, uniqueName, pr)
+	}
+	return uniqueName, false, nil
+}
+
+// pickName returns a name for a component that is not taken, using the NamingStrategy.
+func (n *componentNamer) pickName(name string, pr *processRef, taken func(name string) bool) string {
+	delimiter := n.config.Delimiter
+	definition := pr.ref.FullDefinition
+
+	var candidate string
+	switch n.config.NamingStrategy {
+	case NamingStrategyFileStem:
+		candidate = n.fileStem(definition) + delimiter + name
+	case NamingStrategyDirectoryPath:
+		candidate = n.directoryPrefix(definition) + delimiter + name
+	case NamingStrategyContentHash:
+		candidate = name + delimiter + contentHash(pr.ref.Node)
+	default:
+		uniqueName := name
+		for iterations := 1; ; iterations++ {
+			uniqueName = calculateCollisionName(uniqueName, definition, delimiter, iterations)
+			if !taken(uniqueName) {
+				return uniqueName
+			}
+		}
+	}
+
+	// the same component name can come from files with the same name (or the same content), so a counter is added
+	// until the name is unique, which keeps names stable between runs.
+	uniqueName := candidate
+	for i := 2; taken(uniqueName); i++ {
+		uniqueName = candidate + delimiter + strconv.Itoa(i)
+	}
+	return uniqueName
+}
+
+// definitionFile returns the file (or URL) part of a full definition, components defined in the root document
+// have no file part.
+func (n *componentNamer) definitionFile(definition string) string {
+	file, _, _ := strings.Cut(definition, "#")
+	if file == "" {
+		return n.rootPath
+	}
+	return file
+}
+
+func (n *componentNamer) fileStem(definition string) string {
+	base := path.Base(filepath.ToSlash(n.definitionFile(definition)))
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// directoryPrefix returns the directory of the definition relative to the root document, with each directory
+// separated by the delimiter.
+func (n *componentNamer) directoryPrefix(definition string) string {
+	file := n.definitionFile(definition)
+	var dir string
+	if u, err := url.Parse(file); err == nil && u.Scheme != "" && u.Host != "" {
+		dir = path.Dir(u.Path)
+		if root, rErr := url.Parse(n.rootPath); rErr == nil && root.Host == u.Host {
+			if rel, relErr := filepath.Rel(path.Dir(root.Path), dir); relErr == nil {
+				dir = rel
+			}
+		}
+	} else if rel, relErr := filepath.Rel(filepath.Dir(n.rootPath), filepath.Dir(file)); relErr == nil {
+		dir = rel
+	} else {
+		dir = filepath.Dir(file)
+	}
+
+	var segments []string
+	for _, segment := range strings.Split(filepath.ToSlash(dir), "/") {
+		if segment != "" && segment != "." && segment != ".." {
+			segments = append(segments, segment)
+		}
+	}
+	if len(segments) == 0 {
+		return n.fileStem(definition)
+	}
+	return strings.Join(segments, n.config.Delimiter)
+}
+
+// contentHash returns a short hash of the content of a component, that is the same between runs.
+func contentHash(node *yaml.Node) string {
+	content, err := json.YAMLNodeToJSON(node, "")
+	if err != nil {
+		content, _ = yaml.Marshal(node)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:4])
+}
+
+// componentTypeLabel returns the components section that a map of components belongs to.
+func componentTypeLabel(components any) string {
+	switch components.(type) {
+	case *orderedmap.Map[string, *base.SchemaProxy]:
+		return v3low.SchemasLabel
+	case *orderedmap.Map[string, *v3.Response]:
+		return v3low.ResponsesLabel
+	case *orderedmap.Map[string, *v3.Parameter]:
+		return v3low.ParametersLabel
+	case *orderedmap.Map[string, *v3.Header]:
+		return v3low.HeadersLabel
+	case *orderedmap.Map[string, *v3.RequestBody]:
+		return v3low.RequestBodiesLabel
+	case *orderedmap.Map[string, *base.Example]:
+		return v3low.ExamplesLabel
+	case *orderedmap.Map[string, *v3.Link]:
+		return v3low.LinksLabel
+	case *orderedmap.Map[string, *v3.Callback]:
+		return v3low.CallbacksLabel
+	case *orderedmap.Map[string, *v3.PathItem]:
+		return v3low.PathItemsLabel
+	}
+	return ""
 }
 
 func remapIndex(idx *index.SpecIndex, processedNodes *orderedmap.Map[string, *processRef]) {
@@ -163,6 +364,16 @@ func encodeJSONPointerSegment(s string) string {
 	return s
 }
 
+// decodeJSONPointerSegment decodes a JSON Pointer segment per RFC 6901 (~1 → /, ~0 → ~).
+func decodeJSONPointerSegment(s string) string {
+	if !strings.Contains(s, "~") {
+		return s
+	}
+	s = strings.ReplaceAll(s, "~1", "/")
+	s = strings.ReplaceAll(s, "~0", "~")
+	return s
+}
+
 // joinLocationAsJSONPointer joins location segments into a JSON Pointer,
 // properly encoding each segment per RFC 6901.
 func joinLocationAsJSONPointer(location []string) string {
@@ -190,6 +401,12 @@ func renameRef(idx *index.SpecIndex, def string, processedNodes *orderedmap.Map[
 		}
 		prefix := strings.Join(segs[:len(segs)-1], "/")
 
+		// reference lifted into components from somewhere else (for example a path item under 'paths')
+		if pr := processedNodes.GetOrZero(def); pr != nil && len(pr.location) == 3 &&
+			pr.location[0] == v3low.ComponentsLabel && segs[0] != v3low.ComponentsLabel {
+			return "#/" + joinLocationAsJSONPointer(pr.location)
+		}
+
 		// reference already renamed during composition
 		if pr := processedNodes.GetOrZero(def); pr != nil {
 			return fmt.Sprintf("#/%s/%s", prefix, encodeJSONPointerSegment(pr.name))
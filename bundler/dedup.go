@@ -0,0 +1,150 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// structuralHash returns a hash of the structure of a node, that is the same for nodes that are structurally
+// identical; the order of keys does not matter, and references are compared by what they point to (resolved with
+// the index of the file the node is in), so the same relative reference in two files is not mistaken as the same.
+func structuralHash(node *yaml.Node, idx *index.SpecIndex) string {
+	h := sha256.New()
+	writeStructure(h, node, idx)
+	return hex.EncodeToString(h.Sum(nil)[:8])
+}
+
+func writeStructure(h hash.Hash, node *yaml.Node, idx *index.SpecIndex) {
+	if node == nil {
+		h.Write([]byte("~"))
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			writeStructure(h, n, idx)
+		}
+	case yaml.AliasNode:
+		writeStructure(h, node.Alias, idx)
+	case yaml.ScalarNode:
+		h.Write([]byte(node.ShortTag() + ":" + strconv.Quote(node.Value)))
+	case yaml.SequenceNode:
+		h.Write([]byte("["))
+		for _, n := range node.Content {
+			writeStructure(h, n, idx)
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("]"))
+	case yaml.MappingNode:
+		if isRef, _, ref := utils.IsNodeRefValue(node); isRef {
+			h.Write([]byte("$ref:" + resolvedDefinition(ref, idx)))
+			return
+		}
+		keys := make([]int, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keys = append(keys, i)
+		}
+		slices.SortFunc(keys, func(a, b int) int {
+			return strings.Compare(node.Content[a].Value, node.Content[b].Value)
+		})
+		h.Write([]byte("{"))
+		for _, i := range keys {
+			h.Write([]byte(strconv.Quote(node.Content[i].Value) + ":"))
+			writeStructure(h, node.Content[i+1], idx)
+			h.Write([]byte(","))
+		}
+		h.Write([]byte("}"))
+	}
+}
+
+// resolvedDefinition returns the absolute location of what a reference points to, or the reference as it is
+// written if it cannot be found.
+func resolvedDefinition(ref string, idx *index.SpecIndex) string {
+	if idx == nil {
+		return ref
+	}
+	found, foundIdx := idx.SearchIndexForReference(ref)
+	if found == nil || foundIdx == nil {
+		return ref
+	}
+	definition := foundIdx.GetSpecAbsolutePath()
+	if _, fragment, ok := strings.Cut(found.FullDefinition, "#"); ok {
+		definition += "#" + fragment
+	}
+	return definition
+}
+
+// record remembers the content of a composed component by the name it was composed as, and its structure (by the
+// name it was composed as, and the name it had before a collision was resolved) when deduplicating.
+func (n *componentNamer) record(componentType, name, composedName string, node *yaml.Node, idx *index.SpecIndex) {
+	if node == nil {
+		return
+	}
+	if n.nodes == nil {
+		n.nodes = make(map[string]*yaml.Node)
+	}
+	n.nodes[componentType+"/"+composedName] = node
+	if !n.config.DeduplicateComponents {
+		return
+	}
+	if n.hashes == nil {
+		n.hashes = make(map[string]string)
+		n.duplicates = make(map[string]string)
+	}
+	h := structuralHash(node, idx)
+	n.hashes[componentType+"/"+composedName] = h
+	n.duplicates[componentType+"/"+name+"/"+h] = composedName
+}
+
+// duplicateName returns the name of a component, when deduplicating, that is structurally identical to a node that
+// would be composed with a name that is taken. The component is either one that has been composed (with the same
+// name, or renamed from it), or the one in the root document with the name.
+func (n *componentNamer) duplicateName(componentType, name string, node *yaml.Node, idx *index.SpecIndex) (string, bool) {
+	if !n.config.DeduplicateComponents || node == nil {
+		return "", false
+	}
+	h := structuralHash(node, idx)
+	if existing, ok := n.hashes[componentType+"/"+name]; ok {
+		if existing == h {
+			return name, true
+		}
+	} else if n.rootIdx != nil {
+		found := n.rootIdx.FindComponent(context.Background(),
+			n.componentsPointer+componentType+"/"+encodeJSONPointerSegment(name))
+		if found != nil && found.Node != nil && structuralHash(found.Node, n.rootIdx) == h {
+			return name, true
+		}
+	}
+	composedName, ok := n.duplicates[componentType+"/"+name+"/"+h]
+	return composedName, ok
+}
+
+// existingNode returns the content of the component that has a name; one that has been composed, or the one in the
+// root document.
+func (n *componentNamer) existingNode(componentType, name string) *yaml.Node {
+	if node, ok := n.nodes[componentType+"/"+name]; ok {
+		return node
+	}
+	if n.rootIdx == nil {
+		return nil
+	}
+	found := n.rootIdx.FindComponent(context.Background(),
+		n.componentsPointer+componentType+"/"+encodeJSONPointerSegment(name))
+	if found == nil {
+		return nil
+	}
+	return found.Node
+}
@@ -0,0 +1,185 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+var dedupSpecFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Things
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      responses:
+        '200':
+          description: a
+          content:
+            application/json:
+              schema:
+                $ref: 'a.yaml#/components/schemas/Thing'
+  /b:
+    get:
+      responses:
+        '200':
+          description: b
+          content:
+            application/json:
+              schema:
+                $ref: 'b.yaml#/components/schemas/Thing'
+  /c:
+    get:
+      responses:
+        '200':
+          description: c
+          content:
+            application/json:
+              schema:
+                $ref: 'c.yaml#/components/schemas/Thing'
+  /d:
+    get:
+      responses:
+        '200':
+          description: d
+          content:
+            application/json:
+              schema:
+                $ref: 'd.yaml#/components/schemas/Thing'
+  /x:
+    get:
+      responses:
+        '200':
+          description: x
+          content:
+            application/json:
+              schema:
+                $ref: 'x/item.yaml#/components/schemas/Item'
+  /y:
+    get:
+      responses:
+        '200':
+          description: y
+          content:
+            application/json:
+              schema:
+                $ref: 'y/item.yaml#/components/schemas/Item'`,
+	"a.yaml": `components:
+  schemas:
+    Thing:
+      type: object
+      required: [id]`,
+	"b.yaml": `components:
+  schemas:
+    Thing:
+      required: [id]
+      type: object`,
+	"c.yaml": `components:
+  schemas:
+    Thing:
+      type: string`,
+	"d.yaml": `components:
+  schemas:
+    Thing:
+      type: string`,
+	// the same text, but the references point to different files.
+	"x/item.yaml": `components:
+  schemas:
+    Item:
+      $ref: 'id.yaml'`,
+	"x/id.yaml": `type: integer`,
+	"y/item.yaml": `components:
+  schemas:
+    Item:
+      $ref: 'id.yaml'`,
+	"y/id.yaml": `type: string`,
+}
+
+func composeDeduplicated(t *testing.T, deduplicate bool) *yaml.Node {
+	spec, config := specFixture(t, dedupSpecFiles, "root.yaml")
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{DeduplicateComponents: deduplicate})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	return node.Content[0]
+}
+
+func responseSchemaRef(t *testing.T, root *yaml.Node, path string) string {
+	_, ref := findYAMLPath(root, "paths", path, "get", "responses", "200", "content", "application/json", "schema",
+		"$ref")
+	require.NotNil(t, ref)
+	return ref.Value
+}
+
+func TestBundleBytesComposed_DeduplicateComponents(t *testing.T) {
+	root := composeDeduplicated(t, true)
+
+	assert.Equal(t, "#/components/schemas/Thing", responseSchemaRef(t, root, "/a"))
+	assert.Equal(t, "#/components/schemas/Thing", responseSchemaRef(t, root, "/b"))
+	assert.Equal(t, "#/components/schemas/Thing__c", responseSchemaRef(t, root, "/c"))
+	assert.Equal(t, "#/components/schemas/Thing__c", responseSchemaRef(t, root, "/d"))
+	assert.NotEqual(t, responseSchemaRef(t, root, "/x"), responseSchemaRef(t, root, "/y"))
+	assert.NotContains(t, mappingKeys(t, root, "components", "schemas"), "Thing__b")
+	assert.NotContains(t, mappingKeys(t, root, "components", "schemas"), "Thing__d")
+
+	// without deduplication, every clash is renamed.
+	root = composeDeduplicated(t, false)
+	assert.Equal(t, "#/components/schemas/Thing__b", responseSchemaRef(t, root, "/b"))
+	assert.Equal(t, "#/components/schemas/Thing__d", responseSchemaRef(t, root, "/d"))
+}
+
+func TestBundleBytesComposed_DeduplicateComponents_Swagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Things
+  version: 1.0.0
+paths:
+  /a:
+    get:
+      responses:
+        '200':
+          description: a
+          schema:
+            $ref: 'a.yaml#/definitions/Thing'
+  /b:
+    get:
+      responses:
+        '200':
+          description: b
+          schema:
+            $ref: 'b.yaml#/definitions/Thing'
+definitions:
+  Thing:
+    type: object`,
+		"a.yaml": `definitions:
+  Thing:
+    type: object`,
+		"b.yaml": `definitions:
+  Thing:
+    type: string`,
+	}, "root.yaml")
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{DeduplicateComponents: true})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+
+	// the component in the root document is identical to the one in a.yaml.
+	assert.Equal(t, []string{"Thing", "Thing__b"}, mappingKeys(t, node.Content[0], "definitions"))
+}
+
+func TestStructuralHash(t *testing.T) {
+	var a, b, c yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("type: object\nrequired: [id]"), &a))
+	require.NoError(t, yaml.Unmarshal([]byte("required: [id]\ntype: object"), &b))
+	require.NoError(t, yaml.Unmarshal([]byte("required: ['1']\ntype: object"), &c))
+	assert.Equal(t, structuralHash(&a, nil), structuralHash(&b, nil))
+	assert.NotEqual(t, structuralHash(&a, nil), structuralHash(&c, nil))
+}
@@ -0,0 +1,208 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// CompositionReport describes what composing a bundle would do, without composing it. It lists every reference
+// that would be lifted into the components of the root document (and the name it would be given), and every
+// reference that would be inlined instead. CI pipelines can use it to fail on unexpected renames.
+type CompositionReport struct {
+	Components []*ComposedComponent // Components are the references that would be lifted, in the order they are composed.
+	Inlined    []string             // Inlined are the full definitions of references that cannot be composed, and would be inlined.
+
+	rootPath string
+	prefix   []string                     // the location of the sections of the components in the root document.
+	owners   map[string]string            // the full definition of the component holding each section/name.
+	renames  map[*processRef]*renamedName // the original name of every renamed reference.
+}
+
+// ComposedComponent is a reference that would be lifted into the components of the root document.
+type ComposedComponent struct {
+	Definition    string // Definition is the full definition of the reference, the file path (or URL) and JSON pointer.
+	ComponentType string // ComponentType is the components section, for example 'schemas' or 'responses'.
+	Name          string // Name is the name of the component, before any collision is resolved.
+	ComposedName  string // ComposedName is the name the component would be given in the bundle.
+	CollidesWith  string // CollidesWith is the full definition of the component that already has the name, if renamed.
+}
+
+type renamedName struct {
+	name         string
+	collidesWith string
+}
+
+// Renamed returns true if the component would be given a different name, because its name collides with another.
+func (c *ComposedComponent) Renamed() bool {
+	return c.Name != c.ComposedName
+}
+
+// Renames returns the components that would be renamed.
+func (r *CompositionReport) Renames() []*ComposedComponent {
+	var renamed []*ComposedComponent
+	for _, c := range r.Components {
+		if c.Renamed() {
+			renamed = append(renamed, c)
+		}
+	}
+	return renamed
+}
+
+func newCompositionReport(rootIdx *index.SpecIndex, prefix ...string) *CompositionReport {
+	r := &CompositionReport{
+		prefix:  prefix,
+		owners:  make(map[string]string),
+		renames: make(map[*processRef]*renamedName),
+	}
+	if rootIdx != nil {
+		r.rootPath = rootIdx.GetSpecAbsolutePath()
+	}
+	return r
+}
+
+// renamed records the original name of a renamed reference, and the component it collides with. Names that are
+// not held by a composed component are held by a component of the root document.
+func (r *CompositionReport) renamed(name, componentType, _ string, pr *processRef) {
+	owner, ok := r.owners[componentType+"/"+name]
+	if !ok {
+		owner = r.rootPath + "#/" + joinLocationAsJSONPointer(append(slices.Clone(r.prefix), componentType, name))
+	}
+	r.renames[pr] = &renamedName{name: name, collidesWith: owner}
+}
+
+// composed records a reference that would be lifted into a section of the components.
+func (r *CompositionReport) composed(definition, componentType, composedName string, pr *processRef) {
+	c := &ComposedComponent{
+		Definition:    definition,
+		ComponentType: componentType,
+		Name:          composedName,
+		ComposedName:  composedName,
+	}
+	if renamed, ok := r.renames[pr]; ok {
+		c.Name = renamed.name
+		c.CollidesWith = renamed.collidesWith
+	}
+	r.owners[componentType+"/"+composedName] = definition
+	r.Components = append(r.Components, c)
+}
+
+// DryRunBytesComposed reports what BundleBytesComposed would do with a byte slice of an OpenAPI (or Swagger)
+// specification, without composing it.
+func DryRunBytesComposed(bytes []byte, configuration *datamodel.DocumentConfiguration,
+	compositionConfig *BundleCompositionConfig,
+) (*CompositionReport, error) {
+	doc, err := libopenapi.NewDocumentWithConfiguration(bytes, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	if isSwagger(doc) {
+		v2Doc, e := doc.BuildV2Model()
+		if v2Doc == nil {
+			return nil, errors.Join(ErrInvalidModel, e)
+		}
+		return DryRunSwaggerComposition(&v2Doc.Model, compositionConfig)
+	}
+
+	v3Doc, err := doc.BuildV3Model()
+	if v3Doc == nil || err != nil {
+		return nil, errors.Join(ErrInvalidModel, err)
+	}
+	return DryRunComposition(&v3Doc.Model, compositionConfig)
+}
+
+// DryRunComposition plans the composition of a v3.Document (the same as BundleDocumentComposed), and reports which
+// references would be lifted into components, which names collide, and what they would be renamed to. The model
+// is not changed.
+func DryRunComposition(model *v3.Document, compositionConfig *BundleCompositionConfig) (*CompositionReport, error) {
+	compositionConfig, err := checkCompositionConfig(compositionConfig)
+	if err != nil {
+		return nil, err
+	}
+	if model == nil || model.Rolodex == nil {
+		return nil, errors.New("model or rolodex is nil")
+	}
+
+	rolodex := model.Rolodex
+	report := newCompositionReport(rolodex.GetRootIndex(), v3low.ComponentsLabel)
+	cf := &handleIndexConfig{
+		idx:               rolodex.GetRootIndex(),
+		model:             model,
+		indexes:           rolodex.GetIndexes(),
+		seen:              sync.Map{},
+		refMap:            orderedmap.New[string, *processRef](),
+		compositionConfig: compositionConfig,
+		namer:             newComponentNamer(compositionConfig, rolodex.GetRootIndex()),
+	}
+	cf.namer.renamed = report.renamed
+	if err := handleIndex(cf); err != nil {
+		return nil, err
+	}
+
+	// composition changes the components and the references it is given, so it is given copies.
+	scratch := &v3.Document{Components: copyComponents(model.Components)}
+	var errs []error
+	for _, pr := range cf.refMap.FromOldest() {
+		ref, seqRef := *pr.ref, *pr.seqRef
+		pr.ref, pr.seqRef = &ref, &seqRef
+
+		inlined := len(cf.inlineRequired)
+		errs = append(errs, processReference(scratch, pr, cf))
+		switch {
+		case len(cf.inlineRequired) > inlined:
+			report.Inlined = append(report.Inlined, pr.ref.FullDefinition)
+		case len(pr.location) > 2 && pr.location[0] == v3low.ComponentsLabel:
+			report.composed(pr.ref.FullDefinition, pr.location[1], pr.name, pr)
+		}
+	}
+	return report, errors.Join(errs...)
+}
+
+// DryRunSwaggerComposition plans the composition of a Swagger document (the same as BundleSwaggerDocumentComposed),
+// and reports which references would be lifted into definitions, parameters or responses, which names collide, and
+// what they would be renamed to. The model is not changed.
+func DryRunSwaggerComposition(model *v2.Swagger, compositionConfig *BundleCompositionConfig) (*CompositionReport, error) {
+	compositionConfig, err := checkCompositionConfig(compositionConfig)
+	if err != nil {
+		return nil, err
+	}
+	return bundleSwaggerReport(model, compositionConfig)
+}
+
+// copyComponents returns a copy of the maps of components that composition adds to.
+func copyComponents(components *v3.Components) *v3.Components {
+	if components == nil {
+		return nil
+	}
+	return &v3.Components{
+		Schemas:       copyMap(components.Schemas),
+		Responses:     copyMap(components.Responses),
+		Parameters:    copyMap(components.Parameters),
+		Examples:      copyMap(components.Examples),
+		RequestBodies: copyMap(components.RequestBodies),
+		Headers:       copyMap(components.Headers),
+		Links:         copyMap(components.Links),
+		Callbacks:     copyMap(components.Callbacks),
+		PathItems:     copyMap(components.PathItems),
+	}
+}
+
+func copyMap[T any](m *orderedmap.Map[string, T]) *orderedmap.Map[string, T] {
+	if m == nil {
+		return nil
+	}
+	return orderedmap.From(m.FromOldest())
+}
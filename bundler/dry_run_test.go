@@ -0,0 +1,138 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// dryRunFiles are a specification with a schema that clashes with a component of the root document, and a
+// schema that is a whole file.
+var dryRunFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models/pet.yaml#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        '200':
+          description: owners
+          content:
+            application/json:
+              schema:
+                $ref: 'owner.yaml'
+components:
+  schemas:
+    Pet:
+      type: string`,
+	"models/pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object`,
+	"owner.yaml": `type: object
+properties:
+  name:
+    type: string`,
+}
+
+func TestDryRunComposition(t *testing.T) {
+	spec, config := specFixture(t, dryRunFiles, "root.yaml")
+	dir := config.BasePath
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, config)
+	require.NoError(t, err)
+	v3Doc, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	report, err := DryRunComposition(&v3Doc.Model, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Components, 2)
+
+	pet := report.Components[0]
+	assert.Equal(t, filepath.Join(dir, "models", "pet.yaml")+"#/components/schemas/Pet", pet.Definition)
+	assert.Equal(t, "schemas", pet.ComponentType)
+	assert.Equal(t, "Pet", pet.Name)
+	assert.Equal(t, "Pet__pet", pet.ComposedName)
+	assert.Equal(t, filepath.Join(dir, "root.yaml")+"#/components/schemas/Pet", pet.CollidesWith)
+	assert.True(t, pet.Renamed())
+
+	owner := report.Components[1]
+	assert.Equal(t, "owner", owner.ComposedName)
+	assert.False(t, owner.Renamed())
+	assert.Empty(t, owner.CollidesWith)
+	assert.Equal(t, []*ComposedComponent{pet}, report.Renames())
+	assert.Empty(t, report.Inlined)
+
+	// the model is not changed, and composing it gives the reported names.
+	assert.Equal(t, 1, orderedmap.Len(v3Doc.Model.Components.Schemas))
+	bundled, err := BundleDocumentComposed(&v3Doc.Model, nil)
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	assert.Equal(t, []string{"Pet", "Pet__pet", "owner"}, mappingKeys(t, node.Content[0], "components", "schemas"))
+}
+
+func TestDryRunBytesComposed_NamingStrategy(t *testing.T) {
+	spec, config := specFixture(t, dryRunFiles, "root.yaml")
+	report, err := DryRunBytesComposed(spec, config, &BundleCompositionConfig{NamingStrategy: NamingStrategyFileStem})
+	require.NoError(t, err)
+	require.Len(t, report.Renames(), 1)
+	assert.Equal(t, "pet__Pet", report.Renames()[0].ComposedName)
+
+	_, err = DryRunBytesComposed(spec, config, &BundleCompositionConfig{Delimiter: "#"})
+	assert.Error(t, err)
+}
+
+func TestDryRunBytesComposed_Swagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/definitions/Pet'
+definitions:
+  Pet:
+    type: string`,
+		"models.yaml": `definitions:
+  Pet:
+    type: object`,
+	}, "root.yaml")
+	report, err := DryRunBytesComposed(spec, config, nil)
+	require.NoError(t, err)
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "definitions", report.Components[0].ComponentType)
+	assert.Equal(t, "Pet__models", report.Components[0].ComposedName)
+	assert.Equal(t, filepath.Join(config.BasePath, "root.yaml")+"#/definitions/Pet", report.Components[0].CollidesWith)
+}
+
+func TestDryRunComposition_NilModel(t *testing.T) {
+	_, err := DryRunComposition(nil, nil)
+	assert.Error(t, err)
+	_, err = DryRunSwaggerComposition(nil, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,229 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// BundleEventType identifies what a BundleEvent is reporting.
+type BundleEventType string
+
+const (
+	// BundleEventReferenceDiscovered is emitted for every reference to another file that is found, before it is
+	// bundled.
+	BundleEventReferenceDiscovered BundleEventType = "reference-discovered"
+
+	// BundleEventReferenceInlined is emitted when the content of a reference is copied in place of the reference.
+	BundleEventReferenceInlined BundleEventType = "reference-inlined"
+
+	// BundleEventReferenceComposed is emitted when a reference is lifted into the components of the bundle, and
+	// points to it (composed bundles only).
+	BundleEventReferenceComposed BundleEventType = "reference-composed"
+
+	// BundleEventCircularReferenceSkipped is emitted when a circular reference into another file is left as it is
+	// written, because it cannot be inlined (CircularReferenceModeSkip).
+	BundleEventCircularReferenceSkipped BundleEventType = "circular-reference-skipped"
+
+	// BundleEventFileProcessed is emitted when every reference of a file has been found (OpenAPI 3 documents only).
+	BundleEventFileProcessed BundleEventType = "file-processed"
+)
+
+// BundleEvent reports the progress of a bundle as it happens, so long bundling runs can drive progress bars and
+// structured logs.
+type BundleEvent struct {
+	// Type is what the event is reporting.
+	Type BundleEventType
+
+	// Message is a human-readable description of the event.
+	Message string
+
+	// File is the path or URL of the file that was processed, or the file the reference is written in, if known.
+	File string
+
+	// Ref is the reference ($ref value) as it is written, if known.
+	Ref string
+
+	// Definition is the full definition of what the reference points to, the file path (or URL) and JSON pointer.
+	Definition string
+
+	// Component is the local reference to the component a reference was composed to.
+	Component string
+
+	// Line and Column locate the reference in the file, if known.
+	Line   int
+	Column int
+
+	// Processed is the number of files processed so far, and Total is the number of files in the rolodex (not
+	// every file is always reached), for file processed events.
+	Processed int
+	Total     int
+}
+
+// BundleEventHandler is called with every BundleEvent emitted while bundling. Rendering can run across goroutines, so
+// a handler can be called concurrently and must be safe to do so. Handlers should return quickly, as they are called
+// inline.
+type BundleEventHandler func(event *BundleEvent)
+
+// bundleEvents emits the events of a bundle to a handler, it is nil (and does nothing) without a handler.
+type bundleEvents struct {
+	handler   BundleEventHandler
+	rootPath  string
+	total     int
+	processed int
+	lock      sync.Mutex
+}
+
+func newBundleEvents(handler BundleEventHandler, rolodex *index.Rolodex) *bundleEvents {
+	if handler == nil {
+		return nil
+	}
+	e := &bundleEvents{handler: handler}
+	if rolodex != nil {
+		if rootIdx := rolodex.GetRootIndex(); rootIdx != nil {
+			e.rootPath = rootIdx.GetSpecAbsolutePath()
+			e.total = 1
+		}
+		e.total += len(rolodex.GetIndexes())
+	}
+	return e
+}
+
+func (e *bundleEvents) emit(event *BundleEvent) {
+	if e == nil {
+		return
+	}
+	e.handler(event)
+}
+
+// fileProcessed emits a file processed event, counting the files processed so far.
+func (e *bundleEvents) fileProcessed(file string) {
+	if e == nil {
+		return
+	}
+	e.lock.Lock()
+	e.processed++
+	processed := e.processed
+	e.lock.Unlock()
+	e.emit(&BundleEvent{
+		Type:      BundleEventFileProcessed,
+		Message:   fmt.Sprintf("processed '%s' (%d of %d)", file, processed, e.total),
+		File:      file,
+		Processed: processed,
+		Total:     e.total,
+	})
+}
+
+// referenceEvent emits an event for a reference written in a file, pointing to a full definition.
+func (e *bundleEvents) referenceEvent(eventType BundleEventType, file string, ref *yaml.Node, definition, component string) {
+	if e == nil {
+		return
+	}
+	event := &BundleEvent{
+		Type:       eventType,
+		File:       file,
+		Definition: definition,
+		Component:  component,
+	}
+	if ref != nil {
+		event.Ref, event.Line, event.Column = ref.Value, ref.Line, ref.Column
+	}
+	switch eventType {
+	case BundleEventReferenceDiscovered:
+		event.Message = fmt.Sprintf("discovered reference to '%s'", definition)
+	case BundleEventReferenceInlined:
+		event.Message = fmt.Sprintf("inlined reference to '%s'", definition)
+	case BundleEventReferenceComposed:
+		event.Message = fmt.Sprintf("composed reference to '%s' as '%s'", definition, component)
+	case BundleEventCircularReferenceSkipped:
+		event.Message = fmt.Sprintf("skipped circular reference to '%s'", definition)
+	}
+	e.emit(event)
+}
+
+// discover emits a discovered event for every reference to another file in the rolodex (in the order they are
+// written), and a file processed event for every file, before a document is rendered inline.
+func (e *bundleEvents) discover(rolodex *index.Rolodex) {
+	if e == nil || rolodex == nil || rolodex.GetRootIndex() == nil {
+		return
+	}
+	for _, idx := range append([]*index.SpecIndex{rolodex.GetRootIndex()}, rolodex.GetIndexes()...) {
+		file := idx.GetSpecAbsolutePath()
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			target, _, _ := strings.Cut(ref.FullDefinition, "#")
+			if target == "" || target == e.rootPath {
+				continue
+			}
+			e.referenceEvent(BundleEventReferenceDiscovered, file, refValueNode(ref), ref.FullDefinition, "")
+		}
+		e.fileProcessed(file)
+	}
+}
+
+// annotator is an index.ReferenceAnnotator that emits an inlined event for every reference inlined while rendering.
+func (e *bundleEvents) annotator() index.ReferenceAnnotator {
+	if e == nil {
+		return nil
+	}
+	return func(idx *index.SpecIndex, ref string, _ *yaml.Node) {
+		if idx == nil || idx.GetSpecAbsolutePath() == e.rootPath {
+			return
+		}
+		definition := idx.GetSpecAbsolutePath()
+		if _, fragment, ok := strings.Cut(ref, "#"); ok && fragment != "" {
+			definition += "#" + fragment
+		}
+		e.emit(&BundleEvent{
+			Type:       BundleEventReferenceInlined,
+			Message:    fmt.Sprintf("inlined reference to '%s'", definition),
+			Ref:        ref,
+			Definition: definition,
+		})
+	}
+}
+
+// referenceFile returns the path (or URL) of the file a reference is written in, if known.
+func referenceFile(ref *index.Reference) string {
+	if ref == nil || ref.Index == nil {
+		return ""
+	}
+	return ref.Index.GetSpecAbsolutePath()
+}
+
+// refValueNode returns the value node of the $ref of a reference, or nil.
+func refValueNode(ref *index.Reference) *yaml.Node {
+	if ref == nil || ref.Node == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(ref.Node.Content); i += 2 {
+		if ref.Node.Content[i].Value == "$ref" {
+			return ref.Node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// chainAnnotators returns an index.ReferenceAnnotator that calls each annotator in turn.
+func chainAnnotators(annotators ...index.ReferenceAnnotator) index.ReferenceAnnotator {
+	var chain []index.ReferenceAnnotator
+	for _, a := range annotators {
+		if a != nil {
+			chain = append(chain, a)
+		}
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+	return func(idx *index.SpecIndex, ref string, node *yaml.Node) {
+		for _, a := range chain {
+			a(idx, ref, node)
+		}
+	}
+}
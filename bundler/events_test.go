@@ -0,0 +1,147 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// eventRecorder collects the events of a bundle.
+type eventRecorder struct {
+	events []*BundleEvent
+	lock   sync.Mutex
+}
+
+func (r *eventRecorder) handle(event *BundleEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *eventRecorder) ofType(eventType BundleEventType) []*BundleEvent {
+	var events []*BundleEvent
+	for _, e := range r.events {
+		if e.Type == eventType {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+func TestBundleBytesWithConfig_EventHandler(t *testing.T) {
+	spec, config := specFixture(t, outputFiles, "root.yaml")
+	recorder := &eventRecorder{}
+	_, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{EventHandler: recorder.handle})
+	require.NoError(t, err)
+
+	discovered := recorder.ofType(BundleEventReferenceDiscovered)
+	require.Len(t, discovered, 1)
+	assert.Equal(t, "models.yaml#/components/schemas/Pet", discovered[0].Ref)
+	assert.True(t, strings.HasSuffix(discovered[0].Definition, "models.yaml#/components/schemas/Pet"))
+	assert.True(t, strings.HasSuffix(discovered[0].File, "root.yaml"))
+	assert.Equal(t, 14, discovered[0].Line)
+
+	inlined := recorder.ofType(BundleEventReferenceInlined)
+	require.NotEmpty(t, inlined)
+	assert.Equal(t, discovered[0].Definition, inlined[0].Definition)
+
+	processed := recorder.ofType(BundleEventFileProcessed)
+	require.Len(t, processed, 2)
+	assert.True(t, strings.HasSuffix(processed[0].File, "root.yaml"))
+	assert.Equal(t, 2, processed[1].Processed)
+	assert.Equal(t, 2, processed[1].Total)
+}
+
+func TestBundleBytesWithConfig_EventHandler_CircularReferenceSkipped(t *testing.T) {
+	recorder := &eventRecorder{}
+	_, err := bundleCircular(t, &BundleInlineConfig{EventHandler: recorder.handle})
+	require.NoError(t, err)
+
+	skipped := recorder.ofType(BundleEventCircularReferenceSkipped)
+	require.NotEmpty(t, skipped)
+	var node bool
+	for _, e := range skipped {
+		node = node || strings.HasSuffix(e.Definition, filepath.Join("tree", "node.yaml")+"#/components/schemas/Node")
+	}
+	assert.True(t, node)
+
+	// circular references that are extracted are not skipped.
+	recorder = &eventRecorder{}
+	_, err = bundleCircular(t, &BundleInlineConfig{
+		CircularReferenceMode: CircularReferenceModeExtract,
+		EventHandler:          recorder.handle,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, recorder.ofType(BundleEventCircularReferenceSkipped))
+}
+
+func TestBundleBytesComposed_EventHandler(t *testing.T) {
+	spec, config := specFixture(t, outputFiles, "root.yaml")
+	recorder := &eventRecorder{}
+	_, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{EventHandler: recorder.handle})
+	require.NoError(t, err)
+
+	discovered := recorder.ofType(BundleEventReferenceDiscovered)
+	require.Len(t, discovered, 1)
+	assert.True(t, strings.HasSuffix(discovered[0].File, "root.yaml"))
+
+	composed := recorder.ofType(BundleEventReferenceComposed)
+	require.Len(t, composed, 1)
+	assert.Equal(t, "#/components/schemas/Pet", composed[0].Component)
+	assert.Equal(t, "models.yaml#/components/schemas/Pet", composed[0].Ref)
+	assert.Contains(t, composed[0].Message, "as '#/components/schemas/Pet'")
+
+	processed := recorder.ofType(BundleEventFileProcessed)
+	require.Len(t, processed, 2)
+	assert.Equal(t, 1, processed[0].Processed)
+	assert.True(t, strings.HasSuffix(processed[1].File, "models.yaml"))
+}
+
+func TestBundleSwaggerDocumentComposed_EventHandler(t *testing.T) {
+	dir, spec := swaggerTree(t)
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, swaggerConfig(dir))
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+
+	recorder := &eventRecorder{}
+	_, err = BundleSwaggerDocumentComposed(&m.Model, &BundleCompositionConfig{EventHandler: recorder.handle})
+	require.NoError(t, err)
+	composed := recorder.ofType(BundleEventReferenceComposed)
+	require.NotEmpty(t, composed)
+	assert.Len(t, recorder.ofType(BundleEventReferenceDiscovered), len(composed))
+	var components []string
+	for _, e := range composed {
+		components = append(components, e.Component)
+	}
+	assert.Contains(t, components, "#/definitions/Pet__pets")
+	assert.Empty(t, recorder.ofType(BundleEventReferenceInlined))
+
+	recorder = &eventRecorder{}
+	_, err = BundleBytesWithConfig(spec, swaggerConfig(dir), &BundleInlineConfig{EventHandler: recorder.handle})
+	require.NoError(t, err)
+	assert.NotEmpty(t, recorder.ofType(BundleEventReferenceInlined))
+}
+
+func TestChainAnnotators(t *testing.T) {
+	assert.Nil(t, chainAnnotators(nil, nil))
+
+	var calls []string
+	chain := chainAnnotators(func(_ *index.SpecIndex, ref string, _ *yaml.Node) {
+		calls = append(calls, "first "+ref)
+	}, nil, func(_ *index.SpecIndex, ref string, _ *yaml.Node) {
+		calls = append(calls, "second "+ref)
+	})
+	chain(nil, "pet.yaml", &yaml.Node{})
+	assert.Equal(t, []string{"first pet.yaml", "second pet.yaml"}, calls)
+}
@@ -0,0 +1,193 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// exampleFiles embeds the content of example payload files in the examples that point to them, so the bundle has
+// no loose sidecar files. Files are located relative to the file the example is written in.
+type exampleFiles struct {
+	rolodex *index.Rolodex
+	content map[string]*yaml.Node // the parsed content of every file read, by location.
+	failed  map[*yaml.Node]bool   // the nodes pointing to files that cannot be read.
+	errs    []error
+	lock    sync.Mutex // the annotator can be called from more than one goroutine.
+}
+
+func newExampleFiles(rolodex *index.Rolodex) *exampleFiles {
+	return &exampleFiles{rolodex: rolodex, content: make(map[string]*yaml.Node), failed: make(map[*yaml.Node]bool)}
+}
+
+// embed embeds every example payload file found under a rendered node, written in the file at a location. It
+// replaces the `externalValue` of example objects, and `$ref`s to whole files used as an example `value` (or as
+// an `example`), with the content of the file as the `value`.
+func (e *exampleFiles) embed(location string, node *yaml.Node) {
+	if node == nil {
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		for _, n := range node.Content {
+			e.embed(location, n)
+		}
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i].Value, node.Content[i+1]
+		// examples are payloads, and extensions are nodes of the model, so they are not searched any further.
+		if strings.HasPrefix(key, "x-") {
+			continue
+		}
+		switch key {
+		case "examples":
+			if value.Kind == yaml.MappingNode {
+				for j := 1; j < len(value.Content); j += 2 {
+					e.embedExample(location, value.Content[j])
+				}
+			}
+		case "example":
+			if file := payloadRef(value); file != "" {
+				if content := e.read(location, file, value); content != nil {
+					node.Content[i+1] = content
+				}
+			}
+		default:
+			e.embed(location, value)
+		}
+	}
+}
+
+// embedExample embeds the payload file of an example object.
+func (e *exampleFiles) embedExample(location string, example *yaml.Node) {
+	if example.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(example.Content); i += 2 {
+		key, value := example.Content[i].Value, example.Content[i+1]
+		var file string
+		switch key {
+		case "externalValue":
+			if value.Kind == yaml.ScalarNode && mappingValue(example, "value") == nil {
+				file = value.Value
+			}
+		case "value":
+			file = payloadRef(value)
+		}
+		if file == "" {
+			continue
+		}
+		if content := e.read(location, file, value); content != nil {
+			example.Content[i].Value = "value"
+			example.Content[i+1] = content
+		}
+		return
+	}
+}
+
+// read returns the content of a payload file, relative to the file at a location. JSON and YAML files are parsed,
+// anything else is embedded as a string. The content of each file is read once, and a copy returned every time.
+// The node that points to a file that cannot be read is remembered, so it is only reported once.
+func (e *exampleFiles) read(location, file string, from *yaml.Node) *yaml.Node {
+	target := resolveLocation(location, file)
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.failed[from] {
+		return nil
+	}
+	if content, ok := e.content[target]; ok {
+		return utils.CloneYAMLNode(content)
+	}
+	data, err := e.load(target)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("unable to embed example file '%s': %w", file, err))
+		e.failed[from] = true
+		return nil
+	}
+	var content *yaml.Node
+	switch strings.ToLower(path.Ext(target)) {
+	case ".json", ".yaml", ".yml":
+		var doc yaml.Node
+		if err = yaml.Unmarshal(data, &doc); err != nil {
+			e.errs = append(e.errs, fmt.Errorf("unable to embed example file '%s': %w", file, err))
+			e.failed[from] = true
+			return nil
+		}
+		if len(doc.Content) > 0 {
+			content = doc.Content[0]
+		}
+	}
+	if content == nil {
+		content = utils.CreateStringNode(string(data))
+		if strings.Contains(content.Value, "\n") {
+			content.Style = yaml.LiteralStyle
+		}
+	}
+	e.content[target] = content
+	return utils.CloneYAMLNode(content)
+}
+
+// load reads a payload file through the rolodex, so the same file systems (and remote settings) are used as for
+// the rest of the document. Local files the rolodex does not read (anything other than JSON or YAML) are read
+// from disk.
+func (e *exampleFiles) load(location string) ([]byte, error) {
+	if e.rolodex != nil {
+		if f, err := e.rolodex.Open(location); err == nil && f != nil {
+			return []byte(f.GetContent()), nil
+		}
+	}
+	if isRemoteLocation(location) {
+		return nil, errors.New("the remote file cannot be read")
+	}
+	return os.ReadFile(location)
+}
+
+// annotator is an index.ReferenceAnnotator that embeds the payload files of every inlined reference, relative to
+// the file it was inlined from.
+func (e *exampleFiles) annotator() index.ReferenceAnnotator {
+	return func(idx *index.SpecIndex, _ string, node *yaml.Node) {
+		if idx != nil {
+			e.embed(idx.GetSpecAbsolutePath(), node)
+		}
+	}
+}
+
+// embedComposed embeds the payload files of every component lifted into a rendered composed bundle (relative to
+// the file it was lifted from), then the payload files of the root document.
+func (e *exampleFiles) embedComposed(rendered *yaml.Node, composed []*processRef) {
+	for _, pr := range composed {
+		if _, value := composedComponent(rendered, pr); value != nil && pr.sourceIndex() != nil {
+			e.embed(pr.sourceIndex().GetSpecAbsolutePath(), value)
+		}
+	}
+	e.embed(e.rolodex.GetRootIndex().GetSpecAbsolutePath(), rendered)
+}
+
+func (e *exampleFiles) err() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return errors.Join(e.errs...)
+}
+
+// payloadRef returns the file of a `$ref` to a whole file (with no JSON pointer), or an empty string.
+func payloadRef(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.MappingNode || len(node.Content) != 2 || node.Content[0].Value != "$ref" {
+		return ""
+	}
+	ref := node.Content[1].Value
+	if file, fragment, _ := strings.Cut(ref, "#"); file != "" && strings.Trim(fragment, "/") == "" {
+		return file
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// exampleSpecFiles are a specification with examples in files, referenced by externalValue and $ref.
+var exampleSpecFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: 'models/pet.yaml#/components/schemas/Pet'
+            examples:
+              fido:
+                summary: a dog
+                externalValue: 'examples/fido.json'
+              rex:
+                value:
+                  $ref: 'examples/rex.yaml'
+          text/plain:
+            example:
+              $ref: 'examples/pet.txt'
+      responses:
+        '200':
+          $ref: 'models/pet.yaml#/components/responses/Pet'`,
+	"models/pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+  responses:
+    Pet:
+      description: a pet
+      content:
+        application/json:
+          examples:
+            tom:
+              externalValue: 'payloads/tom.json'`,
+	"examples/fido.json":       `{"name": "fido", "tags": ["good"]}`,
+	"examples/rex.yaml":        "name: rex\n",
+	"examples/pet.txt":         "a pet\ncalled fido\n",
+	"models/payloads/tom.json": `{"name": "tom"}`,
+}
+
+// bundledExamples returns the examples of the request, the plain text example, and the examples of the response
+// of a bundle. The response is found in the components of a composed bundle.
+func bundledExamples(t *testing.T, bundled []byte) (map[string]any, any, map[string]any) {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Example  any            `yaml:"example"`
+					Examples map[string]any `yaml:"examples"`
+				} `yaml:"content"`
+			} `yaml:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]any `yaml:"examples"`
+				} `yaml:"content"`
+			} `yaml:"responses"`
+		} `yaml:"paths"`
+		Components struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]any `yaml:"examples"`
+				} `yaml:"content"`
+			} `yaml:"responses"`
+		} `yaml:"components"`
+	}
+	require.NoError(t, yaml.Unmarshal(bundled, &doc))
+	post := doc.Paths["/pets"]["post"]
+	response := post.Responses["200"].Content["application/json"].Examples
+	if response == nil {
+		response = doc.Components.Responses["Pet"].Content["application/json"].Examples
+	}
+	return post.RequestBody.Content["application/json"].Examples, post.RequestBody.Content["text/plain"].Example,
+		response
+}
+
+func TestBundleBytesWithConfig_InlineExternalExamples(t *testing.T) {
+	spec, config := specFixture(t, exampleSpecFiles, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{InlineExternalExamples: true})
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundled), "externalValue")
+
+	request, text, response := bundledExamples(t, bundled)
+	assert.Equal(t, map[string]any{
+		"summary": "a dog",
+		"value":   map[string]any{"name": "fido", "tags": []any{"good"}},
+	}, request["fido"])
+	assert.Equal(t, map[string]any{"value": map[string]any{"name": "rex"}}, request["rex"])
+	assert.Equal(t, "a pet\ncalled fido\n", text)
+	// the response is inlined from another file, its example is found relative to that file.
+	assert.Equal(t, map[string]any{"value": map[string]any{"name": "tom"}}, response["tom"])
+
+	// external examples are kept by default.
+	bundled, err = BundleBytesWithConfig(spec, config, nil)
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "externalValue: 'examples/fido.json'")
+}
+
+func TestBundleBytesComposed_InlineExternalExamples(t *testing.T) {
+	spec, config := specFixture(t, exampleSpecFiles, "root.yaml")
+
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{InlineExternalExamples: true})
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundled), "externalValue")
+
+	request, text, response := bundledExamples(t, bundled)
+	assert.Equal(t, map[string]any{"name": "fido", "tags": []any{"good"}}, request["fido"].(map[string]any)["value"])
+	assert.Equal(t, "a pet\ncalled fido\n", text)
+	assert.Equal(t, map[string]any{"value": map[string]any{"name": "tom"}}, response["tom"])
+}
+
+func TestBundleBytesWithConfig_InlineExternalExamples_Missing(t *testing.T) {
+	files := maps.Clone(exampleSpecFiles)
+	files["models/pet.yaml"] = `components:
+  schemas:
+    Pet:
+      type: object
+  responses:
+    Pet:
+      description: a pet
+      content:
+        application/json:
+          examples:
+            tom:
+              externalValue: 'payloads/missing.json'`
+	spec, config := specFixture(t, files, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{InlineExternalExamples: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to embed example file 'payloads/missing.json'")
+	assert.Equal(t, 1, len(splitErrors(err)))
+	assert.Contains(t, string(bundled), "externalValue: 'payloads/missing.json'")
+}
+
+func splitErrors(err error) []error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		return joined.Unwrap()
+	}
+	return []error{err}
+}
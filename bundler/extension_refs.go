@@ -10,6 +10,7 @@ import (
 	"go.yaml.in/yaml/v4"
 
 	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
 )
 
 // resolveExtensionRefs resolves $ref pointers within extension fields (x-*).
@@ -46,6 +47,16 @@ func resolveExtensionRefsFromIndex(idx *index.SpecIndex, rolodex *index.Rolodex)
 			continue
 		}
 
+		// references the rolodex is configured to keep are rewritten to point at the same place from the root.
+		if _, refValue := utils.FindKeyNodeTop("$ref", ref.Node.Content); refValue != nil {
+			if _, foundIdx := idx.SearchIndexForReference(refValue.Value); foundIdx != nil {
+				if preserved, ok := rolodex.PreserveReference(foundIdx, refValue.Value); ok {
+					refValue.Value = preserved
+					continue
+				}
+			}
+		}
+
 		// Resolve the reference
 		resolvedContent := resolveExtensionRefContent(ctx, ref, rolodex)
 		if resolvedContent != nil {
@@ -0,0 +1,239 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func unmarshalBundle(t *testing.T, bundled []byte) map[string]any {
+	var doc map[string]any
+	require.NoError(t, yaml.Unmarshal(bundled, &doc))
+	return doc
+}
+
+func TestBundleBytesFast(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'params.yaml#/Limit'
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: 'models/pet.yaml'
+              examples:
+                rex:
+                  value:
+                    $ref: 'payloads/rex.txt'
+        default:
+          $ref: '#/components/responses/Error'
+components:
+  responses:
+    Error:
+      description: an error
+      content:
+        application/json:
+          schema:
+            $ref: 'models/error.yaml'
+  schemas:
+    Id:
+      type: string`,
+		"params.yaml": `Limit:
+  name: limit
+  in: query
+  schema:
+    type: integer`,
+		"models/pet.yaml": `type: object
+properties:
+  id:
+    $ref: '../root.yaml#/components/schemas/Id'
+  owner:
+    $ref: 'owner.yaml'
+    description: the owner of the pet`,
+		"models/owner.yaml": `type: object
+description: an owner
+properties:
+  name:
+    type: string`,
+		"models/error.yaml": `type: object
+properties:
+  message:
+    type: string`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesFast(spec, config)
+	require.NoError(t, err)
+
+	doc := unmarshalBundle(t, bundled)
+	get := doc["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)
+	assert.Equal(t, map[string]any{"name": "limit", "in": "query", "schema": map[string]any{"type": "integer"}},
+		get["parameters"].([]any)[0])
+
+	responses := get["responses"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/responses/Error"}, responses["default"])
+
+	media := responses["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+	pet := media["schema"].(map[string]any)["items"].(map[string]any)
+	properties := pet["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "#/components/schemas/Id"}, properties["id"])
+	assert.Equal(t, "the owner of the pet", properties["owner"].(map[string]any)["description"])
+	assert.Equal(t, "object", properties["owner"].(map[string]any)["type"])
+
+	// example values are payloads, and are left alone.
+	assert.Equal(t, map[string]any{"$ref": "payloads/rex.txt"},
+		media["examples"].(map[string]any)["rex"].(map[string]any)["value"])
+
+	schemas := doc["components"].(map[string]any)["responses"].(map[string]any)["Error"].(map[string]any)
+	assert.Equal(t, "object", schemas["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)["type"])
+}
+
+func TestBundleBytesFast_MatchesBundleBytes(t *testing.T) {
+	specBytes, err := os.ReadFile("test/specs/main.yaml")
+	require.NoError(t, err)
+	config := &datamodel.DocumentConfiguration{
+		BasePath:                "test/specs",
+		ExtractRefsSequentially: true,
+	}
+
+	fast, err := BundleBytesFast(specBytes, config)
+	require.NoError(t, err)
+	bundled, err := BundleBytes(specBytes, config)
+	require.NoError(t, err)
+
+	// the model drops anything it does not know, so the bundles describe the same paths, and the fast bundle
+	// only has local references left.
+	fastPaths := unmarshalBundle(t, fast)["paths"].(map[string]any)
+	bundledPaths := unmarshalBundle(t, bundled)["paths"].(map[string]any)
+	assert.Len(t, fastPaths, len(bundledPaths))
+	for path := range bundledPaths {
+		assert.Contains(t, fastPaths, path)
+	}
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal(fast, &root))
+	for _, ref := range findRefValues(&root) {
+		assert.True(t, strings.HasPrefix(ref, "#/"), "reference %s is not local", ref)
+	}
+}
+
+func findRefValues(node *yaml.Node) []string {
+	var refs []string
+	for i, n := range node.Content {
+		if node.Kind == yaml.MappingNode && i%2 == 0 && n.Value == "$ref" && i+1 < len(node.Content) {
+			refs = append(refs, node.Content[i+1].Value)
+		}
+		refs = append(refs, findRefValues(n)...)
+	}
+	return refs
+}
+
+func TestBundleBytesFast_Circular(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Tree
+  version: 1.0.0
+components:
+  schemas:
+    Tree:
+      $ref: 'tree.yaml'`,
+		"tree.yaml": `type: object
+properties:
+  children:
+    type: array
+    items:
+      $ref: 'tree.yaml'`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesFast(spec, config)
+	require.NoError(t, err)
+
+	tree := unmarshalBundle(t, bundled)["components"].(map[string]any)["schemas"].(map[string]any)["Tree"].(map[string]any)
+	children := tree["properties"].(map[string]any)["children"].(map[string]any)
+	assert.Equal(t, map[string]any{"$ref": "tree.yaml"}, children["items"])
+}
+
+func TestBundleBytesFast_MissingReference(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Missing
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Nope'`,
+		"pet.yaml": `Pet:
+  type: object`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesFast(spec, config)
+	assert.Error(t, err)
+	require.NotNil(t, bundled)
+	pet := unmarshalBundle(t, bundled)["components"].(map[string]any)["schemas"].(map[string]any)["Pet"]
+	assert.Equal(t, map[string]any{"$ref": "pet.yaml#/Nope"}, pet)
+}
+
+func TestBundleBytesFast_ExcludeExtensionRefs(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Extensions
+  version: 1.0.0
+x-shared:
+  $ref: 'shared.yaml'`,
+		"shared.yaml": `name: shared`,
+	}, "root.yaml")
+	config.ExcludeExtensionRefs = true
+
+	bundled, err := BundleBytesFast(spec, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"$ref": "shared.yaml"}, unmarshalBundle(t, bundled)["x-shared"])
+
+	config.ExcludeExtensionRefs = false
+	bundled, err = BundleBytesFast(spec, config)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "shared"}, unmarshalBundle(t, bundled)["x-shared"])
+}
+
+func TestBundleBytesFast_Swagger(t *testing.T) {
+	spec := []byte(`swagger: "2.0"
+info:
+  title: Swagger
+  version: 1.0.0
+paths: {}`)
+
+	fast, err := BundleBytesFast(spec, nil)
+	require.NoError(t, err)
+	bundled, err := BundleBytes(spec, nil)
+	require.NoError(t, err)
+	assert.Equal(t, string(bundled), string(fast))
+}
+
+func TestBundleBytesFast_Invalid(t *testing.T) {
+	_, err := BundleBytesFast([]byte("not: [valid"), nil)
+	assert.Error(t, err)
+
+	_, err = BundleBytesFast(nil, nil)
+	assert.Error(t, err)
+}
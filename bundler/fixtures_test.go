@@ -0,0 +1,28 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/internal/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// specFixture writes the files of a specification into a temporary directory, and returns the root file (a path
+// relative to the directory), with a configuration that resolves file references from the directory of the root file.
+func specFixture(t *testing.T, files map[string]string, root string) ([]byte, *datamodel.DocumentConfiguration) {
+	path := filepath.Join(testutil.WriteFiles(t, files), root)
+	spec, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return spec, &datamodel.DocumentConfiguration{
+		BasePath:                filepath.Dir(path),
+		SpecFilePath:            filepath.Base(path),
+		AllowFileReferences:     true,
+		ExtractRefsSequentially: true,
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// OutputFormat is the format a bundled document is rendered in.
+type OutputFormat int
+
+const (
+	// OutputFormatYAML renders the bundle as YAML (the default).
+	OutputFormatYAML OutputFormat = iota
+
+	// OutputFormatJSON renders the bundle as JSON, with keys in the same order as the YAML.
+	OutputFormatJSON
+)
+
+// BundleOutputConfig controls how a bundled document is rendered.
+type BundleOutputConfig struct {
+	Format OutputFormat // Format is the format of the bundle. Defaults to OutputFormatYAML.
+
+	// Indent is the number of spaces each level is indented by. Defaults to 0, which keeps the indentation of the
+	// bundler (four spaces for OpenAPI 3 YAML, two spaces for Swagger YAML and for JSON).
+	Indent int
+
+	// QuoteKeys when true, renders every mapping key of a YAML bundle in double quotes. JSON keys are always quoted.
+	QuoteKeys bool
+}
+
+// render renders a bundled document (a rendered node, or anything that can be encoded as one) in the configured
+// format. defaultIndent is the indentation of YAML when no indent is configured.
+func (o *BundleOutputConfig) render(rendered any, defaultIndent int) ([]byte, error) {
+	if o.Indent < 0 {
+		return nil, errors.New("output indent cannot be negative")
+	}
+	node, ok := rendered.(*yaml.Node)
+	if !ok || node == nil {
+		node = &yaml.Node{}
+		if err := node.Encode(rendered); err != nil {
+			return nil, err
+		}
+	}
+
+	if o.Format == OutputFormatJSON {
+		indent := o.Indent
+		if indent == 0 {
+			indent = 2
+		}
+		return json.YAMLNodeToJSON(node, strings.Repeat(" ", indent))
+	}
+
+	if o.QuoteKeys {
+		// the rendered nodes can be nodes of the model (extensions, for example), so they are not changed.
+		node = utils.CloneYAMLNode(node)
+		quoteKeys(node)
+	}
+	indent := o.Indent
+	if indent == 0 {
+		indent = defaultIndent
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err := enc.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// quoteKeys sets every scalar mapping key under a node to be rendered in double quotes.
+func quoteKeys(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Kind == yaml.ScalarNode {
+				node.Content[i].Style = yaml.DoubleQuotedStyle
+			}
+		}
+	}
+	for _, n := range node.Content {
+		quoteKeys(n)
+	}
+}
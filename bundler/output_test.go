@@ -0,0 +1,137 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// outputFiles are a specification with a schema in another file.
+var outputFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models.yaml#/components/schemas/Pet'`,
+	"models.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`,
+}
+
+func TestBundleBytesWithConfig_OutputJSON(t *testing.T) {
+	spec, config := specFixture(t, outputFiles, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{
+		Output: &BundleOutputConfig{Format: OutputFormatJSON},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(bundled), "{\n  \"openapi\": \"3.1.0\","))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(bundled, &doc))
+	schema := doc["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, "object", schema["type"])
+
+	bundled, err = BundleBytesWithConfig(spec, config, &BundleInlineConfig{
+		Output: &BundleOutputConfig{Format: OutputFormatJSON, Indent: 4},
+	})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(bundled), "{\n    \"openapi\": \"3.1.0\","))
+}
+
+func TestBundleBytesWithConfig_OutputYAML(t *testing.T) {
+	spec, config := specFixture(t, outputFiles, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{
+		Output: &BundleOutputConfig{Indent: 2, QuoteKeys: true},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "\"info\":\n  \"title\": Pets\n")
+	assert.Contains(t, string(bundled), "\"type\": object")
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	_, typ := findYAMLPath(node.Content[0], "paths", "/pets", "get", "responses", "200", "content",
+		"application/json", "schema", "type")
+	assert.Equal(t, "object", typ.Value)
+
+	// without an output config, the bundle is rendered as before.
+	plain, err := BundleBytesWithConfig(spec, config, nil)
+	require.NoError(t, err)
+	same, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{Output: &BundleOutputConfig{}})
+	require.NoError(t, err)
+	assert.Equal(t, string(plain), string(same))
+
+	_, err = BundleBytesWithConfig(spec, config, &BundleInlineConfig{Output: &BundleOutputConfig{Indent: -1}})
+	assert.Error(t, err)
+}
+
+func TestBundleBytesComposed_OutputJSON(t *testing.T) {
+	spec, config := specFixture(t, outputFiles, "root.yaml")
+
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{
+		Output: &BundleOutputConfig{Format: OutputFormatJSON},
+	})
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(bundled, &doc))
+	schemas := doc["components"].(map[string]any)["schemas"].(map[string]any)
+	assert.Contains(t, schemas, "Pet")
+	schema := doc["paths"].(map[string]any)["/pets"].(map[string]any)["get"].(map[string]any)["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	assert.Equal(t, "#/components/schemas/Pet", schema["$ref"])
+}
+
+func TestBundleBytes_OutputSwagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/definitions/Pet'`,
+		"models.yaml": `definitions:
+  Pet:
+    type: object`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{
+		Output: &BundleOutputConfig{Format: OutputFormatJSON},
+	})
+	require.NoError(t, err)
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(bundled, &doc))
+	assert.Equal(t, "2.0", doc["swagger"])
+
+	bundled, err = BundleBytesComposed(spec, config, &BundleCompositionConfig{
+		Output: &BundleOutputConfig{Indent: 4, QuoteKeys: true},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundled), "\"definitions\":\n    \"Pet\":\n        \"type\": object")
+}
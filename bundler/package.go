@@ -0,0 +1,370 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// PackageConfig configures the layout of the files created by PackageBytes.
+type PackageConfig struct {
+	// RootFile is the name of the file that contains the root of the document. Defaults to the name of the root
+	// file (relative to the other local files), or `openapi.yaml` if the document was not read from a file.
+	RootFile string
+
+	// VendorDir is the directory remote documents are written to. Defaults to `vendor`.
+	VendorDir string
+
+	// VendorPath returns the path (relative to the VendorDir) a remote document is written to. Defaults to
+	// `<host>/<path>`, for example `vendor/example.com/schemas/pet.yaml` for `https://example.com/schemas/pet.yaml`.
+	VendorPath func(location *url.URL) string
+}
+
+// PackageBytes writes an OpenAPI (or Swagger) specification and every file it references into a self-contained,
+// multi-file package, that can be redistributed without access to the network. Unlike bundling, files are not
+// collapsed into one document. Local files keep their layout (relative to each other), and remote documents are
+// vendored as local files (see PackageConfig). Every `$ref` (and discriminator mapping) to another file is rewritten
+// to the relative path of the file in the package.
+//
+// The files are returned as a map of slash separated paths (relative to the root of the package) to their content.
+// Files named `.json` are written as JSON, every other file is written as YAML. References to files that are not
+// documents (and could not be indexed) are not changed.
+func PackageBytes(spec []byte, configuration *datamodel.DocumentConfiguration, config *PackageConfig) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := PackageToWriter(spec, configuration, config, UnbundleWriterFunc(func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// PackageToWriter works the same as PackageBytes, but writes every file to the writer, see DirectoryWriter and
+// NewZipWriter.
+func PackageToWriter(spec []byte, configuration *datamodel.DocumentConfiguration, config *PackageConfig,
+	writer UnbundleWriter,
+) error {
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, configuration)
+	if err != nil {
+		return err
+	}
+	if isSwagger(doc) {
+		v2Doc, e := doc.BuildV2Model()
+		if v2Doc == nil {
+			return errors.Join(ErrInvalidModel, e)
+		}
+		return errors.Join(e, PackageSwaggerDocument(&v2Doc.Model, config, writer))
+	}
+	v3Doc, e := doc.BuildV3Model()
+	if v3Doc == nil {
+		return errors.Join(ErrInvalidModel, e)
+	}
+	return errors.Join(e, PackageDocument(&v3Doc.Model, config, writer))
+}
+
+// PackageDocument writes an OpenAPI 3 document and every file it references into a package, see PackageBytes.
+// The document model is not changed.
+func PackageDocument(model *v3.Document, config *PackageConfig, writer UnbundleWriter) error {
+	if model == nil || model.Rolodex == nil {
+		return errors.New("unable to package, the document has no rolodex")
+	}
+	return packageRolodex(model.Rolodex, config, writer)
+}
+
+// PackageSwaggerDocument writes a Swagger (version 2) document and every file it references into a package, see
+// PackageBytes. The document model is not changed.
+func PackageSwaggerDocument(model *v2.Swagger, config *PackageConfig, writer UnbundleWriter) error {
+	if model == nil || model.GoLow() == nil || model.GoLow().Rolodex == nil {
+		return errors.New("unable to package, the document has no rolodex")
+	}
+	return packageRolodex(model.GoLow().Rolodex, config, writer)
+}
+
+// ZipWriter is an UnbundleWriter that writes every file into a zip archive. Close must be called once every file
+// has been written, to complete the archive.
+type ZipWriter struct {
+	zip *zip.Writer
+}
+
+// NewZipWriter returns a ZipWriter that writes a zip archive to w.
+func NewZipWriter(w io.Writer) *ZipWriter {
+	return &ZipWriter{zip: zip.NewWriter(w)}
+}
+
+// WriteFile adds a file to the archive.
+func (z *ZipWriter) WriteFile(name string, data []byte) error {
+	f, err := z.zip.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// Close completes the archive, it does not close the underlying writer.
+func (z *ZipWriter) Close() error {
+	return z.zip.Close()
+}
+
+// packager lays out every indexed file of a rolodex in a package.
+type packager struct {
+	config *PackageConfig
+	files  map[string]string // the location (path or URL) of every file, to its path in the package.
+	used   map[string]bool
+}
+
+func packageRolodex(rolodex *index.Rolodex, config *PackageConfig, writer UnbundleWriter) error {
+	if writer == nil {
+		return errors.New("unable to package, no writer supplied")
+	}
+	rootIdx := rolodex.GetRootIndex()
+	if rootIdx == nil {
+		return errors.New("unable to package, the document has no index")
+	}
+	if config == nil {
+		config = &PackageConfig{}
+	}
+	p := &packager{config: config, files: make(map[string]string), used: make(map[string]bool)}
+
+	indexes := map[string]*index.SpecIndex{}
+	var locations []string
+	for _, idx := range append([]*index.SpecIndex{rootIdx}, rolodex.GetIndexes()...) {
+		location := idx.GetSpecAbsolutePath()
+		if _, ok := indexes[location]; ok || idx.GetRootNode() == nil {
+			continue
+		}
+		indexes[location] = idx
+		locations = append(locations, location)
+	}
+	rootLocation := rootIdx.GetSpecAbsolutePath()
+	if err := p.layout(rootLocation, locations); err != nil {
+		return err
+	}
+
+	// the root is written first, then every other file in the order of the package.
+	slices.SortStableFunc(locations, func(a, b string) int {
+		switch {
+		case a == rootLocation:
+			return -1
+		case b == rootLocation:
+			return 1
+		}
+		return strings.Compare(p.files[a], p.files[b])
+	})
+	for _, location := range locations {
+		name := p.files[location]
+		node := utils.CloneYAMLNode(indexes[location].GetRootNode())
+		p.rewriteRefs(location, name, node, false)
+
+		var data []byte
+		var err error
+		if strings.EqualFold(path.Ext(name), ".json") {
+			data, err = json.YAMLNodeToJSON(node, "  ")
+		} else {
+			var buf bytes.Buffer
+			enc := yaml.NewEncoder(&buf)
+			enc.SetIndent(2)
+			err = enc.Encode(node)
+			data = buf.Bytes()
+		}
+		if err != nil {
+			return fmt.Errorf("unable to render '%s': %w", name, err)
+		}
+		if err := writer.WriteFile(name, data); err != nil {
+			return fmt.Errorf("unable to write '%s': %w", name, err)
+		}
+	}
+	return nil
+}
+
+// layout gives every file a path in the package. Local files keep their paths, relative to the deepest directory
+// that holds all of them. Remote files are vendored.
+func (p *packager) layout(rootLocation string, locations []string) error {
+	var local, remote []string
+	for _, location := range locations {
+		if location == rootLocation {
+			continue
+		}
+		if isRemoteLocation(location) {
+			remote = append(remote, location)
+		} else {
+			local = append(local, location)
+		}
+	}
+	slices.Sort(local)
+	slices.Sort(remote)
+
+	rootLocal := rootLocation != "" && !isRemoteLocation(rootLocation) && filepath.IsAbs(rootLocation)
+	var base string
+	if rootLocal {
+		base = filepath.Dir(rootLocation)
+	} else if len(local) > 0 {
+		base = filepath.Dir(local[0])
+	}
+	for _, location := range local {
+		for !withinDir(base, location) {
+			parent := filepath.Dir(base)
+			if parent == base {
+				break
+			}
+			base = parent
+		}
+	}
+
+	rootFile := p.config.RootFile
+	if rootFile == "" {
+		rootFile = "openapi.yaml"
+		if rootLocal {
+			rootFile = p.relativeToBase(base, rootLocation)
+		}
+	}
+	p.files[rootLocation] = p.unique(path.Clean(filepath.ToSlash(rootFile)))
+
+	for _, location := range local {
+		p.files[location] = p.unique(p.relativeToBase(base, location))
+	}
+	for _, location := range remote {
+		name, err := p.vendorPath(location)
+		if err != nil {
+			return err
+		}
+		p.files[location] = p.unique(name)
+	}
+	return nil
+}
+
+func (p *packager) relativeToBase(base, location string) string {
+	rel, err := filepath.Rel(base, location)
+	if err != nil {
+		return filepath.Base(location)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// vendorPath returns the path a remote document is written to in the package.
+func (p *packager) vendorPath(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("unable to vendor '%s': %w", location, err)
+	}
+	vendorDir := p.config.VendorDir
+	if vendorDir == "" {
+		vendorDir = "vendor"
+	}
+	if p.config.VendorPath != nil {
+		return path.Join(vendorDir, p.config.VendorPath(u)), nil
+	}
+	segments := []string{unbundleFileName(u.Host)}
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment != "" {
+			segments = append(segments, unbundleFileName(segment))
+		}
+	}
+	if len(segments) == 1 || strings.HasSuffix(u.Path, "/") {
+		segments = append(segments, "index.yaml")
+	}
+	return path.Join(vendorDir, path.Join(segments...)), nil
+}
+
+// unique returns the name, or the name with a number added to it, if another file already has the name.
+func (p *packager) unique(name string) string {
+	candidate := name
+	ext := path.Ext(name)
+	for i := 2; p.used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	p.used[candidate] = true
+	return candidate
+}
+
+// rewriteRefs rewrites every reference to another file in the tree of a file, so it points at the path of the
+// file in the package.
+func (p *packager) rewriteRefs(location, name string, node *yaml.Node, mapping bool) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if (key == "$ref" || mapping) && value.Kind == yaml.ScalarNode {
+				value.Value = p.rewriteRef(location, name, value.Value)
+				continue
+			}
+			p.rewriteRefs(location, name, value, key == "mapping" && isDiscriminator(node))
+		}
+		return
+	}
+	for _, child := range node.Content {
+		p.rewriteRefs(location, name, child, false)
+	}
+}
+
+func (p *packager) rewriteRef(location, name, ref string) string {
+	file, fragment, hasFragment := strings.Cut(ref, "#")
+	if file == "" {
+		return ref
+	}
+	target, ok := p.files[resolveLocation(location, file)]
+	if !ok {
+		return ref
+	}
+	rewritten := relativeFilePath(name, target)
+	if hasFragment {
+		rewritten += "#" + fragment
+	}
+	return rewritten
+}
+
+// resolveLocation resolves a reference to a file, against the location (path or URL) of the file it is written in.
+func resolveLocation(location, ref string) string {
+	if isRemoteLocation(ref) {
+		return ref
+	}
+	if isRemoteLocation(location) {
+		base, err := url.Parse(location)
+		if err != nil {
+			return ref
+		}
+		resolved, err := base.Parse(ref)
+		if err != nil {
+			return ref
+		}
+		return resolved.String()
+	}
+	if filepath.IsAbs(ref) {
+		return filepath.Clean(ref)
+	}
+	return filepath.Join(filepath.Dir(location), filepath.FromSlash(ref))
+}
+
+func isRemoteLocation(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// withinDir returns true if the location is inside the directory (at any depth).
+func withinDir(dir, location string) bool {
+	rel, err := filepath.Rel(dir, location)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,194 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func packageSpec(t *testing.T) (*httptest.Server, []byte, *datamodel.DocumentConfiguration) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/schemas/owner.yaml":
+			_, _ = w.Write([]byte(`Owner:
+  type: object
+  properties:
+    address:
+      $ref: './address.json#/Address'`))
+		case "/schemas/address.json":
+			_, _ = w.Write([]byte(`{"Address": {"type": "object", "properties": {"street": {"type": "string"}}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	spec, config := specFixture(t, map[string]string{
+		"api/root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: './models/pet.yaml#/Pet'
+        default:
+          $ref: '../shared/errors.yaml#/Error'`,
+		"api/models/pet.yaml": `Pet:
+  type: object
+  properties:
+    owner:
+      $ref: '` + server.URL + `/schemas/owner.yaml#/Owner'
+    kind:
+      $ref: '#/Kind'
+Kind:
+  type: string`,
+		"shared/errors.yaml": `Error:
+  description: an error`,
+	}, "api/root.yaml")
+	config.AllowRemoteReferences = true
+	return server, spec, config
+}
+
+func TestPackageBytes(t *testing.T) {
+	server, spec, config := packageSpec(t)
+	u, _ := url.Parse(server.URL)
+	vendor := "vendor/" + strings.ReplaceAll(u.Host, ":", "_") + "/schemas/"
+
+	files, err := PackageBytes(spec, config, nil)
+	require.NoError(t, err)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"api/models/pet.yaml", "api/root.yaml", "shared/errors.yaml",
+		vendor + "address.json", vendor + "owner.yaml"}, names)
+
+	root := string(files["api/root.yaml"])
+	assert.Contains(t, root, "$ref: './models/pet.yaml#/Pet'")
+	assert.Contains(t, root, "$ref: '../shared/errors.yaml#/Error'")
+
+	pet := string(files["api/models/pet.yaml"])
+	assert.Contains(t, pet, "$ref: '../../"+vendor+"owner.yaml#/Owner'")
+	assert.Contains(t, pet, "$ref: '#/Kind'")
+	assert.NotContains(t, pet, server.URL)
+
+	assert.Contains(t, string(files[vendor+"owner.yaml"]), "$ref: './address.json#/Address'")
+	assert.True(t, strings.HasPrefix(string(files[vendor+"address.json"]), "{\n  \"Address\": {"))
+}
+
+func TestPackageToWriter_Directory(t *testing.T) {
+	server, spec, config := packageSpec(t)
+
+	dir := t.TempDir()
+	require.NoError(t, PackageToWriter(spec, config, nil, DirectoryWriter(dir)))
+
+	// the package bundles without the network.
+	server.Close()
+	packaged, err := os.ReadFile(filepath.Join(dir, "api", "root.yaml"))
+	require.NoError(t, err)
+	rebundled, err := BundleBytes(packaged, &datamodel.DocumentConfiguration{
+		BasePath:                filepath.Join(dir, "api"),
+		SpecFilePath:            "root.yaml",
+		AllowFileReferences:     true,
+		ExtractRefsSequentially: true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(rebundled), "street")
+	assert.Contains(t, string(rebundled), "description: an error")
+	assert.NotContains(t, string(rebundled), "$ref")
+}
+
+func TestPackageToWriter_Zip(t *testing.T) {
+	_, spec, config := packageSpec(t)
+
+	var buf bytes.Buffer
+	writer := NewZipWriter(&buf)
+	require.NoError(t, PackageToWriter(spec, config, &PackageConfig{
+		RootFile:   "openapi.yaml",
+		VendorDir:  "deps",
+		VendorPath: func(location *url.URL) string { return filepath.Base(location.Path) },
+	}, writer))
+	require.NoError(t, writer.Close())
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range archive.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, _ := io.ReadAll(r)
+		_ = r.Close()
+		files[f.Name] = string(data)
+	}
+	assert.Len(t, files, 5)
+	assert.Equal(t, "openapi.yaml", archive.File[0].Name)
+	assert.Contains(t, files["openapi.yaml"], "$ref: './api/models/pet.yaml#/Pet'")
+	assert.Contains(t, files["openapi.yaml"], "$ref: './shared/errors.yaml#/Error'")
+	assert.Contains(t, files["api/models/pet.yaml"], "$ref: '../../deps/owner.yaml#/Owner'")
+	assert.Contains(t, files["deps/owner.yaml"], "$ref: './address.json#/Address'")
+	assert.Contains(t, files, "deps/address.json")
+}
+
+func TestPackageBytes_Swagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"spec/swagger.yaml": `swagger: '2.0'
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    $ref: 'models/pet.yaml'`,
+		"spec/models/pet.yaml": `type: object
+properties:
+  kind:
+    type: string`,
+	}, "spec/swagger.yaml")
+	files, err := PackageBytes(spec, config, &PackageConfig{RootFile: "swagger.yaml"})
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+	assert.Contains(t, string(files["swagger.yaml"]), "$ref: './models/pet.yaml'")
+	assert.Contains(t, files, "models/pet.yaml")
+}
+
+func TestPackage_Errors(t *testing.T) {
+	_, spec, config := packageSpec(t)
+	assert.Error(t, PackageToWriter(spec, config, nil, nil))
+	assert.Error(t, PackageDocument(nil, nil, DirectoryWriter(t.TempDir())))
+	assert.Error(t, PackageDocument(&v3.Document{}, nil, DirectoryWriter(t.TempDir())))
+	assert.Error(t, PackageSwaggerDocument(nil, nil, DirectoryWriter(t.TempDir())))
+
+	_, err := PackageBytes([]byte("not: [an openapi document"), nil, nil)
+	assert.Error(t, err)
+
+	err = PackageToWriter(spec, config, nil, UnbundleWriterFunc(func(string, []byte) error {
+		return assert.AnError
+	}))
+	assert.ErrorIs(t, err, assert.AnError)
+}
@@ -0,0 +1,85 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// referencePreserver returns an index.ReferencePreserver that keeps every external reference matching one of the
+// patterns. Patterns are path.Match patterns, matched against the reference as it is written, the full location of
+// the referenced file and its location relative to the root document (with and without the fragment), and the name
+// of the file. For example 'https://schemas.example.com/*', 'shared/*' or 'shared-*.yaml'.
+//
+// References local to the root document are never matched. Kept references are rewritten so they still point at
+// the same place from the root document; URLs stay absolute and files become relative to the root document.
+func referencePreserver(rolodex *index.Rolodex, patterns []string) index.ReferencePreserver {
+	if rolodex == nil || len(patterns) == 0 {
+		return nil
+	}
+	return func(idx *index.SpecIndex, ref string) (string, bool) {
+		if idx == nil || ref == "" {
+			return "", false
+		}
+		rootPath := rolodex.GetRootIndex().GetSpecAbsolutePath()
+		location := idx.GetSpecAbsolutePath()
+		if location == rootPath {
+			return "", false
+		}
+		_, fragment, _ := strings.Cut(ref, "#")
+		locations := []string{location}
+		if !isURL(location) && !isURL(rootPath) {
+			if rel, err := filepath.Rel(filepath.Dir(rootPath), location); err == nil {
+				locations = append(locations, rel)
+			}
+		}
+		if !matchesReference(patterns, ref, locations, fragment) {
+			return "", false
+		}
+		return rootRelativeReference(rootPath, location, fragment), true
+	}
+}
+
+// rootRelativeReference returns a reference to a location (and fragment) that resolves from the root document.
+// URLs stay absolute, and files become relative to the root document.
+func rootRelativeReference(rootPath, location, fragment string) string {
+	if !isURL(location) && !isURL(rootPath) {
+		if rel, err := filepath.Rel(filepath.Dir(rootPath), location); err == nil {
+			location = rel
+		}
+	}
+	location = filepath.ToSlash(location)
+	if fragment != "" {
+		return location + "#" + fragment
+	}
+	return location
+}
+
+func matchesReference(patterns []string, ref string, locations []string, fragment string) bool {
+	candidates := []string{ref}
+	for _, location := range locations {
+		slashed := filepath.ToSlash(location)
+		candidates = append(candidates, slashed, path.Base(slashed))
+		if fragment != "" {
+			candidates = append(candidates, slashed+"#"+fragment)
+		}
+	}
+	for _, pattern := range patterns {
+		for _, candidate := range candidates {
+			if ok, _ := path.Match(pattern, candidate); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isURL(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
@@ -0,0 +1,133 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestBundleBytesWithConfig_PreserveExternalRefs(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `openapi: 3.1.0
+info:
+  title: Orders
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      parameters:
+        - $ref: 'shared/params.yaml#/components/parameters/Limit'
+      responses:
+        '200':
+          description: orders
+          content:
+            application/json:
+              schema:
+                $ref: 'models/order.yaml#/components/schemas/Order'
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'shared/pet.yaml#/components/schemas/Pet'`,
+		"models/order.yaml": `components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        pet:
+          $ref: '../shared/pet.yaml#/components/schemas/Pet'`,
+		"shared/pet.yaml": `components:
+  schemas:
+    Pet:
+      type: object`,
+		"shared/params.yaml": `components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{
+		PreserveExternalRefs: []string{"shared/*"},
+	})
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	_, params := findYAMLPath(root, "paths", "/orders", "get", "parameters")
+	_, ref := findYAMLPath(params.Content[0], "$ref")
+	assert.Equal(t, "shared/params.yaml#/components/parameters/Limit", ref.Value)
+	_, ref = findYAMLPath(root, "paths", "/pets", "get", "responses", "200", "content", "application/json",
+		"schema", "$ref")
+	assert.Equal(t, "shared/pet.yaml#/components/schemas/Pet", ref.Value)
+
+	// the order is inlined, the reference it holds is kept and rewritten relative to the root document.
+	_, order := findYAMLPath(root, "paths", "/orders", "get", "responses", "200", "content", "application/json",
+		"schema")
+	_, typ := findYAMLPath(order, "type")
+	assert.Equal(t, "object", typ.Value)
+	_, ref = findYAMLPath(order, "properties", "pet", "$ref")
+	assert.Equal(t, "shared/pet.yaml#/components/schemas/Pet", ref.Value)
+
+	// without patterns, everything is inlined again.
+	bundled, err = BundleBytesWithConfig(spec, config, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundled), "$ref")
+}
+
+func TestBundleBytesWithConfig_PreserveExternalRefs_Swagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/definitions/Pets'`,
+		"models.yaml": `definitions:
+  Pets:
+    type: array
+    items:
+      $ref: 'canonical.yaml#/definitions/Pet'`,
+		"canonical.yaml": `definitions:
+  Pet:
+    type: object`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{PreserveExternalRefs: []string{"canonical.yaml"}})
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	_, ref := findYAMLPath(node.Content[0], "paths", "/pets", "get", "responses", "200", "schema", "items", "$ref")
+	assert.Equal(t, "canonical.yaml#/definitions/Pet", ref.Value)
+}
+
+func TestMatchesReference(t *testing.T) {
+	assert.True(t, matchesReference([]string{"https://schemas.example.com/*"},
+		"https://schemas.example.com/pet.yaml#/Pet", []string{"https://schemas.example.com/pet.yaml"}, "/Pet"))
+	assert.True(t, matchesReference([]string{"pet.yaml"}, "../pet.yaml", []string{"/specs/pet.yaml"}, ""))
+	assert.True(t, matchesReference([]string{"shared/*"}, "../shared/pet.yaml#/Pet",
+		[]string{"/specs/shared/pet.yaml", "shared/pet.yaml"}, "/Pet"))
+	assert.True(t, matchesReference([]string{"shared/pet.yaml#/components/schemas/*"}, "../shared/pet.yaml#/Pet",
+		[]string{"/specs/shared/pet.yaml", "shared/pet.yaml"}, "/components/schemas/Pet"))
+	assert.False(t, matchesReference([]string{"order.yaml"}, "../pet.yaml", []string{"/specs/pet.yaml"}, ""))
+}
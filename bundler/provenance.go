@@ -0,0 +1,72 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ProvenanceExtension is the extension added to inlined and composed components when provenance is enabled. The
+// value is the file (relative to the root document, or a URL) and JSON pointer the component came from, for example
+// 'models/pet.yaml#/components/schemas/Pet'.
+const ProvenanceExtension = "x-bundled-from"
+
+// provenanceSource returns the value of the provenance extension for a full definition.
+func provenanceSource(rootPath, definition string) string {
+	file, fragment, _ := strings.Cut(definition, "#")
+	if file == "" {
+		file = rootPath
+	}
+	return rootRelativeReference(rootPath, file, fragment)
+}
+
+// addProvenance adds the provenance extension to a mapping node, unless it already has one (a component that was
+// inlined into another component keeps the source it came from).
+func addProvenance(node *yaml.Node, source string) {
+	if node == nil || node.Kind != yaml.MappingNode || mappingValue(node, ProvenanceExtension) != nil {
+		return
+	}
+	if isRef, _, _ := utils.IsNodeRefValue(node); isRef {
+		return
+	}
+	node.Content = append(node.Content, utils.CreateStringNode(ProvenanceExtension), utils.CreateStringNode(source))
+}
+
+// withProvenance returns a copy of a mapping node with the provenance extension added, the node itself is not
+// changed. Anything other than a mapping node (or an empty source) is returned as is.
+func withProvenance(node *yaml.Node, source string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode || source == "" {
+		return node
+	}
+	annotated := *node
+	annotated.Content = slices.Clone(node.Content)
+	addProvenance(&annotated, source)
+	return &annotated
+}
+
+// provenanceAnnotator returns an index.ReferenceAnnotator that adds the provenance extension to every reference
+// inlined from a file other than the root document.
+func provenanceAnnotator(rolodex *index.Rolodex) index.ReferenceAnnotator {
+	return func(idx *index.SpecIndex, ref string, node *yaml.Node) {
+		if idx == nil || rolodex.GetRootIndex() == nil {
+			return
+		}
+		rootPath := rolodex.GetRootIndex().GetSpecAbsolutePath()
+		location := idx.GetSpecAbsolutePath()
+		if location == rootPath {
+			return
+		}
+		definition := location
+		if _, fragment, ok := strings.Cut(ref, "#"); ok && fragment != "" {
+			definition += "#" + fragment
+		}
+		addProvenance(node, provenanceSource(rootPath, definition))
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package bundler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// provenanceFiles are a specification with components in files in two directories.
+var provenanceFiles = map[string]string{
+	"root.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: 'shared/params.yaml#/components/parameters/Limit'
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: 'models/pets.yaml#/components/schemas/Pets'`,
+	"models/pets.yaml": `components:
+  schemas:
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'
+    Pet:
+      type: object`,
+	"shared/params.yaml": `components:
+  parameters:
+    Limit:
+      name: limit
+      in: query
+      schema:
+        type: integer`,
+}
+
+func provenanceOf(t *testing.T, root *yaml.Node, keys ...string) string {
+	_, n := findYAMLPath(root, append(keys, ProvenanceExtension)...)
+	if n == nil {
+		return ""
+	}
+	return n.Value
+}
+
+func TestBundleBytesWithConfig_Provenance(t *testing.T) {
+	spec, config := specFixture(t, provenanceFiles, "root.yaml")
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{Provenance: true})
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	schema := []string{"paths", "/pets", "get", "responses", "200", "content", "application/json", "schema"}
+	assert.Equal(t, "models/pets.yaml#/components/schemas/Pets", provenanceOf(t, root, schema...))
+	assert.Equal(t, "models/pets.yaml#/components/schemas/Pet", provenanceOf(t, root, append(schema, "items")...))
+
+	_, params := findYAMLPath(root, "paths", "/pets", "get", "parameters")
+	require.NotNil(t, params)
+	assert.Equal(t, "shared/params.yaml#/components/parameters/Limit", provenanceOf(t, params.Content[0]))
+
+	// nothing is annotated by default.
+	bundled, err = BundleBytesWithConfig(spec, config, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, string(bundled), ProvenanceExtension)
+}
+
+func TestBundleBytesComposed_Provenance(t *testing.T) {
+	spec, config := specFixture(t, provenanceFiles, "root.yaml")
+	bundled, err := BundleBytesComposed(spec, config, &BundleCompositionConfig{Provenance: true})
+	require.NoError(t, err)
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	root := node.Content[0]
+
+	assert.Equal(t, "models/pets.yaml#/components/schemas/Pets", provenanceOf(t, root, "components", "schemas", "Pets"))
+	assert.Equal(t, "models/pets.yaml#/components/schemas/Pet", provenanceOf(t, root, "components", "schemas", "Pet"))
+	assert.Equal(t, "shared/params.yaml#/components/parameters/Limit",
+		provenanceOf(t, root, "components", "parameters", "Limit"))
+
+	// the references are not annotated.
+	_, ref := findYAMLPath(root, "paths", "/pets", "get", "responses", "200", "content", "application/json",
+		"schema")
+	require.NotNil(t, ref)
+	assert.Empty(t, provenanceOf(t, ref))
+}
+
+func TestBundleBytesWithConfig_Provenance_Swagger(t *testing.T) {
+	spec, config := specFixture(t, map[string]string{
+		"root.yaml": `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          schema:
+            $ref: 'models.yaml#/definitions/Pet'`,
+		"models.yaml": `definitions:
+  Pet:
+    type: object`,
+	}, "root.yaml")
+
+	bundled, err := BundleBytesWithConfig(spec, config, &BundleInlineConfig{Provenance: true})
+	require.NoError(t, err)
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	assert.Equal(t, "models.yaml#/definitions/Pet",
+		provenanceOf(t, node.Content[0], "paths", "/pets", "get", "responses", "200", "schema"))
+
+	bundled, err = BundleBytesComposed(spec, config, &BundleCompositionConfig{Provenance: true})
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(bundled, &node))
+	assert.Equal(t, "models.yaml#/definitions/Pet", provenanceOf(t, node.Content[0], "definitions", "Pet"))
+}
+
+func TestWithProvenance(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	annotated := withProvenance(node, "pet.yaml")
+	assert.Empty(t, node.Content)
+	assert.Len(t, annotated.Content, 2)
+
+	// existing annotations are kept.
+	addProvenance(annotated, "other.yaml")
+	assert.Equal(t, "pet.yaml", mappingValue(annotated, ProvenanceExtension).Value)
+
+	scalar := &yaml.Node{Kind: yaml.ScalarNode, Value: "pet"}
+	assert.Same(t, scalar, withProvenance(scalar, "pet.yaml"))
+	assert.Same(t, node, withProvenance(node, ""))
+}
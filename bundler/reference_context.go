@@ -0,0 +1,107 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// referenceContexts knows which references point to a whole path item or callback, from where the reference is
+// written. A path item or callback file has nothing in it that reliably tells it apart from other components.
+type referenceContexts map[*yaml.Node]string
+
+// newReferenceContexts finds every reference written where a path item (the value of a path or webhook, or of a
+// callback expression) or a callback (the value of a callback of an operation) is expected.
+func newReferenceContexts(indexes ...*index.SpecIndex) referenceContexts {
+	contexts := make(referenceContexts)
+	for _, idx := range indexes {
+		if idx == nil || idx.GetRootNode() == nil {
+			continue
+		}
+		root := idx.GetRootNode()
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		contexts.walk(root, nil)
+	}
+	return contexts
+}
+
+func (c referenceContexts) walk(node *yaml.Node, keys []string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			c.walk(n, keys)
+		}
+	case yaml.MappingNode:
+		if isRef, _, _ := utils.IsNodeRefValue(node); isRef {
+			if kind := referenceContext(keys); kind != "" {
+				c[node] = kind
+			}
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if strings.HasPrefix(node.Content[i].Value, "x-") {
+				continue
+			}
+			c.walk(node.Content[i+1], append(keys, node.Content[i].Value))
+		}
+	}
+}
+
+// referenceContext returns the components section of what is expected at the location of the keys, if it is a path
+// item or a callback.
+func referenceContext(keys []string) string {
+	n := len(keys)
+	if n == 2 && (keys[0] == v3low.PathsLabel || keys[0] == v3low.WebhooksLabel) {
+		return v3low.PathItemsLabel
+	}
+	if n == 3 && keys[0] == v3low.ComponentsLabel {
+		switch keys[1] {
+		case v3low.PathItemsLabel, v3low.CallbacksLabel:
+			return keys[1]
+		}
+	}
+	// callbacks of an operation, and the path items of their expressions.
+	isOperation := func(i int) bool {
+		return i >= 0 && slices.Contains(filterOperationLabels, keys[i])
+	}
+	isCallbacks := func(i int) bool {
+		return i >= 0 && keys[i] == v3low.CallbacksLabel &&
+			(isOperation(i-1) || (i == 1 && keys[0] == v3low.ComponentsLabel))
+	}
+	switch {
+	case n >= 2 && isCallbacks(n-2):
+		return v3low.CallbacksLabel
+	case n >= 3 && isCallbacks(n-3):
+		return v3low.PathItemsLabel
+	}
+	return ""
+}
+
+// supportsPathItems returns true if the document can hold path items in its components (OpenAPI 3.1+).
+func supportsPathItems(model *v3.Document) bool {
+	return model != nil && !strings.HasPrefix(model.Version, "3.0")
+}
+
+// componentName returns a valid component name for the last segment of a JSON pointer, for example '/pets/{id}'
+// becomes 'pets__id' (component names may only contain letters, digits, '.', '-' and '_').
+func componentName(segment string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+			return r
+		}
+		return '_'
+	}, decodeJSONPointerSegment(segment))
+	return strings.Trim(name, "_")
+}
@@ -0,0 +1,308 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// UnbundleWriter receives every file created by Unbundle, the name is a slash separated path, relative to the
+// root of the layout.
+type UnbundleWriter interface {
+	WriteFile(name string, data []byte) error
+}
+
+// UnbundleWriterFunc is a function that meets the UnbundleWriter interface.
+type UnbundleWriterFunc func(name string, data []byte) error
+
+// WriteFile calls the function.
+func (f UnbundleWriterFunc) WriteFile(name string, data []byte) error {
+	return f(name, data)
+}
+
+// DirectoryWriter returns an UnbundleWriter that writes every file into a directory on disk, creating any
+// directories that do not exist.
+func DirectoryWriter(dir string) UnbundleWriter {
+	return UnbundleWriterFunc(func(name string, data []byte) error {
+		location := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(location), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(location, data, 0o644)
+	})
+}
+
+// UnbundleConfig configures the layout of the files created by Unbundle.
+type UnbundleConfig struct {
+	// RootFile is the name of the file that contains the root of the document. Defaults to `openapi.yaml`.
+	RootFile string
+
+	// ComponentPath returns the path of the file a component is written to. The section is the name of the
+	// components section (for example `schemas`) and the name is the name of the component. Defaults to
+	// `components/<section>/<name>.yaml`.
+	ComponentPath func(section, name string) string
+
+	// PathItemPath returns the path of the file a path item is written to. Defaults to `paths/<path>.yaml`, where
+	// the path has the slashes replaced, for example `paths/pets_{id}.yaml` for `/pets/{id}`.
+	PathItemPath func(path string) string
+
+	// InlinePaths keeps all path items in the root file, instead of writing each one to its own file.
+	InlinePaths bool
+
+	// InlineSections are the components sections (for example `examples`) that are kept in the root file. Security
+	// schemes are always kept in the root file, as security requirements refer to them by name.
+	InlineSections []string
+}
+
+// UnbundleBytes is the inverse of bundling, it takes a single (bundled) OpenAPI document, and splits it into
+// multiple files. Every component and every path item is written to its own file, and the root file references
+// each of them. Every local reference (`#/components/...`) is rewritten to a relative file reference, so the
+// files can be loaded (or bundled again) from the root file. Discriminator mappings are rewritten the same way.
+//
+// The files are returned as a map of slash separated paths (relative to the root of the layout) to their
+// content. All files are written as YAML. References to external files are not changed, the document is expected
+// to be bundled already.
+func UnbundleBytes(spec []byte, config *UnbundleConfig) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := UnbundleToWriter(spec, config, UnbundleWriterFunc(func(name string, data []byte) error {
+		files[name] = data
+		return nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// UnbundleToWriter works the same as UnbundleBytes, but writes every file to the writer, see DirectoryWriter.
+func UnbundleToWriter(spec []byte, config *UnbundleConfig, writer UnbundleWriter) error {
+	if writer == nil {
+		return errors.New("unable to unbundle, no writer supplied")
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return fmt.Errorf("unable to unbundle, the document can't be parsed: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return errors.New("unable to unbundle, the document is empty")
+	}
+	u := newUnbundler(config)
+	files, err := u.split(doc.Content[0])
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(f.node); err != nil {
+			return fmt.Errorf("unable to render '%s': %w", f.name, err)
+		}
+		if err := writer.WriteFile(f.name, buf.Bytes()); err != nil {
+			return fmt.Errorf("unable to write '%s': %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+type unbundledFile struct {
+	name string
+	node *yaml.Node
+}
+
+type unbundler struct {
+	rootFile      string
+	componentPath func(section, name string) string
+	pathItemPath  func(path string) string
+	inlinePaths   bool
+	inline        map[string]bool
+
+	// targets maps a local pointer (#/components/schemas/Pet) to the file it is written to.
+	targets map[string]string
+	used    map[string]bool
+}
+
+func newUnbundler(config *UnbundleConfig) *unbundler {
+	if config == nil {
+		config = &UnbundleConfig{}
+	}
+	u := &unbundler{
+		rootFile:      config.RootFile,
+		componentPath: config.ComponentPath,
+		pathItemPath:  config.PathItemPath,
+		inlinePaths:   config.InlinePaths,
+		inline:        make(map[string]bool),
+		targets:       make(map[string]string),
+		used:          make(map[string]bool),
+	}
+	if u.rootFile == "" {
+		u.rootFile = "openapi.yaml"
+	}
+	if u.componentPath == nil {
+		u.componentPath = func(section, name string) string {
+			return "components/" + section + "/" + unbundleFileName(name) + ".yaml"
+		}
+	}
+	if u.pathItemPath == nil {
+		u.pathItemPath = func(p string) string {
+			return "paths/" + unbundleFileName(strings.TrimPrefix(p, "/")) + ".yaml"
+		}
+	}
+	u.inline["securitySchemes"] = true
+	for _, s := range config.InlineSections {
+		u.inline[s] = true
+	}
+	u.used[u.rootFile] = true
+	return u
+}
+
+// split assigns every component and path item a file, then rewrites the references in each file.
+func (u *unbundler) split(root *yaml.Node) ([]*unbundledFile, error) {
+	var files []*unbundledFile
+
+	_, components := utils.FindKeyNodeTop("components", root.Content)
+	if components != nil && components.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			section, entries := components.Content[i].Value, components.Content[i+1]
+			if u.inline[section] || entries.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(entries.Content); j += 2 {
+				name := entries.Content[j].Value
+				file, err := u.assign(u.componentPath(section, name))
+				if err != nil {
+					return nil, err
+				}
+				u.targets["#/components/"+encodeJSONPointerSegment(section)+"/"+encodeJSONPointerSegment(name)] = file
+				files = append(files, &unbundledFile{name: file, node: entries.Content[j+1]})
+				entries.Content[j+1] = utils.CreateRefNode(relativeFilePath(u.rootFile, file))
+			}
+		}
+	}
+
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !u.inlinePaths && paths != nil && paths.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(paths.Content); i += 2 {
+			if strings.HasPrefix(paths.Content[i].Value, "x-") || paths.Content[i+1].Kind != yaml.MappingNode {
+				continue
+			}
+			file, err := u.assign(u.pathItemPath(paths.Content[i].Value))
+			if err != nil {
+				return nil, err
+			}
+			u.targets["#/paths/"+encodeJSONPointerSegment(paths.Content[i].Value)] = file
+			files = append(files, &unbundledFile{name: file, node: paths.Content[i+1]})
+			paths.Content[i+1] = utils.CreateRefNode(relativeFilePath(u.rootFile, file))
+		}
+	}
+
+	files = append([]*unbundledFile{{name: u.rootFile, node: root}}, files...)
+	for _, f := range files {
+		u.rewriteRefs(f.name, f.node, false)
+	}
+	return files, nil
+}
+
+// assign reserves a file name, adding a number to it if it is already used.
+func (u *unbundler) assign(name string) (string, error) {
+	name = path.Clean(filepath.ToSlash(name))
+	if name == "." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+		return "", fmt.Errorf("unable to unbundle, '%s' is not a relative path inside the layout", name)
+	}
+	candidate := name
+	ext := path.Ext(name)
+	for n := 2; u.used[candidate]; n++ {
+		candidate = strings.TrimSuffix(name, ext) + strconv.Itoa(n) + ext
+	}
+	u.used[candidate] = true
+	return candidate, nil
+}
+
+// rewriteRefs rewrites every local reference in the tree of a file, so it points at the file that now holds
+// the referenced component, or at the root file if it was not split out.
+func (u *unbundler) rewriteRefs(file string, node *yaml.Node, mapping bool) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if (key == "$ref" || mapping) && value.Kind == yaml.ScalarNode {
+				value.Value = u.rewriteRef(file, value.Value)
+				continue
+			}
+			u.rewriteRefs(file, value, key == "mapping" && isDiscriminator(node))
+		}
+		return
+	}
+	for _, child := range node.Content {
+		u.rewriteRefs(file, child, false)
+	}
+}
+
+func (u *unbundler) rewriteRef(file, ref string) string {
+	if !strings.HasPrefix(ref, "#/") {
+		return ref
+	}
+	for pointer := ref; ; {
+		if target, ok := u.targets[pointer]; ok {
+			rest := strings.TrimPrefix(ref, pointer)
+			if rest == "" {
+				return relativeFilePath(file, target)
+			}
+			return relativeFilePath(file, target) + "#" + rest
+		}
+		i := strings.LastIndex(pointer, "/")
+		if i <= 1 {
+			break
+		}
+		pointer = pointer[:i]
+	}
+	if file == u.rootFile {
+		return ref
+	}
+	return relativeFilePath(file, u.rootFile) + ref
+}
+
+// relativeFilePath returns the path of the target file, relative to the directory of the file referencing it. Both
+// paths are slash separated.
+func relativeFilePath(from, to string) string {
+	rel, err := filepath.Rel(filepath.FromSlash(path.Dir(from)), filepath.FromSlash(to))
+	if err != nil {
+		return to
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+	return rel
+}
+
+// isDiscriminator returns true if the mapping node looks like a discriminator object.
+func isDiscriminator(node *yaml.Node) bool {
+	_, propertyName := utils.FindKeyNodeTop("propertyName", node.Content)
+	return propertyName != nil
+}
+
+// unbundleFileName replaces the characters in a name that can't be used in a file name.
+func unbundleFileName(name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", ":", "_", "*", "_", "?", "_", "\"", "_",
+		"<", "_", ">", "_", "|", "_", "~", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		return "_" + name
+	}
+	return name
+}
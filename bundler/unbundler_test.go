@@ -0,0 +1,133 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package bundler
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+var unbundleSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{id}:
+    get:
+      parameters:
+        - $ref: '#/components/parameters/Id'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  parameters:
+    Id:
+      name: id
+      in: path
+      required: true
+      schema:
+        type: string
+  schemas:
+    Pet:
+      type: object
+      discriminator:
+        propertyName: kind
+        mapping:
+          cat: '#/components/schemas/Cat'
+      properties:
+        kind:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Owner/properties/name'
+    Cat:
+      allOf:
+        - $ref: '#/components/schemas/Pet'
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+  securitySchemes:
+    key:
+      type: apiKey
+      in: header
+      name: X-Key`
+
+func TestUnbundleBytes(t *testing.T) {
+	files, err := UnbundleBytes([]byte(unbundleSpec), nil)
+	require.NoError(t, err)
+	assert.Len(t, files, 6)
+
+	root := string(files["openapi.yaml"])
+	assert.Contains(t, root, "$ref: './paths/pets_{id}.yaml'")
+	assert.Contains(t, root, "$ref: './components/schemas/Pet.yaml'")
+	assert.Contains(t, root, "X-Key")
+
+	assert.Contains(t, string(files["paths/pets_{id}.yaml"]), "$ref: '../components/parameters/Id.yaml'")
+	pet := string(files["components/schemas/Pet.yaml"])
+	assert.Contains(t, pet, "cat: './Cat.yaml'")
+	assert.Contains(t, pet, "$ref: './Owner.yaml#/properties/name'")
+	assert.Contains(t, string(files["components/schemas/Cat.yaml"]), "$ref: './Pet.yaml'")
+}
+
+func TestUnbundleToWriter_Directory(t *testing.T) {
+	tmp := t.TempDir()
+	require.NoError(t, UnbundleToWriter([]byte(unbundleSpec), nil, DirectoryWriter(tmp)))
+
+	spec, err := os.ReadFile(filepath.Join(tmp, "openapi.yaml"))
+	require.NoError(t, err)
+
+	doc, err := libopenapi.NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{
+		BasePath:            tmp,
+		AllowFileReferences: true,
+	})
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	op := model.Model.Paths.PathItems.GetOrZero("/pets/{id}").Get
+	assert.Equal(t, "id", op.Parameters[0].Name)
+	schema := op.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema()
+	require.NotNil(t, schema)
+	assert.NotNil(t, schema.Properties.GetOrZero("kind"))
+	assert.Equal(t, "string", schema.Properties.GetOrZero("owner").Schema().Type[0])
+	assert.Equal(t, "apiKey", model.Model.Components.SecuritySchemes.GetOrZero("key").Type)
+}
+
+func TestUnbundleBytes_Layout(t *testing.T) {
+	files, err := UnbundleBytes([]byte(unbundleSpec), &UnbundleConfig{
+		RootFile:       "api/root.yaml",
+		InlinePaths:    true,
+		InlineSections: []string{"parameters"},
+		ComponentPath: func(section, name string) string {
+			return "api/" + section + ".yaml"
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, files, "api/schemas.yaml")
+	assert.Contains(t, files, "api/schemas2.yaml")
+	assert.Contains(t, files, "api/schemas3.yaml")
+	assert.NotContains(t, files, "api/parameters.yaml")
+	assert.Contains(t, string(files["api/root.yaml"]), "in: path")
+	assert.Contains(t, string(files["api/root.yaml"]), "/pets/{id}:")
+
+	_, err = UnbundleBytes([]byte(unbundleSpec), &UnbundleConfig{
+		ComponentPath: func(section, name string) string { return "../" + name },
+	})
+	assert.Error(t, err)
+	_, err = UnbundleBytes([]byte(""), nil)
+	assert.Error(t, err)
+}
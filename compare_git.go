@@ -0,0 +1,167 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// CompareGitRevisions compares a specification at two revisions (for example `main` and a feature branch) of a git
+// repository. The spec path is the location of the root specification, relative to the root of the repository.
+//
+// The YAML and JSON files of each revision are extracted (using `git archive`) into a temporary directory, which
+// is used as the local file system of the rolodex, so specifications split into many files (including references
+// to sibling directories) are compared in full. The temporary directories are removed once the comparison is done.
+// The git executable must be installed.
+//
+// The resolved content of referenced schemas is compared (see what_changed.ComparisonConfiguration), so changes
+// made to the schemas of other files are reported, rather than only changes made to the references themselves.
+func CompareGitRevisions(repoPath, originalRevision, updatedRevision, specPath string) (*model.DocumentChanges, error) {
+	return CompareGitRevisionsWithConfiguration(repoPath, originalRevision, updatedRevision, specPath, nil,
+		&what_changed.ComparisonConfiguration{CompareResolvedSchemas: true})
+}
+
+// CompareGitRevisionsWithConfiguration is the same as CompareGitRevisions, except the documents are created using
+// the supplied document configuration, and compared using the supplied comparison configuration. The BasePath,
+// SpecFilePath and LocalFS properties of the document configuration are replaced for each revision. Either
+// configuration can be nil, the comparison configuration is used as it is, so CompareResolvedSchemas should be
+// enabled to report changes made to the schemas of other files.
+func CompareGitRevisionsWithConfiguration(repoPath, originalRevision, updatedRevision, specPath string,
+	documentConfiguration *datamodel.DocumentConfiguration,
+	comparisonConfiguration *what_changed.ComparisonConfiguration,
+) (*model.DocumentChanges, error) {
+	original, err := openGitRevision(repoPath, originalRevision, specPath, documentConfiguration)
+	if original != nil {
+		defer os.RemoveAll(original.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	updated, err := openGitRevision(repoPath, updatedRevision, specPath, documentConfiguration)
+	if updated != nil {
+		defer os.RemoveAll(updated.dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return CompareDocumentsWithConfiguration(original.document, updated.document, comparisonConfiguration)
+}
+
+// gitRevision is a document created from the files of a revision, extracted into a temporary directory.
+type gitRevision struct {
+	dir      string
+	document Document
+}
+
+// openGitRevision extracts the files of a revision of a repository, and creates a document from the specification.
+func openGitRevision(repoPath, revision, specPath string,
+	configuration *datamodel.DocumentConfiguration,
+) (*gitRevision, error) {
+	if revision == "" || strings.HasPrefix(revision, "-") {
+		return nil, fmt.Errorf("unable to compare git revisions, `%s` is not a valid revision", revision)
+	}
+	specPath = path.Clean(filepath.ToSlash(specPath))
+	if path.IsAbs(specPath) || specPath == "." || strings.HasPrefix(specPath, "../") {
+		return nil, fmt.Errorf("unable to compare git revisions, the spec path `%s` must be relative to the "+
+			"root of the repository", specPath)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("git", "-C", repoPath, "archive", "--format=tar", revision)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = errors.New(msg)
+		}
+		return nil, fmt.Errorf("unable to archive revision `%s` of `%s`: %w", revision, repoPath, err)
+	}
+
+	dir, err := os.MkdirTemp("", "libopenapi-git-")
+	if err != nil {
+		return nil, err
+	}
+	rev := &gitRevision{dir: dir}
+	if err = extractSpecFiles(&stdout, dir, specPath); err != nil {
+		return rev, fmt.Errorf("unable to extract revision `%s` of `%s`: %w", revision, repoPath, err)
+	}
+
+	spec, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(specPath)))
+	if err != nil {
+		return rev, fmt.Errorf("unable to read `%s` at revision `%s`: %w", specPath, revision, err)
+	}
+
+	config := datamodel.NewDocumentConfiguration()
+	if configuration != nil {
+		c := *configuration
+		config = &c
+	}
+	config.BasePath = filepath.Join(dir, filepath.FromSlash(path.Dir(specPath)))
+	config.SpecFilePath = path.Base(specPath)
+	config.LocalFS = nil
+	config.AllowFileReferences = true
+
+	rev.document, err = NewDocumentWithConfiguration(spec, config)
+	if err != nil {
+		return rev, fmt.Errorf("unable to create a document from `%s` at revision `%s`: %w",
+			specPath, revision, err)
+	}
+	return rev, nil
+}
+
+// extractSpecFiles extracts the YAML and JSON files (and the specification, whatever its extension) of a tar
+// archive into a directory. Everything else is left out.
+func extractSpecFiles(archive io.Reader, dir, specPath string) error {
+	reader := tar.NewReader(archive)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("archive contains an invalid path `%s`", header.Name)
+		}
+		switch strings.ToLower(path.Ext(name)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			if name != specPath {
+				continue
+			}
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(f, reader)
+		if cErr := f.Close(); err == nil {
+			err = cErr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
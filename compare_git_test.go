@@ -0,0 +1,121 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var gitSpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                $ref: '../shared/pet.yaml'`
+
+// createGitRepo creates a repository with two commits (tagged v1 and v2) of a specification split into two files,
+// the description of the referenced pet schema is changed by the second commit.
+func createGitRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=test", "-c", "user.email=test@pb33f.io",
+			"-c", "commit.gpgsign=false", "-c", "tag.gpgsign=false"}, args...)...)
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	write := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	git("init", "-q")
+	write("api/openapi.yaml", gitSpec)
+	write("shared/pet.yaml", "type: object\ndescription: a pet")
+	write("README.md", "pets")
+	git("add", "-A")
+	git("commit", "-q", "-m", "first")
+	git("tag", "v1")
+	write("shared/pet.yaml", "type: object\ndescription: a very good pet")
+	git("add", "-A")
+	git("commit", "-q", "-m", "second")
+	git("tag", "v2")
+	return dir
+}
+
+func TestCompareGitRevisions(t *testing.T) {
+	repo := createGitRepo(t)
+
+	changes, err := CompareGitRevisions(repo, "v1", "v2", "api/openapi.yaml")
+	require.NoError(t, err)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	located := model.LocateChanges(changes)
+	require.Len(t, located, 1)
+	assert.Equal(t, "description", located[0].Change.Property)
+	assert.Equal(t, "a pet", located[0].Change.Original)
+	assert.Equal(t, "a very good pet", located[0].Change.New)
+
+	changes, err = CompareGitRevisions(repo, "v2", "HEAD", "api/openapi.yaml")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestCompareGitRevisionsWithConfiguration(t *testing.T) {
+	repo := createGitRepo(t)
+
+	// the local file system of the configuration is replaced with the files of each revision.
+	config := datamodel.NewDocumentConfiguration()
+	config.BasePath = "/nowhere"
+	config.LocalFS = os.DirFS("/nowhere")
+	changes, err := CompareGitRevisionsWithConfiguration(repo, "v1", "v2", "./api/../api/openapi.yaml", config,
+		&what_changed.ComparisonConfiguration{CompareResolvedSchemas: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, "/nowhere", config.BasePath)
+
+	// without comparing resolved schemas, only changes made to the references are reported.
+	changes, err = CompareGitRevisionsWithConfiguration(repo, "v1", "v2", "api/openapi.yaml", nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestCompareGitRevisions_Errors(t *testing.T) {
+	repo := createGitRepo(t)
+
+	_, err := CompareGitRevisions(repo, "v1", "nope", "api/openapi.yaml")
+	assert.ErrorContains(t, err, "unable to archive revision `nope`")
+
+	_, err = CompareGitRevisions(repo, "--output=/tmp/nope", "v2", "api/openapi.yaml")
+	assert.ErrorContains(t, err, "is not a valid revision")
+
+	_, err = CompareGitRevisions(repo, "v1", "v2", "../openapi.yaml")
+	assert.ErrorContains(t, err, "must be relative to the root of the repository")
+
+	_, err = CompareGitRevisions(repo, "v1", "v2", "api/missing.yaml")
+	assert.ErrorContains(t, err, "unable to read `api/missing.yaml` at revision `v1`")
+
+	_, err = CompareGitRevisions(repo, "v1", "v2", "README.md")
+	assert.ErrorContains(t, err, "unable to create a document from `README.md` at revision `v1`")
+}
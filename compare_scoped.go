@@ -0,0 +1,319 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// ComparePathItem compares a single path (for example `/burgers`) of two documents, without comparing anything
+// else. The changes returned are the same as the changes for the path in the full comparison (see CompareDocuments).
+//
+// If the path only exists in one of the documents, the addition or removal of the path is the only change returned.
+// If the path exists in neither document, an error is returned. If nothing changed, nil is returned.
+func ComparePathItem(original, updated Document, path string) (*model.PathItemChanges, error) {
+	left, right, err := lowModels(context.Background(), original, updated)
+	if left == nil || right == nil {
+		return nil, err
+	}
+	lKey, lItem := findPathItem(left, path)
+	rKey, rItem := findPathItem(right, path)
+	var changes []*model.Change
+	switch {
+	case lItem == nil && rItem == nil:
+		return nil, fmt.Errorf("unable to compare path '%s', it does not exist in either document", path)
+	case rItem == nil:
+		model.CreateChange(&changes, model.ObjectRemoved, path, lKey, nil,
+			model.BreakingRemoved(model.CompPaths, model.PropPath), lItem, nil)
+	case lItem == nil:
+		model.CreateChange(&changes, model.ObjectAdded, path, nil, rKey,
+			model.BreakingAdded(model.CompPaths, model.PropPath), nil, rItem)
+	default:
+		pc := model.ComparePathItems(lItem, rItem)
+		if pc == nil || pc.TotalChanges() == 0 {
+			return nil, err
+		}
+		return pc, err
+	}
+	return &model.PathItemChanges{PropertyChanges: model.NewPropertyChanges(changes)}, err
+}
+
+// CompareOperationByID compares the operation with the supplied operationId in two documents, without comparing
+// anything else. The operation is found by its ID, so it's compared even if it was moved to another path or method.
+//
+// If the operation only exists in one of the documents, the addition or removal of the operation is the only change
+// returned, using the method of the operation as the property (the same change the path item reports). If the
+// operation exists in neither document, an error is returned. If nothing changed, nil is returned.
+func CompareOperationByID(original, updated Document, operationID string) (*model.OperationChanges, error) {
+	left, right, err := lowModels(context.Background(), original, updated)
+	if left == nil || right == nil {
+		return nil, err
+	}
+	lOp := findOperation(left, operationID)
+	rOp := findOperation(right, operationID)
+	var changes []*model.Change
+	switch {
+	case lOp == nil && rOp == nil:
+		return nil, fmt.Errorf("unable to compare operation '%s', it does not exist in either document", operationID)
+	case rOp == nil:
+		model.CreateChange(&changes, model.PropertyRemoved, lOp.method, lOp.node, nil,
+			model.BreakingRemoved(model.CompPathItem, lOp.rule), lOp.value, nil)
+	case lOp == nil:
+		model.CreateChange(&changes, model.PropertyAdded, rOp.method, nil, rOp.node,
+			model.BreakingAdded(model.CompPathItem, rOp.rule), nil, rOp.value)
+	default:
+		oc := model.CompareOperations(lOp.value, rOp.value)
+		if oc == nil || oc.TotalChanges() == 0 {
+			return nil, err
+		}
+		return oc, err
+	}
+	return &model.OperationChanges{PropertyChanges: model.NewPropertyChanges(changes)}, err
+}
+
+// CompareComponent compares a single component of two documents, without comparing anything else. The component
+// is named using its reference, for example `#/components/schemas/Burger` (or `schemas/Burger`) for OpenAPI, or
+// `#/definitions/Burger` for Swagger. The changes returned are the same as the changes for the component in the
+// full comparison, for example *model.SchemaChanges for a schema or *model.ParameterChanges for a parameter.
+//
+// If the component only exists in one of the documents, the addition or removal of the component is the only
+// change returned, as *model.ComponentsChanges. If the component exists in neither document, or the type of
+// component is not known, an error is returned. If nothing changed, nil is returned.
+func CompareComponent(original, updated Document, name string) (what_changed.Changed, error) {
+	left, right, err := lowModels(context.Background(), original, updated)
+	if left == nil || right == nil {
+		return nil, err
+	}
+	ref := strings.TrimPrefix(strings.TrimPrefix(name, "#"), "/")
+	ref = strings.TrimPrefix(ref, v3low.ComponentsLabel+"/")
+	kind, key, ok := strings.Cut(ref, "/")
+	if !ok || key == "" {
+		return nil, fmt.Errorf("unable to compare component '%s', expected a reference like '#/components/schemas/Name'", name)
+	}
+	key = strings.ReplaceAll(strings.ReplaceAll(key, "~1", "/"), "~0", "~")
+
+	var changes what_changed.Changed
+	var cErr error
+	if l, isSwagger := left.(*v2low.Swagger); isSwagger {
+		changes, cErr = compareSwaggerComponent(l, right.(*v2low.Swagger), kind, key)
+	} else {
+		changes, cErr = compareOpenAPIComponent(left.(*v3low.Document), right.(*v3low.Document), kind, key)
+	}
+	if cErr != nil {
+		return nil, fmt.Errorf("unable to compare component '%s', %w", name, cErr)
+	}
+	return changes, err
+}
+
+func compareOpenAPIComponent(l, r *v3low.Document, kind, key string) (what_changed.Changed, error) {
+	lc, rc := l.Components.Value, r.Components.Value
+	if lc == nil {
+		lc = new(v3low.Components)
+	}
+	if rc == nil {
+		rc = new(v3low.Components)
+	}
+	switch kind {
+	case v3low.SchemasLabel, v2low.DefinitionsLabel:
+		return compareComponent(lc.Schemas.Value, rc.Schemas.Value, key, v3low.SchemasLabel, model.CompareSchemas)
+	case v3low.ResponsesLabel:
+		return compareComponent(lc.Responses.Value, rc.Responses.Value, key, kind, model.CompareResponseV3)
+	case v3low.ParametersLabel:
+		return compareComponent(lc.Parameters.Value, rc.Parameters.Value, key, kind, model.CompareParametersV3)
+	case v3low.ExamplesLabel:
+		return compareComponent(lc.Examples.Value, rc.Examples.Value, key, kind, model.CompareExamples)
+	case v3low.RequestBodiesLabel:
+		return compareComponent(lc.RequestBodies.Value, rc.RequestBodies.Value, key, kind, model.CompareRequestBodies)
+	case v3low.HeadersLabel:
+		return compareComponent(lc.Headers.Value, rc.Headers.Value, key, kind, model.CompareHeadersV3)
+	case v3low.SecuritySchemesLabel, v2low.SecurityDefinitionsLabel:
+		return compareComponent(lc.SecuritySchemes.Value, rc.SecuritySchemes.Value, key, v3low.SecuritySchemesLabel,
+			model.CompareSecuritySchemesV3)
+	case v3low.LinksLabel:
+		return compareComponent(lc.Links.Value, rc.Links.Value, key, kind, model.CompareLinks)
+	case v3low.CallbacksLabel:
+		return compareComponent(lc.Callbacks.Value, rc.Callbacks.Value, key, kind, model.CompareCallback)
+	case v3low.PathItemsLabel:
+		return compareComponent(lc.PathItems.Value, rc.PathItems.Value, key, kind, model.ComparePathItemsV3)
+	case v3low.MediaTypesLabel:
+		return compareComponent(lc.MediaTypes.Value, rc.MediaTypes.Value, key, kind, model.CompareMediaTypes)
+	}
+	return nil, fmt.Errorf("unknown component type '%s'", kind)
+}
+
+func compareSwaggerComponent(l, r *v2low.Swagger, kind, key string) (what_changed.Changed, error) {
+	switch kind {
+	case v2low.DefinitionsLabel:
+		var lm, rm *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]]
+		if l.Definitions.Value != nil {
+			lm = l.Definitions.Value.Schemas
+		}
+		if r.Definitions.Value != nil {
+			rm = r.Definitions.Value.Schemas
+		}
+		return compareComponent(lm, rm, key, kind, model.CompareSchemas)
+	case v3low.ParametersLabel:
+		var lm, rm *orderedmap.Map[low.KeyReference[string], low.ValueReference[*v2low.Parameter]]
+		if l.Parameters.Value != nil {
+			lm = l.Parameters.Value.Definitions
+		}
+		if r.Parameters.Value != nil {
+			rm = r.Parameters.Value.Definitions
+		}
+		return compareComponent(lm, rm, key, kind, func(l, r *v2low.Parameter) *model.ParameterChanges {
+			return model.CompareParameters(l, r)
+		})
+	case v3low.ResponsesLabel:
+		var lm, rm *orderedmap.Map[low.KeyReference[string], low.ValueReference[*v2low.Response]]
+		if l.Responses.Value != nil {
+			lm = l.Responses.Value.Definitions
+		}
+		if r.Responses.Value != nil {
+			rm = r.Responses.Value.Definitions
+		}
+		return compareComponent(lm, rm, key, kind, model.CompareResponseV2)
+	case v2low.SecurityDefinitionsLabel:
+		var lm, rm *orderedmap.Map[low.KeyReference[string], low.ValueReference[*v2low.SecurityScheme]]
+		if l.SecurityDefinitions.Value != nil {
+			lm = l.SecurityDefinitions.Value.Definitions
+		}
+		if r.SecurityDefinitions.Value != nil {
+			rm = r.SecurityDefinitions.Value.Definitions
+		}
+		return compareComponent(lm, rm, key, kind, model.CompareSecuritySchemesV2)
+	}
+	return nil, fmt.Errorf("unknown component type '%s'", kind)
+}
+
+// compareComponent compares the component with the supplied key in two component maps. Additions and removals are
+// breaking (or not) in the same way as they are when comparing components.
+func compareComponent[T any, R what_changed.Changed](l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	key, label string, compare func(l, r T) R,
+) (what_changed.Changed, error) {
+	_, lv := low.FindItemInOrderedMapWithKey(key, l)
+	_, rv := low.FindItemInOrderedMapWithKey(key, r)
+	var changes []*model.Change
+	switch {
+	case lv == nil && rv == nil:
+		return nil, fmt.Errorf("it does not exist in either document")
+	case rv == nil:
+		model.CreateChange(&changes, model.ObjectRemoved, label, lv.ValueNode, nil, true, lv.Value, nil)
+		changes[0].Original = key
+	case lv == nil:
+		model.CreateChange(&changes, model.ObjectAdded, label, nil, rv.ValueNode, false, nil, rv.Value)
+		changes[0].New = key
+	default:
+		ch := compare(lv.Value, rv.Value)
+		if reflect.ValueOf(&ch).Elem().IsZero() || ch.TotalChanges() == 0 {
+			return nil, nil
+		}
+		return ch, nil
+	}
+	return &model.ComponentsChanges{PropertyChanges: model.NewPropertyChanges(changes)}, nil
+}
+
+// findPathItem returns the key node and the low-level path item for a path, or nil if it does not exist.
+func findPathItem(doc any, path string) (*yaml.Node, any) {
+	switch d := doc.(type) {
+	case *v3low.Document:
+		if d.Paths.Value != nil {
+			if k, v := d.Paths.Value.FindPathAndKey(path); v != nil && v.Value != nil {
+				return k.KeyNode, v.Value
+			}
+		}
+	case *v2low.Swagger:
+		if d.Paths.Value != nil {
+			if k, v := d.Paths.Value.FindPathAndKey(path); v != nil && v.Value != nil {
+				return k.KeyNode, v.Value
+			}
+		}
+	}
+	return nil, nil
+}
+
+// scopedOperation is an operation found by its ID.
+type scopedOperation struct {
+	method string     // the method (or additional operation) label
+	rule   string     // the breaking rule property for adding or removing the operation
+	node   *yaml.Node // the value node of the operation
+	value  any        // the low-level operation, *v3low.Operation or *v2low.Operation
+}
+
+// findOperation returns the first operation (in the order of the specification) with the supplied operationId,
+// or nil if there isn't one.
+func findOperation(doc any, operationID string) *scopedOperation {
+	switch d := doc.(type) {
+	case *v3low.Document:
+		if d.Paths.Value == nil {
+			return nil
+		}
+		for _, pathItem := range d.Paths.Value.PathItems.FromOldest() {
+			if op := findOperationV3(pathItem.Value, operationID); op != nil {
+				return op
+			}
+		}
+	case *v2low.Swagger:
+		if d.Paths.Value == nil {
+			return nil
+		}
+		for _, pathItem := range d.Paths.Value.PathItems.FromOldest() {
+			p := pathItem.Value
+			if p == nil {
+				continue
+			}
+			for _, o := range []struct {
+				label string
+				op    low.NodeReference[*v2low.Operation]
+			}{
+				{v2low.GetLabel, p.Get}, {v2low.PutLabel, p.Put}, {v2low.PostLabel, p.Post},
+				{v2low.DeleteLabel, p.Delete}, {v2low.OptionsLabel, p.Options}, {v2low.HeadLabel, p.Head},
+				{v2low.PatchLabel, p.Patch},
+			} {
+				if o.op.Value != nil && o.op.Value.OperationId.Value == operationID {
+					return &scopedOperation{method: o.label, rule: o.label, node: o.op.ValueNode, value: o.op.Value}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func findOperationV3(p *v3low.PathItem, operationID string) *scopedOperation {
+	if p == nil {
+		return nil
+	}
+	for _, o := range []struct {
+		label string
+		op    low.NodeReference[*v3low.Operation]
+	}{
+		{v3low.GetLabel, p.Get}, {v3low.PutLabel, p.Put}, {v3low.PostLabel, p.Post},
+		{v3low.DeleteLabel, p.Delete}, {v3low.OptionsLabel, p.Options}, {v3low.HeadLabel, p.Head},
+		{v3low.PatchLabel, p.Patch}, {v3low.TraceLabel, p.Trace}, {v3low.QueryLabel, p.Query},
+	} {
+		if o.op.Value != nil && o.op.Value.OperationId.Value == operationID {
+			return &scopedOperation{method: o.label, rule: o.label, node: o.op.ValueNode, value: o.op.Value}
+		}
+	}
+	if p.AdditionalOperations.Value != nil {
+		for k, op := range p.AdditionalOperations.Value.FromOldest() {
+			if op.Value != nil && op.Value.OperationId.Value == operationID {
+				return &scopedOperation{method: k.Value, rule: model.PropAdditionalOperations, node: op.ValueNode,
+					value: op.Value}
+			}
+		}
+	}
+	return nil
+}
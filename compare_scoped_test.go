@@ -0,0 +1,190 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func burgerShopScopedDocuments(t *testing.T) (Document, Document, *model.DocumentChanges) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, err := NewDocument(burgerShopOriginal)
+	require.NoError(t, err)
+	updatedDoc, err := NewDocument(burgerShopUpdated)
+	require.NoError(t, err)
+	changes, err := CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	return originalDoc, updatedDoc, changes
+}
+
+func TestComparePathItem(t *testing.T) {
+	originalDoc, updatedDoc, all := burgerShopScopedDocuments(t)
+
+	changes, err := ComparePathItem(originalDoc, updatedDoc, "/burgers")
+	require.NoError(t, err)
+	require.NotNil(t, changes)
+	full := all.PathsChanges.PathItemsChanges["/burgers"]
+	assert.Equal(t, full.TotalChanges(), changes.TotalChanges())
+	assert.Equal(t, full.TotalBreakingChanges(), changes.TotalBreakingChanges())
+	operationIDs := make(map[string]string)
+	for _, c := range changes.PostChanges.Changes {
+		operationIDs[c.Property] = c.New
+	}
+	assert.Equal(t, "createBurgerChanged", operationIDs["operationId"])
+
+	// nothing changed.
+	changes, err = ComparePathItem(originalDoc, originalDoc, "/burgers")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	_, err = ComparePathItem(originalDoc, updatedDoc, "/nope")
+	assert.EqualError(t, err, "unable to compare path '/nope', it does not exist in either document")
+}
+
+func TestComparePathItem_AddedRemoved(t *testing.T) {
+	original, err := NewDocument([]byte(crossVersionOpenAPI))
+	require.NoError(t, err)
+	updated, err := NewDocument([]byte(strings.Replace(crossVersionOpenAPI, "  /pets:", "  /animals:", 1)))
+	require.NoError(t, err)
+
+	changes, err := ComparePathItem(original, updated, "/pets")
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, model.ObjectRemoved, changes.Changes[0].ChangeType)
+	assert.Equal(t, "/pets", changes.Changes[0].Property)
+	assert.True(t, changes.Changes[0].Breaking)
+
+	changes, err = ComparePathItem(original, updated, "/animals")
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, model.ObjectAdded, changes.Changes[0].ChangeType)
+	assert.False(t, changes.Changes[0].Breaking)
+}
+
+func TestCompareOperationByID(t *testing.T) {
+	originalDoc, updatedDoc, all := burgerShopScopedDocuments(t)
+
+	changes, err := CompareOperationByID(originalDoc, updatedDoc, "locateBurger")
+	require.NoError(t, err)
+	require.NotNil(t, changes)
+	full := all.PathsChanges.PathItemsChanges["/burgers/{burgerId}"].GetChanges
+	assert.Equal(t, full.TotalChanges(), changes.TotalChanges())
+	assert.Equal(t, full.TotalBreakingChanges(), changes.TotalBreakingChanges())
+
+	// the ID of the operation changed, so it was removed.
+	changes, err = CompareOperationByID(originalDoc, updatedDoc, "createBurger")
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, model.PropertyRemoved, changes.Changes[0].ChangeType)
+	assert.Equal(t, "post", changes.Changes[0].Property)
+	assert.True(t, changes.Changes[0].Breaking)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	changes, err = CompareOperationByID(originalDoc, updatedDoc, "createBurgerChanged")
+	require.NoError(t, err)
+	require.Len(t, changes.Changes, 1)
+	assert.Equal(t, model.PropertyAdded, changes.Changes[0].ChangeType)
+	assert.Equal(t, 68, *changes.Changes[0].Context.NewLine)
+
+	changes, err = CompareOperationByID(originalDoc, originalDoc, "locateBurger")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	_, err = CompareOperationByID(originalDoc, updatedDoc, "nope")
+	assert.EqualError(t, err, "unable to compare operation 'nope', it does not exist in either document")
+}
+
+func TestCompareComponent(t *testing.T) {
+	originalDoc, updatedDoc, all := burgerShopScopedDocuments(t)
+
+	for _, name := range []string{"#/components/schemas/Burger", "components/schemas/Burger", "schemas/Burger"} {
+		changes, err := CompareComponent(originalDoc, updatedDoc, name)
+		require.NoError(t, err, name)
+		require.IsType(t, &model.SchemaChanges{}, changes, name)
+		assert.Equal(t, all.ComponentsChanges.SchemaChanges["Burger"].TotalChanges(), changes.TotalChanges(), name)
+	}
+
+	changes, err := CompareComponent(originalDoc, updatedDoc, "#/components/securitySchemes/JWTScheme")
+	require.NoError(t, err)
+	require.IsType(t, &model.SecuritySchemeChanges{}, changes)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	changes, err = CompareComponent(originalDoc, updatedDoc, "#/components/parameters/BurgerId2")
+	require.NoError(t, err)
+	require.IsType(t, &model.ComponentsChanges{}, changes)
+	added := changes.GetAllChanges()
+	require.Len(t, added, 1)
+	assert.Equal(t, model.ObjectAdded, added[0].ChangeType)
+	assert.Equal(t, "parameters", added[0].Property)
+	assert.Equal(t, "BurgerId2", added[0].New)
+
+	changes, err = CompareComponent(originalDoc, updatedDoc, "#/components/responses/DressingResponse")
+	require.NoError(t, err)
+	removed := changes.GetAllChanges()
+	require.Len(t, removed, 1)
+	assert.Equal(t, model.ObjectRemoved, removed[0].ChangeType)
+	assert.Equal(t, "DressingResponse", removed[0].Original)
+	assert.True(t, removed[0].Breaking)
+
+	changes, err = CompareComponent(originalDoc, originalDoc, "#/components/schemas/Burger")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	_, err = CompareComponent(originalDoc, updatedDoc, "#/components/schemas/Nope")
+	assert.EqualError(t, err, "unable to compare component '#/components/schemas/Nope', it does not exist in either document")
+	_, err = CompareComponent(originalDoc, updatedDoc, "#/components/things/Burger")
+	assert.EqualError(t, err, "unable to compare component '#/components/things/Burger', unknown component type 'things'")
+	_, err = CompareComponent(originalDoc, updatedDoc, "Burger")
+	assert.Error(t, err)
+}
+
+func TestCompareScoped_Swagger(t *testing.T) {
+	original, err := NewDocument([]byte(crossVersionSwagger))
+	require.NoError(t, err)
+	updated, err := NewDocument([]byte(strings.Replace(
+		strings.Replace(crossVersionSwagger, "operationId: listPets", "operationId: listPets\n      summary: all pets", 1),
+		"      name:\n        type: string", "      name:\n        type: integer", 1)))
+	require.NoError(t, err)
+
+	pathChanges, err := ComparePathItem(original, updated, "/pets")
+	require.NoError(t, err)
+	assert.Equal(t, 1, pathChanges.TotalChanges())
+
+	operationChanges, err := CompareOperationByID(original, updated, "listPets")
+	require.NoError(t, err)
+	require.Len(t, operationChanges.Changes, 1)
+	assert.Equal(t, "summary", operationChanges.Changes[0].Property)
+
+	changes, err := CompareComponent(original, updated, "#/definitions/Pet")
+	require.NoError(t, err)
+	require.IsType(t, &model.SchemaChanges{}, changes)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestCompareScoped_CrossVersion(t *testing.T) {
+	swagger, err := NewDocument([]byte(crossVersionSwagger))
+	require.NoError(t, err)
+	openAPI, err := NewDocument([]byte(crossVersionOpenAPI))
+	require.NoError(t, err)
+
+	// a faithful migration has no changes.
+	pathChanges, err := ComparePathItem(swagger, openAPI, "/pets")
+	require.NoError(t, err)
+	assert.Nil(t, pathChanges)
+	operationChanges, err := CompareOperationByID(swagger, openAPI, "listPets")
+	require.NoError(t, err)
+	assert.Nil(t, operationChanges)
+
+	// swagger definitions are OpenAPI schemas once converted.
+	changes, err := CompareComponent(swagger, openAPI, "#/definitions/Pet")
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
@@ -0,0 +1,117 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// DocumentSeriesChanges is the history of changes made across a series of versions of a document (for example,
+// every release of a specification), oldest first.
+type DocumentSeriesChanges struct {
+	// Versions holds the changes made in each version, compared with the version before it. Versions[0] is always
+	// nil (the first version has nothing to be compared with), and Versions[i] holds the changes made between
+	// documents i-1 and i. Versions that changed nothing are nil.
+	Versions []*model.DocumentChanges
+
+	// History holds the changes made to each object, keyed by the path of the object in the tree of changes (see
+	// model.ChangePath), for example `$.paths.pathItems['/burgers'].post`. Changes are in the order of the versions.
+	History map[string][]*SeriesChange
+}
+
+// SeriesChange is a single change made in a version of a series of documents.
+type SeriesChange struct {
+	// Version is the index of the document the change was made in.
+	Version int
+
+	// Path is the path of the object that changed (see model.ChangePath).
+	Path string
+
+	// Change is the change that was made.
+	Change *model.Change
+}
+
+// CompareDocumentSeries compares a series of versions of a document (oldest first), each with the version before it,
+// and collects the changes made to every object across all versions. Each document is only built once, however
+// many times it is compared.
+//
+// At least two documents are required. Any errors found comparing versions are returned with the changes, and a
+// version that could not be compared at all is left nil.
+func CompareDocumentSeries(documents []Document) (*DocumentSeriesChanges, error) {
+	if len(documents) < 2 {
+		return nil, fmt.Errorf("unable to compare a series of documents, at least two documents are required, %d supplied",
+			len(documents))
+	}
+	series := &DocumentSeriesChanges{
+		Versions: make([]*model.DocumentChanges, len(documents)),
+		History:  make(map[string][]*SeriesChange),
+	}
+	var errs []error
+	for i := 1; i < len(documents); i++ {
+		changes, err := CompareDocuments(documents[i-1], documents[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to compare version %d with version %d: %w", i-1, i, err))
+		}
+		if changes == nil {
+			continue
+		}
+		series.Versions[i] = changes
+		for _, lc := range model.LocateChanges(changes) {
+			path := model.ChangePath(lc.Location)
+			series.History[path] = append(series.History[path], &SeriesChange{Version: i, Path: path, Change: lc.Change})
+		}
+	}
+	return series, errors.Join(errs...)
+}
+
+// ChangesTo returns every change made to an object, and to anything inside it, in the order of the versions. The
+// path is the path of the object in the tree of changes (see model.ChangePath), for example
+// `$.paths.pathItems['/burgers'].post.responses`.
+func (s *DocumentSeriesChanges) ChangesTo(path string) []*SeriesChange {
+	if s == nil {
+		return nil
+	}
+	var changes []*SeriesChange
+	for p, history := range s.History {
+		if isWithinPath(p, path) {
+			changes = append(changes, history...)
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Version != changes[j].Version {
+			return changes[i].Version < changes[j].Version
+		}
+		return changes[i].Path < changes[j].Path
+	})
+	return changes
+}
+
+// LastChanged returns the index of the last version that changed an object, or anything inside it. If the object
+// never changed, -1 is returned.
+func (s *DocumentSeriesChanges) LastChanged(path string) int {
+	last := -1
+	if s == nil {
+		return last
+	}
+	for p, history := range s.History {
+		if isWithinPath(p, path) && history[len(history)-1].Version > last {
+			last = history[len(history)-1].Version
+		}
+	}
+	return last
+}
+
+// isWithinPath determines if a path is the same as, or inside, another path.
+func isWithinPath(path, parent string) bool {
+	if !strings.HasPrefix(path, parent) {
+		return false
+	}
+	rest := path[len(parent):]
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
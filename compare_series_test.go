@@ -0,0 +1,76 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareDocumentSeries(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+
+	var documents []Document
+	for _, spec := range [][]byte{burgerShopOriginal, burgerShopUpdated, burgerShopUpdated, burgerShopOriginal} {
+		doc, err := NewDocument(spec)
+		require.NoError(t, err)
+		documents = append(documents, doc)
+	}
+
+	series, err := CompareDocumentSeries(documents)
+	require.NoError(t, err)
+	require.Len(t, series.Versions, 4)
+	assert.Nil(t, series.Versions[0])
+	assert.Equal(t, 77, series.Versions[1].TotalChanges())
+	assert.Nil(t, series.Versions[2])
+	assert.Equal(t, 77, series.Versions[3].TotalChanges())
+
+	// every change is in the history once.
+	total := 0
+	for path, history := range series.History {
+		for _, c := range history {
+			assert.Equal(t, path, c.Path)
+			assert.NotEqual(t, 2, c.Version)
+		}
+		total += len(history)
+	}
+	assert.Equal(t, 154, total)
+
+	response := "$.paths.pathItems['/burgers'].post.responses.response['200']"
+	changes := series.ChangesTo(response)
+	require.NotEmpty(t, changes)
+	assert.Equal(t, 1, changes[0].Version)
+	assert.Equal(t, 3, changes[len(changes)-1].Version)
+	assert.Equal(t, 3, series.LastChanged(response))
+	assert.Len(t, series.ChangesTo("$.paths"), series.Versions[1].PathsChanges.TotalChanges()*2)
+
+	assert.Empty(t, series.ChangesTo("$.paths.pathItems['/nope']"))
+	assert.Equal(t, -1, series.LastChanged("$.paths.pathItems['/nope']"))
+	assert.Equal(t, 3, series.LastChanged("$"))
+}
+
+func TestCompareDocumentSeries_NotEnoughDocuments(t *testing.T) {
+	doc, err := NewDocument([]byte(crossVersionOpenAPI))
+	require.NoError(t, err)
+
+	_, err = CompareDocumentSeries([]Document{doc})
+	assert.EqualError(t, err,
+		"unable to compare a series of documents, at least two documents are required, 1 supplied")
+
+	var series *DocumentSeriesChanges
+	assert.Nil(t, series.ChangesTo("$"))
+	assert.Equal(t, -1, series.LastChanged("$"))
+}
+
+func TestIsWithinPath(t *testing.T) {
+	assert.True(t, isWithinPath("$.components.schemas.Burger", "$.components.schemas.Burger"))
+	assert.True(t, isWithinPath("$.components.schemas.Burger.properties.name", "$.components.schemas.Burger"))
+	assert.True(t, isWithinPath("$.paths.pathItems['/burgers']", "$.paths.pathItems"))
+	assert.False(t, isWithinPath("$.components.schemas.BurgerBun", "$.components.schemas.Burger"))
+	assert.False(t, isWithinPath("$.components", "$.components.schemas"))
+}
@@ -0,0 +1,66 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import "time"
+
+// BuildEventType identifies the kind of issue a BuildEvent is reporting.
+type BuildEventType string
+
+const (
+	// BuildEventCircularReference is emitted when the resolver finds a circular reference.
+	BuildEventCircularReference BuildEventType = "circular-reference"
+
+	// BuildEventUnresolvedReference is emitted when a reference cannot be located anywhere in the rolodex.
+	BuildEventUnresolvedReference BuildEventType = "unresolved-reference"
+
+	// BuildEventSlowRemoteFetch is emitted when fetching a remote file takes longer than the SlowRemoteFetchThreshold.
+	BuildEventSlowRemoteFetch BuildEventType = "slow-remote-fetch"
+
+	// BuildEventUnknownKey is emitted when the root of a document contains a key that is not defined by the
+	// specification, and is not an extension.
+	BuildEventUnknownKey BuildEventType = "unknown-key"
+)
+
+// DefaultSlowRemoteFetchThreshold is used when the SlowRemoteFetchThreshold of a configuration is not set.
+const DefaultSlowRemoteFetchThreshold = 2 * time.Second
+
+// BuildEvent is a structured warning, emitted as it occurs while a document is being indexed and built. Events are
+// also reported in the final errors (or index results) where applicable, they exist so interactive tools can surface
+// issues as they happen.
+type BuildEvent struct {
+	// Type is the kind of issue being reported.
+	Type BuildEventType
+
+	// Subsystem is the part of libopenapi that emitted the event.
+	Subsystem LogSubsystem
+
+	// Message is a human-readable description of the issue.
+	Message string
+
+	// File is the path or URL of the file the issue was found in, if known.
+	File string
+
+	// Ref is the reference ($ref value) the issue is about, if any.
+	Ref string
+
+	// Line and Column locate the issue in the file, if known.
+	Line   int
+	Column int
+
+	// Duration is how long the operation took, for events reporting slow operations.
+	Duration time.Duration
+}
+
+// BuildEventHandler is called with every BuildEvent emitted while building a document. Indexing and building runs
+// across many goroutines, so a handler can be called concurrently and must be safe to do so. Handlers should return
+// quickly, as they are called inline.
+type BuildEventHandler func(event *BuildEvent)
+
+// EmitBuildEvent sends an event to the BuildEventHandler of the configuration, if one is set.
+func (c *DocumentConfiguration) EmitBuildEvent(event *BuildEvent) {
+	if c != nil && c.BuildEventHandler != nil {
+		c.BuildEventHandler(event)
+	}
+}
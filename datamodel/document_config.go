@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"time"
 
 	"github.com/pb33f/libopenapi/utils"
 )
@@ -24,6 +25,9 @@ const (
 	RejectConflicts
 )
 
+// LicenseIdentifierChecker returns true if the supplied identifier is a valid SPDX license expression.
+type LicenseIdentifierChecker func(identifier string) bool
+
 // DocumentConfiguration is used to configure the document creation process. It was added in v0.6.0 to allow
 // for more fine-grained control over controls and new features.
 //
@@ -68,6 +72,11 @@ type DocumentConfiguration struct {
 
 	// LocalFS is a filesystem that will be used to retrieve local documents. If not set, then the rolodex will
 	// use its own internal local filesystem implementation. The default is to use the internal local filesystem loader.
+	//
+	// Any fs.FS can be used, for example an embed.FS (so specifications can be embedded in a binary), or an
+	// fstest.MapFS. The root of the fs.FS is the BasePath, and relative references are resolved inside it (files are
+	// read when they are first referenced, and the fs.FS is only ever given relative, slash separated paths). Setting
+	// LocalFS is enough to resolve local references, neither BasePath nor AllowFileReferences are required.
 	LocalFS fs.FS
 
 	// AllowFileReferences will allow the index to locate relative file references. This is disabled by default.
@@ -120,6 +129,82 @@ type DocumentConfiguration struct {
 	// will be used, set to the Error level.
 	Logger *slog.Logger
 
+	// SubsystemLoggers allows each subsystem (index, resolver, bundler and builders) to be given its own logger,
+	// so the level and handler of each subsystem can be controlled separately. For example, debug logging can be
+	// enabled for the index only, without the bundler and builders drowning it out. Any subsystem without a logger
+	// in this map uses Logger.
+	SubsystemLoggers map[LogSubsystem]*slog.Logger
+
+	// BuildEventHandler is called with structured warnings (circular references, unresolved references, slow
+	// remote fetches, unknown keys) as they occur during indexing and building, so interactive tools can surface
+	// issues live, rather than waiting for the final errors. The handler can be called from multiple goroutines.
+	BuildEventHandler BuildEventHandler
+
+	// IndexingProgressHandler is called as files are discovered and indexed, and references are resolved, while the
+	// rolodex indexes a document and every file it refers to. Indexing a large tree of files can take seconds, so
+	// tools (like CLIs) can use it to show progress. The handler can be called from multiple goroutines.
+	IndexingProgressHandler IndexingProgressHandler
+
+	// SlowRemoteFetchThreshold is how long fetching a remote file can take, before a BuildEventSlowRemoteFetch
+	// event is emitted. If not set, DefaultSlowRemoteFetchThreshold is used.
+	SlowRemoteFetchThreshold time.Duration
+
+	// RemoteCacheDir is a directory that remote documents are cached in (it is created if it does not exist).
+	// Cached documents are revalidated using the ETag and Last-Modified headers they were served with, so unchanged
+	// documents are not downloaded again, and are used as they are if the server cannot be reached. This makes
+	// repeated builds (and bundles) of specifications with many remote references fast, and tolerant of a flaky
+	// network. The cache is only used with the default HTTP client, a RemoteURLHandler can use index.RemoteCache
+	// itself. Defaults to "" (remote documents are not cached).
+	RemoteCacheDir string
+
+	// RemoteCacheTTL is how long cached remote documents are used without revalidating them with the server, when
+	// the server does not send a Cache-Control max-age with them (a max-age is always honored, and documents served
+	// with no-cache or no-store are never used without asking the server). In CI, a TTL stops every build from
+	// checking the same shared schemas again. Defaults to 0 (documents are revalidated every time).
+	RemoteCacheTTL time.Duration
+
+	// RemoteAuth authenticates the requests made to fetch remote documents, for example documents served behind
+	// SSO. Each authentication is applied to the hosts that match its pattern, and can add static headers, a bearer
+	// token, basic auth or client certificates (mTLS). Authentication is only applied with the default HTTP client
+	// (including when documents are cached), a RemoteURLHandler can use index.RemoteAuthTransport itself.
+	RemoteAuth []*RemoteAuth
+
+	// ObjectStores resolve references to objects in object stores, keyed by the scheme of the references they
+	// resolve, for example 's3' resolves 's3://bucket/specs/pet.yaml' (see ObjectStore). Setting ObjectStores allows
+	// remote references. Objects are fetched by the rolodex in the same way as any other remote document, even if a
+	// RemoteURLHandler is set.
+	ObjectStores map[string]ObjectStore
+
+	// AllowGitReferences resolves references to files in git repositories, at a branch, tag or commit, like
+	// 'git+https://github.com/org/specs.git/schemas/pet.yaml?ref=v1.2#/Pet'. The repository is the part of the URL
+	// up to the '.git' path segment (without the 'git+' prefix), the file is the path after it, and the fragment is a
+	// JSON pointer into the file, like any other reference (so the form some tools use, with the file in the
+	// fragment, like 'git+https://github.com/org/specs.git?ref=v1.2#/schemas/pet.yaml', is not supported). Without
+	// a 'ref', the default branch is used. Only repositories served over https, ssh or git can be referenced, and a
+	// 'ref' must be a branch or tag name, or a commit hash.
+	//
+	// Repositories are fetched (and checked out) with the git command, so it must be installed, and credentials are
+	// provided by git (credential helpers, or SSH keys). Setting AllowGitReferences allows remote references.
+	AllowGitReferences bool
+
+	// GitCacheDir is the directory that git repositories, and the commits checked out of them, are kept in, so they
+	// are not fetched again. References to a commit are never fetched twice, branches and tags are fetched again by
+	// every rolodex. Defaults to a 'libopenapi-git' directory in the temporary directory of the system.
+	GitCacheDir string
+
+	// FileTransformers are applied, in order, to the bytes of every file loaded by the rolodex (local or remote)
+	// before the file is parsed. Each transformer can be limited to files with certain extensions, or paths that
+	// match a pattern. This allows files to be decrypted (for example SOPS managed files), templated includes to be
+	// expanded, or byte order marks to be stripped, without replacing the LocalFS or RemoteFS implementations.
+	// The root document is not loaded by the rolodex, so it is not transformed.
+	FileTransformers []*FileTransformer
+
+	// LicenseIdentifierChecker is used to check the 'identifier' of the info.license object of an OpenAPI 3.1+
+	// document is a valid SPDX license expression. libopenapi does not ship the SPDX license list, so the check is
+	// pluggable, and is skipped if not set. An identifier that fails the check is reported as an error when building
+	// the model.
+	LicenseIdentifierChecker LicenseIdentifierChecker
+
 	// ExtractRefsSequentially will extract all references sequentially, which means the index will look up references
 	// as it finds them, vs looking up everything asynchronously.
 	// This is a more thorough way of building the index, but it's slower. It's required building a document
@@ -130,6 +215,16 @@ type DocumentConfiguration struct {
 	// defaults to false (which means extensions will be included)
 	ExcludeExtensionRefs bool
 
+	// SkipRemoteReferences will resolve local file references, but leave every remote (http/https) reference
+	// untouched, nothing is fetched, even if a BaseURL or RemoteFS is set. This is for offline builds, or
+	// environments that are not allowed to make network requests, that still want as much of the model as possible.
+	//
+	// Instead of failing to build, any object that uses a remote reference is built as a placeholder, an object of
+	// the right type with no content, backed by a low-level object that is a reference to the remote location (the
+	// reference is kept when the model is rendered). Remote schemas build as empty schemas. The references skipped
+	// are available from the index, using GetRemotePlaceholders. Defaults to false.
+	SkipRemoteReferences bool
+
 	// BundleInlineRefs is used by the bundler module. If set to true, all references will be inlined, including
 	// local references (to the root document) as well as all external references. This is false by default.
 	BundleInlineRefs bool
@@ -190,6 +285,58 @@ type DocumentConfiguration struct {
 	// - OverwriteWithRemote: Referenced properties overwrite local properties
 	// - RejectConflicts: Throw error when properties conflict
 	PropertyMergeStrategy PropertyMergeStrategy
+
+	// DeduplicateFilesByContent will make the rolodex share a single index between files in the same directory
+	// (or at the same base URL) that have exactly the same content, even if they were located via different names
+	// (symlinks, copies etc.). This saves memory and means references to either location resolve to the same nodes.
+	// Files in different directories are not shared, as their relative references resolve to different files, unless
+	// one directory is a symlink to the other. Files at different base URLs (like mirrors of the same files) are not
+	// shared. Disabled by default.
+	DeduplicateFilesByContent bool
+
+	// FileContentCacheSize is the most content (in bytes) of local and remote files the rolodex keeps in memory.
+	// When it's exceeded, the content of the files used least recently is dropped, and read again (from disk, or
+	// the network) the next time it's needed. The indexes of the files are kept, because the model built from a
+	// document refers to their nodes. For very large multi-file specifications, this keeps the memory used by file
+	// content flat. Combine it with RemoteCacheDir, so remote files are not downloaded again. Defaults to 0 (all
+	// content is kept in memory).
+	FileContentCacheSize int64
+
+	// UseArenaAllocation will allocate low-level model objects (not the yaml nodes they refer to) from a slab
+	// allocator (low.Arena) that is owned by the document, instead of allocating every object individually. This
+	// lowers the number of allocations when building very large specifications, or many specifications in a
+	// high-throughput service. Objects are allocated in slabs, and a slab is kept in memory for as long as any of its
+	// objects are, so keeping a few objects of a model can keep more memory alive than it would without an arena.
+	// The arena is released when the document's model is invalidated. Disabled by default.
+	UseArenaAllocation bool
+
+	// RoundTripFidelity will make Document.Render() (and RenderAndReload()) reproduce the original specification
+	// as closely as possible, for tools that use libopenapi as a pass-through editor. Disabled by default, because
+	// a snapshot of the model has to be rendered when it's built, which adds to the build time.
+	//
+	// If the model has not been changed, the original bytes are returned exactly as they were read. If the model
+	// has been changed, only the changed parts of the specification are re-written, everything else keeps its
+	// original key order, quoting, flow styles and comments. The following normalizations are applied when
+	// a changed specification is re-encoded:
+	//
+	//   - YAML: blank lines are removed, long plain (unquoted) scalars that span multiple lines are joined into
+	//     a single line, repeated whitespace between a key and its value is collapsed, anchors are moved onto the
+	//     same line as their key, every block (including sequences) is indented using the indentation detected in
+	//     the original specification, and the output always ends with a newline.
+	//   - JSON: the specification is re-printed using the detected indentation, and '<', '>' and '&' are escaped.
+	//
+	// New content added to the model is rendered the same way as a regular Render() call. When the model moves the
+	// keys of a mapping around (for example, the paths of the model are put in a new order), the keys of that mapping
+	// are written in their new order, and keys the model does not render stay after the key they originally followed.
+	RoundTripFidelity bool
+
+	// LazyPaths will skip building the paths of an OpenAPI 3+ model when the model is built, the Paths of the model
+	// are left empty. Most of the memory used by the model of a very large (often generated) specification is used
+	// by its paths, so instead of holding every path item in memory at once, walk them one at a time with
+	// libopenapi.WalkV3Paths, each path item is built when it is reached, and can be released as soon as the walk
+	// moves on. A document configured with LazyPaths cannot be rendered, compared or patched, as its model would be
+	// missing every path. Disabled by default.
+	LazyPaths bool
 }
 
 func NewDocumentConfiguration() *DocumentConfiguration {
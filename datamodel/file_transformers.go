@@ -0,0 +1,92 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FileTransformFunc transforms the bytes of a file, before the file is parsed. The location is the absolute path
+// (or URL) of the file. If an error is returned, the file is not loaded, and the error is reported.
+type FileTransformFunc func(location string, data []byte) ([]byte, error)
+
+// FileTransformer applies a FileTransformFunc to every file loaded by the rolodex that matches its extensions or
+// pattern. If neither Extensions nor Pattern are set, every file is transformed.
+type FileTransformer struct {
+	// Name is used to identify the transformer in errors.
+	Name string
+
+	// Extensions are the file extensions (including the dot, for example '.yaml') to transform. Extensions are
+	// case-insensitive.
+	Extensions []string
+
+	// Pattern is a path.Match pattern, matched against the full location of the file, and against the file name.
+	// For example '*.enc.yaml' or '/specs/secrets/*'.
+	Pattern string
+
+	// Transform is called with the bytes of every matching file.
+	Transform FileTransformFunc
+}
+
+// Matches returns true if the transformer should be applied to the file at the location.
+func (t *FileTransformer) Matches(location string) bool {
+	if t == nil || t.Transform == nil {
+		return false
+	}
+	if len(t.Extensions) == 0 && t.Pattern == "" {
+		return true
+	}
+	if len(t.Extensions) > 0 {
+		ext := filepath.Ext(location)
+		if i := strings.IndexAny(ext, "?#"); i >= 0 {
+			ext = ext[:i]
+		}
+		for _, e := range t.Extensions {
+			if strings.EqualFold(e, ext) {
+				return true
+			}
+		}
+	}
+	if t.Pattern != "" {
+		slashed := filepath.ToSlash(location)
+		if ok, _ := path.Match(t.Pattern, slashed); ok {
+			return true
+		}
+		if ok, _ := path.Match(t.Pattern, path.Base(slashed)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyFileTransformers runs every matching transformer over the bytes of a file, in order, each transformer is
+// given the output of the one before it. The first error stops the chain and is returned.
+func ApplyFileTransformers(transformers []*FileTransformer, location string, data []byte) ([]byte, error) {
+	for _, t := range transformers {
+		if !t.Matches(location) {
+			continue
+		}
+		transformed, err := t.Transform(location, data)
+		if err != nil {
+			if t.Name != "" {
+				return nil, fmt.Errorf("file transformer '%s' failed to transform '%s': %w", t.Name, location, err)
+			}
+			return nil, fmt.Errorf("file transformer failed to transform '%s': %w", location, err)
+		}
+		data = transformed
+	}
+	return data, nil
+}
+
+// StripBOMTransformer is a FileTransformer that removes a UTF-8 byte order mark from the start of every file.
+var StripBOMTransformer = &FileTransformer{
+	Name: "strip-bom",
+	Transform: func(_ string, data []byte) ([]byte, error) {
+		return bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), nil
+	},
+}
@@ -0,0 +1,64 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileTransformer_Matches(t *testing.T) {
+	noop := func(_ string, data []byte) ([]byte, error) { return data, nil }
+
+	assert.False(t, (*FileTransformer)(nil).Matches("a.yaml"))
+	assert.False(t, (&FileTransformer{}).Matches("a.yaml"))
+	assert.True(t, (&FileTransformer{Transform: noop}).Matches("a.yaml"))
+
+	byExt := &FileTransformer{Extensions: []string{".yaml"}, Transform: noop}
+	assert.True(t, byExt.Matches("/specs/a.YAML"))
+	assert.True(t, byExt.Matches("https://example.com/a.yaml?raw=true"))
+	assert.False(t, byExt.Matches("/specs/a.json"))
+
+	byPattern := &FileTransformer{Pattern: "*.enc.yaml", Transform: noop}
+	assert.True(t, byPattern.Matches("/specs/secret.enc.yaml"))
+	assert.False(t, byPattern.Matches("/specs/secret.yaml"))
+
+	byPath := &FileTransformer{Pattern: "/specs/secrets/*", Transform: noop}
+	assert.True(t, byPath.Matches("/specs/secrets/a.yaml"))
+	assert.False(t, byPath.Matches("/specs/a.yaml"))
+}
+
+func TestApplyFileTransformers(t *testing.T) {
+	upper := &FileTransformer{
+		Extensions: []string{".yaml"},
+		Transform: func(_ string, data []byte) ([]byte, error) {
+			return []byte(strings.ToUpper(string(data))), nil
+		},
+	}
+	suffix := &FileTransformer{
+		Transform: func(_ string, data []byte) ([]byte, error) {
+			return append(data, '!'), nil
+		},
+	}
+
+	out, err := ApplyFileTransformers([]*FileTransformer{StripBOMTransformer, upper, suffix}, "a.yaml",
+		[]byte("\xef\xbb\xbfhello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "HELLO!", string(out))
+
+	out, err = ApplyFileTransformers([]*FileTransformer{upper, suffix}, "a.json", []byte("hello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "hello!", string(out))
+
+	failing := &FileTransformer{
+		Transform: func(_ string, _ []byte) ([]byte, error) {
+			return nil, errors.New("boom")
+		},
+	}
+	_, err = ApplyFileTransformers([]*FileTransformer{failing, suffix}, "a.yaml", []byte("hello"))
+	assert.EqualError(t, err, "file transformer failed to transform 'a.yaml': boom")
+}
@@ -0,0 +1,207 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// SuccessAction represents a high-level Arazzo Success Action Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#success-action-object
+type SuccessAction struct {
+	Name       string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	Type       string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	WorkflowId string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	StepId     string                              `json:"stepId,omitempty" yaml:"stepId,omitempty"`
+	Criteria   []*Criterion                        `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+	Reference  string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.SuccessAction
+}
+
+// NewSuccessAction creates a new high-level SuccessAction instance from a low-level one.
+func NewSuccessAction(action *low.SuccessAction) *SuccessAction {
+	s := new(SuccessAction)
+	s.low = action
+	if !action.Name.IsEmpty() {
+		s.Name = action.Name.Value
+	}
+	if !action.Type.IsEmpty() {
+		s.Type = action.Type.Value
+	}
+	if !action.WorkflowId.IsEmpty() {
+		s.WorkflowId = action.WorkflowId.Value
+	}
+	if !action.StepId.IsEmpty() {
+		s.StepId = action.StepId.Value
+	}
+	if !action.Criteria.IsEmpty() {
+		items := make([]*Criterion, 0, len(action.Criteria.Value))
+		for _, v := range action.Criteria.Value {
+			items = append(items, NewCriterion(v.Value))
+		}
+		s.Criteria = items
+	}
+	if !action.Reference.IsEmpty() {
+		s.Reference = action.Reference.Value
+	}
+	s.Extensions = high.ExtractExtensions(action.Extensions)
+	return s
+}
+
+// GoLow returns the low-level SuccessAction instance used to create the high-level one.
+func (s *SuccessAction) GoLow() *low.SuccessAction {
+	return s.low
+}
+
+// GoLowUntyped returns the low-level SuccessAction instance with no type.
+func (s *SuccessAction) GoLowUntyped() any {
+	return s.low
+}
+
+// Render returns a YAML representation of the SuccessAction object as a byte slice.
+func (s *SuccessAction) Render() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the SuccessAction object.
+func (s *SuccessAction) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if s.Reference != "" {
+		m.Set("reference", s.Reference)
+	}
+	if s.Name != "" {
+		m.Set("name", s.Name)
+	}
+	if s.Type != "" {
+		m.Set("type", s.Type)
+	}
+	if s.WorkflowId != "" {
+		m.Set("workflowId", s.WorkflowId)
+	}
+	if s.StepId != "" {
+		m.Set("stepId", s.StepId)
+	}
+	if len(s.Criteria) > 0 {
+		m.Set("criteria", s.Criteria)
+	}
+	for pair := s.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
+
+// IsReusable returns true if the SuccessAction is a Reusable Object, referring to an action defined by the components.
+func (s *SuccessAction) IsReusable() bool {
+	return s.Reference != ""
+}
+
+// FailureAction represents a high-level Arazzo Failure Action Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#failure-action-object
+type FailureAction struct {
+	Name       string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	Type       string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	WorkflowId string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	StepId     string                              `json:"stepId,omitempty" yaml:"stepId,omitempty"`
+	RetryAfter *float64                            `json:"retryAfter,omitempty" yaml:"retryAfter,omitempty"`
+	RetryLimit *int64                              `json:"retryLimit,omitempty" yaml:"retryLimit,omitempty"`
+	Criteria   []*Criterion                        `json:"criteria,omitempty" yaml:"criteria,omitempty"`
+	Reference  string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.FailureAction
+}
+
+// NewFailureAction creates a new high-level FailureAction instance from a low-level one.
+func NewFailureAction(action *low.FailureAction) *FailureAction {
+	f := new(FailureAction)
+	f.low = action
+	if !action.Name.IsEmpty() {
+		f.Name = action.Name.Value
+	}
+	if !action.Type.IsEmpty() {
+		f.Type = action.Type.Value
+	}
+	if !action.WorkflowId.IsEmpty() {
+		f.WorkflowId = action.WorkflowId.Value
+	}
+	if !action.StepId.IsEmpty() {
+		f.StepId = action.StepId.Value
+	}
+	if !action.RetryAfter.IsEmpty() {
+		v := action.RetryAfter.Value
+		f.RetryAfter = &v
+	}
+	if !action.RetryLimit.IsEmpty() {
+		v := action.RetryLimit.Value
+		f.RetryLimit = &v
+	}
+	if !action.Criteria.IsEmpty() {
+		items := make([]*Criterion, 0, len(action.Criteria.Value))
+		for _, v := range action.Criteria.Value {
+			items = append(items, NewCriterion(v.Value))
+		}
+		f.Criteria = items
+	}
+	if !action.Reference.IsEmpty() {
+		f.Reference = action.Reference.Value
+	}
+	f.Extensions = high.ExtractExtensions(action.Extensions)
+	return f
+}
+
+// GoLow returns the low-level FailureAction instance used to create the high-level one.
+func (f *FailureAction) GoLow() *low.FailureAction {
+	return f.low
+}
+
+// GoLowUntyped returns the low-level FailureAction instance with no type.
+func (f *FailureAction) GoLowUntyped() any {
+	return f.low
+}
+
+// Render returns a YAML representation of the FailureAction object as a byte slice.
+func (f *FailureAction) Render() ([]byte, error) {
+	return yaml.Marshal(f)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the FailureAction object.
+func (f *FailureAction) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if f.Reference != "" {
+		m.Set("reference", f.Reference)
+	}
+	if f.Name != "" {
+		m.Set("name", f.Name)
+	}
+	if f.Type != "" {
+		m.Set("type", f.Type)
+	}
+	if f.WorkflowId != "" {
+		m.Set("workflowId", f.WorkflowId)
+	}
+	if f.StepId != "" {
+		m.Set("stepId", f.StepId)
+	}
+	if f.RetryAfter != nil {
+		m.Set("retryAfter", f.RetryAfter)
+	}
+	if f.RetryLimit != nil {
+		m.Set("retryLimit", f.RetryLimit)
+	}
+	if len(f.Criteria) > 0 {
+		m.Set("criteria", f.Criteria)
+	}
+	for pair := f.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
+
+// IsReusable returns true if the FailureAction is a Reusable Object, referring to an action defined by the components.
+func (f *FailureAction) IsReusable() bool {
+	return f.Reference != ""
+}
@@ -0,0 +1,93 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Arazzo represents a high-level Arazzo document.
+// https://spec.openapis.org/arazzo/v1.0.1#arazzo-description
+type Arazzo struct {
+	Arazzo             string                              `json:"arazzo,omitempty" yaml:"arazzo,omitempty"`
+	Info               *Info                               `json:"info,omitempty" yaml:"info,omitempty"`
+	SourceDescriptions []*SourceDescription                `json:"sourceDescriptions,omitempty" yaml:"sourceDescriptions,omitempty"`
+	Workflows          []*Workflow                         `json:"workflows,omitempty" yaml:"workflows,omitempty"`
+	Components         *Components                         `json:"components,omitempty" yaml:"components,omitempty"`
+	Extensions         *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                *low.Arazzo
+}
+
+// NewArazzo creates a new high-level Arazzo instance from a low-level one.
+func NewArazzo(arazzo *low.Arazzo) *Arazzo {
+	a := new(Arazzo)
+	a.low = arazzo
+	if !arazzo.Arazzo.IsEmpty() {
+		a.Arazzo = arazzo.Arazzo.Value
+	}
+	if !arazzo.Info.IsEmpty() {
+		a.Info = NewInfo(arazzo.Info.Value)
+	}
+	if !arazzo.SourceDescriptions.IsEmpty() {
+		items := make([]*SourceDescription, 0, len(arazzo.SourceDescriptions.Value))
+		for _, v := range arazzo.SourceDescriptions.Value {
+			items = append(items, NewSourceDescription(v.Value))
+		}
+		a.SourceDescriptions = items
+	}
+	if !arazzo.Workflows.IsEmpty() {
+		items := make([]*Workflow, 0, len(arazzo.Workflows.Value))
+		for _, v := range arazzo.Workflows.Value {
+			items = append(items, NewWorkflow(v.Value))
+		}
+		a.Workflows = items
+	}
+	if !arazzo.Components.IsEmpty() {
+		a.Components = NewComponents(arazzo.Components.Value)
+	}
+	a.Extensions = high.ExtractExtensions(arazzo.Extensions)
+	return a
+}
+
+// GoLow returns the low-level Arazzo instance used to create the high-level one.
+func (a *Arazzo) GoLow() *low.Arazzo {
+	return a.low
+}
+
+// GoLowUntyped returns the low-level Arazzo instance with no type.
+func (a *Arazzo) GoLowUntyped() any {
+	return a.low
+}
+
+// Render returns a YAML representation of the Arazzo object as a byte slice.
+func (a *Arazzo) Render() ([]byte, error) {
+	return yaml.Marshal(a)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Arazzo object.
+func (a *Arazzo) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if a.Arazzo != "" {
+		m.Set("arazzo", a.Arazzo)
+	}
+	if a.Info != nil {
+		m.Set("info", a.Info)
+	}
+	if len(a.SourceDescriptions) > 0 {
+		m.Set("sourceDescriptions", a.SourceDescriptions)
+	}
+	if len(a.Workflows) > 0 {
+		m.Set("workflows", a.Workflows)
+	}
+	if a.Components != nil {
+		m.Set("components", a.Components)
+	}
+	for pair := a.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
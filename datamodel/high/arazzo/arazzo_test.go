@@ -0,0 +1,137 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowarazzo "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const petWorkflows = `arazzo: 1.0.1
+info:
+  title: Adopt a pet
+  version: 1.0.0
+  x-team: pets
+sourceDescriptions:
+  - name: petStore
+    url: ./petstore.yaml
+    type: openapi
+workflows:
+  - workflowId: adoptPet
+    inputs:
+      type: object
+    dependsOn: [login]
+    steps:
+      - stepId: findPet
+        operationId: petStore.findPets
+        parameters:
+          - name: kind
+            in: query
+            value: $inputs.kind
+          - reference: $components.parameters.page
+            value: 2
+        successCriteria:
+          - condition: $statusCode == 200
+          - context: $response.body
+            condition: $.adopted
+            type:
+              type: jsonpath
+              version: draft-goessner-dispatch-jsonpath-00
+        onSuccess:
+          - reference: $components.successActions.done
+        onFailure:
+          - name: retry
+            type: retry
+            retryAfter: 1.5
+            retryLimit: 3
+        outputs:
+          petId: $response.body#/pets/0/id
+      - stepId: adoptPet
+        operationPath: '{$sourceDescriptions.petStore.url}#/paths/~1pets/post'
+        requestBody:
+          contentType: application/json
+          payload:
+            petId: ''
+          replacements:
+            - target: /petId
+              value: $steps.findPet.outputs.petId
+    outputs:
+      petId: $steps.findPet.outputs.petId
+components:
+  inputs:
+    login:
+      type: object
+  parameters:
+    page:
+      name: page
+      in: query
+      value: 1
+  successActions:
+    done:
+      name: done
+      type: end`
+
+func buildLowArazzo(t *testing.T, spec []byte) *lowarazzo.Arazzo {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal(spec, &node))
+	var a lowarazzo.Arazzo
+	require.NoError(t, low.BuildModel(node.Content[0], &a))
+	require.NoError(t, a.Build(context.Background(), nil, node.Content[0], nil))
+	return &a
+}
+
+func TestNewArazzo(t *testing.T) {
+	lowArazzo := buildLowArazzo(t, []byte(petWorkflows))
+	a := NewArazzo(lowArazzo)
+
+	assert.Equal(t, "1.0.1", a.Arazzo)
+	assert.Same(t, lowArazzo, a.GoLow())
+	assert.Same(t, lowArazzo, a.GoLowUntyped())
+	assert.Equal(t, "Adopt a pet", a.Info.Title)
+	assert.Equal(t, "pets", a.Info.Extensions.GetOrZero("x-team").Value)
+	assert.Equal(t, "./petstore.yaml", a.SourceDescriptions[0].URL)
+
+	workflow := a.Workflows[0]
+	assert.Equal(t, []string{"login"}, workflow.DependsOn)
+	assert.Equal(t, "$steps.findPet.outputs.petId", workflow.Outputs.GetOrZero("petId"))
+
+	find := workflow.Steps[0]
+	assert.Equal(t, 16, find.GoLow().RootNode.Line)
+	assert.False(t, find.Parameters[0].IsReusable())
+	assert.True(t, find.Parameters[1].IsReusable())
+	assert.Equal(t, "2", find.Parameters[1].Value.Value)
+	assert.Equal(t, "", find.SuccessCriteria[0].Type)
+	assert.Equal(t, "jsonpath", find.SuccessCriteria[1].Type)
+	assert.Equal(t, "draft-goessner-dispatch-jsonpath-00", find.SuccessCriteria[1].ExpressionType.Version)
+	assert.True(t, find.OnSuccess[0].IsReusable())
+	assert.False(t, find.OnFailure[0].IsReusable())
+	assert.Equal(t, 1.5, *find.OnFailure[0].RetryAfter)
+	assert.Equal(t, int64(3), *find.OnFailure[0].RetryLimit)
+
+	body := workflow.Steps[1].RequestBody
+	assert.Equal(t, "application/json", body.ContentType)
+	assert.Equal(t, "/petId", body.Replacements[0].Target)
+
+	assert.Equal(t, "object", a.Components.Inputs.GetOrZero("login").Content[1].Value)
+	assert.Equal(t, "page", a.Components.Parameters.GetOrZero("page").Name)
+	assert.Equal(t, "end", a.Components.SuccessActions.GetOrZero("done").Type)
+	assert.Nil(t, a.Components.FailureActions)
+}
+
+func TestArazzo_Render(t *testing.T) {
+	lowArazzo := buildLowArazzo(t, []byte(petWorkflows))
+
+	rendered, err := NewArazzo(lowArazzo).Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "        type:\n")
+
+	// rendering keeps everything, so the rendered document hashes the same as the original.
+	assert.Equal(t, lowArazzo.Hash(), buildLowArazzo(t, rendered).Hash())
+}
@@ -0,0 +1,79 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Components represents a high-level Arazzo Components Object.
+// https://spec.openapis.org/arazzo/v1.0.1#components-object
+type Components struct {
+	Inputs         *orderedmap.Map[string, *yaml.Node]     `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	Parameters     *orderedmap.Map[string, *Parameter]     `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	SuccessActions *orderedmap.Map[string, *SuccessAction] `json:"successActions,omitempty" yaml:"successActions,omitempty"`
+	FailureActions *orderedmap.Map[string, *FailureAction] `json:"failureActions,omitempty" yaml:"failureActions,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node]     `json:"-" yaml:"-"`
+	low            *low.Components
+}
+
+// NewComponents creates a new high-level Components instance from a low-level one.
+func NewComponents(components *low.Components) *Components {
+	c := new(Components)
+	c.low = components
+	if !components.Inputs.IsEmpty() {
+		c.Inputs = lowmodel.FromReferenceMap(components.Inputs.Value)
+	}
+	if !components.Parameters.IsEmpty() {
+		c.Parameters = lowmodel.FromReferenceMapWithFunc(components.Parameters.Value, NewParameter)
+	}
+	if !components.SuccessActions.IsEmpty() {
+		c.SuccessActions = lowmodel.FromReferenceMapWithFunc(components.SuccessActions.Value, NewSuccessAction)
+	}
+	if !components.FailureActions.IsEmpty() {
+		c.FailureActions = lowmodel.FromReferenceMapWithFunc(components.FailureActions.Value, NewFailureAction)
+	}
+	c.Extensions = high.ExtractExtensions(components.Extensions)
+	return c
+}
+
+// GoLow returns the low-level Components instance used to create the high-level one.
+func (c *Components) GoLow() *low.Components {
+	return c.low
+}
+
+// GoLowUntyped returns the low-level Components instance with no type.
+func (c *Components) GoLowUntyped() any {
+	return c.low
+}
+
+// Render returns a YAML representation of the Components object as a byte slice.
+func (c *Components) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Components object.
+func (c *Components) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if orderedmap.Len(c.Inputs) > 0 {
+		m.Set("inputs", c.Inputs)
+	}
+	if orderedmap.Len(c.Parameters) > 0 {
+		m.Set("parameters", c.Parameters)
+	}
+	if orderedmap.Len(c.SuccessActions) > 0 {
+		m.Set("successActions", c.SuccessActions)
+	}
+	if orderedmap.Len(c.FailureActions) > 0 {
+		m.Set("failureActions", c.FailureActions)
+	}
+	for pair := c.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Criterion represents a high-level Arazzo Criterion Object. When the type of the criterion is a Criterion
+// Expression Type Object, it is available as the ExpressionType, and the Type holds the type of the expression.
+// https://spec.openapis.org/arazzo/v1.0.1#criterion-object
+type Criterion struct {
+	Context        string                              `json:"context,omitempty" yaml:"context,omitempty"`
+	Condition      string                              `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Type           string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	ExpressionType *CriterionExpressionType            `json:"-" yaml:"-"` // set when the type is an object.
+	Extensions     *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low            *low.Criterion
+}
+
+// NewCriterion creates a new high-level Criterion instance from a low-level one.
+func NewCriterion(criterion *low.Criterion) *Criterion {
+	c := new(Criterion)
+	c.low = criterion
+	if !criterion.Context.IsEmpty() {
+		c.Context = criterion.Context.Value
+	}
+	if !criterion.Condition.IsEmpty() {
+		c.Condition = criterion.Condition.Value
+	}
+	if !criterion.Type.IsEmpty() {
+		c.Type = criterion.Type.Value
+	}
+	if !criterion.ExpressionType.IsEmpty() {
+		c.ExpressionType = NewCriterionExpressionType(criterion.ExpressionType.Value)
+		c.Type = c.ExpressionType.Type
+	}
+	c.Extensions = high.ExtractExtensions(criterion.Extensions)
+	return c
+}
+
+// GoLow returns the low-level Criterion instance used to create the high-level one.
+func (c *Criterion) GoLow() *low.Criterion {
+	return c.low
+}
+
+// GoLowUntyped returns the low-level Criterion instance with no type.
+func (c *Criterion) GoLowUntyped() any {
+	return c.low
+}
+
+// Render returns a YAML representation of the Criterion object as a byte slice.
+func (c *Criterion) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Criterion object.
+func (c *Criterion) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if c.Context != "" {
+		m.Set("context", c.Context)
+	}
+	if c.Condition != "" {
+		m.Set("condition", c.Condition)
+	}
+	if c.ExpressionType != nil {
+		m.Set("type", c.ExpressionType)
+	} else if c.Type != "" {
+		m.Set("type", c.Type)
+	}
+	for pair := c.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
+
+// CriterionExpressionType represents a high-level Arazzo Criterion Expression Type Object.
+// https://spec.openapis.org/arazzo/v1.0.1#criterion-expression-type-object
+type CriterionExpressionType struct {
+	Type       string                              `json:"type,omitempty" yaml:"type,omitempty"`
+	Version    string                              `json:"version,omitempty" yaml:"version,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.CriterionExpressionType
+}
+
+// NewCriterionExpressionType creates a new high-level CriterionExpressionType instance from a low-level one.
+func NewCriterionExpressionType(expressionType *low.CriterionExpressionType) *CriterionExpressionType {
+	c := new(CriterionExpressionType)
+	c.low = expressionType
+	if !expressionType.Type.IsEmpty() {
+		c.Type = expressionType.Type.Value
+	}
+	if !expressionType.Version.IsEmpty() {
+		c.Version = expressionType.Version.Value
+	}
+	c.Extensions = high.ExtractExtensions(expressionType.Extensions)
+	return c
+}
+
+// GoLow returns the low-level CriterionExpressionType instance used to create the high-level one.
+func (c *CriterionExpressionType) GoLow() *low.CriterionExpressionType {
+	return c.low
+}
+
+// GoLowUntyped returns the low-level CriterionExpressionType instance with no type.
+func (c *CriterionExpressionType) GoLowUntyped() any {
+	return c.low
+}
+
+// Render returns a YAML representation of the CriterionExpressionType object as a byte slice.
+func (c *CriterionExpressionType) Render() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the CriterionExpressionType object.
+func (c *CriterionExpressionType) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if c.Type != "" {
+		m.Set("type", c.Type)
+	}
+	if c.Version != "" {
+		m.Set("version", c.Version)
+	}
+	for pair := c.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
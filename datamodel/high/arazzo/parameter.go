@@ -0,0 +1,83 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Parameter represents a high-level Arazzo Parameter Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#parameter-object
+type Parameter struct {
+	Name       string                              `json:"name,omitempty" yaml:"name,omitempty"`
+	In         string                              `json:"in,omitempty" yaml:"in,omitempty"`
+	Value      *yaml.Node                          `json:"value,omitempty" yaml:"value,omitempty"`
+	Reference  string                              `json:"reference,omitempty" yaml:"reference,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.Parameter
+}
+
+// NewParameter creates a new high-level Parameter instance from a low-level one.
+func NewParameter(param *low.Parameter) *Parameter {
+	p := new(Parameter)
+	p.low = param
+	if !param.Name.IsEmpty() {
+		p.Name = param.Name.Value
+	}
+	if !param.In.IsEmpty() {
+		p.In = param.In.Value
+	}
+	if !param.Value.IsEmpty() {
+		p.Value = param.Value.Value
+	}
+	if !param.Reference.IsEmpty() {
+		p.Reference = param.Reference.Value
+	}
+	p.Extensions = high.ExtractExtensions(param.Extensions)
+	return p
+}
+
+// GoLow returns the low-level Parameter instance used to create the high-level one.
+func (p *Parameter) GoLow() *low.Parameter {
+	return p.low
+}
+
+// GoLowUntyped returns the low-level Parameter instance with no type.
+func (p *Parameter) GoLowUntyped() any {
+	return p.low
+}
+
+// Render returns a YAML representation of the Parameter object as a byte slice.
+func (p *Parameter) Render() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Parameter object.
+func (p *Parameter) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if p.Reference != "" {
+		m.Set("reference", p.Reference)
+	}
+	if p.Name != "" {
+		m.Set("name", p.Name)
+	}
+	if p.In != "" {
+		m.Set("in", p.In)
+	}
+	if p.Value != nil {
+		m.Set("value", p.Value)
+	}
+	for pair := p.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
+
+// IsReusable returns true if the Parameter is a Reusable Object, referring to a parameter defined by the components.
+func (p *Parameter) IsReusable() bool {
+	return p.Reference != ""
+}
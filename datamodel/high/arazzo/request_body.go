@@ -0,0 +1,128 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// RequestBody represents a high-level Arazzo Request Body Object.
+// https://spec.openapis.org/arazzo/v1.0.1#request-body-object
+type RequestBody struct {
+	ContentType  string                              `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	Payload      *yaml.Node                          `json:"payload,omitempty" yaml:"payload,omitempty"`
+	Replacements []*PayloadReplacement               `json:"replacements,omitempty" yaml:"replacements,omitempty"`
+	Extensions   *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low          *low.RequestBody
+}
+
+// NewRequestBody creates a new high-level RequestBody instance from a low-level one.
+func NewRequestBody(body *low.RequestBody) *RequestBody {
+	r := new(RequestBody)
+	r.low = body
+	if !body.ContentType.IsEmpty() {
+		r.ContentType = body.ContentType.Value
+	}
+	if !body.Payload.IsEmpty() {
+		r.Payload = body.Payload.Value
+	}
+	if !body.Replacements.IsEmpty() {
+		items := make([]*PayloadReplacement, 0, len(body.Replacements.Value))
+		for _, v := range body.Replacements.Value {
+			items = append(items, NewPayloadReplacement(v.Value))
+		}
+		r.Replacements = items
+	}
+	r.Extensions = high.ExtractExtensions(body.Extensions)
+	return r
+}
+
+// GoLow returns the low-level RequestBody instance used to create the high-level one.
+func (r *RequestBody) GoLow() *low.RequestBody {
+	return r.low
+}
+
+// GoLowUntyped returns the low-level RequestBody instance with no type.
+func (r *RequestBody) GoLowUntyped() any {
+	return r.low
+}
+
+// Render returns a YAML representation of the RequestBody object as a byte slice.
+func (r *RequestBody) Render() ([]byte, error) {
+	return yaml.Marshal(r)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the RequestBody object.
+func (r *RequestBody) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if r.ContentType != "" {
+		m.Set("contentType", r.ContentType)
+	}
+	if r.Payload != nil {
+		m.Set("payload", r.Payload)
+	}
+	if len(r.Replacements) > 0 {
+		m.Set("replacements", r.Replacements)
+	}
+	for pair := r.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
+
+// PayloadReplacement represents a high-level Arazzo Payload Replacement Object.
+// https://spec.openapis.org/arazzo/v1.0.1#payload-replacement-object
+type PayloadReplacement struct {
+	Target     string                              `json:"target,omitempty" yaml:"target,omitempty"`
+	Value      *yaml.Node                          `json:"value,omitempty" yaml:"value,omitempty"`
+	Extensions *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low        *low.PayloadReplacement
+}
+
+// NewPayloadReplacement creates a new high-level PayloadReplacement instance from a low-level one.
+func NewPayloadReplacement(replacement *low.PayloadReplacement) *PayloadReplacement {
+	p := new(PayloadReplacement)
+	p.low = replacement
+	if !replacement.Target.IsEmpty() {
+		p.Target = replacement.Target.Value
+	}
+	if !replacement.Value.IsEmpty() {
+		p.Value = replacement.Value.Value
+	}
+	p.Extensions = high.ExtractExtensions(replacement.Extensions)
+	return p
+}
+
+// GoLow returns the low-level PayloadReplacement instance used to create the high-level one.
+func (p *PayloadReplacement) GoLow() *low.PayloadReplacement {
+	return p.low
+}
+
+// GoLowUntyped returns the low-level PayloadReplacement instance with no type.
+func (p *PayloadReplacement) GoLowUntyped() any {
+	return p.low
+}
+
+// Render returns a YAML representation of the PayloadReplacement object as a byte slice.
+func (p *PayloadReplacement) Render() ([]byte, error) {
+	return yaml.Marshal(p)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the PayloadReplacement object.
+func (p *PayloadReplacement) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if p.Target != "" {
+		m.Set("target", p.Target)
+	}
+	if p.Value != nil {
+		m.Set("value", p.Value)
+	}
+	for pair := p.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
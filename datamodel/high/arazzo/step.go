@@ -0,0 +1,144 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Step represents a high-level Arazzo Step Object.
+// https://spec.openapis.org/arazzo/v1.0.1#step-object
+type Step struct {
+	StepId          string                              `json:"stepId,omitempty" yaml:"stepId,omitempty"`
+	Description     string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationId     string                              `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	OperationPath   string                              `json:"operationPath,omitempty" yaml:"operationPath,omitempty"`
+	WorkflowId      string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	Parameters      []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody     *RequestBody                        `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	SuccessCriteria []*Criterion                        `json:"successCriteria,omitempty" yaml:"successCriteria,omitempty"`
+	OnSuccess       []*SuccessAction                    `json:"onSuccess,omitempty" yaml:"onSuccess,omitempty"`
+	OnFailure       []*FailureAction                    `json:"onFailure,omitempty" yaml:"onFailure,omitempty"`
+	Outputs         *orderedmap.Map[string, string]     `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Extensions      *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low             *low.Step
+}
+
+// NewStep creates a new high-level Step instance from a low-level one.
+func NewStep(step *low.Step) *Step {
+	s := new(Step)
+	s.low = step
+	if !step.StepId.IsEmpty() {
+		s.StepId = step.StepId.Value
+	}
+	if !step.Description.IsEmpty() {
+		s.Description = step.Description.Value
+	}
+	if !step.OperationId.IsEmpty() {
+		s.OperationId = step.OperationId.Value
+	}
+	if !step.OperationPath.IsEmpty() {
+		s.OperationPath = step.OperationPath.Value
+	}
+	if !step.WorkflowId.IsEmpty() {
+		s.WorkflowId = step.WorkflowId.Value
+	}
+	if !step.Parameters.IsEmpty() {
+		items := make([]*Parameter, 0, len(step.Parameters.Value))
+		for _, v := range step.Parameters.Value {
+			items = append(items, NewParameter(v.Value))
+		}
+		s.Parameters = items
+	}
+	if !step.RequestBody.IsEmpty() {
+		s.RequestBody = NewRequestBody(step.RequestBody.Value)
+	}
+	if !step.SuccessCriteria.IsEmpty() {
+		items := make([]*Criterion, 0, len(step.SuccessCriteria.Value))
+		for _, v := range step.SuccessCriteria.Value {
+			items = append(items, NewCriterion(v.Value))
+		}
+		s.SuccessCriteria = items
+	}
+	if !step.OnSuccess.IsEmpty() {
+		items := make([]*SuccessAction, 0, len(step.OnSuccess.Value))
+		for _, v := range step.OnSuccess.Value {
+			items = append(items, NewSuccessAction(v.Value))
+		}
+		s.OnSuccess = items
+	}
+	if !step.OnFailure.IsEmpty() {
+		items := make([]*FailureAction, 0, len(step.OnFailure.Value))
+		for _, v := range step.OnFailure.Value {
+			items = append(items, NewFailureAction(v.Value))
+		}
+		s.OnFailure = items
+	}
+	if !step.Outputs.IsEmpty() {
+		s.Outputs = lowmodel.FromReferenceMap(step.Outputs.Value)
+	}
+	s.Extensions = high.ExtractExtensions(step.Extensions)
+	return s
+}
+
+// GoLow returns the low-level Step instance used to create the high-level one.
+func (s *Step) GoLow() *low.Step {
+	return s.low
+}
+
+// GoLowUntyped returns the low-level Step instance with no type.
+func (s *Step) GoLowUntyped() any {
+	return s.low
+}
+
+// Render returns a YAML representation of the Step object as a byte slice.
+func (s *Step) Render() ([]byte, error) {
+	return yaml.Marshal(s)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Step object.
+func (s *Step) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if s.StepId != "" {
+		m.Set("stepId", s.StepId)
+	}
+	if s.Description != "" {
+		m.Set("description", s.Description)
+	}
+	if s.OperationId != "" {
+		m.Set("operationId", s.OperationId)
+	}
+	if s.OperationPath != "" {
+		m.Set("operationPath", s.OperationPath)
+	}
+	if s.WorkflowId != "" {
+		m.Set("workflowId", s.WorkflowId)
+	}
+	if len(s.Parameters) > 0 {
+		m.Set("parameters", s.Parameters)
+	}
+	if s.RequestBody != nil {
+		m.Set("requestBody", s.RequestBody)
+	}
+	if len(s.SuccessCriteria) > 0 {
+		m.Set("successCriteria", s.SuccessCriteria)
+	}
+	if len(s.OnSuccess) > 0 {
+		m.Set("onSuccess", s.OnSuccess)
+	}
+	if len(s.OnFailure) > 0 {
+		m.Set("onFailure", s.OnFailure)
+	}
+	if orderedmap.Len(s.Outputs) > 0 {
+		m.Set("outputs", s.Outputs)
+	}
+	for pair := s.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
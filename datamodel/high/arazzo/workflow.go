@@ -0,0 +1,141 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/arazzo"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// Workflow represents a high-level Arazzo Workflow Object.
+// https://spec.openapis.org/arazzo/v1.0.1#workflow-object
+type Workflow struct {
+	WorkflowId     string                              `json:"workflowId,omitempty" yaml:"workflowId,omitempty"`
+	Summary        string                              `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description    string                              `json:"description,omitempty" yaml:"description,omitempty"`
+	Inputs         *yaml.Node                          `json:"inputs,omitempty" yaml:"inputs,omitempty"`
+	DependsOn      []string                            `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty"`
+	Steps          []*Step                             `json:"steps,omitempty" yaml:"steps,omitempty"`
+	SuccessActions []*SuccessAction                    `json:"successActions,omitempty" yaml:"successActions,omitempty"`
+	FailureActions []*FailureAction                    `json:"failureActions,omitempty" yaml:"failureActions,omitempty"`
+	Outputs        *orderedmap.Map[string, string]     `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	Parameters     []*Parameter                        `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Extensions     *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low            *low.Workflow
+}
+
+// NewWorkflow creates a new high-level Workflow instance from a low-level one.
+func NewWorkflow(workflow *low.Workflow) *Workflow {
+	w := new(Workflow)
+	w.low = workflow
+	if !workflow.WorkflowId.IsEmpty() {
+		w.WorkflowId = workflow.WorkflowId.Value
+	}
+	if !workflow.Summary.IsEmpty() {
+		w.Summary = workflow.Summary.Value
+	}
+	if !workflow.Description.IsEmpty() {
+		w.Description = workflow.Description.Value
+	}
+	if !workflow.Inputs.IsEmpty() {
+		w.Inputs = workflow.Inputs.Value
+	}
+	if !workflow.DependsOn.IsEmpty() {
+		items := make([]string, 0, len(workflow.DependsOn.Value))
+		for _, v := range workflow.DependsOn.Value {
+			items = append(items, v.Value)
+		}
+		w.DependsOn = items
+	}
+	if !workflow.Steps.IsEmpty() {
+		items := make([]*Step, 0, len(workflow.Steps.Value))
+		for _, v := range workflow.Steps.Value {
+			items = append(items, NewStep(v.Value))
+		}
+		w.Steps = items
+	}
+	if !workflow.SuccessActions.IsEmpty() {
+		items := make([]*SuccessAction, 0, len(workflow.SuccessActions.Value))
+		for _, v := range workflow.SuccessActions.Value {
+			items = append(items, NewSuccessAction(v.Value))
+		}
+		w.SuccessActions = items
+	}
+	if !workflow.FailureActions.IsEmpty() {
+		items := make([]*FailureAction, 0, len(workflow.FailureActions.Value))
+		for _, v := range workflow.FailureActions.Value {
+			items = append(items, NewFailureAction(v.Value))
+		}
+		w.FailureActions = items
+	}
+	if !workflow.Outputs.IsEmpty() {
+		w.Outputs = lowmodel.FromReferenceMap(workflow.Outputs.Value)
+	}
+	if !workflow.Parameters.IsEmpty() {
+		items := make([]*Parameter, 0, len(workflow.Parameters.Value))
+		for _, v := range workflow.Parameters.Value {
+			items = append(items, NewParameter(v.Value))
+		}
+		w.Parameters = items
+	}
+	w.Extensions = high.ExtractExtensions(workflow.Extensions)
+	return w
+}
+
+// GoLow returns the low-level Workflow instance used to create the high-level one.
+func (w *Workflow) GoLow() *low.Workflow {
+	return w.low
+}
+
+// GoLowUntyped returns the low-level Workflow instance with no type.
+func (w *Workflow) GoLowUntyped() any {
+	return w.low
+}
+
+// Render returns a YAML representation of the Workflow object as a byte slice.
+func (w *Workflow) Render() ([]byte, error) {
+	return yaml.Marshal(w)
+}
+
+// MarshalYAML creates a ready to render YAML representation of the Workflow object.
+func (w *Workflow) MarshalYAML() (any, error) {
+	m := orderedmap.New[string, any]()
+	if w.WorkflowId != "" {
+		m.Set("workflowId", w.WorkflowId)
+	}
+	if w.Summary != "" {
+		m.Set("summary", w.Summary)
+	}
+	if w.Description != "" {
+		m.Set("description", w.Description)
+	}
+	if w.Inputs != nil {
+		m.Set("inputs", w.Inputs)
+	}
+	if len(w.DependsOn) > 0 {
+		m.Set("dependsOn", w.DependsOn)
+	}
+	if len(w.Steps) > 0 {
+		m.Set("steps", w.Steps)
+	}
+	if len(w.SuccessActions) > 0 {
+		m.Set("successActions", w.SuccessActions)
+	}
+	if len(w.FailureActions) > 0 {
+		m.Set("failureActions", w.FailureActions)
+	}
+	if orderedmap.Len(w.Outputs) > 0 {
+		m.Set("outputs", w.Outputs)
+	}
+	if len(w.Parameters) > 0 {
+		m.Set("parameters", w.Parameters)
+	}
+	for pair := w.Extensions.First(); pair != nil; pair = pair.Next() {
+		m.Set(pair.Key(), pair.Value())
+	}
+	return m, nil
+}
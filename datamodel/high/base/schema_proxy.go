@@ -430,6 +430,13 @@ func (sp *SchemaProxy) marshalYAMLInlineInternal(ctx *InlineRenderContext) (inte
 		}
 	}
 
+	// references the rolodex is configured to keep (for example, by the bundler) are not inlined.
+	if sp.IsReference() && sp.schema != nil && sp.schema.Value != nil {
+		if preserved := high.PreservedReference(sp.schema.Value.GetIndex(), sp.GetReference()); preserved != nil {
+			return preserved, nil
+		}
+	}
+
 	// In bundling mode, preserve local component refs that point to schemas in the SAME document.
 	// Only inline refs that point to schemas from EXTERNAL files.
 	// Outside of bundling mode (direct MarshalYAMLInline calls), inline everything.
@@ -555,7 +562,11 @@ func (sp *SchemaProxy) marshalYAMLInlineInternal(ctx *InlineRenderContext) (inte
 		// and cycle detection context is propagated.
 		// Schema.MarshalYAMLInlineWithContext sets preserveReference on OneOf/AnyOf items when
 		// a discriminator is present, which is required for proper bundling.
-		return s.MarshalYAMLInlineWithContext(ctx)
+		rendered, rErr := s.MarshalYAMLInlineWithContext(ctx)
+		if rErr == nil && sp.IsReference() && sp.schema != nil && sp.schema.Value != nil {
+			high.AnnotateInlinedReference(sp.schema.Value.GetIndex(), sp.GetReference(), rendered)
+		}
+		return rendered, rErr
 	}
 	return nil, errors.New("unable to render schema")
 }
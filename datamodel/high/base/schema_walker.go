@@ -0,0 +1,177 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// SkipSchema can be returned by a SchemaVisitor to skip the schemas nested inside the schema being visited. It is
+// not returned by WalkSchema.
+var SkipSchema = errors.New("skip this schema")
+
+// SchemaWalkNode is passed to a SchemaVisitor for every schema found by WalkSchema.
+type SchemaWalkNode struct {
+	// Proxy is the SchemaProxy that was walked to find the schema.
+	Proxy *SchemaProxy
+
+	// Schema is the schema itself, it is nil if the schema could not be built, see Proxy.GetBuildError.
+	Schema *Schema
+
+	// Path holds the keywords (and property names or indexes) followed from the root schema to this one. For example,
+	// ["properties", "pets", "items"]. The root schema has an empty path.
+	Path []string
+
+	// RefChain holds every reference followed from the root schema to this one, in order. The last reference is
+	// the one that points to this schema, if Proxy is a reference.
+	RefChain []string
+
+	// Depth is the number of schemas between the root and this schema, the root schema has a depth of zero.
+	Depth int
+}
+
+// JSONPointer returns the path of the schema as a JSON pointer, relative to the root schema.
+func (n *SchemaWalkNode) JSONPointer() string {
+	var b strings.Builder
+	for _, segment := range n.Path {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// SchemaVisitor is called by WalkSchema for every schema. If the visitor returns SkipSchema, then the schemas
+// nested inside the schema are not walked. Any other error stops the walk, and is returned by WalkSchema.
+type SchemaVisitor func(node *SchemaWalkNode) error
+
+// WalkSchema walks a schema, and every schema nested inside it, calling the visitor for each one. Properties,
+// pattern properties, items, prefix items, composition keywords (allOf, anyOf, oneOf, not), conditionals
+// (if, then, else), dependent schemas, additional and unevaluated properties and items, contains, property names
+// and content schemas are all walked, in that order.
+//
+// Each schema is only visited once, even if it is referenced from multiple places, or is part of a circular
+// reference. The first path used to reach a schema (depth first) is the one reported to the visitor. Schemas are
+// identified by the YAML node they are built from, so references are resolved to the schema they point to.
+func WalkSchema(schema *SchemaProxy, visitor SchemaVisitor) error {
+	if schema == nil || visitor == nil {
+		return nil
+	}
+	w := &schemaWalker{visitor: visitor, seen: make(map[any]struct{})}
+	return w.walk(schema, nil, nil, 0)
+}
+
+type schemaWalker struct {
+	visitor SchemaVisitor
+	seen    map[any]struct{}
+}
+
+// schemaIdentity returns a key that uniquely identifies the schema a proxy will build. References are resolved to
+// the node they point to, so every reference to the same schema has the same identity.
+func schemaIdentity(sp *SchemaProxy) any {
+	if l := sp.GoLow(); l != nil && l.GetValueNode() != nil {
+		// nested schemas are usually built from the node already resolved by the low-level schema, so only a
+		// value node that is still a reference needs to be located.
+		node := l.GetValueNode()
+		if isRef, _, _ := utils.IsNodeRefValue(node); isRef {
+			if l.GetIndex() != nil {
+				if resolved, _, _, _ := low.LocateRefNodeWithContext(l.GetContext(), node, l.GetIndex()); resolved != nil {
+					return resolved
+				}
+			}
+			// an unresolvable reference is identified by the reference itself.
+			return l.GetReference()
+		}
+		return node
+	}
+	if sp.IsReference() {
+		return sp.GetReference()
+	}
+	return sp
+}
+
+func (w *schemaWalker) walk(sp *SchemaProxy, path, refs []string, depth int) error {
+	if sp == nil {
+		return nil
+	}
+	id := schemaIdentity(sp)
+	if _, ok := w.seen[id]; ok {
+		return nil
+	}
+	w.seen[id] = struct{}{}
+
+	if sp.IsReference() {
+		refs = append(refs[:len(refs):len(refs)], sp.GetReference())
+	}
+	node := &SchemaWalkNode{Proxy: sp, Schema: sp.Schema(), Path: path, RefChain: refs, Depth: depth}
+	if err := w.visitor(node); err != nil {
+		if errors.Is(err, SkipSchema) {
+			return nil
+		}
+		return err
+	}
+	s := node.Schema
+	if s == nil {
+		return nil
+	}
+
+	child := func(proxy *SchemaProxy, segments ...string) error {
+		childPath := append(path[:len(path):len(path)], segments...)
+		return w.walk(proxy, childPath, refs, depth+1)
+	}
+	named := func(keyword string, m *orderedmap.Map[string, *SchemaProxy]) error {
+		for name, proxy := range m.FromOldest() {
+			if err := child(proxy, keyword, name); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	list := func(keyword string, proxies []*SchemaProxy) error {
+		for i, proxy := range proxies {
+			if err := child(proxy, keyword, strconv.Itoa(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	dynamic := func(keyword string, dv *DynamicValue[*SchemaProxy, bool]) error {
+		if dv != nil && dv.IsA() {
+			return child(dv.A, keyword)
+		}
+		return nil
+	}
+
+	steps := []func() error{
+		func() error { return named("properties", s.Properties) },
+		func() error { return named("patternProperties", s.PatternProperties) },
+		func() error { return dynamic("items", s.Items) },
+		func() error { return list("prefixItems", s.PrefixItems) },
+		func() error { return list("allOf", s.AllOf) },
+		func() error { return list("anyOf", s.AnyOf) },
+		func() error { return list("oneOf", s.OneOf) },
+		func() error { return child(s.Not, "not") },
+		func() error { return child(s.If, "if") },
+		func() error { return child(s.Then, "then") },
+		func() error { return child(s.Else, "else") },
+		func() error { return named("dependentSchemas", s.DependentSchemas) },
+		func() error { return dynamic("additionalProperties", s.AdditionalProperties) },
+		func() error { return dynamic("unevaluatedProperties", s.UnevaluatedProperties) },
+		func() error { return child(s.UnevaluatedItems, "unevaluatedItems") },
+		func() error { return child(s.Contains, "contains") },
+		func() error { return child(s.PropertyNames, "propertyNames") },
+		func() error { return child(s.ContentSchema, "contentSchema") },
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package base
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const walkerSpec = `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+        owner:
+          $ref: '#/components/schemas/Person'
+        tags:
+          type: array
+          items:
+            $ref: '#/components/schemas/Tag'
+    Person:
+      type: object
+      properties:
+        pets:
+          type: array
+          items:
+            $ref: '#/components/schemas/Pet'
+        best~friend/forever:
+          $ref: '#/components/schemas/Person'
+      additionalProperties:
+        $ref: '#/components/schemas/Tag'
+    Tag:
+      oneOf:
+        - type: string
+        - type: integer
+      not:
+        const: nope`
+
+func walkerProxy(t *testing.T, ref string) *SchemaProxy {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(walkerSpec), &root))
+	cfg := index.CreateOpenAPIIndexConfig()
+	idx := index.NewSpecIndexWithConfig(&root, cfg)
+	resolver := index.NewResolver(idx)
+	resolver.CheckForCircularReferences()
+
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(`$ref: '`+ref+`'`), &node))
+	lowProxy := new(lowbase.SchemaProxy)
+	require.NoError(t, lowProxy.Build(context.Background(), nil, node.Content[0], idx))
+	return NewSchemaProxy(&low.NodeReference[*lowbase.SchemaProxy]{Value: lowProxy})
+}
+
+func TestWalkSchema_Circular(t *testing.T) {
+	var pointers []string
+	var chains []string
+	err := WalkSchema(walkerProxy(t, "#/components/schemas/Pet"), func(n *SchemaWalkNode) error {
+		require.NotNil(t, n.Schema)
+		assert.Equal(t, len(n.Path) > 0, n.Depth > 0)
+		pointers = append(pointers, n.JSONPointer())
+		chains = append(chains, strings.Join(n.RefChain, " > "))
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"",
+		"/properties/name",
+		"/properties/owner",
+		"/properties/owner/properties/pets",
+		"/properties/owner/additionalProperties",
+		"/properties/owner/additionalProperties/oneOf/0",
+		"/properties/owner/additionalProperties/oneOf/1",
+		"/properties/owner/additionalProperties/not",
+		"/properties/tags",
+	}, pointers)
+
+	assert.Equal(t, "#/components/schemas/Pet", chains[0])
+	assert.Equal(t, "#/components/schemas/Pet > #/components/schemas/Person", chains[2])
+	assert.Equal(t, "#/components/schemas/Pet > #/components/schemas/Person > #/components/schemas/Tag", chains[5])
+}
+
+func TestWalkSchema_JSONPointerEscaping(t *testing.T) {
+	n := &SchemaWalkNode{Path: []string{"properties", "best~friend/forever"}}
+	assert.Equal(t, "/properties/best~0friend~1forever", n.JSONPointer())
+}
+
+func TestWalkSchema_SkipAndStop(t *testing.T) {
+	var visited []string
+	err := WalkSchema(walkerProxy(t, "#/components/schemas/Person"), func(n *SchemaWalkNode) error {
+		visited = append(visited, n.JSONPointer())
+		if n.JSONPointer() == "/properties/pets" {
+			return SkipSchema
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"",
+		"/properties/pets",
+		"/additionalProperties",
+		"/additionalProperties/oneOf/0",
+		"/additionalProperties/oneOf/1",
+		"/additionalProperties/not",
+	}, visited)
+
+	stop := errors.New("stop")
+	count := 0
+	err = WalkSchema(walkerProxy(t, "#/components/schemas/Person"), func(n *SchemaWalkNode) error {
+		count++
+		if count == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, count)
+}
+
+func TestWalkSchema_Inline(t *testing.T) {
+	sp := CreateSchemaProxy(&Schema{
+		AllOf: []*SchemaProxy{CreateSchemaProxy(&Schema{Title: "a"})},
+		If:    CreateSchemaProxy(&Schema{Title: "if"}),
+		Items: &DynamicValue[*SchemaProxy, bool]{A: CreateSchemaProxy(&Schema{Title: "item"})},
+	})
+	var visited []string
+	require.NoError(t, WalkSchema(sp, func(n *SchemaWalkNode) error {
+		visited = append(visited, n.JSONPointer())
+		assert.Empty(t, n.RefChain)
+		return nil
+	}))
+	assert.Equal(t, []string{"", "/items", "/allOf/0", "/if"}, visited)
+	assert.NoError(t, WalkSchema(nil, nil))
+}
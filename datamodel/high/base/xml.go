@@ -6,6 +6,7 @@ package base
 import (
 	"log/slog"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	low "github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -50,9 +51,9 @@ func NewXML(xml *low.XML) *XML {
 		version := xml.GetIndex().GetConfig().SpecInfo.VersionNumeric
 		if version >= 3.2 && x.Attribute && x.NodeType == "" {
 			// log deprecation warning
-			logger := xml.GetIndex().GetConfig().Logger
-			if logger != nil {
-				logger.Warn("XML 'attribute' field is deprecated in OpenAPI 3.2+, use 'nodeType' instead",
+			config := xml.GetIndex().GetConfig()
+			if config.Logger != nil || config.SubsystemLoggers[datamodel.LogSubsystemBuilder] != nil {
+				config.GetSubsystemLogger(datamodel.LogSubsystemBuilder).Warn("XML 'attribute' field is deprecated in OpenAPI 3.2+, use 'nodeType' instead",
 					slog.String("name", x.Name))
 			}
 		}
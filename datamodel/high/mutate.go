@@ -0,0 +1,156 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ErrNoLowRootNode is returned by Mutate when the high-level object was not built from a low-level object that
+// has a root node, for example an object created by hand. Mutate the closest parent built from the document instead.
+var ErrNoLowRootNode = errors.New("unable to mutate, the object was not built from a yaml node")
+
+// Mutable is a high-level object that can be changed by Mutate. All high-level models that are built from a
+// low-level model with a root node meet this interface.
+type Mutable interface {
+	GoesLowUntyped
+	MarshalYAML() (interface{}, error)
+}
+
+// Mutate calls mutate with a high-level object, and then writes every change made to the object into the yaml
+// nodes of the low-level object it was built from. Changes are synthesized into the original node tree in place,
+// any part of the tree that was not changed is left alone, so key order, comments, quoting and formatting are kept.
+// New keys are added after the existing keys, and removed keys are deleted.
+//
+// Because the original nodes are updated, serializing the document (Document.Serialize) after a mutation only
+// changes the lines that were touched. The values held by the low-level model itself are not rebuilt.
+//
+// Objects added by the mutation (for example a new operation added to a path item) do not need a low-level
+// model, mutate their parent. If the object was built from a reference, the node that is changed is the node the
+// reference points to. If the object can not be rendered, nothing is written.
+func Mutate[T Mutable](object T, mutate func(T)) error {
+	hasRoot, ok := object.GoLowUntyped().(low.HasRootNode)
+	if !ok || hasRoot == nil || reflect.ValueOf(hasRoot).IsNil() {
+		return ErrNoLowRootNode
+	}
+	root := hasRoot.GetRootNode()
+	if root == nil {
+		return ErrNoLowRootNode
+	}
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+
+	before, err := renderMutable(object)
+	if err != nil {
+		return err
+	}
+	mutate(object)
+	after, err := renderMutable(object)
+	if err != nil {
+		return err
+	}
+	MergeNodeChanges(root, before, after)
+	return nil
+}
+
+func renderMutable(object Mutable) (*yaml.Node, error) {
+	rendered, err := object.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	node, _ := rendered.(*yaml.Node)
+	return node, nil
+}
+
+// MergeNodeChanges applies every difference between two renders of the same object (before and after a change)
+// to the target node tree, in place. Anything that did not change between the renders is left untouched in the
+// target, including anything in the target the renders do not contain at all.
+func MergeNodeChanges(target, before, after *yaml.Node) {
+	if target == nil || after == nil || utils.YAMLNodesEqual(before, after) {
+		return
+	}
+	if before == nil || target.Kind != after.Kind || before.Kind != after.Kind {
+		replaceNode(target, after)
+		return
+	}
+	switch after.Kind {
+	case yaml.MappingNode:
+		mergeMappingChanges(target, before, after)
+	case yaml.SequenceNode:
+		if len(target.Content) != len(after.Content) || len(before.Content) != len(after.Content) {
+			replaceNode(target, after)
+			return
+		}
+		for i := range after.Content {
+			MergeNodeChanges(target.Content[i], before.Content[i], after.Content[i])
+		}
+	case yaml.ScalarNode:
+		target.Value = after.Value
+		target.Tag = after.Tag
+		if target.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 &&
+			after.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			target.Style = after.Style
+		}
+	default:
+		replaceNode(target, after)
+	}
+}
+
+func mergeMappingChanges(target, before, after *yaml.Node) {
+	content := make([]*yaml.Node, 0, len(target.Content))
+	seen := make(map[string]struct{}, len(target.Content)/2)
+	for i := 0; i+1 < len(target.Content); i += 2 {
+		key := target.Content[i].Value
+		seen[key] = struct{}{}
+		a := mappingValue(after, key)
+		b := mappingValue(before, key)
+		switch {
+		case a != nil:
+			MergeNodeChanges(target.Content[i+1], b, a)
+			content = append(content, target.Content[i], target.Content[i+1])
+		case b != nil:
+			// rendered before the change, but not after, so it has been removed.
+		default:
+			// never rendered, so it's not part of the model, keep it.
+			content = append(content, target.Content[i], target.Content[i+1])
+		}
+	}
+	for i := 0; i+1 < len(after.Content); i += 2 {
+		if _, ok := seen[after.Content[i].Value]; !ok {
+			content = append(content, utils.CloneYAMLNode(after.Content[i]), utils.CloneYAMLNode(after.Content[i+1]))
+		}
+	}
+	target.Content = content
+}
+
+// replaceNode replaces the content of the target with a copy of the source, keeping the target's position and
+// comments.
+func replaceNode(target, source *yaml.Node) {
+	clone := utils.CloneYAMLNode(source)
+	target.Kind = clone.Kind
+	target.Tag = clone.Tag
+	target.Value = clone.Value
+	target.Style = clone.Style
+	target.Content = clone.Content
+	target.Anchor = clone.Anchor
+	target.Alias = clone.Alias
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package high
+
+import (
+	"sort"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"go.yaml.in/yaml/v4"
+)
+
+// OrderedEntry is an entry of a high-level map or list, returned in the order it was defined in the original
+// document. The KeyNode is the node the entry was defined with, it's nil for entries that are not part of the
+// original document (for example, entries added after the model was built).
+type OrderedEntry[T any] struct {
+	Key     string
+	Value   T
+	KeyNode *yaml.Node
+}
+
+// InDocumentOrder returns the entries of a high-level map in the order their keys were defined in the original
+// document, with the key node of each entry attached. The order is taken from the low-level map the high-level map
+// was created from. Entries that are not in the low-level map are returned last, in the order of the high-level map.
+func InDocumentOrder[H, L any](entries *orderedmap.Map[string, H],
+	lowEntries *orderedmap.Map[low.KeyReference[string], L],
+) []*OrderedEntry[H] {
+	if entries == nil {
+		return nil
+	}
+	type position struct {
+		index   int
+		keyNode *yaml.Node
+	}
+	positions := make(map[string]position)
+	if lowEntries != nil {
+		i := 0
+		for k := range lowEntries.KeysFromOldest() {
+			positions[k.Value] = position{index: i, keyNode: k.KeyNode}
+			i++
+		}
+	}
+
+	type entry struct {
+		order int
+		entry *OrderedEntry[H]
+	}
+	ordered := make([]entry, 0, entries.Len())
+	for k, v := range entries.FromOldest() {
+		e := entry{order: len(positions) + len(ordered), entry: &OrderedEntry[H]{Key: k, Value: v}}
+		if p, ok := positions[k]; ok {
+			e.order = p.index
+			e.entry.KeyNode = p.keyNode
+		}
+		ordered = append(ordered, e)
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].order < ordered[j].order
+	})
+	result := make([]*OrderedEntry[H], len(ordered))
+	for i := range ordered {
+		result[i] = ordered[i].entry
+	}
+	return result
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -168,6 +169,30 @@ func ResolveExternalRef[H any, L any](
 	return result, nil
 }
 
+// PreservedReference returns a $ref node if the rolodex of the index (the index the reference points to) is
+// configured to keep the reference when rendering inline (see index.Rolodex.SetReferencePreserver), or nil if the
+// reference should be inlined.
+func PreservedReference(idx *index.SpecIndex, ref string) *yaml.Node {
+	if idx == nil || ref == "" {
+		return nil
+	}
+	if preserved, ok := idx.GetRolodex().PreserveReference(idx, ref); ok {
+		return utils.CreateRefNode(preserved)
+	}
+	return nil
+}
+
+// AnnotateInlinedReference passes the rendered node of a reference that has been inlined to the rolodex of the
+// index (the index the reference points to), so it can be annotated (see index.Rolodex.SetReferenceAnnotator).
+func AnnotateInlinedReference(idx *index.SpecIndex, ref string, rendered interface{}) {
+	if idx == nil || ref == "" {
+		return
+	}
+	if node, ok := rendered.(*yaml.Node); ok {
+		idx.GetRolodex().AnnotateReference(idx, ref, node)
+	}
+}
+
 // RenderExternalRef is a convenience function that resolves an external reference and renders it inline.
 // This combines ResolveExternalRef with RenderInline for the common case where you want to
 // resolve and immediately render an external reference.
@@ -181,11 +206,20 @@ func RenderExternalRef[H any, L any](
 	buildLow ExternalRefBuildFunc[L],
 	buildHigh func(L) H,
 ) (interface{}, error) {
+	if lowObj != nil && lowObj.IsReference() {
+		if preserved := PreservedReference(lowObj.GetIndex(), lowObj.GetReference()); preserved != nil {
+			return preserved, nil
+		}
+	}
 	result, err := ResolveExternalRef(lowObj, buildLow, buildHigh)
 	if err != nil || !result.Resolved {
 		return nil, err
 	}
-	return RenderInline(result.High, result.Low)
+	rendered, err := RenderInline(result.High, result.Low)
+	if err == nil {
+		AnnotateInlinedReference(lowObj.GetIndex(), lowObj.GetReference(), rendered)
+	}
+	return rendered, err
 }
 
 // RenderExternalRefWithContext is like RenderExternalRef but passes a context for cycle detection.
@@ -195,9 +229,18 @@ func RenderExternalRefWithContext[H any, L any](
 	buildHigh func(L) H,
 	ctx any,
 ) (interface{}, error) {
+	if lowObj != nil && lowObj.IsReference() {
+		if preserved := PreservedReference(lowObj.GetIndex(), lowObj.GetReference()); preserved != nil {
+			return preserved, nil
+		}
+	}
 	result, err := ResolveExternalRef(lowObj, buildLow, buildHigh)
 	if err != nil || !result.Resolved {
 		return nil, err
 	}
-	return RenderInlineWithContext(result.High, result.Low, ctx)
+	rendered, err := RenderInlineWithContext(result.High, result.Low, ctx)
+	if err == nil {
+		AnnotateInlinedReference(lowObj.GetIndex(), lowObj.GetReference(), rendered)
+	}
+	return rendered, err
 }
@@ -4,6 +4,8 @@
 package v2
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -21,12 +23,22 @@ type Paths struct {
 
 // NewPaths creates a new high-level instance of Paths from a low-level one.
 func NewPaths(paths *v2low.Paths) *Paths {
+	p, _ := NewPathsWithContext(context.Background(), paths)
+	return p
+}
+
+// NewPathsWithContext is the same as NewPaths, except building stops as soon as the supplied context is cancelled
+// or times out, and only the context error is returned.
+func NewPathsWithContext(ctx context.Context, paths *v2low.Paths) (*Paths, error) {
 	p := new(Paths)
 	p.low = paths
 	p.Extensions = high.ExtractExtensions(paths.Extensions)
 	pathItems := orderedmap.New[string, *PathItem]()
 
 	translateFunc := func(pair orderedmap.Pair[low.KeyReference[string], low.ValueReference[*v2low.PathItem]]) (asyncResult[*PathItem], error) {
+		if err := ctx.Err(); err != nil {
+			return asyncResult[*PathItem]{}, err
+		}
 		return asyncResult[*PathItem]{
 			key:    pair.Key().Value,
 			result: NewPathItem(pair.Value().Value),
@@ -36,11 +48,14 @@ func NewPaths(paths *v2low.Paths) *Paths {
 		pathItems.Set(result.key, result.result)
 		return nil
 	}
-	_ = datamodel.TranslateMapParallel[low.KeyReference[string], low.ValueReference[*v2low.PathItem], asyncResult[*PathItem]](
+	err := datamodel.TranslateMapParallel[low.KeyReference[string], low.ValueReference[*v2low.PathItem], asyncResult[*PathItem]](
 		paths.PathItems, translateFunc, resultFunc,
 	)
+	if err != nil {
+		return nil, err
+	}
 	p.PathItems = pathItems
-	return p
+	return p, nil
 }
 
 // GoLow returns the low-level Paths instance that backs the high level one.
@@ -14,6 +14,8 @@
 package v2
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	low "github.com/pb33f/libopenapi/datamodel/low/v2"
@@ -99,6 +101,13 @@ type Swagger struct {
 
 // NewSwaggerDocument will create a new high-level Swagger document from a low-level one.
 func NewSwaggerDocument(document *low.Swagger) *Swagger {
+	d, _ := NewSwaggerDocumentWithContext(context.Background(), document)
+	return d
+}
+
+// NewSwaggerDocumentWithContext is the same as NewSwaggerDocument, except building stops as soon as the supplied
+// context is cancelled or times out, and only the context error is returned.
+func NewSwaggerDocumentWithContext(ctx context.Context, document *low.Swagger) (*Swagger, error) {
 	d := new(Swagger)
 	d.low = document
 	d.Extensions = high.ExtractExtensions(document.Extensions)
@@ -137,7 +146,14 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 		d.Produces = produces
 	}
 	if !document.Paths.IsEmpty() {
-		d.Paths = NewPaths(document.Paths.Value)
+		paths, err := NewPathsWithContext(ctx, document.Paths.Value)
+		if err != nil {
+			return nil, err
+		}
+		d.Paths = paths
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 	if !document.Definitions.IsEmpty() {
 		d.Definitions = NewDefinitions(document.Definitions.Value)
@@ -169,7 +185,10 @@ func NewSwaggerDocument(document *low.Swagger) *Swagger {
 	if !document.ExternalDocs.IsEmpty() {
 		d.ExternalDocs = base.NewExternalDoc(document.ExternalDocs.Value)
 	}
-	return d
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
 }
 
 // GoLow returns the low-level Swagger instance that was used to create the high-level one.
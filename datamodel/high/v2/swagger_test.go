@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -31,6 +32,19 @@ func TestNewSwaggerDocument(t *testing.T) {
 	assert.NotNil(t, h)
 }
 
+func TestNewSwaggerDocumentWithContext(t *testing.T) {
+	initTest()
+	h, err := NewSwaggerDocumentWithContext(context.Background(), doc)
+	assert.NoError(t, err)
+	assert.Equal(t, NewSwaggerDocument(doc).Paths.PathItems.Len(), h.Paths.PathItems.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h, err = NewSwaggerDocumentWithContext(ctx, doc)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, h)
+}
+
 func BenchmarkNewDocument(b *testing.B) {
 	initTest()
 	for i := 0; i < b.N; i++ {
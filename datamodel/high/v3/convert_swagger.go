@@ -0,0 +1,969 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// ConvertedSwaggerVersion is the OpenAPI version of documents created by ConvertSwagger.
+const ConvertedSwaggerVersion = "3.1.0"
+
+// swaggerRefPrefixes maps the location of Swagger 2.0 definitions, to where they live in an OpenAPI 3 document.
+var swaggerRefPrefixes = [][2]string{
+	{"#/definitions/", "#/components/schemas/"},
+	{"#/parameters/", "#/components/parameters/"},
+	{"#/responses/", "#/components/responses/"},
+}
+
+// ConvertSwagger converts a Swagger 2.0 model into an equivalent OpenAPI 3.1 Document.
+//
+//   - definitions become components.schemas, and every reference to them is rewritten.
+//   - parameter and response definitions become components.parameters and components.responses, body parameter
+//     definitions become components.requestBodies.
+//   - body and formData parameters become a requestBody, using consumes as the media types.
+//   - response schemas and examples become content, using produces as the media types.
+//   - host, basePath and schemes become servers.
+//   - securityDefinitions become components.securitySchemes, basic auth becomes an http scheme, and oauth2 flows
+//     are renamed.
+//   - non-body parameters and headers have their type, format and validation moved into a schema, and their
+//     collectionFormat converted into a style.
+//   - schemas have x-nullable, type 'file', boolean exclusiveMinimum/exclusiveMaximum and string discriminators
+//     converted into their OpenAPI 3.1 equivalents.
+//
+// Parameters and responses used by operations are always written inline, as the Swagger model does not keep
+// track of which of them were references. References to external files are copied as they are.
+//
+// The new document is rendered and parsed using the supplied configuration (which can be nil), so it has a full
+// low-level model and index. If the new document has errors, the document is returned alongside the error.
+func ConvertSwagger(swagger *v2.Swagger, config *datamodel.DocumentConfiguration) (*Document, error) {
+	if swagger == nil {
+		return nil, errors.New("unable to convert, no swagger model supplied")
+	}
+	root, err := convertSwaggerNode(swagger)
+	if err != nil {
+		return nil, err
+	}
+	rewriteSwaggerRefs(root)
+
+	rendered, err := yaml.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	info, err := datamodel.ExtractSpecInfo(rendered)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		config = datamodel.NewDocumentConfiguration()
+	}
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, config)
+	if lowDoc == nil {
+		return nil, err
+	}
+	return NewDocument(lowDoc), err
+}
+
+// swaggerConverter holds the global defaults of the swagger document, used when converting operations.
+type swaggerConverter struct {
+	swagger  *v2.Swagger
+	consumes []string
+	produces []string
+}
+
+func convertSwaggerNode(s *v2.Swagger) (*yaml.Node, error) {
+	c := &swaggerConverter{swagger: s, consumes: s.Consumes, produces: s.Produces}
+	root := utils.CreateEmptyMapNode()
+	convertSet(root, "openapi", utils.CreateStringNode(ConvertedSwaggerVersion))
+
+	if s.Info != nil {
+		if err := convertSetRendered(root, "info", s.Info); err != nil {
+			return nil, err
+		}
+	} else {
+		info := utils.CreateEmptyMapNode()
+		convertSet(info, "title", utils.CreateStringNode(""))
+		convertSet(info, "version", utils.CreateStringNode(""))
+		convertSet(root, "info", info)
+	}
+	if servers := c.servers(s.Schemes); servers != nil {
+		convertSet(root, "servers", servers)
+	}
+	if s.Security != nil {
+		security := utils.CreateEmptySequenceNode()
+		for _, req := range s.Security {
+			n, err := convertRender(req)
+			if err != nil {
+				return nil, err
+			}
+			security.Content = append(security.Content, n)
+		}
+		convertSet(root, "security", security)
+	}
+	if len(s.Tags) > 0 {
+		tags := utils.CreateEmptySequenceNode()
+		for _, tag := range s.Tags {
+			n, err := convertRender(tag)
+			if err != nil {
+				return nil, err
+			}
+			tags.Content = append(tags.Content, n)
+		}
+		convertSet(root, "tags", tags)
+	}
+	if s.ExternalDocs != nil {
+		if err := convertSetRendered(root, "externalDocs", s.ExternalDocs); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := utils.CreateEmptyMapNode()
+	if s.Paths != nil {
+		for path, item := range s.Paths.PathItems.FromOldest() {
+			n, err := c.pathItem(item)
+			if err != nil {
+				return nil, err
+			}
+			convertSet(paths, path, n)
+		}
+		convertExtensions(paths, s.Paths.Extensions)
+	}
+	convertSet(root, "paths", paths)
+
+	components, err := c.components()
+	if err != nil {
+		return nil, err
+	}
+	if len(components.Content) > 0 {
+		convertSet(root, "components", components)
+	}
+	convertExtensions(root, s.Extensions)
+	return root, nil
+}
+
+// servers creates servers from the host and base path, one for each scheme.
+func (c *swaggerConverter) servers(schemes []string) *yaml.Node {
+	host, basePath := c.swagger.Host, c.swagger.BasePath
+	if host == "" && basePath == "" {
+		return nil
+	}
+	servers := utils.CreateEmptySequenceNode()
+	addServer := func(url string) {
+		server := utils.CreateEmptyMapNode()
+		convertSet(server, "url", utils.CreateStringNode(url))
+		servers.Content = append(servers.Content, server)
+	}
+	switch {
+	case host == "":
+		addServer(basePath)
+	case len(schemes) == 0:
+		addServer("//" + host + basePath)
+	default:
+		for _, scheme := range schemes {
+			addServer(scheme + "://" + host + basePath)
+		}
+	}
+	return servers
+}
+
+func (c *swaggerConverter) pathItem(item *v2.PathItem) (*yaml.Node, error) {
+	n := utils.CreateEmptyMapNode()
+	if item == nil {
+		return n, nil
+	}
+	if item.Ref != "" {
+		convertSet(n, "$ref", utils.CreateStringNode(item.Ref))
+	}
+	ops := []struct {
+		method string
+		op     *v2.Operation
+	}{
+		{lowv3.GetLabel, item.Get}, {lowv3.PutLabel, item.Put}, {lowv3.PostLabel, item.Post},
+		{lowv3.DeleteLabel, item.Delete}, {lowv3.OptionsLabel, item.Options}, {lowv3.HeadLabel, item.Head},
+		{lowv3.PatchLabel, item.Patch},
+	}
+	for _, o := range ops {
+		if o.op == nil {
+			continue
+		}
+		op, err := c.operation(o.op, item.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		convertSet(n, o.method, op)
+	}
+
+	// body and formData parameters are pushed down into each operation's request body.
+	var params []*v2.Parameter
+	for _, p := range item.Parameters {
+		if p != nil && p.In != "body" && p.In != "formData" {
+			params = append(params, p)
+		}
+	}
+	if len(params) > 0 {
+		seq, err := c.parameters(params)
+		if err != nil {
+			return nil, err
+		}
+		convertSet(n, "parameters", seq)
+	}
+	convertExtensions(n, item.Extensions)
+	return n, nil
+}
+
+func (c *swaggerConverter) operation(op *v2.Operation, pathParams []*v2.Parameter) (*yaml.Node, error) {
+	n := utils.CreateEmptyMapNode()
+	if len(op.Tags) > 0 {
+		tags := utils.CreateEmptySequenceNode()
+		for _, tag := range op.Tags {
+			tags.Content = append(tags.Content, utils.CreateStringNode(tag))
+		}
+		convertSet(n, "tags", tags)
+	}
+	convertSetString(n, "summary", op.Summary)
+	convertSetString(n, "description", op.Description)
+	if op.ExternalDocs != nil {
+		if err := convertSetRendered(n, "externalDocs", op.ExternalDocs); err != nil {
+			return nil, err
+		}
+	}
+	convertSetString(n, "operationId", op.OperationId)
+
+	// operation parameters override path item parameters with the same name and location.
+	var body *v2.Parameter
+	var formData, params []*v2.Parameter
+	all := slices.Clone(op.Parameters)
+	for _, p := range pathParams {
+		if p != nil && !slices.ContainsFunc(op.Parameters, func(o *v2.Parameter) bool {
+			return o != nil && o.Name == p.Name && o.In == p.In
+		}) && (p.In == "body" || p.In == "formData") {
+			all = append(all, p)
+		}
+	}
+	for _, p := range all {
+		if p == nil {
+			continue
+		}
+		switch p.In {
+		case "body":
+			body = p
+		case "formData":
+			formData = append(formData, p)
+		default:
+			params = append(params, p)
+		}
+	}
+	if len(params) > 0 {
+		seq, err := c.parameters(params)
+		if err != nil {
+			return nil, err
+		}
+		convertSet(n, "parameters", seq)
+	}
+
+	consumes := op.Consumes
+	if consumes == nil {
+		consumes = c.consumes
+	}
+	switch {
+	case body != nil:
+		rb, err := c.requestBody(body, consumes)
+		if err != nil {
+			return nil, err
+		}
+		convertSet(n, "requestBody", rb)
+	case len(formData) > 0:
+		rb, err := c.formDataRequestBody(formData, consumes)
+		if err != nil {
+			return nil, err
+		}
+		convertSet(n, "requestBody", rb)
+	}
+
+	produces := op.Produces
+	if produces == nil {
+		produces = c.produces
+	}
+	if op.Responses != nil {
+		responses := utils.CreateEmptyMapNode()
+		for code, resp := range op.Responses.Codes.FromOldest() {
+			r, err := c.response(resp, produces)
+			if err != nil {
+				return nil, err
+			}
+			convertSet(responses, code, r)
+		}
+		if op.Responses.Default != nil {
+			r, err := c.response(op.Responses.Default, produces)
+			if err != nil {
+				return nil, err
+			}
+			convertSet(responses, "default", r)
+		}
+		convertExtensions(responses, op.Responses.Extensions)
+		convertSet(n, "responses", responses)
+	}
+	if op.Deprecated {
+		convertSet(n, "deprecated", utils.CreateBoolNode("true"))
+	}
+	if op.Security != nil {
+		security := utils.CreateEmptySequenceNode()
+		for _, req := range op.Security {
+			r, err := convertRender(req)
+			if err != nil {
+				return nil, err
+			}
+			security.Content = append(security.Content, r)
+		}
+		convertSet(n, "security", security)
+	}
+	if len(op.Schemes) > 0 {
+		if servers := c.servers(op.Schemes); servers != nil {
+			convertSet(n, "servers", servers)
+		}
+	}
+	convertExtensions(n, op.Extensions)
+	return n, nil
+}
+
+func (c *swaggerConverter) parameters(params []*v2.Parameter) (*yaml.Node, error) {
+	seq := utils.CreateEmptySequenceNode()
+	for _, p := range params {
+		n, err := c.parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		seq.Content = append(seq.Content, n)
+	}
+	return seq, nil
+}
+
+// parameter converts a non-body parameter.
+func (c *swaggerConverter) parameter(p *v2.Parameter) (*yaml.Node, error) {
+	n := utils.CreateEmptyMapNode()
+	convertSet(n, "name", utils.CreateStringNode(p.Name))
+	convertSet(n, "in", utils.CreateStringNode(p.In))
+	convertSetString(n, "description", p.Description)
+	if p.Required != nil && *p.Required {
+		convertSet(n, "required", utils.CreateBoolNode("true"))
+	}
+	if p.AllowEmptyValue != nil && *p.AllowEmptyValue && p.In == "query" {
+		convertSet(n, "allowEmptyValue", utils.CreateBoolNode("true"))
+	}
+	if p.Type == "array" {
+		convertCollectionFormat(n, p.In, p.CollectionFormat)
+	}
+	schema, err := c.parameterSchema(p)
+	if err != nil {
+		return nil, err
+	}
+	convertSet(n, "schema", schema)
+	convertExtensions(n, p.Extensions)
+	return n, nil
+}
+
+// parameterSchema creates a schema from the type, format and validation of a non-body parameter.
+func (c *swaggerConverter) parameterSchema(p *v2.Parameter) (*yaml.Node, error) {
+	if p.Schema != nil {
+		return convertSchema(p.Schema)
+	}
+	s := utils.CreateEmptyMapNode()
+	convertSchemaType(s, p.Type, p.Format)
+	if p.Items != nil {
+		convertSet(s, "items", convertItemsSchema(p.Items))
+	}
+	if p.Default != nil {
+		convertSet(s, "default", utils.CloneYAMLNode(p.Default))
+	}
+	convertSetBound(s, "minimum", "exclusiveMinimum", p.Minimum, p.ExclusiveMinimum)
+	convertSetBound(s, "maximum", "exclusiveMaximum", p.Maximum, p.ExclusiveMaximum)
+	convertSetIntPtr(s, "maxLength", p.MaxLength)
+	convertSetIntPtr(s, "minLength", p.MinLength)
+	convertSetString(s, "pattern", p.Pattern)
+	convertSetIntPtr(s, "maxItems", p.MaxItems)
+	convertSetIntPtr(s, "minItems", p.MinItems)
+	if p.UniqueItems != nil && *p.UniqueItems {
+		convertSet(s, "uniqueItems", utils.CreateBoolNode("true"))
+	}
+	convertSetEnum(s, p.Enum)
+	convertSetIntPtr(s, "multipleOf", p.MultipleOf)
+	return s, nil
+}
+
+func (c *swaggerConverter) requestBody(body *v2.Parameter, consumes []string) (*yaml.Node, error) {
+	n := utils.CreateEmptyMapNode()
+	convertSetString(n, "description", body.Description)
+	content := utils.CreateEmptyMapNode()
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	for _, mediaType := range consumes {
+		mt := utils.CreateEmptyMapNode()
+		if body.Schema != nil {
+			schema, err := convertSchema(body.Schema)
+			if err != nil {
+				return nil, err
+			}
+			convertSet(mt, "schema", schema)
+		}
+		convertSet(content, mediaType, mt)
+	}
+	convertSet(n, "content", content)
+	if body.Required != nil && *body.Required {
+		convertSet(n, "required", utils.CreateBoolNode("true"))
+	}
+	convertExtensions(n, body.Extensions)
+	return n, nil
+}
+
+// formDataRequestBody converts formData parameters into an object schema, each parameter is a property.
+func (c *swaggerConverter) formDataRequestBody(params []*v2.Parameter, consumes []string) (*yaml.Node, error) {
+	schema := utils.CreateEmptyMapNode()
+	convertSet(schema, "type", utils.CreateStringNode("object"))
+	properties := utils.CreateEmptyMapNode()
+	required := utils.CreateEmptySequenceNode()
+	hasFile := false
+	for _, p := range params {
+		prop, err := c.parameterSchema(p)
+		if err != nil {
+			return nil, err
+		}
+		if p.Type == "file" {
+			hasFile = true
+		}
+		convertSetString(prop, "description", p.Description)
+		convertSet(properties, p.Name, prop)
+		if p.Required != nil && *p.Required {
+			required.Content = append(required.Content, utils.CreateStringNode(p.Name))
+		}
+	}
+	convertSet(schema, "properties", properties)
+	if len(required.Content) > 0 {
+		convertSet(schema, "required", required)
+	}
+
+	var mediaTypes []string
+	for _, mediaType := range consumes {
+		if mediaType == "multipart/form-data" || mediaType == "application/x-www-form-urlencoded" {
+			mediaTypes = append(mediaTypes, mediaType)
+		}
+	}
+	if len(mediaTypes) == 0 {
+		if hasFile {
+			mediaTypes = []string{"multipart/form-data"}
+		} else {
+			mediaTypes = []string{"application/x-www-form-urlencoded"}
+		}
+	}
+	content := utils.CreateEmptyMapNode()
+	for i, mediaType := range mediaTypes {
+		mt := utils.CreateEmptyMapNode()
+		if i == 0 {
+			convertSet(mt, "schema", schema)
+		} else {
+			convertSet(mt, "schema", utils.CloneYAMLNode(schema))
+		}
+		convertSet(content, mediaType, mt)
+	}
+	n := utils.CreateEmptyMapNode()
+	convertSet(n, "content", content)
+	if len(required.Content) > 0 {
+		convertSet(n, "required", utils.CreateBoolNode("true"))
+	}
+	return n, nil
+}
+
+func (c *swaggerConverter) response(resp *v2.Response, produces []string) (*yaml.Node, error) {
+	n := utils.CreateEmptyMapNode()
+	if resp == nil {
+		return n, nil
+	}
+	convertSet(n, "description", utils.CreateStringNode(resp.Description))
+	if resp.Headers != nil && resp.Headers.Len() > 0 {
+		headers := utils.CreateEmptyMapNode()
+		for name, header := range resp.Headers.FromOldest() {
+			convertSet(headers, name, convertHeader(header))
+		}
+		convertSet(n, "headers", headers)
+	}
+
+	var mediaTypes []string
+	if resp.Schema != nil {
+		mediaTypes = slices.Clone(produces)
+		if len(mediaTypes) == 0 {
+			mediaTypes = []string{"application/json"}
+		}
+	}
+	if resp.Examples != nil && resp.Examples.Values != nil {
+		for mediaType := range resp.Examples.Values.KeysFromOldest() {
+			if !slices.Contains(mediaTypes, mediaType) {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	if len(mediaTypes) > 0 {
+		content := utils.CreateEmptyMapNode()
+		for _, mediaType := range mediaTypes {
+			mt := utils.CreateEmptyMapNode()
+			if resp.Schema != nil {
+				schema, err := convertSchema(resp.Schema)
+				if err != nil {
+					return nil, err
+				}
+				convertSet(mt, "schema", schema)
+			}
+			if resp.Examples != nil && resp.Examples.Values != nil {
+				if example := resp.Examples.Values.GetOrZero(mediaType); example != nil {
+					convertSet(mt, "example", utils.CloneYAMLNode(example))
+				}
+			}
+			convertSet(content, mediaType, mt)
+		}
+		convertSet(n, "content", content)
+	}
+	convertExtensions(n, resp.Extensions)
+	return n, nil
+}
+
+func (c *swaggerConverter) components() (*yaml.Node, error) {
+	s := c.swagger
+	components := utils.CreateEmptyMapNode()
+	if s.Definitions != nil && s.Definitions.Definitions != nil && s.Definitions.Definitions.Len() > 0 {
+		schemas := utils.CreateEmptyMapNode()
+		for name, proxy := range s.Definitions.Definitions.FromOldest() {
+			schema, err := convertSchema(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("unable to convert definition '%s': %w", name, err)
+			}
+			convertSet(schemas, name, schema)
+		}
+		convertSet(components, "schemas", schemas)
+	}
+	if s.Responses != nil && s.Responses.Definitions != nil && s.Responses.Definitions.Len() > 0 {
+		responses := utils.CreateEmptyMapNode()
+		for name, resp := range s.Responses.Definitions.FromOldest() {
+			r, err := c.response(resp, c.produces)
+			if err != nil {
+				return nil, err
+			}
+			convertSet(responses, name, r)
+		}
+		convertSet(components, "responses", responses)
+	}
+	if s.Parameters != nil && s.Parameters.Definitions != nil && s.Parameters.Definitions.Len() > 0 {
+		params := utils.CreateEmptyMapNode()
+		bodies := utils.CreateEmptyMapNode()
+		for name, p := range s.Parameters.Definitions.FromOldest() {
+			switch p.In {
+			case "body":
+				rb, err := c.requestBody(p, c.consumes)
+				if err != nil {
+					return nil, err
+				}
+				convertSet(bodies, name, rb)
+			case "formData":
+				// form parameters are only meaningful as part of a request body, they are inlined where used.
+			default:
+				n, err := c.parameter(p)
+				if err != nil {
+					return nil, err
+				}
+				convertSet(params, name, n)
+			}
+		}
+		if len(params.Content) > 0 {
+			convertSet(components, "parameters", params)
+		}
+		if len(bodies.Content) > 0 {
+			convertSet(components, "requestBodies", bodies)
+		}
+	}
+	if s.SecurityDefinitions != nil && s.SecurityDefinitions.Definitions != nil &&
+		s.SecurityDefinitions.Definitions.Len() > 0 {
+		schemes := utils.CreateEmptyMapNode()
+		for name, scheme := range s.SecurityDefinitions.Definitions.FromOldest() {
+			convertSet(schemes, name, convertSecurityScheme(scheme))
+		}
+		convertSet(components, "securitySchemes", schemes)
+	}
+	return components, nil
+}
+
+func convertSecurityScheme(scheme *v2.SecurityScheme) *yaml.Node {
+	n := utils.CreateEmptyMapNode()
+	switch scheme.Type {
+	case "basic":
+		convertSet(n, "type", utils.CreateStringNode("http"))
+		convertSetString(n, "description", scheme.Description)
+		convertSet(n, "scheme", utils.CreateStringNode("basic"))
+	case "oauth2":
+		convertSet(n, "type", utils.CreateStringNode("oauth2"))
+		convertSetString(n, "description", scheme.Description)
+		flow := utils.CreateEmptyMapNode()
+		convertSetString(flow, "authorizationUrl", scheme.AuthorizationUrl)
+		convertSetString(flow, "tokenUrl", scheme.TokenUrl)
+		scopes := utils.CreateEmptyMapNode()
+		if scheme.Scopes != nil && scheme.Scopes.Values != nil {
+			for k, v := range scheme.Scopes.Values.FromOldest() {
+				convertSet(scopes, k, utils.CreateStringNode(v))
+			}
+		}
+		convertSet(flow, "scopes", scopes)
+		flowName := scheme.Flow
+		switch scheme.Flow {
+		case "application":
+			flowName = "clientCredentials"
+		case "accessCode":
+			flowName = "authorizationCode"
+		}
+		flows := utils.CreateEmptyMapNode()
+		convertSet(flows, flowName, flow)
+		convertSet(n, "flows", flows)
+	default:
+		convertSet(n, "type", utils.CreateStringNode(scheme.Type))
+		convertSetString(n, "description", scheme.Description)
+		convertSetString(n, "name", scheme.Name)
+		convertSetString(n, "in", scheme.In)
+	}
+	convertExtensions(n, scheme.Extensions)
+	return n
+}
+
+func convertHeader(h *v2.Header) *yaml.Node {
+	n := utils.CreateEmptyMapNode()
+	convertSetString(n, "description", h.Description)
+	if h.Type == "array" {
+		convertCollectionFormat(n, "header", h.CollectionFormat)
+	}
+	s := utils.CreateEmptyMapNode()
+	convertSchemaType(s, h.Type, h.Format)
+	if h.Items != nil {
+		convertSet(s, "items", convertItemsSchema(h.Items))
+	}
+	if h.Default != nil {
+		convertSet(s, "default", utils.CreateYamlNode(h.Default))
+	}
+	convertSetIntBound(s, "minimum", "exclusiveMinimum", h.Minimum, h.ExclusiveMinimum)
+	convertSetIntBound(s, "maximum", "exclusiveMaximum", h.Maximum, h.ExclusiveMaximum)
+	convertSetInt(s, "maxLength", h.MaxLength)
+	convertSetInt(s, "minLength", h.MinLength)
+	convertSetString(s, "pattern", h.Pattern)
+	convertSetInt(s, "maxItems", h.MaxItems)
+	convertSetInt(s, "minItems", h.MinItems)
+	if h.UniqueItems {
+		convertSet(s, "uniqueItems", utils.CreateBoolNode("true"))
+	}
+	if len(h.Enum) > 0 {
+		enum := utils.CreateEmptySequenceNode()
+		for _, e := range h.Enum {
+			enum.Content = append(enum.Content, utils.CreateYamlNode(e))
+		}
+		convertSet(s, "enum", enum)
+	}
+	convertSetInt(s, "multipleOf", h.MultipleOf)
+	convertSet(n, "schema", s)
+	convertExtensions(n, h.Extensions)
+	return n
+}
+
+func convertItemsSchema(items *v2.Items) *yaml.Node {
+	s := utils.CreateEmptyMapNode()
+	convertSchemaType(s, items.Type, items.Format)
+	if items.Items != nil {
+		convertSet(s, "items", convertItemsSchema(items.Items))
+	}
+	if items.Default != nil {
+		convertSet(s, "default", utils.CloneYAMLNode(items.Default))
+	}
+	convertSetIntBound(s, "minimum", "exclusiveMinimum", items.Minimum, items.ExclusiveMinimum)
+	convertSetIntBound(s, "maximum", "exclusiveMaximum", items.Maximum, items.ExclusiveMaximum)
+	convertSetInt(s, "maxLength", items.MaxLength)
+	convertSetInt(s, "minLength", items.MinLength)
+	convertSetString(s, "pattern", items.Pattern)
+	convertSetInt(s, "maxItems", items.MaxItems)
+	convertSetInt(s, "minItems", items.MinItems)
+	if items.UniqueItems {
+		convertSet(s, "uniqueItems", utils.CreateBoolNode("true"))
+	}
+	convertSetEnum(s, items.Enum)
+	convertSetInt(s, "multipleOf", items.MultipleOf)
+	return s
+}
+
+// convertSchemaType sets the type and format of a schema, a 'file' type becomes binary content.
+func convertSchemaType(s *yaml.Node, typ, format string) {
+	if typ == "file" {
+		convertSet(s, "type", utils.CreateStringNode("string"))
+		convertSet(s, "contentMediaType", utils.CreateStringNode("application/octet-stream"))
+		return
+	}
+	convertSetString(s, "type", typ)
+	convertSetString(s, "format", format)
+}
+
+// convertCollectionFormat converts a swagger collectionFormat into the equivalent style and explode. Path and
+// header parameters use the 'simple' style by default, which is the same as 'csv'.
+func convertCollectionFormat(n *yaml.Node, in, format string) {
+	switch format {
+	case "", "csv":
+		if in == "query" || in == "formData" {
+			convertSet(n, "style", utils.CreateStringNode("form"))
+			convertSet(n, "explode", utils.CreateBoolNode("false"))
+		}
+	case "ssv":
+		convertSet(n, "style", utils.CreateStringNode("spaceDelimited"))
+	case "pipes":
+		convertSet(n, "style", utils.CreateStringNode("pipeDelimited"))
+	case "multi":
+		convertSet(n, "style", utils.CreateStringNode("form"))
+		convertSet(n, "explode", utils.CreateBoolNode("true"))
+	}
+}
+
+// convertSchema copies a swagger schema, and converts it into an OpenAPI 3.1 schema. Schemas parsed from a
+// document are copied from their original yaml, as the OpenAPI 3 schema model drops swagger only values, like
+// a string discriminator. Schemas created by hand are rendered.
+func convertSchema(proxy *base.SchemaProxy) (*yaml.Node, error) {
+	var n *yaml.Node
+	if proxy.IsReference() {
+		n = utils.CreateRefNode(proxy.GetReference())
+	} else if lowProxy := proxy.GoLow(); lowProxy != nil && lowProxy.GetValueNode() != nil {
+		n = lowProxy.GetValueNode()
+	} else {
+		rendered, err := proxy.MarshalYAML()
+		if err != nil {
+			return nil, err
+		}
+		n, _ = rendered.(*yaml.Node)
+	}
+	if n == nil {
+		return utils.CreateEmptyMapNode(), nil
+	}
+	n = utils.CloneYAMLNode(n)
+	convertSchemaNode(n)
+	return n, nil
+}
+
+// convertSchemaNode converts the swagger only parts of a schema, and every schema inside it, in place.
+func convertSchemaNode(n *yaml.Node) {
+	if n == nil {
+		return
+	}
+	switch n.Kind {
+	case yaml.SequenceNode:
+		for _, child := range n.Content {
+			convertSchemaNode(child)
+		}
+		return
+	case yaml.MappingNode:
+	default:
+		return
+	}
+
+	nullable := false
+	content := make([]*yaml.Node, 0, len(n.Content))
+	var minimum, maximum *yaml.Node
+	exclusiveMin, exclusiveMax := false, false
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		switch key.Value {
+		case "x-nullable":
+			nullable = value.Value == "true"
+			continue
+		case "exclusiveMinimum":
+			if value.Tag == "!!bool" {
+				exclusiveMin = value.Value == "true"
+				continue
+			}
+		case "exclusiveMaximum":
+			if value.Tag == "!!bool" {
+				exclusiveMax = value.Value == "true"
+				continue
+			}
+		case "minimum":
+			minimum = value
+		case "maximum":
+			maximum = value
+		case "discriminator":
+			if value.Kind == yaml.ScalarNode {
+				d := utils.CreateEmptyMapNode()
+				convertSet(d, "propertyName", utils.CreateStringNode(value.Value))
+				value = d
+			}
+		case "type":
+			if value.Kind == yaml.ScalarNode && value.Value == "file" {
+				value = utils.CreateStringNode("string")
+				content = append(content, key, value,
+					utils.CreateStringNode("contentMediaType"), utils.CreateStringNode("application/octet-stream"))
+				continue
+			}
+		case "properties", "patternProperties", "definitions", "$defs":
+			for j := 1; j < len(value.Content); j += 2 {
+				convertSchemaNode(value.Content[j])
+			}
+		case "items", "additionalProperties", "allOf", "anyOf", "oneOf", "not":
+			convertSchemaNode(value)
+		}
+		content = append(content, key, value)
+	}
+	n.Content = content
+
+	if exclusiveMin && minimum != nil {
+		convertReplaceKey(n, "minimum", "exclusiveMinimum")
+	}
+	if exclusiveMax && maximum != nil {
+		convertReplaceKey(n, "maximum", "exclusiveMaximum")
+	}
+	if nullable {
+		convertAddNullType(n)
+	}
+}
+
+// convertAddNullType adds 'null' to the types of a schema.
+func convertAddNullType(n *yaml.Node) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value != "type" {
+			continue
+		}
+		typ := n.Content[i+1]
+		if typ.Kind == yaml.ScalarNode {
+			seq := utils.CreateEmptySequenceNode()
+			seq.Content = append(seq.Content, utils.CreateStringNode(typ.Value), utils.CreateStringNode("null"))
+			n.Content[i+1] = seq
+		} else if typ.Kind == yaml.SequenceNode && !slices.ContainsFunc(typ.Content, func(t *yaml.Node) bool {
+			return t.Value == "null"
+		}) {
+			typ.Content = append(typ.Content, utils.CreateStringNode("null"))
+		}
+		return
+	}
+}
+
+func convertReplaceKey(n *yaml.Node, from, to string) {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == from {
+			n.Content[i] = utils.CreateStringNode(to)
+			return
+		}
+	}
+}
+
+// rewriteSwaggerRefs rewrites every local swagger reference in the tree to its OpenAPI 3 location.
+func rewriteSwaggerRefs(n *yaml.Node) {
+	if n == nil {
+		return
+	}
+	if n.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			if n.Content[i].Value == "$ref" && n.Content[i+1].Kind == yaml.ScalarNode {
+				n.Content[i+1].Value = convertSwaggerRef(n.Content[i+1].Value)
+			}
+		}
+	}
+	for _, child := range n.Content {
+		rewriteSwaggerRefs(child)
+	}
+}
+
+func convertSwaggerRef(ref string) string {
+	for _, p := range swaggerRefPrefixes {
+		if strings.HasPrefix(ref, p[0]) {
+			return p[1] + strings.TrimPrefix(ref, p[0])
+		}
+	}
+	return ref
+}
+
+func convertSet(n *yaml.Node, key string, value *yaml.Node) {
+	n.Content = append(n.Content, utils.CreateStringNode(key), value)
+}
+
+func convertSetString(n *yaml.Node, key, value string) {
+	if value != "" {
+		convertSet(n, key, utils.CreateStringNode(value))
+	}
+}
+
+func convertSetInt(n *yaml.Node, key string, value int) {
+	if value != 0 {
+		convertSet(n, key, utils.CreateIntNode(strconv.Itoa(value)))
+	}
+}
+
+func convertSetIntPtr(n *yaml.Node, key string, value *int) {
+	if value != nil {
+		convertSet(n, key, utils.CreateIntNode(strconv.Itoa(*value)))
+	}
+}
+
+// convertSetBound sets a minimum or maximum, as an exclusive bound if the swagger exclusive flag is set.
+func convertSetBound(n *yaml.Node, key, exclusiveKey string, value *int, exclusive *bool) {
+	if value == nil {
+		return
+	}
+	if exclusive != nil && *exclusive {
+		key = exclusiveKey
+	}
+	convertSet(n, key, utils.CreateIntNode(strconv.Itoa(*value)))
+}
+
+func convertSetIntBound(n *yaml.Node, key, exclusiveKey string, value int, exclusive bool) {
+	if value == 0 && !exclusive {
+		return
+	}
+	convertSetBound(n, key, exclusiveKey, &value, &exclusive)
+}
+
+func convertSetEnum(n *yaml.Node, enum []*yaml.Node) {
+	if len(enum) == 0 {
+		return
+	}
+	seq := utils.CreateEmptySequenceNode()
+	for _, e := range enum {
+		seq.Content = append(seq.Content, utils.CloneYAMLNode(e))
+	}
+	convertSet(n, "enum", seq)
+}
+
+func convertExtensions(n *yaml.Node, extensions *orderedmap.Map[string, *yaml.Node]) {
+	for k, v := range extensions.FromOldest() {
+		convertSet(n, k, utils.CloneYAMLNode(v))
+	}
+}
+
+type convertRenderable interface {
+	MarshalYAML() (interface{}, error)
+}
+
+func convertRender(r convertRenderable) (*yaml.Node, error) {
+	rendered, err := r.MarshalYAML()
+	if err != nil {
+		return nil, err
+	}
+	if n, ok := rendered.(*yaml.Node); ok && n != nil {
+		return utils.CloneYAMLNode(n), nil
+	}
+	return utils.CreateEmptyMapNode(), nil
+}
+
+func convertSetRendered(n *yaml.Node, key string, r convertRenderable) error {
+	rendered, err := convertRender(r)
+	if err != nil {
+		return err
+	}
+	convertSet(n, key, rendered)
+	return nil
+}
@@ -0,0 +1,178 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/high/v2"
+	lowv2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func buildSwagger(t *testing.T, spec []byte) *v2.Swagger {
+	info, err := datamodel.ExtractSpecInfo(spec)
+	require.NoError(t, err)
+	lowDoc, err := lowv2.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	return v2.NewSwaggerDocument(lowDoc)
+}
+
+func TestConvertSwagger(t *testing.T) {
+	spec := `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+host: pets.example.com
+basePath: /v1
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+x-api: pets
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+    put:
+      operationId: updatePet
+      parameters:
+        - name: tags
+          in: query
+          type: array
+          collectionFormat: multi
+          items:
+            type: string
+        - name: body
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "200":
+          description: ok
+          headers:
+            X-Rate:
+              type: integer
+              minimum: 1
+          schema:
+            $ref: '#/definitions/Pet'
+          examples:
+            application/json:
+              name: fluffy
+  /pets/{id}/photo:
+    post:
+      operationId: uploadPhoto
+      parameters:
+        - name: photo
+          in: formData
+          required: true
+          type: file
+        - name: caption
+          in: formData
+          type: string
+      responses:
+        default:
+          $ref: '#/responses/Error'
+definitions:
+  Pet:
+    type: object
+    discriminator: kind
+    properties:
+      kind:
+        type: string
+      age:
+        type: integer
+        minimum: 0
+        exclusiveMinimum: true
+      owner:
+        type: string
+        x-nullable: true
+responses:
+  Error:
+    description: error
+    schema:
+      type: string
+securityDefinitions:
+  basic:
+    type: basic
+  oauth:
+    type: oauth2
+    flow: accessCode
+    authorizationUrl: https://example.com/auth
+    tokenUrl: https://example.com/token
+    scopes:
+      read: read things`
+
+	doc, err := ConvertSwagger(buildSwagger(t, []byte(spec)), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.1.0", doc.Version)
+	assert.Equal(t, "Pets", doc.Info.Title)
+	assert.Equal(t, "https://pets.example.com/v1", doc.Servers[0].URL)
+	assert.Equal(t, "pets", doc.Extensions.GetOrZero("x-api").Value)
+
+	item := doc.Paths.PathItems.GetOrZero("/pets/{id}")
+	require.NotNil(t, item)
+	assert.Equal(t, "id", item.Parameters[0].Name)
+	assert.Equal(t, []string{"string"}, item.Parameters[0].Schema.Schema().Type)
+
+	put := item.Put
+	require.Len(t, put.Parameters, 1)
+	assert.Equal(t, "form", put.Parameters[0].Style)
+	assert.True(t, *put.Parameters[0].Explode)
+	assert.True(t, *put.RequestBody.Required)
+	body := put.RequestBody.Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pet", body.GetReference())
+
+	ok := put.Responses.Codes.GetOrZero("200")
+	assert.Equal(t, "#/components/schemas/Pet", ok.Content.GetOrZero("application/json").Schema.GetReference())
+	assert.Equal(t, "fluffy", ok.Content.GetOrZero("application/json").Example.Content[1].Value)
+	assert.Equal(t, []string{"integer"}, ok.Headers.GetOrZero("X-Rate").Schema.Schema().Type)
+
+	upload := doc.Paths.PathItems.GetOrZero("/pets/{id}/photo").Post
+	form := upload.RequestBody.Content.GetOrZero("multipart/form-data").Schema.Schema()
+	require.NotNil(t, form)
+	assert.Equal(t, []string{"photo"}, form.Required)
+	assert.Equal(t, "application/octet-stream", form.Properties.GetOrZero("photo").Schema().ContentMediaType)
+	assert.Equal(t, "error", upload.Responses.Default.Description)
+
+	pet := doc.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, "kind", pet.Discriminator.PropertyName)
+	age := pet.Properties.GetOrZero("age").Schema()
+	assert.Nil(t, age.Minimum)
+	assert.Equal(t, float64(0), age.ExclusiveMinimum.B)
+	assert.Equal(t, []string{"string", "null"}, pet.Properties.GetOrZero("owner").Schema().Type)
+
+	assert.Equal(t, "error", doc.Components.Responses.GetOrZero("Error").Description)
+	assert.Equal(t, "http", doc.Components.SecuritySchemes.GetOrZero("basic").Type)
+	assert.Equal(t, "basic", doc.Components.SecuritySchemes.GetOrZero("basic").Scheme)
+	oauth := doc.Components.SecuritySchemes.GetOrZero("oauth")
+	assert.Equal(t, "https://example.com/token", oauth.Flows.AuthorizationCode.TokenUrl)
+	assert.Equal(t, "read things", oauth.Flows.AuthorizationCode.Scopes.GetOrZero("read"))
+}
+
+func TestConvertSwagger_Petstore(t *testing.T) {
+	spec, err := os.ReadFile("../../../test_specs/petstorev2.json")
+	require.NoError(t, err)
+
+	doc, err := ConvertSwagger(buildSwagger(t, spec), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://petstore.swagger.io/v2", doc.Servers[0].URL)
+	assert.Equal(t, 6, doc.Components.Schemas.Len())
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.NotContains(t, string(rendered), "#/definitions/")
+}
+
+func TestConvertSwagger_Nil(t *testing.T) {
+	_, err := ConvertSwagger(nil, nil)
+	assert.Error(t, err)
+}
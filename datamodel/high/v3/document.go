@@ -11,7 +11,10 @@ package v3
 
 import (
 	"bytes"
+	"context"
+	"errors"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -104,6 +107,14 @@ type Document struct {
 
 // NewDocument will create a new high-level Document from a low-level one.
 func NewDocument(document *lowv3.Document) *Document {
+	d, _ := NewDocumentWithContext(context.Background(), document)
+	return d
+}
+
+// NewDocumentWithContext is the same as NewDocument, except building stops as soon as the supplied context is
+// cancelled or times out, and only the context error is returned. Use it with
+// lowv3.CreateDocumentFromConfigWithContext to cancel (or set a deadline for) the whole build of a large document.
+func NewDocumentWithContext(ctx context.Context, document *lowv3.Document) (*Document, error) {
 	d := new(Document)
 	d.low = document
 	d.Index = document.Index
@@ -130,10 +141,17 @@ func NewDocument(document *lowv3.Document) *Document {
 		d.Extensions = high.ExtractExtensions(document.Extensions)
 	}
 	if !document.Components.IsEmpty() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		d.Components = NewComponents(document.Components.Value)
 	}
 	if !document.Paths.IsEmpty() {
-		d.Paths = NewPaths(document.Paths.Value)
+		paths, err := NewPathsWithContext(ctx, document.Paths.Value)
+		if err != nil {
+			return nil, err
+		}
+		d.Paths = paths
 	}
 	if !document.JsonSchemaDialect.IsEmpty() {
 		d.JsonSchemaDialect = document.JsonSchemaDialect.Value
@@ -151,7 +169,32 @@ func NewDocument(document *lowv3.Document) *Document {
 		}
 		d.Security = security
 	}
-	return d
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Clone returns a deep copy of the Document. The low-level node tree is copied, and a new low-level document,
+// index and rolodex are built from the copy, so the clone can be changed freely without disturbing the original
+// (and without parsing the specification again). Changes made to the high-level model that have not been written
+// back to the nodes (see high.Mutate) are not part of the clone.
+//
+// Errors (such as circular references) found when building the clone are returned alongside it, the same as
+// when the original was built.
+func (d *Document) Clone() (*Document, error) {
+	if d.low == nil || d.low.Index == nil || d.low.Index.GetConfig().SpecInfo == nil {
+		return nil, errors.New("unable to clone document, it was not built from a specification")
+	}
+	config := d.low.Configuration
+	if config == nil {
+		config = datamodel.NewDocumentConfiguration()
+	}
+	lowDoc, err := lowv3.CreateDocumentFromConfig(d.low.Index.GetConfig().SpecInfo.Clone(), config)
+	if lowDoc == nil {
+		return nil, err
+	}
+	return NewDocument(lowDoc), err
 }
 
 // GoLow returns the low-level Document that was used to create the high level one.
@@ -0,0 +1,147 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// SetInfo sets the title and version of the document, creating the Info object if the document does not have one.
+func (d *Document) SetInfo(title, version string) *base.Info {
+	if d.Info == nil {
+		d.Info = &base.Info{}
+	}
+	d.Info.Title = title
+	d.Info.Version = version
+	return d.Info
+}
+
+// AddServer adds a server to the document, and returns it.
+func (d *Document) AddServer(url, description string) *Server {
+	server := &Server{URL: url, Description: description}
+	d.Servers = append(d.Servers, server)
+	return server
+}
+
+// AddTag adds a tag to the document, and returns it. If a tag with the same name already exists, the existing tag
+// is given the description, and returned.
+func (d *Document) AddTag(name, description string) *base.Tag {
+	for _, tag := range d.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Description = description
+			return tag
+		}
+	}
+	tag := &base.Tag{Name: name, Description: description}
+	d.Tags = append(d.Tags, tag)
+	return tag
+}
+
+// AddPathItem adds a path item to the document, replacing any path item already using the path. The paths of the
+// document are created if they do not exist.
+func (d *Document) AddPathItem(path string, pathItem *PathItem) *PathItem {
+	if d.Paths == nil {
+		d.Paths = &Paths{}
+	}
+	if d.Paths.PathItems == nil {
+		d.Paths.PathItems = orderedmap.New[string, *PathItem]()
+	}
+	d.Paths.PathItems.Set(path, pathItem)
+	return pathItem
+}
+
+// AddOperation adds an operation to a path of the document, creating the path item if it does not exist. Methods
+// are case-insensitive, any method that is not a fixed field of a path item (for example 'copy') is added as an
+// additional operation (OpenAPI 3.2+). Any operation already using the method is replaced.
+func (d *Document) AddOperation(path, method string, operation *Operation) *Operation {
+	var pathItem *PathItem
+	if d.Paths != nil && d.Paths.PathItems != nil {
+		pathItem = d.Paths.PathItems.GetOrZero(path)
+	}
+	if pathItem == nil {
+		pathItem = d.AddPathItem(path, &PathItem{})
+	}
+	pathItem.SetOperation(method, operation)
+	return operation
+}
+
+// SetOperation sets the operation used by a method of the path item. Methods are case-insensitive, any method that
+// is not a fixed field of a path item is set as an additional operation (OpenAPI 3.2+).
+func (p *PathItem) SetOperation(method string, operation *Operation) {
+	switch strings.ToLower(method) {
+	case lowv3.GetLabel:
+		p.Get = operation
+	case lowv3.PutLabel:
+		p.Put = operation
+	case lowv3.PostLabel:
+		p.Post = operation
+	case lowv3.DeleteLabel:
+		p.Delete = operation
+	case lowv3.OptionsLabel:
+		p.Options = operation
+	case lowv3.HeadLabel:
+		p.Head = operation
+	case lowv3.PatchLabel:
+		p.Patch = operation
+	case lowv3.TraceLabel:
+		p.Trace = operation
+	case lowv3.QueryLabel:
+		p.Query = operation
+	default:
+		if p.AdditionalOperations == nil {
+			p.AdditionalOperations = orderedmap.New[string, *Operation]()
+		}
+		p.AdditionalOperations.Set(method, operation)
+	}
+}
+
+// AddWebhook adds a webhook (OpenAPI 3.1+) to the document, replacing any webhook already using the name.
+func (d *Document) AddWebhook(name string, pathItem *PathItem) *PathItem {
+	if d.Webhooks == nil {
+		d.Webhooks = orderedmap.New[string, *PathItem]()
+	}
+	d.Webhooks.Set(name, pathItem)
+	return pathItem
+}
+
+// AddSchema adds a schema to the components of the document, replacing any schema already using the name. The
+// components are created if they do not exist. The returned proxy can be used to reference the schema from
+// elsewhere in the document, see ComponentSchemaRef.
+func (d *Document) AddSchema(name string, schema *base.Schema) *base.SchemaProxy {
+	proxy := base.CreateSchemaProxy(schema)
+	c := d.components()
+	if c.Schemas == nil {
+		c.Schemas = orderedmap.New[string, *base.SchemaProxy]()
+	}
+	c.Schemas.Set(name, proxy)
+	return proxy
+}
+
+// AddSecurityScheme adds a security scheme to the components of the document, replacing any security scheme
+// already using the name. The components are created if they do not exist.
+func (d *Document) AddSecurityScheme(name string, scheme *SecurityScheme) *SecurityScheme {
+	c := d.components()
+	if c.SecuritySchemes == nil {
+		c.SecuritySchemes = orderedmap.New[string, *SecurityScheme]()
+	}
+	c.SecuritySchemes.Set(name, scheme)
+	return scheme
+}
+
+// ComponentSchemaRef returns a schema proxy that references a schema in the components of the document.
+func ComponentSchemaRef(name string) *base.SchemaProxy {
+	return base.CreateSchemaProxyRef("#/components/schemas/" + name)
+}
+
+// components returns the components of the document, creating them if they do not exist.
+func (d *Document) components() *Components {
+	if d.Components == nil {
+		d.Components = &Components{}
+	}
+	return d.Components
+}
@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"log/slog"
@@ -73,6 +74,19 @@ func TestNewDocument_Security(t *testing.T) {
 	assert.Len(t, h.Security[0].Requirements.GetOrZero("OAuthScheme"), 2)
 }
 
+func TestNewDocumentWithContext(t *testing.T) {
+	initTest()
+	h, err := NewDocumentWithContext(context.Background(), lowDoc)
+	assert.NoError(t, err)
+	assert.Equal(t, NewDocument(lowDoc).Paths.PathItems.Len(), h.Paths.PathItems.Len())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	h, err = NewDocumentWithContext(ctx, lowDoc)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, h)
+}
+
 func TestNewDocument_Info(t *testing.T) {
 	initTest()
 	highDoc := NewDocument(lowDoc)
@@ -898,3 +912,23 @@ $self: https://pb33f.io/super-cool-schema
 
 	assert.Equal(t, h.Self, "https://pb33f.io/super-cool-schema")
 }
+
+func TestDocument_Clone(t *testing.T) {
+	initTest()
+	h := NewDocument(lowDoc)
+
+	clone, err := h.Clone()
+	assert.NoError(t, err)
+	assert.NotSame(t, h.GoLow().RootNode, clone.GoLow().RootNode)
+	assert.NotSame(t, h.Index, clone.Index)
+	assert.NotSame(t, h.GoLow().Rolodex, clone.GoLow().Rolodex)
+	assert.Same(t, lowDoc.Configuration, clone.GoLow().Configuration)
+	assert.Equal(t, h.Info.Title, clone.Info.Title)
+	assert.Equal(t, h.Paths.PathItems.Len(), clone.Paths.PathItems.Len())
+
+	clone.GoLow().RootNode.Content[0].Value = "changed"
+	assert.NotEqual(t, "changed", h.GoLow().RootNode.Content[0].Value)
+
+	_, err = (&Document{}).Clone()
+	assert.Error(t, err)
+}
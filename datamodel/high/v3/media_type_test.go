@@ -222,3 +222,28 @@ func TestMediaType_MarshalYAMLInlineWithContext(t *testing.T) {
 	rend, _ := yaml.Marshal(node)
 	assert.Len(t, rend, 290)
 }
+
+func TestMediaType_PrefixEncoding(t *testing.T) {
+	yml := `itemSchema:
+    type: string
+prefixEncoding:
+    - contentType: application/json
+    - contentType: image/png`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n v3.MediaType
+	_ = low.BuildModel(&idxNode, &n)
+	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.Len(t, n.PrefixEncoding.Value, 2)
+	assert.Equal(t, 3, n.PrefixEncoding.KeyNode.Line)
+
+	r := NewMediaType(&n)
+	assert.Len(t, r.PrefixEncoding, 2)
+	assert.Equal(t, "image/png", r.PrefixEncoding[1].ContentType)
+
+	rend, _ := r.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
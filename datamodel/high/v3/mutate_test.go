@@ -0,0 +1,82 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestMutate_MinimalDiff(t *testing.T) {
+	spec := `openapi: 3.1.0
+# the api
+info:
+  title: Pets # the title
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      description: old description
+      x-keep: true
+      responses:
+        "200":
+          description: ok
+  /toys:
+    get:
+      summary: toys
+      responses:
+        "200":
+          description: ok
+`
+	info, err := datamodel.ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewDocument(lowDoc)
+
+	pets := doc.Paths.PathItems.GetOrZero("/pets")
+	require.NoError(t, high.Mutate(pets.Get, func(op *Operation) {
+		op.Description = "new description"
+		op.OperationId = "listPets"
+	}))
+	require.NoError(t, high.Mutate(doc, func(d *Document) {
+		d.Info.Title = "Pet Store"
+		d.Paths.PathItems.Delete("/toys")
+		d.AddOperation("/pets", "post", &Operation{OperationId: "createPet"})
+	}))
+
+	out, err := yaml.Marshal(info.RootNode)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+# the api
+info:
+    title: Pet Store # the title
+    version: "1.0"
+paths:
+    /pets:
+        get:
+            description: new description
+            x-keep: true
+            responses:
+                "200":
+                    description: ok
+            operationId: listPets
+        post:
+            operationId: createPet
+`, string(out))
+
+	// the mutated nodes are the nodes of the low-level model.
+	assert.Equal(t, "new description", pets.Get.GoLow().RootNode.Content[1].Value)
+}
+
+func TestMutate_NoLowModel(t *testing.T) {
+	err := high.Mutate(&Operation{}, func(op *Operation) { op.Summary = "nope" })
+	assert.ErrorIs(t, err, high.ErrNoLowRootNode)
+}
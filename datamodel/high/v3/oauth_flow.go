@@ -14,12 +14,13 @@ import (
 // OAuthFlow represents a high-level OpenAPI 3+ OAuthFlow object that is backed by a low-level one.
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl string                              `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
-	TokenUrl         string                              `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
-	RefreshUrl       string                              `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
-	Scopes           *orderedmap.Map[string, string]     `json:"scopes,renderZero" yaml:"scopes,renderZero"`
-	Extensions       *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
-	low              *lowv3.OAuthFlow
+	AuthorizationUrl       string                              `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	DeviceAuthorizationUrl string                              `json:"deviceAuthorizationUrl,omitempty" yaml:"deviceAuthorizationUrl,omitempty"` // OpenAPI 3.2+ device flow
+	TokenUrl               string                              `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	RefreshUrl             string                              `json:"refreshUrl,omitempty" yaml:"refreshUrl,omitempty"`
+	Scopes                 *orderedmap.Map[string, string]     `json:"scopes,renderZero" yaml:"scopes,renderZero"`
+	Extensions             *orderedmap.Map[string, *yaml.Node] `json:"-" yaml:"-"`
+	low                    *lowv3.OAuthFlow
 }
 
 // NewOAuthFlow creates a new high-level OAuthFlow instance from a low-level one.
@@ -28,6 +29,7 @@ func NewOAuthFlow(flow *lowv3.OAuthFlow) *OAuthFlow {
 	o.low = flow
 	o.TokenUrl = flow.TokenUrl.Value
 	o.AuthorizationUrl = flow.AuthorizationUrl.Value
+	o.DeviceAuthorizationUrl = flow.DeviceAuthorizationUrl.Value
 	o.RefreshUrl = flow.RefreshUrl.Value
 	o.Scopes = low.FromReferenceMap(flow.Scopes.Value)
 	o.Extensions = high.ExtractExtensions(flow.Extensions)
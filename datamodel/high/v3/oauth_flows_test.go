@@ -23,6 +23,7 @@ func TestNewOAuthFlows_WithDevice(t *testing.T) {
         write:burgers: modify and add new burgers implicitly
         read:burgers: read all burgers
 device:
+    deviceAuthorizationUrl: https://pb33f.io/oauth/device/authorize
     tokenUrl: https://pb33f.io/oauth/device/token
     scopes:
         write:burgers: modify burgers using device flow
@@ -41,6 +42,8 @@ device:
 	// Test that device flow was parsed
 	assert.NotNil(t, r.Device)
 	assert.Equal(t, "https://pb33f.io/oauth/device/token", r.Device.TokenUrl)
+	assert.Equal(t, "https://pb33f.io/oauth/device/authorize", r.Device.DeviceAuthorizationUrl)
+	assert.Equal(t, "https://pb33f.io/oauth/device/authorize", n.Device.Value.DeviceAuthorizationUrl.Value)
 	assert.NotNil(t, r.Device.Scopes)
 	assert.Equal(t, 2, r.Device.Scopes.Len())
 }
@@ -0,0 +1,190 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultOperationIdTemplate is the template used by AssignOperationIds when no template is supplied. It creates
+// operationIds like 'getPetsPetIdToys' for 'GET /pets/{petId}/toys'.
+const DefaultOperationIdTemplate = "{method}{PathCamelCase}"
+
+// OperationIdAssignment describes an operationId that was assigned to an operation by AssignOperationIds.
+type OperationIdAssignment struct {
+	// Path is the path (or the name of the webhook) the operation belongs to.
+	Path string `json:"path" yaml:"path"`
+
+	// Method is the method of the operation, for example 'get'.
+	Method string `json:"method" yaml:"method"`
+
+	// Webhook is true if the operation belongs to a webhook, rather than a path.
+	Webhook bool `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+
+	// Previous is the operationId the operation had before it was changed, it is empty if the operation did not
+	// have one.
+	Previous string `json:"previous,omitempty" yaml:"previous,omitempty"`
+
+	// OperationId is the operationId assigned to the operation.
+	OperationId string `json:"operationId" yaml:"operationId"`
+
+	// Operation is the operation that was changed.
+	Operation *Operation `json:"-" yaml:"-"`
+}
+
+// Duplicate returns true if the operation already had an operationId, that was changed because it clashed with
+// the operationId of another operation.
+func (a *OperationIdAssignment) Duplicate() bool {
+	return a.Previous != ""
+}
+
+// AssignOperationIds makes sure every operation in the document (found in paths and webhooks) has an operationId,
+// and that every operationId is unique. The document is changed in place, and every change made is returned, in
+// document order.
+//
+// Operations without an operationId are given one generated from the template, which can use the following
+// placeholders:
+//
+//   - {method} the method in lower case, for example 'get'.
+//   - {Method} the method with the first letter in upper case, for example 'Get'.
+//   - {PathCamelCase} the path in upper camel case, for example 'PetsPetIdToys' for '/pets/{petId}/toys'.
+//   - {pathCamelCase} the path in lower camel case, for example 'petsPetIdToys'.
+//   - {tag} the first tag of the operation in lower camel case, or empty if there are no tags.
+//   - {Tag} the first tag of the operation in upper camel case.
+//
+// If the template is empty, DefaultOperationIdTemplate is used. The root path '/' is named 'Root'.
+//
+// The first operation to use an operationId keeps it, any later operations that use the same operationId (or
+// generate one that is already in use) have a number appended, starting at 2, for example 'getPets2'. The same
+// document and template always produce the same operationIds.
+func (d *Document) AssignOperationIds(template string) []*OperationIdAssignment {
+	if d == nil {
+		return nil
+	}
+	if template == "" {
+		template = DefaultOperationIdTemplate
+	}
+
+	type pathOperation struct {
+		path    string
+		method  string
+		webhook bool
+		op      *Operation
+	}
+	var operations []*pathOperation
+	if d.Paths != nil {
+		for path, pathItem := range d.Paths.PathItems.FromOldest() {
+			if pathItem == nil {
+				continue
+			}
+			for method, op := range pathItem.GetOperations().FromOldest() {
+				operations = append(operations, &pathOperation{path: path, method: method, op: op})
+			}
+		}
+	}
+	for name, pathItem := range d.Webhooks.FromOldest() {
+		if pathItem == nil {
+			continue
+		}
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			operations = append(operations, &pathOperation{path: name, method: method, webhook: true, op: op})
+		}
+	}
+
+	// every operationId already defined is reserved by the first operation that uses it, so existing
+	// operationIds are never changed to make room for generated ones.
+	used := make(map[string]struct{})
+	owner := make(map[string]*Operation)
+	for _, po := range operations {
+		if id := po.op.OperationId; id != "" {
+			if _, ok := owner[id]; !ok {
+				owner[id] = po.op
+				used[id] = struct{}{}
+			}
+		}
+	}
+
+	var assigned []*OperationIdAssignment
+	for _, po := range operations {
+		previous := po.op.OperationId
+		if previous != "" && owner[previous] == po.op {
+			continue
+		}
+		base := previous
+		if base == "" {
+			base = generateOperationId(template, po.method, po.path, po.op)
+		}
+		id := base
+		for i := 2; ; i++ {
+			if _, ok := used[id]; !ok && id != "" {
+				break
+			}
+			id = base + strconv.Itoa(i)
+		}
+		used[id] = struct{}{}
+		po.op.OperationId = id
+		assigned = append(assigned, &OperationIdAssignment{
+			Path:        po.path,
+			Method:      po.method,
+			Webhook:     po.webhook,
+			Previous:    previous,
+			OperationId: id,
+			Operation:   po.op,
+		})
+	}
+	return assigned
+}
+
+// generateOperationId renders an operationId template for an operation.
+func generateOperationId(template, method, path string, op *Operation) string {
+	pathName := camelCase(path)
+	if pathName == "" {
+		pathName = "Root"
+	}
+	tag := ""
+	if len(op.Tags) > 0 {
+		tag = camelCase(op.Tags[0])
+	}
+	method = strings.ToLower(method)
+	return strings.NewReplacer(
+		"{method}", method,
+		"{Method}", upperFirst(method),
+		"{PathCamelCase}", pathName,
+		"{pathCamelCase}", lowerFirst(pathName),
+		"{tag}", lowerFirst(tag),
+		"{Tag}", tag,
+	).Replace(template)
+}
+
+// camelCase splits a value into words on any character that is not a letter or a digit, and joins them back
+// together in upper camel case. '/pets/{petId}/toy-box' becomes 'PetsPetIdToyBox'.
+func camelCase(value string) string {
+	var b strings.Builder
+	for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		b.WriteString(upperFirst(word))
+	}
+	return b.String()
+}
+
+func upperFirst(value string) string {
+	if value == "" {
+		return value
+	}
+	r := []rune(value)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(value string) string {
+	if value == "" {
+		return value
+	}
+	r := []rune(value)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
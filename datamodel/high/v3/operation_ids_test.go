@@ -0,0 +1,95 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	lowv3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_AssignOperationIds(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /:
+    get:
+      responses: {}
+  /pets:
+    get:
+      operationId: listPets
+    post:
+      operationId: listPets
+  /pets/{petId}/toy-box:
+    put:
+      tags: [toys]
+    delete:
+      operationId: deletePetsPetIdToyBox
+  /pets/{petId}.toy-box:
+    delete: {}
+webhooks:
+  newPet:
+    post: {}`
+
+	info, err := datamodel.ExtractSpecInfo([]byte(yml))
+	require.NoError(t, err)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewDocument(lowDoc)
+
+	assigned := doc.AssignOperationIds("")
+	require.Len(t, assigned, 5)
+
+	var ids []string
+	for _, a := range assigned {
+		ids = append(ids, a.Method+" "+a.Path+" "+a.OperationId)
+	}
+	assert.Equal(t, []string{
+		"get / getRoot",
+		"post /pets listPets2",
+		"put /pets/{petId}/toy-box putPetsPetIdToyBox",
+		"delete /pets/{petId}.toy-box deletePetsPetIdToyBox2",
+		"post newPet postNewPet",
+	}, ids)
+
+	assert.True(t, assigned[1].Duplicate())
+	assert.Equal(t, "listPets", assigned[1].Previous)
+	assert.False(t, assigned[0].Duplicate())
+	assert.True(t, assigned[4].Webhook)
+	assert.Equal(t, "listPets2", doc.Paths.PathItems.GetOrZero("/pets").Post.OperationId)
+	assert.Same(t, doc.Paths.PathItems.GetOrZero("/pets").Post, assigned[1].Operation)
+
+	// everything is now unique, so nothing else changes.
+	assert.Empty(t, doc.AssignOperationIds(""))
+
+	rendered, err := doc.Render()
+	require.NoError(t, err)
+	assert.True(t, strings.Contains(string(rendered), "operationId: getRoot"))
+}
+
+func TestDocument_AssignOperationIds_Template(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    get:
+      tags: [pet store]
+    post: {}`
+
+	info, err := datamodel.ExtractSpecInfo([]byte(yml))
+	require.NoError(t, err)
+	lowDoc, err := lowv3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	require.NoError(t, err)
+	doc := NewDocument(lowDoc)
+
+	assigned := doc.AssignOperationIds("{tag}{Method}{pathCamelCase}")
+	require.Len(t, assigned, 2)
+	assert.Equal(t, "petStoreGetpets", assigned[0].OperationId)
+	assert.Equal(t, "Postpets", assigned[1].OperationId)
+
+	var nilDoc *Document
+	assert.Nil(t, nilDoc.AssignOperationIds(""))
+}
@@ -0,0 +1,102 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"sort"
+
+	"github.com/pb33f/libopenapi/datamodel/high"
+	lowmodel "github.com/pb33f/libopenapi/datamodel/low"
+	low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"go.yaml.in/yaml/v4"
+)
+
+// CodesInOrder returns the response codes in the order they were defined in the original document, with the key
+// node of each code attached. The default response is not included.
+func (r *Responses) CodesInOrder() []*high.OrderedEntry[*Response] {
+	if r.low == nil {
+		return high.InDocumentOrder[*Response, lowmodel.ValueReference[*low.Response]](r.Codes, nil)
+	}
+	return high.InDocumentOrder(r.Codes, r.low.Codes)
+}
+
+// ContentInOrder returns the media types of the response in the order they were defined in the original document,
+// with the key node of each media type attached.
+func (r *Response) ContentInOrder() []*high.OrderedEntry[*MediaType] {
+	if r.low == nil {
+		return high.InDocumentOrder[*MediaType, lowmodel.ValueReference[*low.MediaType]](r.Content, nil)
+	}
+	return high.InDocumentOrder(r.Content, r.low.Content.Value)
+}
+
+// ContentInOrder returns the media types of the request body in the order they were defined in the original
+// document, with the key node of each media type attached.
+func (r *RequestBody) ContentInOrder() []*high.OrderedEntry[*MediaType] {
+	if r.low == nil {
+		return high.InDocumentOrder[*MediaType, lowmodel.ValueReference[*low.MediaType]](r.Content, nil)
+	}
+	return high.InDocumentOrder(r.Content, r.low.Content.Value)
+}
+
+// ParametersInOrder returns the parameters of the operation in the order they were defined in the original document,
+// keyed by name. The key node of each entry is the node the parameter was defined with in the list (the $ref node
+// for references).
+func (o *Operation) ParametersInOrder() []*high.OrderedEntry[*Parameter] {
+	var lowParams []lowmodel.ValueReference[*low.Parameter]
+	if o.low != nil {
+		lowParams = o.low.Parameters.Value
+	}
+	return parametersInOrder(o.Parameters, lowParams)
+}
+
+// ParametersInOrder returns the parameters shared by every operation of the path item, in the order they were
+// defined in the original document, keyed by name. The key node of each entry is the node the parameter was defined
+// with in the list (the $ref node for references).
+func (p *PathItem) ParametersInOrder() []*high.OrderedEntry[*Parameter] {
+	var lowParams []lowmodel.ValueReference[*low.Parameter]
+	if p.low != nil {
+		lowParams = p.low.Parameters.Value
+	}
+	return parametersInOrder(p.Parameters, lowParams)
+}
+
+func parametersInOrder(params []*Parameter, lowParams []lowmodel.ValueReference[*low.Parameter]) []*high.OrderedEntry[*Parameter] {
+	if len(params) == 0 {
+		return nil
+	}
+	type position struct {
+		index   int
+		keyNode *yaml.Node
+	}
+	positions := make(map[*low.Parameter]position, len(lowParams))
+	for i, lp := range lowParams {
+		node := lp.ValueNode
+		if lp.IsReference() {
+			node = lp.GetReferenceNode()
+		}
+		positions[lp.Value] = position{index: i, keyNode: node}
+	}
+
+	type entry struct {
+		order int
+		entry *high.OrderedEntry[*Parameter]
+	}
+	entries := make([]entry, len(params))
+	for i, param := range params {
+		e := entry{order: len(lowParams) + i, entry: &high.OrderedEntry[*Parameter]{Key: param.Name, Value: param}}
+		if p, ok := positions[param.low]; ok && param.low != nil {
+			e.order = p.index
+			e.entry.KeyNode = p.keyNode
+		}
+		entries[i] = e
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].order < entries[j].order
+	})
+	ordered := make([]*high.OrderedEntry[*Parameter], len(entries))
+	for i := range entries {
+		ordered[i] = entries[i].entry
+	}
+	return ordered
+}
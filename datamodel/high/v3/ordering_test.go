@@ -0,0 +1,127 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package v3
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestOperation_InOrder(t *testing.T) {
+	yml := `components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+paths:
+  /pets:
+    parameters:
+      - name: shared
+        in: header
+    post:
+      parameters:
+        - name: zebra
+          in: query
+        - $ref: '#/components/parameters/limit'
+        - name: apple
+          in: query
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+          application/json:
+            schema:
+              type: object
+      responses:
+        "500":
+          description: bad
+        "200":
+          description: good
+          content:
+            application/xml:
+              schema:
+                type: object
+            application/json:
+              schema:
+                type: object
+        "404":
+          description: missing`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	var n v3.Paths
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	_, pathsNode := utils.FindKeyNodeTop("paths", idxNode.Content[0].Content)
+	_ = n.Build(context.Background(), nil, pathsNode, idx)
+
+	paths := NewPaths(&n)
+	pathItem := paths.PathItems.GetOrZero("/pets")
+	op := pathItem.Post
+
+	params := op.ParametersInOrder()
+	assert.Len(t, params, 3)
+	assert.Equal(t, "zebra", params[0].Key)
+	assert.Equal(t, "limit", params[1].Key)
+	assert.Equal(t, "apple", params[2].Key)
+	assert.Equal(t, 13, params[0].KeyNode.Line)
+	assert.Equal(t, 15, params[1].KeyNode.Line)
+	assert.Equal(t, 16, params[2].KeyNode.Line)
+
+	shared := pathItem.ParametersInOrder()
+	assert.Len(t, shared, 1)
+	assert.Equal(t, "shared", shared[0].Key)
+
+	content := op.RequestBody.ContentInOrder()
+	assert.Len(t, content, 2)
+	assert.Equal(t, "text/plain", content[0].Key)
+	assert.Equal(t, "application/json", content[1].Key)
+	assert.Equal(t, 20, content[0].KeyNode.Line)
+
+	codes := op.Responses.CodesInOrder()
+	assert.Len(t, codes, 3)
+	assert.Equal(t, "500", codes[0].Key)
+	assert.Equal(t, "200", codes[1].Key)
+	assert.Equal(t, "404", codes[2].Key)
+	assert.Equal(t, 27, codes[0].KeyNode.Line)
+
+	// entries added after the model was built come last, without a key node.
+	op.Responses.Codes.Set("201", &Response{Description: "created"})
+	codes = op.Responses.CodesInOrder()
+	assert.Len(t, codes, 4)
+	assert.Equal(t, "201", codes[3].Key)
+	assert.Nil(t, codes[3].KeyNode)
+
+	mediaTypes := codes[1].Value.ContentInOrder()
+	assert.Equal(t, "application/xml", mediaTypes[0].Key)
+	assert.Equal(t, "application/json", mediaTypes[1].Key)
+
+	op.Parameters = append([]*Parameter{{Name: "new"}}, op.Parameters...)
+	params = op.ParametersInOrder()
+	assert.Len(t, params, 4)
+	assert.Equal(t, "zebra", params[0].Key)
+	assert.Equal(t, "new", params[3].Key)
+	assert.Nil(t, params[3].KeyNode)
+}
+
+func TestInOrder_NoLow(t *testing.T) {
+	assert.Nil(t, (&Responses{}).CodesInOrder())
+	assert.Nil(t, (&Response{}).ContentInOrder())
+	assert.Nil(t, (&RequestBody{}).ContentInOrder())
+	assert.Nil(t, (&Operation{}).ParametersInOrder())
+	assert.Nil(t, (&PathItem{}).ParametersInOrder())
+
+	params := (&Operation{Parameters: []*Parameter{{Name: "a"}, {Name: "b"}}}).ParametersInOrder()
+	assert.Equal(t, "a", params[0].Key)
+	assert.Equal(t, "b", params[1].Key)
+}
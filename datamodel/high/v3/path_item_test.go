@@ -673,3 +673,28 @@ paths: {}`
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 }
+
+func TestNewPathItem_AdditionalOperations_FromBuild(t *testing.T) {
+	yml := `get:
+    operationId: getPet
+additionalOperations:
+    COPY:
+        operationId: copyPet`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n lowV3.PathItem
+	_ = low.BuildModel(&idxNode, &n)
+	err := n.Build(context.Background(), nil, idxNode.Content[0], idx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n.AdditionalOperations.KeyNode.Line)
+
+	r := NewPathItem(&n)
+	assert.Equal(t, 1, r.AdditionalOperations.Len())
+	assert.Equal(t, "copyPet", r.AdditionalOperations.GetOrZero("COPY").OperationId)
+
+	rend, _ := r.Render()
+	assert.Equal(t, yml, strings.TrimSpace(string(rend)))
+}
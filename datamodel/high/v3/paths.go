@@ -4,6 +4,7 @@
 package v3
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -30,6 +31,13 @@ type Paths struct {
 
 // NewPaths creates a new high-level instance of Paths from a low-level one.
 func NewPaths(paths *v3low.Paths) *Paths {
+	p, _ := NewPathsWithContext(context.Background(), paths)
+	return p
+}
+
+// NewPathsWithContext is the same as NewPaths, except building stops as soon as the supplied context is cancelled
+// or times out, and only the context error is returned.
+func NewPathsWithContext(ctx context.Context, paths *v3low.Paths) (*Paths, error) {
 	p := new(Paths)
 	p.low = paths
 	p.Extensions = high.ExtractExtensions(paths.Extensions)
@@ -41,17 +49,23 @@ func NewPaths(paths *v3low.Paths) *Paths {
 	}
 
 	translateFunc := func(pair orderedmap.Pair[low.KeyReference[string], low.ValueReference[*v3low.PathItem]]) (pathItemResult, error) {
+		if err := ctx.Err(); err != nil {
+			return pathItemResult{}, err
+		}
 		return pathItemResult{key: pair.Key().Value, value: NewPathItem(pair.Value().Value)}, nil
 	}
 	resultFunc := func(value pathItemResult) error {
 		items.Set(value.key, value.value)
 		return nil
 	}
-	_ = datamodel.TranslateMapParallel[low.KeyReference[string], low.ValueReference[*v3low.PathItem], pathItemResult](
+	err := datamodel.TranslateMapParallel[low.KeyReference[string], low.ValueReference[*v3low.PathItem], pathItemResult](
 		paths.PathItems, translateFunc, resultFunc,
 	)
+	if err != nil {
+		return nil, err
+	}
 	p.PathItems = items
-	return p
+	return p, nil
 }
 
 // GoLow returns the low-level Paths instance used to create the high-level one.
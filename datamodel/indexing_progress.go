@@ -0,0 +1,27 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+// IndexingProgress is a snapshot of how far indexing a document, and every file it refers to, has come. Files are
+// discovered as references to them are found, so FilesDiscovered grows while indexing runs, and is only the total
+// once indexing is complete.
+type IndexingProgress struct {
+	// FilesDiscovered is the number of files (local and remote, including the root document) found so far.
+	FilesDiscovered int
+
+	// FilesIndexed is the number of discovered files that have been indexed.
+	FilesIndexed int
+
+	// ReferencesResolved is the number of references, across every file, that have been located.
+	ReferencesResolved int
+
+	// CurrentFile is the path or URL of the file the progress was made in. It's empty for a root document that was
+	// not read from a file.
+	CurrentFile string
+}
+
+// IndexingProgressHandler is called every time a file is discovered or indexed, or references are resolved, while a
+// document is being indexed. Indexing runs across many goroutines, so a handler can be called concurrently and must
+// be safe to do so. Handlers should return quickly, as they are called inline.
+type IndexingProgressHandler func(progress *IndexingProgress)
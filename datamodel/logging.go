@@ -0,0 +1,64 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"log/slog"
+	"os"
+)
+
+// LogSubsystem identifies the part of libopenapi that is writing a log entry. Each subsystem can be given its own
+// logger (and therefore its own level and handler), using the SubsystemLoggers property of DocumentConfiguration.
+type LogSubsystem string
+
+const (
+	// LogSubsystemIndex is the index and the rolodex, including local and remote file systems.
+	LogSubsystemIndex LogSubsystem = "index"
+
+	// LogSubsystemResolver is the resolver, which checks for and resolves circular references.
+	LogSubsystemResolver LogSubsystem = "resolver"
+
+	// LogSubsystemBundler is the bundler, which inlines and composes references.
+	LogSubsystemBundler LogSubsystem = "bundler"
+
+	// LogSubsystemBuilder is the low-level and high-level model builders.
+	LogSubsystemBuilder LogSubsystem = "builder"
+)
+
+// Structured attribute keys used consistently by every subsystem when logging.
+const (
+	// LogKeySubsystem is the LogSubsystem that wrote the log entry, it is added to every subsystem logger.
+	LogKeySubsystem = "subsystem"
+
+	// LogKeyFile is the path or URL of the file being logged about.
+	LogKeyFile = "file"
+
+	// LogKeyRef is the reference ($ref value) being logged about.
+	LogKeyRef = "ref"
+
+	// LogKeyLine is the line number in the file being logged about.
+	LogKeyLine = "line"
+)
+
+// SubsystemLogger returns the logger to use for a subsystem. If the subsystem has a logger in the supplied map,
+// it is used, otherwise the fallback logger is used. If both are nil, a default logger set to the Error level is used.
+// The returned logger adds the subsystem name to every entry, using the LogKeySubsystem attribute.
+func SubsystemLogger(loggers map[LogSubsystem]*slog.Logger, fallback *slog.Logger, subsystem LogSubsystem) *slog.Logger {
+	logger := loggers[subsystem]
+	if logger == nil {
+		logger = fallback
+	}
+	if logger == nil {
+		logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+			Level: slog.LevelError,
+		}))
+	}
+	return logger.With(LogKeySubsystem, string(subsystem))
+}
+
+// GetSubsystemLogger returns the logger to use for a subsystem, falling back to Logger if the subsystem has no
+// logger of its own. See SubsystemLogger for details.
+func (c *DocumentConfiguration) GetSubsystemLogger(subsystem LogSubsystem) *slog.Logger {
+	return SubsystemLogger(c.SubsystemLoggers, c.Logger, subsystem)
+}
@@ -0,0 +1,40 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubsystemLogger(t *testing.T) {
+	var fallback, resolver bytes.Buffer
+	config := &DocumentConfiguration{
+		Logger: slog.New(slog.NewJSONHandler(&fallback, nil)),
+		SubsystemLoggers: map[LogSubsystem]*slog.Logger{
+			LogSubsystemResolver: slog.New(slog.NewJSONHandler(&resolver, &slog.HandlerOptions{Level: slog.LevelDebug})),
+		},
+	}
+
+	config.GetSubsystemLogger(LogSubsystemResolver).Debug("resolving", LogKeyRef, "#/components/schemas/Pet")
+	assert.Contains(t, resolver.String(), `"subsystem":"resolver"`)
+	assert.Contains(t, resolver.String(), `"ref":"#/components/schemas/Pet"`)
+
+	config.GetSubsystemLogger(LogSubsystemIndex).Debug("too quiet")
+	config.GetSubsystemLogger(LogSubsystemIndex).Info("indexing")
+	assert.NotContains(t, fallback.String(), "too quiet")
+	assert.Contains(t, fallback.String(), `"subsystem":"index"`)
+	assert.NotContains(t, resolver.String(), "indexing")
+}
+
+func TestSubsystemLogger_Default(t *testing.T) {
+	logger := SubsystemLogger(nil, nil, LogSubsystemBuilder)
+	assert.NotNil(t, logger)
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelError))
+}
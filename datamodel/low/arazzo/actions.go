@@ -0,0 +1,233 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// SuccessAction represents a low-level Arazzo Success Action Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#success-action-object
+type SuccessAction struct {
+	Name       low.NodeReference[string]
+	Type       low.NodeReference[string]
+	WorkflowId low.NodeReference[string]
+	StepId     low.NodeReference[string]
+	Criteria   low.NodeReference[[]low.ValueReference[*Criterion]]
+	Reference  low.NodeReference[string]
+	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	index      *index.SpecIndex
+	context    context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the SuccessAction object
+func (s *SuccessAction) GetIndex() *index.SpecIndex {
+	return s.index
+}
+
+// GetContext returns the context.Context instance used when building the SuccessAction object
+func (s *SuccessAction) GetContext() context.Context {
+	return s.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (s *SuccessAction) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the SuccessAction object
+func (s *SuccessAction) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the SuccessAction object
+func (s *SuccessAction) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// Build will extract the criteria and extensions for the SuccessAction object.
+func (s *SuccessAction) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	s.RootNode = root
+	utils.CheckForMergeNodes(root)
+	s.Nodes = low.ExtractNodes(ctx, root)
+	s.Extensions = low.ExtractExtensions(root)
+	s.index = idx
+	s.context = ctx
+	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
+
+	criteria, err := extractArray[*Criterion](ctx, CriteriaLabel, root, idx)
+	if err != nil {
+		return err
+	}
+	s.Criteria = criteria
+	return nil
+}
+
+// GetExtensions returns all SuccessAction extensions and satisfies the low.HasExtensions interface.
+func (s *SuccessAction) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.Extensions
+}
+
+// Hash will return a consistent Hash of the SuccessAction object
+func (s *SuccessAction) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !s.Name.IsEmpty() {
+			h.WriteString(s.Name.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.Type.IsEmpty() {
+			h.WriteString(s.Type.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.WorkflowId.IsEmpty() {
+			h.WriteString(s.WorkflowId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.StepId.IsEmpty() {
+			h.WriteString(s.StepId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, s.Criteria.Value)
+		if !s.Reference.IsEmpty() {
+			h.WriteString(s.Reference.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(s.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// IsReusable returns true if the SuccessAction is a Reusable Object, referring to an action defined by the components.
+func (s *SuccessAction) IsReusable() bool {
+	return !s.Reference.IsEmpty()
+}
+
+// FailureAction represents a low-level Arazzo Failure Action Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#failure-action-object
+type FailureAction struct {
+	Name       low.NodeReference[string]
+	Type       low.NodeReference[string]
+	WorkflowId low.NodeReference[string]
+	StepId     low.NodeReference[string]
+	RetryAfter low.NodeReference[float64]
+	RetryLimit low.NodeReference[int64]
+	Criteria   low.NodeReference[[]low.ValueReference[*Criterion]]
+	Reference  low.NodeReference[string]
+	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	index      *index.SpecIndex
+	context    context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the FailureAction object
+func (f *FailureAction) GetIndex() *index.SpecIndex {
+	return f.index
+}
+
+// GetContext returns the context.Context instance used when building the FailureAction object
+func (f *FailureAction) GetContext() context.Context {
+	return f.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (f *FailureAction) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, f.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the FailureAction object
+func (f *FailureAction) GetRootNode() *yaml.Node {
+	return f.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the FailureAction object
+func (f *FailureAction) GetKeyNode() *yaml.Node {
+	return f.KeyNode
+}
+
+// Build will extract the criteria and extensions for the FailureAction object.
+func (f *FailureAction) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	f.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	f.RootNode = root
+	utils.CheckForMergeNodes(root)
+	f.Nodes = low.ExtractNodes(ctx, root)
+	f.Extensions = low.ExtractExtensions(root)
+	f.index = idx
+	f.context = ctx
+	low.ExtractExtensionNodes(ctx, f.Extensions, f.Nodes)
+
+	criteria, err := extractArray[*Criterion](ctx, CriteriaLabel, root, idx)
+	if err != nil {
+		return err
+	}
+	f.Criteria = criteria
+	return nil
+}
+
+// GetExtensions returns all FailureAction extensions and satisfies the low.HasExtensions interface.
+func (f *FailureAction) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return f.Extensions
+}
+
+// Hash will return a consistent Hash of the FailureAction object
+func (f *FailureAction) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !f.Name.IsEmpty() {
+			h.WriteString(f.Name.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !f.Type.IsEmpty() {
+			h.WriteString(f.Type.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !f.WorkflowId.IsEmpty() {
+			h.WriteString(f.WorkflowId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !f.StepId.IsEmpty() {
+			h.WriteString(f.StepId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !f.RetryAfter.IsEmpty() {
+			h.WriteString(low.ValueToString(f.RetryAfter.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !f.RetryLimit.IsEmpty() {
+			low.HashInt64(h, f.RetryLimit.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, f.Criteria.Value)
+		if !f.Reference.IsEmpty() {
+			h.WriteString(f.Reference.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(f.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// IsReusable returns true if the FailureAction is a Reusable Object, referring to an action defined by the components.
+func (f *FailureAction) IsReusable() bool {
+	return !f.Reference.IsEmpty()
+}
@@ -0,0 +1,159 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Arazzo represents a low-level Arazzo document.
+// https://spec.openapis.org/arazzo/v1.0.1#arazzo-description
+type Arazzo struct {
+	Arazzo             low.NodeReference[string]
+	Info               low.NodeReference[*Info]
+	SourceDescriptions low.NodeReference[[]low.ValueReference[*SourceDescription]]
+	Workflows          low.NodeReference[[]low.ValueReference[*Workflow]]
+	Components         low.NodeReference[*Components]
+	Extensions         *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode            *yaml.Node
+	RootNode           *yaml.Node
+	index              *index.SpecIndex
+	context            context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Arazzo object
+func (a *Arazzo) GetIndex() *index.SpecIndex {
+	return a.index
+}
+
+// GetContext returns the context.Context instance used when building the Arazzo object
+func (a *Arazzo) GetContext() context.Context {
+	return a.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (a *Arazzo) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, a.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Arazzo object
+func (a *Arazzo) GetRootNode() *yaml.Node {
+	return a.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Arazzo object
+func (a *Arazzo) GetKeyNode() *yaml.Node {
+	return a.KeyNode
+}
+
+// Build will extract all properties of the Arazzo document.
+func (a *Arazzo) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	a.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	a.RootNode = root
+	utils.CheckForMergeNodes(root)
+	a.Nodes = low.ExtractNodes(ctx, root)
+	a.Extensions = low.ExtractExtensions(root)
+	a.index = idx
+	a.context = ctx
+	low.ExtractExtensionNodes(ctx, a.Extensions, a.Nodes)
+
+	var err error
+	if a.Info, err = low.ExtractObject[*Info](ctx, InfoLabel, root, idx); err != nil {
+		return err
+	}
+	if a.SourceDescriptions, err = extractArray[*SourceDescription](ctx, SourceDescriptionsLabel, root, idx); err != nil {
+		return err
+	}
+	if a.Workflows, err = extractArray[*Workflow](ctx, WorkflowsLabel, root, idx); err != nil {
+		return err
+	}
+	a.Components, err = low.ExtractObject[*Components](ctx, ComponentsLabel, root, idx)
+	return err
+}
+
+// GetExtensions returns all Arazzo extensions and satisfies the low.HasExtensions interface.
+func (a *Arazzo) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return a.Extensions
+}
+
+// Hash will return a consistent Hash of the Arazzo object
+func (a *Arazzo) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !a.Arazzo.IsEmpty() {
+			h.WriteString(a.Arazzo.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !a.Info.IsEmpty() {
+			h.WriteString(low.GenerateHashString(a.Info.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, a.SourceDescriptions.Value)
+		hashArray(h, a.Workflows.Value)
+		if !a.Components.IsEmpty() {
+			h.WriteString(low.GenerateHashString(a.Components.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(a.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// extractArray extracts an array of objects into a NodeReference, which is empty if the label is not found.
+func extractArray[T low.Buildable[N], N any](ctx context.Context, label string, root *yaml.Node,
+	idx *index.SpecIndex,
+) (low.NodeReference[[]low.ValueReference[T]], error) {
+	items, labelNode, valueNode, err := low.ExtractArray[T](ctx, label, root, idx)
+	if err != nil {
+		return low.NodeReference[[]low.ValueReference[T]]{}, err
+	}
+	return low.NodeReference[[]low.ValueReference[T]]{Value: items, KeyNode: labelNode, ValueNode: valueNode}, nil
+}
+
+// extractMap extracts a map of objects into a NodeReference, which is empty if the label is not found.
+func extractMap[T low.Buildable[N], N any](ctx context.Context, label string, root *yaml.Node,
+	idx *index.SpecIndex,
+) (low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]], error) {
+	items, labelNode, valueNode, err := low.ExtractMap[T](ctx, label, root, idx)
+	if err != nil {
+		return low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]]{}, err
+	}
+	return low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[T]]]{
+		Value: items, KeyNode: labelNode, ValueNode: valueNode,
+	}, nil
+}
+
+// extractNodeMap extracts a map of raw yaml nodes (such as JSON schemas) into a NodeReference.
+func extractNodeMap(label string, root *yaml.Node) low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]] {
+	var result low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]]
+	_, labelNode, valueNode := utils.FindKeyNodeFullTop(label, root.Content)
+	if valueNode == nil || !utils.IsNodeMap(valueNode) {
+		return result
+	}
+	items := orderedmap.New[low.KeyReference[string], low.ValueReference[*yaml.Node]]()
+	for i := 0; i+1 < len(valueNode.Content); i += 2 {
+		items.Set(low.KeyReference[string]{Value: valueNode.Content[i].Value, KeyNode: valueNode.Content[i]},
+			low.ValueReference[*yaml.Node]{Value: valueNode.Content[i+1], ValueNode: valueNode.Content[i+1]})
+	}
+	result.Value, result.KeyNode, result.ValueNode = items, labelNode, valueNode
+	return result
+}
+
+func hashArray[T any](h *maphash.Hash, items []low.ValueReference[T]) {
+	for _, item := range items {
+		h.WriteString(low.GenerateHashString(item.Value))
+		h.WriteByte(low.HASH_PIPE)
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const petWorkflows = `arazzo: 1.0.1
+info:
+  title: Adopt a pet
+  summary: Find and adopt a pet
+  version: 1.0.0
+  x-team: pets
+sourceDescriptions:
+  - name: petStore
+    url: ./petstore.yaml
+    type: openapi
+workflows:
+  - workflowId: adoptPet
+    summary: find a pet, then adopt it
+    inputs:
+      type: object
+      properties:
+        kind:
+          type: string
+    dependsOn: [login]
+    steps:
+      - stepId: findPet
+        operationId: petStore.findPets
+        parameters:
+          - name: kind
+            in: query
+            value: $inputs.kind
+          - reference: $components.parameters.page
+            value: 2
+        successCriteria:
+          - condition: $statusCode == 200
+          - context: $response.body
+            condition: $[?count(@.pets) > 0]
+            type: jsonpath
+        onSuccess:
+          - name: adopt
+            type: goto
+            stepId: adoptPet
+        onFailure:
+          - reference: $components.failureActions.retry
+        outputs:
+          petId: $response.body#/pets/0/id
+      - stepId: adoptPet
+        operationPath: '{$sourceDescriptions.petStore.url}#/paths/~1pets~1{id}~1adopt/post'
+        requestBody:
+          contentType: application/json
+          payload:
+            petId: ''
+          replacements:
+            - target: /petId
+              value: $steps.findPet.outputs.petId
+        successCriteria:
+          - context: $response.body
+            condition: $.adopted
+            type:
+              type: jsonpath
+              version: draft-goessner-dispatch-jsonpath-00
+    outputs:
+      petId: $steps.findPet.outputs.petId
+components:
+  inputs:
+    login:
+      type: object
+  parameters:
+    page:
+      name: page
+      in: query
+      value: 1
+  failureActions:
+    retry:
+      name: retry
+      type: retry
+      retryAfter: 1.5
+      retryLimit: 3
+      criteria:
+        - condition: $statusCode == 503
+  successActions:
+    done:
+      name: done
+      type: end`
+
+func buildArazzo(t *testing.T, spec string) *Arazzo {
+	var node yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &node))
+	var a Arazzo
+	require.NoError(t, low.BuildModel(node.Content[0], &a))
+	require.NoError(t, a.Build(context.Background(), nil, node.Content[0], nil))
+	return &a
+}
+
+func TestArazzo_Build(t *testing.T) {
+	a := buildArazzo(t, petWorkflows)
+
+	assert.Equal(t, "1.0.1", a.Arazzo.Value)
+	assert.Equal(t, "Adopt a pet", a.Info.Value.Title.Value)
+	assert.Equal(t, 2, a.Info.KeyNode.Line)
+	assert.NotNil(t, a.Info.Value.FindExtension("x-team"))
+
+	require.Len(t, a.SourceDescriptions.Value, 1)
+	source := a.SourceDescriptions.Value[0].Value
+	assert.Equal(t, "./petstore.yaml", source.URL.Value)
+	assert.Equal(t, SourceDescriptionTypeOpenAPI, source.Type.Value)
+
+	require.Len(t, a.Workflows.Value, 1)
+	workflow := a.Workflows.Value[0].Value
+	assert.Equal(t, "adoptPet", workflow.WorkflowId.Value)
+	assert.Equal(t, "object", workflow.Inputs.Value.Content[1].Value)
+	assert.Equal(t, "login", workflow.DependsOn.Value[0].Value)
+	assert.Equal(t, "$steps.findPet.outputs.petId", low.FindItemInOrderedMap("petId", workflow.Outputs.Value).Value)
+
+	require.Len(t, workflow.Steps.Value, 2)
+	find := workflow.Steps.Value[0].Value
+	assert.Equal(t, "petStore.findPets", find.OperationId.Value)
+	assert.Equal(t, 21, find.RootNode.Line)
+	require.Len(t, find.Parameters.Value, 2)
+	assert.False(t, find.Parameters.Value[0].Value.IsReusable())
+	assert.Equal(t, "$inputs.kind", find.Parameters.Value[0].Value.Value.Value.Value)
+	assert.True(t, find.Parameters.Value[1].Value.IsReusable())
+	assert.Equal(t, "$components.parameters.page", find.Parameters.Value[1].Value.Reference.Value)
+
+	require.Len(t, find.SuccessCriteria.Value, 2)
+	assert.Equal(t, "jsonpath", find.SuccessCriteria.Value[1].Value.Type.Value)
+	assert.True(t, find.SuccessCriteria.Value[1].Value.ExpressionType.IsEmpty())
+	assert.Equal(t, "goto", find.OnSuccess.Value[0].Value.Type.Value)
+	assert.True(t, find.OnFailure.Value[0].Value.IsReusable())
+
+	adopt := workflow.Steps.Value[1].Value
+	assert.Equal(t, "application/json", adopt.RequestBody.Value.ContentType.Value)
+	assert.Equal(t, "/petId", adopt.RequestBody.Value.Replacements.Value[0].Value.Target.Value)
+	expression := adopt.SuccessCriteria.Value[0].Value.ExpressionType
+	require.False(t, expression.IsEmpty())
+	assert.Equal(t, "jsonpath", expression.Value.Type.Value)
+	assert.Equal(t, "draft-goessner-dispatch-jsonpath-00", expression.Value.Version.Value)
+
+	components := a.Components.Value
+	assert.Equal(t, 1, components.Inputs.Value.Len())
+	page := low.FindItemInOrderedMap("page", components.Parameters.Value)
+	assert.Equal(t, "page", page.Value.Name.Value)
+	retry := low.FindItemInOrderedMap("retry", components.FailureActions.Value).Value
+	assert.Equal(t, 1.5, retry.RetryAfter.Value)
+	assert.Equal(t, int64(3), retry.RetryLimit.Value)
+	assert.Len(t, retry.Criteria.Value, 1)
+	assert.Equal(t, 1, components.SuccessActions.Value.Len())
+}
+
+func TestArazzo_Hash(t *testing.T) {
+	a := buildArazzo(t, petWorkflows)
+	b := buildArazzo(t, petWorkflows)
+	assert.Equal(t, a.Hash(), b.Hash())
+
+	c := buildArazzo(t, strings.Replace(petWorkflows, "retryLimit: 3", "retryLimit: 4", 1))
+	assert.NotEqual(t, a.Hash(), c.Hash())
+	assert.NotEqual(t, a.Workflows.Value[0].Value.Hash(), a.Workflows.Value[0].Value.Steps.Value[0].Value.Hash())
+}
+
+func TestArazzo_Build_Errors(t *testing.T) {
+	var node yaml.Node
+	for _, spec := range []string{
+		"arazzo: 1.0.1\nworkflows: nope",
+		"arazzo: 1.0.1\nsourceDescriptions: nope",
+		"arazzo: 1.0.1\nworkflows:\n  - steps: nope",
+		"arazzo: 1.0.1\nworkflows:\n  - steps:\n      - parameters: nope",
+		"arazzo: 1.0.1\nworkflows:\n  - successActions:\n      - criteria: nope",
+	} {
+		require.NoError(t, yaml.Unmarshal([]byte(spec), &node))
+		var a Arazzo
+		require.NoError(t, low.BuildModel(node.Content[0], &a))
+		assert.Error(t, a.Build(context.Background(), nil, node.Content[0], nil), spec)
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Components represents a low-level Arazzo Components Object.
+// https://spec.openapis.org/arazzo/v1.0.1#components-object
+type Components struct {
+	Inputs         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]]
+	Parameters     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Parameter]]]
+	SuccessActions low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*SuccessAction]]]
+	FailureActions low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*FailureAction]]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	context        context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Components object
+func (c *Components) GetIndex() *index.SpecIndex {
+	return c.index
+}
+
+// GetContext returns the context.Context instance used when building the Components object
+func (c *Components) GetContext() context.Context {
+	return c.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (c *Components) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, c.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Components object
+func (c *Components) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Components object
+func (c *Components) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// Build will extract the inputs, parameters, actions and extensions for the Components object.
+func (c *Components) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensions(root)
+	c.index = idx
+	c.context = ctx
+	low.ExtractExtensionNodes(ctx, c.Extensions, c.Nodes)
+
+	c.Inputs = extractNodeMap(InputsLabel, root)
+	var err error
+	if c.Parameters, err = extractMap[*Parameter](ctx, ParametersLabel, root, idx); err != nil {
+		return err
+	}
+	if c.SuccessActions, err = extractMap[*SuccessAction](ctx, SuccessActionsLabel, root, idx); err != nil {
+		return err
+	}
+	c.FailureActions, err = extractMap[*FailureAction](ctx, FailureActionsLabel, root, idx)
+	return err
+}
+
+// GetExtensions returns all Components extensions and satisfies the low.HasExtensions interface.
+func (c *Components) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// Hash will return a consistent Hash of the Components object
+func (c *Components) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		for _, v := range low.AppendMapHashes(nil, c.Inputs.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, v := range low.AppendMapHashes(nil, c.Parameters.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, v := range low.AppendMapHashes(nil, c.SuccessActions.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, v := range low.AppendMapHashes(nil, c.FailureActions.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(c.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
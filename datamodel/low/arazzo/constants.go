@@ -0,0 +1,32 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+// Constants for labels used to look up values within Arazzo specifications.
+// https://spec.openapis.org/arazzo/v1.0.1
+const (
+	ArazzoLabel             = "arazzo"
+	InfoLabel               = "info"
+	SourceDescriptionsLabel = "sourceDescriptions"
+	WorkflowsLabel          = "workflows"
+	ComponentsLabel         = "components"
+	StepsLabel              = "steps"
+	ParametersLabel         = "parameters"
+	SuccessActionsLabel     = "successActions"
+	FailureActionsLabel     = "failureActions"
+	SuccessCriteriaLabel    = "successCriteria"
+	OnSuccessLabel          = "onSuccess"
+	OnFailureLabel          = "onFailure"
+	RequestBodyLabel        = "requestBody"
+	ReplacementsLabel       = "replacements"
+	CriteriaLabel           = "criteria"
+	TypeLabel               = "type"
+	InputsLabel             = "inputs"
+)
+
+// Source description types.
+const (
+	SourceDescriptionTypeOpenAPI = "openapi"
+	SourceDescriptionTypeArazzo  = "arazzo"
+)
@@ -0,0 +1,188 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Criterion represents a low-level Arazzo Criterion Object.
+// https://spec.openapis.org/arazzo/v1.0.1#criterion-object
+type Criterion struct {
+	Context        low.NodeReference[string]
+	Condition      low.NodeReference[string]
+	Type           low.NodeReference[string]
+	ExpressionType low.NodeReference[*CriterionExpressionType]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	ctx            context.Context // not named context, BuildModel would match it to the context property.
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Criterion object
+func (c *Criterion) GetIndex() *index.SpecIndex {
+	return c.index
+}
+
+// GetContext returns the context.Context instance used when building the Criterion object
+func (c *Criterion) GetContext() context.Context {
+	return c.ctx
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (c *Criterion) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, c.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Criterion object
+func (c *Criterion) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Criterion object
+func (c *Criterion) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// Build will extract the expression type (when the type is an object) and extensions for the Criterion object.
+func (c *Criterion) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensions(root)
+	c.index = idx
+	c.ctx = ctx
+	low.ExtractExtensionNodes(ctx, c.Extensions, c.Nodes)
+
+	// the type is either a simple type name, or a criterion expression type object.
+	_, typeKey, typeValue := utils.FindKeyNodeFullTop(TypeLabel, root.Content)
+	if typeValue != nil && utils.IsNodeMap(typeValue) {
+		c.Type = low.NodeReference[string]{KeyNode: typeKey, ValueNode: typeValue}
+		expressionType, err := low.ExtractObject[*CriterionExpressionType](ctx, TypeLabel, root, idx)
+		if err != nil {
+			return err
+		}
+		c.ExpressionType = expressionType
+	}
+	return nil
+}
+
+// GetExtensions returns all Criterion extensions and satisfies the low.HasExtensions interface.
+func (c *Criterion) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// Hash will return a consistent Hash of the Criterion object
+func (c *Criterion) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !c.Context.IsEmpty() {
+			h.WriteString(c.Context.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !c.Condition.IsEmpty() {
+			h.WriteString(c.Condition.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !c.Type.IsEmpty() {
+			h.WriteString(c.Type.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !c.ExpressionType.IsEmpty() {
+			h.WriteString(low.GenerateHashString(c.ExpressionType.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(c.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// CriterionExpressionType represents a low-level Arazzo Criterion Expression Type Object.
+// https://spec.openapis.org/arazzo/v1.0.1#criterion-expression-type-object
+type CriterionExpressionType struct {
+	Type       low.NodeReference[string]
+	Version    low.NodeReference[string]
+	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	index      *index.SpecIndex
+	context    context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the CriterionExpressionType object
+func (c *CriterionExpressionType) GetIndex() *index.SpecIndex {
+	return c.index
+}
+
+// GetContext returns the context.Context instance used when building the CriterionExpressionType object
+func (c *CriterionExpressionType) GetContext() context.Context {
+	return c.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (c *CriterionExpressionType) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, c.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the CriterionExpressionType object
+func (c *CriterionExpressionType) GetRootNode() *yaml.Node {
+	return c.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the CriterionExpressionType object
+func (c *CriterionExpressionType) GetKeyNode() *yaml.Node {
+	return c.KeyNode
+}
+
+// Build will extract extensions for the CriterionExpressionType object.
+func (c *CriterionExpressionType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	c.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	c.RootNode = root
+	utils.CheckForMergeNodes(root)
+	c.Nodes = low.ExtractNodes(ctx, root)
+	c.Extensions = low.ExtractExtensions(root)
+	c.index = idx
+	c.context = ctx
+	low.ExtractExtensionNodes(ctx, c.Extensions, c.Nodes)
+	return nil
+}
+
+// GetExtensions returns all CriterionExpressionType extensions and satisfies the low.HasExtensions interface.
+func (c *CriterionExpressionType) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return c.Extensions
+}
+
+// Hash will return a consistent Hash of the CriterionExpressionType object
+func (c *CriterionExpressionType) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !c.Type.IsEmpty() {
+			h.WriteString(c.Type.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !c.Version.IsEmpty() {
+			h.WriteString(c.Version.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(c.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
@@ -0,0 +1,101 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Info represents a low-level Arazzo Info Object.
+// https://spec.openapis.org/arazzo/v1.0.1#info-object
+type Info struct {
+	Title       low.NodeReference[string]
+	Summary     low.NodeReference[string]
+	Description low.NodeReference[string]
+	Version     low.NodeReference[string]
+	Extensions  *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode     *yaml.Node
+	RootNode    *yaml.Node
+	index       *index.SpecIndex
+	context     context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Info object
+func (i *Info) GetIndex() *index.SpecIndex {
+	return i.index
+}
+
+// GetContext returns the context.Context instance used when building the Info object
+func (i *Info) GetContext() context.Context {
+	return i.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (i *Info) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, i.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Info object
+func (i *Info) GetRootNode() *yaml.Node {
+	return i.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Info object
+func (i *Info) GetKeyNode() *yaml.Node {
+	return i.KeyNode
+}
+
+// Build will extract extensions for the Info object.
+func (i *Info) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	i.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	i.RootNode = root
+	utils.CheckForMergeNodes(root)
+	i.Nodes = low.ExtractNodes(ctx, root)
+	i.Extensions = low.ExtractExtensions(root)
+	i.index = idx
+	i.context = ctx
+	low.ExtractExtensionNodes(ctx, i.Extensions, i.Nodes)
+	return nil
+}
+
+// GetExtensions returns all Info extensions and satisfies the low.HasExtensions interface.
+func (i *Info) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return i.Extensions
+}
+
+// Hash will return a consistent Hash of the Info object
+func (i *Info) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !i.Title.IsEmpty() {
+			h.WriteString(i.Title.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !i.Summary.IsEmpty() {
+			h.WriteString(i.Summary.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !i.Description.IsEmpty() {
+			h.WriteString(i.Description.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !i.Version.IsEmpty() {
+			h.WriteString(i.Version.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(i.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
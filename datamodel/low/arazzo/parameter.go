@@ -0,0 +1,106 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Parameter represents a low-level Arazzo Parameter Object, or a Reusable Object that refers to one.
+// https://spec.openapis.org/arazzo/v1.0.1#parameter-object
+type Parameter struct {
+	Name       low.NodeReference[string]
+	In         low.NodeReference[string]
+	Value      low.NodeReference[*yaml.Node]
+	Reference  low.NodeReference[string]
+	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	index      *index.SpecIndex
+	context    context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Parameter object
+func (p *Parameter) GetIndex() *index.SpecIndex {
+	return p.index
+}
+
+// GetContext returns the context.Context instance used when building the Parameter object
+func (p *Parameter) GetContext() context.Context {
+	return p.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (p *Parameter) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, p.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Parameter object
+func (p *Parameter) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Parameter object
+func (p *Parameter) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// Build will extract extensions for the Parameter object.
+func (p *Parameter) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	p.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	p.RootNode = root
+	utils.CheckForMergeNodes(root)
+	p.Nodes = low.ExtractNodes(ctx, root)
+	p.Extensions = low.ExtractExtensions(root)
+	p.index = idx
+	p.context = ctx
+	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
+	return nil
+}
+
+// GetExtensions returns all Parameter extensions and satisfies the low.HasExtensions interface.
+func (p *Parameter) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.Extensions
+}
+
+// Hash will return a consistent Hash of the Parameter object
+func (p *Parameter) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !p.Name.IsEmpty() {
+			h.WriteString(p.Name.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !p.In.IsEmpty() {
+			h.WriteString(p.In.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !p.Value.IsEmpty() {
+			h.WriteString(low.GenerateHashString(p.Value.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !p.Reference.IsEmpty() {
+			h.WriteString(p.Reference.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(p.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// IsReusable returns true if the Parameter is a Reusable Object, referring to a parameter defined by the components.
+func (p *Parameter) IsReusable() bool {
+	return !p.Reference.IsEmpty()
+}
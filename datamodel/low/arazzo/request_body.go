@@ -0,0 +1,175 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// RequestBody represents a low-level Arazzo Request Body Object.
+// https://spec.openapis.org/arazzo/v1.0.1#request-body-object
+type RequestBody struct {
+	ContentType  low.NodeReference[string]
+	Payload      low.NodeReference[*yaml.Node]
+	Replacements low.NodeReference[[]low.ValueReference[*PayloadReplacement]]
+	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode      *yaml.Node
+	RootNode     *yaml.Node
+	index        *index.SpecIndex
+	context      context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the RequestBody object
+func (r *RequestBody) GetIndex() *index.SpecIndex {
+	return r.index
+}
+
+// GetContext returns the context.Context instance used when building the RequestBody object
+func (r *RequestBody) GetContext() context.Context {
+	return r.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (r *RequestBody) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, r.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the RequestBody object
+func (r *RequestBody) GetRootNode() *yaml.Node {
+	return r.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the RequestBody object
+func (r *RequestBody) GetKeyNode() *yaml.Node {
+	return r.KeyNode
+}
+
+// Build will extract the replacements and extensions for the RequestBody object.
+func (r *RequestBody) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	r.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	r.RootNode = root
+	utils.CheckForMergeNodes(root)
+	r.Nodes = low.ExtractNodes(ctx, root)
+	r.Extensions = low.ExtractExtensions(root)
+	r.index = idx
+	r.context = ctx
+	low.ExtractExtensionNodes(ctx, r.Extensions, r.Nodes)
+
+	replacements, err := extractArray[*PayloadReplacement](ctx, ReplacementsLabel, root, idx)
+	if err != nil {
+		return err
+	}
+	r.Replacements = replacements
+	return nil
+}
+
+// GetExtensions returns all RequestBody extensions and satisfies the low.HasExtensions interface.
+func (r *RequestBody) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return r.Extensions
+}
+
+// Hash will return a consistent Hash of the RequestBody object
+func (r *RequestBody) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !r.ContentType.IsEmpty() {
+			h.WriteString(r.ContentType.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !r.Payload.IsEmpty() {
+			h.WriteString(low.GenerateHashString(r.Payload.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, r.Replacements.Value)
+		for _, ext := range low.HashExtensions(r.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
+
+// PayloadReplacement represents a low-level Arazzo Payload Replacement Object.
+// https://spec.openapis.org/arazzo/v1.0.1#payload-replacement-object
+type PayloadReplacement struct {
+	Target     low.NodeReference[string]
+	Value      low.NodeReference[*yaml.Node]
+	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode    *yaml.Node
+	RootNode   *yaml.Node
+	index      *index.SpecIndex
+	context    context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the PayloadReplacement object
+func (p *PayloadReplacement) GetIndex() *index.SpecIndex {
+	return p.index
+}
+
+// GetContext returns the context.Context instance used when building the PayloadReplacement object
+func (p *PayloadReplacement) GetContext() context.Context {
+	return p.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (p *PayloadReplacement) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, p.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the PayloadReplacement object
+func (p *PayloadReplacement) GetRootNode() *yaml.Node {
+	return p.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the PayloadReplacement object
+func (p *PayloadReplacement) GetKeyNode() *yaml.Node {
+	return p.KeyNode
+}
+
+// Build will extract extensions for the PayloadReplacement object.
+func (p *PayloadReplacement) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	p.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	p.RootNode = root
+	utils.CheckForMergeNodes(root)
+	p.Nodes = low.ExtractNodes(ctx, root)
+	p.Extensions = low.ExtractExtensions(root)
+	p.index = idx
+	p.context = ctx
+	low.ExtractExtensionNodes(ctx, p.Extensions, p.Nodes)
+	return nil
+}
+
+// GetExtensions returns all PayloadReplacement extensions and satisfies the low.HasExtensions interface.
+func (p *PayloadReplacement) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return p.Extensions
+}
+
+// Hash will return a consistent Hash of the PayloadReplacement object
+func (p *PayloadReplacement) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !p.Target.IsEmpty() {
+			h.WriteString(p.Target.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !p.Value.IsEmpty() {
+			h.WriteString(low.GenerateHashString(p.Value.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(p.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
@@ -0,0 +1,139 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Step represents a low-level Arazzo Step Object.
+// https://spec.openapis.org/arazzo/v1.0.1#step-object
+type Step struct {
+	StepId          low.NodeReference[string]
+	Description     low.NodeReference[string]
+	OperationId     low.NodeReference[string]
+	OperationPath   low.NodeReference[string]
+	WorkflowId      low.NodeReference[string]
+	Parameters      low.NodeReference[[]low.ValueReference[*Parameter]]
+	RequestBody     low.NodeReference[*RequestBody]
+	SuccessCriteria low.NodeReference[[]low.ValueReference[*Criterion]]
+	OnSuccess       low.NodeReference[[]low.ValueReference[*SuccessAction]]
+	OnFailure       low.NodeReference[[]low.ValueReference[*FailureAction]]
+	Outputs         low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Extensions      *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode         *yaml.Node
+	RootNode        *yaml.Node
+	index           *index.SpecIndex
+	context         context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Step object
+func (s *Step) GetIndex() *index.SpecIndex {
+	return s.index
+}
+
+// GetContext returns the context.Context instance used when building the Step object
+func (s *Step) GetContext() context.Context {
+	return s.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (s *Step) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, s.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Step object
+func (s *Step) GetRootNode() *yaml.Node {
+	return s.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Step object
+func (s *Step) GetKeyNode() *yaml.Node {
+	return s.KeyNode
+}
+
+// Build will extract the parameters, request body, criteria, actions and extensions for the Step object.
+func (s *Step) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	s.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	s.RootNode = root
+	utils.CheckForMergeNodes(root)
+	s.Nodes = low.ExtractNodes(ctx, root)
+	s.Extensions = low.ExtractExtensions(root)
+	s.index = idx
+	s.context = ctx
+	low.ExtractExtensionNodes(ctx, s.Extensions, s.Nodes)
+
+	var err error
+	if s.Parameters, err = extractArray[*Parameter](ctx, ParametersLabel, root, idx); err != nil {
+		return err
+	}
+	if s.RequestBody, err = low.ExtractObject[*RequestBody](ctx, RequestBodyLabel, root, idx); err != nil {
+		return err
+	}
+	if s.SuccessCriteria, err = extractArray[*Criterion](ctx, SuccessCriteriaLabel, root, idx); err != nil {
+		return err
+	}
+	if s.OnSuccess, err = extractArray[*SuccessAction](ctx, OnSuccessLabel, root, idx); err != nil {
+		return err
+	}
+	s.OnFailure, err = extractArray[*FailureAction](ctx, OnFailureLabel, root, idx)
+	return err
+}
+
+// GetExtensions returns all Step extensions and satisfies the low.HasExtensions interface.
+func (s *Step) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return s.Extensions
+}
+
+// Hash will return a consistent Hash of the Step object
+func (s *Step) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !s.StepId.IsEmpty() {
+			h.WriteString(s.StepId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.Description.IsEmpty() {
+			h.WriteString(s.Description.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.OperationId.IsEmpty() {
+			h.WriteString(s.OperationId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.OperationPath.IsEmpty() {
+			h.WriteString(s.OperationPath.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !s.WorkflowId.IsEmpty() {
+			h.WriteString(s.WorkflowId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, s.Parameters.Value)
+		if !s.RequestBody.IsEmpty() {
+			h.WriteString(low.GenerateHashString(s.RequestBody.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, s.SuccessCriteria.Value)
+		hashArray(h, s.OnSuccess.Value)
+		hashArray(h, s.OnFailure.Value)
+		for _, v := range low.AppendMapHashes(nil, s.Outputs.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, ext := range low.HashExtensions(s.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
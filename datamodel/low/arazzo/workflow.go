@@ -0,0 +1,131 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package arazzo
+
+import (
+	"context"
+	"hash/maphash"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Workflow represents a low-level Arazzo Workflow Object.
+// https://spec.openapis.org/arazzo/v1.0.1#workflow-object
+type Workflow struct {
+	WorkflowId     low.NodeReference[string]
+	Summary        low.NodeReference[string]
+	Description    low.NodeReference[string]
+	Inputs         low.NodeReference[*yaml.Node]
+	DependsOn      low.NodeReference[[]low.ValueReference[string]]
+	Steps          low.NodeReference[[]low.ValueReference[*Step]]
+	SuccessActions low.NodeReference[[]low.ValueReference[*SuccessAction]]
+	FailureActions low.NodeReference[[]low.ValueReference[*FailureAction]]
+	Outputs        low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Parameters     low.NodeReference[[]low.ValueReference[*Parameter]]
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	context        context.Context
+	low.NodeMap
+}
+
+// GetIndex returns the index.SpecIndex instance attached to the Workflow object
+func (w *Workflow) GetIndex() *index.SpecIndex {
+	return w.index
+}
+
+// GetContext returns the context.Context instance used when building the Workflow object
+func (w *Workflow) GetContext() context.Context {
+	return w.context
+}
+
+// FindExtension returns a ValueReference containing the extension value, if found.
+func (w *Workflow) FindExtension(ext string) *low.ValueReference[*yaml.Node] {
+	return low.FindItemInOrderedMap(ext, w.Extensions)
+}
+
+// GetRootNode returns the root yaml node of the Workflow object
+func (w *Workflow) GetRootNode() *yaml.Node {
+	return w.RootNode
+}
+
+// GetKeyNode returns the key yaml node of the Workflow object
+func (w *Workflow) GetKeyNode() *yaml.Node {
+	return w.KeyNode
+}
+
+// Build will extract the steps, actions, parameters and extensions for the Workflow object.
+func (w *Workflow) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
+	w.KeyNode = keyNode
+	root = utils.NodeAlias(root)
+	w.RootNode = root
+	utils.CheckForMergeNodes(root)
+	w.Nodes = low.ExtractNodes(ctx, root)
+	w.Extensions = low.ExtractExtensions(root)
+	w.index = idx
+	w.context = ctx
+	low.ExtractExtensionNodes(ctx, w.Extensions, w.Nodes)
+
+	var err error
+	if w.Steps, err = extractArray[*Step](ctx, StepsLabel, root, idx); err != nil {
+		return err
+	}
+	if w.SuccessActions, err = extractArray[*SuccessAction](ctx, SuccessActionsLabel, root, idx); err != nil {
+		return err
+	}
+	if w.FailureActions, err = extractArray[*FailureAction](ctx, FailureActionsLabel, root, idx); err != nil {
+		return err
+	}
+	w.Parameters, err = extractArray[*Parameter](ctx, ParametersLabel, root, idx)
+	return err
+}
+
+// GetExtensions returns all Workflow extensions and satisfies the low.HasExtensions interface.
+func (w *Workflow) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]] {
+	return w.Extensions
+}
+
+// Hash will return a consistent Hash of the Workflow object
+func (w *Workflow) Hash() uint64 {
+	return low.WithHasher(func(h *maphash.Hash) uint64 {
+		if !w.WorkflowId.IsEmpty() {
+			h.WriteString(w.WorkflowId.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !w.Summary.IsEmpty() {
+			h.WriteString(w.Summary.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !w.Description.IsEmpty() {
+			h.WriteString(w.Description.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		if !w.Inputs.IsEmpty() {
+			h.WriteString(low.GenerateHashString(w.Inputs.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
+		for _, v := range w.DependsOn.Value {
+			h.WriteString(v.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, w.Steps.Value)
+		hashArray(h, w.SuccessActions.Value)
+		hashArray(h, w.FailureActions.Value)
+		for _, v := range low.AppendMapHashes(nil, w.Outputs.Value) {
+			h.WriteString(v)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		hashArray(h, w.Parameters.Value)
+		for _, ext := range low.HashExtensions(w.Extensions) {
+			h.WriteString(ext)
+			h.WriteByte(low.HASH_PIPE)
+		}
+		return h.Sum64()
+	})
+}
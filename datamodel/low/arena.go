@@ -0,0 +1,117 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// ArenaKey is the context key used to carry an *Arena through low-level model building.
+const ArenaKey index.ContextKey = "arena"
+
+// arenaSlabSize is the number of objects allocated at once for each type, per slab.
+const arenaSlabSize = 256
+
+// Arena is an optional slab allocator used when building low-level models. Instead of allocating every single
+// model object on its own, objects of the same type are handed out from pre-allocated slabs of 256 objects.
+//
+// Only the model objects themselves (schemas, schema proxies, and the objects built by the extraction functions,
+// like operations, parameters and responses) come from the arena. The NodeReference, KeyReference and
+// ValueReference values they hold are part of those objects, and the yaml nodes the references point to are
+// allocated by the parser, so neither is affected. The number of allocations saved is the number of model objects,
+// not the number of nodes in the specification.
+//
+// An Arena is enabled via the UseArenaAllocation property of datamodel.DocumentConfiguration. It is safe for
+// concurrent use.
+//
+// Release should be called when the document is no longer needed, which drops the arena's hold on its slabs.
+// Objects that are still referenced after a release remain valid, however a slab is only freed by the garbage
+// collector once none of its objects are referenced, so holding on to a single object keeps its whole slab alive.
+type Arena struct {
+	slabs     sync.Map // reflect.Type -> *arenaSlab[N]
+	allocated atomic.Int64
+	released  atomic.Bool
+}
+
+type arenaSlab[N any] struct {
+	mu    sync.Mutex
+	items []N
+	next  int
+}
+
+// NewArena creates a new, empty Arena.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// Allocated returns the number of objects that have been handed out by the arena.
+func (a *Arena) Allocated() int64 {
+	if a == nil {
+		return 0
+	}
+	return a.allocated.Load()
+}
+
+// Released returns true if Release has been called on the arena.
+func (a *Arena) Released() bool {
+	if a == nil {
+		return false
+	}
+	return a.released.Load()
+}
+
+// Release drops all slabs held by the arena. Any allocations requested after a release fall back to the
+// standard allocator.
+func (a *Arena) Release() {
+	if a == nil {
+		return
+	}
+	a.released.Store(true)
+	a.slabs.Clear()
+}
+
+// WithArena returns a copy of the supplied context that carries the arena.
+func WithArena(ctx context.Context, arena *Arena) context.Context {
+	return context.WithValue(ctx, ArenaKey, arena)
+}
+
+// GetArena returns the arena carried by the supplied context, or nil if there isn't one.
+func GetArena(ctx context.Context) *Arena {
+	if ctx == nil {
+		return nil
+	}
+	if a, ok := ctx.Value(ArenaKey).(*Arena); ok {
+		return a
+	}
+	return nil
+}
+
+// Allocate returns a pointer to a new, zeroed N. If the context carries an Arena, the object is taken from
+// the arena, otherwise it is allocated normally using new(N).
+func Allocate[N any](ctx context.Context) *N {
+	a := GetArena(ctx)
+	if a == nil || a.released.Load() {
+		return new(N)
+	}
+	s, ok := a.slabs.Load(reflect.TypeFor[N]())
+	if !ok {
+		s, _ = a.slabs.LoadOrStore(reflect.TypeFor[N](), &arenaSlab[N]{})
+	}
+	slab := s.(*arenaSlab[N])
+	slab.mu.Lock()
+	if slab.next == len(slab.items) {
+		slab.items = make([]N, arenaSlabSize)
+		slab.next = 0
+	}
+	n := &slab.items[slab.next]
+	slab.next++
+	slab.mu.Unlock()
+	a.allocated.Add(1)
+	return n
+}
@@ -0,0 +1,107 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package low
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type arenaThing struct {
+	Name string
+	Size int
+}
+
+func TestAllocate_NoArena(t *testing.T) {
+	a := Allocate[arenaThing](context.Background())
+	b := Allocate[arenaThing](context.Background())
+	assert.NotNil(t, a)
+	assert.NotSame(t, a, b)
+	assert.Nil(t, GetArena(context.Background()))
+	assert.Nil(t, GetArena(nil))
+}
+
+func TestAllocate_Arena(t *testing.T) {
+	arena := NewArena()
+	ctx := WithArena(context.Background(), arena)
+	assert.Same(t, arena, GetArena(ctx))
+
+	seen := make(map[*arenaThing]bool)
+	for i := 0; i < arenaSlabSize*2+1; i++ {
+		n := Allocate[arenaThing](ctx)
+		assert.Equal(t, arenaThing{}, *n)
+		assert.False(t, seen[n])
+		seen[n] = true
+		n.Size = i
+	}
+	assert.Equal(t, int64(arenaSlabSize*2+1), arena.Allocated())
+
+	// different types come from different slabs.
+	s := Allocate[string](ctx)
+	*s = "pizza"
+	assert.Equal(t, int64(arenaSlabSize*2+2), arena.Allocated())
+}
+
+func TestAllocate_Arena_Concurrent(t *testing.T) {
+	arena := NewArena()
+	ctx := WithArena(context.Background(), arena)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[*arenaThing]bool)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				n := Allocate[arenaThing](ctx)
+				mu.Lock()
+				assert.False(t, seen[n])
+				seen[n] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, seen, 4000)
+	assert.Equal(t, int64(4000), arena.Allocated())
+}
+
+func TestArena_Release(t *testing.T) {
+	arena := NewArena()
+	ctx := WithArena(context.Background(), arena)
+	held := Allocate[arenaThing](ctx)
+	held.Name = "still here"
+
+	arena.Release()
+	assert.True(t, arena.Released())
+	assert.Equal(t, "still here", held.Name)
+
+	// falls back to the standard allocator.
+	_ = Allocate[arenaThing](ctx)
+	assert.Equal(t, int64(1), arena.Allocated())
+
+	var nilArena *Arena
+	nilArena.Release()
+	assert.False(t, nilArena.Released())
+	assert.Zero(t, nilArena.Allocated())
+}
+
+func BenchmarkAllocate_New(b *testing.B) {
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Allocate[arenaThing](ctx)
+	}
+}
+
+func BenchmarkAllocate_Arena(b *testing.B) {
+	ctx := WithArena(context.Background(), NewArena())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Allocate[arenaThing](ctx)
+	}
+}
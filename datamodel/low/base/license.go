@@ -5,8 +5,11 @@ package base
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"hash/maphash"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -31,7 +34,7 @@ type License struct {
 	low.NodeMap
 }
 
-// Build out a license, complain if both a URL and identifier are present as they are mutually exclusive
+// Build out a license. Use Validate to check the URL and identifier are not both present.
 func (l *License) Build(ctx context.Context, keyNode, root *yaml.Node, idx *index.SpecIndex) error {
 	l.KeyNode = keyNode
 	root = utils.NodeAlias(root)
@@ -46,6 +49,30 @@ func (l *License) Build(ctx context.Context, keyNode, root *yaml.Node, idx *inde
 	return nil
 }
 
+// Validate checks the license is valid, returning an error for each problem found. The URL and identifier are
+// mutually exclusive, so both cannot be present. If a checker is supplied, the identifier is also checked to be
+// a valid SPDX license expression. Each error returned is an *index.IndexingError, locating the problem.
+func (l *License) Validate(checker datamodel.LicenseIdentifierChecker) []error {
+	var errs []error
+	if !l.URL.IsEmpty() && !l.Identifier.IsEmpty() {
+		errs = append(errs, &index.IndexingError{
+			Err:     errors.New("license 'url' and 'identifier' are mutually exclusive, only one can be defined"),
+			Node:    l.Identifier.ValueNode,
+			KeyNode: l.Identifier.KeyNode,
+			Path:    "$.info.license.identifier",
+		})
+	}
+	if checker != nil && !l.Identifier.IsEmpty() && !checker(l.Identifier.Value) {
+		errs = append(errs, &index.IndexingError{
+			Err:     fmt.Errorf("license identifier '%s' is not a valid SPDX license expression", l.Identifier.Value),
+			Node:    l.Identifier.ValueNode,
+			KeyNode: l.Identifier.KeyNode,
+			Path:    "$.info.license.identifier",
+		})
+	}
+	return errs
+}
+
 // GetIndex will return the index.SpecIndex instance attached to the License object
 func (l *License) GetIndex() *index.SpecIndex {
 	return l.index
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
 	"go.yaml.in/yaml/v4"
 )
@@ -64,3 +65,35 @@ description: the ranch`
 
 	assert.Equal(t, lDoc.Hash(), rDoc.Hash())
 }
+
+func TestLicense_Validate(t *testing.T) {
+	yml := `name: pizza
+url: https://pb33f.io
+identifier: NOT-A-LICENSE`
+
+	var node yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &node)
+
+	var l License
+	_ = low.BuildModel(node.Content[0], &l)
+
+	errs := l.Validate(nil)
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "license 'url' and 'identifier' are mutually exclusive, only one can be defined", errs[0].Error())
+	assert.Equal(t, 3, errs[0].(*index.IndexingError).Node.Line)
+
+	checker := func(identifier string) bool {
+		return identifier == "MIT" || identifier == "Apache-2.0"
+	}
+	errs = l.Validate(checker)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "license identifier 'NOT-A-LICENSE' is not a valid SPDX license expression", errs[1].Error())
+
+	yml = `name: pizza
+identifier: MIT`
+	node = yaml.Node{}
+	_ = yaml.Unmarshal([]byte(yml), &node)
+	l = License{}
+	_ = low.BuildModel(node.Content[0], &l)
+	assert.Empty(t, l.Validate(checker))
+}
@@ -1417,7 +1417,11 @@ func buildPropertyMap(ctx context.Context, parent *Schema, root *yaml.Node, idx
 				}
 			}
 
-			sp := &SchemaProxy{ctx: foundCtx, kn: currentProp, vn: prop, idx: foundIdx}
+			sp := low.Allocate[SchemaProxy](foundCtx)
+			sp.ctx = foundCtx
+			sp.kn = currentProp
+			sp.vn = prop
+			sp.idx = foundIdx
 			sp.SetReference(refString, refNode)
 
 			_ = sp.Build(foundCtx, currentProp, prop, foundIdx)
@@ -1527,7 +1531,7 @@ func buildSchema(ctx context.Context, schemas chan schemaProxyBuildResult, label
 			// chasing down circles, that in turn spin up endless threads.
 			// In order to combat this, we need a schema proxy that will only resolve the schema when asked, and then
 			// it will only do it one level at a time.
-			sp := new(SchemaProxy)
+			sp := low.Allocate[SchemaProxy](pctx)
 
 			// call Build to ensure transformation happens
 			_ = sp.Build(pctx, kn, vn, fIdx)
@@ -1676,7 +1680,11 @@ func ExtractSchema(ctx context.Context, root *yaml.Node, idx *index.SpecIndex) (
 
 	if schNode != nil {
 		// check if schema has already been built.
-		schema := &SchemaProxy{kn: schLabel, vn: schNode, idx: foundIndex, ctx: foundCtx}
+		schema := low.Allocate[SchemaProxy](foundCtx)
+		schema.kn = schLabel
+		schema.vn = schNode
+		schema.idx = foundIndex
+		schema.ctx = foundCtx
 
 		// call Build to ensure transformation happens
 		_ = schema.Build(foundCtx, schLabel, schNode, foundIndex)
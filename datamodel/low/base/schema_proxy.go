@@ -8,7 +8,6 @@ import (
 	"errors"
 	"fmt"
 	"hash/maphash"
-	"log/slog"
 	"sync"
 
 	"github.com/pb33f/libopenapi/datamodel"
@@ -128,7 +127,7 @@ func (sp *SchemaProxy) Schema() *Schema {
 		}
 	}
 
-	schema := new(Schema)
+	schema := low.Allocate[Schema](sp.ctx)
 	utils.CheckForMergeNodes(buildNode)
 	err := schema.Build(sp.ctx, buildNode, sp.idx)
 	if err != nil {
@@ -216,14 +215,12 @@ func (sp *SchemaProxy) Hash() uint64 {
 					hash = sch.Hash()
 				}
 			} else {
-				var logger *slog.Logger
-				if sp.idx != nil && sp.idx.GetLogger() != nil {
-					logger = sp.idx.GetLogger()
-				}
-				if logger != nil {
+				if sp.idx != nil {
 					bErr := errors.Join(sp.GetBuildError(), hashError)
 					if bErr != nil {
-						logger.Warn("SchemaProxy.Hash() unable to complete hash: ", "error", bErr.Error())
+						sp.idx.GetSubsystemLogger(datamodel.LogSubsystemBuilder).Warn(
+							"SchemaProxy.Hash() unable to complete hash: ", "error", bErr.Error(),
+							datamodel.LogKeyFile, sp.idx.GetSpecAbsolutePath())
 					}
 				}
 				hash = 0
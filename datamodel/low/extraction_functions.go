@@ -4,7 +4,6 @@
 package low
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
@@ -44,7 +43,7 @@ var hashCache sync.Map
 // ClearHashCache clears the global hash cache. This should be called before
 // starting a new document comparison to ensure clean state.
 func ClearHashCache() {
-	hashCache = sync.Map{}
+	hashCache.Clear()
 }
 
 // GetStringBuilder retrieves a strings.Builder from the pool, resets it, and returns it.
@@ -256,6 +255,12 @@ func LocateRefNodeWithContext(ctx context.Context, root *yaml.Node, idx *index.S
 			}
 		}
 
+		// remote references are not looked up when they are skipped, the reference node itself is returned as a
+		// placeholder, so the object is built as a reference with no content.
+		if idx.GetConfig() != nil && idx.GetConfig().SkipRemoteReferences && index.IsRemoteReference(rv) {
+			return root, idx, nil, ctx
+		}
+
 		foundRef, fIdx, newCtx := idx.SearchIndexForReferenceWithContext(ctx, rv)
 		if foundRef != nil {
 			return utils.NodeAlias(foundRef.Node), fIdx, nil, newCtx
@@ -313,7 +318,7 @@ func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yam
 			}
 		}
 	}
-	var n T = new(N)
+	var n T = Allocate[N](ctx)
 	err := BuildModel(root, n)
 	if err != nil {
 		return n, err, isReference, referenceValue
@@ -335,6 +340,46 @@ func ExtractObjectRaw[T Buildable[N], N any](ctx context.Context, key, root *yam
 	return n, nil, isReference, referenceValue
 }
 
+// FindComponentAs will locate a reference (for example '#/components/parameters/limit', or 'models.yaml#/Pet')
+// using the supplied index, and build it as the requested low-level type, in one call. References to references
+// are followed, and the object is built using the index of the file it was found in. The result is wrapped in a
+// NodeReference[T] that contains the value node the object was built from, there is no key node.
+//
+//	param, err := low.FindComponentAs[*v3.Parameter](ctx, idx, "#/components/parameters/limit")
+func FindComponentAs[T Buildable[N], N any](ctx context.Context, idx *index.SpecIndex, ref string) (NodeReference[T], error) {
+	if idx == nil {
+		return NodeReference[T]{}, fmt.Errorf("unable to locate reference '%s', there is no index", ref)
+	}
+	refNode := utils.CreateRefNode(ref)
+	vn, fIdx, err, nCtx := LocateRefNodeWithContext(ctx, refNode, idx)
+	if vn == nil {
+		if err != nil {
+			return NodeReference[T]{}, err
+		}
+		return NodeReference[T]{}, fmt.Errorf("unable to locate reference '%s'", ref)
+	}
+	if fIdx != nil {
+		idx = fIdx
+	}
+	var n T = Allocate[N](nCtx)
+	if bErr := BuildModel(vn, n); bErr != nil {
+		return NodeReference[T]{}, bErr
+	}
+	if bErr := n.Build(nCtx, nil, vn, idx); bErr != nil {
+		return NodeReference[T]{}, bErr
+	}
+	res := NodeReference[T]{
+		Value:     n,
+		ValueNode: vn,
+	}
+
+	// circular references are reported as errors, unless they are allowed to be resolved.
+	if err != nil && !idx.AllowCircularReferenceResolving() {
+		return res, err
+	}
+	return res, nil
+}
+
 // ExtractObject will extract a typed Buildable[N] object from a root yaml.Node. The result is wrapped in a
 // NodeReference[T] that contains the key node found and value node found when looking up the reference.
 func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, root *yaml.Node, idx *index.SpecIndex) (NodeReference[T], error) {
@@ -387,7 +432,7 @@ func ExtractObject[T Buildable[N], N any](ctx context.Context, label string, roo
 			}
 		}
 	}
-	var n T = new(N)
+	var n T = Allocate[N](ctx)
 	err := BuildModel(vn, n)
 	if err != nil {
 		return NodeReference[T]{}, err
@@ -520,7 +565,7 @@ func ExtractArray[T Buildable[N], N any](ctx context.Context, label string, root
 					}
 				}
 			}
-			var n T = new(N)
+			var n T = Allocate[N](foundCtx)
 			err := BuildModel(node, n)
 			if err != nil {
 				return []ValueReference[T]{}, ln, vn, err
@@ -620,7 +665,7 @@ func ExtractMapNoLookupExtensions[PT Buildable[N], N any](
 					}
 				}
 			}
-			var n PT = new(N)
+			var n PT = Allocate[N](foundContext)
 			err := BuildModel(node, n)
 			if err != nil {
 				return nil, err
@@ -822,7 +867,7 @@ func ExtractMapExtensions[PT Buildable[N], N any](
 				}
 			}
 
-			var n PT = new(N)
+			var n PT = Allocate[N](sCtx)
 			en = utils.NodeAlias(en)
 			_ = BuildModel(en, n)
 			err := n.Build(sCtx, input.label, en, sIdx)
@@ -1139,31 +1184,9 @@ func hashNodeTree(h hash.Hash, n *yaml.Node, visited map[*yaml.Node]bool) {
 }
 
 // CompareYAMLNodes compares two YAML nodes for equality without marshaling to YAML.
-// This reuses the hashNodeTree logic to generate consistent hashes for comparison,
-// avoiding the expensive yaml.Marshal operations that cause massive allocations.
+// Positions, comments and styles are ignored, but the order of keys is not (see utils.YAMLNodesEqualInOrder).
 func CompareYAMLNodes(left, right *yaml.Node) bool {
-	if left == nil && right == nil {
-		return true
-	}
-	if left == nil || right == nil {
-		return false
-	}
-
-	// Use the existing hashNodeTree function to generate consistent hashes
-	leftHash := sha256.New()
-	rightHash := sha256.New()
-
-	leftVisited := make(map[*yaml.Node]bool)
-	rightVisited := make(map[*yaml.Node]bool)
-
-	hashNodeTree(leftHash, left, leftVisited)
-	hashNodeTree(rightHash, right, rightVisited)
-
-	leftSum := leftHash.Sum(nil)
-	rightSum := rightHash.Sum(nil)
-
-	// Compare the hash bytes directly
-	return bytes.Equal(leftSum, rightSum)
+	return utils.YAMLNodesEqualInOrder(left, right)
 }
 
 // YAMLNodeToBytes converts a YAML node to bytes in a more efficient way than yaml.Marshal
@@ -1297,6 +1320,10 @@ func LocateRefEnd(ctx context.Context, root *yaml.Node, idx *index.SpecIndex, de
 	if err != nil {
 		return ref, fIdx, err, nCtx
 	}
+	// a skipped remote reference resolves to itself, there is nothing more to follow.
+	if ref == root && idx != nil && idx.GetConfig() != nil && idx.GetConfig().SkipRemoteReferences {
+		return ref, fIdx, err, nCtx
+	}
 	if rf, _, _ := utils.IsNodeRefValue(ref); rf {
 		return LocateRefEnd(nCtx, ref, fIdx, depth)
 	} else {
@@ -1321,3 +1348,25 @@ func FromReferenceMapWithFunc[K comparable, V any, VOut any](refMap *orderedmap.
 	}
 	return om
 }
+
+// EmitUnknownKeys emits a datamodel.BuildEventUnknownKey event for every key of the supplied mapping node that is
+// not one of the known keys, and is not an extension. Nothing is emitted if no BuildEventHandler is configured.
+func EmitUnknownKeys(config *datamodel.DocumentConfiguration, file string, node *yaml.Node, known map[string]struct{}) {
+	if config == nil || config.BuildEventHandler == nil || node == nil || !utils.IsNodeMap(node) {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		if _, ok := known[key.Value]; ok || strings.HasPrefix(key.Value, "x-") {
+			continue
+		}
+		config.EmitBuildEvent(&datamodel.BuildEvent{
+			Type:      datamodel.BuildEventUnknownKey,
+			Subsystem: datamodel.LogSubsystemBuilder,
+			Message:   fmt.Sprintf("unknown key '%s' is not defined by the specification", key.Value),
+			File:      file,
+			Line:      key.Line,
+			Column:    key.Column,
+		})
+	}
+}
@@ -168,6 +168,39 @@ func TestExtractObject(t *testing.T) {
 	assert.Equal(t, "hello pizza", tag.Value.Description.Value)
 }
 
+func TestFindComponentAs(t *testing.T) {
+	yml := `components:
+  schemas:
+    cake:
+      description: cake time!
+    pizza:
+      $ref: '#/components/schemas/cake'`
+
+	var idxNode yaml.Node
+	mErr := yaml.Unmarshal([]byte(yml), &idxNode)
+	assert.NoError(t, mErr)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateClosedAPIIndexConfig())
+
+	cake, err := FindComponentAs[*pizza](context.Background(), idx, "#/components/schemas/cake")
+	assert.NoError(t, err)
+	assert.Equal(t, "cake time!", cake.Value.Description.Value)
+	assert.Equal(t, 4, cake.ValueNode.Line)
+	assert.Nil(t, cake.KeyNode)
+
+	double, err := FindComponentAs[*pizza](context.Background(), idx, "#/components/schemas/pizza")
+	assert.NoError(t, err)
+	assert.Equal(t, "cake time!", double.Value.Description.Value)
+
+	_, err = FindComponentAs[*pizza](context.Background(), idx, "#/components/schemas/burger")
+	assert.EqualError(t, err, "reference '#/components/schemas/burger' at line 0, column 0 was not found")
+
+	_, err = FindComponentAs[*pizza](context.Background(), idx, "")
+	assert.Error(t, err)
+
+	_, err = FindComponentAs[*pizza](context.Background(), nil, "#/components/schemas/cake")
+	assert.EqualError(t, err, "unable to locate reference '#/components/schemas/cake', there is no index")
+}
+
 func TestExtractObject_Ref(t *testing.T) {
 	yml := `components:
   schemas:
@@ -2586,6 +2619,29 @@ func TestCompareYAMLNodes_ComplexNodes(t *testing.T) {
 	assert.False(t, result2)
 }
 
+func TestCompareYAMLNodes_KeyOrder(t *testing.T) {
+	var left, right yaml.Node
+	_ = yaml.Unmarshal([]byte("a: 1\nb: [x, y]"), &left)
+	_ = yaml.Unmarshal([]byte("b: [x, y]\na: 1"), &right)
+
+	// the hashes match, but the keys are in a different order.
+	assert.True(t, utils.YAMLNodesEqual(&left, &right))
+	assert.False(t, CompareYAMLNodes(&left, &right))
+
+	var styled yaml.Node
+	_ = yaml.Unmarshal([]byte("# comment\na: '1'\nb:\n  - x\n  - \"y\""), &styled)
+	assert.False(t, CompareYAMLNodes(&left, &styled))
+	_ = yaml.Unmarshal([]byte("# comment\na: 1\nb:\n  - x\n  - \"y\""), &styled)
+	assert.True(t, CompareYAMLNodes(&left, &styled))
+}
+
+func TestCompareYAMLNodes_Circular(t *testing.T) {
+	var left, right yaml.Node
+	_ = yaml.Unmarshal([]byte("a: &a\n  b: *a"), &left)
+	_ = yaml.Unmarshal([]byte("a: &a\n  b: *a"), &right)
+	assert.True(t, CompareYAMLNodes(&left, &right))
+}
+
 func TestGenerateHashString_SchemaProxyNoCache(t *testing.T) {
 	// Test that SchemaProxy types don't get cached (shouldCache = false)
 	// We can't easily test this without creating actual SchemaProxy objects
@@ -116,6 +116,10 @@ type Swagger struct {
 	// Rolodex is a reference to the index.Rolodex instance created when the specification was read.
 	// The rolodex is used to look up references from file systems (local or remote)
 	Rolodex *index.Rolodex
+
+	// Arena is the slab allocator used to build the document, only set when UseArenaAllocation is enabled on the
+	// document configuration. This is not part of the OpenAPI schema.
+	Arena *low.Arena `json:"-" yaml:"-"`
 }
 
 // FindExtension locates an extension from the root of the Swagger document.
@@ -132,35 +136,72 @@ func (s *Swagger) GetExtensions() *orderedmap.Map[low.KeyReference[string], low.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo,
 	configuration *datamodel.DocumentConfiguration,
 ) (*Swagger, error) {
-	return createDocument(info, configuration)
+	return createDocument(context.Background(), info, configuration)
+}
+
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is used
+// for indexing and model building. If the context is cancelled or times out, the build stops at the next
+// opportunity and no document is returned, only the context error.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	configuration *datamodel.DocumentConfiguration,
+) (*Swagger, error) {
+	return createDocument(ctx, info, configuration)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
+func createDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Swagger, error) {
 	doc := Swagger{Swagger: low.ValueReference[string]{Value: info.Version, ValueNode: info.RootNode}}
 	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
 
 	// create an index config and shadow the document configuration.
 	idxConfig := index.CreateClosedAPIIndexConfig()
 	idxConfig.SpecInfo = info
+	idxConfig.UseSchemaQuickHash = config.UseSchemaQuickHash
 	idxConfig.IgnoreArrayCircularReferences = config.IgnoreArrayCircularReferences
 	idxConfig.IgnorePolymorphicCircularReferences = config.IgnorePolymorphicCircularReferences
 	idxConfig.AllowUnknownExtensionContentDetection = config.AllowUnknownExtensionContentDetection
+	idxConfig.DeduplicateFilesByContent = config.DeduplicateFilesByContent
+	idxConfig.FileContentCacheSize = config.FileContentCacheSize
 	idxConfig.AvoidCircularReferenceCheck = true
 	idxConfig.BaseURL = config.BaseURL
 	idxConfig.BasePath = config.BasePath
 	idxConfig.Logger = config.Logger
+	idxConfig.SubsystemLoggers = config.SubsystemLoggers
+	idxConfig.BuildEventHandler = config.BuildEventHandler
+	idxConfig.IndexingProgressHandler = config.IndexingProgressHandler
+	idxConfig.SlowRemoteFetchThreshold = config.SlowRemoteFetchThreshold
+	idxConfig.RemoteCacheDir = config.RemoteCacheDir
+	idxConfig.RemoteCacheTTL = config.RemoteCacheTTL
+	idxConfig.RemoteAuth = config.RemoteAuth
+	idxConfig.ObjectStores = config.ObjectStores
+	idxConfig.AllowGitReferences = config.AllowGitReferences
+	idxConfig.GitCacheDir = config.GitCacheDir
+	idxConfig.FileTransformers = config.FileTransformers
 	idxConfig.ExcludeExtensionRefs = config.ExcludeExtensionRefs
+	idxConfig.SkipRemoteReferences = config.SkipRemoteReferences
+	idxConfig.ExtractRefsSequentially = config.ExtractRefsSequentially
 	rolodex := index.NewRolodex(idxConfig)
 	rolodex.SetRootNode(info.RootNode)
 	doc.Rolodex = rolodex
 
-	// If basePath is provided, add a local filesystem to the rolodex.
-	if idxConfig.BasePath != "" {
+	// If basePath (or a local filesystem) is provided, add a local filesystem to the rolodex.
+	if idxConfig.BasePath != "" || config.LocalFS != nil {
 		var cwd string
 		cwd, _ = filepath.Abs(config.BasePath)
 		// if a supplied local filesystem is provided, add it to the rolodex.
-		if config.LocalFS != nil {
+		if _, ok := config.LocalFS.(index.RolodexFS); ok {
 			rolodex.AddLocalFS(cwd, config.LocalFS)
+		} else if config.LocalFS != nil {
+
+			// any other fs.FS (like an embed.FS) is read by a local filesystem, rooted at the base path, so the
+			// files it holds are indexed and resolved in the same way as files on disk.
+			fileFS, _ := index.NewLocalFSWithConfig(&index.LocalFSConfig{
+				BaseDirectory: cwd,
+				DirFS:         config.LocalFS,
+				LazyDirFS:     true,
+				IndexConfig:   idxConfig,
+			})
+			idxConfig.AllowFileLookup = true
+			rolodex.AddLocalFS(cwd, fileFS)
 		} else {
 
 			// create a local filesystem
@@ -177,18 +218,28 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		}
 	}
 
-	// if base url is provided, add a remote filesystem to the rolodex.
-	if idxConfig.BaseURL != nil {
+	// if base url (or an object store, or git references) is provided, add a remote filesystem to the rolodex.
+	if idxConfig.BaseURL != nil || len(config.ObjectStores) > 0 || config.AllowGitReferences {
 
-		// create a remote filesystem
-		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
-		if config.RemoteURLHandler != nil {
-			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
+		u := "default"
+		if config.BaseURL != nil {
+			u = config.BaseURL.String()
 		}
 		idxConfig.AllowRemoteLookup = true
 
-		// add to the rolodex
-		rolodex.AddRemoteFS(config.BaseURL.String(), remoteFS)
+		// if a supplied remote filesystem is provided, add it to the rolodex.
+		if config.RemoteFS != nil {
+			rolodex.AddRemoteFS(u, config.RemoteFS)
+		} else {
+			// create a remote filesystem
+			remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
+			if config.RemoteURLHandler != nil {
+				remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
+			}
+
+			// add to the rolodex
+			rolodex.AddRemoteFS(u, remoteFS)
+		}
 
 	}
 
@@ -197,12 +248,18 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	var errs []error
 
 	// index all the things!
-	_ = rolodex.IndexTheRolodex(context.Background())
+	_ = rolodex.IndexTheRolodex(ctx)
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// check for circular references
 	if !config.SkipCircularReferenceCheck {
 		rolodex.CheckForCircularReferences()
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	// extract errors
 	roloErrs := rolodex.GetCaughtErrors()
@@ -217,7 +274,10 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	// build out swagger scalar variables.
 	_ = low.BuildModel(info.RootNode.Content[0], &doc)
 
-	ctx := context.Background()
+	if config.UseArenaAllocation {
+		doc.Arena = low.NewArena()
+		ctx = low.WithArena(ctx, doc.Arena)
+	}
 
 	// extract externalDocs
 	extDocs, err := low.ExtractObject[*base.ExternalDoc](ctx, base.ExternalDocsLabel, info.RootNode, rolodex.GetRootIndex())
@@ -252,10 +312,21 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			errs = append(errs, e)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	low.EmitUnknownKeys(config, rolodex.GetRootIndex().GetSpecAbsolutePath(), info.RootNode.Content[0], swaggerKeys)
 
 	return &doc, errors.Join(errs...)
 }
 
+// swaggerKeys are the keys defined by the specification for the root of a Swagger document.
+var swaggerKeys = map[string]struct{}{
+	"swagger": {}, base.InfoLabel: {}, "host": {}, "basePath": {}, "schemes": {}, "consumes": {}, "produces": {},
+	PathsLabel: {}, DefinitionsLabel: {}, ParametersLabel: {}, ResponsesLabel: {}, SecurityDefinitionsLabel: {},
+	SecurityLabel: {}, base.TagsLabel: {}, base.ExternalDocsLabel: {},
+}
+
 func (s *Swagger) GetExternalDocs() *low.NodeReference[any] {
 	return &low.NodeReference[any]{
 		KeyNode:   s.ExternalDocs.KeyNode,
@@ -4,6 +4,7 @@
 package v2
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -43,6 +44,17 @@ func BenchmarkCreateDocument(b *testing.B) {
 	}
 }
 
+func TestCreateDocumentFromConfigWithContext_Cancelled(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/petstorev2-complete.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d, err := CreateDocumentFromConfigWithContext(ctx, info, datamodel.NewDocumentConfiguration())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, d)
+}
+
 func TestCreateDocument(t *testing.T) {
 	initTest()
 	doc := doc
@@ -321,7 +333,7 @@ func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	cf.LocalFS = os.DirFS(baseDir)
 	lDoc, err := CreateDocumentFromConfig(info, cf)
 	assert.NotNil(t, lDoc)
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
 func TestRolodexLocalFileSystem_ProvideRolodexFS(t *testing.T) {
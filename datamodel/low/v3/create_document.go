@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"path/filepath"
 	"strings"
@@ -23,32 +24,165 @@ import (
 // Deprecated: Use CreateDocumentFromConfig instead. This function will be removed in a later version, it
 // defaults to allowing file and remote references, and does not support relative file references.
 func CreateDocument(info *datamodel.SpecInfo) (*Document, error) {
-	return createDocument(info, datamodel.NewDocumentConfiguration())
+	return createDocument(context.Background(), info, datamodel.NewDocumentConfiguration())
 }
 
 // CreateDocumentFromConfig Create a new document from the provided SpecInfo and DocumentConfiguration pointer.
 func CreateDocumentFromConfig(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
-	return createDocument(info, config)
+	return createDocument(context.Background(), info, config)
 }
 
-func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
+// CreateDocumentFromConfigWithContext is the same as CreateDocumentFromConfig, except the supplied context is used
+// for indexing and model building. If the context is cancelled or times out, the build stops at the next
+// opportunity and no document is returned, only the context error.
+func CreateDocumentFromConfigWithContext(ctx context.Context, info *datamodel.SpecInfo,
+	config *datamodel.DocumentConfiguration,
+) (*Document, error) {
+	return createDocument(ctx, info, config)
+}
+
+func createDocument(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*Document, error) {
 	_, labelNode, versionNode := utils.FindKeyNodeFull(OpenAPILabel, info.RootNode.Content)
 	var version low.NodeReference[string]
 	if versionNode == nil {
 		return nil, errors.New("no openapi version/tag found, cannot create document")
 	}
 	version = low.NodeReference[string]{Value: versionNode.Value, KeyNode: labelNode, ValueNode: versionNode}
-	doc := Document{Version: version}
+	doc := Document{Version: version, Configuration: config}
 	doc.Nodes = low.ExtractNodes(nil, info.RootNode.Content[0])
+
+	// only log when a logger has been configured.
+	var logger *slog.Logger
+	if config.Logger != nil || config.SubsystemLoggers[datamodel.LogSubsystemBuilder] != nil {
+		logger = config.GetSubsystemLogger(datamodel.LogSubsystemBuilder)
+	}
+
+	rolodex, errs, err := createRolodex(ctx, info, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	doc.Rolodex = rolodex
+
+	// set root index.
+	doc.Index = rolodex.GetRootIndex()
+	var wg sync.WaitGroup
+
+	var cacheMap sync.Map
+	modelContext := base.ModelContext{SchemaCache: &cacheMap}
+	ctx = context.WithValue(ctx, "modelCtx", &modelContext)
+	if config.UseArenaAllocation {
+		doc.Arena = low.NewArena()
+		ctx = low.WithArena(ctx, doc.Arena)
+	}
+
+	doc.RootNode = info.RootNode.Content[0]
+	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
+	low.ExtractExtensionNodes(ctx, doc.Extensions, doc.Nodes)
+
+	// if set, extract jsonSchemaDialect (3.1)
+	_, dialectLabel, dialectNode := utils.FindKeyNodeFull(JSONSchemaDialectLabel, info.RootNode.Content)
+	if dialectNode != nil {
+		doc.JsonSchemaDialect = low.NodeReference[string]{
+			Value: dialectNode.Value, KeyNode: dialectLabel, ValueNode: dialectNode,
+		}
+	}
+
+	// if set, extract $self (3.2)
+	_, selfLabel, selfNode := utils.FindKeyNodeFull(SelfLabel, info.RootNode.Content)
+	if selfNode != nil {
+		doc.Self = low.NodeReference[string]{
+			Value: selfNode.Value, KeyNode: selfLabel, ValueNode: selfNode,
+		}
+	}
+
+	runExtraction := func(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex,
+		runFunc func(ctx context.Context, i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error,
+		ers *[]error,
+		wg *sync.WaitGroup,
+	) {
+		defer wg.Done()
+		if ctx.Err() != nil {
+			return
+		}
+		if er := runFunc(ctx, info, doc, idx); er != nil {
+			*ers = append(*ers, er)
+		}
+	}
+	extractionFuncs := []func(ctx context.Context, i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error{
+		extractInfo,
+		extractServers,
+		extractTags,
+		extractComponents,
+		extractSecurity,
+		extractExternalDocs,
+		extractWebhooks,
+	}
+	if !config.LazyPaths {
+		extractionFuncs = append(extractionFuncs, extractPaths)
+	}
+
+	wg.Add(len(extractionFuncs))
+	if logger != nil {
+		logger.Debug("running extractions")
+	}
+	now := time.Now()
+	for _, f := range extractionFuncs {
+		runExtraction(ctx, info, &doc, rolodex.GetRootIndex(), f, &errs, &wg)
+	}
+	wg.Wait()
+	done := time.Duration(time.Since(now).Milliseconds())
+	if logger != nil {
+		logger.Debug("extractions complete", "time", done)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	low.EmitUnknownKeys(config, rolodex.GetRootIndex().GetSpecAbsolutePath(), info.RootNode.Content[0], documentKeys)
+	if doc.Info.Value != nil && doc.Info.Value.License.Value != nil {
+		errs = append(errs, doc.Info.Value.License.Value.Validate(config.LicenseIdentifierChecker)...)
+	}
+	return &doc, errors.Join(errs...)
+}
+
+// CreateRolodex creates the rolodex of an OpenAPI 3 specification, with the local and remote file systems allowed by
+// the configuration, and indexes it (and every file it references) without building the document model. This is
+// useful for tools that work directly with the indexed yaml nodes. The errors caught while indexing are returned
+// with the rolodex, which can still be used.
+func CreateRolodex(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration) (*index.Rolodex, error) {
+	if info == nil || info.RootNode == nil {
+		return nil, errors.New("no specification provided, cannot create rolodex")
+	}
+	if config == nil {
+		config = datamodel.NewDocumentConfiguration()
+	}
+	var logger *slog.Logger
+	if config.Logger != nil || config.SubsystemLoggers[datamodel.LogSubsystemBuilder] != nil {
+		logger = config.GetSubsystemLogger(datamodel.LogSubsystemBuilder)
+	}
+	rolodex, errs, err := createRolodex(ctx, info, config, logger)
+	if err != nil {
+		return nil, err
+	}
+	return rolodex, errors.Join(errs...)
+}
+
+// createRolodex creates and indexes the rolodex of a document, returning the errors caught while indexing. An
+// error is only returned if the context is done.
+func createRolodex(ctx context.Context, info *datamodel.SpecInfo, config *datamodel.DocumentConfiguration,
+	logger *slog.Logger,
+) (*index.Rolodex, []error, error) {
 	// create an index config and shadow the document configuration.
 	idxConfig := index.CreateClosedAPIIndexConfig()
 	idxConfig.SpecInfo = info
 	idxConfig.UseSchemaQuickHash = config.UseSchemaQuickHash
 	idxConfig.ExcludeExtensionRefs = config.ExcludeExtensionRefs
+	idxConfig.SkipRemoteReferences = config.SkipRemoteReferences
 	idxConfig.IgnoreArrayCircularReferences = config.IgnoreArrayCircularReferences
 	idxConfig.IgnorePolymorphicCircularReferences = config.IgnorePolymorphicCircularReferences
 	idxConfig.AllowUnknownExtensionContentDetection = config.AllowUnknownExtensionContentDetection
 	idxConfig.TransformSiblingRefs = config.TransformSiblingRefs
+	idxConfig.DeduplicateFilesByContent = config.DeduplicateFilesByContent
+	idxConfig.FileContentCacheSize = config.FileContentCacheSize
 	idxConfig.AvoidCircularReferenceCheck = true
 
 	// handle $self field for OpenAPI 3.2+ documents
@@ -57,8 +191,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		selfURL, err := url.Parse(info.Self)
 		if err != nil {
 			// log error but continue with original config
-			if config.Logger != nil {
-				config.Logger.Error("$self field contains invalid URL", "self", info.Self, "error", err)
+			if logger != nil {
+				logger.Error("$self field contains invalid URL", "self", info.Self, "error", err)
 			}
 			// store error in spec info for later retrieval
 			if info.Error == nil {
@@ -68,8 +202,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			// validate http/https URLs
 			if config.BaseURL != nil {
 				// conflict detected
-				if config.Logger != nil {
-					config.Logger.Error("BaseURL and $self have been set and conflict, defaulting to BaseURL",
+				if logger != nil {
+					logger.Error("BaseURL and $self have been set and conflict, defaulting to BaseURL",
 						"baseURL", config.BaseURL.String(), "self", info.Self)
 				}
 				// use config BaseURL (programmatic control trumps document)
@@ -80,8 +214,8 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 		} else {
 			// for non-http URLs (like file:// or custom schemes), use as-is if no conflict
 			if config.BaseURL != nil {
-				if config.Logger != nil {
-					config.Logger.Error("BaseURL and $self have been set and conflict, defaulting to BaseURL",
+				if logger != nil {
+					logger.Error("BaseURL and $self have been set and conflict, defaulting to BaseURL",
 						"baseURL", config.BaseURL.String(), "self", info.Self)
 				}
 			} else {
@@ -94,19 +228,41 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 	idxConfig.BasePath = config.BasePath
 	idxConfig.SpecFilePath = config.SpecFilePath
 	idxConfig.Logger = config.Logger
+	idxConfig.SubsystemLoggers = config.SubsystemLoggers
+	idxConfig.BuildEventHandler = config.BuildEventHandler
+	idxConfig.IndexingProgressHandler = config.IndexingProgressHandler
+	idxConfig.SlowRemoteFetchThreshold = config.SlowRemoteFetchThreshold
+	idxConfig.RemoteCacheDir = config.RemoteCacheDir
+	idxConfig.RemoteCacheTTL = config.RemoteCacheTTL
+	idxConfig.RemoteAuth = config.RemoteAuth
+	idxConfig.ObjectStores = config.ObjectStores
+	idxConfig.AllowGitReferences = config.AllowGitReferences
+	idxConfig.GitCacheDir = config.GitCacheDir
+	idxConfig.FileTransformers = config.FileTransformers
 	extract := config.ExtractRefsSequentially
 	idxConfig.ExtractRefsSequentially = extract
 	rolodex := index.NewRolodex(idxConfig)
 	rolodex.SetRootNode(info.RootNode)
-	doc.Rolodex = rolodex
 
-	// If basePath is provided, add a local filesystem to the rolodex.
-	if idxConfig.BasePath != "" || config.AllowFileReferences {
+	// If basePath (or a local filesystem) is provided, add a local filesystem to the rolodex.
+	if idxConfig.BasePath != "" || config.AllowFileReferences || config.LocalFS != nil {
 		var cwd string
 		cwd, _ = filepath.Abs(config.BasePath)
 		// if a supplied local filesystem is provided, add it to the rolodex.
-		if config.LocalFS != nil {
+		if _, ok := config.LocalFS.(index.RolodexFS); ok {
 			rolodex.AddLocalFS(cwd, config.LocalFS)
+		} else if config.LocalFS != nil {
+
+			// any other fs.FS (like an embed.FS) is read by a local filesystem, rooted at the base path, so the
+			// files it holds are indexed and resolved in the same way as files on disk.
+			fileFS, _ := index.NewLocalFSWithConfig(&index.LocalFSConfig{
+				BaseDirectory: cwd,
+				DirFS:         config.LocalFS,
+				LazyDirFS:     true,
+				IndexConfig:   idxConfig,
+			})
+			idxConfig.AllowFileLookup = true
+			rolodex.AddLocalFS(cwd, fileFS)
 		} else {
 
 			// create a local filesystem
@@ -123,119 +279,74 @@ func createDocument(info *datamodel.SpecInfo, config *datamodel.DocumentConfigur
 			rolodex.AddLocalFS(cwd, fileFS)
 		}
 	}
-	// if base url is provided, add a remote filesystem to the rolodex.
-	if idxConfig.BaseURL != nil || config.AllowRemoteReferences {
-
-		// create a remote filesystem
-		remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
-		if config.RemoteURLHandler != nil {
-			remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
-		}
-		idxConfig.AllowRemoteLookup = true
+	// if base url (or an object store, or git references) is provided, add a remote filesystem to the rolodex.
+	if idxConfig.BaseURL != nil || config.AllowRemoteReferences || len(config.ObjectStores) > 0 || config.AllowGitReferences {
 
 		// add to the rolodex
 		u := "default"
 		if config.BaseURL != nil {
 			u = config.BaseURL.String()
 		}
-		rolodex.AddRemoteFS(u, remoteFS)
+		idxConfig.AllowRemoteLookup = true
+
+		// if a supplied remote filesystem is provided, add it to the rolodex.
+		if config.RemoteFS != nil {
+			rolodex.AddRemoteFS(u, config.RemoteFS)
+		} else {
+			// create a remote filesystem
+			remoteFS, _ := index.NewRemoteFSWithConfig(idxConfig)
+			if config.RemoteURLHandler != nil {
+				remoteFS.RemoteHandlerFunc = config.RemoteURLHandler
+			}
+			rolodex.AddRemoteFS(u, remoteFS)
+		}
 	}
 
 	// index the rolodex
 	var errs []error
 
 	// index all the things.
-	if config.Logger != nil {
-		config.Logger.Debug("indexing rolodex")
+	if logger != nil {
+		logger.Debug("indexing rolodex")
 	}
 	now := time.Now()
-	_ = rolodex.IndexTheRolodex(context.Background())
+	_ = rolodex.IndexTheRolodex(ctx)
 	done := time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("rolodex indexed", "ms", done)
+	if logger != nil {
+		logger.Debug("rolodex indexed", "ms", done)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 	// check for circular references
-	if config.Logger != nil {
-		config.Logger.Debug("checking for circular references")
+	if logger != nil {
+		logger.Debug("checking for circular references")
 	}
 	now = time.Now()
 	if !config.SkipCircularReferenceCheck {
 		rolodex.CheckForCircularReferences()
 	}
 	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
+	if logger != nil {
 		if !config.SkipCircularReferenceCheck {
-			config.Logger.Debug("circular check completed", "ms", done)
+			logger.Debug("circular check completed", "ms", done)
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 	// extract errors
 	roloErrs := rolodex.GetCaughtErrors()
 	if roloErrs != nil {
 		errs = append(errs, roloErrs...)
 	}
+	return rolodex, errs, nil
+}
 
-	// set root index.
-	doc.Index = rolodex.GetRootIndex()
-	var wg sync.WaitGroup
-
-	var cacheMap sync.Map
-	modelContext := base.ModelContext{SchemaCache: &cacheMap}
-	ctx := context.WithValue(context.Background(), "modelCtx", &modelContext)
-
-	doc.Extensions = low.ExtractExtensions(info.RootNode.Content[0])
-	low.ExtractExtensionNodes(ctx, doc.Extensions, doc.Nodes)
-
-	// if set, extract jsonSchemaDialect (3.1)
-	_, dialectLabel, dialectNode := utils.FindKeyNodeFull(JSONSchemaDialectLabel, info.RootNode.Content)
-	if dialectNode != nil {
-		doc.JsonSchemaDialect = low.NodeReference[string]{
-			Value: dialectNode.Value, KeyNode: dialectLabel, ValueNode: dialectNode,
-		}
-	}
-
-	// if set, extract $self (3.2)
-	_, selfLabel, selfNode := utils.FindKeyNodeFull(SelfLabel, info.RootNode.Content)
-	if selfNode != nil {
-		doc.Self = low.NodeReference[string]{
-			Value: selfNode.Value, KeyNode: selfLabel, ValueNode: selfNode,
-		}
-	}
-
-	runExtraction := func(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex,
-		runFunc func(ctx context.Context, i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error,
-		ers *[]error,
-		wg *sync.WaitGroup,
-	) {
-		if er := runFunc(ctx, info, doc, idx); er != nil {
-			*ers = append(*ers, er)
-		}
-		wg.Done()
-	}
-	extractionFuncs := []func(ctx context.Context, i *datamodel.SpecInfo, d *Document, idx *index.SpecIndex) error{
-		extractInfo,
-		extractServers,
-		extractTags,
-		extractComponents,
-		extractSecurity,
-		extractExternalDocs,
-		extractPaths,
-		extractWebhooks,
-	}
-
-	wg.Add(len(extractionFuncs))
-	if config.Logger != nil {
-		config.Logger.Debug("running extractions")
-	}
-	now = time.Now()
-	for _, f := range extractionFuncs {
-		runExtraction(ctx, info, &doc, rolodex.GetRootIndex(), f, &errs, &wg)
-	}
-	wg.Wait()
-	done = time.Duration(time.Since(now).Milliseconds())
-	if config.Logger != nil {
-		config.Logger.Debug("extractions complete", "time", done)
-	}
-	return &doc, errors.Join(errs...)
+// documentKeys are the keys defined by the specification for the root of an OpenAPI 3 document.
+var documentKeys = map[string]struct{}{
+	OpenAPILabel: {}, SelfLabel: {}, base.InfoLabel: {}, JSONSchemaDialectLabel: {}, ServersLabel: {}, PathsLabel: {},
+	WebhooksLabel: {}, ComponentsLabel: {}, SecurityLabel: {}, base.TagsLabel: {}, base.ExternalDocsLabel: {},
 }
 
 func extractInfo(ctx context.Context, info *datamodel.SpecInfo, doc *Document, idx *index.SpecIndex) error {
@@ -3,10 +3,12 @@ package v3
 import (
 	"context"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/pb33f/libopenapi/index"
@@ -164,6 +166,46 @@ func TestRolodexLocalFileSystem(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestCreateRolodex(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	cf := datamodel.NewDocumentConfiguration()
+	cf.BasePath = "../../../test_specs"
+	cf.FileFilter = []string{"first.yaml", "second.yaml", "third.yaml"}
+	rolodex, err := CreateRolodex(context.Background(), info, cf)
+	assert.NoError(t, err)
+	require.NotNil(t, rolodex)
+	assert.NotNil(t, rolodex.GetRootIndex())
+	assert.NotEmpty(t, rolodex.GetIndexes())
+}
+
+func TestCreateRolodex_BadPath(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/first.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	cf := datamodel.NewDocumentConfiguration()
+	cf.BasePath = "/NOWHERE"
+	rolodex, err := CreateRolodex(context.Background(), info, cf)
+	assert.Error(t, err)
+	assert.NotNil(t, rolodex)
+}
+
+func TestCreateRolodex_NoSpec(t *testing.T) {
+	rolodex, err := CreateRolodex(context.Background(), nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, rolodex)
+}
+
+func TestCreateRolodex_Cancelled(t *testing.T) {
+	info, _ := datamodel.ExtractSpecInfo([]byte("openapi: 3.1.0"))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rolodex, err := CreateRolodex(ctx, info, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, rolodex)
+}
+
 func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	data, _ := os.ReadFile("../../../test_specs/first.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -175,7 +217,7 @@ func TestRolodexLocalFileSystem_ProvideNonRolodexFS(t *testing.T) {
 	cf.LocalFS = os.DirFS(baseDir)
 	lDoc, err := CreateDocumentFromConfig(info, cf)
 	assert.NotNil(t, lDoc)
-	assert.Error(t, err)
+	assert.NoError(t, err)
 }
 
 func TestRolodexLocalFileSystem_ProvideRolodexFS(t *testing.T) {
@@ -253,6 +295,35 @@ func TestRolodexRemoteFileSystem_CustomRemote_NoBaseURL(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestRolodexRemoteFileSystem_CustomRemote(t *testing.T) {
+	spec := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'https://example.com/pet.yaml'`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+
+	var fetched []string
+	u, _ := url.Parse("https://example.com")
+	remoteFS, _ := index.NewRemoteFSWithConfig(&index.SpecIndexConfig{BaseURL: u, AllowRemoteLookup: true})
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		fetched = append(fetched, url)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("type: object")),
+		}, nil
+	}
+
+	cf := datamodel.NewDocumentConfiguration()
+	cf.BaseURL = u
+	cf.RemoteFS = remoteFS
+	lDoc, err := CreateDocumentFromConfig(info, cf)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/pet.yaml"}, fetched)
+	assert.Equal(t, "object", lDoc.Components.Value.FindSchema("Pet").Value.Schema().Type.Value.A)
+}
+
 func TestRolodexRemoteFileSystem_CustomHttpHandler(t *testing.T) {
 	data, _ := os.ReadFile("../../../test_specs/first.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -347,6 +418,55 @@ func BenchmarkCreateDocument_Petstore(b *testing.B) {
 	}
 }
 
+func BenchmarkCreateDocument_Stripe_Arena(b *testing.B) {
+	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{UseArenaAllocation: true})
+		if err != nil {
+			panic("this should not error")
+		}
+		d.Arena.Release()
+	}
+}
+
+func TestCreateDocument_ArenaAllocation(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	d, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{UseArenaAllocation: true})
+	require.NoError(t, err)
+	require.NotNil(t, d.Arena)
+	assert.Greater(t, d.Arena.Allocated(), int64(0))
+	assert.Equal(t, "object", d.Components.Value.FindSchema("Burger").Value.Schema().Type.Value.A)
+
+	plain, err := CreateDocumentFromConfig(info, &datamodel.DocumentConfiguration{})
+	require.NoError(t, err)
+	assert.Nil(t, plain.Arena)
+	assert.Equal(t, plain.Paths.Value.Hash(), d.Paths.Value.Hash())
+}
+
+func TestCreateDocumentFromConfigWithContext(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	d, err := CreateDocumentFromConfigWithContext(context.Background(), info, &datamodel.DocumentConfiguration{})
+	require.NoError(t, err)
+	assert.NotNil(t, d.Components.Value.FindSchema("Burger"))
+}
+
+func TestCreateDocumentFromConfigWithContext_Cancelled(t *testing.T) {
+	data, _ := os.ReadFile("../../../test_specs/burgershop.openapi.yaml")
+	info, _ := datamodel.ExtractSpecInfo(data)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	d, err := CreateDocumentFromConfigWithContext(ctx, info, &datamodel.DocumentConfiguration{})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, d)
+}
+
 func TestCreateDocumentStripe(t *testing.T) {
 	data, _ := os.ReadFile("../../../test_specs/stripe.yaml")
 	info, _ := datamodel.ExtractSpecInfo(data)
@@ -1083,3 +1203,43 @@ paths: {}`
 	// but the index should use the configured BaseURL, not $self
 	assert.NotNil(t, doc.Index)
 }
+
+func TestCreateDocument_LicenseValidation(t *testing.T) {
+	yml := `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+  license:
+    name: pizza
+    url: https://pb33f.io
+    identifier: MIT
+paths: {}`
+
+	info, _ := datamodel.ExtractSpecInfo([]byte(yml))
+	doc, err := CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.EqualError(t, err, "license 'url' and 'identifier' are mutually exclusive, only one can be defined")
+	assert.NotNil(t, doc)
+	assert.Equal(t, "MIT", doc.Info.Value.License.Value.Identifier.Value)
+
+	yml = `openapi: 3.1.0
+info:
+  title: Test API
+  version: 1.0.0
+  license:
+    name: pizza
+    identifier: MIT-ish
+paths: {}`
+
+	info, _ = datamodel.ExtractSpecInfo([]byte(yml))
+	doc, err = CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+	assert.NoError(t, err)
+
+	config := datamodel.NewDocumentConfiguration()
+	config.LicenseIdentifierChecker = func(identifier string) bool {
+		return identifier == "MIT"
+	}
+	info, _ = datamodel.ExtractSpecInfo([]byte(yml))
+	doc, err = CreateDocumentFromConfig(info, config)
+	assert.EqualError(t, err, "license identifier 'MIT-ish' is not a valid SPDX license expression")
+	assert.NotNil(t, doc)
+}
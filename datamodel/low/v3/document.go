@@ -12,6 +12,7 @@ import (
 	"hash/maphash"
 	"sort"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/index"
@@ -84,6 +85,9 @@ type Document struct {
 	// Extensions contains all custom extensions defined for the top-level document.
 	Extensions *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 
+	// RootNode is the root mapping node of the document, the node every other node of the document sits under.
+	RootNode *yaml.Node `json:"-" yaml:"-"`
+
 	// Index is a reference to the *index.SpecIndex that was created for the document and used
 	// as a guide when building out the Document. Ideal if further processing is required on the model and
 	// the original details are required to continue the work.
@@ -94,6 +98,13 @@ type Document struct {
 	// Rolodex is a reference to the rolodex used when creating this document.
 	Rolodex *index.Rolodex
 
+	// Configuration is the configuration used when creating this document. This is not part of the OpenAPI schema.
+	Configuration *datamodel.DocumentConfiguration `json:"-" yaml:"-"`
+
+	// Arena is the slab allocator used to build the document, only set when UseArenaAllocation is enabled on the
+	// document configuration. This is not part of the OpenAPI schema.
+	Arena *low.Arena `json:"-" yaml:"-"`
+
 	// StorageRoot is the root path to the storage location of the document. This has no effect on resolving references.
 	// but it's used by the doctor to determine where to store the document. This is not part of the OpenAPI schema.
 	StorageRoot string `json:"-" yaml:"-"`
@@ -127,6 +138,11 @@ func (d *Document) GetExternalDocs() *low.NodeReference[any] {
 	}
 }
 
+// GetRootNode returns the root mapping node of the document and satisfies the low.HasRootNode interface.
+func (d *Document) GetRootNode() *yaml.Node {
+	return d.RootNode
+}
+
 func (d *Document) GetIndex() *index.SpecIndex {
 	return d.Index
 }
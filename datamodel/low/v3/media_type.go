@@ -21,17 +21,18 @@ import (
 // Each Media Type Object provides schema and examples for the media type identified by its key.
 //   - https://spec.openapis.org/oas/v3.1.0#media-type-object
 type MediaType struct {
-	Schema       low.NodeReference[*base.SchemaProxy]
-	ItemSchema   low.NodeReference[*base.SchemaProxy]
-	Example      low.NodeReference[*yaml.Node]
-	Examples     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
-	Encoding     low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
-	ItemEncoding low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
-	Extensions   *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	KeyNode      *yaml.Node
-	RootNode     *yaml.Node
-	index        *index.SpecIndex
-	context      context.Context
+	Schema         low.NodeReference[*base.SchemaProxy]
+	ItemSchema     low.NodeReference[*base.SchemaProxy]
+	Example        low.NodeReference[*yaml.Node]
+	Examples       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.Example]]]
+	Encoding       low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
+	ItemEncoding   low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[*Encoding]]]
+	PrefixEncoding low.NodeReference[[]low.ValueReference[*Encoding]] // OpenAPI 3.2+
+	Extensions     *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	KeyNode        *yaml.Node
+	RootNode       *yaml.Node
+	index          *index.SpecIndex
+	context        context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -181,6 +182,20 @@ func (mt *MediaType) Build(ctx context.Context, keyNode, root *yaml.Node, idx *i
 		}
 	}
 
+	// handle prefixEncoding
+	prefixEncs, prefixEncsL, prefixEncsN, prefixEncErr := low.ExtractArray[*Encoding](ctx, PrefixEncodingLabel, root, idx)
+	if prefixEncErr != nil {
+		return prefixEncErr
+	}
+	if prefixEncs != nil {
+		mt.PrefixEncoding = low.NodeReference[[]low.ValueReference[*Encoding]]{
+			Value:     prefixEncs,
+			KeyNode:   prefixEncsL,
+			ValueNode: prefixEncsN,
+		}
+		mt.Nodes.Store(prefixEncsL.Line, prefixEncsL)
+	}
+
 	return nil
 }
 
@@ -211,6 +226,10 @@ func (mt *MediaType) Hash() uint64 {
 			h.WriteString(low.GenerateHashString(v.Value))
 			h.WriteByte(low.HASH_PIPE)
 		}
+		for _, v := range mt.PrefixEncoding.Value {
+			h.WriteString(low.GenerateHashString(v.Value))
+			h.WriteByte(low.HASH_PIPE)
+		}
 		for _, ext := range low.HashExtensions(mt.Extensions) {
 			h.WriteString(ext)
 			h.WriteByte(low.HASH_PIPE)
@@ -141,14 +141,15 @@ func (o *OAuthFlows) Hash() uint64 {
 // OAuthFlow represents a low-level OpenAPI 3+ OAuthFlow object.
 //   - https://spec.openapis.org/oas/v3.1.0#oauth-flow-object
 type OAuthFlow struct {
-	AuthorizationUrl low.NodeReference[string]
-	TokenUrl         low.NodeReference[string]
-	RefreshUrl       low.NodeReference[string]
-	Scopes           low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
-	Extensions       *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
-	RootNode         *yaml.Node
-	index            *index.SpecIndex
-	context          context.Context
+	AuthorizationUrl       low.NodeReference[string]
+	DeviceAuthorizationUrl low.NodeReference[string] // OpenAPI 3.2+ device flow
+	TokenUrl               low.NodeReference[string]
+	RefreshUrl             low.NodeReference[string]
+	Scopes                 low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.ValueReference[string]]]
+	Extensions             *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
+	RootNode               *yaml.Node
+	index                  *index.SpecIndex
+	context                context.Context
 	*low.Reference
 	low.NodeMap
 }
@@ -209,6 +210,10 @@ func (o *OAuthFlow) Hash() uint64 {
 			h.WriteString(o.AuthorizationUrl.Value)
 			h.WriteByte(low.HASH_PIPE)
 		}
+		if !o.DeviceAuthorizationUrl.IsEmpty() {
+			h.WriteString(o.DeviceAuthorizationUrl.Value)
+			h.WriteByte(low.HASH_PIPE)
+		}
 		if !o.TokenUrl.IsEmpty() {
 			h.WriteString(o.TokenUrl.Value)
 			h.WriteByte(low.HASH_PIPE)
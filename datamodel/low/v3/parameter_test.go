@@ -365,3 +365,26 @@ schema:
 	hash2 := n.Hash()
 	assert.NotEqual(t, hash1, hash2)
 }
+
+func TestParameter_FindComponentAs(t *testing.T) {
+	yml := `components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+    pageLimit:
+      $ref: '#/components/parameters/limit'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndexWithConfig(&idxNode, index.CreateOpenAPIIndexConfig())
+
+	param, err := low.FindComponentAs[*Parameter](context.Background(), idx, "#/components/parameters/pageLimit")
+	require.NoError(t, err)
+	assert.Equal(t, "limit", param.Value.Name.Value)
+	assert.Equal(t, "query", param.Value.In.Value)
+	assert.Equal(t, "integer", param.Value.Schema.Value.Schema().Type.Value.A)
+	assert.Same(t, idx, param.Value.GetIndex())
+}
@@ -198,6 +198,7 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 	var errors []error
 	var ops []low.NodeReference[*Operation]
 	var additionalOps *orderedmap.Map[low.KeyReference[string], low.NodeReference[*Operation]]
+	var additionalOpsKeyNode, additionalOpsValueNode *yaml.Node
 
 	// extract parameters
 	params, ln, vn, pErr := low.ExtractArray[*Parameter](ctx, ParametersLabel, root, idx)
@@ -334,6 +335,7 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 
 			// now we need to determine if these are inline additional operations, or just plonked into the root.
 			if currentNode.Value == AdditionalOperationsLabel {
+				additionalOpsKeyNode, additionalOpsValueNode = currentNode, pathNode
 
 				for j := 0; j < len(pathNode.Content); j += 2 {
 					opKeyNode := pathNode.Content[j]
@@ -412,9 +414,18 @@ func (p *PathItem) Build(ctx context.Context, keyNode, root *yaml.Node, idx *ind
 		}
 
 		err = datamodel.TranslateSliceParallel[low.NodeReference[*Operation], any](extrOps, translateFunc, nil)
+		if err != nil {
+			return err
+		}
 
+		// operations that are not inside an 'additionalOperations' map are attached to the path item itself.
+		if additionalOpsValueNode == nil {
+			additionalOpsValueNode = root
+		}
 		p.AdditionalOperations = low.NodeReference[*orderedmap.Map[low.KeyReference[string], low.NodeReference[*Operation]]]{
-			Value: additionalOps,
+			Value:     additionalOps,
+			KeyNode:   additionalOpsKeyNode,
+			ValueNode: additionalOpsValueNode,
 		}
 	}
 	return nil
@@ -199,55 +199,11 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 
 	err := datamodel.TranslatePipeline[buildInput, buildResult](in, out,
 		func(value buildInput) (buildResult, error) {
-			pNode := value.pathNode
-			cNode := value.currentNode
-
-			foundContext := ctx
-			var isRef bool
-			var refNode *yaml.Node
-			if ok, _, _ := utils.IsNodeRefValue(pNode); ok {
-				isRef = true
-				refNode = pNode
-				r, _, err, fCtx := low.LocateRefNodeWithContext(ctx, pNode, idx)
-				if r != nil {
-					pNode = r
-					foundContext = fCtx
-					if err != nil {
-						if !idx.AllowCircularReferenceResolving() {
-							return buildResult{}, fmt.Errorf("path item build failed: %s", err.Error())
-						}
-					}
-				} else {
-					return buildResult{}, fmt.Errorf("path item build failed: cannot find reference: '%s' at line %d, col %d",
-						pNode.Content[1].Value, pNode.Content[1].Line, pNode.Content[1].Column)
-				}
-			}
-
-			path := new(PathItem)
-			_ = low.BuildModel(pNode, path)
-			err := path.Build(foundContext, cNode, pNode, idx)
-
-			if isRef {
-				path.SetReference(refNode.Content[1].Value, refNode)
-			}
-
+			key, item, err := buildPathItem(ctx, value.currentNode, value.pathNode, idx)
 			if err != nil {
-				if idx != nil && idx.GetLogger() != nil {
-					idx.GetLogger().Error(fmt.Sprintf("error building path item: %s", err.Error()))
-				}
-				// return buildResult{}, err
+				return buildResult{}, err
 			}
-
-			return buildResult{
-				key: low.KeyReference[string]{
-					Value:   cNode.Value,
-					KeyNode: cNode,
-				},
-				value: low.ValueReference[*PathItem]{
-					Value:     path,
-					ValueNode: pNode,
-				},
-			}, nil
+			return buildResult{key: key, value: item}, nil
 		},
 	)
 	wg.Wait()
@@ -256,3 +212,80 @@ func extractPathItemsMap(ctx context.Context, root *yaml.Node, idx *index.SpecIn
 	}
 	return pathsMap, nil
 }
+
+// WalkPathItems builds the path items of a Paths node one at a time, in the order they appear, and calls fn with
+// each one. Unlike building the Paths, the path items are not kept, so each one can be released as soon as fn
+// returns. The walk stops if the context is cancelled, or fn returns an error, and the error is returned.
+func WalkPathItems(ctx context.Context, root *yaml.Node, idx *index.SpecIndex,
+	fn func(key low.KeyReference[string], pathItem low.ValueReference[*PathItem]) error,
+) error {
+	root = utils.NodeAlias(root)
+	if root == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if strings.HasPrefix(strings.ToLower(root.Content[i].Value), "x-") {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		key, item, err := buildPathItem(ctx, root.Content[i], root.Content[i+1], idx)
+		if err != nil {
+			return err
+		}
+		item.Value.Nodes.Store(key.KeyNode.Line, key.KeyNode)
+		if err = fn(key, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildPathItem builds the path item of a single path, resolving it first if it's a reference.
+func buildPathItem(ctx context.Context, cNode, pNode *yaml.Node,
+	idx *index.SpecIndex,
+) (low.KeyReference[string], low.ValueReference[*PathItem], error) {
+	foundContext := ctx
+	var isRef bool
+	var refNode *yaml.Node
+	if ok, _, _ := utils.IsNodeRefValue(pNode); ok {
+		isRef = true
+		refNode = pNode
+		r, _, err, fCtx := low.LocateRefNodeWithContext(ctx, pNode, idx)
+		if r != nil {
+			pNode = r
+			foundContext = fCtx
+			if err != nil {
+				if !idx.AllowCircularReferenceResolving() {
+					return low.KeyReference[string]{}, low.ValueReference[*PathItem]{},
+						fmt.Errorf("path item build failed: %s", err.Error())
+				}
+			}
+		} else {
+			return low.KeyReference[string]{}, low.ValueReference[*PathItem]{},
+				fmt.Errorf("path item build failed: cannot find reference: '%s' at line %d, col %d",
+					pNode.Content[1].Value, pNode.Content[1].Line, pNode.Content[1].Column)
+		}
+	}
+
+	path := new(PathItem)
+	_ = low.BuildModel(pNode, path)
+	err := path.Build(foundContext, cNode, pNode, idx)
+
+	if isRef {
+		path.SetReference(refNode.Content[1].Value, refNode)
+	}
+
+	// errors building the path item are logged, the path item is still returned.
+	if err != nil {
+		if idx != nil {
+			idx.GetSubsystemLogger(datamodel.LogSubsystemBuilder).Error(
+				fmt.Sprintf("error building path item: %s", err.Error()),
+				datamodel.LogKeyFile, idx.GetSpecAbsolutePath(), datamodel.LogKeyLine, cNode.Line)
+		}
+	}
+
+	key := low.KeyReference[string]{Value: cNode.Value, KeyNode: cNode}
+	return key, low.ValueReference[*PathItem]{Value: path, ValueNode: pNode}, nil
+}
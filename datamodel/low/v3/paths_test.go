@@ -163,8 +163,8 @@ func TestPaths_Build_FailRefDeadEnd(t *testing.T) {
 
 	n.Build(context.Background(), nil, idxNode.Content[0], idx)
 
-	assert.Contains(t, buf.String(), "msg=\"unable to locate reference anywhere in the rolodex\" reference=#/no/path")
-	assert.Contains(t, buf.String(), "msg=\"unable to locate reference anywhere in the rolodex\" reference=#/nowhere")
+	assert.Contains(t, buf.String(), "msg=\"unable to locate reference anywhere in the rolodex\" subsystem=index ref=#/no/path")
+	assert.Contains(t, buf.String(), "msg=\"unable to locate reference anywhere in the rolodex\" subsystem=index ref=#/nowhere")
 }
 
 func TestPaths_Build_SuccessRef(t *testing.T) {
@@ -264,7 +264,7 @@ func TestPaths_Build_BadRef(t *testing.T) {
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
 
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
-	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" reference=#/no-where")
+	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" subsystem=index ref=#/no-where")
 	assert.Contains(t, buf.String(), "error building path item: path item build failed: cannot find reference: #/no-where at line 4, col 10")
 }
 
@@ -332,7 +332,7 @@ func TestPathItem_Build_BadRef(t *testing.T) {
 	assert.NoError(t, err)
 
 	_ = n.Build(context.Background(), nil, idxNode.Content[0], idx)
-	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" reference=#/~1cakes/NotFound")
+	assert.Contains(t, buf.String(), "unable to locate reference anywhere in the rolodex\" subsystem=index ref=#/~1cakes/NotFound")
 	assert.Contains(t, buf.String(), "error building path item: path item build failed: cannot find reference: #/~1another~1path/get at line 4, col 10")
 }
 
@@ -572,3 +572,67 @@ func TestPaths_Build_Fail_Many(t *testing.T) {
 	errors := strings.Split(buf.String(), "\n")
 	assert.Len(t, errors, 1001)
 }
+
+func TestWalkPathItems(t *testing.T) {
+	yml := `"/some/path":
+  description: this is some path
+  get:
+    $ref: '#/~1another~1path/get'
+x-milk: cold
+"/another/path":
+  get:
+    description: get method from /another/path`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	var n Paths
+	_ = low.BuildModel(idxNode.Content[0], &n)
+	assert.NoError(t, n.Build(context.Background(), nil, idxNode.Content[0], idx))
+
+	// walking builds the same path items as building the paths, in the same order.
+	var walked []string
+	err := WalkPathItems(context.Background(), idxNode.Content[0], idx,
+		func(key low.KeyReference[string], pathItem low.ValueReference[*PathItem]) error {
+			walked = append(walked, key.Value)
+			assert.Equal(t, n.FindPath(key.Value).Value.Hash(), pathItem.Value.Hash())
+			return nil
+		})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/some/path", "/another/path"}, walked)
+
+	stop := fmt.Errorf("stop")
+	walked = nil
+	err = WalkPathItems(context.Background(), idxNode.Content[0], idx,
+		func(key low.KeyReference[string], pathItem low.ValueReference[*PathItem]) error {
+			walked = append(walked, key.Value)
+			return stop
+		})
+	assert.ErrorIs(t, err, stop)
+	assert.Len(t, walked, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = WalkPathItems(ctx, idxNode.Content[0], idx,
+		func(key low.KeyReference[string], pathItem low.ValueReference[*PathItem]) error {
+			return nil
+		})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.NoError(t, WalkPathItems(context.Background(), nil, idx, nil))
+}
+
+func TestWalkPathItems_BadRef(t *testing.T) {
+	yml := `"/some/path":
+  $ref: '#/no/path'`
+
+	var idxNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &idxNode)
+	idx := index.NewSpecIndex(&idxNode)
+
+	err := WalkPathItems(context.Background(), idxNode.Content[0], idx,
+		func(key low.KeyReference[string], pathItem low.ValueReference[*PathItem]) error {
+			return nil
+		})
+	assert.ErrorContains(t, err, "cannot find reference")
+}
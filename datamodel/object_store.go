@@ -0,0 +1,26 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// ObjectStore is a store of objects (files) kept in buckets, like Amazon S3 or Google Cloud Storage. Object stores
+// are configured by URL scheme (for example 's3' or 'gs'), and the rolodex resolves references to objects (like
+// 's3://bucket/specs/pet.yaml', where the host is the bucket, and the path is the key) with them, in the same way as
+// any other remote reference.
+//
+// index.S3Store is an implementation for S3 compatible stores, and index.ObjectStoreFS reads a bucket (or a prefix
+// of one) as a file system.
+type ObjectStore interface {
+	// GetObject returns the content of an object, and when it was last modified (which can be zero). If the object
+	// does not exist, the error returned wraps fs.ErrNotExist.
+	GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, time.Time, error)
+
+	// ListObjects returns the keys of every object in a bucket that starts with a prefix, in order.
+	ListObjects(ctx context.Context, bucket, prefix string) ([]string, error)
+}
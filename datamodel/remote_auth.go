@@ -0,0 +1,111 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// BearerTokenProvider returns the bearer token to send to a host. It's called for every request, so tokens can be
+// cached and refreshed by the provider (for example, an SSO token that expires). If an error is returned, the
+// request is not sent, and the error is reported.
+type BearerTokenProvider func(host string) (string, error)
+
+// RemoteAuth authenticates the requests made to fetch remote files, for hosts that match its pattern. Headers,
+// bearer tokens and basic auth are added to every matching request, and client certificates are presented to
+// matching hosts that ask for them (mTLS).
+type RemoteAuth struct {
+	// Name is used to identify the authentication in errors.
+	Name string
+
+	// HostPattern is a path.Match pattern, matched against the host of every request (without the port), and
+	// against the host and port. For example 'specs.example.com', '*.internal.example.com' or 'localhost:8443'.
+	// If not set, every host is matched.
+	HostPattern string
+
+	// Headers are added to every matching request, for example an API key header.
+	Headers map[string]string
+
+	// BearerToken is called to get the token sent in the Authorization header of every matching request.
+	BearerToken BearerTokenProvider
+
+	// Username and Password are sent as basic auth in the Authorization header of every matching request, unless
+	// a bearer token is sent.
+	Username string
+	Password string
+
+	// ClientCertificates are presented to matching hosts that ask for a client certificate (mTLS).
+	ClientCertificates []tls.Certificate
+
+	// RootCAs are the certificate authorities trusted when connecting to matching hosts, for example a private CA
+	// that signs internal servers. If not set, the system roots are used.
+	RootCAs *x509.CertPool
+}
+
+// Matches returns true if the authentication should be applied to requests sent to a host (with an optional port).
+func (a *RemoteAuth) Matches(host string) bool {
+	if a == nil {
+		return false
+	}
+	if a.HostPattern == "" {
+		return true
+	}
+	host = strings.ToLower(host)
+	pattern := strings.ToLower(a.HostPattern)
+	if ok, _ := path.Match(pattern, host); ok {
+		return true
+	}
+	if i := strings.LastIndex(host, ":"); i >= 0 && !strings.HasSuffix(host, "]") {
+		if ok, _ := path.Match(pattern, host[:i]); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// UsesTLS returns true if the authentication changes how TLS connections are made to matching hosts.
+func (a *RemoteAuth) UsesTLS() bool {
+	return a != nil && (len(a.ClientCertificates) > 0 || a.RootCAs != nil)
+}
+
+// Apply adds the headers, bearer token or basic auth of the authentication to a request.
+func (a *RemoteAuth) Apply(req *http.Request) error {
+	for k, v := range a.Headers {
+		req.Header.Set(k, v)
+	}
+	if a.BearerToken != nil {
+		token, err := a.BearerToken(req.URL.Hostname())
+		if err != nil {
+			if a.Name != "" {
+				return fmt.Errorf("remote auth '%s' failed to provide a token for '%s': %w", a.Name, req.URL.Host, err)
+			}
+			return fmt.Errorf("remote auth failed to provide a token for '%s': %w", req.URL.Host, err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+	if a.Username != "" || a.Password != "" {
+		req.SetBasicAuth(a.Username, a.Password)
+	}
+	return nil
+}
+
+// ApplyRemoteAuth applies every authentication that matches the host of a request, in order, so later
+// authentications override the headers set by earlier ones.
+func ApplyRemoteAuth(auth []*RemoteAuth, req *http.Request) error {
+	for _, a := range auth {
+		if !a.Matches(req.URL.Host) {
+			continue
+		}
+		if err := a.Apply(req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteAuth_Matches(t *testing.T) {
+	assert.False(t, (*RemoteAuth)(nil).Matches("example.com"))
+	assert.True(t, (&RemoteAuth{}).Matches("example.com"))
+
+	wildcard := &RemoteAuth{HostPattern: "*.internal.example.com"}
+	assert.True(t, wildcard.Matches("specs.internal.example.com"))
+	assert.True(t, wildcard.Matches("SPECS.internal.example.com:8443"))
+	assert.False(t, wildcard.Matches("internal.example.com"))
+	assert.False(t, wildcard.Matches("example.com"))
+
+	withPort := &RemoteAuth{HostPattern: "localhost:8443"}
+	assert.True(t, withPort.Matches("localhost:8443"))
+	assert.False(t, withPort.Matches("localhost:8080"))
+}
+
+func TestApplyRemoteAuth(t *testing.T) {
+	auth := []*RemoteAuth{
+		{HostPattern: "*.example.com", Headers: map[string]string{"X-Api-Key": "key", "X-Team": "a"}},
+		{HostPattern: "specs.example.com", Headers: map[string]string{"X-Team": "b"}, Username: "u", Password: "p"},
+		{HostPattern: "sso.example.com", Username: "ignored", BearerToken: func(host string) (string, error) {
+			return "token-for-" + host, nil
+		}},
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://specs.example.com/a.yaml", nil)
+	require.NoError(t, ApplyRemoteAuth(auth, req))
+	assert.Equal(t, "key", req.Header.Get("X-Api-Key"))
+	assert.Equal(t, "b", req.Header.Get("X-Team"))
+	user, pass, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "u", user)
+	assert.Equal(t, "p", pass)
+
+	req, _ = http.NewRequest(http.MethodGet, "https://sso.example.com:8443/a.yaml", nil)
+	require.NoError(t, ApplyRemoteAuth(auth, req))
+	assert.Equal(t, "Bearer token-for-sso.example.com", req.Header.Get("Authorization"))
+
+	req, _ = http.NewRequest(http.MethodGet, "https://other.com/a.yaml", nil)
+	require.NoError(t, ApplyRemoteAuth(auth, req))
+	assert.Empty(t, req.Header)
+}
+
+func TestApplyRemoteAuth_TokenError(t *testing.T) {
+	failing := func(string) (string, error) { return "", errors.New("expired") }
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/a.yaml", nil)
+
+	err := ApplyRemoteAuth([]*RemoteAuth{{Name: "sso", BearerToken: failing}}, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote auth 'sso'")
+	assert.Contains(t, err.Error(), "expired")
+
+	err = ApplyRemoteAuth([]*RemoteAuth{{BearerToken: failing}}, req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remote auth failed")
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -38,6 +39,11 @@ type SpecInfo struct {
 	Generated           time.Time               `json:"-"`
 	OriginalIndentation int                     `json:"-"` // the original whitespace
 	Self                string                  `json:"-"`     // the $self field for OpenAPI 3.2+ documents (base URI)
+
+	// VersionFeatures lists the version-specific features (3.1+ keywords, webhooks, 3.0 only nullable etc.) used by
+	// an OpenAPI 3 specification, so tools can warn when a specification uses constructs from a different version
+	// than the one it claims, before building a model. See UnsupportedVersionFeatures.
+	VersionFeatures []*VersionFeatureUsage `json:"versionFeatures,omitempty"`
 }
 
 func ExtractSpecInfoWithConfig(spec []byte, config *DocumentConfiguration) (*SpecInfo, error) {
@@ -151,37 +157,18 @@ func ExtractSpecInfoWithDocumentCheck(spec []byte, bypass bool) (*SpecInfo, erro
 
 		specInfo.SpecType = utils.OpenApi3
 		specInfo.Version = version
-		specInfo.SpecFormat = OAS3
+		setOpenAPI3Format(specInfo)
 
-		// Extract the prefix version
-		prefixVersion := specInfo.Version
-		if len(specInfo.Version) >= 3 {
-			prefixVersion = specInfo.Version[:3]
-		}
-		switch prefixVersion {
-		case "3.1":
-			specInfo.VersionNumeric = 3.1
-			specInfo.APISchema = OpenAPI31SchemaData
-			specInfo.SpecFormat = OAS31
-			// extract $self field for OpenAPI 3.1+ (might be used as forward-compatible feature)
+		// extract $self field for OpenAPI 3.2+ (and 3.1, as it might be used as forward-compatible feature)
+		if specInfo.SpecFormat == OAS31 || specInfo.SpecFormat == OAS32 {
 			_, selfNode := utils.FindKeyNode("$self", parsedSpec.Content)
 			if selfNode != nil && selfNode.Value != "" {
 				specInfo.Self = selfNode.Value
 			}
-		case "3.2":
-			specInfo.VersionNumeric = 3.2
-			specInfo.APISchema = OpenAPI32SchemaData
-			specInfo.SpecFormat = OAS32
-			// extract $self field for OpenAPI 3.2+
-			_, selfNode := utils.FindKeyNode("$self", parsedSpec.Content)
-			if selfNode != nil && selfNode.Value != "" {
-				specInfo.Self = selfNode.Value
-			}
-		default:
-			specInfo.VersionNumeric = 3.0
-			specInfo.APISchema = OpenAPI3SchemaData
 		}
 
+		specInfo.VersionFeatures = detectVersionFeatures(&parsedSpec)
+
 		// parse JSON
 		if err := parseJSON(spec, specInfo, &parsedSpec); err != nil && !bypass {
 			return nil, err
@@ -288,7 +275,95 @@ func ExtractSpecInfo(spec []byte) (*SpecInfo, error) {
 	return ExtractSpecInfoWithDocumentCheck(spec, false)
 }
 
+// ExtractSpecInfoFromReader is a convenience wrapper around ExtractSpecInfoWithDocumentCheck, for specifications that
+// come from an io.Reader (a file, or a network response) rather than a byte array. The specification is read in full
+// first, SpecInfo always carries the original bytes (via SpecBytes), so the memory used is the same as reading the
+// specification into a byte array.
+func ExtractSpecInfoFromReader(r io.Reader, bypass bool) (*SpecInfo, error) {
+	var buf bytes.Buffer
+	if l, ok := r.(interface{ Len() int }); ok {
+		buf.Grow(l.Len())
+	}
+	if _, err := buf.ReadFrom(r); err != nil {
+		return nil, err
+	}
+	return ExtractSpecInfoWithDocumentCheck(buf.Bytes(), bypass)
+}
+
+// Clone returns a deep copy of the SpecInfo. The root node tree and the original bytes are copied, so the copy
+// can be changed (or built into a new model) without affecting the original, and without parsing the bytes again.
+func (s *SpecInfo) Clone() *SpecInfo {
+	if s == nil {
+		return nil
+	}
+	c := *s
+	if s.RootNode != nil {
+		c.RootNode = utils.CloneYAMLNode(s.RootNode)
+	}
+	if s.SpecBytes != nil {
+		b := bytes.Clone(*s.SpecBytes)
+		c.SpecBytes = &b
+	}
+	if s.SpecJSONBytes != nil {
+		b := bytes.Clone(*s.SpecJSONBytes)
+		c.SpecJSONBytes = &b
+	}
+	if s.SpecJSON != nil {
+		m := cloneJSONValue(*s.SpecJSON).(map[string]interface{})
+		c.SpecJSON = &m
+	}
+	if s.VersionFeatures != nil {
+		c.VersionFeatures = make([]*VersionFeatureUsage, len(s.VersionFeatures))
+		for i, f := range s.VersionFeatures {
+			feature := *f
+			c.VersionFeatures[i] = &feature
+		}
+	}
+	return &c
+}
+
+func cloneJSONValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for k, val := range t {
+			m[k] = cloneJSONValue(val)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, len(t))
+		for i, val := range t {
+			s[i] = cloneJSONValue(val)
+		}
+		return s
+	}
+	return v
+}
+
 // extract version number from specification
+// setOpenAPI3Format sets the format, numeric version and schema of an OpenAPI 3 specification, using the prefix
+// of the version.
+func setOpenAPI3Format(specInfo *SpecInfo) {
+	prefixVersion := specInfo.Version
+	if len(specInfo.Version) >= 3 {
+		prefixVersion = specInfo.Version[:3]
+	}
+	switch prefixVersion {
+	case "3.1":
+		specInfo.VersionNumeric = 3.1
+		specInfo.APISchema = OpenAPI31SchemaData
+		specInfo.SpecFormat = OAS31
+	case "3.2":
+		specInfo.VersionNumeric = 3.2
+		specInfo.APISchema = OpenAPI32SchemaData
+		specInfo.SpecFormat = OAS32
+	default:
+		specInfo.VersionNumeric = 3.0
+		specInfo.APISchema = OpenAPI3SchemaData
+		specInfo.SpecFormat = OAS3
+	}
+}
+
 func parseVersionTypeData(d interface{}) (string, int, error) {
 	r := []rune(strings.TrimSpace(fmt.Sprintf("%v", d)))
 	if len(r) <= 0 {
@@ -0,0 +1,210 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ExtractSpecInfoShallow determines the file type (JSON or YAML), spec type and version of a specification by
+// scanning the top-level keys at the head of the document, without parsing the entire file. Scanning stops as soon
+// as the 'openapi', 'swagger' or 'asyncapi' key is found, which is almost always the first key in the document.
+//
+// This is designed for classifying large numbers of files quickly. The returned SpecInfo only has the SpecBytes,
+// SpecFileType, SpecType, Version, SpecFormat, VersionNumeric and APISchema properties set, there is no RootNode,
+// and the document is not checked for validity. Use ExtractSpecInfo to fully parse a specification.
+//
+// The same errors are returned as ExtractSpecInfo when a document is empty, uses a version that does not match its
+// spec type, or is not a type of specification supported by libopenapi.
+func ExtractSpecInfoShallow(spec []byte) (*SpecInfo, error) {
+	specInfo := &SpecInfo{}
+	specInfo.SpecBytes = &spec
+
+	trimmed := bytes.TrimSpace(spec)
+	if len(trimmed) == 0 {
+		return specInfo, errors.New("there is nothing in the spec, it's empty - so there is nothing to be done")
+	}
+
+	var specType, version string
+	if trimmed[0] == '{' && trimmed[len(trimmed)-1] == '}' {
+		specInfo.SpecFileType = JSONFileType
+		specType, version = sniffJSONSpecType(trimmed)
+	} else {
+		specInfo.SpecFileType = YAMLFileType
+		specType, version = sniffYAMLSpecType(trimmed)
+	}
+
+	if specType == "" {
+		specInfo.Error = errors.New("spec type not supported by libopenapi, sorry")
+		return specInfo, specInfo.Error
+	}
+
+	version, majorVersion, err := parseVersionTypeData(version)
+	if err != nil {
+		return nil, err
+	}
+	specInfo.SpecType = specType
+	specInfo.Version = version
+
+	switch specType {
+	case utils.OpenApi3:
+		setOpenAPI3Format(specInfo)
+		if majorVersion < 3 {
+			specInfo.Error = errors.New("spec is defined as an openapi spec, but is using a swagger (2.0), or unknown version")
+			return specInfo, specInfo.Error
+		}
+	case utils.OpenApi2:
+		specInfo.SpecFormat = OAS2
+		specInfo.VersionNumeric = 2.0
+		specInfo.APISchema = OpenAPI2SchemaData
+		if majorVersion > 2 {
+			specInfo.Error = errors.New("spec is defined as a swagger (openapi 2.0) spec, but is an openapi 3 or unknown version")
+			return specInfo, specInfo.Error
+		}
+	case utils.AsyncApi:
+		if majorVersion > 2 {
+			specInfo.Error = errors.New("spec is defined as asyncapi, but has a major version that is invalid")
+			return specInfo, specInfo.Error
+		}
+	}
+	return specInfo, nil
+}
+
+// isSpecTypeKey returns true if the key is used to declare the type (and version) of a specification.
+func isSpecTypeKey(key string) bool {
+	return key == utils.OpenApi3 || key == utils.OpenApi2 || key == utils.AsyncApi
+}
+
+// sniffJSONSpecType reads the top-level keys of a JSON document as a stream of tokens, returning the first key
+// that declares the spec type, and its value. Nested values are skipped over without being decoded.
+func sniffJSONSpecType(spec []byte) (string, string) {
+	decoder := json.NewDecoder(bytes.NewReader(spec))
+	decoder.UseNumber()
+	if t, err := decoder.Token(); err != nil || t != json.Delim('{') {
+		return "", ""
+	}
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			return "", ""
+		}
+		key, _ := t.(string)
+		if isSpecTypeKey(key) {
+			value, err := decoder.Token()
+			if err != nil {
+				return "", ""
+			}
+			switch v := value.(type) {
+			case string:
+				return key, v
+			case json.Number:
+				return key, v.String()
+			}
+			return "", ""
+		}
+		if err = skipJSONValue(decoder); err != nil {
+			return "", ""
+		}
+	}
+	return "", ""
+}
+
+// skipJSONValue reads past the next value in the stream, including every token of an object or array.
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		t, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// sniffYAMLSpecType reads a YAML document line by line, looking at the keys that are not indented (the top-level
+// keys), returning the first key that declares the spec type, and its value. Only the first document in a stream
+// is read.
+func sniffYAMLSpecType(spec []byte) (string, string) {
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(spec, []byte("\xef\xbb\xbf"))))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	started := false
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || line[0] == '#' || line[0] == '%' {
+			continue
+		}
+		if line == "---" || strings.HasPrefix(line, "--- ") {
+			if started {
+				return "", ""
+			}
+			started = true
+			continue
+		}
+		if line == "..." {
+			return "", ""
+		}
+		started = true
+		if line[0] == ' ' || line[0] == '\t' || line[0] == '-' {
+			continue
+		}
+		key, value, ok := splitYAMLKeyValue(line)
+		if ok && isSpecTypeKey(key) {
+			return key, value
+		}
+	}
+	return "", ""
+}
+
+// splitYAMLKeyValue splits a single line 'key: value' mapping into its key and scalar value, removing any quotes
+// and trailing comments.
+func splitYAMLKeyValue(line string) (string, string, bool) {
+	var key, rest string
+	if line[0] == '"' || line[0] == '\'' {
+		end := strings.IndexByte(line[1:], line[0])
+		if end < 0 {
+			return "", "", false
+		}
+		key = line[1 : end+1]
+		rest = strings.TrimLeft(line[end+2:], " \t")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		rest = rest[1:]
+	} else {
+		idx := strings.Index(line, ":")
+		if idx < 0 {
+			return "", "", false
+		}
+		key = strings.TrimSpace(line[:idx])
+		rest = line[idx+1:]
+	}
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", "", false
+	}
+	value := strings.TrimSpace(rest)
+	if value != "" && (value[0] == '"' || value[0] == '\'') {
+		if end := strings.IndexByte(value[1:], value[0]); end >= 0 {
+			return key, value[1 : end+1], true
+		}
+		return key, "", true
+	}
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = strings.TrimSpace(value[:idx])
+	}
+	return key, value, true
+}
@@ -4,12 +4,18 @@
 package datamodel
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/pb33f/libopenapi/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -487,3 +493,168 @@ paths:
 	assert.Equal(t, "3.0.0", r.Version)
 	assert.Equal(t, YAMLFileType, r.SpecFileType)
 }
+
+func TestExtractSpecInfoFromReader(t *testing.T) {
+	for _, f := range []string{"petstorev3.json", "petstorev2.json", "burgershop.openapi.yaml", "k8s.json",
+		"yaml-anchor.yaml", "asana.yaml"} {
+		spec, _ := os.ReadFile("../test_specs/" + f)
+		expected, err := ExtractSpecInfo(spec)
+		assert.NoError(t, err)
+
+		info, err := ExtractSpecInfoFromReader(iotest.OneByteReader(bytes.NewReader(spec)), false)
+		assert.NoError(t, err, f)
+		assert.Equal(t, spec, *info.SpecBytes, f)
+		assert.Equal(t, expected.SpecType, info.SpecType, f)
+		assert.Equal(t, expected.SpecFormat, info.SpecFormat, f)
+		assert.Equal(t, expected.SpecFileType, info.SpecFileType, f)
+		assert.Equal(t, expected.Version, info.Version, f)
+		assert.Equal(t, expected.VersionNumeric, info.VersionNumeric, f)
+		assert.Equal(t, expected.NumLines, info.NumLines, f)
+		assert.Equal(t, expected.OriginalIndentation, info.OriginalIndentation, f)
+		assert.Equal(t, expected.SpecJSON, info.SpecJSON, f)
+		assert.True(t, utils.YAMLNodesEqual(expected.RootNode, info.RootNode), f)
+		assert.Equal(t, expected.RootNode.Content[0].Content[1].Line, info.RootNode.Content[0].Content[1].Line, f)
+	}
+}
+
+func TestExtractSpecInfoFromReader_Nothing(t *testing.T) {
+	_, err := ExtractSpecInfoFromReader(strings.NewReader("  \n\t "), false)
+	assert.Error(t, err)
+}
+
+func TestExtractSpecInfoFromReader_EscapedSlashes(t *testing.T) {
+	info, err := ExtractSpecInfoFromReader(strings.NewReader(`{"openapi": "3.1.0", "info": {"title": "a\/b"}}`), false)
+	assert.NoError(t, err)
+	assert.Equal(t, JSONFileType, info.SpecFileType)
+	_, infoNode := utils.FindKeyNode("info", info.RootNode.Content)
+	_, title := utils.FindKeyNode("title", infoNode.Content)
+	assert.Equal(t, "a/b", title.Value)
+}
+
+func TestExtractSpecInfoFromReader_Invalid(t *testing.T) {
+	_, err := ExtractSpecInfoFromReader(strings.NewReader("openapi: 3.1.0\n  bad: [yaml"), false)
+	assert.Error(t, err)
+
+	info, err := ExtractSpecInfoFromReader(strings.NewReader("openapi: 3.1.0\n  bad: [yaml"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\n  bad: [yaml", info.RootNode.Content[0].Value)
+
+	_, err = ExtractSpecInfoFromReader(strings.NewReader("# only a comment"), false)
+	assert.Error(t, err)
+}
+
+func TestExtractSpecInfoFromReader_ReadError(t *testing.T) {
+	_, err := ExtractSpecInfoFromReader(iotest.ErrReader(errors.New("pop")), false)
+	assert.EqualError(t, err, "pop")
+
+	_, err = ExtractSpecInfoFromReader(io.MultiReader(strings.NewReader("openapi: 3.1.0"),
+		iotest.ErrReader(errors.New("pop"))), false)
+	assert.EqualError(t, err, "pop")
+}
+
+func TestExtractSpecInfoShallow(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		fileType string
+		specType string
+		version  string
+		format   string
+		numeric  float32
+	}{
+		{"yaml 3.0", "openapi: 3.0.3\ninfo:\n  title: pizza", YAMLFileType, utils.OpenApi3, "3.0.3", OAS3, 3.0},
+		{"yaml 3.1 quoted", "# comment\n---\ninfo:\n  openapi: nope\n'openapi': \"3.1.0\" # hi", YAMLFileType, utils.OpenApi3, "3.1.0", OAS31, 3.1},
+		{"yaml 3.2 comment", "\xef\xbb\xbfopenapi: 3.2.0 # the latest", YAMLFileType, utils.OpenApi3, "3.2.0", OAS32, 3.2},
+		{"yaml swagger", "info:\n  title: old\nswagger: '2.0'", YAMLFileType, utils.OpenApi2, "2.0", OAS2, 2.0},
+		{"yaml asyncapi", "asyncapi: 2.6.0", YAMLFileType, utils.AsyncApi, "2.6.0", "", 0},
+		{"json 3.1", `{"info": {"openapi": "nope", "x": [1, {"a": []}]}, "openapi": "3.1.1"}`, JSONFileType, utils.OpenApi3, "3.1.1", OAS31, 3.1},
+		{"json swagger", `{"swagger": 2.0}`, JSONFileType, utils.OpenApi2, "2.0", OAS2, 2.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := ExtractSpecInfoShallow([]byte(tt.spec))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.fileType, info.SpecFileType)
+			assert.Equal(t, tt.specType, info.SpecType)
+			assert.Equal(t, tt.version, info.Version)
+			assert.Equal(t, tt.format, info.SpecFormat)
+			assert.Equal(t, tt.numeric, info.VersionNumeric)
+			assert.Nil(t, info.RootNode)
+		})
+	}
+}
+
+func TestExtractSpecInfoShallow_Errors(t *testing.T) {
+	_, err := ExtractSpecInfoShallow([]byte("  "))
+	assert.Error(t, err)
+
+	_, err = ExtractSpecInfoShallow([]byte("info:\n  openapi: 3.1.0\n---\nopenapi: 3.1.0"))
+	assert.EqualError(t, err, "spec type not supported by libopenapi, sorry")
+
+	_, err = ExtractSpecInfoShallow([]byte(`{"info": {"openapi": "3.1.0"}}`))
+	assert.EqualError(t, err, "spec type not supported by libopenapi, sorry")
+
+	_, err = ExtractSpecInfoShallow([]byte(`{"openapi": {"bad": true}}`))
+	assert.EqualError(t, err, "spec type not supported by libopenapi, sorry")
+
+	_, err = ExtractSpecInfoShallow([]byte("openapi:"))
+	assert.Error(t, err)
+
+	info, err := ExtractSpecInfoShallow([]byte("openapi: 2.0"))
+	assert.EqualError(t, err, "spec is defined as an openapi spec, but is using a swagger (2.0), or unknown version")
+	assert.Equal(t, utils.OpenApi3, info.SpecType)
+
+	_, err = ExtractSpecInfoShallow([]byte("swagger: 3.0"))
+	assert.Error(t, err)
+
+	_, err = ExtractSpecInfoShallow([]byte("asyncapi: 3.0.0"))
+	assert.Error(t, err)
+}
+
+func TestExtractSpecInfoShallow_MatchesExtractSpecInfo(t *testing.T) {
+	for _, file := range []string{
+		"../test_specs/stripe.yaml", "../test_specs/petstorev2.json", "../test_specs/petstorev3.json",
+		"../test_specs/petstorev2-complete.yaml", "../test_specs/k8s.json", "../test_specs/burgershop.openapi.yaml",
+	} {
+		spec, _ := os.ReadFile(file)
+		full, err := ExtractSpecInfo(spec)
+		assert.NoError(t, err, file)
+		shallow, err := ExtractSpecInfoShallow(spec)
+		assert.NoError(t, err, file)
+		assert.Equal(t, full.SpecFileType, shallow.SpecFileType, file)
+		assert.Equal(t, full.SpecType, shallow.SpecType, file)
+		assert.Equal(t, full.Version, shallow.Version, file)
+		assert.Equal(t, full.SpecFormat, shallow.SpecFormat, file)
+		assert.Equal(t, full.VersionNumeric, shallow.VersionNumeric, file)
+		assert.Equal(t, full.APISchema, shallow.APISchema, file)
+	}
+}
+
+func BenchmarkExtractSpecInfoShallow_Stripe(b *testing.B) {
+	spec, _ := os.ReadFile("../test_specs/stripe.yaml")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = ExtractSpecInfoShallow(spec)
+	}
+}
+
+func TestSpecInfo_Clone(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte("openapi: 3.0.1\ninfo:\n  title: Pets\ncomponents:\n  schemas:\n    A:\n      nullable: true\n      enum: [a, b]"))
+	require.NoError(t, err)
+
+	clone := info.Clone()
+	assert.Equal(t, info.Version, clone.Version)
+	assert.NotSame(t, info.RootNode, clone.RootNode)
+	assert.Equal(t, string(*info.SpecBytes), string(*clone.SpecBytes))
+	assert.Equal(t, *info.SpecJSON, *clone.SpecJSON)
+	assert.Len(t, clone.VersionFeatures, len(info.VersionFeatures))
+
+	(*clone.SpecBytes)[0] = 'x'
+	clone.RootNode.Content[0].Content[1].Value = "3.1.0"
+	(*clone.SpecJSON)["info"].(map[string]interface{})["title"] = "Cats"
+	assert.Equal(t, byte('o'), (*info.SpecBytes)[0])
+	assert.Equal(t, "3.0.1", info.RootNode.Content[0].Content[1].Value)
+	assert.Equal(t, "Pets", (*info.SpecJSON)["info"].(map[string]interface{})["title"])
+
+	assert.Nil(t, (*SpecInfo)(nil).Clone())
+}
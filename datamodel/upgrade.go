@@ -0,0 +1,277 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// UpgradedOpenAPIVersion is the version of OpenAPI that UpgradeOpenAPI30Node upgrades documents to.
+const UpgradedOpenAPIVersion = "3.1.0"
+
+// UpgradeChange describes a single change made when upgrading a document.
+type UpgradeChange struct {
+	Path        string `json:"path"`        // JSON pointer to the changed object, for example '#/components/schemas/Pet'.
+	Key         string `json:"key"`         // the key that was changed, for example 'nullable'.
+	Line        int    `json:"line"`        // the line of the key in the original document.
+	Column      int    `json:"column"`      // the column of the key in the original document.
+	Description string `json:"description"` // what was changed.
+}
+
+// UpgradeOpenAPI30Node upgrades an OpenAPI 3.0 document into an OpenAPI 3.1 document, changing the supplied yaml
+// node tree in place. Only the parts of the tree that need to change are touched, so key order, comments and
+// formatting are kept. Every change made is returned.
+//
+// The version is set to 3.1.0, and every schema in the document is upgraded:
+//
+//   - 'nullable: true' adds 'null' to the type (and to the enum, if there is one), 'nullable' is removed.
+//   - boolean 'exclusiveMinimum' and 'exclusiveMaximum' become the numeric bound, replacing 'minimum' or 'maximum'.
+//   - 'example' becomes 'examples', holding the example as the only item.
+//   - 'format: binary' becomes 'contentMediaType: application/octet-stream', 'format: byte' and
+//     'format: base64' become 'contentEncoding: base64'.
+//
+// The 'example' property of media types, parameters and headers is still valid in OpenAPI 3.1, so it is not
+// changed. A document that is already OpenAPI 3.1 (or later) is left alone, anything that is not an OpenAPI 3.0
+// document returns an error.
+func UpgradeOpenAPI30Node(root *yaml.Node) ([]*UpgradeChange, error) {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root == nil || root.Kind != yaml.MappingNode {
+		return nil, errors.New("unable to upgrade, the document is empty")
+	}
+	versionKey, version := utils.FindKeyNodeTop("openapi", root.Content)
+	if version == nil {
+		return nil, errors.New("unable to upgrade, only OpenAPI 3.0 documents can be upgraded")
+	}
+	if !strings.HasPrefix(version.Value, "3.0") {
+		if strings.HasPrefix(version.Value, "3.") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to upgrade, version '%s' is not OpenAPI 3.0", version.Value)
+	}
+	u := &upgrader{}
+	u.record("#", versionKey, fmt.Sprintf("version upgraded from '%s' to '%s'", version.Value, UpgradedOpenAPIVersion))
+	version.Value = UpgradedOpenAPIVersion
+	version.Tag = "!!str"
+	u.walk(root, "#")
+	return u.changes, nil
+}
+
+type upgrader struct {
+	changes []*UpgradeChange
+}
+
+func (u *upgrader) record(path string, key *yaml.Node, description string) {
+	u.changes = append(u.changes, &UpgradeChange{
+		Path: path, Key: key.Value, Line: key.Line, Column: key.Column, Description: description,
+	})
+}
+
+// walk searches the document for schemas, everything that is not a schema is left as it is.
+func (u *upgrader) walk(node *yaml.Node, path string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			u.walk(n, path+"/"+strconv.Itoa(i))
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i].Value, node.Content[i+1]
+			if strings.HasPrefix(key, "x-") {
+				continue
+			}
+			if _, ok := literalKeys[key]; ok {
+				continue
+			}
+			p := path + "/" + upgradePointerSegment(key)
+			switch {
+			case key == "schema":
+				u.upgradeSchema(value, p)
+			case key == "schemas" && path == "#/components":
+				u.upgradeNamedSchemas(value, p)
+			case key == "examples":
+				// examples are objects holding literal values.
+			default:
+				u.walk(value, p)
+			}
+		}
+	}
+}
+
+func (u *upgrader) upgradeNamedSchemas(node *yaml.Node, path string) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		u.upgradeSchema(node.Content[i+1], path+"/"+upgradePointerSegment(node.Content[i].Value))
+	}
+}
+
+// upgradeSchema upgrades a schema, and every schema inside it.
+func (u *upgrader) upgradeSchema(schema *yaml.Node, path string) {
+	if schema == nil || schema.Kind != yaml.MappingNode {
+		return
+	}
+	var nullableKey, minimum, maximum *yaml.Node
+	nullable := false
+	content := make([]*yaml.Node, 0, len(schema.Content))
+	for i := 0; i+1 < len(schema.Content); i += 2 {
+		k, v := schema.Content[i], schema.Content[i+1]
+		p := path + "/" + upgradePointerSegment(k.Value)
+		switch k.Value {
+		case "nullable":
+			nullableKey = k
+			nullable = v.Value == "true"
+			continue
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if v.Tag == "!!bool" {
+				bound := "minimum"
+				if k.Value == "exclusiveMaximum" {
+					bound = "maximum"
+				}
+				if v.Value == "true" {
+					if _, b := utils.FindKeyNodeTop(bound, schema.Content); b != nil {
+						v = utils.CloneYAMLNode(b)
+						if bound == "minimum" {
+							minimum = b
+						} else {
+							maximum = b
+						}
+						u.record(path, k, fmt.Sprintf("boolean '%s' replaced by the value of '%s'", k.Value, bound))
+						content = append(content, k, v)
+						continue
+					}
+				}
+				u.record(path, k, fmt.Sprintf("boolean '%s' removed", k.Value))
+				continue
+			}
+		case "example":
+			if examplesKey, _ := utils.FindKeyNodeTop("examples", schema.Content); examplesKey == nil {
+				u.record(path, k, "'example' replaced by 'examples'")
+				seq := utils.CreateEmptySequenceNode()
+				seq.Content = []*yaml.Node{v}
+				content = append(content, upgradeRenameKey(k, "examples"), seq)
+				continue
+			}
+		case "format":
+			switch v.Value {
+			case "binary":
+				u.record(path, k, "'format: binary' replaced by 'contentMediaType: application/octet-stream'")
+				content = append(content, upgradeRenameKey(k, "contentMediaType"),
+					upgradeReplaceValue(v, "application/octet-stream"))
+				continue
+			case "byte", "base64":
+				u.record(path, k, fmt.Sprintf("'format: %s' replaced by 'contentEncoding: base64'", v.Value))
+				content = append(content, upgradeRenameKey(k, "contentEncoding"), upgradeReplaceValue(v, "base64"))
+				continue
+			}
+		case "properties", "patternProperties", "$defs", "definitions", "dependentSchemas":
+			u.upgradeNamedSchemas(v, p)
+		case "items", "additionalProperties", "not", "contains", "propertyNames", "if", "then", "else",
+			"unevaluatedItems", "unevaluatedProperties", "additionalItems", "contentSchema":
+			u.upgradeSchema(v, p)
+		case "allOf", "anyOf", "oneOf", "prefixItems":
+			if v.Kind == yaml.SequenceNode {
+				for j, s := range v.Content {
+					u.upgradeSchema(s, p+"/"+strconv.Itoa(j))
+				}
+			} else {
+				u.upgradeSchema(v, p)
+			}
+		}
+		content = append(content, k, v)
+	}
+	if minimum != nil || maximum != nil {
+		filtered := content[:0]
+		for i := 0; i+1 < len(content); i += 2 {
+			if content[i+1] == minimum || content[i+1] == maximum {
+				continue
+			}
+			filtered = append(filtered, content[i], content[i+1])
+		}
+		content = filtered
+	}
+	schema.Content = content
+
+	if nullableKey == nil {
+		return
+	}
+	if !nullable {
+		u.record(path, nullableKey, "'nullable: false' removed")
+		return
+	}
+	_, typ := utils.FindKeyNodeTop("type", schema.Content)
+	refKey, ref := utils.FindKeyNodeTop("$ref", schema.Content)
+	switch {
+	case typ == nil && ref != nil:
+		// properties next to a reference are ignored in OpenAPI 3.0, so the reference is all there is.
+		nullType := utils.CreateEmptyMapNode()
+		nullType.Content = []*yaml.Node{utils.CreateStringNode("type"), utils.CreateStringNode("null")}
+		refSchema := utils.CreateEmptyMapNode()
+		refSchema.Content = []*yaml.Node{refKey, ref}
+		anyOf := utils.CreateEmptySequenceNode()
+		anyOf.Content = []*yaml.Node{refSchema, nullType}
+		schema.Content = []*yaml.Node{upgradeRenameKey(refKey, "anyOf"), anyOf}
+		u.record(path, nullableKey, "'nullable: true' replaced by 'anyOf' the reference or 'null'")
+		return
+	case typ == nil:
+		u.record(path, nullableKey, "'nullable' removed, the schema has no type, so it already allows null")
+		return
+	case typ.Kind == yaml.ScalarNode:
+		seq := utils.CreateEmptySequenceNode()
+		seq.Style = yaml.FlowStyle
+		seq.Content = []*yaml.Node{upgradeReplaceValue(typ, typ.Value), upgradeStringLike(typ, "null")}
+		*typ = *seq
+	case typ.Kind == yaml.SequenceNode:
+		typ.Content = append(typ.Content, utils.CreateStringNode("null"))
+	}
+	if _, enum := utils.FindKeyNodeTop("enum", schema.Content); enum != nil && enum.Kind == yaml.SequenceNode {
+		hasNull := false
+		for _, e := range enum.Content {
+			if e.Tag == "!!null" {
+				hasNull = true
+			}
+		}
+		if !hasNull {
+			enum.Content = append(enum.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"})
+		}
+	}
+	u.record(path, nullableKey, "'nullable: true' replaced by adding 'null' to the type")
+}
+
+// upgradeRenameKey returns a copy of a key with a new name, keeping its position and comments.
+func upgradeRenameKey(key *yaml.Node, name string) *yaml.Node {
+	renamed := *key
+	renamed.Value = name
+	renamed.Style = 0
+	return &renamed
+}
+
+// upgradeReplaceValue returns a copy of a scalar with a new value, keeping its position, style and comments.
+func upgradeReplaceValue(value *yaml.Node, str string) *yaml.Node {
+	replaced := *value
+	replaced.Kind = yaml.ScalarNode
+	replaced.Tag = "!!str"
+	replaced.Value = str
+	replaced.Content = nil
+	return &replaced
+}
+
+// upgradeStringLike creates a string scalar with the same style (quoting) as another.
+func upgradeStringLike(like *yaml.Node, str string) *yaml.Node {
+	n := utils.CreateStringNode(str)
+	n.Style = like.Style
+	return n
+}
+
+func upgradePointerSegment(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,157 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func upgradeYAML(t *testing.T, spec string) (string, []*UpgradeChange) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	changes, err := UpgradeOpenAPI30Node(&root)
+	require.NoError(t, err)
+	out, err := yaml.Marshal(&root)
+	require.NoError(t, err)
+	return string(out), changes
+}
+
+func TestUpgradeOpenAPI30Node(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+    title: Pets
+    version: 1.0.0
+paths:
+    /pets:
+        get:
+            parameters:
+                - name: limit
+                  in: query
+                  example: 10
+                  schema:
+                    type: integer
+                    minimum: 1
+                    exclusiveMinimum: true
+                    maximum: 100
+                    exclusiveMaximum: false
+            responses:
+                "200":
+                    description: ok
+                    content:
+                        application/octet-stream:
+                            schema:
+                                type: string
+                                format: binary
+components:
+    schemas:
+        Pet:
+            type: object
+            properties:
+                name:
+                    type: string
+                    nullable: true
+                    example: fluffy
+                kind:
+                    type: string
+                    nullable: true
+                    enum: [cat, dog]
+                photo:
+                    type: string
+                    format: byte
+                owner:
+                    $ref: '#/components/schemas/Owner'
+                    nullable: true
+                tags:
+                    type: array
+                    items:
+                        nullable: false
+                        type: string
+    examples:
+        Pet:
+            value:
+                nullable: true
+`
+	out, changes := upgradeYAML(t, spec)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Pets
+    version: 1.0.0
+paths:
+    /pets:
+        get:
+            parameters:
+                - name: limit
+                  in: query
+                  example: 10
+                  schema:
+                    type: integer
+                    exclusiveMinimum: 1
+                    maximum: 100
+            responses:
+                "200":
+                    description: ok
+                    content:
+                        application/octet-stream:
+                            schema:
+                                type: string
+                                contentMediaType: application/octet-stream
+components:
+    schemas:
+        Pet:
+            type: object
+            properties:
+                name:
+                    type: [string, "null"]
+                    examples:
+                        - fluffy
+                kind:
+                    type: [string, "null"]
+                    enum: [cat, dog, null]
+                photo:
+                    type: string
+                    contentEncoding: base64
+                owner:
+                    anyOf:
+                        - $ref: '#/components/schemas/Owner'
+                        - type: "null"
+                tags:
+                    type: array
+                    items:
+                        type: string
+    examples:
+        Pet:
+            value:
+                nullable: true
+`, out)
+
+	require.Len(t, changes, 10)
+	assert.Equal(t, "#", changes[0].Path)
+	assert.Equal(t, "openapi", changes[0].Key)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/schema", changes[1].Path)
+	assert.Equal(t, "exclusiveMinimum", changes[1].Key)
+	assert.Equal(t, 15, changes[1].Line)
+	assert.Equal(t, "#/components/schemas/Pet/properties/name", changes[4].Path)
+}
+
+func TestUpgradeOpenAPI30Node_Versions(t *testing.T) {
+	out, changes := upgradeYAML(t, "openapi: 3.1.0\ncomponents:\n  schemas:\n    A:\n      example: 1\n")
+	assert.Nil(t, changes)
+	assert.Contains(t, out, "example: 1")
+
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte("swagger: '2.0'"), &root))
+	_, err := UpgradeOpenAPI30Node(&root)
+	assert.Error(t, err)
+
+	require.NoError(t, yaml.Unmarshal([]byte("openapi: 2.5.0"), &root))
+	_, err = UpgradeOpenAPI30Node(&root)
+	assert.Error(t, err)
+
+	_, err = UpgradeOpenAPI30Node(nil)
+	assert.Error(t, err)
+}
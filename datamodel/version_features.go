@@ -0,0 +1,308 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// VersionFeature is a construct that is only valid in some versions of OpenAPI 3.
+type VersionFeature string
+
+const (
+	// FeatureWebhooks is the root 'webhooks' object (3.1+).
+	FeatureWebhooks VersionFeature = "webhooks"
+
+	// FeatureJSONSchemaDialect is the root 'jsonSchemaDialect' property (3.1+).
+	FeatureJSONSchemaDialect VersionFeature = "jsonSchemaDialect"
+
+	// FeatureInfoSummary is the 'summary' property of the info object (3.1+).
+	FeatureInfoSummary VersionFeature = "info-summary"
+
+	// FeatureLicenseIdentifier is the 'identifier' property of the license object (3.1+).
+	FeatureLicenseIdentifier VersionFeature = "license-identifier"
+
+	// FeaturePathItemsComponent is the 'pathItems' component (3.1+).
+	FeaturePathItemsComponent VersionFeature = "components-pathItems"
+
+	// FeatureTypeArray is a schema 'type' defined as an array of types (3.1+).
+	FeatureTypeArray VersionFeature = "type-array"
+
+	// FeatureNumericExclusiveBound is a schema 'exclusiveMinimum' or 'exclusiveMaximum' defined as a number (3.1+).
+	FeatureNumericExclusiveBound VersionFeature = "numeric-exclusive-bound"
+
+	// FeatureJSONSchemaKeyword is a JSON Schema 2020-12 keyword that is not part of the OpenAPI 3.0 schema
+	// subset, such as 'const', 'prefixItems', '$defs' or 'unevaluatedProperties' (3.1+). The keyword used is
+	// available as the Key of the VersionFeatureUsage.
+	FeatureJSONSchemaKeyword VersionFeature = "json-schema-keyword"
+
+	// FeatureSelf is the root '$self' property (3.2+).
+	FeatureSelf VersionFeature = "$self"
+
+	// FeatureMediaTypesComponent is the 'mediaTypes' component (3.2+).
+	FeatureMediaTypesComponent VersionFeature = "components-mediaTypes"
+
+	// FeatureQueryOperation is the 'query' operation of a path item (3.2+).
+	FeatureQueryOperation VersionFeature = "query-operation"
+
+	// FeatureAdditionalOperations is the 'additionalOperations' object of a path item (3.2+).
+	FeatureAdditionalOperations VersionFeature = "additionalOperations"
+
+	// FeatureNullable is the schema 'nullable' keyword (3.0 only).
+	FeatureNullable VersionFeature = "nullable"
+
+	// FeatureBooleanExclusiveBound is a schema 'exclusiveMinimum' or 'exclusiveMaximum' defined as a boolean
+	// (3.0 only).
+	FeatureBooleanExclusiveBound VersionFeature = "boolean-exclusive-bound"
+)
+
+// versionFeatureSupport holds the first, and last (zero for none) version of OpenAPI that supports a feature.
+var versionFeatureSupport = map[VersionFeature][2]float32{
+	FeatureWebhooks:              {3.1, 0},
+	FeatureJSONSchemaDialect:     {3.1, 0},
+	FeatureInfoSummary:           {3.1, 0},
+	FeatureLicenseIdentifier:     {3.1, 0},
+	FeaturePathItemsComponent:    {3.1, 0},
+	FeatureTypeArray:             {3.1, 0},
+	FeatureNumericExclusiveBound: {3.1, 0},
+	FeatureJSONSchemaKeyword:     {3.1, 0},
+	FeatureSelf:                  {3.2, 0},
+	FeatureMediaTypesComponent:   {3.2, 0},
+	FeatureQueryOperation:        {3.2, 0},
+	FeatureAdditionalOperations:  {3.2, 0},
+	FeatureNullable:              {3.0, 3.0},
+	FeatureBooleanExclusiveBound: {3.0, 3.0},
+}
+
+// SupportedBy returns true if the feature is valid in the supplied version of OpenAPI (as found in the
+// VersionNumeric property of SpecInfo).
+func (f VersionFeature) SupportedBy(version float32) bool {
+	support, ok := versionFeatureSupport[f]
+	if !ok {
+		return true
+	}
+	return version >= support[0] && (support[1] == 0 || version <= support[1])
+}
+
+// VersionFeatureUsage describes a version-specific feature used by a specification, and where it was first used.
+type VersionFeatureUsage struct {
+	Feature VersionFeature `json:"feature"`
+	Key     string         `json:"key"`    // the key that uses the feature, for example 'nullable' or 'prefixItems'.
+	Line    int            `json:"line"`   // the line the feature is first used on.
+	Column  int            `json:"column"` // the column the feature is first used on.
+	Count   int            `json:"count"`  // the number of times the feature is used.
+}
+
+// UsesVersionFeature returns true if the specification uses the supplied feature.
+func (s *SpecInfo) UsesVersionFeature(feature VersionFeature) bool {
+	for _, usage := range s.VersionFeatures {
+		if usage.Feature == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// UnsupportedVersionFeatures returns every feature used by the specification that is not valid for the version the
+// specification claims to be. For example, a specification that claims to be 3.0.3, but uses 'webhooks' or 'const'.
+func (s *SpecInfo) UnsupportedVersionFeatures() []*VersionFeatureUsage {
+	var unsupported []*VersionFeatureUsage
+	for _, usage := range s.VersionFeatures {
+		if !usage.Feature.SupportedBy(s.VersionNumeric) {
+			unsupported = append(unsupported, usage)
+		}
+	}
+	return unsupported
+}
+
+// JSON Schema 2020-12 keywords that are not part of the OpenAPI 3.0 schema subset.
+var jsonSchemaKeywords = map[string]struct{}{
+	"const": {}, "prefixItems": {}, "contains": {}, "minContains": {}, "maxContains": {}, "$defs": {},
+	"if": {}, "then": {}, "else": {}, "dependentSchemas": {}, "dependentRequired": {}, "propertyNames": {},
+	"unevaluatedItems": {}, "unevaluatedProperties": {}, "contentEncoding": {}, "contentMediaType": {},
+	"contentSchema": {}, "$anchor": {}, "$dynamicRef": {}, "$dynamicAnchor": {}, "$id": {}, "$comment": {},
+}
+
+// keys whose values are maps keyed by names (properties, paths, components), rather than keywords.
+var namedMapKeys = map[string]struct{}{
+	"properties": {}, "patternProperties": {}, "$defs": {}, "definitions": {}, "dependentSchemas": {},
+	"schemas": {}, "responses": {}, "parameters": {}, "examples": {}, "requestBodies": {}, "headers": {},
+	"securitySchemes": {}, "links": {}, "mediaTypes": {}, "content": {}, "encoding": {}, "variables": {},
+	"mapping": {}, "scopes": {},
+}
+
+// keys whose values are literal data, which are never searched.
+var literalKeys = map[string]struct{}{
+	"example": {}, "default": {}, "enum": {}, "const": {}, "value": {},
+}
+
+type featureContext int
+
+const (
+	featureContextObject featureContext = iota
+	featureContextRoot
+	featureContextInfo
+	featureContextComponents
+	featureContextPathItem
+)
+
+// versionFeatureDetector walks an OpenAPI 3 specification, recording the version-specific features it uses.
+type versionFeatureDetector struct {
+	usages []*VersionFeatureUsage
+	seen   map[string]*VersionFeatureUsage
+}
+
+// detectVersionFeatures returns every version-specific feature used by an OpenAPI 3 specification, in the order
+// they are first used.
+func detectVersionFeatures(root *yaml.Node) []*VersionFeatureUsage {
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	d := &versionFeatureDetector{seen: make(map[string]*VersionFeatureUsage)}
+	d.walkObject(root, featureContextRoot)
+	return d.usages
+}
+
+func (d *versionFeatureDetector) record(feature VersionFeature, key *yaml.Node) {
+	id := string(feature) + "|" + key.Value
+	if usage, ok := d.seen[id]; ok {
+		usage.Count++
+		return
+	}
+	usage := &VersionFeatureUsage{Feature: feature, Key: key.Value, Line: key.Line, Column: key.Column, Count: 1}
+	d.seen[id] = usage
+	d.usages = append(d.usages, usage)
+}
+
+func (d *versionFeatureDetector) walk(node *yaml.Node, ctx featureContext) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		d.walkObject(node, ctx)
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			d.walk(n, featureContextObject)
+		}
+	}
+}
+
+// walkNamed walks a map keyed by names, each value is walked using the supplied context.
+func (d *versionFeatureDetector) walkNamed(node *yaml.Node, ctx featureContext) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if !strings.HasPrefix(node.Content[i].Value, "x-") {
+			d.walk(node.Content[i+1], ctx)
+		}
+	}
+}
+
+func (d *versionFeatureDetector) walkObject(node *yaml.Node, ctx featureContext) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+		key := k.Value
+		if strings.HasPrefix(key, "x-") {
+			continue
+		}
+
+		switch ctx {
+		case featureContextRoot:
+			switch key {
+			case "webhooks":
+				d.record(FeatureWebhooks, k)
+			case "jsonSchemaDialect":
+				d.record(FeatureJSONSchemaDialect, k)
+			case "$self":
+				d.record(FeatureSelf, k)
+			}
+		case featureContextInfo:
+			switch key {
+			case "summary":
+				d.record(FeatureInfoSummary, k)
+			case "license":
+				if v.Kind == yaml.MappingNode {
+					if idKey, _ := utils.FindKeyNodeTop("identifier", v.Content); idKey != nil {
+						d.record(FeatureLicenseIdentifier, idKey)
+					}
+				}
+			}
+		case featureContextComponents:
+			switch key {
+			case "pathItems":
+				d.record(FeaturePathItemsComponent, k)
+			case "mediaTypes":
+				d.record(FeatureMediaTypesComponent, k)
+			}
+		case featureContextPathItem:
+			switch key {
+			case "query":
+				d.record(FeatureQueryOperation, k)
+			case "additionalOperations":
+				d.record(FeatureAdditionalOperations, k)
+				d.walkNamed(v, featureContextObject)
+				continue
+			}
+		}
+
+		// schema keywords.
+		switch key {
+		case "nullable":
+			d.record(FeatureNullable, k)
+		case "type":
+			if v.Kind == yaml.SequenceNode {
+				d.record(FeatureTypeArray, k)
+			}
+		case "exclusiveMinimum", "exclusiveMaximum":
+			if v.Tag == "!!bool" {
+				d.record(FeatureBooleanExclusiveBound, k)
+			} else if v.Tag == "!!int" || v.Tag == "!!float" {
+				d.record(FeatureNumericExclusiveBound, k)
+			}
+		case "examples":
+			if v.Kind == yaml.SequenceNode {
+				d.record(FeatureJSONSchemaKeyword, k)
+				continue
+			}
+		default:
+			if _, ok := jsonSchemaKeywords[key]; ok && ctx == featureContextObject {
+				d.record(FeatureJSONSchemaKeyword, k)
+			}
+		}
+
+		if _, ok := literalKeys[key]; ok {
+			continue
+		}
+		switch {
+		case ctx == featureContextRoot && key == "info":
+			d.walk(v, featureContextInfo)
+		case ctx == featureContextRoot && key == "components":
+			d.walk(v, featureContextComponents)
+		case (ctx == featureContextRoot && (key == "paths" || key == "webhooks")) ||
+			(ctx == featureContextComponents && key == "pathItems"):
+			d.walkNamed(v, featureContextPathItem)
+		case key == "callbacks":
+			// callbacks are named maps of expressions, to path items.
+			if v.Kind == yaml.MappingNode {
+				for j := 1; j < len(v.Content); j += 2 {
+					d.walkNamed(v.Content[j], featureContextPathItem)
+				}
+			}
+		default:
+			if _, ok := namedMapKeys[key]; ok && v.Kind == yaml.MappingNode {
+				d.walkNamed(v, featureContextObject)
+			} else {
+				d.walk(v, featureContextObject)
+			}
+		}
+	}
+}
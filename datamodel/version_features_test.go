@@ -0,0 +1,139 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package datamodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractSpecInfo_VersionFeatures(t *testing.T) {
+	spec := `openapi: 3.0.3
+info:
+  title: Pets
+  summary: all the pets
+  version: 1.0.0
+  license:
+    name: MIT
+    identifier: MIT
+jsonSchemaDialect: https://spec.openapis.org/oas/3.1/dialect/base
+webhooks:
+  newPet:
+    post:
+      responses:
+        "200":
+          description: OK
+paths:
+  /pets:
+    query:
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: [object, "null"]
+                properties:
+                  nullable:
+                    type: string
+                    nullable: true
+                  const:
+                    const: 1
+                    example:
+                      nullable: true
+                  age:
+                    type: integer
+                    exclusiveMinimum: 0
+                    examples: [1, 2]
+                  weight:
+                    type: number
+                    exclusiveMaximum: true
+                    nullable: true
+x-thing:
+  nullable: true
+components:
+  pathItems:
+    pet:
+      get:
+        callbacks:
+          onPet:
+            '{$request.body#/url}':
+              query:
+                description: hello`
+
+	info, err := ExtractSpecInfo([]byte(spec))
+	require.NoError(t, err)
+
+	features := make(map[VersionFeature]*VersionFeatureUsage)
+	for _, usage := range info.VersionFeatures {
+		if usage.Feature != FeatureJSONSchemaKeyword {
+			features[usage.Feature] = usage
+		}
+	}
+	assert.Len(t, features, 10)
+	assert.Equal(t, 4, features[FeatureInfoSummary].Line)
+	assert.Equal(t, "identifier", features[FeatureLicenseIdentifier].Key)
+	assert.Equal(t, 8, features[FeatureLicenseIdentifier].Line)
+	assert.NotNil(t, features[FeatureJSONSchemaDialect])
+	assert.NotNil(t, features[FeatureWebhooks])
+	assert.NotNil(t, features[FeaturePathItemsComponent])
+	assert.Equal(t, 2, features[FeatureQueryOperation].Count) // the callback path item is found too.
+	assert.Equal(t, 18, features[FeatureQueryOperation].Line)
+	assert.NotNil(t, features[FeatureTypeArray])
+	assert.NotNil(t, features[FeatureNumericExclusiveBound])
+	assert.NotNil(t, features[FeatureBooleanExclusiveBound])
+	// property names, examples and extensions are not features.
+	assert.Equal(t, 2, features[FeatureNullable].Count)
+	assert.Equal(t, 29, features[FeatureNullable].Line)
+
+	var keywords []string
+	for _, usage := range info.VersionFeatures {
+		if usage.Feature == FeatureJSONSchemaKeyword {
+			keywords = append(keywords, usage.Key)
+		}
+	}
+	assert.Equal(t, []string{"const", "examples"}, keywords)
+
+	assert.True(t, info.UsesVersionFeature(FeatureWebhooks))
+	assert.False(t, info.UsesVersionFeature(FeatureSelf))
+	unsupported := info.UnsupportedVersionFeatures()
+	assert.Len(t, unsupported, len(info.VersionFeatures)-2) // nullable and boolean bounds are fine in 3.0
+	for _, usage := range unsupported {
+		assert.NotEqual(t, FeatureNullable, usage.Feature)
+	}
+}
+
+func TestExtractSpecInfo_VersionFeatures_Supported(t *testing.T) {
+	info, err := ExtractSpecInfo([]byte(`openapi: 3.1.0
+$self: https://pb33f.io/openapi.yaml
+webhooks: {}
+components:
+  mediaTypes: {}
+  schemas:
+    Pet:
+      nullable: true`))
+	require.NoError(t, err)
+	assert.True(t, info.UsesVersionFeature(FeatureSelf))
+	assert.True(t, info.UsesVersionFeature(FeatureMediaTypesComponent))
+
+	unsupported := info.UnsupportedVersionFeatures()
+	require.Len(t, unsupported, 3)
+	assert.Equal(t, FeatureSelf, unsupported[0].Feature)
+	assert.Equal(t, FeatureMediaTypesComponent, unsupported[1].Feature)
+	assert.Equal(t, FeatureNullable, unsupported[2].Feature)
+
+	info, _ = ExtractSpecInfo([]byte("swagger: 2.0\ninfo:\n  summary: nope"))
+	assert.Empty(t, info.VersionFeatures)
+}
+
+func TestVersionFeature_SupportedBy(t *testing.T) {
+	assert.True(t, FeatureNullable.SupportedBy(3.0))
+	assert.False(t, FeatureNullable.SupportedBy(3.1))
+	assert.False(t, FeatureWebhooks.SupportedBy(3.0))
+	assert.True(t, FeatureWebhooks.SupportedBy(3.2))
+	assert.False(t, FeatureQueryOperation.SupportedBy(3.1))
+	assert.True(t, VersionFeature("pizza").SupportedBy(3.0))
+}
@@ -14,8 +14,13 @@
 package libopenapi
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
 
 	lowbase "github.com/pb33f/libopenapi/datamodel/low/base"
 
@@ -26,6 +31,7 @@ import (
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
 	v2low "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/patch"
 	"github.com/pb33f/libopenapi/utils"
 	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/pb33f/libopenapi/what-changed/model"
@@ -34,6 +40,12 @@ import (
 
 // Document Represents an OpenAPI specification that can then be rendered into a model or serialized back into
 // a string document after being manipulated.
+//
+// A Document is safe for concurrent use. Building, rendering, patching, invalidating and configuring the document are
+// synchronized internally, so a model is only ever built once, even if many goroutines ask for it at the same time.
+// The models returned are not synchronized however, mutating a model while another goroutine is reading or rendering
+// it is a race. Readers that need a stable view while another goroutine rebuilds, patches or invalidates the
+// document should hold a Snapshot().
 type Document interface {
 	// GetVersion will return the exact version of the OpenAPI specification set for the document.
 	GetVersion() string
@@ -56,14 +68,79 @@ type Document interface {
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 2 specifications and will throw an error for
 	// any other types.
+	//
+	// The model is only ever built once. Repeated calls return the same cached model that was produced by the
+	// first call, until InvalidateModel() is called. If the first call failed to produce a model, the same errors
+	// are returned again rather than attempting another build.
 	BuildV2Model() (*DocumentModel[v2high.Swagger], error)
 
+	// BuildV2ModelWithContext is the same as BuildV2Model, except the supplied context is used when indexing and
+	// building the model. If the context is cancelled or times out before the build completes, no model is
+	// returned, only the context error. A cancelled build is not cached, so it can be attempted again.
+	BuildV2ModelWithContext(ctx context.Context) (*DocumentModel[v2high.Swagger], error)
+
 	// BuildV3Model will build out an OpenAPI (version 3+) model from the specification used to create the document
 	// If there are any issues, then no model will be returned, instead a slice of errors will explain all the
 	// problems that occurred. This method will only support version 3 specifications and will throw an error for
 	// any other types.
+	//
+	// The model is only ever built once. Repeated calls return the same cached model that was produced by the
+	// first call, until InvalidateModel() is called. If the first call failed to produce a model, the same errors
+	// are returned again rather than attempting another build.
 	BuildV3Model() (*DocumentModel[v3high.Document], error)
 
+	// BuildV3ModelWithContext is the same as BuildV3Model, except the supplied context is used when indexing and
+	// building the model. If the context is cancelled or times out before the build completes, no model is
+	// returned, only the context error. A cancelled build is not cached, so it can be attempted again.
+	BuildV3ModelWithContext(ctx context.Context) (*DocumentModel[v3high.Document], error)
+
+	// BuildModel will build out a Swagger or OpenAPI 3+ model, depending on the version of the specification, and
+	// return a version-agnostic view of it. Use this when building tooling that works the same way for every
+	// version, instead of calling BuildV2Model() or BuildV3Model() and writing two code paths.
+	//
+	// The underlying model is built (and cached) exactly as BuildV2Model() or BuildV3Model() would, so any errors
+	// are returned the same way.
+	BuildModel() (Model, error)
+
+	// BuildModelWithContext is the same as BuildModel, except the supplied context is used when indexing and
+	// building the model, see BuildV2ModelWithContext and BuildV3ModelWithContext.
+	BuildModelWithContext(ctx context.Context) (Model, error)
+
+	// InvalidateModel will discard any cached models (V2 or V3), the rolodex and the spec info, and then
+	// re-read the specification from the original bytes. The next call to BuildV2Model() or BuildV3Model() will
+	// build a brand-new model from scratch. If the model was built using arena allocation, the arena is released.
+	//
+	// Use this if the underlying specification bytes have been mutated after the document was created.
+	InvalidateModel() error
+
+	// Snapshot returns a cheap, point-in-time copy of the document. The snapshot shares the specification, the
+	// configuration and any models that have already been built (nothing is copied), but it is not affected by
+	// anything that happens to the original document afterwards. Readers can hold a snapshot safely while another
+	// goroutine rebuilds, patches or invalidates the original document.
+	//
+	// Models built before the snapshot was taken are shared by both documents, so they should be treated as
+	// read-only. Models built by the snapshot after it was taken belong to the snapshot only.
+	Snapshot() Document
+
+	// Clone returns a deep copy of the document. Unlike Snapshot, nothing is shared, the specification (the yaml
+	// node tree and the original bytes) and the configuration are copied, so the clone can be changed, patched or
+	// built without disturbing the original, and without parsing the specification again. Any model (and with it,
+	// the index and rolodex) the original has built is built again by the clone from the copied nodes.
+	//
+	// Changes made to the original's high-level model that have not been rendered or written back to the nodes
+	// are not part of the clone.
+	Clone() (Document, error)
+
+	// ApplyPatch applies a JSON Patch (RFC 6902) document to the specification. The operations are applied
+	// directly to the underlying yaml nodes, so comments and formatting in the rest of the specification are
+	// preserved. The specification is re-read from the patched nodes and any cached models are invalidated, so the
+	// next call to BuildV2Model() or BuildV3Model() will build a model that includes the changes.
+	//
+	// A patch is applied atomically, if any operation fails (or the patched specification is no longer valid), an
+	// error is returned and the document is left untouched. Documents configured with LazyPaths cannot be patched,
+	// ErrLazyPaths is returned.
+	ApplyPatch(patch []byte) error
+
 	// RenderAndReload will render the high level model as it currently exists (including any mutations, additions
 	// and removals to and from any object in the tree). It will then reload the low level model with the new bytes
 	// extracted from the model that was re-rendered. This is useful if you want to make changes to the high level model
@@ -92,7 +169,8 @@ type Document interface {
 	// 'reload' the model into memory, so that line numbers and column numbers are correct and the index is accurate.
 	// However, if you don't care about the low-level model, and you're not using the index, and you just want to
 	// print the state of the model as it currently exists, then Render() is the method to use.
-	// **IMPORTANT** This method only supports OpenAPI Documents.
+	// **IMPORTANT** This method only supports OpenAPI Documents. Documents configured with LazyPaths cannot be
+	// rendered, ErrLazyPaths is returned.
 	Render() ([]byte, error)
 
 	// Serialize will re-render a Document back into a []byte slice. If any modifications have been made to the
@@ -113,6 +191,13 @@ type document struct {
 	config            *datamodel.DocumentConfiguration
 	highOpenAPI3Model *DocumentModel[v3high.Document]
 	highSwaggerModel  *DocumentModel[v2high.Swagger]
+	openAPI3Built     bool  // true once BuildV3Model has run, regardless of outcome.
+	openAPI3Errs      error // errors from a BuildV3Model call that failed to produce a model.
+	swaggerBuilt      bool  // true once BuildV2Model has run, regardless of outcome.
+	swaggerErrs       error // errors from a BuildV2Model call that failed to produce a model.
+	parseDuration     time.Duration
+	roundTripSnapshot *yaml.Node // the model as rendered when built, only captured for RoundTripFidelity.
+	lock              sync.RWMutex
 }
 
 // DocumentModel represents either a Swagger document (version 2) or an OpenAPI document (version 3) that is
@@ -120,6 +205,7 @@ type document struct {
 type DocumentModel[T v2high.Swagger | v3high.Document] struct {
 	Model T
 	Index *index.SpecIndex // index created from the document.
+	Stats *BuildStats      // timings and reference counts captured while building the model.
 }
 
 // NewDocument will create a new OpenAPI instance from an OpenAPI specification []byte array. If anything goes
@@ -139,6 +225,7 @@ func NewDocument(specByteArray []byte) (Document, error) {
 }
 
 func NewDocumentWithTypeCheck(specByteArray []byte, bypassCheck bool) (Document, error) {
+	started := time.Now()
 	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(specByteArray, bypassCheck)
 	if err != nil {
 		return nil, err
@@ -146,6 +233,7 @@ func NewDocumentWithTypeCheck(specByteArray []byte, bypassCheck bool) (Document,
 	d := new(document)
 	d.version = info.Version
 	d.info = info
+	d.parseDuration = time.Since(started)
 	return d, nil
 }
 
@@ -166,27 +254,194 @@ func NewDocumentWithConfiguration(specByteArray []byte, configuration *datamodel
 	return d, err
 }
 
+// NewDocumentFromReader is the same as NewDocumentWithConfiguration, except the specification is read from an
+// io.Reader, for example a file or a network response, instead of a []byte. The document keeps the original bytes
+// of the specification, so the whole specification is held in memory, as it is when using a []byte. The
+// configuration can be nil.
+func NewDocumentFromReader(reader io.Reader, configuration *datamodel.DocumentConfiguration) (Document, error) {
+	bypass := configuration != nil && configuration.BypassDocumentCheck
+	started := time.Now()
+	info, err := datamodel.ExtractSpecInfoFromReader(reader, bypass)
+	if err != nil {
+		return nil, err
+	}
+	d := new(document)
+	d.version = info.Version
+	d.info = info
+	d.parseDuration = time.Since(started)
+	d.SetConfiguration(configuration)
+	return d, nil
+}
+
 func (d *document) GetRolodex() *index.Rolodex {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.rolodex
 }
 
 func (d *document) GetVersion() string {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.version
 }
 
 func (d *document) GetSpecInfo() *datamodel.SpecInfo {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.info
 }
 
 func (d *document) GetConfiguration() *datamodel.DocumentConfiguration {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	return d.config
 }
 
 func (d *document) SetConfiguration(configuration *datamodel.DocumentConfiguration) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	d.config = configuration
 }
 
+func (d *document) Snapshot() Document {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return &document{
+		rolodex:           d.rolodex,
+		version:           d.version,
+		info:              d.info,
+		config:            d.config,
+		highOpenAPI3Model: d.highOpenAPI3Model,
+		highSwaggerModel:  d.highSwaggerModel,
+		openAPI3Built:     d.openAPI3Built,
+		openAPI3Errs:      d.openAPI3Errs,
+		swaggerBuilt:      d.swaggerBuilt,
+		swaggerErrs:       d.swaggerErrs,
+		parseDuration:     d.parseDuration,
+		roundTripSnapshot: d.roundTripSnapshot,
+	}
+}
+
+func (d *document) Clone() (Document, error) {
+	d.lock.RLock()
+	clone := &document{
+		version:       d.version,
+		info:          d.info.Clone(),
+		parseDuration: d.parseDuration,
+	}
+	if d.config != nil {
+		config := *d.config
+		clone.config = &config
+	}
+	buildV3, buildV2 := d.openAPI3Built, d.swaggerBuilt
+	d.lock.RUnlock()
+
+	// build the same models as the original, errors are cached by the clone, the same as the original.
+	if buildV3 {
+		if _, err := clone.BuildV3Model(); err != nil && clone.highOpenAPI3Model == nil {
+			return clone, err
+		}
+	}
+	if buildV2 {
+		if _, err := clone.BuildV2Model(); err != nil && clone.highSwaggerModel == nil {
+			return clone, err
+		}
+	}
+	return clone, nil
+}
+
+func (d *document) InvalidateModel() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.resetModels()
+	if d.info == nil || d.info.SpecBytes == nil {
+		return nil
+	}
+	bypass := d.config != nil && d.config.BypassDocumentCheck
+	started := time.Now()
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(*d.info.SpecBytes, bypass)
+	if err != nil {
+		return err
+	}
+	d.info = info
+	d.version = info.Version
+	d.parseDuration = time.Since(started)
+	return nil
+}
+
+func (d *document) ApplyPatch(patchBytes []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.info == nil || d.info.RootNode == nil {
+		return errors.New("unable to apply patch, no specification has been loaded")
+	}
+	if d.lazyPaths() {
+		return fmt.Errorf("unable to apply patch: %w", ErrLazyPaths)
+	}
+	ops, err := patch.Decode(patchBytes)
+	if err != nil {
+		return err
+	}
+	patched, err := patch.Apply(d.info.RootNode, ops)
+	if err != nil {
+		return err
+	}
+
+	newBytes, err := d.encodeNode(patched)
+	if err != nil {
+		return err
+	}
+
+	bypass := d.config != nil && d.config.BypassDocumentCheck
+	started := time.Now()
+	info, err := datamodel.ExtractSpecInfoWithDocumentCheck(newBytes, bypass)
+	if err != nil {
+		return err
+	}
+	d.resetModels()
+	d.info = info
+	d.version = info.Version
+	d.parseDuration = time.Since(started)
+	return nil
+}
+
+// GeneratePatch compares the specifications of two documents, and returns a JSON Patch (RFC 6902) document that
+// turns the original specification into the updated one, so that calling ApplyPatch on the original with the
+// result makes both specifications the same. The specifications are compared as they were written (not the built
+// models), and neither document is changed. Where what-changed describes what a change means to the API, the
+// patch describes exactly how the specification changed.
+func GeneratePatch(original, updated Document) ([]byte, error) {
+	if original == nil || updated == nil {
+		return nil, errors.New("unable to generate patch, both documents are required")
+	}
+	originalInfo, updatedInfo := original.GetSpecInfo(), updated.GetSpecInfo()
+	if originalInfo == nil || originalInfo.RootNode == nil || updatedInfo == nil || updatedInfo.RootNode == nil {
+		return nil, errors.New("unable to generate patch, no specification has been loaded")
+	}
+	return patch.Encode(patch.Diff(originalInfo.RootNode, updatedInfo.RootNode))
+}
+
+// resetModels discards any cached models and the rolodex, releasing any arenas used to build them.
+func (d *document) resetModels() {
+	if d.highOpenAPI3Model != nil && d.highOpenAPI3Model.Model.GoLow() != nil {
+		d.highOpenAPI3Model.Model.GoLow().Arena.Release()
+	}
+	if d.highSwaggerModel != nil && d.highSwaggerModel.Model.GoLow() != nil {
+		d.highSwaggerModel.Model.GoLow().Arena.Release()
+	}
+	d.highOpenAPI3Model = nil
+	d.highSwaggerModel = nil
+	d.openAPI3Built = false
+	d.openAPI3Errs = nil
+	d.swaggerBuilt = false
+	d.swaggerErrs = nil
+	d.rolodex = nil
+	d.roundTripSnapshot = nil
+}
+
 func (d *document) Serialize() ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to serialize, document has not yet been initialized")
 	}
@@ -204,7 +459,7 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 		return nil, nil, nil, rerr
 	}
 
-	newDoc, err := NewDocumentWithConfiguration(newBytes, d.config)
+	newDoc, err := NewDocumentWithConfiguration(newBytes, d.GetConfiguration())
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -218,7 +473,14 @@ func (d *document) RenderAndReload() ([]byte, Document, *DocumentModel[v3high.Do
 	return newBytes, newDoc, m, nil
 }
 
+// lazyPaths returns true if the model of the document is built without its paths. The lock must be held.
+func (d *document) lazyPaths() bool {
+	return d.config != nil && d.config.LazyPaths && d.info != nil && d.info.SpecType == utils.OpenApi3
+}
+
 func (d *document) Render() ([]byte, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
 	if d.highOpenAPI3Model == nil {
 		// check for Swagger model first, to give a more helpful error message.
 		if d.highSwaggerModel != nil {
@@ -229,18 +491,17 @@ func (d *document) Render() ([]byte, error) {
 	if d.info == nil {
 		return nil, errors.New("unable to render, no specification has been loaded")
 	}
+	if d.lazyPaths() {
+		return nil, fmt.Errorf("unable to render: %w", ErrLazyPaths)
+	}
+	if d.roundTripSnapshot != nil {
+		return d.renderRoundTrip()
+	}
 
 	var newBytes []byte
 	var jsonErr error
 	if d.info.SpecFileType == datamodel.JSONFileType {
-		jsonIndent := "  "
-		i := d.info.OriginalIndentation
-		if i > 2 {
-			for l := 0; l < i-2; l++ {
-				jsonIndent += " "
-			}
-		}
-		newBytes, jsonErr = d.highOpenAPI3Model.Model.RenderJSON(jsonIndent)
+		newBytes, jsonErr = d.highOpenAPI3Model.Model.RenderJSON(jsonIndentation(d.info.OriginalIndentation))
 	}
 	if d.info.SpecFileType == datamodel.YAMLFileType {
 		newBytes = d.highOpenAPI3Model.Model.RenderWithIndention(d.info.OriginalIndentation)
@@ -248,10 +509,43 @@ func (d *document) Render() ([]byte, error) {
 	return newBytes, jsonErr
 }
 
+// jsonIndentation returns the indentation string used to render JSON, based on the original indentation of
+// the specification. The minimum is two spaces.
+func jsonIndentation(original int) string {
+	if original > 2 {
+		return strings.Repeat(" ", original)
+	}
+	return "  "
+}
+
 func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], error) {
+	return d.BuildV2ModelWithContext(context.Background())
+}
+
+func (d *document) BuildV2ModelWithContext(ctx context.Context) (*DocumentModel[v2high.Swagger], error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	if d.highSwaggerModel != nil {
 		return d.highSwaggerModel, nil
 	}
+	if d.swaggerBuilt {
+		return nil, d.swaggerErrs
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m, err := d.buildV2Model(ctx)
+	if m == nil && ctx.Err() != nil {
+		return nil, ctx.Err() // cancelled builds are not cached.
+	}
+	if d.info != nil {
+		d.swaggerBuilt = true
+		d.swaggerErrs = err
+	}
+	return m, err
+}
+
+func (d *document) buildV2Model(ctx context.Context) (*DocumentModel[v2high.Swagger], error) {
 	var errs []error
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to build swagger document, no specification has been loaded")
@@ -266,8 +560,12 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], error) {
 		d.config = datamodel.NewDocumentConfiguration()
 	}
 
+	started := time.Now()
 	var docErr error
-	lowDoc, docErr = v2low.CreateDocumentFromConfig(d.info, d.config)
+	lowDoc, docErr = v2low.CreateDocumentFromConfigWithContext(ctx, d.info, d.config)
+	if lowDoc == nil {
+		return nil, docErr
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
@@ -284,20 +582,76 @@ func (d *document) BuildV2Model() (*DocumentModel[v2high.Swagger], error) {
 			}
 		}
 	}
-	highDoc := v2high.NewSwaggerDocument(lowDoc)
+	highDoc, err := v2high.NewSwaggerDocumentWithContext(ctx, lowDoc)
+	if err != nil {
+		return nil, err
+	}
 
 	d.highSwaggerModel = &DocumentModel[v2high.Swagger]{
 		Model: *highDoc,
 		Index: lowDoc.Index,
+		Stats: newBuildStats(d.parseDuration, time.Since(started), lowDoc.Rolodex),
 	}
 	lowbase.SchemaQuickHashMap.Clear()
 	return d.highSwaggerModel, errors.Join(errs...)
 }
 
 func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], error) {
+	return d.BuildV3ModelWithContext(context.Background())
+}
+
+func (d *document) BuildV3ModelWithContext(ctx context.Context) (*DocumentModel[v3high.Document], error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
 	if d.highOpenAPI3Model != nil {
 		return d.highOpenAPI3Model, nil
 	}
+	if d.openAPI3Built {
+		return nil, d.openAPI3Errs
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	m, err := d.buildV3Model(ctx)
+	if m == nil && ctx.Err() != nil {
+		return nil, ctx.Err() // cancelled builds are not cached.
+	}
+	if d.info != nil {
+		d.openAPI3Built = true
+		d.openAPI3Errs = err
+	}
+	return m, err
+}
+
+func (d *document) BuildModel() (Model, error) {
+	return d.BuildModelWithContext(context.Background())
+}
+
+func (d *document) BuildModelWithContext(ctx context.Context) (Model, error) {
+	d.lock.RLock()
+	info, version := d.info, d.version
+	d.lock.RUnlock()
+	if info == nil {
+		return nil, errors.New("unable to build model, no specification has been loaded")
+	}
+	switch info.SpecFormat {
+	case datamodel.OAS2:
+		m, err := d.BuildV2ModelWithContext(ctx)
+		if m == nil {
+			return nil, err
+		}
+		return newV2Model(version, info.SpecFormat, m), err
+	case datamodel.OAS3, datamodel.OAS31, datamodel.OAS32:
+		m, err := d.BuildV3ModelWithContext(ctx)
+		if m == nil {
+			return nil, err
+		}
+		return newV3Model(version, info.SpecFormat, m), err
+	}
+	return nil, fmt.Errorf("unable to build model, unsupported specification format (%v)", info.SpecFormat)
+}
+
+func (d *document) buildV3Model(ctx context.Context) (*DocumentModel[v3high.Document], error) {
 	var errs []error
 	if d.info == nil {
 		return nil, fmt.Errorf("unable to build document, no specification has been loaded")
@@ -317,8 +671,12 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], error) {
 		}
 	}
 
+	started := time.Now()
 	var docErr error
-	lowDoc, docErr = v3low.CreateDocumentFromConfig(d.info, d.config)
+	lowDoc, docErr = v3low.CreateDocumentFromConfigWithContext(ctx, d.info, d.config)
+	if lowDoc == nil {
+		return nil, docErr
+	}
 	d.rolodex = lowDoc.Rolodex
 
 	if docErr != nil {
@@ -336,12 +694,19 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], error) {
 		}
 	}
 
-	highDoc := v3high.NewDocument(lowDoc)
+	highDoc, err := v3high.NewDocumentWithContext(ctx, lowDoc)
+	if err != nil {
+		return nil, err
+	}
 	highDoc.Rolodex = lowDoc.Index.GetRolodex()
 
 	d.highOpenAPI3Model = &DocumentModel[v3high.Document]{
 		Model: *highDoc,
 		Index: lowDoc.Index,
+		Stats: newBuildStats(d.parseDuration, time.Since(started), lowDoc.Rolodex),
+	}
+	if d.config.RoundTripFidelity {
+		d.roundTripSnapshot = utils.CloneYAMLNode(renderModelNode(&d.highOpenAPI3Model.Model))
 	}
 	lowbase.SchemaQuickHashMap.Clear()
 	return d.highOpenAPI3Model, errors.Join(errs...)
@@ -353,35 +718,180 @@ func (d *document) BuildV3Model() (*DocumentModel[v3high.Document], error) {
 // If there are any errors when building the models, those errors are returned with a nil pointer for the
 // model.DocumentChanges. If there are any changes found however between either Document, then a pointer to
 // model.DocumentChanges is returned containing every single change, broken down, model by model.
+//
+// A Swagger 2.0 document can be compared with an OpenAPI 3 document (in either order), to verify a migration.
+// Both are normalized into OpenAPI 3.1 first: the Swagger document is converted (see v3high.ConvertSwagger) and
+// an OpenAPI 3.0 document is upgraded (see UpgradeDocument). The converted Swagger document takes the OpenAPI
+// version of the document it is compared with, so the change of version is not reported. Line and column numbers
+// for the Swagger side refer to the converted document, not the original Swagger specification.
+//
+// Documents configured with LazyPaths cannot be compared, ErrLazyPaths is returned.
 func CompareDocuments(original, updated Document) (*model.DocumentChanges, error) {
-	var errs []error
-	if original.GetSpecInfo().SpecType == utils.OpenApi3 && updated.GetSpecInfo().SpecType == utils.OpenApi3 {
-		v3ModelLeft, oErrs := original.BuildV3Model()
-		if oErrs != nil {
-			errs = append(errs, oErrs)
+	return CompareDocumentsWithContext(context.Background(), original, updated)
+}
+
+// CompareDocumentsWithContext is the same as CompareDocuments, except the supplied context is used when building
+// the models, and the comparison stops if the context is cancelled or times out. In that case no changes
+// are returned, only the context error.
+func CompareDocumentsWithContext(ctx context.Context, original, updated Document) (*model.DocumentChanges, error) {
+	return compareDocuments(ctx, original, updated, nil)
+}
+
+// CompareDocumentsWithConfiguration is the same as CompareDocuments, except the comparison is tuned using the
+// supplied configuration (breaking rule presets and overrides, perspective, filters, extension and example
+// handling, and resolved schema comparison). The configuration can be nil, in which case the behavior is the same as
+// CompareDocuments.
+//
+// When CompareResolvedSchemas is enabled, new documents are created from the bytes of the original and updated
+// documents with UseSchemaQuickHash enabled, the documents supplied are not modified.
+func CompareDocumentsWithConfiguration(original, updated Document,
+	configuration *what_changed.ComparisonConfiguration,
+) (*model.DocumentChanges, error) {
+	return compareDocuments(context.Background(), original, updated, configuration)
+}
+
+func compareDocuments(ctx context.Context, original, updated Document,
+	configuration *what_changed.ComparisonConfiguration,
+) (*model.DocumentChanges, error) {
+	if lazyPaths(original) || lazyPaths(updated) {
+		return nil, fmt.Errorf("unable to compare documents: %w", ErrLazyPaths)
+	}
+	if configuration != nil && configuration.BreakingRulesPreset != "" {
+		if _, err := model.BreakingRulesPreset(configuration.BreakingRulesPreset); err != nil {
+			return nil, err
 		}
-		v3ModelRight, uErrs := updated.BuildV3Model()
-		if uErrs != nil {
-			errs = append(errs, uErrs)
+	}
+	if configuration != nil && configuration.CompareResolvedSchemas {
+		var err error
+		if original, err = withSchemaQuickHash(original); err != nil {
+			return nil, err
 		}
+		if updated, err = withSchemaQuickHash(updated); err != nil {
+			return nil, err
+		}
+	}
+	left, right, err := lowModels(ctx, original, updated)
+	if left == nil || right == nil {
+		return nil, err
+	}
+	if l, ok := left.(*v2low.Swagger); ok {
+		return compareWithContext(ctx, func() *model.DocumentChanges {
+			return what_changed.CompareSwaggerDocumentsWithContext(ctx, l, right.(*v2low.Swagger), configuration)
+		}, err)
+	}
+	l, r := left.(*v3low.Document), right.(*v3low.Document)
+	if isCrossVersion(original.GetSpecInfo().SpecType, updated.GetSpecInfo().SpecType) {
+		// the converted swagger document takes the version of the document it's compared with.
+		swagger, openAPI := l, r
+		if updated.GetSpecInfo().SpecType == utils.OpenApi2 {
+			swagger, openAPI = r, l
+		}
+		if swagger.Version.ValueNode != nil && openAPI.Version.ValueNode != nil {
+			version := *swagger.Version.ValueNode
+			version.Value = openAPI.Version.Value
+			swagger.Version.Value, swagger.Version.ValueNode = openAPI.Version.Value, &version
+		}
+	}
+	return compareWithContext(ctx, func() *model.DocumentChanges {
+		return what_changed.CompareOpenAPIDocumentsWithContext(ctx, l, r, configuration)
+	}, err)
+}
+
+// lowModels builds the low-level models of two documents, so they can be compared. Both models are either
+// OpenAPI 3 (*v3low.Document) or Swagger (*v2low.Swagger). When one document is Swagger 2.0 and the other is
+// OpenAPI 3, both are normalized into OpenAPI 3.1 (see normalizedV3Model). If either model cannot be built, nil
+// models are returned. Any errors found building the models are returned with them.
+func lowModels(ctx context.Context, original, updated Document) (any, any, error) {
+	var errs []error
+	var left, right any
+	originalType, updatedType := original.GetSpecInfo().SpecType, updated.GetSpecInfo().SpecType
+	switch {
+	case originalType == utils.OpenApi3 && updatedType == utils.OpenApi3:
+		v3ModelLeft, oErrs := original.BuildV3ModelWithContext(ctx)
+		v3ModelRight, uErrs := updated.BuildV3ModelWithContext(ctx)
+		errs = append(errs, oErrs, uErrs)
 		if v3ModelLeft != nil && v3ModelRight != nil {
-			return what_changed.CompareOpenAPIDocuments(v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow()),
-				errors.Join(errs...)
-		} else {
-			return nil, errors.Join(errs...)
+			left, right = v3ModelLeft.Model.GoLow(), v3ModelRight.Model.GoLow()
 		}
+	case originalType == utils.OpenApi2 && updatedType == utils.OpenApi2:
+		v2ModelLeft, oErrs := original.BuildV2ModelWithContext(ctx)
+		v2ModelRight, uErrs := updated.BuildV2ModelWithContext(ctx)
+		errs = append(errs, oErrs, uErrs)
+		if v2ModelLeft != nil && v2ModelRight != nil {
+			left, right = v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow()
+		}
+	case isCrossVersion(originalType, updatedType):
+		l, oErr := normalizedV3Model(ctx, original)
+		r, uErr := normalizedV3Model(ctx, updated)
+		errs = append(errs, oErr, uErr)
+		if l != nil && r != nil {
+			left, right = l, r
+		}
+	default:
+		return nil, nil, fmt.Errorf("unable to compare documents, one or both documents are not of the same version")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
-	if original.GetSpecInfo().SpecType == utils.OpenApi2 && updated.GetSpecInfo().SpecType == utils.OpenApi2 {
-		v2ModelLeft, oErrs := original.BuildV2Model()
-		if oErrs != nil {
-			errs = append(errs, oErrs)
+	return left, right, errors.Join(errs...)
+}
+
+// isCrossVersion determines if one document is Swagger 2.0 and the other is OpenAPI 3.
+func isCrossVersion(original, updated string) bool {
+	return (original == utils.OpenApi2 && updated == utils.OpenApi3) ||
+		(original == utils.OpenApi3 && updated == utils.OpenApi2)
+}
+
+// normalizedV3Model returns an OpenAPI 3.1 low-level model for a document. Swagger 2.0 documents are converted,
+// and OpenAPI 3.0 documents are upgraded.
+func normalizedV3Model(ctx context.Context, doc Document) (*v3low.Document, error) {
+	if doc.GetSpecInfo().SpecType == utils.OpenApi2 {
+		swagger, err := doc.BuildV2ModelWithContext(ctx)
+		if swagger == nil {
+			return nil, err
 		}
-		v2ModelRight, uErrs := updated.BuildV2Model()
-		if uErrs != nil {
-			errs = append(errs, uErrs)
+		converted, cErr := v3high.ConvertSwagger(&swagger.Model, doc.GetConfiguration())
+		if converted == nil {
+			return nil, errors.Join(err, cErr)
 		}
-		return what_changed.CompareSwaggerDocuments(v2ModelLeft.Model.GoLow(), v2ModelRight.Model.GoLow()),
-			errors.Join(errs...)
+		return converted.GoLow(), errors.Join(err, cErr)
+	}
+	upgraded, _, err := UpgradeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	v3Model, err := upgraded.BuildV3ModelWithContext(ctx)
+	if v3Model == nil {
+		return nil, err
+	}
+	return v3Model.Model.GoLow(), err
+}
+
+// withSchemaQuickHash returns a new document created from the same bytes and configuration as the supplied
+// document, with UseSchemaQuickHash enabled. If it is already enabled, the document is returned as is.
+func withSchemaQuickHash(doc Document) (Document, error) {
+	config := doc.GetConfiguration()
+	info := doc.GetSpecInfo()
+	if (config != nil && config.UseSchemaQuickHash) || info == nil || info.SpecBytes == nil {
+		return doc, nil
+	}
+	quickHash := datamodel.NewDocumentConfiguration()
+	if config != nil {
+		c := *config
+		quickHash = &c
+	}
+	quickHash.UseSchemaQuickHash = true
+	return NewDocumentWithConfiguration(*info.SpecBytes, quickHash)
+}
+
+// compareWithContext runs a comparison that observes the context. If the context is done by the time the
+// comparison returns, the context error is returned and the incomplete changes are discarded.
+func compareWithContext(ctx context.Context, compare func() *model.DocumentChanges,
+	err error,
+) (*model.DocumentChanges, error) {
+	changes := compare()
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
 	}
-	return nil, fmt.Errorf("unable to compare documents, one or both documents are not of the same version")
+	return changes, err
 }
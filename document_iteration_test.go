@@ -18,7 +18,7 @@ type loopFrame struct {
 	Restricted bool
 }
 
-type context struct {
+type iterationContext struct {
 	visited []string
 	stack   []loopFrame
 }
@@ -79,7 +79,7 @@ func runTest(t *testing.T, specLocation string) {
 			t.Log(name)
 		}
 
-		handleSchema(t, schemaProxy, context{})
+		handleSchema(t, schemaProxy, iterationContext{})
 	}
 
 	require.Equal(t, uint64(10), m.Index.GetHighCacheMisses())
@@ -102,7 +102,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 			}
 
 			if param.Schema != nil {
-				handleSchema(t, param.Schema, context{})
+				handleSchema(t, param.Schema, iterationContext{})
 			}
 		}
 
@@ -117,7 +117,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 				}
 
 				if mediaType.Schema != nil {
-					handleSchema(t, mediaType.Schema, context{})
+					handleSchema(t, mediaType.Schema, iterationContext{})
 				}
 			}
 		}
@@ -139,7 +139,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 				}
 
 				if mediaType.Schema != nil {
-					handleSchema(t, mediaType.Schema, context{})
+					handleSchema(t, mediaType.Schema, iterationContext{})
 				}
 			}
 		}
@@ -166,7 +166,7 @@ func iterateOperations(t *testing.T, ops *orderedmap.Map[string, *v3.Operation])
 	}
 }
 
-func handleSchema(t *testing.T, schProxy *base.SchemaProxy, ctx context) {
+func handleSchema(t *testing.T, schProxy *base.SchemaProxy, ctx iterationContext) {
 	if checkCircularReference(t, &ctx, schProxy) {
 		return
 	}
@@ -263,7 +263,7 @@ func getResolvedType(sch *base.Schema) (string, []string) {
 	return "oneOf", subTypes
 }
 
-func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx context) {
+func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	var schemas []*base.SchemaProxy
 
 	switch {
@@ -282,7 +282,7 @@ func handleAllOfAnyOfOneOf(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func handleArray(t *testing.T, sch *base.Schema, ctx context) {
+func handleArray(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	ctx.stack = append(ctx.stack, loopFrame{Type: "array", Restricted: sch.MinItems != nil && *sch.MinItems > 0})
 
 	if sch.Items != nil && sch.Items.IsA() {
@@ -300,7 +300,7 @@ func handleArray(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func handleObject(t *testing.T, sch *base.Schema, ctx context) {
+func handleObject(t *testing.T, sch *base.Schema, ctx iterationContext) {
 	for name, schemaProxy := range sch.Properties.FromOldest() {
 		ctx.stack = append(ctx.stack, loopFrame{Type: "object", Restricted: slices.Contains(sch.Required, name)})
 		handleSchema(t, schemaProxy, ctx)
@@ -311,7 +311,7 @@ func handleObject(t *testing.T, sch *base.Schema, ctx context) {
 	}
 }
 
-func checkCircularReference(t *testing.T, ctx *context, schProxy *base.SchemaProxy) bool {
+func checkCircularReference(t *testing.T, ctx *iterationContext, schProxy *base.SchemaProxy) bool {
 	loopRef := getSimplifiedRef(schProxy.GetReference())
 
 	if loopRef != "" {
@@ -6,21 +6,32 @@ import (
 	"bytes"
 	stdContext "context"
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"net/http"
+	"net/http/cgi"
+	"net/http/httptest"
 	"net/url"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/datamodel/high/base"
 	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/pb33f/libopenapi/patch"
 	"github.com/pb33f/libopenapi/utils"
+	what_changed "github.com/pb33f/libopenapi/what-changed"
 	"github.com/pb33f/libopenapi/what-changed/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -648,13 +659,156 @@ func TestDocument_BuildModel_CompareDocsV2_Error(t *testing.T) {
 	assert.Nil(t, changes)
 }
 
-func TestDocument_BuildModel_CompareDocsV2V3Mix_Error(t *testing.T) {
+func TestDocument_BuildModel_CompareDocsV2V3Mix(t *testing.T) {
 	burgerShopOriginal, _ := os.ReadFile("test_specs/petstorev2.json")
 	burgerShopUpdated, _ := os.ReadFile("test_specs/petstorev3.json")
 	originalDoc, _ := NewDocument(burgerShopOriginal)
 	updatedDoc, _ := NewDocument(burgerShopUpdated)
-	changes, errors := CompareDocuments(updatedDoc, originalDoc)
-	assert.Error(t, errors)
+	changes, errors := CompareDocuments(originalDoc, updatedDoc)
+	assert.NoError(t, errors)
+	require.NotNil(t, changes)
+	assert.Greater(t, changes.TotalChanges(), 0)
+
+	changes, errors = CompareDocuments(updatedDoc, originalDoc)
+	assert.NoError(t, errors)
+	require.NotNil(t, changes)
+	assert.Greater(t, changes.TotalChanges(), 0)
+}
+
+var crossVersionSwagger = `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+host: api.example.com
+basePath: /v1
+schemes: [https]
+consumes: [application/json]
+produces: [application/json]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          type: integer
+          format: int32
+      responses:
+        '200':
+          description: pets
+          schema:
+            type: array
+            items:
+              $ref: '#/definitions/Pet'
+definitions:
+  Pet:
+    type: object
+    required: [name]
+    properties:
+      name:
+        type: string
+      tag:
+        type: string
+        x-nullable: true`
+
+var crossVersionOpenAPI = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+servers:
+  - url: https://api.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            format: int32
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+          nullable: true`
+
+func TestCompareDocuments_CrossVersion_Equivalent(t *testing.T) {
+	swagger, err := NewDocument([]byte(crossVersionSwagger))
+	require.NoError(t, err)
+	openAPI, err := NewDocument([]byte(crossVersionOpenAPI))
+	require.NoError(t, err)
+
+	// a faithful migration has no changes, in either direction.
+	changes, err := CompareDocuments(swagger, openAPI)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+	changes, err = CompareDocuments(openAPI, swagger)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+
+	// the swagger document takes the version of the OpenAPI 3.1 document, so it's not reported.
+	openAPI31, err := NewDocument([]byte(strings.Replace(
+		strings.Replace(crossVersionOpenAPI, "openapi: 3.0.3", "openapi: 3.1.1", 1),
+		"          type: string\n          nullable: true", "          type: [string, 'null']", 1)))
+	require.NoError(t, err)
+	changes, err = CompareDocuments(swagger, openAPI31)
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocuments_CrossVersion_Breaking(t *testing.T) {
+	swagger, err := NewDocument([]byte(crossVersionSwagger))
+	require.NoError(t, err)
+	migrated := strings.Replace(crossVersionOpenAPI, `        name:
+          type: string`, `        name:
+          type: integer`, 1)
+	migrated = strings.Replace(migrated, "          in: query", "          in: query\n          required: true", 1)
+	openAPI, err := NewDocument([]byte(migrated))
+	require.NoError(t, err)
+
+	changes, err := CompareDocuments(swagger, openAPI)
+	require.NoError(t, err)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	properties := make(map[string]*model.Change)
+	for _, c := range changes.GetAllChanges() {
+		properties[c.Property] = c
+	}
+	require.Contains(t, properties, "type")
+	assert.Equal(t, "string", properties["type"].Original)
+	assert.Equal(t, "integer", properties["type"].New)
+	assert.True(t, properties["type"].Breaking)
+	require.Contains(t, properties, "required")
+	// lines of the OpenAPI document are kept, the 3.0 document is upgraded in place.
+	assert.Equal(t, 14, *properties["required"].Context.NewLine)
+}
+
+func TestCompareDocuments_CrossVersion_Cancelled(t *testing.T) {
+	swagger, _ := NewDocument([]byte(crossVersionSwagger))
+	openAPI, _ := NewDocument([]byte(crossVersionOpenAPI))
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	changes, err := CompareDocumentsWithContext(ctx, swagger, openAPI)
+	assert.ErrorIs(t, err, stdContext.Canceled)
 	assert.Nil(t, changes)
 }
 
@@ -1928,3 +2082,969 @@ components:
 		t.Fatal("components or schemas not found in reloaded model")
 	}
 }
+
+func TestDocument_BuildV3Model_Cached(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	first, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	second, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Same(t, first, second)
+	assert.Same(t, first.Index, second.Index)
+}
+
+func TestDocument_BuildV3Model_CachedErrors(t *testing.T) {
+	doc, err := NewDocument([]byte(`swagger: 2.0`))
+	require.NoError(t, err)
+
+	first, errs := doc.BuildV3Model()
+	require.Error(t, errs)
+	assert.Nil(t, first)
+	second, secondErrs := doc.BuildV3Model()
+	assert.Nil(t, second)
+	assert.Equal(t, errs, secondErrs)
+}
+
+func TestDocument_BuildV2Model_Cached(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	first, errs := doc.BuildV2Model()
+	require.NoError(t, errs)
+	second, errs := doc.BuildV2Model()
+	require.NoError(t, errs)
+	assert.Same(t, first, second)
+}
+
+func TestDocument_InvalidateModel(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: before`)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	first, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, "before", first.Model.Info.Title)
+
+	// mutate the underlying bytes in place.
+	copy(spec[len(spec)-6:], "after!")
+
+	cached, _ := doc.BuildV3Model()
+	assert.Same(t, first, cached)
+
+	require.NoError(t, doc.InvalidateModel())
+	rebuilt, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.NotSame(t, first, rebuilt)
+	assert.Equal(t, "after!", rebuilt.Model.Info.Title)
+}
+
+func TestDocument_InvalidateModel_BadBytes(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0`)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	_, _ = doc.BuildV3Model()
+
+	copy(spec, "{{{{{{{{{{{{{{")
+	assert.Error(t, doc.InvalidateModel())
+}
+
+func TestDocument_InvalidateModel_NoSpec(t *testing.T) {
+	doc := new(document) // not how this should be instantiated.
+	assert.NoError(t, doc.InvalidateModel())
+}
+
+func TestDocument_InvalidateModel_ReleasesArena(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocumentWithConfiguration(petstore, &datamodel.DocumentConfiguration{UseArenaAllocation: true})
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	arena := m.Model.GoLow().Arena
+	require.NotNil(t, arena)
+	assert.Greater(t, arena.Allocated(), int64(0))
+
+	require.NoError(t, doc.InvalidateModel())
+	assert.True(t, arena.Released())
+}
+
+func TestDocument_BuildV3ModelWithContext(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	m, errs := doc.BuildV3ModelWithContext(stdContext.Background())
+	require.NoError(t, errs)
+	require.NotNil(t, m)
+
+	// the cached model is returned by both methods.
+	cached, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Same(t, m, cached)
+}
+
+func TestDocument_BuildV3ModelWithContext_Cancelled(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	m, errs := doc.BuildV3ModelWithContext(ctx)
+	assert.ErrorIs(t, errs, stdContext.Canceled)
+	assert.Nil(t, m)
+
+	// a cancelled build is not cached.
+	m, errs = doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.NotNil(t, m)
+}
+
+func TestDocument_BuildV2ModelWithContext_Cancelled(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	m, errs := doc.BuildV2ModelWithContext(ctx)
+	assert.ErrorIs(t, errs, stdContext.Canceled)
+	assert.Nil(t, m)
+
+	// a cancelled build is not cached.
+	m, errs = doc.BuildV2ModelWithContext(stdContext.Background())
+	require.NoError(t, errs)
+	require.NotNil(t, m)
+	cached, errs := doc.BuildV2Model()
+	require.NoError(t, errs)
+	assert.Same(t, m, cached)
+}
+
+func TestDocument_BuildModelWithContext_Cancelled(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	m, errs := doc.BuildModelWithContext(ctx)
+	assert.ErrorIs(t, errs, stdContext.Canceled)
+	assert.Nil(t, m)
+
+	m, errs = doc.BuildModelWithContext(stdContext.Background())
+	require.NoError(t, errs)
+	assert.NotNil(t, m)
+}
+
+func TestCompareDocumentsWithContext(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+
+	changes, err := CompareDocumentsWithContext(stdContext.Background(), originalDoc, updatedDoc)
+	require.NoError(t, err)
+	expected, err := CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	assert.Greater(t, changes.TotalChanges(), 0)
+	assert.Equal(t, expected.TotalChanges(), changes.TotalChanges())
+}
+
+func TestCompareDocumentsWithContext_Cancelled(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	changes, err := CompareDocumentsWithContext(ctx, originalDoc, updatedDoc)
+	assert.ErrorIs(t, err, stdContext.Canceled)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocumentsWithContext_Swagger_Cancelled(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	originalDoc, _ := NewDocument(petstore)
+	updatedDoc, _ := NewDocument(petstore)
+
+	ctx, cancel := stdContext.WithCancel(stdContext.Background())
+	cancel()
+	changes, err := CompareDocumentsWithContext(ctx, originalDoc, updatedDoc)
+	assert.ErrorIs(t, err, stdContext.Canceled)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocumentsWithConfiguration(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+	updatedDoc, _ := NewDocument(burgerShopUpdated)
+
+	expected, err := CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	changes, err := CompareDocumentsWithConfiguration(originalDoc, updatedDoc, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected.TotalChanges(), changes.TotalChanges())
+
+	changes, err = CompareDocumentsWithConfiguration(originalDoc, updatedDoc, &what_changed.ComparisonConfiguration{
+		IgnoreExtensions: true,
+		Filter: func(change *model.Change) bool {
+			return change.Breaking
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, changes.TotalBreakingChanges(), changes.TotalChanges())
+	assert.Equal(t, expected.TotalBreakingChanges(), changes.TotalChanges())
+}
+
+func TestCompareDocumentsWithConfiguration_UnknownBreakingRulesPreset(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	originalDoc, _ := NewDocument(burgerShopOriginal)
+
+	changes, err := CompareDocumentsWithConfiguration(originalDoc, originalDoc, &what_changed.ComparisonConfiguration{
+		BreakingRulesPreset: "chaotic",
+	})
+	assert.Nil(t, changes)
+	assert.ErrorContains(t, err, "unknown breaking rules preset 'chaotic'")
+
+	_, err = CompareDocumentsWithConfiguration(originalDoc, originalDoc, &what_changed.ComparisonConfiguration{
+		BreakingRulesPreset: model.PresetInternalAPI,
+	})
+	assert.NoError(t, err)
+}
+
+func TestCompareDocumentsWithConfiguration_CompareResolvedSchemas(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("test_specs/burgershop.openapi-modified.yaml")
+	originalDoc, _ := NewDocumentWithConfiguration(burgerShopOriginal, datamodel.NewDocumentConfiguration())
+	updatedDoc, _ := NewDocumentWithConfiguration(burgerShopUpdated, datamodel.NewDocumentConfiguration())
+
+	changes, err := CompareDocumentsWithConfiguration(originalDoc, updatedDoc, &what_changed.ComparisonConfiguration{
+		CompareResolvedSchemas: true,
+	})
+	require.NoError(t, err)
+	assert.Greater(t, changes.TotalChanges(), 0)
+
+	// the supplied documents are left alone.
+	assert.False(t, originalDoc.GetConfiguration().UseSchemaQuickHash)
+	assert.False(t, updatedDoc.GetConfiguration().UseSchemaQuickHash)
+
+	petstore, _ := os.ReadFile("test_specs/petstorev2.json")
+	left, _ := NewDocument(petstore)
+	right, _ := NewDocument(petstore)
+	changes, err = CompareDocumentsWithConfiguration(left, right, &what_changed.ComparisonConfiguration{
+		CompareResolvedSchemas: true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestCompareDocumentsWithConfiguration_CompareResolvedSchemas_MovedReferences(t *testing.T) {
+	original := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      description: a pet
+      properties:
+        kind:
+          $ref: '#/components/schemas/Kind'
+        owner:
+          type: object
+          properties:
+            name:
+              type: string
+    Kind:
+      type: string
+      enum: [cat, dog]
+    Species:
+      type: string
+      enum: [cat, dog]
+    Owner:
+      type: object
+      properties:
+        name:
+          type: integer`
+	// the description changes, kind points to an identical schema, and owner moves into components (and changes).
+	updated := strings.Replace(strings.Replace(strings.Replace(original,
+		"description: a pet", "description: a lovely pet", 1),
+		"schemas/Kind'", "schemas/Species'", 1),
+		`        owner:
+          type: object
+          properties:
+            name:
+              type: string`, `        owner:
+          $ref: '#/components/schemas/Owner'`, 1)
+	originalDoc, _ := NewDocument([]byte(original))
+	updatedDoc, _ := NewDocument([]byte(updated))
+
+	changes, err := CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	properties := make([]string, 0)
+	for _, c := range changes.GetAllChanges() {
+		properties = append(properties, c.Property)
+	}
+	assert.ElementsMatch(t, []string{"description", "$ref", "$ref"}, properties)
+
+	changes, err = CompareDocumentsWithConfiguration(originalDoc, updatedDoc, &what_changed.ComparisonConfiguration{
+		CompareResolvedSchemas: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, changes.TotalChanges())
+	all := changes.GetAllChanges()
+	assert.Equal(t, "description", all[0].Property)
+	assert.Equal(t, "type", all[1].Property)
+	assert.Equal(t, "string", all[1].Original)
+	assert.Equal(t, "integer", all[1].New)
+	assert.True(t, all[1].Breaking)
+}
+
+func TestCompareDocumentsWithConfiguration_CompareResolvedSchemas_Swagger(t *testing.T) {
+	original := `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths: {}
+definitions:
+  Pet:
+    type: object
+    properties:
+      kind:
+        $ref: '#/definitions/Kind'
+  Kind:
+    type: string
+    enum: [cat, dog]
+  Species:
+    type: string
+    enum: [cat, dog]`
+	originalDoc, _ := NewDocument([]byte(original))
+	updatedDoc, _ := NewDocument([]byte(strings.Replace(original, "definitions/Kind'", "definitions/Species'", 1)))
+
+	changes, err := CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+	assert.Equal(t, 1, changes.TotalChanges())
+
+	changes, err = CompareDocumentsWithConfiguration(originalDoc, updatedDoc, &what_changed.ComparisonConfiguration{
+		CompareResolvedSchemas: true,
+	})
+	require.NoError(t, err)
+	assert.Nil(t, changes)
+}
+
+func TestNewDocumentWithConfiguration_SubsystemLoggers(t *testing.T) {
+	var logs, builder bytes.Buffer
+	config := datamodel.NewDocumentConfiguration()
+	config.Logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelError}))
+	config.SubsystemLoggers = map[datamodel.LogSubsystem]*slog.Logger{
+		datamodel.LogSubsystemBuilder: slog.New(slog.NewJSONHandler(&builder, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+	doc, err := NewDocumentWithConfiguration([]byte("openapi: 3.1.0\ninfo:\n  title: Pets\n  version: 1.0.0"), config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.NoError(t, err)
+
+	assert.Contains(t, builder.String(), `"msg":"indexing rolodex","subsystem":"builder"`)
+	assert.Empty(t, logs.String())
+}
+
+func TestNewDocumentWithConfiguration_BuildEvents(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+x-fine: true
+definitions:
+  Pet:
+    type: object
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [parent]
+      properties:
+        parent:
+          $ref: '#/components/schemas/Pet'
+        owner:
+          $ref: '#/components/schemas/Owner'`
+
+	var lock sync.Mutex
+	events := make(map[datamodel.BuildEventType][]*datamodel.BuildEvent)
+	config := datamodel.NewDocumentConfiguration()
+	config.BuildEventHandler = func(event *datamodel.BuildEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		events[event.Type] = append(events[event.Type], event)
+	}
+	doc, err := NewDocumentWithConfiguration([]byte(spec), config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.Error(t, err)
+
+	require.Len(t, events[datamodel.BuildEventUnknownKey], 1)
+	unknown := events[datamodel.BuildEventUnknownKey][0]
+	assert.Equal(t, datamodel.LogSubsystemBuilder, unknown.Subsystem)
+	assert.Contains(t, unknown.Message, "definitions")
+	assert.Equal(t, 6, unknown.Line)
+	assert.Equal(t, 1, unknown.Column)
+
+	require.NotEmpty(t, events[datamodel.BuildEventCircularReference])
+	circular := events[datamodel.BuildEventCircularReference][0]
+	assert.Equal(t, datamodel.LogSubsystemResolver, circular.Subsystem)
+	assert.Equal(t, "#/components/schemas/Pet", circular.Ref)
+
+	require.NotEmpty(t, events[datamodel.BuildEventUnresolvedReference])
+	assert.Equal(t, "#/components/schemas/Owner", events[datamodel.BuildEventUnresolvedReference][0].Ref)
+}
+
+func TestDocument_ConcurrentBuild(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	models := make([]*DocumentModel[v3high.Document], 10)
+	for i := range models {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			models[i], _ = doc.BuildV3Model()
+			_, _ = doc.Render()
+			_ = doc.GetSpecInfo()
+		}(i)
+	}
+	wg.Wait()
+	for _, m := range models {
+		assert.Same(t, models[0], m)
+	}
+}
+
+func TestDocument_Snapshot(t *testing.T) {
+	doc, err := NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0`))
+	require.NoError(t, err)
+	m, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	snapshot := doc.Snapshot()
+	require.NoError(t, doc.ApplyPatch([]byte(`[{"op": "replace", "path": "/info/title", "value": "Cats"}]`)))
+
+	// the snapshot still sees the document as it was.
+	sm, err := snapshot.BuildV3Model()
+	require.NoError(t, err)
+	assert.Same(t, m, sm)
+	assert.Equal(t, "Pets", sm.Model.Info.Title)
+	rendered, err := snapshot.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "title: Pets")
+
+	// the original has moved on.
+	m, err = doc.BuildV3Model()
+	require.NoError(t, err)
+	assert.Equal(t, "Cats", m.Model.Info.Title)
+
+	// invalidating a snapshot does not touch the original.
+	require.NoError(t, snapshot.InvalidateModel())
+	sm, _ = snapshot.BuildV3Model()
+	assert.NotSame(t, m, sm)
+	again, _ := doc.BuildV3Model()
+	assert.Same(t, m, again)
+}
+
+func TestDocument_ApplyPatch(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Pets # keep me
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets`)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+	before, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+
+	err = doc.ApplyPatch([]byte(`[
+  {"op": "replace", "path": "/info/version", "value": "2.0.0"},
+  {"op": "add", "path": "/paths/~1dogs", "value": {"get": {"description": "list dogs"}}}
+]`))
+	require.NoError(t, err)
+
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: Pets # keep me
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      description: list pets
+  /dogs:
+    get:
+      description: list dogs
+`, string(*doc.GetSpecInfo().SpecBytes))
+
+	after, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.NotSame(t, before, after)
+	assert.Equal(t, "2.0.0", after.Model.Info.Version)
+	assert.Equal(t, "list dogs", after.Model.Paths.PathItems.GetOrZero("/dogs").Get.Description)
+	assert.Equal(t, 4, after.Model.Info.GoLow().Version.ValueNode.Line)
+}
+
+func TestDocument_ApplyPatch_JSON(t *testing.T) {
+	spec := []byte(`{
+    "openapi": "3.1.0",
+    "info": {"title": "Pets", "version": "1.0.0"}
+}`)
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	require.NoError(t, doc.ApplyPatch([]byte(`[{"op": "remove", "path": "/info/title"}]`)))
+	assert.Equal(t, datamodel.JSONFileType, doc.GetSpecInfo().SpecFileType)
+	assert.Equal(t, `{
+    "openapi": "3.1.0",
+    "info": {
+        "version": "1.0.0"
+    }
+}`, string(*doc.GetSpecInfo().SpecBytes))
+}
+
+func TestDocument_ApplyPatch_Failed(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+
+	err = doc.ApplyPatch([]byte(`[
+  {"op": "remove", "path": "/info"},
+  {"op": "remove", "path": "/nope"}
+]`))
+	assert.ErrorIs(t, err, patch.ErrPathNotFound)
+
+	// removing the version makes the specification invalid.
+	err = doc.ApplyPatch([]byte(`[{"op": "remove", "path": "/openapi"}]`))
+	assert.Error(t, err)
+
+	err = doc.ApplyPatch([]byte(`{}`))
+	assert.ErrorIs(t, err, patch.ErrInvalidPatch)
+
+	// the document and the cached model are untouched.
+	assert.Equal(t, petstore, *doc.GetSpecInfo().SpecBytes)
+	cached, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Same(t, m, cached)
+}
+
+func TestDocument_ApplyPatch_NoSpec(t *testing.T) {
+	doc := new(document) // not how this should be instantiated.
+	assert.Error(t, doc.ApplyPatch([]byte(`[]`)))
+}
+
+func TestNewDocumentFromReader(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocumentFromReader(bytes.NewReader(petstore), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "3.0.2", doc.GetVersion())
+	assert.Equal(t, petstore, *doc.GetSpecInfo().SpecBytes)
+
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, "Swagger Petstore - OpenAPI 3.0", m.Model.Info.Title)
+}
+
+func TestNewDocumentFromReader_Configuration(t *testing.T) {
+	config := &datamodel.DocumentConfiguration{BypassDocumentCheck: true}
+	doc, err := NewDocumentFromReader(strings.NewReader("openapi: 3.1.0\n  bad: [yaml"), config)
+	require.NoError(t, err)
+	assert.Same(t, config, doc.GetConfiguration())
+
+	_, err = NewDocumentFromReader(strings.NewReader("openapi: 3.1.0\n  bad: [yaml"), nil)
+	assert.Error(t, err)
+}
+
+func TestNewDocumentWithConfiguration_LayeredFS(t *testing.T) {
+	dir := t.TempDir()
+	root := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'
+    Toy:
+      $ref: 'toy.yaml'`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(root), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("type: object\ndescription: disk pet"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toy.yaml"), []byte("type: object\ndescription: disk toy"), 0o644))
+
+	indexConfig := index.CreateOpenAPIIndexConfig()
+	indexConfig.BasePath = dir
+	disk, err := index.NewLocalFSWithConfig(&index.LocalFSConfig{BaseDirectory: dir, IndexConfig: indexConfig})
+	require.NoError(t, err)
+
+	// unsaved editor buffers are layered over the disk.
+	buffers := fstest.MapFS{
+		"pet.yaml": {Data: []byte("type: object\ndescription: unsaved pet"), ModTime: time.Now()},
+	}
+
+	config := datamodel.NewDocumentConfiguration()
+	config.BasePath = dir
+	config.SpecFilePath = "openapi.yaml"
+	config.AllowFileReferences = true
+	config.LocalFS = index.NewLayeredFS(buffers, disk)
+
+	doc, err := NewDocumentWithConfiguration([]byte(root), config)
+	require.NoError(t, err)
+	m, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	assert.Equal(t, "unsaved pet", m.Model.Components.Schemas.GetOrZero("Pet").Schema().Description)
+	assert.Equal(t, "disk toy", m.Model.Components.Schemas.GetOrZero("Toy").Schema().Description)
+}
+
+func TestNewDocumentWithConfiguration_SkipRemoteReferences(t *testing.T) {
+	var fetched atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		fetched.Add(1)
+		_, _ = rw.Write([]byte("Limit:\n  name: limit\n  in: query\nPet:\n  type: object"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	root := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - $ref: '` + server.URL + `/remote.yaml#/Limit'
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  local:
+                    $ref: 'local.yaml#/Local'
+                  remote:
+                    $ref: '` + server.URL + `/remote.yaml#/Pet'`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(root), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "local.yaml"), []byte("Local:\n  type: string"), 0o644))
+
+	config := datamodel.NewDocumentConfiguration()
+	config.BasePath = dir
+	config.SpecFilePath = "openapi.yaml"
+	config.AllowFileReferences = true
+	config.AllowRemoteReferences = true
+	config.SkipRemoteReferences = true
+
+	doc, err := NewDocumentWithConfiguration([]byte(root), config)
+	require.NoError(t, err)
+	m, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	assert.Zero(t, fetched.Load())
+
+	op := m.Model.Paths.PathItems.GetOrZero("/pets").Get
+	require.Len(t, op.Parameters, 1)
+	assert.True(t, op.Parameters[0].GoLow().IsReference())
+	assert.Equal(t, server.URL+"/remote.yaml#/Limit", op.Parameters[0].GoLow().GetReference())
+	assert.Empty(t, op.Parameters[0].Name)
+
+	props := op.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema.Schema().Properties
+	assert.Equal(t, []string{"string"}, props.GetOrZero("local").Schema().Type)
+	remote := props.GetOrZero("remote")
+	assert.True(t, remote.IsReference())
+	assert.Equal(t, server.URL+"/remote.yaml#/Pet", remote.GetReference())
+	require.NotNil(t, remote.Schema())
+	assert.Empty(t, remote.Schema().Type)
+
+	assert.Len(t, m.Index.GetRemotePlaceholders(), 2)
+	assert.True(t, m.Index.IsRemotePlaceholder(server.URL+"/remote.yaml#/Pet"))
+	assert.False(t, m.Index.IsRemotePlaceholder("local.yaml#/Local"))
+
+	rendered, err := m.Model.Render()
+	require.NoError(t, err)
+	assert.Contains(t, string(rendered), "$ref: '"+server.URL+"/remote.yaml#/Limit'")
+}
+
+func TestGeneratePatch(t *testing.T) {
+	original, err := NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: Pets # keep me
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets`))
+	require.NoError(t, err)
+	updated, err := NewDocument([]byte(`{"openapi": "3.1.0", "info": {"title": "Pets", "version": "2.0.0"}}`))
+	require.NoError(t, err)
+
+	p, err := GeneratePatch(original, updated)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"op": "remove", "path": "/paths"},
+  {"op": "replace", "path": "/info/version", "value": "2.0.0"}
+]`, string(p))
+
+	require.NoError(t, original.ApplyPatch(p))
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: Pets # keep me
+  version: 2.0.0
+`, string(*original.GetSpecInfo().SpecBytes))
+
+	_, err = GeneratePatch(original, nil)
+	assert.Error(t, err)
+}
+
+func TestDocument_Clone(t *testing.T) {
+	petstore, _ := os.ReadFile("test_specs/petstorev3.json")
+	doc, err := NewDocument(petstore)
+	require.NoError(t, err)
+	m, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+
+	clone, err := doc.Clone()
+	require.NoError(t, err)
+	cm, errs := clone.BuildV3Model()
+	require.NoError(t, errs)
+
+	assert.NotSame(t, m, cm)
+	assert.NotSame(t, doc.GetSpecInfo().RootNode, clone.GetSpecInfo().RootNode)
+	assert.NotSame(t, doc.GetRolodex(), clone.GetRolodex())
+	assert.NotSame(t, doc.GetConfiguration(), clone.GetConfiguration())
+	assert.Equal(t, m.Model.Info.Title, cm.Model.Info.Title)
+	assert.Len(t, cm.Index.GetAllComponentSchemas(), len(m.Index.GetAllComponentSchemas()))
+
+	// changing the clone does not touch the original.
+	require.NoError(t, clone.ApplyPatch([]byte(`[{"op": "replace", "path": "/info/title", "value": "Clone"}]`)))
+	cm, _ = clone.BuildV3Model()
+	assert.Equal(t, "Clone", cm.Model.Info.Title)
+	assert.Equal(t, "Swagger Petstore - OpenAPI 3.0", m.Model.Info.Title)
+	again, _ := doc.BuildV3Model()
+	assert.Same(t, m, again)
+	assert.Equal(t, string(petstore), string(*doc.GetSpecInfo().SpecBytes))
+
+	// an unbuilt document clones without building anything.
+	unbuilt, _ := NewDocument(petstore)
+	clone, err = unbuilt.Clone()
+	require.NoError(t, err)
+	assert.Nil(t, clone.GetRolodex())
+}
+
+// pathOnlyFS rejects the absolute and backslash separated paths the fs.FS interface does not allow.
+type pathOnlyFS struct {
+	fstest.MapFS
+}
+
+func (p pathOnlyFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	return p.MapFS.Open(name)
+}
+
+func TestDocument_LocalFS_PlainFS(t *testing.T) {
+	v3Spec := `openapi: 3.1.0
+info:
+  title: Embedded
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'models/pet.yaml#/Pet'`
+	v2Spec := `swagger: 2.0
+info:
+  title: Embedded
+  version: 1.0.0
+definitions:
+  Pet:
+    $ref: 'models/pet.yaml#/Pet'`
+	specs := pathOnlyFS{fstest.MapFS{
+		"models/pet.yaml":   {Data: []byte("Pet:\n  type: object\n  properties:\n    owner:\n      $ref: 'owner.yaml#/Owner'")},
+		"models/owner.yaml": {Data: []byte("Owner:\n  type: object\n  properties:\n    name:\n      type: string")},
+	}}
+
+	// no base path is needed, the fs.FS is rooted at the working directory.
+	doc, err := NewDocumentWithConfiguration([]byte(v3Spec), &datamodel.DocumentConfiguration{LocalFS: specs})
+	require.NoError(t, err)
+	v3Model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	pet := v3Model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	owner := pet.Properties.GetOrZero("owner").Schema()
+	assert.Equal(t, "string", owner.Properties.GetOrZero("name").Schema().Type[0])
+
+	doc, err = NewDocumentWithConfiguration([]byte(v2Spec), &datamodel.DocumentConfiguration{LocalFS: specs})
+	require.NoError(t, err)
+	v2Model, err := doc.BuildV2Model()
+	require.NoError(t, err)
+	pet = v2Model.Model.Definitions.Definitions.GetOrZero("Pet").Schema()
+	assert.Equal(t, "object", pet.Properties.GetOrZero("owner").Schema().Type[0])
+
+	// the base path is the root of the fs.FS, references outside of it cannot be resolved.
+	outside := strings.Replace(v3Spec, "models/pet.yaml", "../models/pet.yaml", 1)
+	doc, err = NewDocumentWithConfiguration([]byte(outside), &datamodel.DocumentConfiguration{
+		LocalFS: specs, BasePath: "specs",
+	})
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.Error(t, err)
+}
+
+func TestDocument_ObjectStores(t *testing.T) {
+	objects := map[string]string{
+		"/specs/models/pet.yaml":   "Pet:\n  type: object\n  properties:\n    owner:\n      $ref: 's3://specs/models/owner.yaml#/Owner'",
+		"/specs/models/owner.yaml": "Owner:\n  type: object\n  properties:\n    name:\n      type: string",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, ok := objects[r.URL.Path]
+		if !ok || !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	v3Spec := `openapi: 3.1.0
+info:
+  title: Object Store
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 's3://specs/models/pet.yaml#/Pet'`
+	config := &datamodel.DocumentConfiguration{
+		ObjectStores: map[string]datamodel.ObjectStore{"s3": &index.S3Store{
+			Endpoint:    server.URL,
+			PathStyle:   true,
+			Credentials: index.StaticS3Credentials("key", "secret", ""),
+		}},
+	}
+	doc, err := NewDocumentWithConfiguration([]byte(v3Spec), config)
+	require.NoError(t, err)
+	v3Model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	pet := v3Model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	owner := pet.Properties.GetOrZero("owner").Schema()
+	assert.Equal(t, "string", owner.Properties.GetOrZero("name").Schema().Type[0])
+
+	v2Spec := `swagger: 2.0
+info:
+  title: Object Store
+  version: 1.0.0
+definitions:
+  Pet:
+    $ref: 's3://specs/models/pet.yaml#/Pet'`
+	doc, err = NewDocumentWithConfiguration([]byte(v2Spec), config)
+	require.NoError(t, err)
+	v2Model, err := doc.BuildV2Model()
+	require.NoError(t, err)
+	pet = v2Model.Model.Definitions.Definitions.GetOrZero("Pet").Schema()
+	assert.Equal(t, "object", pet.Properties.GetOrZero("owner").Schema().Type[0])
+}
+
+func TestDocument_GitReferences(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	git := func(dir string, args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=specs", "-c", "user.email=specs@pb33f.io"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	projects := t.TempDir()
+	repo := filepath.Join(projects, "specs.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, "models"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "models", "pet.yaml"),
+		[]byte("Pet:\n  type: object\n  properties:\n    name:\n      type: string"), 0o644))
+	for _, args := range [][]string{{"init", "-q"}, {"add", "."}, {"commit", "-q", "-m", "pet"}, {"tag", "v1.2"}} {
+		git(repo, args...)
+	}
+
+	// only repositories served over https, ssh or git are fetched, so the repository is served by git http-backend.
+	server := httptest.NewTLSServer(&cgi.Handler{
+		Path: filepath.Join(git(projects, "--exec-path"), "git-http-backend"),
+		Env:  []string{"GIT_PROJECT_ROOT=" + projects, "GIT_HTTP_EXPORT_ALL=1"},
+	})
+	defer server.Close()
+	t.Setenv("GIT_SSL_NO_VERIFY", "1")
+
+	spec := `openapi: 3.1.0
+info:
+  title: Git
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: '%s/models/pet.yaml?ref=v1.2#/Pet'`
+	config := &datamodel.DocumentConfiguration{
+		AllowGitReferences: true,
+		GitCacheDir:        t.TempDir(),
+	}
+	doc, err := NewDocumentWithConfiguration([]byte(fmt.Sprintf(spec, "git+"+server.URL+"/specs.git")), config)
+	require.NoError(t, err)
+	v3Model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	pet := v3Model.Model.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.Equal(t, "string", pet.Properties.GetOrZero("name").Schema().Type[0])
+
+	// repositories on the file system can't be reached by a document.
+	doc, err = NewDocumentWithConfiguration([]byte(fmt.Sprintf(spec, "git+file://"+filepath.ToSlash(repo))), config)
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	assert.Error(t, err)
+}
+
+func TestDocument_IndexingProgressHandler(t *testing.T) {
+	tmp := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "pet.yaml"), []byte("Pet:\n  type: object"), 0o644))
+	spec := `openapi: 3.1.0
+info:
+  title: Progress
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'`
+
+	var mu sync.Mutex
+	var last *datamodel.IndexingProgress
+	doc, err := NewDocumentWithConfiguration([]byte(spec), &datamodel.DocumentConfiguration{
+		BasePath:            tmp,
+		AllowFileReferences: true,
+		IndexingProgressHandler: func(progress *datamodel.IndexingProgress) {
+			mu.Lock()
+			last = progress
+			mu.Unlock()
+		},
+	})
+	require.NoError(t, err)
+	_, err = doc.BuildV3Model()
+	require.NoError(t, err)
+	require.NotNil(t, last)
+	assert.Equal(t, 2, last.FilesDiscovered)
+	assert.Equal(t, 2, last.FilesIndexed)
+	assert.Equal(t, 1, last.ReferencesResolved)
+}
@@ -0,0 +1,30 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NewEmptyDocument creates a new Document from nothing, for building a specification programmatically, rather than
+// parsing one. The version is either '2.0' for a Swagger document, or a 3.x version like '3.1.0' for an OpenAPI
+// document.
+//
+// The document only contains the fields required by the specification (the version, an empty info object and empty
+// paths), once a model has been built, it can be filled in using the high-level model (see the builder methods on
+// v3.Document, like AddOperation and AddSchema) and rendered using Render or RenderAndReload.
+func NewEmptyDocument(version string) (Document, error) {
+	var spec string
+	switch {
+	case version == "2.0":
+		spec = "swagger: \"2.0\"\ninfo:\n  title: \"\"\n  version: \"\"\npaths: {}\n"
+	case strings.HasPrefix(version, "3.") && strings.Count(version, ".") == 2:
+		spec = fmt.Sprintf("openapi: %s\ninfo:\n  title: \"\"\n  version: \"\"\npaths: {}\n", version)
+	default:
+		return nil, fmt.Errorf("unable to create an empty document, version '%s' is not supported, "+
+			"use '2.0' or a 3.x version, like '3.1.0'", version)
+	}
+	return NewDocument([]byte(spec))
+}
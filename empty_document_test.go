@@ -0,0 +1,84 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEmptyDocument(t *testing.T) {
+	doc, err := NewEmptyDocument("3.1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "3.1.0", doc.GetVersion())
+
+	m, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	api := &m.Model
+	api.SetInfo("Pets", "1.0.0")
+	api.AddServer("https://api.example.com", "production")
+	api.AddTag("pets", "everything about pets")
+	api.AddTag("pets", "all about pets")
+	api.AddSchema("Pet", &base.Schema{
+		Type:       []string{"object"},
+		Properties: orderedmap.ToOrderedMap(map[string]*base.SchemaProxy{"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})}),
+	})
+
+	responses := &v3high.Responses{Codes: orderedmap.New[string, *v3high.Response]()}
+	responses.Codes.Set("200", &v3high.Response{
+		Description: "a pet",
+		Content: orderedmap.ToOrderedMap(map[string]*v3high.MediaType{
+			"application/json": {Schema: v3high.ComponentSchemaRef("Pet")},
+		}),
+	})
+	api.AddOperation("/pets/{id}", "GET", &v3high.Operation{OperationId: "getPet", Tags: []string{"pets"}, Responses: responses})
+	api.AddOperation("/pets/{id}", "copy", &v3high.Operation{OperationId: "copyPet"})
+
+	rendered, err := doc.Render()
+	assert.Contains(t, string(rendered), "additionalOperations:\n      copy:\n        operationId: copyPet")
+	require.NoError(t, err)
+
+	reloaded, err := NewDocument(rendered)
+	require.NoError(t, err)
+	rm, err := reloaded.BuildV3Model()
+	require.NoError(t, err)
+
+	assert.Equal(t, "Pets", rm.Model.Info.Title)
+	assert.Equal(t, "1.0.0", rm.Model.Info.Version)
+	assert.Equal(t, "https://api.example.com", rm.Model.Servers[0].URL)
+	require.Len(t, rm.Model.Tags, 1)
+	assert.Equal(t, "all about pets", rm.Model.Tags[0].Description)
+
+	pathItem := rm.Model.Paths.PathItems.GetOrZero("/pets/{id}")
+	require.NotNil(t, pathItem)
+	assert.Equal(t, "getPet", pathItem.Get.OperationId)
+	require.NotNil(t, pathItem.AdditionalOperations)
+	assert.Equal(t, "copyPet", pathItem.AdditionalOperations.GetOrZero("copy").OperationId)
+
+	schema := pathItem.Get.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pet", schema.GetReference())
+	assert.Equal(t, []string{"object"}, schema.Schema().Type)
+}
+
+func TestNewEmptyDocument_Swagger(t *testing.T) {
+	doc, err := NewEmptyDocument("2.0")
+	require.NoError(t, err)
+	m, err := doc.BuildV2Model()
+	require.NoError(t, err)
+	assert.Equal(t, "2.0", m.Model.Swagger)
+	assert.NotNil(t, m.Model.Paths)
+}
+
+func TestNewEmptyDocument_BadVersion(t *testing.T) {
+	_, err := NewEmptyDocument("4")
+	assert.ErrorContains(t, err, "version '4' is not supported")
+	_, err = NewEmptyDocument("3.1")
+	assert.Error(t, err)
+}
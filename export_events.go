@@ -0,0 +1,280 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"encoding/json"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/index"
+	"go.yaml.in/yaml/v4"
+)
+
+// ModelEvent is a single object of a model, as emitted by DocumentModel.ExportEvents.
+type ModelEvent struct {
+	// Type is the name of the type of object, for example 'Operation', 'Parameter' or 'Schema'.
+	Type string `json:"type"`
+
+	// Path is the JSON path of the object in the document, for example "$.paths['/pets'].get".
+	Path string `json:"path"`
+
+	// Reference is the $ref of the object, if it is a reference. References are not walked, the object they point
+	// to has its own event wherever it is defined (for example, under components).
+	Reference string `json:"$ref,omitempty"`
+
+	// Fields holds the key fields of the object that are set, such as 'operationId', 'name', 'in' or 'type'.
+	Fields map[string]any `json:"fields,omitempty"`
+
+	// Location is where the object was defined, it is nil for objects that were not read from a document.
+	Location *ModelEventLocation `json:"location,omitempty"`
+}
+
+// ModelEventLocation is the source location of a ModelEvent.
+type ModelEventLocation struct {
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// keyEventFields are the fields (by their YAML name) that are added to the Fields of a ModelEvent when they are set.
+var keyEventFields = map[string]struct{}{
+	"openapi": {}, "swagger": {}, "operationId": {}, "name": {}, "in": {}, "title": {}, "version": {},
+	"type": {}, "format": {}, "url": {}, "required": {}, "deprecated": {}, "tags": {}, "summary": {},
+	"scheme": {}, "style": {}, "propertyName": {},
+}
+
+// eventContainerFields are map fields that are not rendered as a key of their own, their entries belong directly to
+// the parent object (for example, the path items of 'paths', or the status codes of 'responses').
+var eventContainerFields = map[string]struct{}{
+	"PathItems": {}, "Codes": {}, "Expression": {}, "Definitions": {}, "Values": {},
+}
+
+var plainPathSegment = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+
+// ExportEvents walks the model, writing an event for every object found (operations, parameters, schemas, responses
+// and so on) to the writer, as JSON Lines (one JSON encoded ModelEvent per line). Events are written in the
+// order the objects appear in the model, parents before children.
+//
+// This allows tools that are not written in Go to consume the view of a document built by libopenapi, without
+// having to bind to the Go API. Only the key fields of each object are exported, consumers that need the full
+// content of an object can use the path and location to find it in the source document.
+func (d *DocumentModel[T]) ExportEvents(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	e := &eventExporter{encoder: encoder, index: d.Index}
+	return e.walk(reflect.ValueOf(&d.Model), "$")
+}
+
+type eventExporter struct {
+	encoder *json.Encoder
+	index   *index.SpecIndex
+}
+
+func (e *eventExporter) walk(v reflect.Value, path string) error {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+	}
+
+	switch obj := v.Interface().(type) {
+	case *base.SchemaProxy:
+		if obj.IsReference() {
+			return e.emit("Schema", path, obj.GetReference(), nil, e.location(obj.GetReferenceNode()))
+		}
+		if schema := obj.Schema(); schema != nil {
+			return e.walk(reflect.ValueOf(schema), path)
+		}
+		return nil
+	case *yaml.Node:
+		return nil
+	}
+
+	// dynamic values hold one of two values, only the one that is set is walked.
+	if dv, ok := v.Interface().(interface{ IsA() bool }); ok && v.Kind() == reflect.Pointer {
+		if dv.IsA() {
+			return e.walk(v.Elem().FieldByName("A"), path)
+		}
+		return e.walk(v.Elem().FieldByName("B"), path)
+	}
+
+	// ordered maps, keyed by names.
+	if m := v.MethodByName("FromOldest"); m.IsValid() && v.Kind() == reflect.Pointer {
+		for key, value := range m.Call(nil)[0].Seq2() {
+			if err := e.walk(value, appendEventPath(path, key.String())); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := e.walk(v.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Pointer:
+		if v.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	// only high-level model objects (which are backed by a low-level object) are events.
+	goLow := v.MethodByName("GoLow")
+	if !goLow.IsValid() || goLow.Type().NumIn() != 0 || goLow.Type().NumOut() != 1 {
+		return nil
+	}
+	reference := ""
+	if r, ok := v.Interface().(interface{ IsReference() bool }); ok && r.IsReference() {
+		if g, ok := v.Interface().(interface{ GetReference() string }); ok {
+			reference = g.GetReference()
+		}
+	}
+
+	elem := v.Elem()
+	fields := make(map[string]any)
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := eventFieldName(field)
+		if name == "" {
+			continue
+		}
+		if _, ok := keyEventFields[name]; ok {
+			if value, ok := eventScalar(elem.Field(i)); ok {
+				fields[name] = value
+			}
+		}
+	}
+	if err := e.emit(elem.Type().Name(), path, reference, fields, e.location(lowNode(goLow.Call(nil)[0].Interface()))); err != nil {
+		return err
+	}
+	if reference != "" {
+		return nil
+	}
+
+	for i := 0; i < elem.NumField(); i++ {
+		field := elem.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		childPath := path
+		if _, ok := eventContainerFields[field.Name]; !ok || !elem.Field(i).MethodByName("FromOldest").IsValid() {
+			name := eventFieldName(field)
+			if name == "" {
+				continue
+			}
+			childPath = appendEventPath(path, name)
+		}
+		if err := e.walk(elem.Field(i), childPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *eventExporter) emit(typeName, path, reference string, fields map[string]any, location *ModelEventLocation) error {
+	event := &ModelEvent{Type: typeName, Path: path, Reference: reference, Location: location}
+	if len(fields) > 0 {
+		event.Fields = fields
+	}
+	return e.encoder.Encode(event)
+}
+
+// lowNode returns the node a low-level object was built from.
+func lowNode(low any) *yaml.Node {
+	if low == nil || (reflect.ValueOf(low).Kind() == reflect.Pointer && reflect.ValueOf(low).IsNil()) {
+		return nil
+	}
+	if r, ok := low.(interface{ GetRootNode() *yaml.Node }); ok && r.GetRootNode() != nil {
+		return r.GetRootNode()
+	}
+	if k, ok := low.(interface{ GetKeyNode() *yaml.Node }); ok {
+		return k.GetKeyNode()
+	}
+	return nil
+}
+
+// location finds where a node was defined, using the rolodex to find the file it came from.
+func (e *eventExporter) location(node *yaml.Node) *ModelEventLocation {
+	if node == nil || node.Line == 0 {
+		return nil
+	}
+	loc := &ModelEventLocation{Line: node.Line, Column: node.Column}
+	if e.index != nil {
+		loc.File = e.index.GetSpecAbsolutePath()
+		var origin *index.NodeOrigin
+		if rolodex := e.index.GetRolodex(); rolodex != nil {
+			origin = rolodex.FindNodeOrigin(node)
+		} else {
+			origin = e.index.FindNodeOrigin(node)
+		}
+		if origin != nil && origin.AbsoluteLocation != "" {
+			loc.File = origin.AbsoluteLocation
+		}
+	}
+	return loc
+}
+
+// eventFieldName returns the name of a field in the document, using its YAML tag, or an empty string if the field
+// is not part of the document.
+func eventFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("yaml")
+	if tag == "-" {
+		return ""
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name
+	}
+	if field.Name == "Extensions" {
+		return ""
+	}
+	return strings.ToLower(field.Name[:1]) + field.Name[1:]
+}
+
+// eventScalar returns the value of a scalar field (or a slice of strings), and false if the field is not set.
+func eventScalar(v reflect.Value) (any, bool) {
+	ptr := v.Kind() == reflect.Pointer
+	if ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.String() != ""
+	case reflect.Bool:
+		// a bool that is not a pointer cannot be unset, so it is only reported when true.
+		return v.Bool(), v.Bool() || ptr
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String && v.Len() > 0 {
+			return v.Interface(), true
+		}
+	}
+	return nil, false
+}
+
+// appendEventPath adds a key to a JSON path, using bracket notation for keys that are not plain names.
+func appendEventPath(path, key string) string {
+	if plainPathSegment.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "['" + strings.ReplaceAll(key, "'", "\\'") + "']"
+}
@@ -0,0 +1,149 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportEvents(t *testing.T, export func(buf *bytes.Buffer) error) []*ModelEvent {
+	var buf bytes.Buffer
+	require.NoError(t, export(&buf))
+	var events []*ModelEvent
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var event ModelEvent
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, &event)
+	}
+	return events
+}
+
+func findEvent(events []*ModelEvent, path string) *ModelEvent {
+	for _, e := range events {
+		if e.Path == path {
+			return e
+		}
+	}
+	return nil
+}
+
+func TestDocumentModel_ExportEvents(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      tags: [pets]
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+
+	events := exportEvents(t, func(buf *bytes.Buffer) error { return model.ExportEvents(buf) })
+
+	assert.Equal(t, "Document", events[0].Type)
+	assert.Equal(t, "$", events[0].Path)
+	assert.Equal(t, "3.1.0", events[0].Fields["openapi"])
+
+	info := findEvent(events, "$.info")
+	require.NotNil(t, info)
+	assert.Equal(t, "Info", info.Type)
+	assert.Equal(t, "Pets", info.Fields["title"])
+	assert.Equal(t, 3, info.Location.Line)
+
+	op := findEvent(events, "$.paths['/pets/{petId}'].get")
+	require.NotNil(t, op)
+	assert.Equal(t, "Operation", op.Type)
+	assert.Equal(t, "getPet", op.Fields["operationId"])
+	assert.Equal(t, []any{"pets"}, op.Fields["tags"])
+	assert.Equal(t, 8, op.Location.Line)
+
+	param := findEvent(events, "$.paths['/pets/{petId}'].get.parameters[0]")
+	require.NotNil(t, param)
+	assert.Equal(t, "Parameter", param.Type)
+	assert.Equal(t, "petId", param.Fields["name"])
+	assert.Equal(t, "path", param.Fields["in"])
+	assert.Equal(t, true, param.Fields["required"])
+	assert.NotContains(t, param.Fields, "deprecated")
+
+	ref := findEvent(events, "$.paths['/pets/{petId}'].get.responses['200'].content['application/json'].schema")
+	require.NotNil(t, ref)
+	assert.Equal(t, "Schema", ref.Type)
+	assert.Equal(t, "#/components/schemas/Pet", ref.Reference)
+	assert.Equal(t, 22, ref.Location.Line)
+	assert.Nil(t, findEvent(events, "$.paths['/pets/{petId}'].get.responses['200'].content['application/json'].schema.properties.name"))
+
+	name := findEvent(events, "$.components.schemas.Pet.properties.name")
+	require.NotNil(t, name)
+	assert.Equal(t, []any{"string"}, name.Fields["type"])
+	assert.Equal(t, 29, name.Location.Line)
+}
+
+func TestDocumentModel_ExportEvents_Swagger(t *testing.T) {
+	spec := `swagger: "2.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok`
+
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+	model, err := doc.BuildV2Model()
+	require.NoError(t, err)
+
+	events := exportEvents(t, func(buf *bytes.Buffer) error { return model.ExportEvents(buf) })
+	op := findEvent(events, "$.paths['/pets'].get")
+	require.NotNil(t, op)
+	assert.Equal(t, "listPets", op.Fields["operationId"])
+	assert.NotNil(t, findEvent(events, "$.paths['/pets'].get.responses['200']"))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("pipe closed")
+}
+
+func TestDocumentModel_ExportEvents_WriteError(t *testing.T) {
+	doc, err := NewDocument([]byte("openapi: 3.1.0\ninfo:\n  title: t"))
+	require.NoError(t, err)
+	model, err := doc.BuildV3Model()
+	require.NoError(t, err)
+	assert.EqualError(t, model.ExportEvents(failingWriter{}), "pipe closed")
+}
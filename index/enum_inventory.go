@@ -0,0 +1,276 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// EnumUsage is a single place an enum (or const) is used in a document.
+type EnumUsage struct {
+	// Const is true if the usage is a 'const' keyword, rather than an 'enum' keyword.
+	Const bool
+
+	// Name is the name of the schema (or property) that owns the enum, for example the name of the property the
+	// enum belongs to, or the name of the component. It is empty if the owner has no name, for example the items
+	// of an array.
+	Name string
+
+	// JSONPointer is the location of the enum (or const) keyword in the document, for example
+	// '#/components/schemas/Pet/properties/status/enum'.
+	JSONPointer string
+
+	// Path is the JSON Path of the enum (or const) keyword, for example
+	// "$.components.schemas['Pet'].properties['status']['enum']"
+	Path string
+
+	// Types are the types declared by the owning schema, if the owner does not declare a type, then the types are
+	// taken from the values.
+	Types []string
+
+	// KeyNode is the 'enum' or 'const' key.
+	KeyNode *yaml.Node
+
+	// Node is the value of the keyword, a sequence for an enum, any node for a const.
+	Node *yaml.Node
+
+	// SchemaNode is the schema (or server variable) that owns the enum.
+	SchemaNode *yaml.Node
+
+	// Index is the index of the file the enum was found in.
+	Index *SpecIndex
+}
+
+// EnumValueSet is a set of values used by one or more enums (or consts). Every usage has exactly the same values,
+// ignoring order.
+type EnumValueSet struct {
+	// Values are the values of the set, in the order of the first usage.
+	Values []*yaml.Node
+
+	// Types are the types used by every usage of the set, in the order they were first found.
+	Types []string
+
+	// Usages are all the places the set is used, in document order.
+	Usages []*EnumUsage
+
+	hashes []uint64
+}
+
+// Shared returns true if the value set is used by more than one enum (or const).
+func (e *EnumValueSet) Shared() bool {
+	return len(e.Usages) > 1
+}
+
+// GetEnumInventory returns every enum and const found in the document of this index, grouped into sets of
+// identical values. See BuildEnumInventory.
+func (index *SpecIndex) GetEnumInventory() []*EnumValueSet {
+	return BuildEnumInventory(index)
+}
+
+// BuildEnumInventory returns every enum and const found in the documents of the supplied indexes, grouped into sets
+// of identical values, so enums that can be shared can be found. A const is treated as an enum with a single value.
+// To build an inventory of a multi-file document, supply every index in the rolodex.
+//
+// Two enums have the same values if they contain the same values, regardless of the order they are written in.
+// Values are compared using their content, so 1 and "1" are different values. Value sets are returned in the
+// order they were first found.
+//
+// Enums are found in schemas (and server variables) anywhere in the document, including inside components,
+// operations and external files. Examples, defaults and extensions are not searched.
+func BuildEnumInventory(indexes ...*SpecIndex) []*EnumValueSet {
+	var sets []*EnumValueSet
+	for _, idx := range indexes {
+		if idx == nil || idx.GetRootNode() == nil {
+			continue
+		}
+		root := idx.GetRootNode()
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		var usages []*EnumUsage
+		collectEnumUsages(idx, root, nil, "", false, make(map[*yaml.Node]struct{}), &usages)
+		for _, usage := range usages {
+			sets = addEnumUsage(sets, usage)
+		}
+	}
+	return sets
+}
+
+// enumNameMapKeys are the keys that hold a map of names to schemas, rather than a schema.
+var enumNameMapKeys = map[string]struct{}{
+	"properties":        {},
+	"patternProperties": {},
+	"dependentSchemas":  {},
+	"$defs":             {},
+	"definitions":       {},
+	"schemas":           {},
+	"parameters":        {},
+	"headers":           {},
+	"variables":         {},
+}
+
+// enumSkippedKeys hold values that are never schemas.
+var enumSkippedKeys = map[string]struct{}{
+	"example":  {},
+	"examples": {},
+	"default":  {},
+	"enum":     {},
+	"const":    {},
+}
+
+func collectEnumUsages(idx *SpecIndex, node *yaml.Node, path []string, name string, nameMap bool,
+	seen map[*yaml.Node]struct{}, usages *[]*EnumUsage,
+) {
+	if node == nil {
+		return
+	}
+	if _, ok := seen[node]; ok {
+		return
+	}
+	seen[node] = struct{}{}
+
+	switch node.Kind {
+	case yaml.SequenceNode:
+		for i, n := range node.Content {
+			collectEnumUsages(idx, n, append(path[:len(path):len(path)], strconv.Itoa(i)), "", false, seen, usages)
+		}
+	case yaml.MappingNode:
+		if !nameMap {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				if key != "enum" && key != "const" {
+					continue
+				}
+				if key == "enum" && node.Content[i+1].Kind != yaml.SequenceNode {
+					continue
+				}
+				pointer := buildEnumPointer(append(path[:len(path):len(path)], key))
+				_, jsonPath := utils.ConvertComponentIdIntoFriendlyPathSearch(pointer)
+				*usages = append(*usages, &EnumUsage{
+					Const:       key == "const",
+					Name:        name,
+					JSONPointer: pointer,
+					Path:        jsonPath,
+					Types:       enumTypes(node, node.Content[i+1], key == "const"),
+					KeyNode:     node.Content[i],
+					Node:        node.Content[i+1],
+					SchemaNode:  node,
+					Index:       idx,
+				})
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if !nameMap {
+				if _, ok := enumSkippedKeys[key]; ok {
+					continue
+				}
+				if strings.HasPrefix(key, "x-") {
+					continue
+				}
+			}
+			childName := name
+			if nameMap {
+				childName = key
+			}
+			_, childNameMap := enumNameMapKeys[key]
+			collectEnumUsages(idx, node.Content[i+1], append(path[:len(path):len(path)], key), childName,
+				childNameMap && !nameMap, seen, usages)
+		}
+	}
+}
+
+func buildEnumPointer(path []string) string {
+	var b strings.Builder
+	b.WriteString("#")
+	for _, segment := range path {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// enumTypes returns the types declared by the schema, or the types of the values if there is no declared type.
+func enumTypes(schema, value *yaml.Node, isConst bool) []string {
+	var types []string
+	if _, typeNode := utils.FindKeyNodeTop("type", schema.Content); typeNode != nil {
+		if typeNode.Kind == yaml.SequenceNode {
+			for _, t := range typeNode.Content {
+				types = append(types, t.Value)
+			}
+		} else if typeNode.Value != "" {
+			types = append(types, typeNode.Value)
+		}
+		return types
+	}
+	values := value.Content
+	if isConst {
+		values = []*yaml.Node{value}
+	}
+	for _, v := range values {
+		t := enumValueType(v)
+		if !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func enumValueType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func addEnumUsage(sets []*EnumValueSet, usage *EnumUsage) []*EnumValueSet {
+	values := usage.Node.Content
+	if usage.Const {
+		values = []*yaml.Node{usage.Node}
+	}
+	hashes := make([]uint64, 0, len(values))
+	for _, v := range values {
+		h := utils.HashYAMLNode(v)
+		if !slices.Contains(hashes, h) {
+			hashes = append(hashes, h)
+		}
+	}
+	slices.Sort(hashes)
+
+	for _, set := range sets {
+		if slices.Equal(set.hashes, hashes) {
+			set.Usages = append(set.Usages, usage)
+			for _, t := range usage.Types {
+				if !slices.Contains(set.Types, t) {
+					set.Types = append(set.Types, t)
+				}
+			}
+			return sets
+		}
+	}
+	return append(sets, &EnumValueSet{
+		Values: values,
+		Types:  slices.Clone(usage.Types),
+		Usages: []*EnumUsage{usage},
+		hashes: hashes,
+	})
+}
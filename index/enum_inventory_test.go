@@ -0,0 +1,115 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestSpecIndex_GetEnumInventory(t *testing.T) {
+	yml := `openapi: 3.1.0
+servers:
+  - url: https://{env}.example.com
+    variables:
+      env:
+        default: prod
+        enum: [prod, dev]
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: status
+          in: query
+          schema:
+            type: string
+            enum: [sold, available]
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              example:
+                enum: [not, an, enum]
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      x-enum:
+        enum: [ignored]
+      properties:
+        enum:
+          type: string
+        status:
+          type: string
+          enum: [available, sold]
+        kind:
+          const: pet
+        size:
+          type: [integer, "null"]
+          enum: [1, 2, null]
+        code:
+          enum: ["1", "2"]`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	inventory := idx.GetEnumInventory()
+	require.Len(t, inventory, 5)
+
+	env := inventory[0]
+	assert.False(t, env.Shared())
+	assert.Equal(t, "env", env.Usages[0].Name)
+	assert.Equal(t, []string{"string"}, env.Types)
+
+	status := inventory[1]
+	assert.True(t, status.Shared())
+	require.Len(t, status.Values, 2)
+	assert.Equal(t, "sold", status.Values[0].Value)
+	assert.Equal(t, []string{"string"}, status.Types)
+	assert.Equal(t, "#/paths/~1pets/get/parameters/0/schema/enum", status.Usages[0].JSONPointer)
+	assert.Equal(t, "status", status.Usages[1].Name)
+	assert.Equal(t, "#/components/schemas/Pet/properties/status/enum", status.Usages[1].JSONPointer)
+	assert.Equal(t, "$.components.schemas['Pet'].properties['status']['enum']", status.Usages[1].Path)
+	assert.Equal(t, 37, status.Usages[1].KeyNode.Line)
+	assert.Equal(t, idx, status.Usages[1].Index)
+
+	kind := inventory[2]
+	assert.True(t, kind.Usages[0].Const)
+	assert.Equal(t, "kind", kind.Usages[0].Name)
+	assert.Equal(t, []string{"string"}, kind.Types)
+	require.Len(t, kind.Values, 1)
+	assert.Equal(t, "pet", kind.Values[0].Value)
+
+	size := inventory[3]
+	assert.Equal(t, []string{"integer", "null"}, size.Types)
+	assert.Len(t, size.Values, 3)
+
+	// values are compared by content, so strings and integers are different values.
+	code := inventory[4]
+	assert.Equal(t, "code", code.Usages[0].Name)
+	assert.Equal(t, []string{"string"}, code.Types)
+}
+
+func TestBuildEnumInventory_MultipleIndexes(t *testing.T) {
+	var a, b yaml.Node
+	_ = yaml.Unmarshal([]byte("Color:\n  type: string\n  enum: [red, green]"), &a)
+	_ = yaml.Unmarshal([]byte("Colour:\n  enum: [green, red]"), &b)
+
+	idxA := NewSpecIndexWithConfig(&a, CreateOpenAPIIndexConfig())
+	idxB := NewSpecIndexWithConfig(&b, CreateOpenAPIIndexConfig())
+
+	inventory := BuildEnumInventory(idxA, nil, idxB)
+	require.Len(t, inventory, 1)
+	assert.Len(t, inventory[0].Usages, 2)
+	assert.Equal(t, idxB, inventory[0].Usages[1].Index)
+	assert.Equal(t, []string{"string"}, inventory[0].Types)
+	assert.Empty(t, BuildEnumInventory())
+}
@@ -17,6 +17,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
 	"golang.org/x/sync/singleflight"
@@ -297,6 +298,10 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 					if utils.IsNodeArray(node) {
 						continue
 					}
+					// payload files used as examples (text, for example) are not documents, and cannot be indexed.
+					if isExamplePayloadRef(seenPath, node.Content[i+1].Value) {
+						continue
+					}
 				}
 
 				index.linesWithRefs[n.Line] = true
@@ -319,7 +324,7 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 
 					// determine absolute path to this definition
 					var defRoot string
-					if strings.HasPrefix(index.specAbsolutePath, "http") {
+					if isURLLocation(index.specAbsolutePath) {
 						defRoot = index.specAbsolutePath
 					} else {
 						defRoot = filepath.Dir(index.specAbsolutePath)
@@ -333,7 +338,7 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 							fullDefinitionPath = fmt.Sprintf("%s#/%s", index.specAbsolutePath, uri[1])
 							componentName = value
 						} else {
-							if strings.HasPrefix(uri[0], "http") {
+							if isURLLocation(uri[0]) {
 								fullDefinitionPath = value
 								componentName = fmt.Sprintf("#/%s", uri[1])
 							} else {
@@ -353,7 +358,7 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 										// if the index has a base URL, use that to resolve the path.
 										if index.config.BaseURL != nil && !filepath.IsAbs(defRoot) {
 											var u url.URL
-											if strings.HasPrefix(defRoot, "http") {
+											if isURLLocation(defRoot) {
 												up, _ := url.Parse(defRoot)
 												up.Path = utils.ReplaceWindowsDriveWithLinuxPath(filepath.Dir(up.Path))
 												u = *up
@@ -377,12 +382,12 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 							}
 						}
 					} else {
-						if strings.HasPrefix(uri[0], "http") {
+						if isURLLocation(uri[0]) {
 							fullDefinitionPath = value
 						} else {
 							// is it a relative file include?
 							if !strings.Contains(uri[0], "#") {
-								if strings.HasPrefix(defRoot, "http") {
+								if isURLLocation(defRoot) {
 									if !filepath.IsAbs(uri[0]) {
 										u, _ := url.Parse(defRoot)
 										pathDir := filepath.Dir(u.Path)
@@ -594,7 +599,8 @@ func (index *SpecIndex) ExtractRefs(ctx context.Context, node, parent *yaml.Node
 								"base", baseUri,
 								"definitionPath", definitionPath,
 								"error", resolveErr.Error(),
-								"line", idNode.Line)
+								datamodel.LogKeyFile, index.specAbsolutePath,
+								datamodel.LogKeyLine, idNode.Line)
 						}
 						resolvedUri = idValue // Use original as fallback
 					}
@@ -838,6 +844,9 @@ func (index *SpecIndex) ExtractComponentsFromRefs(ctx context.Context, refs []*R
 	}
 
 	refsToCheck := refs
+	if index.config != nil && index.config.SkipRemoteReferences {
+		refsToCheck = index.skipRemoteReferences(refs)
+	}
 	mappedRefsInSequence := make([]*ReferenceMapped, len(refsToCheck))
 
 	// Sequential mode: process refs one at a time (used for bundling)
@@ -998,6 +1007,7 @@ func (index *SpecIndex) ExtractComponentsFromRefs(ctx context.Context, refs []*R
 		}
 	}
 
+	index.rolodex.referencesResolved(index.specAbsolutePath, len(found))
 	return found
 }
 
@@ -1037,3 +1047,60 @@ func (index *SpecIndex) locateRef(ctx context.Context, ref *Reference) *Referenc
 
 	return located
 }
+
+// skipRemoteReferences removes every remote reference from refs, recording each one as a placeholder, so it is
+// never looked up.
+func (index *SpecIndex) skipRemoteReferences(refs []*Reference) []*Reference {
+	local := make([]*Reference, 0, len(refs))
+	for _, ref := range refs {
+		if !IsRemoteReference(ref.FullDefinition) {
+			local = append(local, ref)
+			continue
+		}
+		index.refLock.Lock()
+		if !slices.ContainsFunc(index.remotePlaceholders, func(r *Reference) bool { return r.Node == ref.Node }) {
+			index.remotePlaceholders = append(index.remotePlaceholders, ref)
+		}
+		index.refLock.Unlock()
+	}
+	return local
+}
+
+// IsRemoteReference returns true if a (fully qualified) reference points to a remote location, a URL like
+// 'https://example.com/pet.yaml', or 's3://bucket/pet.yaml'.
+func IsRemoteReference(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://") || hasURLScheme(ref)
+}
+
+// isURLLocation returns true if a location is a URL, rather than a file path. Locations that start with 'http' are
+// always URLs, as are locations with any other scheme (like 's3://bucket/pet.yaml').
+func isURLLocation(location string) bool {
+	return strings.HasPrefix(location, "http") || hasURLScheme(location)
+}
+
+// hasURLScheme returns true if a location starts with a URL scheme, followed by '://'. Single letter schemes are
+// Windows drives, not schemes.
+func hasURLScheme(location string) bool {
+	i := strings.Index(location, "://")
+	if i < 2 {
+		return false
+	}
+	for j, c := range location[:i] {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case j > 0 && (c >= '0' && c <= '9' || c == '+' || c == '-' || c == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// isExamplePayloadRef returns true if a reference found under an example (or examples) points to a whole file that
+// is not a document the rolodex can read (for example a text payload), so it cannot be indexed.
+func isExamplePayloadRef(seenPath []string, ref string) bool {
+	if strings.Contains(ref, "#") || ExtractFileType(ref) != UNSUPPORTED {
+		return false
+	}
+	return slices.Contains(seenPath, "example") || slices.Contains(seenPath, "examples")
+}
@@ -707,3 +707,70 @@ components:
 	assert.NotNil(t, idx)
 	assert.Greater(t, len(idx.GetAllReferences()), 0)
 }
+
+func TestSpecIndex_ExtractRefs_SkipRemoteReferences(t *testing.T) {
+	yml := `openapi: 3.1.0
+components:
+  schemas:
+    Local:
+      type: string
+    Pet:
+      properties:
+        local:
+          $ref: '#/components/schemas/Local'
+        remote:
+          $ref: 'https://example.com/schemas.yaml#/Remote'
+        again:
+          $ref: 'https://example.com/schemas.yaml#/Remote'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+
+	config := CreateOpenAPIIndexConfig()
+	config.AllowRemoteLookup = true
+	config.SkipRemoteReferences = true
+	idx := NewSpecIndexWithConfig(&rootNode, config)
+
+	assert.Empty(t, idx.GetReferenceIndexErrors())
+	assert.Len(t, idx.GetRemotePlaceholders(), 1)
+	assert.True(t, idx.IsRemotePlaceholder("https://example.com/schemas.yaml#/Remote"))
+	assert.False(t, idx.IsRemotePlaceholder("#/components/schemas/Local"))
+
+	assert.True(t, IsRemoteReference("http://example.com/a.yaml"))
+	assert.True(t, IsRemoteReference("https://example.com/a.yaml#/A"))
+	assert.False(t, IsRemoteReference("a.yaml#/A"))
+	assert.False(t, IsRemoteReference("#/components/schemas/A"))
+}
+
+func TestSpecIndex_ExtractRefs_ExamplePayloadFiles(t *testing.T) {
+	yml := `openapi: 3.1.0
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          text/plain:
+            example:
+              $ref: 'examples/pet.txt'
+            examples:
+              csv:
+                value:
+                  $ref: 'examples/pets.csv'
+      responses:
+        '200':
+          $ref: 'missing.txt'`
+
+	var rootNode yaml.Node
+	_ = yaml.Unmarshal([]byte(yml), &rootNode)
+	idx := NewSpecIndexWithConfig(&rootNode, CreateOpenAPIIndexConfig())
+
+	// only the reference outside the examples is an error.
+	if assert.Len(t, idx.GetReferenceIndexErrors(), 1) {
+		assert.Contains(t, idx.GetReferenceIndexErrors()[0].Error(), "missing.txt")
+	}
+
+	assert.True(t, isExamplePayloadRef([]string{"paths", "example"}, "examples/pet.txt"))
+	assert.False(t, isExamplePayloadRef([]string{"paths", "example"}, "examples/pet.json"))
+	assert.False(t, isExamplePayloadRef([]string{"paths", "example"}, "examples/pet.txt#/a"))
+	assert.False(t, isExamplePayloadRef([]string{"paths", "schema"}, "examples/pet.txt"))
+}
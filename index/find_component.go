@@ -14,6 +14,7 @@ import (
 	jsonpathconfig "github.com/pb33f/jsonpath/pkg/jsonpath/config"
 
 	"github.com/pb33f/jsonpath/pkg/jsonpath"
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
 )
@@ -140,13 +141,16 @@ func (index *SpecIndex) lookupRolodex(ctx context.Context, uri []string) *Refere
 
 	if len(uri) > 0 {
 
-		// split string to remove file reference
-		file := strings.ReplaceAll(uri[0], "file:", "")
+		// split string to remove file reference (git references can be to 'git+file:' repositories).
+		file := uri[0]
+		if !strings.HasPrefix(file, "git+") {
+			file = strings.ReplaceAll(file, "file:", "")
+		}
 
 		var absoluteFileLocation, fileName string
 		fileName = filepath.Base(file)
 		absoluteFileLocation = file
-		if !filepath.IsAbs(file) && !strings.HasPrefix(file, "http") {
+		if !filepath.IsAbs(file) && !isURLLocation(file) {
 			// When resolving relative references, use the directory of the current index's spec file
 			// if available, not the root BasePath. This ensures that external files can resolve
 			// relative paths correctly (e.g., "./models/foo.yaml" from an external file should
@@ -162,19 +166,23 @@ func (index *SpecIndex) lookupRolodex(ctx context.Context, uri []string) *Refere
 		ext := filepath.Ext(absoluteFileLocation)
 		var parsedDocument *yaml.Node
 		idx := index
+		// remote files are never opened when remote references are skipped.
+		if index.config != nil && index.config.SkipRemoteReferences && IsRemoteReference(absoluteFileLocation) {
+			return nil
+		}
 		if ext != "" {
 			// extract the document from the rolodex.
 			rFile, rError := index.rolodex.OpenWithContext(ctx, absoluteFileLocation)
 
 			if rError != nil {
 				index.logger.Error("unable to open the rolodex file, check specification references and base path",
-					"file", absoluteFileLocation, "error", rError)
+					datamodel.LogKeyFile, absoluteFileLocation, "error", rError)
 				return nil
 			}
 
 			if rFile == nil {
 				index.logger.Error("cannot locate file in the rolodex, check specification references and base path",
-					"file", absoluteFileLocation)
+					datamodel.LogKeyFile, absoluteFileLocation)
 				return nil
 			}
 			// Check if the index is already available (handles recursive lookups within same goroutine).
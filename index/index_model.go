@@ -11,6 +11,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/pb33f/libopenapi/utils"
 
@@ -44,6 +45,24 @@ type Reference struct {
 	In                    string              `json:"-"`                            // parameter location (path, query, header, cookie) - cached for performance
 }
 
+// LogAttributes returns the file, reference and line of the reference as structured logging attributes, using
+// the datamodel.LogKeyFile, datamodel.LogKeyRef and datamodel.LogKeyLine keys. Attributes that are not known are
+// left out.
+func (r *Reference) LogAttributes() []any {
+	attrs := []any{datamodel.LogKeyRef, r.FullDefinition}
+	file := r.RemoteLocation
+	if file == "" && r.Index != nil {
+		file = r.Index.specAbsolutePath
+	}
+	if file != "" {
+		attrs = append(attrs, datamodel.LogKeyFile, file)
+	}
+	if r.Node != nil {
+		attrs = append(attrs, datamodel.LogKeyLine, r.Node.Line)
+	}
+	return attrs
+}
+
 // ReferenceMapped is a helper struct for mapped references put into sequence (we lose the key)
 type ReferenceMapped struct {
 	OriginalReference *Reference `json:"originalReference,omitempty"`
@@ -145,6 +164,50 @@ type SpecIndexConfig struct {
 	// will be used, set to the Error level.
 	Logger *slog.Logger
 
+	// SubsystemLoggers allows the index, resolver, bundler and builders to each use their own logger. Any subsystem
+	// without a logger in this map uses Logger. See datamodel.DocumentConfiguration for details.
+	SubsystemLoggers map[datamodel.LogSubsystem]*slog.Logger
+
+	// BuildEventHandler is called with structured warnings as they occur during indexing and resolving.
+	// See datamodel.DocumentConfiguration for details.
+	BuildEventHandler datamodel.BuildEventHandler
+
+	// IndexingProgressHandler is called as the rolodex discovers and indexes files, and resolves references.
+	// See datamodel.DocumentConfiguration for details.
+	IndexingProgressHandler datamodel.IndexingProgressHandler
+
+	// SlowRemoteFetchThreshold is how long fetching a remote file can take, before a slow fetch event is emitted.
+	// If not set, datamodel.DefaultSlowRemoteFetchThreshold is used.
+	SlowRemoteFetchThreshold time.Duration
+
+	// RemoteCacheDir is a directory remote documents are cached in, when they are fetched with the default HTTP
+	// client. See datamodel.DocumentConfiguration for details.
+	RemoteCacheDir string
+
+	// RemoteCacheTTL is how long cached remote documents without a Cache-Control max-age are used before they are
+	// revalidated. See datamodel.DocumentConfiguration for details.
+	RemoteCacheTTL time.Duration
+
+	// RemoteAuth authenticates the requests made by the default HTTP client to fetch remote documents.
+	// See datamodel.DocumentConfiguration for details.
+	RemoteAuth []*datamodel.RemoteAuth
+
+	// ObjectStores resolve references to objects in object stores, keyed by scheme. See
+	// datamodel.DocumentConfiguration for details.
+	ObjectStores map[string]datamodel.ObjectStore
+
+	// AllowGitReferences resolves references to files in git repositories, like
+	// 'git+https://github.com/org/specs.git/schemas/pet.yaml?ref=v1.2'. See datamodel.DocumentConfiguration for
+	// details.
+	AllowGitReferences bool
+
+	// GitCacheDir is the directory git repositories are kept in. See datamodel.DocumentConfiguration for details.
+	GitCacheDir string
+
+	// FileTransformers are applied to the bytes of every file loaded by the rolodex, before it is parsed.
+	// See datamodel.DocumentConfiguration for details.
+	FileTransformers []*datamodel.FileTransformer
+
 	// SpecInfo is a pointer to the SpecInfo struct that contains the root node and the spec version. It's the
 	// struct that was used to create this index.
 	SpecInfo *datamodel.SpecInfo
@@ -185,6 +248,11 @@ type SpecIndexConfig struct {
 	// defaults to false (which means extensions will be included)
 	ExcludeExtensionRefs bool
 
+	// SkipRemoteReferences will prevent any remote (http/https) references from being looked up, even if a
+	// BaseURL or RemoteFS is set. Remote references are recorded as placeholders (see GetRemotePlaceholders),
+	// rather than being fetched, or reported as errors. Local file references are still resolved.
+	SkipRemoteReferences bool
+
 	// UseSchemaQuickHash will use a quick hash to determine if a schema is the same as another schema if its a reference.
 	// This is important when a root / entry document does not have a components/schemas node, and schemas are defined in
 	// external documents. Enabling this will allow the what-changed module to perform deeper schema reference checks.
@@ -214,6 +282,18 @@ type SpecIndexConfig struct {
 	// PropertyMergeStrategy defines how to handle conflicts when merging properties.
 	PropertyMergeStrategy datamodel.PropertyMergeStrategy
 
+	// DeduplicateFilesByContent will make the rolodex share a single index between files in the same directory
+	// (or at the same base URL) that have exactly the same content (symlinks, copies etc.). Files in different
+	// directories are not shared, as their relative references resolve to different files, unless one directory is
+	// a symlink to the other. Files at different base URLs (like mirrors of the same files) are not shared. The
+	// first file to be indexed owns the shared index. This is disabled by default.
+	DeduplicateFilesByContent bool
+
+	// FileContentCacheSize is the most content (in bytes) of local and remote files the rolodex keeps in memory,
+	// the least recently used content is dropped, and read again when it's needed. See
+	// datamodel.DocumentConfiguration for details. Defaults to 0 (all content is kept in memory).
+	FileContentCacheSize int64
+
 	// private fields
 	uri []string
 	id  string
@@ -264,8 +344,43 @@ func (s *SpecIndexConfig) ToDocumentConfiguration() *datamodel.DocumentConfigura
 		TransformSiblingRefs:                  s.TransformSiblingRefs,
 		MergeReferencedProperties:             s.MergeReferencedProperties,
 		PropertyMergeStrategy:                 strategy,
+		DeduplicateFilesByContent:             s.DeduplicateFilesByContent,
+		FileContentCacheSize:                  s.FileContentCacheSize,
 		Logger:                                s.Logger,
+		SubsystemLoggers:                      s.SubsystemLoggers,
+		BuildEventHandler:                     s.BuildEventHandler,
+		IndexingProgressHandler:               s.IndexingProgressHandler,
+		SlowRemoteFetchThreshold:              s.SlowRemoteFetchThreshold,
+		RemoteCacheDir:                        s.RemoteCacheDir,
+		RemoteCacheTTL:                        s.RemoteCacheTTL,
+		ObjectStores:                          s.ObjectStores,
+		AllowGitReferences:                    s.AllowGitReferences,
+		GitCacheDir:                           s.GitCacheDir,
+		RemoteAuth:                            s.RemoteAuth,
+		FileTransformers:                      s.FileTransformers,
+	}
+}
+
+// GetSubsystemLogger returns the logger to use for a subsystem, falling back to Logger if the subsystem has no
+// logger of its own.
+func (s *SpecIndexConfig) GetSubsystemLogger(subsystem datamodel.LogSubsystem) *slog.Logger {
+	return datamodel.SubsystemLogger(s.SubsystemLoggers, s.Logger, subsystem)
+}
+
+// EmitBuildEvent sends an event to the BuildEventHandler of the configuration, if one is set.
+func (s *SpecIndexConfig) EmitBuildEvent(event *datamodel.BuildEvent) {
+	if s != nil && s.BuildEventHandler != nil {
+		s.BuildEventHandler(event)
+	}
+}
+
+// TransformFile applies the FileTransformers of the configuration to the bytes of the file at the location. If
+// there are no transformers, the bytes are returned untouched.
+func (s *SpecIndexConfig) TransformFile(location string, data []byte) ([]byte, error) {
+	if s == nil || len(s.FileTransformers) == 0 {
+		return data, nil
 	}
+	return datamodel.ApplyFileTransformers(s.FileTransformers, location, data)
 }
 
 // CreateOpenAPIIndexConfig is a helper function to create a new SpecIndexConfig with the AllowRemoteLookup and
@@ -375,6 +490,7 @@ type SpecIndex struct {
 	allExternalDocuments                map[string]*Reference                         // all external documents
 	externalSpecIndex                   map[string]*SpecIndex                         // create a primary index of all external specs and componentIds
 	refErrors                           []error                                       // errors when indexing references
+	remotePlaceholders                  []*Reference                                  // remote references skipped by SkipRemoteReferences
 	operationParamErrors                []error                                       // errors when indexing parameters
 	allDescriptions                     []*DescriptionReference                       // every single description found in the spec.
 	allSummaries                        []*DescriptionReference                       // every single summary found in the spec.
@@ -4,10 +4,13 @@
 package index
 
 import (
+	"bytes"
 	"encoding/json"
+	"log/slog"
 	"net/url"
 	"testing"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 	"go.yaml.in/yaml/v4"
 )
@@ -107,3 +110,31 @@ func TestSpecIndexConfig_ToDocumentConfiguration_AllFields(t *testing.T) {
 	assert.True(t, result.TransformSiblingRefs)
 	assert.False(t, result.MergeReferencedProperties) // default disabled for index configs
 }
+
+func TestSpecIndexConfig_GetSubsystemLogger(t *testing.T) {
+	var buf bytes.Buffer
+	bundler := slog.New(slog.NewJSONHandler(&buf, nil))
+	config := &SpecIndexConfig{
+		SubsystemLoggers: map[datamodel.LogSubsystem]*slog.Logger{datamodel.LogSubsystemBundler: bundler},
+	}
+	config.GetSubsystemLogger(datamodel.LogSubsystemBundler).Info("bundling")
+	assert.Contains(t, buf.String(), `"subsystem":"bundler"`)
+	assert.Equal(t, config.SubsystemLoggers, config.ToDocumentConfiguration().SubsystemLoggers)
+
+	idx := NewSpecIndexWithConfig(nil, config)
+	assert.NotNil(t, idx.GetSubsystemLogger(datamodel.LogSubsystemResolver))
+	assert.NotNil(t, new(SpecIndex).GetSubsystemLogger(datamodel.LogSubsystemResolver))
+}
+
+func TestReference_LogAttributes(t *testing.T) {
+	ref := &Reference{
+		FullDefinition: "pets.yaml#/components/schemas/Pet",
+		RemoteLocation: "/specs/pets.yaml",
+		Node:           &yaml.Node{Line: 12},
+	}
+	assert.Equal(t, []any{"ref", "pets.yaml#/components/schemas/Pet", "file", "/specs/pets.yaml", "line", 12},
+		ref.LogAttributes())
+
+	ref = &Reference{FullDefinition: "#/components/schemas/Pet", Index: &SpecIndex{specAbsolutePath: "/specs/root.yaml"}}
+	assert.Equal(t, []any{"ref", "#/components/schemas/Pet", "file", "/specs/root.yaml"}, ref.LogAttributes())
+}
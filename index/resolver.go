@@ -13,6 +13,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 	"go.yaml.in/yaml/v4"
 )
@@ -84,6 +85,28 @@ func NewResolver(index *SpecIndex) *Resolver {
 	return r
 }
 
+// addCircularReference records a circular reference, and emits a build event for it.
+func (resolver *Resolver) addCircularReference(circRef *CircularReferenceResult) {
+	resolver.circularReferences = append(resolver.circularReferences, circRef)
+	if resolver.specIndex.config == nil || resolver.specIndex.config.BuildEventHandler == nil {
+		return
+	}
+	event := &datamodel.BuildEvent{
+		Type:      datamodel.BuildEventCircularReference,
+		Subsystem: datamodel.LogSubsystemResolver,
+		Message:   fmt.Sprintf("circular reference detected: %s", circRef.GenerateJourneyPath()),
+		File:      resolver.specIndex.specAbsolutePath,
+	}
+	if circRef.LoopPoint != nil {
+		event.Ref = circRef.LoopPoint.FullDefinition
+		if circRef.LoopPoint.Node != nil {
+			event.Line = circRef.LoopPoint.Node.Line
+			event.Column = circRef.LoopPoint.Node.Column
+		}
+	}
+	resolver.specIndex.config.EmitBuildEvent(event)
+}
+
 // GetIgnoredCircularPolyReferences returns all ignored circular references that are polymorphic
 func (resolver *Resolver) GetIgnoredCircularPolyReferences() []*CircularReferenceResult {
 	return resolver.ignoredPolyReferences
@@ -407,7 +430,7 @@ func (resolver *Resolver) VisitReference(ref *Reference, seen map[string]bool, j
 							resolver.ignoredArrayReferences = append(resolver.ignoredArrayReferences, circRef)
 						} else {
 							if !resolver.circChecked {
-								resolver.circularReferences = append(resolver.circularReferences, circRef)
+								resolver.addCircularReference(circRef)
 							}
 						}
 						r.Seen = true
@@ -492,14 +515,14 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 
 	// this is a safety check to prevent a stack overflow.
 	if depth > 500 {
-		def := "unknown"
-		if ref != nil {
-			def = ref.FullDefinition
-		}
-		if resolver.specIndex != nil && resolver.specIndex.logger != nil {
-			resolver.specIndex.logger.Warn("libopenapi resolver: relative depth exceeded 100 levels, "+
-				"check for circular references - resolving may be incomplete",
-				"reference", def)
+		if resolver.specIndex != nil {
+			attrs := []any{datamodel.LogKeyRef, "unknown"}
+			if ref != nil {
+				attrs = ref.LogAttributes()
+			}
+			resolver.specIndex.GetSubsystemLogger(datamodel.LogSubsystemResolver).Warn(
+				"libopenapi resolver: relative depth exceeded 100 levels, "+
+					"check for circular references - resolving may be incomplete", attrs...)
 		}
 
 		loop := append(journey, ref)
@@ -511,7 +534,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 			IsInfiniteLoop: true,
 		}
 		if !resolver.circChecked {
-			resolver.circularReferences = append(resolver.circularReferences, circRef)
+			resolver.addCircularReference(circRef)
 			ref.Circular = true
 		}
 		return nil
@@ -563,10 +586,10 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 				if len(exp) == 2 {
 					definition = fmt.Sprintf("#/%s", exp[1])
 					if exp[0] != "" {
-						if strings.HasPrefix(exp[0], "http") {
+						if isURLLocation(exp[0]) {
 							fullDef = value
 						} else {
-							if strings.HasPrefix(ref.FullDefinition, "http") {
+							if isURLLocation(ref.FullDefinition) {
 
 								// split the http URI into parts
 								httpExp := strings.Split(ref.FullDefinition, "#/")
@@ -591,7 +614,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 						}
 					} else {
 						// local component, full def is based on passed in ref
-						if strings.HasPrefix(ref.FullDefinition, "http") {
+						if isURLLocation(ref.FullDefinition) {
 
 							// split the http URI into parts
 							httpExp := strings.Split(ref.FullDefinition, "#/")
@@ -613,7 +636,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 					definition = value
 
 					// if the reference is a http link
-					if strings.HasPrefix(value, "http") {
+					if isURLLocation(value) {
 						fullDef = value
 					} else {
 
@@ -621,7 +644,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 						fileDef := strings.Split(ref.FullDefinition, "#/")
 
 						// is the file def a http link?
-						if strings.HasPrefix(fileDef[0], "http") {
+						if isURLLocation(fileDef[0]) {
 							u, _ := url.Parse(fileDef[0])
 							absPath, _ := filepath.Abs(utils.CheckPathOverlap(path.Dir(u.Path), exp[0], string(filepath.Separator)))
 							u.Path = utils.ReplaceWindowsDriveWithLinuxPath(absPath)
@@ -733,7 +756,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 												resolver.ignoredPolyReferences = append(resolver.ignoredPolyReferences, circRef)
 											} else {
 												if !resolver.circChecked {
-													resolver.circularReferences = append(resolver.circularReferences, circRef)
+													resolver.addCircularReference(circRef)
 												}
 											}
 										}
@@ -778,7 +801,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 												resolver.ignoredPolyReferences = append(resolver.ignoredPolyReferences, circRef)
 											} else {
 												if !resolver.circChecked {
-													resolver.circularReferences = append(resolver.circularReferences, circRef)
+													resolver.addCircularReference(circRef)
 												}
 											}
 										}
@@ -824,7 +847,7 @@ func (resolver *Resolver) extractRelatives(ref *Reference, node, parent *yaml.No
 												resolver.ignoredPolyReferences = append(resolver.ignoredPolyReferences, circRef)
 											} else {
 												if !resolver.circChecked {
-													resolver.circularReferences = append(resolver.circularReferences, circRef)
+													resolver.addCircularReference(circRef)
 												}
 											}
 										}
@@ -852,9 +875,9 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 	exp := strings.Split(l, "#/")
 	if len(exp) == 2 {
 		if exp[0] != "" {
-			if !strings.HasPrefix(exp[0], "http") {
+			if !isURLLocation(exp[0]) {
 				if !filepath.IsAbs(exp[0]) {
-					if strings.HasPrefix(ref.FullDefinition, "http") {
+					if isURLLocation(ref.FullDefinition) {
 
 						u, _ := url.Parse(ref.FullDefinition)
 						p, _ := filepath.Abs(utils.CheckPathOverlap(path.Dir(u.Path), exp[0], string(filepath.Separator)))
@@ -887,7 +910,7 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 				}
 			}
 		} else {
-			if strings.HasPrefix(ref.FullDefinition, "http") {
+			if isURLLocation(ref.FullDefinition) {
 				u, _ := url.Parse(ref.FullDefinition)
 				u.Fragment = ""
 				def = fmt.Sprintf("%s#/%s", u.String(), exp[1])
@@ -902,11 +925,11 @@ func (resolver *Resolver) buildDefPath(ref *Reference, l string) string {
 			}
 		}
 	} else {
-		if strings.HasPrefix(l, "http") {
+		if isURLLocation(l) {
 			def = l
 		} else {
 			// check if were dealing with a remote file
-			if strings.HasPrefix(ref.FullDefinition, "http") {
+			if isURLLocation(ref.FullDefinition) {
 
 				// split the url.
 				u, _ := url.Parse(ref.FullDefinition)
@@ -466,16 +466,17 @@ func TestResolver_DeepDepth(t *testing.T) {
 	refA.Content = append(refA.Content, refB)
 	refB.Content = append(refB.Content, refA)
 
-	idx := NewSpecIndexWithConfig(nil, CreateClosedAPIIndexConfig())
-	resolver := NewResolver(idx)
-
-	// add a logger
+	// add a resolver logger
 	var log []byte
 	buf := bytes.NewBuffer(log)
 	logger := slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
 		Level: slog.LevelDebug,
 	}))
-	idx.logger = logger
+	config := CreateClosedAPIIndexConfig()
+	config.SubsystemLoggers = map[datamodel.LogSubsystem]*slog.Logger{datamodel.LogSubsystemResolver: logger}
+
+	idx := NewSpecIndexWithConfig(nil, config)
+	resolver := NewResolver(idx)
 
 	ref := &Reference{
 		FullDefinition: "#/components/schemas/A",
@@ -484,6 +485,7 @@ func TestResolver_DeepDepth(t *testing.T) {
 
 	assert.Nil(t, found)
 	assert.Contains(t, buf.String(), "libopenapi resolver: relative depth exceeded 100 levels")
+	assert.Contains(t, buf.String(), "subsystem=resolver ref=#/components/schemas/A")
 }
 
 func TestResolver_ResolveComponents_Stripe_NoRolodex(t *testing.T) {
@@ -4,14 +4,18 @@
 package index
 
 import (
+	"crypto/sha256"
 	"errors"
 	"fmt"
+	"hash/maphash"
 	"io"
 	"io/fs"
 	"log/slog"
 	"maps"
 	"math"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -20,6 +24,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 
 	"context"
@@ -73,6 +78,7 @@ type Rolodex struct {
 	circChecked                bool
 	indexConfig                *SpecIndexConfig
 	indexingDuration           time.Duration
+	circularCheckDuration      time.Duration
 	indexes                    []*SpecIndex
 	indexMap                   map[string]*SpecIndex
 	indexLock                  sync.Mutex
@@ -86,28 +92,48 @@ type Rolodex struct {
 	id                         string // unique ID for the rolodex, can be used to identify it in logs or other contexts.
 	globalSchemaIdRegistry     map[string]*SchemaIdEntry
 	schemaIdRegistryLock       sync.RWMutex
+	contentIndexes             map[uint64]*contentIndex
+	contentIndexLock           sync.RWMutex
+	referencePreserver         ReferencePreserver
+	referenceAnnotator         ReferenceAnnotator
+	contentCache               *contentCache
+	progress                   rolodexProgress
+}
+
+// ReferencePreserver decides if a reference (as it is written) is kept when a model is rendered inline, instead of
+// being replaced by what it references. The index supplied is the index of the file the reference points to. It
+// returns the reference to render in its place (references are rendered into another file, so relative references
+// may need to change), and true if the reference is kept.
+type ReferencePreserver func(idx *SpecIndex, ref string) (string, bool)
+
+// ReferenceAnnotator is called with the rendered node of every reference (as it is written) that is replaced by
+// what it references when a model is rendered inline. The index supplied is the index of the file the reference
+// points to. The node can be changed, for example to record where it came from.
+type ReferenceAnnotator func(idx *SpecIndex, ref string, node *yaml.Node)
+
+// contentIndex pairs a shared index with the base its relative references resolve against, and a digest of the
+// bytes it was built from, so hash collisions can be ruled out without holding on to the bytes.
+type contentIndex struct {
+	base  string
+	sum   [sha256.Size]byte
+	index *SpecIndex
 }
 
 // NewRolodex creates a new rolodex with the provided index configuration.
 func NewRolodex(indexConfig *SpecIndexConfig) *Rolodex {
-	logger := indexConfig.Logger
-	if logger == nil {
-		logger = slog.New(
-			slog.NewJSONHandler(
-				os.Stdout, &slog.HandlerOptions{
-					Level: slog.LevelError,
-				},
-			),
-		)
-	}
+	logger := indexConfig.GetSubsystemLogger(datamodel.LogSubsystemIndex)
 
 	r := &Rolodex{
-		indexConfig: indexConfig,
-		id:          utils.GenerateAlphanumericString(6),
-		localFS:     make(map[string]fs.FS),
-		remoteFS:    make(map[string]fs.FS),
-		logger:      logger,
-		indexMap:    make(map[string]*SpecIndex),
+		indexConfig:    indexConfig,
+		id:             utils.GenerateAlphanumericString(6),
+		localFS:        make(map[string]fs.FS),
+		remoteFS:       make(map[string]fs.FS),
+		logger:         logger,
+		indexMap:       make(map[string]*SpecIndex),
+		contentIndexes: make(map[uint64]*contentIndex),
+	}
+	if indexConfig.FileContentCacheSize > 0 {
+		r.contentCache = newContentCache(indexConfig.FileContentCacheSize)
 	}
 	indexConfig.Rolodex = r
 	return r
@@ -191,6 +217,11 @@ func (r *Rolodex) GetIndexingDuration() time.Duration {
 	return r.indexingDuration
 }
 
+// GetCircularCheckDuration returns the duration it took to check the rolodex for circular references.
+func (r *Rolodex) GetCircularCheckDuration() time.Duration {
+	return r.circularCheckDuration
+}
+
 // GetRootIndex returns the root index of the rolodex (the entry point, the main document)
 func (r *Rolodex) GetRootIndex() *SpecIndex {
 	return r.rootIndex
@@ -238,6 +269,36 @@ func (r *Rolodex) SetRootIndex(rootIndex *SpecIndex) {
 	r.rootIndex = rootIndex
 }
 
+// SetReferencePreserver sets the ReferencePreserver used when models of the rolodex are rendered inline, set nil
+// to inline every reference again. The bundler uses this to keep selected external references.
+func (r *Rolodex) SetReferencePreserver(preserver ReferencePreserver) {
+	r.referencePreserver = preserver
+}
+
+// PreserveReference returns the reference to render, and true, if a reference (pointing to the file of the index)
+// should be kept when rendering inline. If no ReferencePreserver is set, nothing is kept.
+func (r *Rolodex) PreserveReference(idx *SpecIndex, ref string) (string, bool) {
+	if r == nil || r.referencePreserver == nil {
+		return "", false
+	}
+	return r.referencePreserver(idx, ref)
+}
+
+// SetReferenceAnnotator sets the ReferenceAnnotator used when models of the rolodex are rendered inline, set nil
+// to stop annotating. The bundler uses this to record where inlined references came from.
+func (r *Rolodex) SetReferenceAnnotator(annotator ReferenceAnnotator) {
+	r.referenceAnnotator = annotator
+}
+
+// AnnotateReference calls the ReferenceAnnotator (if one is set) with the rendered node of a reference (pointing to
+// the file of the index) that has been inlined.
+func (r *Rolodex) AnnotateReference(idx *SpecIndex, ref string, node *yaml.Node) {
+	if r == nil || r.referenceAnnotator == nil || node == nil {
+		return
+	}
+	r.referenceAnnotator(idx, ref, node)
+}
+
 func (r *Rolodex) AddExternalIndex(idx *SpecIndex, location string) {
 	r.indexLock.Lock()
 	defer r.indexLock.Unlock()
@@ -256,6 +317,68 @@ func (r *Rolodex) AddExternalIndex(idx *SpecIndex, location string) {
 	r.RegisterIdsFromIndex(idx)
 }
 
+// FindIndexByContent returns a previously built index for a file, in the same directory (or at the same base URL)
+// as the location, that has exactly the same content as the supplied bytes. Files in other directories are never
+// shared, because their relative references point to other files, unless the directories are the same directory
+// reached through a symlink. Files at different URLs (like mirrors) are never shared. If DeduplicateFilesByContent
+// is not enabled on the rolodex configuration, or no such file has been indexed yet, nil is returned.
+func (r *Rolodex) FindIndexByContent(location string, data []byte) *SpecIndex {
+	if r == nil || r.indexConfig == nil || !r.indexConfig.DeduplicateFilesByContent || len(data) == 0 {
+		return nil
+	}
+	base := contentBase(location)
+	r.contentIndexLock.RLock()
+	defer r.contentIndexLock.RUnlock()
+	if ci, ok := r.contentIndexes[contentKey(base, data)]; ok && ci.base == base && ci.sum == sha256.Sum256(data) {
+		return ci.index
+	}
+	return nil
+}
+
+// registerIndexContent records a fully built index against the content of the file it was built from, so that
+// any other file with the same content can share it. The first index registered for any given content wins.
+func (r *Rolodex) registerIndexContent(data []byte, idx *SpecIndex) {
+	if r == nil || idx == nil || r.indexConfig == nil || !r.indexConfig.DeduplicateFilesByContent || len(data) == 0 {
+		return
+	}
+	base := contentBase(idx.specAbsolutePath)
+	key := contentKey(base, data)
+	r.contentIndexLock.Lock()
+	defer r.contentIndexLock.Unlock()
+	if r.contentIndexes == nil {
+		r.contentIndexes = make(map[uint64]*contentIndex)
+	}
+	if _, ok := r.contentIndexes[key]; !ok {
+		r.contentIndexes[key] = &contentIndex{base: base, sum: sha256.Sum256(data), index: idx}
+	}
+}
+
+// contentBase returns the directory (or the URL of the directory) relative references in a file resolve against.
+// Symlinks in a local directory are resolved, so a directory and a symlink to it have the same base. URLs are used
+// as they are, mirrors of the same files at different URLs have different bases.
+func contentBase(location string) string {
+	if u, err := url.Parse(location); err == nil && u.Scheme != "" && u.Host != "" {
+		u.Path = path.Dir(u.Path)
+		u.Fragment = ""
+		return u.String()
+	}
+	dir := filepath.Dir(location)
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+	return dir
+}
+
+// contentKey hashes the content of a file, along with the base its relative references resolve against.
+func contentKey(base string, data []byte) uint64 {
+	var h maphash.Hash
+	h.SetSeed(globalHashSeed)
+	_, _ = h.WriteString(base)
+	_ = h.WriteByte(0)
+	_, _ = h.Write(data)
+	return h.Sum64()
+}
+
 func (r *Rolodex) AddIndex(idx *SpecIndex) {
 	if idx != nil {
 		p := idx.specAbsolutePath
@@ -263,11 +386,52 @@ func (r *Rolodex) AddIndex(idx *SpecIndex) {
 	}
 }
 
+// removeIndex removes the index of a file from the rolodex, along with the content and $id registrations made for
+// it, so the file can be indexed again.
+func (r *Rolodex) removeIndex(location string) {
+	removed := make(map[*SpecIndex]bool)
+	r.indexLock.Lock()
+	kept := r.indexes[:0]
+	for _, idx := range r.indexes {
+		if idx.specAbsolutePath == location {
+			removed[idx] = true
+			continue
+		}
+		kept = append(kept, idx)
+	}
+	clear(r.indexes[len(kept):])
+	r.indexes = kept
+	if idx := r.indexMap[location]; idx != nil {
+		removed[idx] = true
+		delete(r.indexMap, location)
+	}
+	r.indexLock.Unlock()
+
+	r.contentIndexLock.Lock()
+	for key, ci := range r.contentIndexes {
+		if removed[ci.index] {
+			delete(r.contentIndexes, key)
+		}
+	}
+	r.contentIndexLock.Unlock()
+
+	r.schemaIdRegistryLock.Lock()
+	for key, entry := range r.globalSchemaIdRegistry {
+		if removed[entry.Index] {
+			delete(r.globalSchemaIdRegistry, key)
+		}
+	}
+	r.schemaIdRegistryLock.Unlock()
+}
+
 // AddRemoteFS adds a remote file system to the rolodex.
 func (r *Rolodex) AddRemoteFS(baseURL string, fileSystem fs.FS) {
 	if f, ok := fileSystem.(*RemoteFS); ok {
 		f.rolodex = r
 		f.logger = r.logger
+	} else if f, ok := fileSystem.(Rolodexable); ok {
+		f.SetRolodex(r)
+		f.SetLogger(r.logger)
 	}
 	r.remoteFS[baseURL] = fileSystem
 }
@@ -300,15 +464,18 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 			idx, err := idxFile.Index(&copiedConfig)
 
 			if err == nil { // Index() does not throw an error anymore.
-				// for each index, we need a resolver
-				resolver := NewResolver(idx)
-
-				// check if the config has been set to ignore circular references in arrays and polymorphic schemas
-				if copiedConfig.IgnoreArrayCircularReferences {
-					resolver.IgnoreArrayCircularReferences()
-				}
-				if copiedConfig.IgnorePolymorphicCircularReferences {
-					resolver.IgnorePolymorphicCircularReferences()
+				// an index that has already been built by another rolodex sharing this file system keeps its resolver.
+				if !idx.built {
+					// for each index, we need a resolver
+					resolver := NewResolver(idx)
+
+					// check if the config has been set to ignore circular references in arrays and polymorphic schemas
+					if copiedConfig.IgnoreArrayCircularReferences {
+						resolver.IgnoreArrayCircularReferences()
+					}
+					if copiedConfig.IgnorePolymorphicCircularReferences {
+						resolver.IgnorePolymorphicCircularReferences()
+					}
 				}
 				indexChan <- idx
 			}
@@ -319,6 +486,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 
 			for _, f := range lfs.GetFiles() {
 				if idxFile, ko := f.(CanBeIndexed); ko {
+					r.fileDiscovered(f.GetFullPath())
 					wg.Add(1)
 					wait = true
 					go indexFileFunc(idxFile, f.GetFullPath())
@@ -373,6 +541,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 
 	for _, idx := range indexBuildQueue {
 		idx.BuildIndex()
+		r.fileIndexed(idx.specAbsolutePath)
 		if r.indexConfig.AvoidCircularReferenceCheck {
 			continue
 		}
@@ -414,6 +583,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 
 		// Here we take the root node and also build the index for it.
 		// This involves extracting references.
+		r.fileDiscovered(r.indexConfig.SpecAbsolutePath)
 		index := NewSpecIndexWithConfigAndContext(ctx, r.rootNode, r.indexConfig)
 		resolver := NewResolver(index)
 
@@ -426,6 +596,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 		r.rootIndex = index
 		r.logger.Debug("[rolodex] starting root index build")
 		index.BuildIndex()
+		r.fileIndexed(r.indexConfig.SpecAbsolutePath)
 		r.logger.Debug("[rolodex] root index build completed")
 
 		if !r.indexConfig.AvoidCircularReferenceCheck {
@@ -460,6 +631,7 @@ func (r *Rolodex) IndexTheRolodex(ctx context.Context) error {
 // CheckForCircularReferences checks for circular references in the rolodex.
 func (r *Rolodex) CheckForCircularReferences() {
 	if !r.circChecked {
+		started := time.Now()
 		if r.rootIndex != nil && r.rootIndex.resolver != nil {
 			resolvingErrors := r.rootIndex.resolver.CheckForCircularReferences()
 			for e := range resolvingErrors {
@@ -482,6 +654,7 @@ func (r *Rolodex) CheckForCircularReferences() {
 				r.infiniteCircularReferences, r.rootIndex.resolver.GetInfiniteCircularReferences()...,
 			)
 		}
+		r.circularCheckDuration = time.Since(started)
 		r.circChecked = true
 	}
 }
@@ -580,7 +753,7 @@ func (r *Rolodex) OpenWithContext(ctx context.Context, location string) (Rolodex
 	var remoteFile *RemoteFile
 	fileLookup := location
 	isUrl := false
-	if strings.HasPrefix(location, "http") {
+	if isURLLocation(location) {
 		isUrl = true
 	}
 
@@ -668,6 +841,9 @@ func (r *Rolodex) OpenWithContext(ctx context.Context, location string) (Rolodex
 
 			// not a native FS, so we need to read the file and create a local file.
 			bytes, rErr := io.ReadAll(f)
+			if rErr == nil {
+				bytes, rErr = r.indexConfig.TransformFile(fileLookup, bytes)
+			}
 			if rErr != nil {
 				errorStack = append(errorStack, rErr)
 				continue
@@ -725,6 +901,9 @@ func (r *Rolodex) OpenWithContext(ctx context.Context, location string) (Rolodex
 				} else {
 
 					bytes, rErr := io.ReadAll(f)
+					if rErr == nil {
+						bytes, rErr = r.indexConfig.TransformFile(fileLookup, bytes)
+					}
 					if rErr != nil {
 						errorStack = append(errorStack, rErr)
 						continue
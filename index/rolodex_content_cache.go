@@ -0,0 +1,79 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"container/list"
+	"sync"
+)
+
+// evictable is a rolodex file that can drop its content from memory, and load it again when it's needed.
+type evictable interface {
+	evictContent()
+}
+
+// contentCache is a least recently used cache of the content of the files in a rolodex, bounded by the total size
+// of the content. When the limit is exceeded, the content of the files used least recently is evicted from memory,
+// and loaded again (from disk or the network) the next time it's read. The indexes of evicted files are kept, the
+// rolodex (and any model built from it) refers to their nodes.
+type contentCache struct {
+	limit   int64
+	size    int64
+	order   *list.List // most recently used at the front.
+	entries map[evictable]*list.Element
+	mu      sync.Mutex
+}
+
+type contentCacheEntry struct {
+	file evictable
+	size int64
+}
+
+func newContentCache(limit int64) *contentCache {
+	return &contentCache{limit: limit, order: list.New(), entries: make(map[evictable]*list.Element)}
+}
+
+// use records that the content of a file has been read, and evicts the content of the least recently used files
+// if the cache is over its limit. The file that was read is never evicted by its own use.
+func (c *contentCache) use(f evictable, size int64) {
+	if c == nil {
+		return
+	}
+	var evicted []evictable
+	c.mu.Lock()
+	if e, ok := c.entries[f]; ok {
+		entry := e.Value.(*contentCacheEntry)
+		c.size += size - entry.size
+		entry.size = size
+		c.order.MoveToFront(e)
+	} else {
+		c.entries[f] = c.order.PushFront(&contentCacheEntry{file: f, size: size})
+		c.size += size
+	}
+	for c.size > c.limit && c.order.Len() > 1 {
+		e := c.order.Back()
+		entry := e.Value.(*contentCacheEntry)
+		c.order.Remove(e)
+		delete(c.entries, entry.file)
+		c.size -= entry.size
+		evicted = append(evicted, entry.file)
+	}
+	c.mu.Unlock()
+
+	// evicting content locks the file, so it's done outside the cache lock.
+	for _, e := range evicted {
+		e.evictContent()
+	}
+}
+
+// inMemory returns the total size of the content held in memory.
+func (c *contentCache) inMemory() int64 {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size
+}
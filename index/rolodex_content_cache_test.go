@@ -0,0 +1,131 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvictable struct {
+	evictions int
+}
+
+func (t *testEvictable) evictContent() {
+	t.evictions++
+}
+
+func TestContentCache_Use(t *testing.T) {
+	cache := newContentCache(100)
+	a, b, c := &testEvictable{}, &testEvictable{}, &testEvictable{}
+
+	cache.use(a, 40)
+	cache.use(b, 40)
+	assert.Equal(t, int64(80), cache.inMemory())
+
+	// a was used more recently than b, so b is evicted.
+	cache.use(a, 40)
+	cache.use(c, 40)
+	assert.Equal(t, int64(80), cache.inMemory())
+	assert.Equal(t, 0, a.evictions)
+	assert.Equal(t, 1, b.evictions)
+
+	// a file bigger than the limit evicts everything else, but not itself.
+	cache.use(b, 200)
+	assert.Equal(t, int64(200), cache.inMemory())
+	assert.Equal(t, 1, a.evictions)
+	assert.Equal(t, 1, b.evictions)
+	assert.Equal(t, 1, c.evictions)
+
+	var nilCache *contentCache
+	nilCache.use(a, 10)
+	assert.Zero(t, nilCache.inMemory())
+}
+
+func contentCacheSchema(name string) string {
+	return fmt.Sprintf("components:\n  schemas:\n    %s:\n      type: object\n      description: %s\n",
+		name, strings.Repeat(name, 20))
+}
+
+func TestRolodex_FileContentCacheSize_LocalFS(t *testing.T) {
+	tmp := t.TempDir()
+	names := []string{"pet", "owner", "vet"}
+	for _, n := range names {
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, n+".yaml"), []byte(contentCacheSchema(n)), 0o644))
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	cf.FileContentCacheSize = int64(len(contentCacheSchema("owner")) + 10)
+	rolo := NewRolodex(cf)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo.AddLocalFS(tmp, fileFS)
+
+	files := make([]RolodexFile, len(names))
+	for i, n := range names {
+		files[i], err = rolo.Open(filepath.Join(tmp, n+".yaml"))
+		require.NoError(t, err)
+	}
+	assert.LessOrEqual(t, rolo.contentCache.inMemory(), cf.FileContentCacheSize)
+
+	// evicted files keep their index, and their content is read again when it's needed.
+	pet := files[0].(*rolodexFile).localFile
+	assert.True(t, pet.evicted)
+	assert.Nil(t, pet.data)
+	assert.NotNil(t, pet.GetIndex())
+	assert.Equal(t, contentCacheSchema("pet"), files[0].GetContent())
+	assert.False(t, pet.evicted)
+	assert.True(t, files[1].(*rolodexFile).localFile.evicted)
+	assert.LessOrEqual(t, rolo.contentCache.inMemory(), cf.FileContentCacheSize)
+
+	node, err := files[1].GetContentAsYAMLNode()
+	require.NoError(t, err)
+	assert.NotNil(t, node)
+}
+
+func TestRolodex_FileContentCacheSize_RemoteFS(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(contentCacheSchema(strings.TrimSuffix(filepath.Base(r.URL.Path), ".yaml"))))
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	cf.FileContentCacheSize = 1
+	rolo := NewRolodex(cf)
+	remoteFS, err := NewRemoteFSWithConfig(cf)
+	require.NoError(t, err)
+	rolo.AddRemoteFS(server.URL, remoteFS)
+
+	pet, err := remoteFS.Open(server.URL + "/pet.yaml")
+	require.NoError(t, err)
+	_, err = remoteFS.Open(server.URL + "/owner.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	remoteFile := pet.(*RemoteFile)
+	assert.True(t, remoteFile.evicted)
+	assert.NotNil(t, remoteFile.GetIndex())
+	assert.Equal(t, contentCacheSchema("pet"), remoteFile.GetContent())
+	assert.Equal(t, int32(3), requests.Load())
+
+	// content that cannot be fetched again is empty.
+	server.Close()
+	remoteFile.evictContent()
+	assert.Empty(t, remoteFile.GetContent())
+}
@@ -45,10 +45,10 @@ func (rf *rolodexFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
 	}
 	var content []byte
 	if rf.localFile != nil {
-		content = rf.localFile.data
+		content = rf.localFile.content()
 	}
 	if rf.remoteFile != nil {
-		content = rf.remoteFile.data
+		content = rf.remoteFile.content()
 	}
 
 	// first, we must parse the content of the file
@@ -66,10 +66,10 @@ func (rf *rolodexFile) Index(config *SpecIndexConfig) (*SpecIndex, error) {
 
 func (rf *rolodexFile) GetContent() string {
 	if rf.localFile != nil {
-		return string(rf.localFile.data)
+		return rf.localFile.GetContent()
 	}
 	if rf.remoteFile != nil {
-		return string(rf.remoteFile.data)
+		return rf.remoteFile.GetContent()
 	}
 	return ""
 }
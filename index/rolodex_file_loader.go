@@ -92,97 +92,106 @@ func (l *LocalFS) OpenWithContext(ctx context.Context, name string) (fs.File, er
 		return f.(*LocalFile), nil
 	}
 
-	// Only enter new-file logic if DirFS is not set
-	if l.fsConfig != nil && l.fsConfig.DirFS == nil {
-
-		// Use LoadOrStore to atomically check if someone is already processing this file.
-		// This prevents the race condition where two goroutines both see "not processing"
-		// and both start processing the same file.
-		processingWaiter := &waiterLocal{f: name}
-		processingWaiter.mu.Lock()
-
-		if existing, loaded := l.processingFiles.LoadOrStore(name, processingWaiter); loaded {
-			// Someone else is already processing this file, wait for them
-			processingWaiter.mu.Unlock() // Release our unused waiter's lock
-			wait := existing.(*waiterLocal)
-
-			wait.mu.Lock()
-			l.logger.Debug("[rolodex file loader]: waiting for existing OS load to complete", "file", name, "listeners", wait.listeners)
-			f := wait.file
-			e := wait.error
-			l.logger.Debug("[rolodex file loader]: waiting done, OS load completed, returning file", "file", name, "listeners", wait.listeners)
-			wait.mu.Unlock()
-			return f, e
-		}
+	// Only enter new-file logic if DirFS is not set, or is read lazily
+	if l.fsConfig != nil && (l.fsConfig.DirFS == nil || l.fsConfig.LazyDirFS) {
+		return l.openNewFile(ctx, name)
+	}
 
-		// We successfully stored our waiter, so we're responsible for processing this file
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
 
-		var extractedFile *LocalFile
-		var extErr error
-		l.logger.Debug("[rolodex file loader]: extracting file from OS", "file", name)
-		extractedFile, extErr = l.extractFile(name)
+// openNewFile reads a file that is not in the file system yet, indexes it, and adds it to the file system (and its
+// index to the rolodex).
+func (l *LocalFS) openNewFile(ctx context.Context, name string) (fs.File, error) {
+	// Use LoadOrStore to atomically check if someone is already processing this file.
+	// This prevents the race condition where two goroutines both see "not processing"
+	// and both start processing the same file.
+	processingWaiter := &waiterLocal{f: name}
+	processingWaiter.mu.Lock()
+
+	if existing, loaded := l.processingFiles.LoadOrStore(name, processingWaiter); loaded {
+		// Someone else is already processing this file, wait for them
+		processingWaiter.mu.Unlock() // Release our unused waiter's lock
+		wait := existing.(*waiterLocal)
+
+		wait.mu.Lock()
+		l.logger.Debug("[rolodex file loader]: waiting for existing OS load to complete", "file", name, "listeners", wait.listeners)
+		f := wait.file
+		e := wait.error
+		l.logger.Debug("[rolodex file loader]: waiting done, OS load completed, returning file", "file", name, "listeners", wait.listeners)
+		wait.mu.Unlock()
+		return f, e
+	}
 
-		if extErr != nil {
-			processingWaiter.error = extErr
-			processingWaiter.done = true
-			l.processingFiles.Delete(name)
-			processingWaiter.mu.Unlock()
+	// We successfully stored our waiter, so we're responsible for processing this file
 
-			return nil, extErr
-		}
+	var extractedFile *LocalFile
+	var extErr error
+	l.logger.Debug("[rolodex file loader]: extracting file from OS", "file", name)
+	extractedFile, extErr = l.extractFile(name)
 
-		// Store in Files and release the waiter BEFORE indexing to prevent deadlocks.
-		// If file A needs file B and file B needs file A, holding the lock during indexing
-		// would cause a deadlock. The indexOnce in IndexWithContext handles concurrent
-		// access to index creation safely.
-		if extractedFile != nil {
-			l.Files.Store(name, extractedFile)
-		}
-
-		processingWaiter.file = extractedFile
+	if extErr != nil {
 		processingWaiter.error = extErr
 		processingWaiter.done = true
 		l.processingFiles.Delete(name)
 		processingWaiter.mu.Unlock()
 
-		// Now index the file AFTER releasing the lock
-		if extractedFile != nil && l.indexConfig != nil {
-			copiedCfg := *l.indexConfig
-			copiedCfg.SpecAbsolutePath = name
-			copiedCfg.AvoidBuildIndex = true
-			copiedCfg.SpecInfo = nil
+		return nil, extErr
+	}
 
-			// Add this file to the context's indexing set to prevent deadlocks
-			// when circular references cause the same file to be looked up recursively.
-			indexingCtx := AddIndexingFile(ctx, name)
+	// Store in Files and release the waiter BEFORE indexing to prevent deadlocks.
+	// If file A needs file B and file B needs file A, holding the lock during indexing
+	// would cause a deadlock. The indexOnce in IndexWithContext handles concurrent
+	// access to index creation safely.
+	if extractedFile != nil {
+		l.Files.Store(name, extractedFile)
+	}
 
-			idx, _ := extractedFile.IndexWithContext(indexingCtx, &copiedCfg)
-			if idx != nil && l.rolodex != nil {
-				idx.rolodex = l.rolodex
-			}
+	processingWaiter.file = extractedFile
+	processingWaiter.error = extErr
+	processingWaiter.done = true
+	l.processingFiles.Delete(name)
+	processingWaiter.mu.Unlock()
 
-			if idx != nil {
-				resolver := NewResolver(idx)
-				idx.resolver = resolver
-				idx.BuildIndex()
-			}
-			if len(extractedFile.data) > 0 {
-				l.logger.Debug("[rolodex file loader]: successfully loaded and indexed file", "file", name)
-			}
+	// Now index the file AFTER releasing the lock
+	if extractedFile != nil && l.indexConfig != nil {
+		l.rolodex.fileDiscovered(name)
+		copiedCfg := *l.indexConfig
+		copiedCfg.SpecAbsolutePath = name
+		copiedCfg.AvoidBuildIndex = true
+		copiedCfg.SpecInfo = nil
+
+		// Add this file to the context's indexing set to prevent deadlocks
+		// when circular references cause the same file to be looked up recursively.
+		indexingCtx := AddIndexingFile(ctx, name)
+
+		idx, _ := extractedFile.IndexWithContext(indexingCtx, &copiedCfg)
+
+		// an index that has already been built is shared with another file that has the same content.
+		if idx != nil && !idx.built {
 			if l.rolodex != nil {
-				l.rolodex.AddIndex(idx)
+				idx.rolodex = l.rolodex
 			}
+			resolver := NewResolver(idx)
+			idx.resolver = resolver
+			idx.BuildIndex()
+			l.rolodex.registerIndexContent(extractedFile.content(), idx)
 		}
-
-		// Signal that indexing is complete - other goroutines waiting for this file can proceed
-		if extractedFile != nil {
-			extractedFile.signalIndexingComplete()
+		if extractedFile.Size() > 0 {
+			l.logger.Debug("[rolodex file loader]: successfully loaded and indexed file", "file", name)
+		}
+		if l.rolodex != nil {
+			l.rolodex.AddIndex(idx)
+			l.rolodex.fileIndexed(name)
 		}
+	}
 
-		return extractedFile, nil
+	// Signal that indexing is complete - other goroutines waiting for this file can proceed
+	if extractedFile != nil {
+		extractedFile.signalIndexingComplete()
 	}
 
-	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	return extractedFile, nil
 }
 
 // Open opens a file, returning it or an error. If the file is not found, the error is of type *PathError.
@@ -205,6 +214,55 @@ type LocalFile struct {
 	parseMutex       sync.Mutex
 	indexOnce        sync.Once
 	indexingComplete chan struct{} // Closed when indexing is complete
+	localFS          *LocalFS      // the file system the file was read from, used to read it again once evicted.
+	dataLock         sync.Mutex
+	evicted          bool
+}
+
+// content returns the content of the file, reading it again if it was evicted from the content cache of the
+// rolodex.
+func (l *LocalFile) content() []byte {
+	l.dataLock.Lock()
+	if l.evicted {
+		l.data = l.reload()
+		l.evicted = false
+	}
+	data := l.data
+	l.dataLock.Unlock()
+	if cache := l.contentCache(); cache != nil && data != nil {
+		cache.use(l, int64(len(data)))
+	}
+	return data
+}
+
+// contentCache returns the content cache of the rolodex the file belongs to, if it has one.
+func (l *LocalFile) contentCache() *contentCache {
+	if l.localFS == nil || l.localFS.rolodex == nil {
+		return nil
+	}
+	return l.localFS.rolodex.contentCache
+}
+
+// reload reads the content of the file again.
+func (l *LocalFile) reload() []byte {
+	lf, err := l.localFS.extractFile(l.filename)
+	if err != nil || lf == nil {
+		l.localFS.logger.Error("[rolodex file loader]: unable to read evicted file again", "file", l.fullPath,
+			"error", err)
+		return nil
+	}
+	return lf.data
+}
+
+// evictContent drops the content of the file from memory.
+func (l *LocalFile) evictContent() {
+	l.dataLock.Lock()
+	l.data = nil
+	l.evicted = true
+	l.dataLock.Unlock()
+	l.parseMutex.Lock()
+	l.parsed = nil
+	l.parseMutex.Unlock()
 }
 
 // GetIndex returns the *SpecIndex for the file.
@@ -247,7 +305,11 @@ func (l *LocalFile) IndexWithContext(ctx context.Context, config *SpecIndexConfi
 	var result *SpecIndex
 	var resultErr error
 	l.indexOnce.Do(func() {
-		content := l.data
+		content := l.content()
+		if shared := config.Rolodex.FindIndexByContent(l.fullPath, content); shared != nil {
+			l.index.Store(shared)
+			return
+		}
 		// first, we must parse the content of the file,
 		// the check is bypassed, so as long as it's readable, we're good.
 		info, _ := datamodel.ExtractSpecInfoWithDocumentCheck(content, true)
@@ -268,7 +330,7 @@ func (l *LocalFile) IndexWithContext(ctx context.Context, config *SpecIndexConfi
 
 // GetContent returns the content of the file as a string.
 func (l *LocalFile) GetContent() string {
-	return string(l.data)
+	return string(l.content())
 }
 
 // GetContentAsYAMLNode returns the content of the file as a *yaml.Node. If something went wrong
@@ -279,6 +341,9 @@ func (l *LocalFile) GetContentAsYAMLNode() (*yaml.Node, error) {
 		return idx.GetRootNode(), nil
 	}
 
+	// read the content before locking, reading it can evict (and lock) other files.
+	data := l.content()
+
 	// Lock before proceeding with parsing or modifications
 	l.parseMutex.Lock()
 	defer l.parseMutex.Unlock()
@@ -289,11 +354,11 @@ func (l *LocalFile) GetContentAsYAMLNode() (*yaml.Node, error) {
 		return l.parsed, nil
 	}
 
-	if l.data == nil {
+	if data == nil {
 		return nil, fmt.Errorf("no data to parse for file: %s", l.fullPath)
 	}
 	var root yaml.Node
-	err := yaml.Unmarshal(l.data, &root)
+	err := yaml.Unmarshal(data, &root)
 	if err != nil {
 		// we can't parse it, so create a fake document node with a single string content
 		root = yaml.Node{
@@ -302,7 +367,7 @@ func (l *LocalFile) GetContentAsYAMLNode() (*yaml.Node, error) {
 				{
 					Kind:  yaml.ScalarNode,
 					Tag:   "!!str",
-					Value: string(l.data),
+					Value: string(data),
 				},
 			},
 		}
@@ -341,7 +406,7 @@ func (l *LocalFile) Name() string {
 
 // Size returns the size of the file.
 func (l *LocalFile) Size() int64 {
-	return int64(len(l.data))
+	return int64(len(l.content()))
 }
 
 // Mode returns the file mode bits for the file.
@@ -376,13 +441,14 @@ func (l *LocalFile) Stat() (fs.FileInfo, error) {
 
 // Read reads the file into a byte slice, makes it compatible with io.Reader.
 func (l *LocalFile) Read(b []byte) (int, error) {
-	if l.offset >= int64(len(l.GetContent())) {
+	data := l.content()
+	if l.offset >= int64(len(data)) {
 		return 0, io.EOF
 	}
 	if l.offset < 0 {
 		return 0, &fs.PathError{Op: "read", Path: l.GetFullPath(), Err: fs.ErrInvalid}
 	}
-	n := copy(b, l.GetContent()[l.offset:])
+	n := copy(b, data[l.offset:])
 	l.offset += int64(n)
 	return n, nil
 }
@@ -398,9 +464,13 @@ type LocalFSConfig struct {
 	// supply a list of specific files to index only
 	FileFilters []string
 
-	// supply a custom fs.FS to use
+	// supply a custom fs.FS to use (for example an embed.FS), the root of the fs.FS is the base directory.
 	DirFS fs.FS
 
+	// read files from DirFS when they are first opened (like files on disk), instead of reading (and indexing)
+	// every file in DirFS when the file system is created. File filters are not used.
+	LazyDirFS bool
+
 	// supply an index configuration to use
 	IndexConfig *SpecIndexConfig
 }
@@ -410,6 +480,9 @@ func NewLocalFSWithConfig(config *LocalFSConfig) (*LocalFS, error) {
 	var allErrors []error
 
 	log := config.Logger
+	if log == nil && config.IndexConfig != nil {
+		log = config.IndexConfig.GetSubsystemLogger(datamodel.LogSubsystemIndex)
+	}
 	if log == nil {
 		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level: slog.LevelError,
@@ -432,7 +505,7 @@ func NewLocalFSWithConfig(config *LocalFSConfig) (*LocalFS, error) {
 	}
 
 	// if a directory filesystem is supplied, use that to walk the directory and pick up everything it finds.
-	if config.DirFS != nil {
+	if config.DirFS != nil && !config.LazyDirFS {
 		walkErr := fs.WalkDir(config.DirFS, ".", func(p string, d fs.DirEntry, err error) error {
 			if err != nil {
 				return err
@@ -476,6 +549,25 @@ func NewLocalFSWithConfig(config *LocalFSConfig) (*LocalFS, error) {
 	return localFS, nil
 }
 
+// dirFSPath returns the path of a file in DirFS, which is relative to the base directory, and slash separated.
+// Paths outside the base directory are returned as they are, and are rejected by DirFS.
+func (l *LocalFS) dirFSPath(p string) string {
+	if filepath.IsAbs(p) {
+		if rel, err := filepath.Rel(l.baseDirectory, p); err == nil {
+			p = rel
+		}
+	}
+	return filepath.ToSlash(p)
+}
+
+// stat returns information about an (absolute) file, from DirFS if it's set, or the operating system.
+func (l *LocalFS) stat(p string) (fs.FileInfo, error) {
+	if l.fsConfig != nil && l.fsConfig.DirFS != nil {
+		return fs.Stat(l.fsConfig.DirFS, l.dirFSPath(p))
+	}
+	return os.Stat(p)
+}
+
 func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 	extension := ExtractFileType(p)
 	var readingErrors []error
@@ -494,7 +586,12 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 		var file fs.File
 		if config != nil && config.DirFS != nil {
 			l.logger.Debug("[rolodex file loader]: collecting file from dirFS", "file", extension, "location", abs)
-			file, _ = config.DirFS.Open(p)
+			var fileError error
+			file, fileError = config.DirFS.Open(l.dirFSPath(p))
+			if fileError != nil {
+				return nil, fileError
+			}
+			defer file.Close()
 		} else {
 			l.logger.Debug("[rolodex file loader]: reading local file from OS", "file", extension, "location", abs)
 			var fileError error
@@ -513,6 +610,16 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 		}
 		fileData, _ = io.ReadAll(file)
 
+		transformConfig := l.indexConfig
+		if transformConfig == nil && l.rolodex != nil {
+			transformConfig = l.rolodex.indexConfig
+		}
+		var transformErr error
+		if fileData, transformErr = transformConfig.TransformFile(abs, fileData); transformErr != nil {
+			l.logger.Error("[rolodex file loader]: unable to transform file", "location", abs, "error", transformErr)
+			return nil, transformErr
+		}
+
 		lf := &LocalFile{
 			filename:         p,
 			name:             filepath.Base(p),
@@ -522,6 +629,7 @@ func (l *LocalFS) extractFile(p string) (*LocalFile, error) {
 			lastModified:     modTime,
 			readingErrors:    readingErrors,
 			indexingComplete: make(chan struct{}),
+			localFS:          l,
 		}
 		// Note: We intentionally don't store in l.Files here.
 		// The caller is responsible for storing after the file is fully processed
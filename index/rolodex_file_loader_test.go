@@ -4,6 +4,8 @@
 package index
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"io/fs"
 	"log/slog"
@@ -15,6 +17,7 @@ import (
 	"time"
 
 	"context"
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 	"go.yaml.in/yaml/v4"
 )
@@ -417,3 +420,83 @@ func TestLocalFile_SignalIndexingComplete_NilChannel(t *testing.T) {
 		lf.signalIndexingComplete()
 	}, "signalIndexingComplete should not panic when channel is nil")
 }
+
+func TestRolodexLocalFS_FileTransformers(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "plain.yaml"), []byte("\xef\xbb\xbfname: plain"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "secret.enc.yaml"), []byte("name: ENCRYPTED"), 0o644)
+	_ = os.WriteFile(filepath.Join(dir, "broken.enc.yaml"), []byte("name: nope"), 0o644)
+
+	config := CreateOpenAPIIndexConfig()
+	config.FileTransformers = []*datamodel.FileTransformer{
+		datamodel.StripBOMTransformer,
+		{
+			Name:    "decrypt",
+			Pattern: "*.enc.yaml",
+			Transform: func(location string, data []byte) ([]byte, error) {
+				if !bytes.Contains(data, []byte("ENCRYPTED")) {
+					return nil, errors.New("not encrypted")
+				}
+				return bytes.ReplaceAll(data, []byte("ENCRYPTED"), []byte("decrypted")), nil
+			},
+		},
+	}
+
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: dir,
+		IndexConfig:   config,
+	})
+	assert.NoError(t, err)
+
+	f, err := fileFS.Open("plain.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "name: plain", f.(*LocalFile).GetContent())
+
+	f, err = fileFS.Open("secret.enc.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "name: decrypted", f.(*LocalFile).GetContent())
+
+	_, err = fileFS.Open("broken.enc.yaml")
+	assert.ErrorContains(t, err, "file transformer 'decrypt' failed to transform")
+	assert.ErrorContains(t, err, "not encrypted")
+}
+
+func TestLocalFS_LazyDirFS(t *testing.T) {
+	dirFS := strictFS{FS: fstest.MapFS{
+		"openapi.yaml":      {Data: []byte("openapi: 3.1.0")},
+		"models/pet.yaml":   {Data: []byte("Pet:\n  $ref: 'owner.yaml#/Owner'")},
+		"models/owner.yaml": {Data: []byte("Owner:\n  type: object")},
+	}}
+	base, _ := filepath.Abs(filepath.Join(string(filepath.Separator), "embedded", "specs"))
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.AllowFileLookup = true
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{
+		BaseDirectory: base,
+		DirFS:         dirFS,
+		LazyDirFS:     true,
+		IndexConfig:   cf,
+	})
+	assert.NoError(t, err)
+	rolo := NewRolodex(cf)
+	rolo.AddLocalFS(base, fileFS)
+
+	// nothing is read until it's opened.
+	assert.Empty(t, fileFS.GetFiles())
+
+	f, err := rolo.Open("models/pet.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(base, "models", "pet.yaml"), f.GetFullPath())
+	assert.NotNil(t, f.GetIndex())
+
+	// the relative reference in the file was resolved inside the fs.FS.
+	owner, err := fileFS.Open(filepath.Join(base, "models", "owner.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Owner:\n  type: object", owner.(*LocalFile).GetContent())
+	assert.Len(t, fileFS.GetFiles(), 2)
+
+	_, err = fileFS.Open(filepath.Join(base, "models", "missing.yaml"))
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fileFS.Open(filepath.Join(base, "..", "outside.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,353 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// GitRepositories checks out revisions (branches, tags or commits) of git repositories with the git command, so
+// references to files in them can be resolved. Repositories are fetched into bare repositories kept in a directory,
+// and each commit is checked out once, into a directory of its own. Commits never change, so checkouts are shared by
+// every GitRepositories that uses the same directory, and kept between runs.
+//
+// The RemoteFS resolves references with a 'git+' scheme with a GitRepositories, when AllowGitReferences is set. A
+// checkout can also be used as the local file system of a document. Repositories and revisions come from documents,
+// so only repositories served over https, ssh or git are fetched, and revisions must be valid branch or tag names,
+// or commit hashes.
+type GitRepositories struct {
+	dir       string
+	protocols string   // the transports git is allowed to use, as GIT_ALLOW_PROTOCOL.
+	revisions sync.Map // repository@revision -> *gitRevision
+	locks     sync.Map // repository directory -> *sync.Mutex
+}
+
+// gitProtocols are the transports repositories can be fetched with. Transports like 'file' and 'ext' would let a
+// document read the file system, or run commands.
+const gitProtocols = "https:ssh:git"
+
+// gitRevision is a revision of a repository, checked out into a directory.
+type gitRevision struct {
+	lock   sync.Mutex
+	done   bool
+	dir    string
+	commit string
+	time   time.Time
+}
+
+// NewGitRepositories creates a GitRepositories that keeps repositories in a directory. If the directory is empty,
+// a 'libopenapi-git' directory in the temporary directory of the system is used.
+func NewGitRepositories(dir string) *GitRepositories {
+	if dir == "" {
+		dir = filepath.Join(os.TempDir(), "libopenapi-git")
+	}
+	return &GitRepositories{dir: dir, protocols: gitProtocols}
+}
+
+// Checkout checks out a revision of a repository (the default branch, if the revision is empty), and returns the
+// directory it's checked out in. Each revision is fetched once, unless fetching it fails, in which case it's fetched
+// again the next time it's checked out.
+func (g *GitRepositories) Checkout(ctx context.Context, repository, revision string) (string, error) {
+	r, err := g.checkout(ctx, repository, revision)
+	if err != nil {
+		return "", err
+	}
+	return r.dir, nil
+}
+
+// checkout checks out a revision once. Errors are not kept, they can be caused by the context of the caller (when
+// it's cancelled), or by the network, so a revision that fails is forgotten, and resolved again by the next caller.
+func (g *GitRepositories) checkout(ctx context.Context, repository, revision string) (*gitRevision, error) {
+	key := repository + "@" + revision
+	v, _ := g.revisions.LoadOrStore(key, &gitRevision{})
+	r := v.(*gitRevision)
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.done {
+		return r, nil
+	}
+	var resolved gitRevision
+	if err := g.resolve(ctx, repository, revision, &resolved); err != nil {
+		g.revisions.CompareAndDelete(key, r)
+		return nil, err
+	}
+	r.dir, r.commit, r.time, r.done = resolved.dir, resolved.commit, resolved.time, true
+	return r, nil
+}
+
+// resolve fetches a revision of a repository (unless it's a commit that has been fetched already), and checks it
+// out.
+func (g *GitRepositories) resolve(ctx context.Context, repository, revision string, r *gitRevision) error {
+	if err := g.checkRepository(repository); err != nil {
+		return err
+	}
+	if err := g.checkRevision(ctx, revision); err != nil {
+		return err
+	}
+	sum := sha256.Sum256([]byte(repository))
+	repoDir := filepath.Join(g.dir, "repositories", hex.EncodeToString(sum[:8]))
+
+	// fetches into a repository are not safe to run at the same time, they share FETCH_HEAD.
+	lock, _ := g.locks.LoadOrStore(repoDir, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	if _, err := os.Stat(repoDir); err != nil {
+		if err = os.MkdirAll(filepath.Dir(repoDir), 0o755); err != nil {
+			return err
+		}
+		if _, err = g.run(ctx, "", "init", "--bare", "-q", repoDir); err != nil {
+			return err
+		}
+	}
+
+	if isCommitHash(revision) {
+		if _, err := g.run(ctx, repoDir, "cat-file", "-e", revision+"^{commit}"); err == nil {
+			r.commit = revision
+		}
+	}
+	if r.commit == "" {
+		ref := revision
+		if ref == "" {
+			ref = "HEAD"
+		}
+		if _, err := g.run(ctx, repoDir, "fetch", "-q", "--depth", "1", "--no-tags", "--", repository, ref); err != nil {
+			return fmt.Errorf("unable to fetch '%s' from git repository '%s': %w", ref, repository, err)
+		}
+		out, err := g.run(ctx, repoDir, "rev-parse", "FETCH_HEAD^{commit}")
+		if err != nil {
+			return err
+		}
+		r.commit = strings.TrimSpace(out)
+		if !isCommitHash(r.commit) {
+			return fmt.Errorf("git rev-parse: unexpected commit '%s'", r.commit)
+		}
+	}
+
+	if out, err := g.run(ctx, repoDir, "show", "-s", "--format=%ct", r.commit); err == nil {
+		if seconds, parseErr := strconv.ParseInt(strings.TrimSpace(out), 10, 64); parseErr == nil {
+			r.time = time.Unix(seconds, 0)
+		}
+	}
+
+	var err error
+	r.dir, err = g.extract(ctx, repoDir, r.commit)
+	return err
+}
+
+// extract checks out a commit into a directory of its own, unless it has been already.
+func (g *GitRepositories) extract(ctx context.Context, repoDir, commit string) (string, error) {
+	checkout := filepath.Join(g.dir, "checkouts", commit)
+	if _, err := os.Stat(checkout); err == nil {
+		return checkout, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(checkout), 0o755); err != nil {
+		return "", err
+	}
+
+	// the commit is extracted into a temporary directory, and moved into place once it's complete.
+	tmp, err := os.MkdirTemp(filepath.Dir(checkout), commit+"-")
+	if err != nil {
+		return "", err
+	}
+	cmd := g.command(ctx, repoDir, "archive", "--format=tar", commit)
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		if err = cmd.Start(); err == nil {
+			err = untar(tmp, stdout)
+			_, _ = io.Copy(io.Discard, stdout)
+			if waitErr := cmd.Wait(); err == nil {
+				err = waitErr
+			}
+		}
+	}
+	if err == nil {
+		if err = os.Rename(tmp, checkout); err != nil {
+			if _, statErr := os.Stat(checkout); statErr == nil {
+				// checked out at the same time by someone else.
+				_ = os.RemoveAll(tmp)
+				return checkout, nil
+			}
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(tmp)
+		return "", fmt.Errorf("unable to check out commit '%s': %w", commit, err)
+	}
+	return checkout, nil
+}
+
+// checkRepository returns an error if a repository is not fetched with one of the allowed transports.
+func (g *GitRepositories) checkRepository(repository string) error {
+	u, err := url.Parse(repository)
+	if err != nil {
+		return fmt.Errorf("invalid git repository '%s': %w", repository, err)
+	}
+	for _, protocol := range strings.Split(g.protocols, ":") {
+		if u.Scheme == protocol {
+			return nil
+		}
+	}
+	return fmt.Errorf("git repository '%s' is not fetched over %s", u.Redacted(),
+		strings.ReplaceAll(g.protocols, ":", ", "))
+}
+
+// checkRevision returns an error if a revision is not empty, a commit hash, or a valid branch or tag name. Revisions
+// are passed to git as arguments, so one that looks like an option is never valid.
+func (g *GitRepositories) checkRevision(ctx context.Context, revision string) error {
+	if revision == "" || isCommitHash(revision) {
+		return nil
+	}
+	if strings.HasPrefix(revision, "-") {
+		return fmt.Errorf("invalid git revision '%s'", revision)
+	}
+	if _, err := g.run(ctx, "", "check-ref-format", "--allow-onelevel", revision); err != nil {
+		return fmt.Errorf("invalid git revision '%s': %w", revision, err)
+	}
+	return nil
+}
+
+// fetch fetches the file a git reference refers to, as an HTTP response, so the RemoteFS can treat it like any other
+// remote document. Files that don't exist are a 404 response.
+func (g *GitRepositories) fetch(u *url.URL) (*http.Response, error) {
+	repository, revision, file, err := parseGitURL(u)
+	if err != nil {
+		return nil, err
+	}
+	r, err := g.checkout(context.Background(), repository, revision)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(r.dir, filepath.FromSlash(file)))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return notFoundResponse(fmt.Errorf("'%s' does not exist in git repository '%s' at commit '%s'",
+				file, repository, r.commit)), nil
+		}
+		return nil, err
+	}
+	return fileResponse(f, r.time), nil
+}
+
+func (g *GitRepositories) command(ctx context.Context, dir string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_TERMINAL_PROMPT=0", // fail, instead of waiting for credentials.
+		"GIT_ALLOW_PROTOCOL="+g.protocols)
+	return cmd
+}
+
+// run runs a git command, and returns what it writes to stdout.
+func (g *GitRepositories) run(ctx context.Context, dir string, args ...string) (string, error) {
+	out, err := g.command(ctx, dir, args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(out), nil
+}
+
+// parseGitURL splits a git reference, like 'git+https://github.com/org/specs.git/schemas/pet.yaml?ref=v1.2', into
+// the URL of the repository, the revision, and the path of the file in the repository.
+//
+// The file is the path after the '.git' segment. Some tools put it in the fragment instead, like
+// 'git+https://github.com/org/specs.git?ref=v1.2#/schemas/pet.yaml', but the fragment of a reference is a JSON
+// pointer into the file it refers to, and is removed before the file is fetched, so that form is not supported.
+func parseGitURL(u *url.URL) (repository, revision, file string, err error) {
+	transport, ok := strings.CutPrefix(u.Scheme, "git+")
+	if !ok || transport == "" {
+		return "", "", "", fmt.Errorf("'%s' is not a git reference", u.Redacted())
+	}
+	if strings.HasSuffix(u.Path, ".git") {
+		return "", "", "", fmt.Errorf("git reference '%s' refers to a repository, the path of a file must follow "+
+			"the '.git' path segment, like 'specs.git/schemas/pet.yaml'", u.Redacted())
+	}
+	repoPath, file, found := strings.Cut(u.Path, ".git/")
+	if !found {
+		return "", "", "", fmt.Errorf("git reference '%s' has no '.git' path segment, to end the repository",
+			u.Redacted())
+	}
+	file = path.Clean(file)
+	if !fs.ValidPath(file) || file == "." {
+		return "", "", "", fmt.Errorf("git reference '%s' has an invalid file path '%s'", u.Redacted(), file)
+	}
+	repo := url.URL{Scheme: transport, User: u.User, Host: u.Host, Path: repoPath + ".git"}
+	return repo.String(), u.Query().Get("ref"), file, nil
+}
+
+// isGitURL returns true if a URL is a git reference.
+func isGitURL(u *url.URL) bool {
+	return strings.HasPrefix(u.Scheme, "git+")
+}
+
+// isCommitHash returns true if a revision is a full SHA-1 or SHA-256 commit hash.
+func isCommitHash(revision string) bool {
+	if len(revision) != 40 && len(revision) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(revision)
+	return err == nil
+}
+
+// untar extracts the regular files and directories of a tar archive into a directory.
+func untar(dir string, r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		h, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := filepath.FromSlash(h.Name)
+		if !filepath.IsLocal(name) {
+			continue
+		}
+		target := filepath.Join(dir, name)
+		switch h.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err = os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			f, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(f, tr)
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gitTestRepository creates a repository with two commits of 'models/pet.yaml', the first tagged 'v1', and returns
+// its directory and the hash of the first commit.
+func gitTestRepository(t *testing.T) (string, string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := filepath.Join(t.TempDir(), "specs.git")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "models"), 0o755))
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=specs", "-c", "user.email=specs@pb33f.io"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "-q")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models", "pet.yaml"), []byte("type: object"), 0o644))
+	git("add", ".")
+	git("commit", "-q", "-m", "v1")
+	git("tag", "v1")
+	first := git("rev-parse", "HEAD")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "models", "pet.yaml"), []byte("type: string"), 0o644))
+	git("commit", "-q", "-am", "v2")
+	return dir, first
+}
+
+func TestGitRepositories_Checkout(t *testing.T) {
+	repo, first := gitTestRepository(t)
+	cacheDir := t.TempDir()
+	repository := "file://" + filepath.ToSlash(repo)
+	ctx := context.Background()
+
+	// test repositories are local, which is only allowed explicitly.
+	_, err := NewGitRepositories(cacheDir).Checkout(ctx, repository, "v1")
+	assert.ErrorContains(t, err, "is not fetched over https, ssh, git")
+	repos := NewGitRepositories(cacheDir)
+	repos.protocols = "file"
+	dir, err := repos.Checkout(ctx, repository, "v1")
+	require.NoError(t, err)
+	data, err := os.ReadFile(filepath.Join(dir, "models", "pet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "type: object", string(data))
+
+	head, err := repos.Checkout(ctx, repository, "")
+	require.NoError(t, err)
+	data, err = os.ReadFile(filepath.Join(head, "models", "pet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "type: string", string(data))
+
+	// commits that have been fetched are checked out again without the repository.
+	require.NoError(t, os.RemoveAll(repo))
+	repos = NewGitRepositories(cacheDir)
+	repos.protocols = "file"
+	dir, err = repos.Checkout(ctx, repository, first)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(cacheDir, "checkouts", first), dir)
+
+	_, err = repos.Checkout(ctx, repository, "v2")
+	assert.ErrorContains(t, err, "unable to fetch 'v2'")
+}
+
+func TestGitRepositories_CheckoutFailed(t *testing.T) {
+	repo, _ := gitTestRepository(t)
+	repos := NewGitRepositories(t.TempDir())
+	repos.protocols = "file"
+	repository := "file://" + filepath.ToSlash(repo)
+
+	// a checkout that fails is not kept, so a cancelled caller doesn't fail the callers that follow it.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := repos.Checkout(ctx, repository, "v1")
+	require.Error(t, err)
+	_, found := repos.revisions.Load(repository + "@v1")
+	assert.False(t, found)
+
+	dir, err := repos.Checkout(context.Background(), repository, "v1")
+	require.NoError(t, err)
+	assert.FileExists(t, filepath.Join(dir, "models", "pet.yaml"))
+}
+
+func TestGitRepositories_InvalidRevisions(t *testing.T) {
+	repo, _ := gitTestRepository(t)
+	marker := filepath.Join(t.TempDir(), "marker")
+	repos := NewGitRepositories(t.TempDir())
+	repos.protocols = "file"
+
+	// revisions that look like options are never passed to git.
+	for _, revision := range []string{"--upload-pack=touch " + marker, "-v", "v1..v2", "refs/heads/"} {
+		_, err := repos.Checkout(context.Background(), "file://"+filepath.ToSlash(repo), revision)
+		assert.ErrorContains(t, err, "invalid git revision", revision)
+	}
+	assert.NoFileExists(t, marker)
+}
+
+func TestRemoteFS_GitReferences(t *testing.T) {
+	repo, first := gitTestRepository(t)
+	base := "git+file://" + filepath.ToSlash(repo) + "/models/pet.yaml"
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.AllowGitReferences = true
+	cf.GitCacheDir = t.TempDir()
+	rfs, err := NewRemoteFSWithConfig(cf)
+	require.NoError(t, err)
+	rfs.git.protocols = "file"
+	rolo := NewRolodex(cf)
+	rolo.AddRemoteFS("", rfs)
+
+	// the same file at different revisions are different files.
+	for ref, content := range map[string]string{"?ref=v1": "type: object", "": "type: string", "?ref=" + first: "type: object"} {
+		f, err := rolo.Open(base + ref)
+		require.NoError(t, err, ref)
+		assert.Equal(t, content, f.GetContent(), ref)
+		assert.NotNil(t, f.GetIndex())
+	}
+	assert.Len(t, rfs.GetFiles(), 3)
+
+	_, err = rfs.Open(strings.Replace(base, "pet.yaml", "vet.yaml", 1))
+	assert.ErrorContains(t, err, "error 404")
+
+	_, err = rfs.Open(base + "?ref=--upload-pack=true")
+	assert.ErrorContains(t, err, "invalid git revision")
+	_, err = rfs.Open(strings.Replace(base, "git+file", "git+ext", 1) + "?ref=v2")
+	assert.ErrorContains(t, err, "is not fetched over file")
+
+	// without AllowGitReferences, git references are fetched by the handler.
+	cf = CreateOpenAPIIndexConfig()
+	rfs, err = NewRemoteFSWithConfig(cf)
+	require.NoError(t, err)
+	_, err = rfs.Open(base)
+	assert.Error(t, err)
+}
+
+func TestParseGitURL(t *testing.T) {
+	u, _ := url.Parse("git+https://user@github.com/org/specs.git/schemas/pet.yaml?ref=v1.2")
+	repository, revision, file, err := parseGitURL(u)
+	require.NoError(t, err)
+	assert.Equal(t, "https://user@github.com/org/specs.git", repository)
+	assert.Equal(t, "v1.2", revision)
+	assert.Equal(t, "schemas/pet.yaml", file)
+
+	for _, ref := range []string{
+		"https://github.com/org/specs.git/pet.yaml",
+		"git+https://github.com/org/specs/pet.yaml",
+		"git+https://github.com/org/specs.git/../pet.yaml",
+		"git+https://github.com/org/specs.git/",
+		"git+https://github.com/org/specs.git?ref=v1.2#/schemas/pet.yaml",
+	} {
+		u, _ = url.Parse(ref)
+		_, _, _, err = parseGitURL(u)
+		assert.Error(t, err, ref)
+	}
+
+	u, _ = url.Parse("git+https://github.com/org/specs.git?ref=v1.2#/schemas/pet.yaml")
+	_, _, _, err = parseGitURL(u)
+	assert.ErrorContains(t, err, "refers to a repository")
+}
@@ -0,0 +1,116 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log/slog"
+)
+
+// LayeredFS is a file system that layers multiple file systems on top of each other. When a file is opened, each
+// layer is tried in order, and the first layer that can open the file wins. Layers that cannot open a file are
+// skipped, so lower layers are only used for files the layers above do not have.
+//
+// This allows a rolodex to resolve references against content that has not been written anywhere yet, for example
+// an editor can layer its unsaved buffers (using any fs.FS, such as an fstest.MapFS) over a LocalFS, or a local
+// working copy can be layered over a RemoteFS baseline.
+//
+// A LayeredFS is added to a rolodex the same way as any other file system, using AddLocalFS or AddRemoteFS. Every
+// layer is given the same path that would be given to a single file system added in the same way.
+type LayeredFS struct {
+	layers  []fs.FS
+	rolodex *Rolodex
+	logger  *slog.Logger
+}
+
+// NewLayeredFS creates a new LayeredFS from the supplied layers. The first layer has the highest precedence, and
+// the last layer has the lowest.
+func NewLayeredFS(layers ...fs.FS) *LayeredFS {
+	return &LayeredFS{layers: layers}
+}
+
+// GetLayers returns the layers of the file system, in order of precedence.
+func (l *LayeredFS) GetLayers() []fs.FS {
+	return l.layers
+}
+
+// SetRolodex sets the rolodex used by every layer that is a rolodex file system.
+func (l *LayeredFS) SetRolodex(rolodex *Rolodex) {
+	l.rolodex = rolodex
+	for _, layer := range l.layers {
+		switch f := layer.(type) {
+		case *RemoteFS:
+			f.rolodex = rolodex
+		case Rolodexable:
+			f.SetRolodex(rolodex)
+		}
+	}
+}
+
+// SetLogger sets the logger used by every layer that is a rolodex file system.
+func (l *LayeredFS) SetLogger(logger *slog.Logger) {
+	l.logger = logger
+	for _, layer := range l.layers {
+		switch f := layer.(type) {
+		case *RemoteFS:
+			f.logger = logger
+		case Rolodexable:
+			f.SetLogger(logger)
+		}
+	}
+}
+
+// GetFiles returns every file that has been opened by the layers that are rolodex file systems, keyed by the full
+// path of the file. If more than one layer has a file with the same path, the file from the highest layer is used.
+func (l *LayeredFS) GetFiles() map[string]RolodexFile {
+	files := make(map[string]RolodexFile)
+	for i := len(l.layers) - 1; i >= 0; i-- {
+		if rfs, ok := l.layers[i].(RolodexFS); ok {
+			for k, v := range rfs.GetFiles() {
+				files[k] = v
+			}
+		}
+	}
+	return files
+}
+
+// GetErrors returns the errors from every layer that reports errors.
+func (l *LayeredFS) GetErrors() []error {
+	var errs []error
+	for _, layer := range l.layers {
+		if e, ok := layer.(interface{ GetErrors() []error }); ok {
+			errs = append(errs, e.GetErrors()...)
+		}
+	}
+	return errs
+}
+
+// OpenWithContext opens a file from the highest layer that has it. If no layer can open the file, the errors from
+// every layer are returned.
+func (l *LayeredFS) OpenWithContext(ctx context.Context, name string) (fs.File, error) {
+	var errs []error
+	for _, layer := range l.layers {
+		f, err := openFile(ctx, name, layer)
+		if err == nil && f != nil {
+			if l.logger != nil {
+				l.logger.Debug("[rolodex layered fs] file opened", "file", name)
+			}
+			return f, nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist})
+	}
+	return nil, errors.Join(errs...)
+}
+
+// Open opens a file from the highest layer that has it.
+func (l *LayeredFS) Open(name string) (fs.File, error) {
+	return l.OpenWithContext(context.Background(), name)
+}
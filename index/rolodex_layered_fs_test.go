@@ -0,0 +1,74 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLayeredFS_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pet.yaml"), []byte("description: disk pet"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "toy.yaml"), []byte("description: disk toy"), 0o644))
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = dir
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: dir, IndexConfig: cf})
+	require.NoError(t, err)
+
+	buffers := fstest.MapFS{
+		"pet.yaml": {Data: []byte("description: unsaved pet"), ModTime: time.Now()},
+	}
+	layered := NewLayeredFS(buffers, localFS)
+	assert.Len(t, layered.GetLayers(), 2)
+
+	rolo := NewRolodex(cf)
+	rolo.AddLocalFS(dir, layered)
+	assert.Same(t, rolo, localFS.rolodex)
+
+	f, err := rolo.Open("pet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "description: unsaved pet", f.GetContent())
+
+	f, err = rolo.Open("toy.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "description: disk toy", f.GetContent())
+
+	// only the files opened by rolodex file systems are reported.
+	files := layered.GetFiles()
+	assert.Len(t, files, 1)
+	assert.Contains(t, files, filepath.Join(dir, "toy.yaml"))
+
+	_, err = rolo.Open("nope.yaml")
+	assert.Error(t, err)
+	_, err = layered.Open("nope.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Empty(t, layered.GetErrors())
+}
+
+func TestLayeredFS_Empty(t *testing.T) {
+	_, err := NewLayeredFS().OpenWithContext(context.Background(), "pet.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.Empty(t, NewLayeredFS().GetFiles())
+}
+
+func TestLayeredFS_Remote(t *testing.T) {
+	remoteFS, _ := NewRemoteFSWithRootURL("https://pb33f.io")
+	layered := NewLayeredFS(fstest.MapFS{}, remoteFS)
+
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	rolo.AddRemoteFS("https://pb33f.io", layered)
+	assert.Same(t, rolo, layered.rolodex)
+	assert.Same(t, rolo, remoteFS.rolodex)
+	assert.NotNil(t, remoteFS.logger)
+}
@@ -0,0 +1,222 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// fetchObject fetches the object a URL refers to (the host is the bucket, and the path is the key) from an object
+// store, as an HTTP response, so the RemoteFS can treat it like any other remote document. Objects that don't exist
+// are a 404 response.
+func fetchObject(store datamodel.ObjectStore, u *url.URL) (*http.Response, error) {
+	if u.Host == "" {
+		return nil, fmt.Errorf("object store reference '%s' has no bucket", u.String())
+	}
+	body, modified, err := store.GetObject(context.Background(), u.Host, strings.TrimPrefix(u.Path, "/"))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return notFoundResponse(err), nil
+		}
+		return nil, err
+	}
+	return fileResponse(body, modified), nil
+}
+
+// fileResponse is a successful response of a document fetched from somewhere other than an HTTP server.
+func fileResponse(body io.ReadCloser, modified time.Time) *http.Response {
+	header := http.Header{}
+	if !modified.IsZero() {
+		header.Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	return &http.Response{Status: "200 OK", StatusCode: http.StatusOK, Header: header, Body: body}
+}
+
+// notFoundResponse is the response of a document that does not exist, fetched from somewhere other than an HTTP
+// server.
+func notFoundResponse(err error) *http.Response {
+	return &http.Response{
+		Status:     "404 Not Found",
+		StatusCode: http.StatusNotFound,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(err.Error())),
+	}
+}
+
+// ObjectStoreFS is a read-only file system of the objects in a bucket of an object store, with keys that start with
+// a prefix. Names in the file system are the keys without the prefix, and the '/' separated parts of keys are
+// directories.
+//
+// An ObjectStoreFS can be used as the DirFS of a LocalFS (every object under the prefix is indexed), or as the
+// LocalFS of a document configuration, so the relative references of a specification kept in a bucket are resolved
+// from it.
+type ObjectStoreFS struct {
+	store  datamodel.ObjectStore
+	bucket string
+	prefix string
+}
+
+// NewObjectStoreFS creates an ObjectStoreFS of the objects in a bucket with keys that start with a prefix. An empty
+// prefix is the whole bucket.
+func NewObjectStoreFS(store datamodel.ObjectStore, bucket, prefix string) *ObjectStoreFS {
+	prefix = strings.TrimPrefix(prefix, "/")
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	return &ObjectStoreFS{store: store, bucket: bucket, prefix: prefix}
+}
+
+// Open opens the object, or the directory, with a name.
+func (o *ObjectStoreFS) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return &objectStoreDir{fsys: o, name: name}, nil
+	}
+	body, modified, err := o.store.GetObject(context.Background(), o.bucket, o.prefix+name)
+	if err == nil {
+		data, readErr := io.ReadAll(body)
+		_ = body.Close()
+		if readErr != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: readErr}
+		}
+		info := &objectStoreInfo{name: path.Base(name), size: int64(len(data)), modified: modified}
+		return &objectStoreFile{info: info, reader: bytes.NewReader(data)}, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	// there is no object with the name, but it may be a directory of other objects.
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+	return &objectStoreDir{fsys: o, name: name, entries: entries, read: true}, nil
+}
+
+// ReadDir lists the objects and directories in a directory, sorted by name.
+func (o *ObjectStoreFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	dirPrefix := o.prefix
+	if name != "." {
+		dirPrefix += name + "/"
+	}
+	keys, err := o.store.ListObjects(context.Background(), o.bucket, dirPrefix)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	seen := make(map[string]bool)
+	var entries []fs.DirEntry
+	for _, key := range keys {
+		rest := strings.TrimPrefix(key, dirPrefix)
+		if rest == "" || !strings.HasPrefix(key, dirPrefix) {
+			continue
+		}
+		info := &objectStoreInfo{name: rest}
+		if i := strings.IndexByte(rest, '/'); i >= 0 {
+			info = &objectStoreInfo{name: rest[:i], dir: true}
+		}
+		if seen[info.name] {
+			continue
+		}
+		seen[info.name] = true
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	if len(entries) == 0 && name != "." {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// objectStoreInfo describes an object, or a directory, of an ObjectStoreFS. The size of objects listed in a
+// directory is not known until they are opened.
+type objectStoreInfo struct {
+	name     string
+	size     int64
+	modified time.Time
+	dir      bool
+}
+
+func (i *objectStoreInfo) Name() string       { return i.name }
+func (i *objectStoreInfo) Size() int64        { return i.size }
+func (i *objectStoreInfo) ModTime() time.Time { return i.modified }
+func (i *objectStoreInfo) IsDir() bool        { return i.dir }
+func (i *objectStoreInfo) Sys() any           { return nil }
+
+func (i *objectStoreInfo) Mode() fs.FileMode {
+	if i.dir {
+		return fs.ModeDir | 0o555
+	}
+	return 0o444
+}
+
+// objectStoreFile is an object opened from an ObjectStoreFS.
+type objectStoreFile struct {
+	info   *objectStoreInfo
+	reader *bytes.Reader
+}
+
+func (f *objectStoreFile) Stat() (fs.FileInfo, error) { return f.info, nil }
+func (f *objectStoreFile) Read(b []byte) (int, error) { return f.reader.Read(b) }
+func (f *objectStoreFile) Close() error               { return nil }
+
+// objectStoreDir is a directory opened from an ObjectStoreFS, its entries are listed when they are first read.
+type objectStoreDir struct {
+	fsys    *ObjectStoreFS
+	name    string
+	entries []fs.DirEntry
+	read    bool
+	offset  int
+}
+
+func (d *objectStoreDir) Stat() (fs.FileInfo, error) {
+	return &objectStoreInfo{name: path.Base(d.name), dir: true}, nil
+}
+
+func (d *objectStoreDir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *objectStoreDir) Close() error { return nil }
+
+// ReadDir returns the next n entries of the directory, or all the remaining entries if n <= 0.
+func (d *objectStoreDir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if !d.read {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.read = entries, true
+	}
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	n = min(n, len(remaining))
+	d.offset += n
+	return remaining[:n], nil
+}
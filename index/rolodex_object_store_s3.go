@@ -0,0 +1,320 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// emptyPayloadHash is the SHA-256 hash of an empty request body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+var s3DefaultClient = &http.Client{Timeout: time.Second * 120}
+
+// S3Credentials are the credentials used to sign requests to an S3 compatible object store.
+type S3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // the token of temporary credentials, optional.
+}
+
+// S3CredentialsProvider returns the credentials to sign a request with. It's called for every request, so
+// credentials that expire can be refreshed.
+type S3CredentialsProvider func(ctx context.Context) (*S3Credentials, error)
+
+// StaticS3Credentials returns a provider of fixed credentials.
+func StaticS3Credentials(accessKeyID, secretAccessKey, sessionToken string) S3CredentialsProvider {
+	creds := &S3Credentials{AccessKeyID: accessKeyID, SecretAccessKey: secretAccessKey, SessionToken: sessionToken}
+	return func(context.Context) (*S3Credentials, error) {
+		return creds, nil
+	}
+}
+
+// EnvironmentS3Credentials provides the credentials in the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and
+// AWS_SESSION_TOKEN environment variables.
+func EnvironmentS3Credentials(context.Context) (*S3Credentials, error) {
+	creds := &S3Credentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are not set")
+	}
+	return creds, nil
+}
+
+// S3Store is a datamodel.ObjectStore for Amazon S3, and stores with an S3 compatible API, like Google Cloud Storage
+// (using HMAC keys), MinIO or Cloudflare R2. Requests are signed with AWS Signature Version 4.
+//
+// To resolve references like 's3://bucket/specs/pet.yaml', add a store to the ObjectStores of a configuration:
+//
+//	config.ObjectStores = map[string]datamodel.ObjectStore{
+//		"s3": &index.S3Store{Region: "eu-west-1", Credentials: index.EnvironmentS3Credentials},
+//		"gs": &index.S3Store{Region: "auto", Endpoint: "https://storage.googleapis.com", Credentials: hmacKeys},
+//	}
+type S3Store struct {
+	// Region is the region of the buckets, used to sign requests. Defaults to 'us-east-1'.
+	Region string
+
+	// Endpoint is the URL of the store, for example 'https://storage.googleapis.com'. Defaults to the Amazon S3
+	// endpoint of the region.
+	Endpoint string
+
+	// PathStyle puts buckets in the path of requests (https://endpoint/bucket/key), instead of the host
+	// (https://bucket.endpoint/key). Stores like MinIO need it.
+	PathStyle bool
+
+	// Credentials provides the credentials to sign requests with. If not set, requests are not signed, which works
+	// for public buckets.
+	Credentials S3CredentialsProvider
+
+	// Client sends requests. If not set, a client with a two minute timeout is used.
+	Client *http.Client
+}
+
+// GetObject returns the content of an object, and when it was last modified.
+func (s *S3Store) GetObject(ctx context.Context, bucket, key string) (io.ReadCloser, time.Time, error) {
+	resp, err := s.do(ctx, bucket, key, nil)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, time.Time{}, fmt.Errorf("object '%s' in bucket '%s': %w", key, bucket, fs.ErrNotExist)
+	}
+	if resp.StatusCode >= 300 {
+		return nil, time.Time{}, s3ResponseError(resp)
+	}
+	modified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+	return resp.Body, modified, nil
+}
+
+// s3ListResult is the result of a ListObjectsV2 request.
+type s3ListResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects returns the keys of every object in a bucket that starts with a prefix, following every page of
+// results.
+func (s *S3Store) ListObjects(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		resp, err := s.do(ctx, bucket, "", query)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 300 {
+			return nil, s3ResponseError(resp)
+		}
+		var result s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the objects of bucket '%s': %w", bucket, err)
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, c.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// do sends a signed GET request for a key (or the bucket, if the key is empty).
+func (s *S3Store) do(ctx context.Context, bucket, key string, query url.Values) (*http.Response, error) {
+	u, err := s.url(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = s3CanonicalQuery(query)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL = u
+	if s.Credentials != nil {
+		creds, err := s.Credentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get credentials for bucket '%s': %w", bucket, err)
+		}
+		signS3Request(req, creds, s.region(), time.Now())
+	}
+	client := s.Client
+	if client == nil {
+		client = s3DefaultClient
+	}
+	return client.Do(req)
+}
+
+func (s *S3Store) region() string {
+	if s.Region == "" {
+		return "us-east-1"
+	}
+	return s.Region
+}
+
+// url returns the URL of a key in a bucket.
+func (s *S3Store) url(bucket, key string) (*url.URL, error) {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + s.region() + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object store endpoint '%s': %w", endpoint, err)
+	}
+	p := "/" + s3Escape(key, true)
+	if s.PathStyle {
+		p = strings.TrimSuffix(u.EscapedPath(), "/") + "/" + s3Escape(bucket, false)
+		if key != "" {
+			p += "/" + s3Escape(key, true)
+		}
+	} else {
+		u.Host = bucket + "." + u.Host
+	}
+	u.RawPath = p
+	u.Path, _ = url.PathUnescape(p)
+	return u, nil
+}
+
+// s3ResponseError reads the error returned by a store, and closes the response.
+func s3ResponseError(resp *http.Response) error {
+	defer resp.Body.Close()
+	var body struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	_ = xml.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&body)
+	if body.Code == "" {
+		return fmt.Errorf("object store request '%s' failed with status %d", resp.Request.URL.Redacted(),
+			resp.StatusCode)
+	}
+	return fmt.Errorf("object store request '%s' failed with status %d: %s: %s", resp.Request.URL.Redacted(),
+		resp.StatusCode, body.Code, body.Message)
+}
+
+// signS3Request signs a request (without a body) with AWS Signature Version 4, adding the X-Amz-Date,
+// X-Amz-Content-Sha256, X-Amz-Security-Token (for temporary credentials) and Authorization headers. Every header
+// of the request is signed.
+func signS3Request(req *http.Request, creds *S3Credentials, region string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		req.Header.Set("X-Amz-Content-Sha256", emptyPayloadHash)
+	}
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range req.Header {
+		trimmed := make([]string, len(values))
+		for i, v := range values {
+			trimmed[i] = strings.Join(strings.Fields(v), " ")
+		}
+		headers[strings.ToLower(name)] = strings.Join(trimmed, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{date, region, "s3", "aws4_request"} {
+		key = s3HMAC(key, part)
+	}
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func s3HMAC(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3CanonicalQuery encodes a query, sorted by name and value, the way requests are signed.
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var params []string
+	for _, name := range names {
+		values := append([]string(nil), query[name]...)
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, s3Escape(name, false)+"="+s3Escape(v, false))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// s3Escape percent-encodes every byte of a string other than unreserved characters (and '/', for paths).
+func s3Escape(s string, path bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', path && c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// rolodexProgress counts the files a rolodex has discovered and indexed, and the references its indexes have
+// resolved. Files are counted once, however many times (and from however many goroutines) they are reported.
+type rolodexProgress struct {
+	discovered      sync.Map // location -> struct{}
+	indexed         sync.Map // location -> struct{}
+	filesDiscovered atomic.Int64
+	filesIndexed    atomic.Int64
+	refsResolved    atomic.Int64
+}
+
+// GetIndexingProgress returns how many files the rolodex has discovered and indexed, and how many references have
+// been resolved, so far.
+func (r *Rolodex) GetIndexingProgress() *datamodel.IndexingProgress {
+	// files are discovered before they are indexed, so the indexed count is loaded first, to never exceed it.
+	indexed := r.progress.filesIndexed.Load()
+	return &datamodel.IndexingProgress{
+		FilesDiscovered:    int(r.progress.filesDiscovered.Load()),
+		FilesIndexed:       int(indexed),
+		ReferencesResolved: int(r.progress.refsResolved.Load()),
+	}
+}
+
+// fileDiscovered records a file that has been found, and is about to be indexed.
+func (r *Rolodex) fileDiscovered(location string) {
+	if r == nil {
+		return
+	}
+	if _, seen := r.progress.discovered.LoadOrStore(location, struct{}{}); !seen {
+		r.progress.filesDiscovered.Add(1)
+		r.reportProgress(location)
+	}
+}
+
+// fileIndexed records a file that has been indexed. A file that was never discovered is discovered as well.
+func (r *Rolodex) fileIndexed(location string) {
+	if r == nil {
+		return
+	}
+	if _, seen := r.progress.discovered.LoadOrStore(location, struct{}{}); !seen {
+		r.progress.filesDiscovered.Add(1)
+	}
+	if _, seen := r.progress.indexed.LoadOrStore(location, struct{}{}); !seen {
+		r.progress.filesIndexed.Add(1)
+		r.reportProgress(location)
+	}
+}
+
+// referencesResolved records references located by the index of a file.
+func (r *Rolodex) referencesResolved(location string, count int) {
+	if r == nil || count == 0 {
+		return
+	}
+	r.progress.refsResolved.Add(int64(count))
+	r.reportProgress(location)
+}
+
+// reportProgress sends the progress of the rolodex to the IndexingProgressHandler of its configuration, if one is set.
+func (r *Rolodex) reportProgress(location string) {
+	if r.indexConfig == nil || r.indexConfig.IndexingProgressHandler == nil {
+		return
+	}
+	progress := r.GetIndexingProgress()
+	progress.CurrentFile = location
+	r.indexConfig.IndexingProgressHandler(progress)
+}
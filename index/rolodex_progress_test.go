@@ -0,0 +1,75 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestRolodex_IndexingProgress(t *testing.T) {
+	tmp := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "pet.yaml"),
+		[]byte("Pet:\n  type: object\n  properties:\n    owner:\n      $ref: 'owner.yaml#/Owner'"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "owner.yaml"), []byte("Owner:\n  type: object"), 0o644))
+	root := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'
+    Owner:
+      $ref: 'owner.yaml#/Owner'
+    Pets:
+      type: array
+      items:
+        $ref: '#/components/schemas/Pet'`
+
+	var mu sync.Mutex
+	var reports []*datamodel.IndexingProgress
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	cf.SpecAbsolutePath = filepath.Join(tmp, "root.yaml")
+	cf.IndexingProgressHandler = func(progress *datamodel.IndexingProgress) {
+		mu.Lock()
+		reports = append(reports, progress)
+		mu.Unlock()
+	}
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo := NewRolodex(cf)
+	rolo.AddLocalFS(tmp, localFS)
+	var rootNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(root), &rootNode))
+	rolo.SetRootNode(&rootNode)
+	require.NoError(t, rolo.IndexTheRolodex(context.Background()))
+
+	progress := rolo.GetIndexingProgress()
+	assert.Equal(t, 3, progress.FilesDiscovered)
+	assert.Equal(t, 3, progress.FilesIndexed)
+	assert.Equal(t, 4, progress.ReferencesResolved)
+
+	// every file is reported as it's discovered, and as it's indexed.
+	files := make(map[string]int)
+	for _, p := range reports {
+		files[p.CurrentFile]++
+		assert.LessOrEqual(t, p.FilesIndexed, p.FilesDiscovered)
+	}
+	for _, name := range []string{"root.yaml", "pet.yaml", "owner.yaml"} {
+		assert.GreaterOrEqual(t, files[filepath.Join(tmp, name)], 2, name)
+	}
+
+	// files that are opened again are not counted again.
+	_, err = rolo.Open(filepath.Join(tmp, "pet.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, rolo.GetIndexingProgress().FilesDiscovered)
+}
@@ -0,0 +1,71 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/tls"
+	"net/http"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// RemoteAuthTransport is an http.RoundTripper that authenticates every request it sends, using the authentications
+// (see datamodel.RemoteAuth) that match the host of the request. Requests to hosts that match an authentication
+// with client certificates or root CAs are sent over a transport of their own, configured with them.
+//
+// The RemoteAuth of a configuration is applied to the default HTTP client of the rolodex using this transport. A
+// RemoteURLHandler can use it in a client of its own.
+type RemoteAuthTransport struct {
+	auth       []*datamodel.RemoteAuth
+	base       http.RoundTripper
+	transports sync.Map // *datamodel.RemoteAuth -> http.RoundTripper
+}
+
+// NewRemoteAuthTransport creates a RemoteAuthTransport that authenticates requests, before sending them with the
+// base transport. If the base transport is nil, http.DefaultTransport is used.
+func NewRemoteAuthTransport(auth []*datamodel.RemoteAuth, base http.RoundTripper) *RemoteAuthTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RemoteAuthTransport{auth: auth, base: base}
+}
+
+// RoundTrip authenticates a copy of the request, and sends it.
+func (t *RemoteAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	authed := req.Clone(req.Context())
+	if err := datamodel.ApplyRemoteAuth(t.auth, authed); err != nil {
+		return nil, err
+	}
+	return t.transport(req.URL.Host).RoundTrip(authed)
+}
+
+// transport returns the transport that sends requests to a host, which is the transport of the first matching
+// authentication that changes TLS, or the base transport.
+func (t *RemoteAuthTransport) transport(host string) http.RoundTripper {
+	for _, a := range t.auth {
+		if !a.UsesTLS() || !a.Matches(host) {
+			continue
+		}
+		if rt, ok := t.transports.Load(a); ok {
+			return rt.(http.RoundTripper)
+		}
+		base, ok := t.base.(*http.Transport)
+		if !ok {
+			base = http.DefaultTransport.(*http.Transport)
+		}
+		tr := base.Clone()
+		if tr.TLSClientConfig == nil {
+			tr.TLSClientConfig = &tls.Config{}
+		}
+		tr.TLSClientConfig.Certificates = a.ClientCertificates
+		if a.RootCAs != nil {
+			tr.TLSClientConfig.RootCAs = a.RootCAs
+		}
+		rt, _ := t.transports.LoadOrStore(a, tr)
+		return rt.(http.RoundTripper)
+	}
+	return t.base
+}
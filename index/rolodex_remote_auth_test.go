@@ -0,0 +1,116 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRemoteFSWithConfig_RemoteAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Team") != "specs" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	rfs, err := NewRemoteFSWithConfig(&SpecIndexConfig{
+		RemoteCacheDir: t.TempDir(),
+		RemoteAuth: []*datamodel.RemoteAuth{{
+			HostPattern: "127.0.0.1",
+			Headers:     map[string]string{"X-Team": "specs"},
+			BearerToken: func(string) (string, error) { return "secret", nil },
+		}},
+	})
+	require.NoError(t, err)
+	resp, err := rfs.RemoteHandlerFunc(server.URL + "/pet.yaml")
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+
+	// hosts that don't match are not authenticated.
+	rfs, err = NewRemoteFSWithConfig(&SpecIndexConfig{
+		RemoteAuth: []*datamodel.RemoteAuth{{
+			HostPattern: "specs.example.com",
+			BearerToken: func(string) (string, error) { return "secret", nil },
+		}},
+	})
+	require.NoError(t, err)
+	resp, err = rfs.RemoteHandlerFunc(server.URL + "/pet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestRemoteAuthTransport_TokenError(t *testing.T) {
+	transport := NewRemoteAuthTransport([]*datamodel.RemoteAuth{{
+		BearerToken: func(string) (string, error) { return "", errors.New("expired") },
+	}}, nil)
+	_, err := (&http.Client{Transport: transport}).Get("http://127.0.0.1:1/pet.yaml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "expired")
+}
+
+func TestRemoteAuthTransport_ClientCertificates(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("client: " + r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	// without a certificate, the handshake fails.
+	transport := NewRemoteAuthTransport([]*datamodel.RemoteAuth{{RootCAs: roots}}, nil)
+	_, err := (&http.Client{Transport: transport}).Get(server.URL + "/pet.yaml")
+	require.Error(t, err)
+
+	transport = NewRemoteAuthTransport([]*datamodel.RemoteAuth{{
+		HostPattern:        "127.0.0.1",
+		ClientCertificates: []tls.Certificate{clientCertificate(t, "libopenapi")},
+		RootCAs:            roots,
+	}}, nil)
+	client := &http.Client{Transport: transport}
+	for range 2 {
+		resp, err := client.Get(server.URL + "/pet.yaml")
+		require.NoError(t, err)
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, "client: libopenapi", string(body))
+	}
+}
+
+// clientCertificate creates a self-signed client certificate.
+func clientCertificate(t *testing.T, name string) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
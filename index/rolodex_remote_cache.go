@@ -0,0 +1,240 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteCache is an on-disk cache of remote documents. Documents are revalidated with the server once they are
+// stale, using the ETag and Last-Modified headers the server sent with them, so unchanged documents are not
+// downloaded again. If the server cannot be reached (or fails), the cached document is used, so repeated builds
+// keep working on a flaky network.
+//
+// How long a document stays fresh (and is used without asking the server) is set by the Cache-Control header the
+// server sent with it: max-age is honored, no-cache documents are always revalidated, and no-store documents are not
+// cached at all. Documents served without a max-age are fresh for the TTL of the cache (see SetTTL), which is zero
+// by default, so they are revalidated every time they are fetched.
+//
+// Fetch is a utils.RemoteURLHandler, and can be used as the RemoteURLHandler of a configuration. The
+// RemoteCacheDir of a configuration does this with the default HTTP client.
+type RemoteCache struct {
+	dir    string
+	client *http.Client
+	ttl    time.Duration
+	now    func() time.Time
+	locks  sync.Map // a lock for every cached URL, so a URL is not fetched and written by two goroutines at once.
+}
+
+// remoteCacheEntry is the metadata stored next to the body of a cached document.
+type remoteCacheEntry struct {
+	URL    string      `json:"url"`
+	Header http.Header `json:"header"`
+
+	// Validated is when the document was last downloaded, or revalidated with the server.
+	Validated time.Time `json:"validated"`
+}
+
+// NewRemoteCache creates a RemoteCache that stores documents in a directory (which is created if it does not
+// exist), and fetches them with a client. If the client is nil, an HTTP client with a 120-second timeout is used.
+func NewRemoteCache(dir string, client *http.Client) (*RemoteCache, error) {
+	if dir == "" {
+		return nil, errors.New("no remote cache directory provided")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create remote cache directory '%s': %w", dir, err)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: time.Second * 120}
+	}
+	return &RemoteCache{dir: dir, client: client, now: time.Now}, nil
+}
+
+// SetTTL sets how long documents served without a Cache-Control max-age are used from the cache, before they are
+// revalidated with the server. A TTL of zero (the default) revalidates them every time.
+func (c *RemoteCache) SetTTL(ttl time.Duration) {
+	c.ttl = ttl
+}
+
+// fresh returns true if a cached document can be used without revalidating it.
+func (c *RemoteCache) fresh(entry *remoteCacheEntry) bool {
+	maxAge := c.ttl
+	for _, directive := range strings.Split(entry.Header.Get("Cache-Control"), ",") {
+		name, value, _ := strings.Cut(strings.ToLower(strings.TrimSpace(directive)), "=")
+		switch name {
+		case "no-cache", "no-store":
+			return false
+		case "max-age":
+			if seconds, err := strconv.Atoi(strings.Trim(value, `"`)); err == nil {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return maxAge > 0 && c.now().Sub(entry.Validated) < maxAge
+}
+
+// noStore returns true if the server does not allow a document to be cached.
+func noStore(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+// Fetch returns the document at a URL, from the cache if it is fresh or has not changed. The response is always
+// complete (a 304 from the server is returned as the cached 200 response).
+func (c *RemoteCache) Fetch(url string) (*http.Response, error) {
+	lock, _ := c.locks.LoadOrStore(url, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	key := c.key(url)
+	entry, body := c.load(key)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if entry != nil && c.fresh(entry) {
+		return cachedResponse(req, entry, body), nil
+	}
+	if entry != nil {
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			req.Header.Set("If-None-Match", etag)
+		}
+		if modified := entry.Header.Get("Last-Modified"); modified != "" {
+			req.Header.Set("If-Modified-Since", modified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if entry != nil {
+			return cachedResponse(req, entry, body), nil
+		}
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotModified && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		// the server can send new caching headers with a 304.
+		for _, h := range []string{"Cache-Control", "ETag", "Last-Modified"} {
+			if v := resp.Header.Get(h); v != "" {
+				entry.Header.Set(h, v)
+			}
+		}
+		entry.Validated = c.now()
+		c.store(key, entry, body)
+		return cachedResponse(req, entry, body), nil
+	case resp.StatusCode >= http.StatusInternalServerError && entry != nil:
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+		return cachedResponse(req, entry, body), nil
+	case resp.StatusCode != http.StatusOK:
+		return resp, nil
+	}
+
+	fetched, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		if entry != nil {
+			return cachedResponse(req, entry, body), nil
+		}
+		return nil, err
+	}
+	if noStore(resp.Header) {
+		c.remove(key)
+	} else {
+		c.store(key, &remoteCacheEntry{URL: url, Header: resp.Header, Validated: c.now()}, fetched)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(fetched))
+	resp.ContentLength = int64(len(fetched))
+	return resp, nil
+}
+
+// Clear removes every document from the cache.
+func (c *RemoteCache) Clear() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); ext == ".body" || ext == ".json" {
+			errs = append(errs, os.Remove(filepath.Join(c.dir, e.Name())))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *RemoteCache) key(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:]))
+}
+
+// load returns the cached metadata and body of a document, or nil if it is not cached (or cannot be read).
+func (c *RemoteCache) load(key string) (*remoteCacheEntry, []byte) {
+	meta, err := os.ReadFile(key + ".json")
+	if err != nil {
+		return nil, nil
+	}
+	body, err := os.ReadFile(key + ".body")
+	if err != nil {
+		return nil, nil
+	}
+	var entry remoteCacheEntry
+	if json.Unmarshal(meta, &entry) != nil {
+		return nil, nil
+	}
+	return &entry, body
+}
+
+// store writes a document to the cache. A document that cannot be cached is still returned, so errors are ignored.
+func (c *RemoteCache) store(key string, entry *remoteCacheEntry, body []byte) {
+	meta, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if os.WriteFile(key+".body", body, 0o644) != nil {
+		return
+	}
+	_ = os.WriteFile(key+".json", meta, 0o644)
+}
+
+// remove deletes a document from the cache.
+func (c *RemoteCache) remove(key string) {
+	_ = os.Remove(key + ".json")
+	_ = os.Remove(key + ".body")
+}
+
+func cachedResponse(req *http.Request, entry *remoteCacheEntry, body []byte) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
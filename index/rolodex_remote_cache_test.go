@@ -0,0 +1,220 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readCachedResponse(t *testing.T, resp *http.Response, err error) string {
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return string(body)
+}
+
+func TestRemoteCache_Fetch(t *testing.T) {
+	var requests, notModified atomic.Int32
+	var failing atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		_, _ = w.Write([]byte("type: object"))
+	}))
+
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewRemoteCache(dir, nil)
+	require.NoError(t, err)
+	url := server.URL + "/pet.yaml"
+
+	resp, err := cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(0), notModified.Load())
+
+	// the document is revalidated, and read from the cache.
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(1), notModified.Load())
+	assert.Equal(t, `"v1"`, resp.Header.Get("ETag"))
+	assert.Equal(t, "Wed, 21 Oct 2015 07:28:00 GMT", resp.Header.Get("Last-Modified"))
+
+	// a new cache in the same directory uses the same documents.
+	cache, err = NewRemoteCache(dir, nil)
+	require.NoError(t, err)
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(2), notModified.Load())
+
+	// a failing server falls back to the cache, documents that are not cached fail.
+	failing.Store(true)
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	resp, err = cache.Fetch(server.URL + "/owner.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+
+	// an unreachable server falls back to the cache.
+	server.Close()
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	_, err = cache.Fetch(server.URL + "/owner.yaml")
+	assert.Error(t, err)
+
+	require.NoError(t, cache.Clear())
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+	_, err = cache.Fetch(url)
+	assert.Error(t, err)
+}
+
+func TestNewRemoteCache_Errors(t *testing.T) {
+	_, err := NewRemoteCache("", nil)
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, []byte("not a directory"), 0o644))
+	_, err = NewRemoteCache(filepath.Join(file, "cache"), nil)
+	assert.Error(t, err)
+}
+
+func TestNewRemoteFSWithConfig_RemoteCacheDir(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	rfs, err := NewRemoteFSWithConfig(&SpecIndexConfig{RemoteCacheDir: t.TempDir()})
+	require.NoError(t, err)
+	resp, err := rfs.RemoteHandlerFunc(server.URL + "/pet.yaml")
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+
+	server.Close()
+	resp, err = rfs.RemoteHandlerFunc(server.URL + "/pet.yaml")
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(1), requests.Load())
+}
+
+func TestRemoteCache_Fetch_Freshness(t *testing.T) {
+	var requests atomic.Int32
+	var cacheControl atomic.Value
+	cacheControl.Store("")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if cc := cacheControl.Load().(string); cc != "" {
+			w.Header().Set("Cache-Control", cc)
+		}
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		_, _ = w.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache, err := NewRemoteCache(t.TempDir(), nil)
+	require.NoError(t, err)
+	cache.now = func() time.Time { return now }
+	cache.SetTTL(time.Minute)
+	url := server.URL + "/pet.yaml"
+
+	// fresh documents are not revalidated, until the TTL has passed.
+	for range 2 {
+		resp, err := cache.Fetch(url)
+		assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	}
+	assert.Equal(t, int32(1), requests.Load())
+	now = now.Add(time.Minute)
+	resp, err := cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(2), requests.Load())
+
+	// a 304 refreshes the document, and the max-age sent with it overrides the TTL.
+	cacheControl.Store("public, max-age=3600")
+	now = now.Add(time.Minute)
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(3), requests.Load())
+	now = now.Add(30 * time.Minute)
+	resp, err = cache.Fetch(url)
+	assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	assert.Equal(t, int32(3), requests.Load())
+
+	// no-cache documents are always revalidated.
+	cacheControl.Store("no-cache")
+	now = now.Add(time.Hour)
+	for range 2 {
+		resp, err = cache.Fetch(url)
+		assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	}
+	assert.Equal(t, int32(5), requests.Load())
+}
+
+func TestRemoteCache_Fetch_NoStore(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	cache, err := NewRemoteCache(dir, nil)
+	require.NoError(t, err)
+	cache.SetTTL(time.Hour)
+	for range 2 {
+		resp, err := cache.Fetch(server.URL + "/pet.yaml")
+		assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	}
+	assert.Equal(t, int32(2), requests.Load())
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries)
+}
+
+func TestNewRemoteFSWithConfig_RemoteCacheTTL(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte("type: object"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	for range 2 {
+		// every build creates a new remote file system, using the same cache.
+		rfs, err := NewRemoteFSWithConfig(&SpecIndexConfig{RemoteCacheDir: dir, RemoteCacheTTL: time.Hour})
+		require.NoError(t, err)
+		resp, err := rfs.RemoteHandlerFunc(server.URL + "/pet.yaml")
+		assert.Equal(t, "type: object", readCachedResponse(t, resp, err))
+	}
+	assert.Equal(t, int32(1), requests.Load())
+}
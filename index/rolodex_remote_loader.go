@@ -13,7 +13,6 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
-	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -258,6 +257,7 @@ type RemoteFS struct {
 	extractedFiles    map[string]RolodexFile
 	rolodex           *Rolodex
 	errMutex          sync.Mutex
+	git               *GitRepositories
 }
 
 // RemoteFile is a file that has been indexed by the RemoteFS. It implements the RolodexFile interface.
@@ -276,6 +276,81 @@ type RemoteFile struct {
 	indexOnce        sync.Once
 	contentLock      sync.Mutex
 	indexingComplete chan struct{} // Closed when indexing is complete
+	remoteFS         *RemoteFS     // the file system the file was fetched by, used to fetch it again once evicted.
+	dataLock         sync.Mutex
+	evicted          bool
+}
+
+// content returns the content of the file, fetching it again if it was evicted from the content cache of the
+// rolodex.
+func (f *RemoteFile) content() []byte {
+	f.dataLock.Lock()
+	if f.evicted {
+		f.data = f.reload()
+		f.evicted = false
+	}
+	data := f.data
+	f.dataLock.Unlock()
+	if cache := f.contentCache(); cache != nil && data != nil {
+		cache.use(f, int64(len(data)))
+	}
+	return data
+}
+
+// contentCache returns the content cache of the rolodex the file belongs to, if it has one.
+func (f *RemoteFile) contentCache() *contentCache {
+	if f.remoteFS == nil || f.remoteFS.rolodex == nil {
+		return nil
+	}
+	return f.remoteFS.rolodex.contentCache
+}
+
+// reload fetches the content of the file again.
+func (f *RemoteFile) reload() []byte {
+	data, err := f.fetchContent()
+	if err != nil {
+		f.remoteFS.logger.Error("[rolodex remote loader] unable to fetch evicted file again", "file", f.fullPath,
+			"error", err)
+		return nil
+	}
+	return data
+}
+
+// fetchContent fetches the content of the file again, and transforms it. If the file no longer exists, the error
+// wraps fs.ErrNotExist.
+func (f *RemoteFile) fetchContent() ([]byte, error) {
+	i := f.remoteFS
+	response, err := i.fetch(f.fullPath)
+	if err != nil {
+		return nil, err
+	}
+	if response == nil {
+		return nil, fmt.Errorf("empty response from remote URL: %s", f.fullPath)
+	}
+	data, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode == http.StatusNotFound || response.StatusCode == http.StatusGone {
+		return nil, fmt.Errorf("remote file '%s' returned status code %d: %w", f.fullPath, response.StatusCode,
+			fs.ErrNotExist)
+	}
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("remote file '%s' returned status code %d", f.fullPath, response.StatusCode)
+	}
+	return i.indexConfig.TransformFile(f.fullPath, data)
+}
+
+// evictContent drops the content of the file from memory.
+func (f *RemoteFile) evictContent() {
+	f.dataLock.Lock()
+	f.data = nil
+	f.evicted = true
+	f.dataLock.Unlock()
+	f.contentLock.Lock()
+	f.parsed = nil
+	f.contentLock.Unlock()
 }
 
 // GetFileName returns the name of the file.
@@ -285,23 +360,25 @@ func (f *RemoteFile) GetFileName() string {
 
 // GetContent returns the content of the file as a string.
 func (f *RemoteFile) GetContent() string {
-	return string(f.data)
+	return string(f.content())
 }
 
 // GetContentAsYAMLNode returns the content of the file as a yaml.Node.
 func (f *RemoteFile) GetContentAsYAMLNode() (*yaml.Node, error) {
+	// read the content before locking, reading it can evict (and lock) other files.
+	data := f.content()
 	f.contentLock.Lock()
 	idx := f.GetIndex()
 	if idx != nil && idx.root != nil {
 		f.contentLock.Unlock()
 		return idx.GetRootNode(), nil
 	}
-	if f.data == nil {
+	if data == nil {
 		f.contentLock.Unlock()
 		return nil, fmt.Errorf("no data to parse for file: %s", f.fullPath)
 	}
 	var root yaml.Node
-	err := yaml.Unmarshal(f.data, &root)
+	err := yaml.Unmarshal(data, &root)
 
 	if err != nil {
 		f.contentLock.Unlock()
@@ -346,7 +423,7 @@ func (f *RemoteFile) Name() string {
 
 // Size returns the size of the file.
 func (f *RemoteFile) Size() int64 {
-	return int64(len(f.data))
+	return int64(len(f.content()))
 }
 
 // Mode returns the file mode bits for the file.
@@ -383,13 +460,14 @@ func (f *RemoteFile) Stat() (fs.FileInfo, error) {
 
 // Read reads the file. Makes it compatible with io.Reader.
 func (f *RemoteFile) Read(b []byte) (int, error) {
-	if f.offset >= int64(len(f.data)) {
+	data := f.content()
+	if f.offset >= int64(len(data)) {
 		return 0, io.EOF
 	}
 	if f.offset < 0 {
 		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fs.ErrInvalid}
 	}
-	n := copy(b, f.data[f.offset:])
+	n := copy(b, data[f.offset:])
 	f.offset += int64(n)
 	return n, nil
 }
@@ -400,7 +478,11 @@ func (f *RemoteFile) Index(ctx context.Context, config *SpecIndexConfig) (*SpecI
 	var resultErr error
 	f.indexOnce.Do(func() {
 
-		content := f.data
+		content := f.content()
+		if shared := config.Rolodex.FindIndexByContent(config.SpecAbsolutePath, content); shared != nil {
+			f.index.Store(shared)
+			return
+		}
 		// first, we must parse the content of the file,
 		// the check is bypassed, so as long as it's readable, we're good.
 		info, _ := datamodel.ExtractSpecInfoWithDocumentCheck(content, true)
@@ -453,18 +535,34 @@ func (f *RemoteFile) signalIndexingComplete() {
 	}
 }
 
+// checkSlowFetch emits a build event if fetching a remote file took longer than the configured threshold.
+func (i *RemoteFS) checkSlowFetch(remoteURL string, elapsed time.Duration) {
+	if i.indexConfig == nil || i.indexConfig.BuildEventHandler == nil {
+		return
+	}
+	threshold := i.indexConfig.SlowRemoteFetchThreshold
+	if threshold <= 0 {
+		threshold = datamodel.DefaultSlowRemoteFetchThreshold
+	}
+	if elapsed < threshold {
+		return
+	}
+	i.indexConfig.EmitBuildEvent(&datamodel.BuildEvent{
+		Type:      datamodel.BuildEventSlowRemoteFetch,
+		Subsystem: datamodel.LogSubsystemIndex,
+		Message:   fmt.Sprintf("fetching remote file '%s' took %s", remoteURL, elapsed),
+		File:      remoteURL,
+		Duration:  elapsed,
+	})
+}
+
 // NewRemoteFSWithConfig creates a new RemoteFS using the supplied SpecIndexConfig.
 func NewRemoteFSWithConfig(specIndexConfig *SpecIndexConfig) (*RemoteFS, error) {
 	if specIndexConfig == nil {
 		return nil, errors.New("no spec index config provided")
 	}
 	remoteRootURL := specIndexConfig.BaseURL
-	log := specIndexConfig.Logger
-	if log == nil {
-		log = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelError,
-		}))
-	}
+	log := specIndexConfig.GetSubsystemLogger(datamodel.LogSubsystemIndex)
 
 	rfs := &RemoteFS{
 		indexConfig:   specIndexConfig,
@@ -475,6 +573,9 @@ func NewRemoteFSWithConfig(specIndexConfig *SpecIndexConfig) (*RemoteFS, error)
 	if remoteRootURL != nil {
 		rfs.rootURL = remoteRootURL.String()
 	}
+	if specIndexConfig.AllowGitReferences {
+		rfs.git = NewGitRepositories(specIndexConfig.GitCacheDir)
+	}
 	if specIndexConfig.RemoteURLHandler != nil {
 		rfs.RemoteHandlerFunc = specIndexConfig.RemoteURLHandler
 	} else {
@@ -482,9 +583,20 @@ func NewRemoteFSWithConfig(specIndexConfig *SpecIndexConfig) (*RemoteFS, error)
 		client := &http.Client{
 			Timeout: time.Second * 120,
 		}
+		if len(specIndexConfig.RemoteAuth) > 0 {
+			client.Transport = NewRemoteAuthTransport(specIndexConfig.RemoteAuth, nil)
+		}
 		rfs.RemoteHandlerFunc = func(url string) (*http.Response, error) {
 			return client.Get(url)
 		}
+		if specIndexConfig.RemoteCacheDir != "" {
+			if cache, err := NewRemoteCache(specIndexConfig.RemoteCacheDir, client); err == nil {
+				cache.SetTTL(specIndexConfig.RemoteCacheTTL)
+				rfs.RemoteHandlerFunc = cache.Fetch
+			} else {
+				log.Warn("[rolodex remote loader] remote documents will not be cached", "error", err.Error())
+			}
+		}
 	}
 	return rfs, nil
 }
@@ -500,6 +612,56 @@ func NewRemoteFSWithRootURL(rootURL string) (*RemoteFS, error) {
 	return NewRemoteFSWithConfig(config)
 }
 
+// fetch fetches a remote document, from the object store configured for the scheme of its URL, or with the
+// remote handler function.
+func (i *RemoteFS) fetch(remoteURL string) (*http.Response, error) {
+	if i.git != nil || (i.indexConfig != nil && len(i.indexConfig.ObjectStores) > 0) {
+		if u, err := url.Parse(remoteURL); err == nil {
+			if i.git != nil && isGitURL(u) {
+				return i.git.fetch(u)
+			}
+			if i.indexConfig != nil {
+				if store, ok := i.indexConfig.ObjectStores[u.Scheme]; ok {
+					return fetchObject(store, u)
+				}
+			}
+		}
+	}
+	return i.RemoteHandlerFunc(remoteURL)
+}
+
+// isRemote returns true if a location is fetched by the RemoteFS; an HTTP URL, a git reference (when git references
+// are allowed), or an object in one of the object stores.
+func (i *RemoteFS) isRemote(location string) bool {
+	if strings.HasPrefix(location, "http") {
+		return true
+	}
+	if !hasURLScheme(location) {
+		return false
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return false
+	}
+	if i.git != nil && isGitURL(u) {
+		return true
+	}
+	if i.indexConfig != nil {
+		_, ok := i.indexConfig.ObjectStores[u.Scheme]
+		return ok
+	}
+	return false
+}
+
+// remoteFileKey returns the key of the file a URL refers to. Files are keyed by path, apart from files in git
+// repositories, which are also keyed by the revision they were checked out at.
+func remoteFileKey(u *url.URL) string {
+	if isGitURL(u) && u.RawQuery != "" {
+		return u.Path + "?" + u.RawQuery
+	}
+	return u.Path
+}
+
 // SetRemoteHandlerFunc sets the remote handler function.
 func (i *RemoteFS) SetRemoteHandlerFunc(handlerFunc utils.RemoteURLHandler) {
 	i.RemoteHandlerFunc = handlerFunc
@@ -541,7 +703,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 			"AllowRemoteLookup to true as part of the index configuration", remoteURL)
 	}
 
-	if !strings.HasPrefix(remoteURL, "http") {
+	if !i.isRemote(remoteURL) {
 		if i.logger != nil {
 			i.logger.Debug("[rolodex remote loader] not a remote file, ignoring", "file", remoteURL)
 		}
@@ -554,8 +716,10 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	}
 	remoteParsedURLOriginal, _ := url.Parse(remoteURL)
 
+	fileKey := remoteFileKey(remoteParsedURL)
+
 	// try path first
-	if r, ok := i.Files.Load(remoteParsedURL.Path); ok {
+	if r, ok := i.Files.Load(fileKey); ok {
 		return r.(*RemoteFile), nil
 	}
 
@@ -568,7 +732,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 				i.logger.Debug("[rolodex remote loader] attempting content detection for unknown file extension", "url", remoteParsedURL.String())
 			}
 			// Attempt to detect content type
-			fileExt = detectRemoteContentType(remoteParsedURL.String(), i.RemoteHandlerFunc, i.logger)
+			fileExt = detectRemoteContentType(remoteParsedURL.String(), i.fetch, i.logger)
 			if fileExt == UNSUPPORTED {
 				// Clear cache entry on completion to keep memory usage low
 				defer func() {
@@ -605,10 +769,10 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	// Use LoadOrStore to atomically check if someone is already processing this file.
 	// This prevents the race condition where two goroutines both see "not processing"
 	// and both start processing the same file.
-	processingWaiter := &waiterRemote{f: remoteParsedURL.Path}
+	processingWaiter := &waiterRemote{f: fileKey}
 	processingWaiter.mu.Lock()
 
-	if existing, loaded := i.ProcessingFiles.LoadOrStore(remoteParsedURL.Path, processingWaiter); loaded {
+	if existing, loaded := i.ProcessingFiles.LoadOrStore(fileKey, processingWaiter); loaded {
 		// Someone else is already processing this file, wait for them
 		processingWaiter.mu.Unlock() // Release our unused waiter's lock
 		wait := existing.(*waiterRemote)
@@ -628,7 +792,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 
 	// if the remote URL is absolute (http:// or https://), and we have a rootURL defined, we need to override
 	// the host being defined by this URL, and use the rootURL instead, but keep the path.
-	if i.rootURLParsed != nil {
+	if i.rootURLParsed != nil && strings.HasPrefix(remoteParsedURL.Scheme, "http") {
 		remoteParsedURL.Host = i.rootURLParsed.Host
 		remoteParsedURL.Scheme = i.rootURLParsed.Scheme
 		// this has been disabled, because I don't think it has value, it causes more problems than it solves currently.
@@ -641,14 +805,15 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	if remoteParsedURL.Scheme == "" {
 
 		processingWaiter.done = true
-		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		i.ProcessingFiles.Delete(fileKey)
 		processingWaiter.mu.Unlock()
 		return nil, nil // not a remote file, nothing wrong with that - just we can't keep looking here partner.
 	}
 
 	i.logger.Debug("[rolodex remote loader] loading remote file", "file", remoteURL, "remoteURL", remoteParsedURL.String())
 
-	response, clientErr := i.RemoteHandlerFunc(remoteParsedURL.String())
+	fetchStarted := time.Now()
+	response, clientErr := i.fetch(remoteParsedURL.String())
 	if clientErr != nil {
 
 		i.errMutex.Lock()
@@ -657,7 +822,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 
 		// remove from processing
 		processingWaiter.done = true
-		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		i.ProcessingFiles.Delete(fileKey)
 		processingWaiter.mu.Unlock()
 
 		if response != nil {
@@ -670,7 +835,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	if response == nil {
 		// remove from processing
 		processingWaiter.done = true
-		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		i.ProcessingFiles.Delete(fileKey)
 		processingWaiter.mu.Unlock()
 		return nil, fmt.Errorf("empty response from remote URL: %s", remoteParsedURL.String())
 	}
@@ -680,18 +845,20 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 		// remove from processing
 		processingWaiter.error = readError
 		processingWaiter.done = true
-		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		i.ProcessingFiles.Delete(fileKey)
 		processingWaiter.mu.Unlock()
 		return nil, fmt.Errorf("error reading bytes from remote file '%s': [%s]",
 			remoteParsedURL.String(), readError.Error())
 	}
 
+	i.checkSlowFetch(remoteParsedURL.String(), time.Since(fetchStarted))
+
 	if response.StatusCode >= 400 {
 
 		// remove from processing
 		processingWaiter.error = fmt.Errorf("remote file '%s' returned status code %d", remoteParsedURL.String(), response.StatusCode)
 		processingWaiter.done = true
-		i.ProcessingFiles.Delete(remoteParsedURL.Path)
+		i.ProcessingFiles.Delete(fileKey)
 		i.logger.Error("unable to fetch remote document",
 			"file", remoteParsedURL.Path, "status", response.StatusCode, "resp", string(responseBytes))
 		processingWaiter.mu.Unlock()
@@ -699,7 +866,18 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 			response.StatusCode)
 	}
 
-	absolutePath := remoteParsedURL.Path
+	var transformErr error
+	if responseBytes, transformErr = i.indexConfig.TransformFile(remoteParsedURL.String(), responseBytes); transformErr != nil {
+
+		// remove from processing
+		processingWaiter.error = transformErr
+		processingWaiter.done = true
+		i.ProcessingFiles.Delete(fileKey)
+		processingWaiter.mu.Unlock()
+		return nil, transformErr
+	}
+
+	absolutePath := fileKey
 
 	// extract last modified from response
 	lastModified := response.Header.Get("Last-Modified")
@@ -723,6 +901,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 		URL:              remoteParsedURL,
 		lastModified:     lastModifiedTime,
 		indexingComplete: make(chan struct{}),
+		remoteFS:         i,
 	}
 
 	copiedCfg := *i.indexConfig
@@ -752,7 +931,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	// remove from processing
 	processingWaiter.file = remoteFile
 	processingWaiter.done = true
-	i.ProcessingFiles.Delete(remoteParsedURL.Path)
+	i.ProcessingFiles.Delete(fileKey)
 	processingWaiter.mu.Unlock()
 
 	// Add this file to the context's indexing set to prevent deadlocks
@@ -768,6 +947,7 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 	indexingCtx = AddIndexingFile(indexingCtx, remoteParsedURLOriginal.String())
 
 	// Now index the file AFTER releasing the lock
+	i.rolodex.fileDiscovered(remoteFile.fullPath)
 	idx, idxError := remoteFile.Index(indexingCtx, &copiedCfg)
 
 	if idxError != nil && idx == nil {
@@ -776,12 +956,17 @@ func (i *RemoteFS) OpenWithContext(ctx context.Context, remoteURL string) (fs.Fi
 		i.errMutex.Unlock()
 	} else {
 
-		// for each index, we need a resolver
-		resolver := NewResolver(idx)
-		idx.resolver = resolver
-		idx.BuildIndex()
+		// an index that has already been built is shared with another file that has the same content.
+		if !idx.built {
+			// for each index, we need a resolver
+			resolver := NewResolver(idx)
+			idx.resolver = resolver
+			idx.BuildIndex()
+			i.rolodex.registerIndexContent(remoteFile.content(), idx)
+		}
 		if i.rolodex != nil {
 			i.rolodex.AddExternalIndex(idx, remoteParsedURL.String())
+			i.rolodex.fileIndexed(remoteFile.fullPath)
 		}
 	}
 
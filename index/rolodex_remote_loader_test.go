@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"context"
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -478,3 +479,31 @@ func TestRemoteFile_SignalIndexingComplete_NilChannel(t *testing.T) {
 		rf.signalIndexingComplete()
 	}, "signalIndexingComplete should not panic when channel is nil")
 }
+
+func TestRemoteFS_SlowFetchEvent(t *testing.T) {
+	var events []*datamodel.BuildEvent
+	cf := CreateOpenAPIIndexConfig()
+	cf.SlowRemoteFetchThreshold = time.Nanosecond
+	cf.BuildEventHandler = func(event *datamodel.BuildEvent) {
+		events = append(events, event)
+	}
+	remoteFS, err := NewRemoteFSWithConfig(cf)
+	assert.NoError(t, err)
+	remoteFS.RemoteHandlerFunc = func(url string) (*http.Response, error) {
+		time.Sleep(time.Millisecond)
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader("type: string"))}, nil
+	}
+
+	_, err = remoteFS.Open("https://pb33f.io/slow.yaml")
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, datamodel.BuildEventSlowRemoteFetch, events[0].Type)
+	assert.Equal(t, "https://pb33f.io/slow.yaml", events[0].File)
+	assert.GreaterOrEqual(t, events[0].Duration, time.Millisecond)
+
+	// nothing is emitted for fast fetches.
+	remoteFS.indexConfig.SlowRemoteFetchThreshold = 0
+	_, err = remoteFS.Open("https://pb33f.io/fast.yaml")
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+}
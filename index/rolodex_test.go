@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -2182,3 +2183,176 @@ func (tfi *testFileInfo) Mode() fs.FileMode  { return 0644 }
 func (tfi *testFileInfo) ModTime() time.Time { return time.Now() }
 func (tfi *testFileInfo) IsDir() bool        { return false }
 func (tfi *testFileInfo) Sys() any           { return nil }
+
+func TestRolodex_DeduplicateFilesByContent(t *testing.T) {
+	pet := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      type: object`
+
+	tmp := t.TempDir()
+	_ = os.WriteFile(filepath.Join(tmp, "pet.yaml"), []byte(pet), 0o644)
+	_ = os.MkdirAll(filepath.Join(tmp, "mirror"), 0o755)
+	_ = os.WriteFile(filepath.Join(tmp, "mirror", "pet.yaml"), []byte(pet), 0o644)
+	_ = os.WriteFile(filepath.Join(tmp, "copy.yaml"), []byte(pet), 0o644)
+	_ = os.WriteFile(filepath.Join(tmp, "other.yaml"), []byte(pet+"\n    Other:\n      type: string"), 0o644)
+
+	open := func(dedupe bool) (RolodexFile, RolodexFile, RolodexFile, RolodexFile) {
+		cf := CreateOpenAPIIndexConfig()
+		cf.BasePath = tmp
+		cf.DeduplicateFilesByContent = dedupe
+		rolo := NewRolodex(cf)
+		fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+		assert.NoError(t, err)
+		rolo.AddLocalFS(tmp, fileFS)
+
+		a, err := rolo.Open(filepath.Join(tmp, "pet.yaml"))
+		assert.NoError(t, err)
+		b, err := rolo.Open(filepath.Join(tmp, "copy.yaml"))
+		assert.NoError(t, err)
+		c, err := rolo.Open(filepath.Join(tmp, "other.yaml"))
+		assert.NoError(t, err)
+		d, err := rolo.Open(filepath.Join(tmp, "mirror", "pet.yaml"))
+		assert.NoError(t, err)
+		return a, b, c, d
+	}
+
+	a, b, c, d := open(true)
+	assert.NotNil(t, a.GetIndex())
+	assert.Same(t, a.GetIndex(), b.GetIndex())
+	assert.NotSame(t, a.GetIndex(), c.GetIndex())
+	assert.NotSame(t, a.GetIndex(), d.GetIndex()) // files in other directories are never shared.
+
+	a, b, _, _ = open(false)
+	assert.NotSame(t, a.GetIndex(), b.GetIndex())
+}
+
+func TestRolodex_DeduplicateFilesByContent_Symlink(t *testing.T) {
+	tmp := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmp, "specs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "specs", "pet.yaml"), []byte("Pet:\n  type: object"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmp, "specs", "copy.yaml"), []byte("Pet:\n  type: object"), 0o644))
+	if err := os.Symlink(filepath.Join(tmp, "specs"), filepath.Join(tmp, "linked")); err != nil {
+		t.Skip("symlinks are not supported")
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	cf.DeduplicateFilesByContent = true
+	rolo := NewRolodex(cf)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo.AddLocalFS(tmp, fileFS)
+
+	// a symlinked directory is the same directory, so its files resolve their relative references the same way.
+	pet, err := rolo.Open(filepath.Join(tmp, "specs", "pet.yaml"))
+	require.NoError(t, err)
+	linked, err := rolo.Open(filepath.Join(tmp, "linked", "copy.yaml"))
+	require.NoError(t, err)
+	assert.NotNil(t, pet.GetIndex())
+	assert.Same(t, pet.GetIndex(), linked.GetIndex())
+
+	// mirrors at different URLs are not the same directory.
+	assert.NotEqual(t, contentBase("https://a.example.com/specs/pet.yaml"),
+		contentBase("https://b.example.com/specs/pet.yaml"))
+}
+
+func TestRolodex_DeduplicateFilesByContent_RelativeReferences(t *testing.T) {
+	tmp := t.TempDir()
+	for dir, ownerType := range map[string]string{"cats": "string", "dogs": "integer"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmp, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, dir, "pet.yaml"),
+			[]byte("Pet:\n  type: object\n  properties:\n    owner:\n      $ref: 'owner.yaml#/Owner'"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(tmp, dir, "owner.yaml"),
+			[]byte("Owner:\n  type: "+ownerType), 0o644))
+	}
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	cf.DeduplicateFilesByContent = true
+	rolo := NewRolodex(cf)
+	fileFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo.AddLocalFS(tmp, fileFS)
+
+	// the pets are identical, but their owners are not the same file.
+	for _, dir := range []string{"cats", "dogs"} {
+		pet, err := rolo.Open(filepath.Join(tmp, dir, "pet.yaml"))
+		require.NoError(t, err)
+		owner := filepath.Join(tmp, dir, "owner.yaml") + "#/Owner"
+		require.Contains(t, pet.GetIndex().GetMappedReferences(), owner, dir)
+		assert.Equal(t, owner, pet.GetIndex().GetMappedReferences()[owner].FullDefinition)
+	}
+}
+
+func TestRolodex_FindIndexByContent_Disabled(t *testing.T) {
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	idx := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	rolo.registerIndexContent([]byte("hello"), idx)
+	assert.Nil(t, rolo.FindIndexByContent("", []byte("hello")))
+
+	var nilRolo *Rolodex
+	assert.Nil(t, nilRolo.FindIndexByContent("", []byte("hello")))
+}
+
+func TestRolodex_FindIndexByContent_FirstWins(t *testing.T) {
+	cf := CreateOpenAPIIndexConfig()
+	cf.DeduplicateFilesByContent = true
+	rolo := NewRolodex(cf)
+	first := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	second := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	rolo.registerIndexContent([]byte("hello"), first)
+	rolo.registerIndexContent([]byte("hello"), second)
+	assert.Same(t, first, rolo.FindIndexByContent("", []byte("hello")))
+	assert.Nil(t, rolo.FindIndexByContent("", []byte("goodbye")))
+	assert.Nil(t, rolo.FindIndexByContent("", nil))
+}
+
+func TestRolodex_PreserveReference(t *testing.T) {
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	idx := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	_, ok := rolo.PreserveReference(idx, "shared.yaml#/Pet")
+	assert.False(t, ok)
+
+	rolo.SetReferencePreserver(func(i *SpecIndex, ref string) (string, bool) {
+		return "kept/" + ref, i == idx
+	})
+	preserved, ok := rolo.PreserveReference(idx, "shared.yaml#/Pet")
+	assert.True(t, ok)
+	assert.Equal(t, "kept/shared.yaml#/Pet", preserved)
+
+	rolo.SetReferencePreserver(nil)
+	_, ok = rolo.PreserveReference(idx, "shared.yaml#/Pet")
+	assert.False(t, ok)
+
+	var nilRolo *Rolodex
+	_, ok = nilRolo.PreserveReference(idx, "shared.yaml#/Pet")
+	assert.False(t, ok)
+}
+
+func TestRolodex_AnnotateReference(t *testing.T) {
+	rolo := NewRolodex(CreateOpenAPIIndexConfig())
+	idx := NewSpecIndexWithConfig(&yaml.Node{}, CreateOpenAPIIndexConfig())
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	rolo.AnnotateReference(idx, "shared.yaml#/Pet", node)
+	assert.Empty(t, node.Content)
+
+	rolo.SetReferenceAnnotator(func(i *SpecIndex, ref string, n *yaml.Node) {
+		n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "x-from"},
+			&yaml.Node{Kind: yaml.ScalarNode, Value: ref})
+	})
+	rolo.AnnotateReference(idx, "shared.yaml#/Pet", node)
+	assert.Len(t, node.Content, 2)
+	assert.Equal(t, "shared.yaml#/Pet", node.Content[1].Value)
+
+	// nothing to annotate.
+	rolo.AnnotateReference(idx, "shared.yaml#/Pet", nil)
+
+	rolo.SetReferenceAnnotator(nil)
+	rolo.AnnotateReference(idx, "shared.yaml#/Pet", node)
+	assert.Len(t, node.Content, 2)
+
+	var nilRolo *Rolodex
+	nilRolo.AnnotateReference(idx, "shared.yaml#/Pet", node)
+}
@@ -0,0 +1,284 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RolodexWatchEvent describes a file of a rolodex that changed, and was indexed again.
+type RolodexWatchEvent struct {
+	// Location is the absolute path, or URL, of the file.
+	Location string
+
+	// Removed is true if the file no longer exists. It's removed from the rolodex, along with its index.
+	Removed bool
+
+	// Index is the new index of the file. It's nil if the file was removed, or could not be indexed again.
+	Index *SpecIndex
+
+	// Affected are the references, in every other index of the rolodex, that point into the file. The components they
+	// refer to may have changed, or no longer exist.
+	Affected []*Reference
+
+	// Error is set if the file could not be read, or indexed, again.
+	Error error
+}
+
+// RolodexWatcherConfig configures a RolodexWatcher.
+type RolodexWatcherConfig struct {
+	// Interval is how often local files are checked for changes. Defaults to one second.
+	Interval time.Duration
+
+	// RemoteInterval is how often remote files are checked for changes, by fetching them again (through the remote
+	// cache, if there is one). Defaults to one minute. If it's negative, remote files are not checked.
+	RemoteInterval time.Duration
+}
+
+// RolodexWatcher watches every file in a rolodex, local and remote, for changes. When the content of a file changes
+// it is indexed again, its index is replaced in the rolodex, and an event identifying the references affected by the
+// change is sent. Files are polled: local files are read again only when their modification time or size changes.
+//
+// The indexes that refer to a changed file, and models built from them, still refer to its old content. Events are
+// the signal to build them again, the way an editor or a preview reloads a document.
+type RolodexWatcher struct {
+	rolodex    *Rolodex
+	config     RolodexWatcherConfig
+	events     chan *RolodexWatchEvent
+	files      map[string]*watchedFile
+	lastRemote time.Time
+	mu         sync.Mutex
+}
+
+// watchedFile is the last known state of a watched file.
+type watchedFile struct {
+	modTime time.Time
+	size    int64
+	sum     [sha256.Size]byte
+}
+
+// NewRolodexWatcher creates a RolodexWatcher for the files in a rolodex, as they are now. Files added to the rolodex
+// later are watched from the first check that finds them. If config is nil, the defaults are used.
+func NewRolodexWatcher(rolodex *Rolodex, config *RolodexWatcherConfig) *RolodexWatcher {
+	w := &RolodexWatcher{
+		rolodex: rolodex,
+		events:  make(chan *RolodexWatchEvent, 16),
+		files:   make(map[string]*watchedFile),
+	}
+	if config != nil {
+		w.config = *config
+	}
+	if w.config.Interval <= 0 {
+		w.config.Interval = time.Second
+	}
+	if w.config.RemoteInterval == 0 {
+		w.config.RemoteInterval = time.Minute
+	}
+	w.Check(context.Background(), false)
+	w.lastRemote = time.Now()
+	return w
+}
+
+// Events returns the channel events are sent to while watching. It's closed when Watch returns.
+func (w *RolodexWatcher) Events() <-chan *RolodexWatchEvent {
+	return w.events
+}
+
+// Watch checks the files of the rolodex at the configured intervals, and sends an event for every file that changed,
+// until the context is done.
+func (w *RolodexWatcher) Watch(ctx context.Context) {
+	defer close(w.events)
+	ticker := time.NewTicker(w.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		remote := w.config.RemoteInterval >= 0 && time.Since(w.lastRemote) >= w.config.RemoteInterval
+		for _, e := range w.Check(ctx, remote) {
+			select {
+			case w.events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Check checks every local file of the rolodex once (and every remote file, if remote is true), indexes the files
+// that changed again, and returns an event for each of them. Events returned by Check are not sent to the events
+// channel.
+func (w *RolodexWatcher) Check(ctx context.Context, remote bool) []*RolodexWatchEvent {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if remote {
+		w.lastRemote = time.Now()
+	}
+
+	var events []*RolodexWatchEvent
+	seen := make(map[string]bool)
+	for _, fsys := range w.rolodex.localFS {
+		lfs, ok := fsys.(*LocalFS)
+		if !ok {
+			continue
+		}
+		for location, f := range lfs.GetFiles() {
+			seen[location] = true
+			if e := w.checkLocal(ctx, lfs, location, f.(*LocalFile)); e != nil {
+				events = append(events, e)
+			}
+		}
+	}
+	for _, fsys := range w.rolodex.remoteFS {
+		rfs, ok := fsys.(*RemoteFS)
+		if !ok {
+			continue
+		}
+		for key, f := range rfs.GetFiles() {
+			rf := f.(*RemoteFile)
+			seen[rf.fullPath] = true
+			if e := w.checkRemote(ctx, rfs, key, rf, remote); e != nil {
+				events = append(events, e)
+			}
+		}
+	}
+
+	// files that have left the rolodex are no longer watched.
+	for location := range w.files {
+		if !seen[location] {
+			delete(w.files, location)
+		}
+	}
+	if len(events) > 0 {
+		w.rolodex.ClearIndexCaches()
+	}
+	return events
+}
+
+// checkLocal checks a local file, and indexes it again if its content changed.
+func (w *RolodexWatcher) checkLocal(ctx context.Context, lfs *LocalFS, location string, f *LocalFile) *RolodexWatchEvent {
+	info, err := lfs.stat(location)
+	state, ok := w.files[location]
+	if !ok {
+		state = &watchedFile{sum: sha256.Sum256(f.content())}
+		if err == nil {
+			state.modTime, state.size = info.ModTime(), info.Size()
+		}
+		w.files[location] = state
+		return nil
+	}
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return w.remove(location, func() { lfs.Files.Delete(location) })
+		}
+		return nil // it may be readable again next time.
+	}
+	if info.ModTime().Equal(state.modTime) && info.Size() == state.size {
+		return nil
+	}
+	state.modTime, state.size = info.ModTime(), info.Size()
+
+	changed, err := lfs.extractFile(location)
+	if err != nil || changed == nil {
+		return &RolodexWatchEvent{Location: location, Error: fmt.Errorf("unable to read '%s' again: %w", location, err)}
+	}
+	if sum := sha256.Sum256(changed.data); sum != state.sum {
+		state.sum = sum
+		return w.reindex(location, func() (*SpecIndex, error) {
+			lfs.Files.Delete(location)
+			opened, err := lfs.openNewFile(ctx, location)
+			if lf, ok := opened.(*LocalFile); ok && lf != nil && err == nil {
+				return lf.GetIndex(), nil
+			}
+			return nil, fmt.Errorf("unable to index '%s' again: %w", location, err)
+		})
+	}
+	return nil
+}
+
+// checkRemote checks a remote file (if remote is true), and indexes it again if its content changed.
+func (w *RolodexWatcher) checkRemote(ctx context.Context, rfs *RemoteFS, key string, f *RemoteFile, remote bool) *RolodexWatchEvent {
+	location := f.fullPath
+	state, ok := w.files[location]
+	if !ok {
+		w.files[location] = &watchedFile{sum: sha256.Sum256(f.content())}
+		return nil
+	}
+	if !remote {
+		return nil
+	}
+	data, err := f.fetchContent()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return w.remove(location, func() { rfs.Files.Delete(key) })
+		}
+		w.rolodex.logger.Debug("[rolodex watcher] unable to check remote file", "file", location, "error", err)
+		return nil
+	}
+	if sum := sha256.Sum256(data); sum != state.sum {
+		state.sum = sum
+		return w.reindex(location, func() (*SpecIndex, error) {
+			rfs.Files.Delete(key)
+			opened, err := rfs.OpenWithContext(ctx, location)
+			if rf, ok := opened.(*RemoteFile); ok && rf != nil {
+				return rf.GetIndex(), nil // errors of other remote files are returned too.
+			}
+			return nil, fmt.Errorf("unable to index '%s' again: %w", location, err)
+		})
+	}
+	return nil
+}
+
+// reindex replaces the index of a changed file in the rolodex, with the index of the file opened again.
+func (w *RolodexWatcher) reindex(location string, open func() (*SpecIndex, error)) *RolodexWatchEvent {
+	affected := w.affected(location)
+	w.rolodex.removeIndex(location)
+	idx, err := open()
+	w.rolodex.logger.Debug("[rolodex watcher] file changed, indexed again", "file", location,
+		"affected", len(affected))
+	return &RolodexWatchEvent{Location: location, Index: idx, Affected: affected, Error: err}
+}
+
+// remove removes a file that no longer exists from the rolodex.
+func (w *RolodexWatcher) remove(location string, deleteFile func()) *RolodexWatchEvent {
+	affected := w.affected(location)
+	deleteFile()
+	w.rolodex.removeIndex(location)
+	delete(w.files, location)
+	w.rolodex.logger.Debug("[rolodex watcher] file removed", "file", location, "affected", len(affected))
+	return &RolodexWatchEvent{Location: location, Removed: true, Affected: affected}
+}
+
+// affected returns the references, in every index of the rolodex other than the index of the file, that point into
+// a file.
+func (w *RolodexWatcher) affected(location string) []*Reference {
+	w.rolodex.indexLock.Lock()
+	indexes := append([]*SpecIndex{w.rolodex.rootIndex}, w.rolodex.indexes...)
+	w.rolodex.indexLock.Unlock()
+
+	var affected []*Reference
+	seen := make(map[*SpecIndex]bool)
+	for _, idx := range indexes {
+		if idx == nil || seen[idx] || idx.specAbsolutePath == location {
+			continue
+		}
+		seen[idx] = true
+		for _, ref := range idx.GetRawReferencesSequenced() {
+			if file, _, _ := strings.Cut(ref.FullDefinition, "#"); file == location {
+				affected = append(affected, ref)
+			}
+		}
+	}
+	return affected
+}
@@ -0,0 +1,166 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+// SPDX-License-Identifier: MIT
+
+package index
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// writeWatched writes a watched file, with a modification time that is different to its last one.
+func writeWatched(t *testing.T, name, content string, modified time.Time) {
+	require.NoError(t, os.WriteFile(name, []byte(content), 0o644))
+	require.NoError(t, os.Chtimes(name, modified, modified))
+}
+
+func TestRolodexWatcher_LocalFiles(t *testing.T) {
+	tmp := t.TempDir()
+	petFile, ownerFile := filepath.Join(tmp, "pet.yaml"), filepath.Join(tmp, "owner.yaml")
+	writeWatched(t, petFile, "Pet:\n  type: object\n  properties:\n    owner:\n      $ref: 'owner.yaml#/Owner'", time.Now())
+	writeWatched(t, ownerFile, "Owner:\n  type: object", time.Now())
+	root := `openapi: 3.1.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml#/Pet'
+    Owner:
+      $ref: 'owner.yaml#/Owner'`
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo := NewRolodex(cf)
+	rolo.AddLocalFS(tmp, localFS)
+	var rootNode yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(root), &rootNode))
+	rolo.SetRootNode(&rootNode)
+	require.NoError(t, rolo.IndexTheRolodex(context.Background()))
+	require.Len(t, localFS.GetFiles(), 2)
+
+	watcher := NewRolodexWatcher(rolo, nil)
+	ctx := context.Background()
+	assert.Empty(t, watcher.Check(ctx, true))
+
+	// a file that is written without changing its content has not changed.
+	later := time.Now().Add(time.Minute)
+	writeWatched(t, ownerFile, "Owner:\n  type: object", later)
+	assert.Empty(t, watcher.Check(ctx, false))
+
+	writeWatched(t, petFile, "Pet:\n  type: object\n  properties:\n    name:\n      type: string\n    owner:\n"+
+		"      $ref: 'owner.yaml#/Owner'", later)
+	events := watcher.Check(ctx, false)
+	require.Len(t, events, 1)
+	assert.Equal(t, petFile, events[0].Location)
+	require.NoError(t, events[0].Error)
+	require.NotNil(t, events[0].Index)
+	assert.Equal(t, petFile, events[0].Index.GetSpecAbsolutePath())
+	require.Len(t, events[0].Affected, 1)
+	assert.Equal(t, petFile+"#/Pet", events[0].Affected[0].FullDefinition)
+
+	pet, err := rolo.Open(petFile)
+	require.NoError(t, err)
+	assert.Contains(t, pet.GetContent(), "name:")
+	assert.Same(t, events[0].Index, pet.GetIndex())
+	assert.Contains(t, rolo.GetIndexes(), events[0].Index)
+
+	// references into a removed file, from the root and the new index of the changed file, are affected.
+	require.NoError(t, os.Remove(ownerFile))
+	events = watcher.Check(ctx, false)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Removed)
+	assert.Nil(t, events[0].Index)
+	assert.Len(t, events[0].Affected, 2)
+	assert.NotContains(t, localFS.GetFiles(), ownerFile)
+	assert.Empty(t, watcher.Check(ctx, false))
+}
+
+func TestRolodexWatcher_Watch(t *testing.T) {
+	tmp := t.TempDir()
+	petFile := filepath.Join(tmp, "pet.yaml")
+	writeWatched(t, petFile, "Pet:\n  type: object", time.Now())
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BasePath = tmp
+	localFS, err := NewLocalFSWithConfig(&LocalFSConfig{BaseDirectory: tmp, IndexConfig: cf})
+	require.NoError(t, err)
+	rolo := NewRolodex(cf)
+	rolo.AddLocalFS(tmp, localFS)
+	_, err = rolo.Open(petFile)
+	require.NoError(t, err)
+
+	watcher := NewRolodexWatcher(rolo, &RolodexWatcherConfig{Interval: 5 * time.Millisecond, RemoteInterval: -1})
+	ctx, cancel := context.WithCancel(context.Background())
+	go watcher.Watch(ctx)
+
+	writeWatched(t, petFile, "Pet:\n  type: string", time.Now().Add(time.Minute))
+	select {
+	case e := <-watcher.Events():
+		assert.Equal(t, petFile, e.Location)
+		assert.NotNil(t, e.Index)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event for a changed file")
+	}
+
+	cancel()
+	for range watcher.Events() {
+	}
+}
+
+func TestRolodexWatcher_RemoteFiles(t *testing.T) {
+	var content atomic.Value
+	content.Store("Pet:\n  type: object")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := content.Load().(string)
+		if c == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(c))
+	}))
+	defer server.Close()
+
+	cf := CreateOpenAPIIndexConfig()
+	cf.BaseURL, _ = url.Parse(server.URL)
+	remoteFS, err := NewRemoteFSWithConfig(cf)
+	require.NoError(t, err)
+	rolo := NewRolodex(cf)
+	rolo.AddRemoteFS(server.URL, remoteFS)
+	_, err = rolo.Open(server.URL + "/pet.yaml")
+	require.NoError(t, err)
+
+	watcher := NewRolodexWatcher(rolo, nil)
+	ctx := context.Background()
+	content.Store("Pet:\n  type: string")
+
+	// remote files are only fetched when they are checked.
+	assert.Empty(t, watcher.Check(ctx, false))
+	events := watcher.Check(ctx, true)
+	require.Len(t, events, 1)
+	assert.Equal(t, server.URL+"/pet.yaml", events[0].Location)
+	require.NotNil(t, events[0].Index)
+	pet, err := rolo.Open(server.URL + "/pet.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "Pet:\n  type: string", pet.GetContent())
+	assert.Same(t, events[0].Index, pet.GetIndex())
+	assert.Empty(t, watcher.Check(ctx, true))
+
+	content.Store("")
+	events = watcher.Check(ctx, true)
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Removed)
+	assert.Empty(t, remoteFS.GetFiles())
+}
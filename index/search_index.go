@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"path/filepath"
 	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
 )
 
 type ContextKey string
@@ -55,11 +57,11 @@ func IsFileBeingIndexed(ctx context.Context, filePath string) bool {
 		return true
 	}
 	// For HTTP URLs, also check if the path matches any indexed file's path
-	if strings.HasPrefix(filePath, "http") {
+	if isURLLocation(filePath) {
 		if u, err := url.Parse(filePath); err == nil {
 			// Check if the path portion matches any indexed file
 			for indexedFile := range files {
-				if strings.HasPrefix(indexedFile, "http") {
+				if isURLLocation(indexedFile) {
 					if indexedU, err2 := url.Parse(indexedFile); err2 == nil {
 						// Compare paths (the filename portion)
 						if u.Path == indexedU.Path || filepath.Base(u.Path) == filepath.Base(indexedU.Path) {
@@ -124,7 +126,7 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 	uri := strings.Split(ref, "#/")
 	if len(uri) == 2 {
 		if uri[0] != "" {
-			if strings.HasPrefix(uri[0], "http") {
+			if isURLLocation(uri[0]) {
 				roloLookup = searchRef.FullDefinition
 			} else {
 				if filepath.IsAbs(uri[0]) {
@@ -152,7 +154,7 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 		if filepath.IsAbs(uri[0]) {
 			roloLookup = uri[0]
 		} else {
-			if strings.HasPrefix(uri[0], "http") {
+			if isURLLocation(uri[0]) {
 				roloLookup = ref
 			} else {
 				if filepath.Ext(absPath) != "" {
@@ -218,6 +220,10 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 			}
 			return r, index, ctx
 		}
+		// remote files are never opened when remote references are skipped.
+		if index.config != nil && index.config.SkipRemoteReferences && IsRemoteReference(roloLookup) {
+			return nil, index, ctx
+		}
 		rFile, err := index.rolodex.Open(roloLookup)
 		if err != nil {
 			return nil, index, ctx
@@ -326,7 +332,15 @@ func (index *SpecIndex) SearchIndexForReferenceByReferenceWithContext(ctx contex
 				}
 			}
 		}
-		index.logger.Error("unable to locate reference anywhere in the rolodex", "reference", ref)
+		index.logger.Error("unable to locate reference anywhere in the rolodex",
+			datamodel.LogKeyRef, ref, datamodel.LogKeyFile, index.specAbsolutePath)
+		index.config.EmitBuildEvent(&datamodel.BuildEvent{
+			Type:      datamodel.BuildEventUnresolvedReference,
+			Subsystem: datamodel.LogSubsystemIndex,
+			Message:   fmt.Sprintf("unable to locate reference '%s' anywhere in the rolodex", ref),
+			File:      index.specAbsolutePath,
+			Ref:       ref,
+		})
 	}
 	return nil, index, ctx
 }
@@ -16,7 +16,6 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -25,6 +24,7 @@ import (
 	"github.com/pb33f/jsonpath/pkg/jsonpath"
 	jsonpathconfig "github.com/pb33f/jsonpath/pkg/jsonpath/config"
 
+	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/utils"
 
 	"go.yaml.in/yaml/v4"
@@ -43,13 +43,7 @@ func NewSpecIndexWithConfigAndContext(ctx context.Context, rootNode *yaml.Node,
 	index.rolodex = config.Rolodex
 	index.uri = config.uri
 	index.specAbsolutePath = config.SpecAbsolutePath
-	if config.Logger != nil {
-		index.logger = config.Logger
-	} else {
-		index.logger = slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-			Level: slog.LevelError,
-		}))
-	}
+	index.logger = config.GetSubsystemLogger(datamodel.LogSubsystemIndex)
 	if rootNode == nil || len(rootNode.Content) <= 0 {
 		return index
 	}
@@ -171,10 +165,20 @@ func (index *SpecIndex) BuildIndex() {
 	index.built = true
 }
 
+// GetLogger returns the logger used by the index.
 func (index *SpecIndex) GetLogger() *slog.Logger {
 	return index.logger
 }
 
+// GetSubsystemLogger returns the logger configured for a subsystem (such as the resolver, bundler or builders)
+// that is working with this index. If the index has no configuration, the index logger is used.
+func (index *SpecIndex) GetSubsystemLogger(subsystem datamodel.LogSubsystem) *slog.Logger {
+	if index.config == nil {
+		return datamodel.SubsystemLogger(nil, index.logger, subsystem)
+	}
+	return index.config.GetSubsystemLogger(subsystem)
+}
+
 // GetRootNode returns document root node.
 func (index *SpecIndex) GetRootNode() *yaml.Node {
 	return index.root
@@ -535,6 +539,25 @@ func (index *SpecIndex) GetReferenceIndexErrors() []error {
 	return index.refErrors
 }
 
+// GetRemotePlaceholders will return every remote reference that was not looked up, because SkipRemoteReferences
+// is set in the index configuration.
+func (index *SpecIndex) GetRemotePlaceholders() []*Reference {
+	index.refLock.RLock()
+	defer index.refLock.RUnlock()
+	return index.remotePlaceholders
+}
+
+// IsRemotePlaceholder returns true if the supplied (fully qualified) reference was not looked up, because
+// SkipRemoteReferences is set in the index configuration.
+func (index *SpecIndex) IsRemotePlaceholder(ref string) bool {
+	for _, placeholder := range index.GetRemotePlaceholders() {
+		if placeholder.FullDefinition == ref {
+			return true
+		}
+	}
+	return false
+}
+
 // GetOperationParametersIndexErrors any errors that occurred when indexing operation parameters
 func (index *SpecIndex) GetOperationParametersIndexErrors() []error {
 	return index.operationParamErrors
@@ -124,13 +124,13 @@ func extractRequiredReferenceProperties(fulldef string, requiredPropDefNode *yam
 
 	defPath := fulldef
 
-	if strings.HasPrefix(refName, "http") || filepath.IsAbs(refName) {
+	if isURLLocation(refName) || filepath.IsAbs(refName) {
 		defPath = refName
 	} else {
 		exp := strings.Split(fulldef, "#/")
 		if len(exp) == 2 {
 			if exp[0] != "" {
-				if strings.HasPrefix(exp[0], "http") {
+				if isURLLocation(exp[0]) {
 					u, _ := url.Parse(exp[0])
 					r := strings.Split(refName, "#/")
 					if len(r) == 2 {
@@ -175,7 +175,7 @@ func extractRequiredReferenceProperties(fulldef string, requiredPropDefNode *yam
 				defPath = refName
 			}
 		} else {
-			if strings.HasPrefix(exp[0], "http") {
+			if isURLLocation(exp[0]) {
 				u, _ := url.Parse(exp[0])
 				r := strings.Split(refName, "#/")
 				if len(r) == 2 {
@@ -607,7 +607,7 @@ func GenerateCleanSpecConfigBaseURL(baseURL *url.URL, dir string, includeFile bo
 		}
 		cleanedPath = fmt.Sprintf("%s/%s", strings.Join(pathSegs, "/"), strings.Join(cleanedSegs, "/"))
 	} else {
-		if !strings.HasPrefix(dir, "http") {
+		if !isURLLocation(dir) {
 			if len(pathSegs) > 1 || len(dirSegs) > 1 {
 				cleanedPath = fmt.Sprintf("%s/%s", strings.Join(pathSegs, "/"), strings.Join(dirSegs, "/"))
 			}
@@ -616,7 +616,7 @@ func GenerateCleanSpecConfigBaseURL(baseURL *url.URL, dir string, includeFile bo
 		}
 	}
 	var p string
-	if baseURL.Scheme != "" && !strings.HasPrefix(dir, "http") {
+	if baseURL.Scheme != "" && !isURLLocation(dir) {
 		p = fmt.Sprintf("%s://%s%s", baseURL.Scheme, baseURL.Host, cleanedPath)
 	} else {
 		if !strings.Contains(cleanedPath, "/") {
@@ -0,0 +1,336 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// MergeConflictPolicy decides what MergeDocuments does when both documents define something with the same name,
+// but different content.
+type MergeConflictPolicy int
+
+const (
+	// MergeConflictError stops the merge, and returns an error describing the conflict.
+	MergeConflictError MergeConflictPolicy = iota
+
+	// MergeConflictRename keeps both components, the component from the right document is renamed by adding a
+	// number to its name (starting at 2, for example 'Pet2'), and every reference to it in the right document is
+	// updated. Paths and operations can not be renamed, so a conflicting operation is still an error.
+	MergeConflictRename
+
+	// MergeConflictPreferLeft keeps whatever the left document defines, and drops the conflicting definition from
+	// the right document.
+	MergeConflictPreferLeft
+)
+
+// MergeOptions control how MergeDocuments merges two documents.
+type MergeOptions struct {
+	// ConflictPolicy is used when both documents define a component, operation, tag or server with the same name,
+	// but different content. Definitions that are identical in both documents are never a conflict.
+	ConflictPolicy MergeConflictPolicy
+
+	// Configuration is used to create the merged document. If not set, the configuration of the left document is used.
+	Configuration *datamodel.DocumentConfiguration
+}
+
+// MergeConflict describes something defined by both documents being merged, with different content.
+type MergeConflict struct {
+	// Location is a JSON pointer to the conflicting definition, for example '#/components/schemas/Pet'.
+	Location string
+
+	// RenamedTo is the JSON pointer the definition from the right document was renamed to, it is empty unless the
+	// MergeConflictRename policy is used.
+	RenamedTo string
+}
+
+// ErrMergeConflict is wrapped by the error returned from MergeDocuments when a conflict stops the merge.
+var ErrMergeConflict = errors.New("merge conflict")
+
+// mergeComponentSections are the sections of components that are merged by name.
+var mergeComponentSections = []string{
+	"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes",
+	"links", "callbacks", "pathItems", "mediaTypes",
+}
+
+// MergeDocuments merges two OpenAPI 3+ documents into a new document. Everything from the left document is kept,
+// and the paths, webhooks, components, tags and servers of the right document are added to it. The info object,
+// global security and everything else at the root of the new document comes from the left document. Neither of
+// the supplied documents is changed. To merge more than two documents, merge the result with the next document.
+//
+// Paths that only exist in one document are copied as-is, paths that exist in both documents are merged
+// operation by operation. Tags are merged by name and servers are merged by URL.
+//
+// When both documents define something with the same name but different content, the options decide what
+// happens, see MergeConflictPolicy. Every conflict found is returned, alongside the merged document.
+//
+// References are copied exactly as they are written, so references to external files in the right document must
+// resolve from the location of the left document. Bundle documents before merging them if they do not.
+func MergeDocuments(left, right Document, options *MergeOptions) (Document, []*MergeConflict, error) {
+	if left == nil || right == nil {
+		return nil, nil, errors.New("unable to merge, both documents are required")
+	}
+	if options == nil {
+		options = &MergeOptions{}
+	}
+	leftRoot, err := mergeRootNode(left)
+	if err != nil {
+		return nil, nil, err
+	}
+	rightRoot, err := mergeRootNode(right)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m := &documentMerger{policy: options.ConflictPolicy, renames: make(map[string]string)}
+	if err = m.mergeComponents(leftRoot, rightRoot); err != nil {
+		return nil, m.conflicts, err
+	}
+	if len(m.renames) > 0 {
+		rewriteMergedRefs(rightRoot, m.renames)
+	}
+	for _, key := range []string{"paths", "webhooks"} {
+		if err = m.mergePathItems(leftRoot, rightRoot, key); err != nil {
+			return nil, m.conflicts, err
+		}
+	}
+	if err = m.mergeNamedList(leftRoot, rightRoot, "tags", "name"); err != nil {
+		return nil, m.conflicts, err
+	}
+	if err = m.mergeNamedList(leftRoot, rightRoot, "servers", "url"); err != nil {
+		return nil, m.conflicts, err
+	}
+
+	merged, err := yaml.Marshal(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{leftRoot}})
+	if err != nil {
+		return nil, m.conflicts, err
+	}
+	if left.GetSpecInfo().SpecFileType == datamodel.JSONFileType {
+		if merged, err = utils.ConvertYAMLtoJSON(merged); err != nil {
+			return nil, m.conflicts, err
+		}
+	}
+	config := options.Configuration
+	if config == nil {
+		config = left.GetConfiguration()
+	}
+	doc, err := NewDocumentWithConfiguration(merged, config)
+	return doc, m.conflicts, err
+}
+
+// mergeRootNode returns a copy of the root mapping node of a document, so it can be changed freely.
+func mergeRootNode(doc Document) (*yaml.Node, error) {
+	info := doc.GetSpecInfo()
+	if info == nil || info.RootNode == nil || len(info.RootNode.Content) == 0 {
+		return nil, errors.New("unable to merge, the document has not been parsed")
+	}
+	if info.SpecType != utils.OpenApi3 {
+		return nil, fmt.Errorf("unable to merge, only OpenAPI 3+ documents can be merged, not '%s' documents",
+			info.SpecType)
+	}
+	return utils.CloneYAMLNode(info.RootNode.Content[0]), nil
+}
+
+type documentMerger struct {
+	policy    MergeConflictPolicy
+	renames   map[string]string
+	conflicts []*MergeConflict
+}
+
+// conflict records a conflict, and returns an error if the policy (or the lack of a way to rename) stops the merge.
+func (m *documentMerger) conflict(location string, canRename bool) error {
+	m.conflicts = append(m.conflicts, &MergeConflict{Location: location})
+	if m.policy == MergeConflictError || (m.policy == MergeConflictRename && !canRename) {
+		return fmt.Errorf("%w: '%s' is defined by both documents, with different content", ErrMergeConflict, location)
+	}
+	return nil
+}
+
+func (m *documentMerger) mergeComponents(leftRoot, rightRoot *yaml.Node) error {
+	rightComponents := mergeMappingValue(rightRoot, "components")
+	if rightComponents == nil || rightComponents.Kind != yaml.MappingNode {
+		return nil
+	}
+	leftComponents := mergeEnsureMapping(leftRoot, "components")
+	for _, section := range mergeComponentSections {
+		rightSection := mergeMappingValue(rightComponents, section)
+		if rightSection == nil || rightSection.Kind != yaml.MappingNode {
+			continue
+		}
+		leftSection := mergeEnsureMapping(leftComponents, section)
+		for i := 0; i+1 < len(rightSection.Content); i += 2 {
+			name := rightSection.Content[i].Value
+			value := rightSection.Content[i+1]
+			existing := mergeMappingValue(leftSection, name)
+			if existing == nil {
+				mergeSetMappingValue(leftSection, name, value)
+				continue
+			}
+			if utils.YAMLNodesEqual(existing, value) {
+				continue
+			}
+			location := "#/components/" + section + "/" + name
+			if err := m.conflict(location, true); err != nil {
+				return err
+			}
+			if m.policy != MergeConflictRename {
+				continue
+			}
+			renamed := name
+			for n := 2; mergeMappingValue(leftSection, renamed) != nil || mergeMappingValue(rightSection, renamed) != nil; n++ {
+				renamed = name + strconv.Itoa(n)
+			}
+			mergeSetMappingValue(leftSection, renamed, value)
+			renamedLocation := "#/components/" + section + "/" + renamed
+			m.renames[location] = renamedLocation
+			m.conflicts[len(m.conflicts)-1].RenamedTo = renamedLocation
+		}
+	}
+	return nil
+}
+
+func (m *documentMerger) mergePathItems(leftRoot, rightRoot *yaml.Node, key string) error {
+	rightPaths := mergeMappingValue(rightRoot, key)
+	if rightPaths == nil || rightPaths.Kind != yaml.MappingNode {
+		return nil
+	}
+	leftPaths := mergeEnsureMapping(leftRoot, key)
+	for i := 0; i+1 < len(rightPaths.Content); i += 2 {
+		path := rightPaths.Content[i].Value
+		rightItem := rightPaths.Content[i+1]
+		leftItem := mergeMappingValue(leftPaths, path)
+		if leftItem == nil {
+			mergeSetMappingValue(leftPaths, path, rightItem)
+			continue
+		}
+		if utils.YAMLNodesEqual(leftItem, rightItem) {
+			continue
+		}
+		if leftItem.Kind != yaml.MappingNode || rightItem.Kind != yaml.MappingNode {
+			if err := m.conflict(mergePointer(key, path), false); err != nil {
+				return err
+			}
+			continue
+		}
+		for j := 0; j+1 < len(rightItem.Content); j += 2 {
+			field := rightItem.Content[j].Value
+			rightValue := rightItem.Content[j+1]
+			leftValue := mergeMappingValue(leftItem, field)
+			if leftValue == nil {
+				mergeSetMappingValue(leftItem, field, rightValue)
+				continue
+			}
+			if utils.YAMLNodesEqual(leftValue, rightValue) {
+				continue
+			}
+			if err := m.conflict(mergePointer(key, path, field), false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeNamedList merges two sequences of mappings, identified by the value of the name field.
+func (m *documentMerger) mergeNamedList(leftRoot, rightRoot *yaml.Node, key, nameField string) error {
+	rightList := mergeMappingValue(rightRoot, key)
+	if rightList == nil || rightList.Kind != yaml.SequenceNode {
+		return nil
+	}
+	leftList := mergeMappingValue(leftRoot, key)
+	if leftList == nil {
+		leftList = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		mergeSetMappingValue(leftRoot, key, leftList)
+	}
+	for i, rightItem := range rightList.Content {
+		name := mergeMappingValue(rightItem, nameField)
+		var existing *yaml.Node
+		if name != nil {
+			for _, leftItem := range leftList.Content {
+				if n := mergeMappingValue(leftItem, nameField); n != nil && n.Value == name.Value {
+					existing = leftItem
+					break
+				}
+			}
+		}
+		if existing == nil {
+			leftList.Content = append(leftList.Content, rightItem)
+			continue
+		}
+		if utils.YAMLNodesEqual(existing, rightItem) {
+			continue
+		}
+		if err := m.conflict(mergePointer(key, strconv.Itoa(i)), false); err != nil && m.policy == MergeConflictError {
+			return err
+		}
+	}
+	return nil
+}
+
+// rewriteMergedRefs updates every reference in the tree that points to a renamed component, or inside one.
+func rewriteMergedRefs(node *yaml.Node, renames map[string]string) {
+	if node == nil {
+		return
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value != "$ref" || node.Content[i+1].Kind != yaml.ScalarNode {
+				continue
+			}
+			ref := node.Content[i+1].Value
+			for from, to := range renames {
+				if ref == from || strings.HasPrefix(ref, from+"/") {
+					node.Content[i+1].Value = to + strings.TrimPrefix(ref, from)
+					break
+				}
+			}
+		}
+	}
+	for _, child := range node.Content {
+		rewriteMergedRefs(child, renames)
+	}
+}
+
+func mergePointer(segments ...string) string {
+	var b strings.Builder
+	b.WriteByte('#')
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+func mergeMappingValue(n *yaml.Node, key string) *yaml.Node {
+	if n == nil || n.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return n.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func mergeSetMappingValue(n *yaml.Node, key string, value *yaml.Node) {
+	n.Content = append(n.Content, utils.CreateStringNode(key), value)
+}
+
+// mergeEnsureMapping returns the mapping under the key, adding an empty one if it does not exist.
+func mergeEnsureMapping(n *yaml.Node, key string) *yaml.Node {
+	if v := mergeMappingValue(n, key); v != nil {
+		return v
+	}
+	v := utils.CreateEmptyMapNode()
+	mergeSetMappingValue(n, key, v)
+	return v
+}
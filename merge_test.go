@@ -0,0 +1,207 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var mergeLeftSpec = `openapi: 3.1.0
+info:
+  title: Left
+  version: 1.0.0
+servers:
+  - url: https://left.example.com
+tags:
+  - name: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string
+    Shared:
+      type: string`
+
+var mergeRightSpec = `openapi: 3.1.0
+info:
+  title: Right
+  version: 2.0.0
+servers:
+  - url: https://right.example.com
+tags:
+  - name: pets
+  - name: stores
+paths:
+  /pets:
+    post:
+      operationId: createPet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "201":
+          description: created
+  /stores:
+    get:
+      operationId: listStores
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Store'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        id:
+          type: integer
+    Shared:
+      type: string
+    Store:
+      type: object
+      properties:
+        pet:
+          $ref: '#/components/schemas/Pet'`
+
+func mergeDocs(t *testing.T) (Document, Document) {
+	left, err := NewDocument([]byte(mergeLeftSpec))
+	require.NoError(t, err)
+	right, err := NewDocument([]byte(mergeRightSpec))
+	require.NoError(t, err)
+	return left, right
+}
+
+func TestMergeDocuments_ConflictError(t *testing.T) {
+	left, right := mergeDocs(t)
+	doc, conflicts, err := MergeDocuments(left, right, nil)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrMergeConflict))
+	assert.Nil(t, doc)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "#/components/schemas/Pet", conflicts[0].Location)
+}
+
+func TestMergeDocuments_PreferLeft(t *testing.T) {
+	left, right := mergeDocs(t)
+	doc, conflicts, err := MergeDocuments(left, right, &MergeOptions{ConflictPolicy: MergeConflictPreferLeft})
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+
+	model, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	m := model.Model
+	assert.Equal(t, "Left", m.Info.Title)
+	assert.Equal(t, 3, m.Components.Schemas.Len())
+	pet := m.Components.Schemas.GetOrZero("Pet").Schema()
+	assert.NotNil(t, pet.Properties.GetOrZero("name"))
+	assert.Nil(t, pet.Properties.GetOrZero("id"))
+
+	pets := m.Paths.PathItems.GetOrZero("/pets")
+	require.NotNil(t, pets)
+	assert.Equal(t, "listPets", pets.Get.OperationId)
+	assert.Equal(t, "createPet", pets.Post.OperationId)
+	assert.NotNil(t, m.Paths.PathItems.GetOrZero("/stores"))
+
+	require.Len(t, m.Tags, 2)
+	assert.Equal(t, "stores", m.Tags[1].Name)
+	require.Len(t, m.Servers, 2)
+	assert.Equal(t, "https://right.example.com", m.Servers[1].URL)
+
+	// the originals are not changed
+	assert.NotContains(t, string(*left.GetSpecInfo().SpecBytes), "stores")
+}
+
+func TestMergeDocuments_Rename(t *testing.T) {
+	left, right := mergeDocs(t)
+	doc, conflicts, err := MergeDocuments(left, right, &MergeOptions{ConflictPolicy: MergeConflictRename})
+	require.NoError(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "#/components/schemas/Pet2", conflicts[0].RenamedTo)
+
+	model, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	m := model.Model
+	assert.Equal(t, 4, m.Components.Schemas.Len())
+	assert.NotNil(t, m.Components.Schemas.GetOrZero("Pet2").Schema().Properties.GetOrZero("id"))
+
+	post := m.Paths.PathItems.GetOrZero("/pets").Post
+	schema := post.RequestBody.Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pet2", schema.GetReference())
+
+	get := m.Paths.PathItems.GetOrZero("/pets").Get
+	schema = get.Responses.Codes.GetOrZero("200").Content.GetOrZero("application/json").Schema
+	assert.Equal(t, "#/components/schemas/Pet", schema.GetReference())
+
+	store := m.Components.Schemas.GetOrZero("Store").Schema()
+	assert.Equal(t, "#/components/schemas/Pet2", store.Properties.GetOrZero("pet").GetReference())
+}
+
+func TestMergeDocuments_OperationConflict(t *testing.T) {
+	left, err := NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: a
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: a`))
+	require.NoError(t, err)
+	right, err := NewDocument([]byte(`openapi: 3.1.0
+info:
+  title: b
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: b`))
+	require.NoError(t, err)
+
+	_, conflicts, err := MergeDocuments(left, right, &MergeOptions{ConflictPolicy: MergeConflictRename})
+	require.Error(t, err)
+	require.Len(t, conflicts, 1)
+	assert.Equal(t, "#/paths/~1pets/get", conflicts[0].Location)
+
+	doc, _, err := MergeDocuments(left, right, &MergeOptions{ConflictPolicy: MergeConflictPreferLeft})
+	require.NoError(t, err)
+	model, errs := doc.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Equal(t, "a", model.Model.Paths.PathItems.GetOrZero("/pets").Get.OperationId)
+}
+
+func TestMergeDocuments_Swagger(t *testing.T) {
+	left, err := NewDocument([]byte(`swagger: "2.0"
+info:
+  title: a
+  version: 1.0.0
+paths: {}`))
+	require.NoError(t, err)
+	right, _ := mergeDocs(t)
+
+	_, _, err = MergeDocuments(left, right, nil)
+	assert.Error(t, err)
+	_, _, err = MergeDocuments(nil, right, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,356 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v2high "github.com/pb33f/libopenapi/datamodel/high/v2"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// Model is a version-agnostic, read-only view of a Swagger (OpenAPI 2) or OpenAPI 3+ model, returned by
+// Document.BuildModel(). Generic tooling (linters, statistics, search) can use a Model to read operations,
+// parameters and schemas in the same shape regardless of the version of the specification, instead of needing
+// a code path for each version.
+//
+// The normalized shapes only cover what both versions have in common. The full version specific model is always
+// available via V2() or V3().
+type Model interface {
+	// GetVersion returns the exact version of the specification, for example '2.0' or '3.1.0'.
+	GetVersion() string
+
+	// GetSpecFormat returns the format of the specification, one of datamodel.OAS2, datamodel.OAS3,
+	// datamodel.OAS31 or datamodel.OAS32.
+	GetSpecFormat() string
+
+	// GetInfo returns the info object of the specification.
+	GetInfo() *base.Info
+
+	// GetTags returns the global tags of the specification.
+	GetTags() []*base.Tag
+
+	// GetOperations returns every operation in the specification, in the order of the paths and the order
+	// the operations are defined in each path.
+	GetOperations() []*ModelOperation
+
+	// GetParameters returns the reusable parameters of the specification, which are the top level 'parameters'
+	// for Swagger and 'components/parameters' for OpenAPI 3+.
+	GetParameters() *orderedmap.Map[string, *ModelParameter]
+
+	// GetSchemas returns the reusable schemas of the specification, which are the top level 'definitions'
+	// for Swagger and 'components/schemas' for OpenAPI 3+.
+	GetSchemas() *orderedmap.Map[string, *base.SchemaProxy]
+
+	// GetIndex returns the index of the root document.
+	GetIndex() *index.SpecIndex
+
+	// GetStats returns the timings and reference counts collected when the model was built.
+	GetStats() *BuildStats
+
+	// V2 returns the Swagger model, or nil if the specification is not a Swagger specification.
+	V2() *DocumentModel[v2high.Swagger]
+
+	// V3 returns the OpenAPI 3+ model, or nil if the specification is not an OpenAPI 3+ specification.
+	V3() *DocumentModel[v3high.Document]
+}
+
+// ModelOperation is an operation in a version-agnostic shape.
+type ModelOperation struct {
+	Path        string
+	Method      string
+	OperationId string
+	Summary     string
+	Description string
+	Tags        []string
+	Deprecated  bool
+
+	// Parameters contains the parameters of the path the operation belongs to, and the parameters of the
+	// operation itself, which override path parameters with the same name and location.
+	// Swagger 'body' parameters are not included, they are normalized into the RequestBody.
+	Parameters []*ModelParameter
+
+	// RequestBody is nil if the operation does not accept a request body.
+	RequestBody *ModelRequestBody
+
+	// Responses are keyed by status code, a default response is keyed as 'default'.
+	Responses *orderedmap.Map[string, *ModelResponse]
+}
+
+// ModelParameter is a parameter in a version-agnostic shape. The schema of a Swagger parameter that is not a
+// 'body' parameter is created from its type, format, items, enum and default values.
+type ModelParameter struct {
+	Name        string
+	In          string
+	Description string
+	Required    bool
+	Deprecated  bool
+	Schema      *base.SchemaProxy
+}
+
+// ModelRequestBody is a request body in a version-agnostic shape. The schema of a Swagger 'body' parameter is
+// keyed by each of the media types the operation consumes.
+type ModelRequestBody struct {
+	Description string
+	Required    bool
+	Content     *orderedmap.Map[string, *base.SchemaProxy]
+}
+
+// ModelResponse is a response in a version-agnostic shape. The schema of a Swagger response is keyed by each of
+// the media types the operation produces.
+type ModelResponse struct {
+	Description string
+	Content     *orderedmap.Map[string, *base.SchemaProxy]
+}
+
+// anyMediaType is used for Swagger schemas, when an operation does not declare any media types it consumes
+// or produces.
+const anyMediaType = "*/*"
+
+// agnosticModel implements Model for both Swagger and OpenAPI 3+ models. It's normalized when it's created.
+type agnosticModel struct {
+	version    string
+	specFormat string
+	info       *base.Info
+	tags       []*base.Tag
+	operations []*ModelOperation
+	parameters *orderedmap.Map[string, *ModelParameter]
+	schemas    *orderedmap.Map[string, *base.SchemaProxy]
+	index      *index.SpecIndex
+	stats      *BuildStats
+	v2         *DocumentModel[v2high.Swagger]
+	v3         *DocumentModel[v3high.Document]
+}
+
+func (m *agnosticModel) GetVersion() string                                      { return m.version }
+func (m *agnosticModel) GetSpecFormat() string                                   { return m.specFormat }
+func (m *agnosticModel) GetInfo() *base.Info                                     { return m.info }
+func (m *agnosticModel) GetTags() []*base.Tag                                    { return m.tags }
+func (m *agnosticModel) GetOperations() []*ModelOperation                        { return m.operations }
+func (m *agnosticModel) GetParameters() *orderedmap.Map[string, *ModelParameter] { return m.parameters }
+func (m *agnosticModel) GetSchemas() *orderedmap.Map[string, *base.SchemaProxy]  { return m.schemas }
+func (m *agnosticModel) GetIndex() *index.SpecIndex                              { return m.index }
+func (m *agnosticModel) GetStats() *BuildStats                                   { return m.stats }
+func (m *agnosticModel) V2() *DocumentModel[v2high.Swagger]                      { return m.v2 }
+func (m *agnosticModel) V3() *DocumentModel[v3high.Document]                     { return m.v3 }
+
+// newV3Model normalizes an OpenAPI 3+ model.
+func newV3Model(version, specFormat string, dm *DocumentModel[v3high.Document]) *agnosticModel {
+	m := &agnosticModel{
+		version:    version,
+		specFormat: specFormat,
+		info:       dm.Model.Info,
+		tags:       dm.Model.Tags,
+		parameters: orderedmap.New[string, *ModelParameter](),
+		schemas:    orderedmap.New[string, *base.SchemaProxy](),
+		index:      dm.Index,
+		stats:      dm.Stats,
+		v3:         dm,
+	}
+	if c := dm.Model.Components; c != nil {
+		for name, p := range c.Parameters.FromOldest() {
+			m.parameters.Set(name, v3Parameter(p))
+		}
+		for name, s := range c.Schemas.FromOldest() {
+			m.schemas.Set(name, s)
+		}
+	}
+	if dm.Model.Paths == nil {
+		return m
+	}
+	for path, pathItem := range dm.Model.Paths.PathItems.FromOldest() {
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			mo := &ModelOperation{
+				Path:        path,
+				Method:      method,
+				OperationId: op.OperationId,
+				Summary:     op.Summary,
+				Description: op.Description,
+				Tags:        op.Tags,
+				Deprecated:  op.Deprecated != nil && *op.Deprecated,
+				Responses:   orderedmap.New[string, *ModelResponse](),
+			}
+			for _, p := range pathItem.Parameters {
+				mo.Parameters = mergeParameter(mo.Parameters, v3Parameter(p))
+			}
+			for _, p := range op.Parameters {
+				mo.Parameters = mergeParameter(mo.Parameters, v3Parameter(p))
+			}
+			if rb := op.RequestBody; rb != nil {
+				mo.RequestBody = &ModelRequestBody{
+					Description: rb.Description,
+					Required:    rb.Required != nil && *rb.Required,
+					Content:     v3Content(rb.Content),
+				}
+			}
+			if op.Responses != nil {
+				for code, r := range op.Responses.Codes.FromOldest() {
+					mo.Responses.Set(code, &ModelResponse{Description: r.Description, Content: v3Content(r.Content)})
+				}
+				if r := op.Responses.Default; r != nil {
+					mo.Responses.Set("default", &ModelResponse{Description: r.Description, Content: v3Content(r.Content)})
+				}
+			}
+			m.operations = append(m.operations, mo)
+		}
+	}
+	return m
+}
+
+func v3Parameter(p *v3high.Parameter) *ModelParameter {
+	mp := &ModelParameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required != nil && *p.Required,
+		Deprecated:  p.Deprecated,
+		Schema:      p.Schema,
+	}
+	// parameters can define their schema using content instead, there can only be one entry.
+	if first := orderedmap.First(p.Content); mp.Schema == nil && first != nil {
+		mp.Schema = first.Value().Schema
+	}
+	return mp
+}
+
+func v3Content(content *orderedmap.Map[string, *v3high.MediaType]) *orderedmap.Map[string, *base.SchemaProxy] {
+	c := orderedmap.New[string, *base.SchemaProxy]()
+	for mediaType, mt := range content.FromOldest() {
+		c.Set(mediaType, mt.Schema)
+	}
+	return c
+}
+
+// newV2Model normalizes a Swagger model.
+func newV2Model(version, specFormat string, dm *DocumentModel[v2high.Swagger]) *agnosticModel {
+	m := &agnosticModel{
+		version:    version,
+		specFormat: specFormat,
+		info:       dm.Model.Info,
+		tags:       dm.Model.Tags,
+		parameters: orderedmap.New[string, *ModelParameter](),
+		schemas:    orderedmap.New[string, *base.SchemaProxy](),
+		index:      dm.Index,
+		stats:      dm.Stats,
+		v2:         dm,
+	}
+	if dm.Model.Parameters != nil {
+		for name, p := range dm.Model.Parameters.Definitions.FromOldest() {
+			m.parameters.Set(name, v2Parameter(p))
+		}
+	}
+	if dm.Model.Definitions != nil {
+		for name, s := range dm.Model.Definitions.Definitions.FromOldest() {
+			m.schemas.Set(name, s)
+		}
+	}
+	if dm.Model.Paths == nil {
+		return m
+	}
+	for path, pathItem := range dm.Model.Paths.PathItems.FromOldest() {
+		for method, op := range pathItem.GetOperations().FromOldest() {
+			mo := &ModelOperation{
+				Path:        path,
+				Method:      method,
+				OperationId: op.OperationId,
+				Summary:     op.Summary,
+				Description: op.Description,
+				Tags:        op.Tags,
+				Deprecated:  op.Deprecated,
+				Responses:   orderedmap.New[string, *ModelResponse](),
+			}
+			consumes := mediaTypes(op.Consumes, dm.Model.Consumes)
+			produces := mediaTypes(op.Produces, dm.Model.Produces)
+
+			var body *v2high.Parameter
+			for _, params := range [][]*v2high.Parameter{pathItem.Parameters, op.Parameters} {
+				for _, p := range params {
+					if p.In == "body" {
+						body = p
+						continue
+					}
+					mo.Parameters = mergeParameter(mo.Parameters, v2Parameter(p))
+				}
+			}
+			if body != nil {
+				mo.RequestBody = &ModelRequestBody{
+					Description: body.Description,
+					Required:    body.Required != nil && *body.Required,
+					Content:     v2Content(consumes, body.Schema),
+				}
+			}
+			if op.Responses != nil {
+				for code, r := range op.Responses.Codes.FromOldest() {
+					mo.Responses.Set(code, &ModelResponse{Description: r.Description, Content: v2Content(produces, r.Schema)})
+				}
+				if r := op.Responses.Default; r != nil {
+					mo.Responses.Set("default", &ModelResponse{Description: r.Description, Content: v2Content(produces, r.Schema)})
+				}
+			}
+			m.operations = append(m.operations, mo)
+		}
+	}
+	return m
+}
+
+func v2Parameter(p *v2high.Parameter) *ModelParameter {
+	mp := &ModelParameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required != nil && *p.Required,
+		Schema:      p.Schema,
+	}
+	if mp.Schema == nil && p.Type != "" {
+		s := &base.Schema{Type: []string{p.Type}, Format: p.Format, Enum: p.Enum, Default: p.Default}
+		if p.Items != nil {
+			s.Items = &base.DynamicValue[*base.SchemaProxy, bool]{A: v2ItemsSchema(p.Items)}
+		}
+		mp.Schema = base.CreateSchemaProxy(s)
+	}
+	return mp
+}
+
+func v2ItemsSchema(items *v2high.Items) *base.SchemaProxy {
+	s := &base.Schema{Type: []string{items.Type}, Format: items.Format, Enum: items.Enum, Default: items.Default}
+	if items.Items != nil {
+		s.Items = &base.DynamicValue[*base.SchemaProxy, bool]{A: v2ItemsSchema(items.Items)}
+	}
+	return base.CreateSchemaProxy(s)
+}
+
+func v2Content(mediaTypes []string, schema *base.SchemaProxy) *orderedmap.Map[string, *base.SchemaProxy] {
+	c := orderedmap.New[string, *base.SchemaProxy]()
+	if schema == nil {
+		return c
+	}
+	for _, mediaType := range mediaTypes {
+		c.Set(mediaType, schema)
+	}
+	return c
+}
+
+// mediaTypes returns the media types of an operation, falling back to the global media types of the
+// specification, and then any media type.
+func mediaTypes(operation, global []string) []string {
+	if len(operation) > 0 {
+		return operation
+	}
+	if len(global) > 0 {
+		return global
+	}
+	return []string{anyMediaType}
+}
+
+// mergeParameter adds a parameter, replacing any existing parameter with the same name and location.
+func mergeParameter(params []*ModelParameter, p *ModelParameter) []*ModelParameter {
+	for i := range params {
+		if params[i].Name == p.Name && params[i].In == p.In {
+			params[i] = p
+			return params
+		}
+	}
+	return append(params, p)
+}
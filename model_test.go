@@ -0,0 +1,258 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const modelSwaggerSpec = `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+consumes:
+  - application/json
+parameters:
+  limit:
+    name: limit
+    in: query
+    type: integer
+    format: int32
+definitions:
+  Pet:
+    type: object
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        type: string
+      - name: verbose
+        in: query
+        type: boolean
+    put:
+      operationId: updatePet
+      summary: Update a pet
+      tags: [pets]
+      deprecated: true
+      produces:
+        - application/xml
+      parameters:
+        - name: verbose
+          in: query
+          description: be chatty
+          type: array
+          items:
+            type: string
+            enum: [yes, no]
+        - name: pet
+          in: body
+          required: true
+          schema:
+            $ref: '#/definitions/Pet'
+      responses:
+        "200":
+          description: OK
+          schema:
+            $ref: '#/definitions/Pet'
+        default:
+          description: Error
+    get:
+      operationId: getPet
+      responses:
+        "204":
+          description: No content`
+
+const modelOpenAPISpec = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+components:
+  parameters:
+    limit:
+      name: limit
+      in: query
+      schema:
+        type: integer
+        format: int32
+  schemas:
+    Pet:
+      type: object
+paths:
+  /pets/{id}:
+    parameters:
+      - name: id
+        in: path
+        required: true
+        schema:
+          type: string
+      - name: verbose
+        in: query
+        schema:
+          type: boolean
+    put:
+      operationId: updatePet
+      summary: Update a pet
+      tags: [pets]
+      deprecated: true
+      parameters:
+        - name: verbose
+          in: query
+          description: be chatty
+          content:
+            application/json:
+              schema:
+                type: array
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+      responses:
+        "200":
+          description: OK
+          content:
+            application/xml:
+              schema:
+                $ref: '#/components/schemas/Pet'
+        default:
+          description: Error
+    get:
+      operationId: getPet
+      responses:
+        "204":
+          description: No content`
+
+func TestDocument_BuildModel_Normalized(t *testing.T) {
+	for _, spec := range []string{modelSwaggerSpec, modelOpenAPISpec} {
+		doc, err := NewDocument([]byte(spec))
+		require.NoError(t, err)
+		m, err := doc.BuildModel()
+		require.NoError(t, err)
+
+		assert.Equal(t, "Pets", m.GetInfo().Title)
+		assert.Len(t, m.GetTags(), 1)
+		assert.Equal(t, "int32", m.GetParameters().GetOrZero("limit").Schema.Schema().Format)
+		assert.Equal(t, []string{"object"}, m.GetSchemas().GetOrZero("Pet").Schema().Type)
+		assert.NotNil(t, m.GetIndex())
+		assert.NotNil(t, m.GetStats())
+
+		ops := m.GetOperations()
+		require.Len(t, ops, 2)
+		get, put := ops[0], ops[1]
+		if get.Method != "get" {
+			get, put = put, get
+		}
+		assert.Equal(t, "getPet", get.OperationId)
+		assert.Nil(t, get.RequestBody)
+		assert.Len(t, get.Parameters, 2)
+
+		assert.Equal(t, "/pets/{id}", put.Path)
+		assert.Equal(t, "put", put.Method)
+		assert.Equal(t, "updatePet", put.OperationId)
+		assert.Equal(t, "Update a pet", put.Summary)
+		assert.Equal(t, []string{"pets"}, put.Tags)
+		assert.True(t, put.Deprecated)
+
+		// path parameters are merged with operation parameters, which win.
+		require.Len(t, put.Parameters, 2)
+		assert.Equal(t, "id", put.Parameters[0].Name)
+		assert.True(t, put.Parameters[0].Required)
+		assert.Equal(t, []string{"string"}, put.Parameters[0].Schema.Schema().Type)
+		assert.Equal(t, "be chatty", put.Parameters[1].Description)
+		assert.Equal(t, []string{"array"}, put.Parameters[1].Schema.Schema().Type)
+
+		require.NotNil(t, put.RequestBody)
+		assert.True(t, put.RequestBody.Required)
+		assert.Equal(t, []string{"object"}, put.RequestBody.Content.GetOrZero("application/json").Schema().Type)
+
+		assert.Equal(t, []string{"200", "default"}, orderedKeys(put.Responses))
+		assert.Equal(t, "OK", put.Responses.GetOrZero("200").Description)
+		assert.Equal(t, []string{"object"},
+			put.Responses.GetOrZero("200").Content.GetOrZero("application/xml").Schema().Type)
+		assert.Equal(t, 0, put.Responses.GetOrZero("default").Content.Len())
+	}
+}
+
+func TestDocument_BuildModel_Versions(t *testing.T) {
+	doc, _ := NewDocument([]byte(modelSwaggerSpec))
+	m, _ := doc.BuildModel()
+	assert.Equal(t, "2.0", m.GetVersion())
+	assert.Equal(t, datamodel.OAS2, m.GetSpecFormat())
+	assert.NotNil(t, m.V2())
+	assert.Nil(t, m.V3())
+	v2, _ := doc.BuildV2Model()
+	assert.Same(t, v2, m.V2())
+
+	doc, _ = NewDocument([]byte(modelOpenAPISpec))
+	m, _ = doc.BuildModel()
+	assert.Equal(t, "3.1.0", m.GetVersion())
+	assert.Equal(t, datamodel.OAS31, m.GetSpecFormat())
+	assert.Nil(t, m.V2())
+	v3, _ := doc.BuildV3Model()
+	assert.Same(t, v3, m.V3())
+}
+
+func TestDocument_BuildModel_SwaggerItems(t *testing.T) {
+	doc, _ := NewDocument([]byte(modelSwaggerSpec))
+	m, _ := doc.BuildModel()
+	put := m.GetOperations()[0]
+	verbose := put.Parameters[1].Schema.Schema()
+	items := verbose.Items.A.Schema()
+	assert.Equal(t, []string{"string"}, items.Type)
+	assert.Len(t, items.Enum, 2)
+}
+
+func TestDocument_BuildModel_Corpus(t *testing.T) {
+	for _, f := range []string{"test_specs/petstorev2-complete.yaml", "test_specs/petstorev3.json"} {
+		bs, _ := os.ReadFile(f)
+		doc, err := NewDocument(bs)
+		require.NoError(t, err)
+		m, err := doc.BuildModel()
+		require.NoError(t, err, f)
+		assert.Len(t, m.GetOperations(), m.GetIndex().GetOperationCount(), f)
+		assert.Equal(t, m.GetIndex().GetComponentSchemaCount(), m.GetSchemas().Len(), f)
+	}
+}
+
+func TestDocument_BuildModel_Errors(t *testing.T) {
+	_, err := new(document).BuildModel()
+	assert.Error(t, err)
+
+	doc, err := NewDocumentWithConfiguration([]byte("pizza: time"), &datamodel.DocumentConfiguration{BypassDocumentCheck: true})
+	require.NoError(t, err)
+	_, err = doc.BuildModel()
+	assert.Error(t, err)
+
+	doc, err = NewDocument([]byte(`swagger: "2.0"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          $ref: '#/nope'`))
+	require.NoError(t, err)
+	_, err = doc.BuildModel()
+	assert.Error(t, err)
+}
+
+func orderedKeys[V any](m *orderedmap.Map[string, V]) []string {
+	var k []string
+	for key := range m.KeysFromOldest() {
+		k = append(k, key)
+	}
+	return k
+}
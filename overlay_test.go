@@ -4,6 +4,7 @@
 package libopenapi
 
 import (
+	"context"
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel"
@@ -451,7 +452,19 @@ func (m *mockDocument) BuildV2Model() (*DocumentModel[v2.Swagger], error) { retu
 func (m *mockDocument) BuildV3Model() (*DocumentModel[v3.Document], error) {
 	return nil, nil
 }
-func (m *mockDocument) Serialize() ([]byte, error) { return nil, nil }
+func (m *mockDocument) BuildV3ModelWithContext(context.Context) (*DocumentModel[v3.Document], error) {
+	return m.BuildV3Model()
+}
+func (m *mockDocument) BuildV2ModelWithContext(context.Context) (*DocumentModel[v2.Swagger], error) {
+	return nil, nil
+}
+func (m *mockDocument) BuildModel() (Model, error)                           { return nil, nil }
+func (m *mockDocument) BuildModelWithContext(context.Context) (Model, error) { return nil, nil }
+func (m *mockDocument) Serialize() ([]byte, error)                           { return nil, nil }
+func (m *mockDocument) InvalidateModel() error                               { return nil }
+func (m *mockDocument) Snapshot() Document                                   { return m }
+func (m *mockDocument) Clone() (Document, error)                             { return m, nil }
+func (m *mockDocument) ApplyPatch([]byte) error                              { return nil }
 func (m *mockDocument) RenderAndReload() ([]byte, Document, *DocumentModel[v3.Document], error) {
 	return nil, nil, nil, nil
 }
@@ -531,4 +544,3 @@ actions:
 	assert.Error(t, err)
 	assert.Nil(t, result)
 }
-
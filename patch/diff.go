@@ -0,0 +1,125 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Diff returns the operations that turn the 'from' tree into the 'to' tree, so that applying them to 'from'
+// results in a tree equal to 'to'. Mappings are compared key by key, and sequences item by item (items removed
+// from or added to the start or end of a sequence become single remove or add operations). Values that are not
+// the same kind are replaced entirely. Neither tree is modified, the values of the operations are copies.
+func Diff(from, to *yaml.Node) []*Operation {
+	var ops []*Operation
+	diffNode(unwrapDocument(from), unwrapDocument(to), "", &ops)
+	return ops
+}
+
+// Encode renders operations as a JSON Patch document.
+func Encode(operations []*Operation) ([]byte, error) {
+	seq := utils.CreateEmptySequenceNode()
+	for _, op := range operations {
+		n := utils.CreateEmptyMapNode()
+		n.Content = append(n.Content, utils.CreateStringNode("op"), utils.CreateStringNode(op.Op),
+			utils.CreateStringNode("path"), utils.CreateStringNode(op.Path))
+		if op.Op == OpMove || op.Op == OpCopy {
+			n.Content = append(n.Content, utils.CreateStringNode("from"), utils.CreateStringNode(op.From))
+		}
+		if op.Value != nil {
+			n.Content = append(n.Content, utils.CreateStringNode("value"), op.Value)
+		}
+		seq.Content = append(seq.Content, n)
+	}
+	return json.YAMLNodeToJSON(seq, "  ")
+}
+
+func unwrapDocument(n *yaml.Node) *yaml.Node {
+	if n != nil && n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		return n.Content[0]
+	}
+	return n
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n != nil && n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+func diffNode(from, to *yaml.Node, pointer string, ops *[]*Operation) {
+	from, to = resolveAlias(from), resolveAlias(to)
+	if utils.YAMLNodesEqual(from, to) {
+		return
+	}
+	switch {
+	case from == nil || to == nil || from.Kind != to.Kind:
+	case from.Kind == yaml.MappingNode:
+		diffMapping(from, to, pointer, ops)
+		return
+	case from.Kind == yaml.SequenceNode:
+		diffSequence(from, to, pointer, ops)
+		return
+	}
+	*ops = append(*ops, &Operation{Op: OpReplace, Path: pointer, Value: utils.CloneYAMLNode(to)})
+}
+
+func diffMapping(from, to *yaml.Node, pointer string, ops *[]*Operation) {
+	for i := 0; i+1 < len(from.Content); i += 2 {
+		key := from.Content[i].Value
+		if keyIndex(to, key) < 0 {
+			*ops = append(*ops, &Operation{Op: OpRemove, Path: pointer + "/" + escapeToken(key)})
+		}
+	}
+	for i := 0; i+1 < len(to.Content); i += 2 {
+		key := to.Content[i].Value
+		p := pointer + "/" + escapeToken(key)
+		if j := keyIndex(from, key); j >= 0 {
+			diffNode(from.Content[j+1], to.Content[i+1], p, ops)
+			continue
+		}
+		*ops = append(*ops, &Operation{Op: OpAdd, Path: p, Value: utils.CloneYAMLNode(to.Content[i+1])})
+	}
+}
+
+// diffSequence skips the items that are the same at the start and the end of both sequences, and compares the
+// items left in the middle by position.
+func diffSequence(from, to *yaml.Node, pointer string, ops *[]*Operation) {
+	prefix := 0
+	for prefix < len(from.Content) && prefix < len(to.Content) &&
+		utils.YAMLNodesEqual(from.Content[prefix], to.Content[prefix]) {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from.Content)-prefix && suffix < len(to.Content)-prefix &&
+		utils.YAMLNodesEqual(from.Content[len(from.Content)-1-suffix], to.Content[len(to.Content)-1-suffix]) {
+		suffix++
+	}
+	fromMiddle := from.Content[prefix : len(from.Content)-suffix]
+	toMiddle := to.Content[prefix : len(to.Content)-suffix]
+
+	common := min(len(fromMiddle), len(toMiddle))
+	for i := 0; i < common; i++ {
+		diffNode(fromMiddle[i], toMiddle[i], pointer+"/"+strconv.Itoa(prefix+i), ops)
+	}
+	// remove from the end first, so the indexes of the items still to be removed do not change.
+	for i := len(fromMiddle) - 1; i >= common; i-- {
+		*ops = append(*ops, &Operation{Op: OpRemove, Path: pointer + "/" + strconv.Itoa(prefix+i)})
+	}
+	for i := common; i < len(toMiddle); i++ {
+		*ops = append(*ops, &Operation{
+			Op: OpAdd, Path: pointer + "/" + strconv.Itoa(prefix+i), Value: utils.CloneYAMLNode(toMiddle[i]),
+		})
+	}
+}
+
+func escapeToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,116 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestDiff(t *testing.T) {
+	updated := `openapi: 3.1.0
+info:
+  title: Cats
+  version: 1.0.0
+  description: all the cats
+tags:
+  - name: zero
+  - name: one
+  - name: three
+paths:
+  /pets:
+    get: list pets
+  /cats/{id}:
+    get:
+      description: a cat`
+
+	var from, to yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(target), &from))
+	require.NoError(t, yaml.Unmarshal([]byte(updated), &to))
+
+	ops := Diff(&from, &to)
+	rendered, err := Encode(ops)
+	require.NoError(t, err)
+	assert.Equal(t, `[
+  {
+    "op": "replace",
+    "path": "/info/title",
+    "value": "Cats"
+  },
+  {
+    "op": "add",
+    "path": "/info/description",
+    "value": "all the cats"
+  },
+  {
+    "op": "replace",
+    "path": "/tags/0/name",
+    "value": "zero"
+  },
+  {
+    "op": "replace",
+    "path": "/tags/1/name",
+    "value": "one"
+  },
+  {
+    "op": "add",
+    "path": "/tags/2",
+    "value": {
+      "name": "three"
+    }
+  },
+  {
+    "op": "replace",
+    "path": "/paths/~1pets/get",
+    "value": "list pets"
+  },
+  {
+    "op": "add",
+    "path": "/paths/~1cats~1{id}",
+    "value": {
+      "get": {
+        "description": "a cat"
+      }
+    }
+  }
+]`, string(rendered))
+}
+
+func TestDiff_RoundTrip(t *testing.T) {
+	docs := []string{
+		target,
+		"openapi: 3.1.0\ntags: [{name: two}]\nx-list: [1, 2, 3, 4]",
+		"openapi: 3.1.0\ninfo: {title: Pets}\ntags: []\nx-list: [0, 1, 4, 5, 6]",
+		"openapi: 3.1.0\npaths: {}\nx-list: [1, 2, 3, 4]",
+	}
+	for _, f := range docs {
+		for _, u := range docs {
+			var from, to yaml.Node
+			require.NoError(t, yaml.Unmarshal([]byte(f), &from))
+			require.NoError(t, yaml.Unmarshal([]byte(u), &to))
+
+			rendered, err := Encode(Diff(&from, &to))
+			require.NoError(t, err)
+			ops, err := Decode(rendered)
+			require.NoError(t, err)
+			patched, err := Apply(&from, ops)
+			require.NoError(t, err)
+			assert.True(t, utils.YAMLNodesEqual(&to, patched), "%s\n->\n%s", f, u)
+		}
+	}
+}
+
+func TestDiff_Same(t *testing.T) {
+	var from yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(target), &from))
+	assert.Empty(t, Diff(&from, &from))
+
+	rendered, err := Encode(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(rendered))
+}
@@ -0,0 +1,48 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PatchError represents an error that occurred while applying a single operation of a patch.
+type PatchError struct {
+	Operation *Operation
+	Index     int // position of the operation in the patch.
+	Cause     error
+}
+
+func (e *PatchError) Error() string {
+	if e.Operation != nil {
+		return fmt.Sprintf("patch error at operation %d (%s '%s'): %v",
+			e.Index, e.Operation.Op, e.Operation.Path, e.Cause)
+	}
+	return fmt.Sprintf("patch error: %v", e.Cause)
+}
+
+func (e *PatchError) Unwrap() error {
+	return e.Cause
+}
+
+// Sentinel errors for patch operations.
+var (
+	// Parsing errors
+	ErrInvalidPatch     = errors.New("invalid patch, must be an array of operations")
+	ErrInvalidOperation = errors.New("invalid operation, must be one of add, remove, replace, move, copy or test")
+	ErrMissingPath      = errors.New("missing required 'path' field")
+	ErrMissingFrom      = errors.New("missing required 'from' field")
+	ErrMissingValue     = errors.New("missing required 'value' field")
+
+	// JSON Pointer errors
+	ErrInvalidPointer = errors.New("invalid JSON Pointer")
+	ErrPathNotFound   = errors.New("path does not exist in the document")
+	ErrInvalidIndex   = errors.New("invalid array index")
+
+	// Application errors
+	ErrNoTargetDocument = errors.New("no target document provided")
+	ErrMoveIntoChild    = errors.New("cannot move a location into one of its children")
+	ErrTestFailed       = errors.New("test operation failed, value does not match")
+)
@@ -0,0 +1,330 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package patch applies JSON Patch (RFC 6902) documents directly to yaml.Node trees.
+//
+// Operations are applied to the nodes themselves, so comments, key ordering and styles everywhere else in the
+// tree are left intact. A patch is applied atomically; if any operation fails, the original tree is not modified.
+// Diff creates the patch that describes the difference between two trees.
+package patch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// Supported patch operations.
+const (
+	OpAdd     = "add"
+	OpRemove  = "remove"
+	OpReplace = "replace"
+	OpMove    = "move"
+	OpCopy    = "copy"
+	OpTest    = "test"
+)
+
+// Operation is a single JSON Patch operation.
+type Operation struct {
+	Op    string
+	Path  string
+	From  string     // only used by move and copy.
+	Value *yaml.Node // only used by add, replace and test.
+}
+
+// Decode parses a JSON Patch document. As JSON is a subset of YAML, the patch may also be written as YAML.
+func Decode(patch []byte) ([]*Operation, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(patch, &root); err != nil {
+		return nil, err
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.SequenceNode {
+		return nil, ErrInvalidPatch
+	}
+	var ops []*Operation
+	for i, n := range root.Content[0].Content {
+		op, err := decodeOperation(n)
+		if err != nil {
+			return nil, &PatchError{Operation: op, Index: i, Cause: err}
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+func decodeOperation(n *yaml.Node) (*Operation, error) {
+	if n.Kind != yaml.MappingNode {
+		return nil, ErrInvalidPatch
+	}
+	op := new(Operation)
+	var hasPath, hasFrom bool
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		v := n.Content[i+1]
+		switch n.Content[i].Value {
+		case "op":
+			op.Op = v.Value
+		case "path":
+			op.Path, hasPath = v.Value, true
+		case "from":
+			op.From, hasFrom = v.Value, true
+		case "value":
+			op.Value = v
+		}
+	}
+	switch op.Op {
+	case OpAdd, OpReplace, OpTest:
+		if op.Value == nil {
+			return op, ErrMissingValue
+		}
+		// values arrive in JSON flow style, reset them so they take on the style of the target document.
+		resetStyle(op.Value)
+	case OpMove, OpCopy:
+		if !hasFrom {
+			return op, ErrMissingFrom
+		}
+	case OpRemove:
+	default:
+		return op, ErrInvalidOperation
+	}
+	if !hasPath {
+		return op, ErrMissingPath
+	}
+	return op, nil
+}
+
+// Apply applies the operations to a copy of the supplied root node, and returns the patched copy. The root node
+// itself is never modified, so if any operation fails, the error is returned and the original tree is untouched.
+func Apply(root *yaml.Node, operations []*Operation) (*yaml.Node, error) {
+	if root == nil {
+		return nil, ErrNoTargetDocument
+	}
+	doc := utils.CloneYAMLNode(root)
+	if doc.Kind != yaml.DocumentNode {
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{doc}}
+	}
+	for i, op := range operations {
+		if err := applyOperation(doc, op); err != nil {
+			return nil, &PatchError{Operation: op, Index: i, Cause: err}
+		}
+	}
+	if root.Kind != yaml.DocumentNode {
+		return doc.Content[0], nil
+	}
+	return doc, nil
+}
+
+func applyOperation(doc *yaml.Node, op *Operation) error {
+	switch op.Op {
+	case OpAdd:
+		return add(doc, op.Path, utils.CloneYAMLNode(op.Value))
+	case OpRemove:
+		_, err := remove(doc, op.Path)
+		return err
+	case OpReplace:
+		return replace(doc, op.Path, utils.CloneYAMLNode(op.Value))
+	case OpMove:
+		if op.From == op.Path {
+			return nil
+		}
+		if strings.HasPrefix(op.Path, op.From+"/") {
+			return ErrMoveIntoChild
+		}
+		n, err := remove(doc, op.From)
+		if err != nil {
+			return err
+		}
+		return add(doc, op.Path, n)
+	case OpCopy:
+		n, err := get(doc, op.From)
+		if err != nil {
+			return err
+		}
+		return add(doc, op.Path, utils.CloneYAMLNode(n))
+	case OpTest:
+		n, err := get(doc, op.Path)
+		if err != nil {
+			return err
+		}
+		if !utils.YAMLNodesEqual(n, op.Value) {
+			return ErrTestFailed
+		}
+		return nil
+	}
+	return ErrInvalidOperation
+}
+
+// parsePointer splits a JSON Pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, ErrInvalidPointer
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(tokens[i], "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+// locate returns the parent node of the location a pointer refers to, along with the final reference token.
+// The parent for the root of the document is the document node itself.
+func locate(doc *yaml.Node, pointer string) (*yaml.Node, string, error) {
+	tokens, err := parsePointer(pointer)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(tokens) == 0 {
+		return doc, "", nil
+	}
+	parent := doc.Content[0]
+	for _, token := range tokens[:len(tokens)-1] {
+		if parent, err = child(parent, token); err != nil {
+			return nil, "", err
+		}
+	}
+	return parent, tokens[len(tokens)-1], nil
+}
+
+func child(n *yaml.Node, token string) (*yaml.Node, error) {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	switch n.Kind {
+	case yaml.MappingNode:
+		if i := keyIndex(n, token); i >= 0 {
+			return n.Content[i+1], nil
+		}
+	case yaml.SequenceNode:
+		i, err := arrayIndex(token, len(n.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		return n.Content[i], nil
+	}
+	return nil, ErrPathNotFound
+}
+
+func get(doc *yaml.Node, pointer string) (*yaml.Node, error) {
+	parent, token, err := locate(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	if parent == doc {
+		return doc.Content[0], nil
+	}
+	return child(parent, token)
+}
+
+func add(doc *yaml.Node, pointer string, value *yaml.Node) error {
+	parent, token, err := locate(doc, pointer)
+	if err != nil {
+		return err
+	}
+	switch {
+	case parent == doc:
+		doc.Content[0] = value
+	case parent.Kind == yaml.MappingNode:
+		if i := keyIndex(parent, token); i >= 0 {
+			parent.Content[i+1] = value
+			return nil
+		}
+		parent.Content = append(parent.Content, utils.CreateStringNode(token), value)
+	case parent.Kind == yaml.SequenceNode:
+		i := len(parent.Content)
+		if token != "-" {
+			if i, err = arrayIndex(token, len(parent.Content)); err != nil {
+				return err
+			}
+		}
+		parent.Content = append(parent.Content, nil)
+		copy(parent.Content[i+1:], parent.Content[i:])
+		parent.Content[i] = value
+	default:
+		return ErrPathNotFound
+	}
+	return nil
+}
+
+// replace swaps the value at an existing location in place, so mapping keys keep their position.
+func replace(doc *yaml.Node, pointer string, value *yaml.Node) error {
+	parent, token, err := locate(doc, pointer)
+	if err != nil {
+		return err
+	}
+	switch {
+	case parent == doc:
+		doc.Content[0] = value
+		return nil
+	case parent.Kind == yaml.MappingNode:
+		if i := keyIndex(parent, token); i >= 0 {
+			parent.Content[i+1] = value
+			return nil
+		}
+	case parent.Kind == yaml.SequenceNode:
+		i, err := arrayIndex(token, len(parent.Content)-1)
+		if err != nil {
+			return err
+		}
+		parent.Content[i] = value
+		return nil
+	}
+	return ErrPathNotFound
+}
+
+func remove(doc *yaml.Node, pointer string) (*yaml.Node, error) {
+	parent, token, err := locate(doc, pointer)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case parent == doc:
+		return nil, ErrInvalidPointer // the root of the document cannot be removed.
+	case parent.Kind == yaml.MappingNode:
+		if i := keyIndex(parent, token); i >= 0 {
+			n := parent.Content[i+1]
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+			return n, nil
+		}
+	case parent.Kind == yaml.SequenceNode:
+		i, err := arrayIndex(token, len(parent.Content)-1)
+		if err != nil {
+			return nil, err
+		}
+		n := parent.Content[i]
+		parent.Content = append(parent.Content[:i], parent.Content[i+1:]...)
+		return n, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+func keyIndex(n *yaml.Node, key string) int {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// arrayIndex parses an array index token, which must be a plain non-negative integer no larger than maxIndex.
+func arrayIndex(token string, maxIndex int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, ErrInvalidIndex
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i > maxIndex {
+		return 0, ErrInvalidIndex
+	}
+	return i, nil
+}
+
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+const target = `openapi: 3.1.0
+info:
+  title: Pets # the title
+  version: 1.0.0
+tags:
+  - name: one
+  - name: two
+paths:
+  /pets:
+    get:
+      description: list pets`
+
+func applyPatch(t *testing.T, doc, patch string) (*yaml.Node, string, error) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &root))
+	ops, err := Decode([]byte(patch))
+	require.NoError(t, err)
+	patched, err := Apply(&root, ops)
+	if err != nil {
+		return &root, "", err
+	}
+	out, _ := yaml.Marshal(patched)
+	return &root, string(out), nil
+}
+
+func TestApply_Add(t *testing.T) {
+	_, out, err := applyPatch(t, target, `[
+  {"op": "add", "path": "/info/description", "value": "all the pets"},
+  {"op": "add", "path": "/tags/1", "value": {"name": "middle"}},
+  {"op": "add", "path": "/tags/-", "value": {"name": "last"}},
+  {"op": "add", "path": "/paths/~1pets/get/x-thing~0s", "value": [1, "2"]}
+]`)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Pets # the title
+    version: 1.0.0
+    description: all the pets
+tags:
+    - name: one
+    - name: middle
+    - name: two
+    - name: last
+paths:
+    /pets:
+        get:
+            description: list pets
+            x-thing~s:
+                - 1
+                - "2"
+`, out)
+}
+
+func TestApply_Remove(t *testing.T) {
+	_, out, err := applyPatch(t, target, `[
+  {"op": "remove", "path": "/tags/0"},
+  {"op": "remove", "path": "/paths"}
+]`)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    title: Pets # the title
+    version: 1.0.0
+tags:
+    - name: two
+`, out)
+}
+
+func TestApply_Replace(t *testing.T) {
+	_, out, err := applyPatch(t, target, `[
+  {"op": "replace", "path": "/openapi", "value": "3.2.0"},
+  {"op": "replace", "path": "/tags/1/name", "value": "three"}
+]`)
+	require.NoError(t, err)
+	assert.Contains(t, out, "openapi: 3.2.0\ninfo:")
+	assert.Contains(t, out, "- name: three")
+}
+
+func TestApply_Replace_Root(t *testing.T) {
+	_, out, err := applyPatch(t, target, `[{"op": "replace", "path": "", "value": {"openapi": "3.1.1"}}]`)
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.1\n", out)
+}
+
+func TestApply_MoveAndCopy(t *testing.T) {
+	_, out, err := applyPatch(t, target, `[
+  {"op": "copy", "from": "/paths/~1pets", "path": "/paths/~1dogs"},
+  {"op": "move", "from": "/info/title", "path": "/info/summary"},
+  {"op": "move", "from": "/tags/0", "path": "/tags/0"}
+]`)
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+    version: 1.0.0
+    summary: Pets # the title
+tags:
+    - name: one
+    - name: two
+paths:
+    /pets:
+        get:
+            description: list pets
+    /dogs:
+        get:
+            description: list pets
+`, out)
+}
+
+func TestApply_Test(t *testing.T) {
+	_, _, err := applyPatch(t, target, `[
+  {"op": "test", "path": "/info", "value": {"version": "1.0.0", "title": "Pets"}},
+  {"op": "test", "path": "/tags/1/name", "value": "two"}
+]`)
+	assert.NoError(t, err)
+
+	_, _, err = applyPatch(t, target, `[{"op": "test", "path": "/info/version", "value": "2.0.0"}]`)
+	assert.ErrorIs(t, err, ErrTestFailed)
+}
+
+func TestApply_Atomic(t *testing.T) {
+	root, _, err := applyPatch(t, target, `[
+  {"op": "remove", "path": "/paths"},
+  {"op": "remove", "path": "/nope"}
+]`)
+	var patchErr *PatchError
+	require.ErrorAs(t, err, &patchErr)
+	assert.Equal(t, 1, patchErr.Index)
+	assert.ErrorIs(t, err, ErrPathNotFound)
+	assert.Equal(t, "patch error at operation 1 (remove '/nope'): path does not exist in the document", err.Error())
+
+	// the original tree is untouched.
+	out, _ := yaml.Marshal(root)
+	assert.Contains(t, string(out), "/pets")
+}
+
+func TestApply_Errors(t *testing.T) {
+	tests := []struct {
+		patch string
+		err   error
+	}{
+		{`[{"op": "add", "path": "info", "value": 1}]`, ErrInvalidPointer},
+		{`[{"op": "add", "path": "/nope/nope", "value": 1}]`, ErrPathNotFound},
+		{`[{"op": "add", "path": "/tags/3", "value": 1}]`, ErrInvalidIndex},
+		{`[{"op": "add", "path": "/tags/01", "value": 1}]`, ErrInvalidIndex},
+		{`[{"op": "add", "path": "/openapi/x", "value": 1}]`, ErrPathNotFound},
+		{`[{"op": "remove", "path": ""}]`, ErrInvalidPointer},
+		{`[{"op": "remove", "path": "/tags/2"}]`, ErrInvalidIndex},
+		{`[{"op": "replace", "path": "/nope", "value": 1}]`, ErrPathNotFound},
+		{`[{"op": "replace", "path": "/tags/-", "value": 1}]`, ErrInvalidIndex},
+		{`[{"op": "move", "from": "/info", "path": "/info/nested"}]`, ErrMoveIntoChild},
+		{`[{"op": "move", "from": "/nope", "path": "/info/nested"}]`, ErrPathNotFound},
+		{`[{"op": "copy", "from": "/nope", "path": "/info/nested"}]`, ErrPathNotFound},
+		{`[{"op": "test", "path": "/nope", "value": 1}]`, ErrPathNotFound},
+	}
+	for _, tt := range tests {
+		_, _, err := applyPatch(t, target, tt.patch)
+		assert.ErrorIs(t, err, tt.err, tt.patch)
+	}
+}
+
+func TestApply_NoTarget(t *testing.T) {
+	_, err := Apply(nil, nil)
+	assert.ErrorIs(t, err, ErrNoTargetDocument)
+}
+
+func TestApply_NonDocumentNode(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(target), &root))
+	ops, err := Decode([]byte(`[{"op": "remove", "path": "/paths"}]`))
+	require.NoError(t, err)
+
+	patched, err := Apply(root.Content[0], ops)
+	require.NoError(t, err)
+	assert.Equal(t, yaml.MappingNode, patched.Kind)
+	assert.Len(t, patched.Content, 6)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := []struct {
+		patch string
+		err   error
+	}{
+		{`{"op": "add"}`, ErrInvalidPatch},
+		{`["add"]`, ErrInvalidPatch},
+		{`[{"op": "pizza", "path": "/a"}]`, ErrInvalidOperation},
+		{`[{"op": "add", "value": 1}]`, ErrMissingPath},
+		{`[{"op": "add", "path": "/a"}]`, ErrMissingValue},
+		{`[{"op": "move", "path": "/a"}]`, ErrMissingFrom},
+	}
+	for _, tt := range tests {
+		_, err := Decode([]byte(tt.patch))
+		assert.ErrorIs(t, err, tt.err, tt.patch)
+	}
+
+	_, err := Decode([]byte(`[{{{`))
+	assert.Error(t, err)
+	_, err = Decode([]byte(``))
+	assert.ErrorIs(t, err, ErrInvalidPatch)
+}
+
+func TestDecode_YAML(t *testing.T) {
+	ops, err := Decode([]byte(`- op: remove
+  path: /a
+- op: add
+  path: /b
+  value:
+    c: d`))
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+	assert.Equal(t, OpRemove, ops[0].Op)
+	assert.Equal(t, "/b", ops[1].Path)
+	assert.Equal(t, yaml.MappingNode, ops[1].Value.Kind)
+}
+
+func TestPatchError_NoOperation(t *testing.T) {
+	err := &PatchError{Cause: ErrInvalidPatch}
+	assert.Equal(t, "patch error: invalid patch, must be an array of operations", err.Error())
+}
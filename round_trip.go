@@ -0,0 +1,203 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"bytes"
+	"slices"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/json"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// renderModelNode renders the high-level model into a yaml.Node tree, the same tree that Render() encodes.
+func renderModelNode(m *v3high.Document) *yaml.Node {
+	n, _ := m.MarshalYAML()
+	node, _ := n.(*yaml.Node)
+	return node
+}
+
+// renderRoundTrip renders the model when the RoundTripFidelity configuration option is enabled.
+//
+// The snapshot is the model as it was rendered when it was built. If the model still renders exactly the same
+// way, nothing has been changed and the original bytes are returned. Otherwise, the differences between the
+// snapshot and the current model are merged into the original yaml node tree, and that tree is encoded.
+func (d *document) renderRoundTrip() ([]byte, error) {
+	current := renderModelNode(&d.highOpenAPI3Model.Model)
+	if utils.YAMLNodesEqualInOrder(d.roundTripSnapshot, current) && d.info.SpecBytes != nil {
+		return bytes.Clone(*d.info.SpecBytes), nil
+	}
+	original := d.info.RootNode
+	if original.Kind == yaml.DocumentNode && len(original.Content) > 0 {
+		original = original.Content[0]
+	}
+	return d.encodeNode(mergeRoundTrip(original, d.roundTripSnapshot, current))
+}
+
+// encodeNode encodes a yaml.Node tree in the same format (YAML or JSON) and with the same indentation as the
+// original specification.
+func (d *document) encodeNode(node *yaml.Node) ([]byte, error) {
+	if d.info.SpecFileType == datamodel.JSONFileType {
+		return json.YAMLNodeToJSON(node, jsonIndentation(d.info.OriginalIndentation))
+	}
+	var buf bytes.Buffer
+	yamlEncoder := yaml.NewEncoder(&buf)
+	if d.info.OriginalIndentation > 0 {
+		yamlEncoder.SetIndent(d.info.OriginalIndentation)
+	}
+	if err := yamlEncoder.Encode(node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// mergeRoundTrip performs a three-way merge. Any parts of the tree that differ between the snapshot and the
+// current render are applied to the original tree, which is returned as a new tree (the original is not
+// modified). Anything that has not changed is taken from the original as-is, which preserves its formatting,
+// comments and any content the model does not render.
+func mergeRoundTrip(original, snapshot, current *yaml.Node) *yaml.Node {
+	if current == nil {
+		return nil
+	}
+	if original == nil || snapshot == nil {
+		return utils.CloneYAMLNode(current)
+	}
+	if utils.YAMLNodesEqualInOrder(snapshot, current) {
+		return utils.CloneYAMLNode(original)
+	}
+	if original.Kind != current.Kind || snapshot.Kind != current.Kind {
+		return utils.CloneYAMLNode(current)
+	}
+	switch current.Kind {
+	case yaml.MappingNode:
+		return mergeRoundTripMapping(original, snapshot, current)
+	case yaml.SequenceNode:
+		if len(original.Content) != len(current.Content) || len(snapshot.Content) != len(current.Content) {
+			return utils.CloneYAMLNode(current)
+		}
+		n := utils.CloneYAMLNode(original)
+		for i := range current.Content {
+			n.Content[i] = mergeRoundTrip(original.Content[i], snapshot.Content[i], current.Content[i])
+		}
+		return n
+	case yaml.ScalarNode:
+		n := utils.CloneYAMLNode(original)
+		n.Value = current.Value
+		n.Tag = current.Tag
+		if n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 && current.Style&(yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			n.Style = current.Style
+		}
+		return n
+	}
+	return utils.CloneYAMLNode(current)
+}
+
+func mergeRoundTripMapping(original, snapshot, current *yaml.Node) *yaml.Node {
+	if mappingReordered(snapshot, current) {
+		return mergeRoundTripReordered(original, snapshot, current)
+	}
+	n := utils.CloneYAMLNode(original)
+	n.Content = nil
+	seen := make(map[string]struct{}, len(original.Content)/2)
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		key := original.Content[i].Value
+		seen[key] = struct{}{}
+		c := mappingValue(current, key)
+		s := mappingValue(snapshot, key)
+		switch {
+		case c != nil:
+			n.Content = append(n.Content, utils.CloneYAMLNode(original.Content[i]),
+				mergeRoundTrip(original.Content[i+1], s, c))
+		case s != nil:
+			// rendered when the model was built, but no longer, so it has been removed.
+		default:
+			// never rendered by the model, so it's kept as-is.
+			n.Content = append(n.Content, utils.CloneYAMLNode(original.Content[i]),
+				utils.CloneYAMLNode(original.Content[i+1]))
+		}
+	}
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		if _, ok := seen[current.Content[i].Value]; !ok {
+			n.Content = append(n.Content, utils.CloneYAMLNode(current.Content[i]),
+				utils.CloneYAMLNode(current.Content[i+1]))
+		}
+	}
+	return n
+}
+
+// mergeRoundTripReordered merges a mapping that has keys the model moved around. The keys are written in the order
+// of the current render, and keys that were never rendered by the model follow the key they followed originally.
+func mergeRoundTripReordered(original, snapshot, current *yaml.Node) *yaml.Node {
+	n := utils.CloneYAMLNode(original)
+	n.Content = nil
+	// the pairs of the original that were never rendered, by the index of the current key they follow (or -1).
+	unrendered := make(map[int][]int)
+	prev := -1
+	for i := 0; i+1 < len(original.Content); i += 2 {
+		key := original.Content[i].Value
+		if c := mappingIndex(current, key); c >= 0 {
+			prev = c
+		} else if mappingIndex(snapshot, key) < 0 {
+			unrendered[prev] = append(unrendered[prev], i)
+		}
+	}
+	keepUnrendered := func(after int) {
+		for _, i := range unrendered[after] {
+			n.Content = append(n.Content, utils.CloneYAMLNode(original.Content[i]),
+				utils.CloneYAMLNode(original.Content[i+1]))
+		}
+	}
+	keepUnrendered(-1)
+	for c := 0; c+1 < len(current.Content); c += 2 {
+		key := current.Content[c].Value
+		if o := mappingIndex(original, key); o >= 0 {
+			n.Content = append(n.Content, utils.CloneYAMLNode(original.Content[o]),
+				mergeRoundTrip(original.Content[o+1], mappingValue(snapshot, key), current.Content[c+1]))
+		} else {
+			n.Content = append(n.Content, utils.CloneYAMLNode(current.Content[c]),
+				utils.CloneYAMLNode(current.Content[c+1]))
+		}
+		keepUnrendered(c)
+	}
+	return n
+}
+
+// mappingReordered determines if the keys found in both the snapshot and current mappings are in a different order.
+func mappingReordered(snapshot, current *yaml.Node) bool {
+	var before, after []string
+	for i := 0; i+1 < len(snapshot.Content); i += 2 {
+		if mappingIndex(current, snapshot.Content[i].Value) >= 0 {
+			before = append(before, snapshot.Content[i].Value)
+		}
+	}
+	for i := 0; i+1 < len(current.Content); i += 2 {
+		if mappingIndex(snapshot, current.Content[i].Value) >= 0 {
+			after = append(after, current.Content[i].Value)
+		}
+	}
+	return !slices.Equal(before, after)
+}
+
+func mappingValue(n *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(n, key); i >= 0 {
+		return n.Content[i+1]
+	}
+	return nil
+}
+
+// mappingIndex returns the index of a key in a mapping, or -1 if the key is not in the mapping.
+func mappingIndex(n *yaml.Node, key string) int {
+	if n == nil {
+		return -1
+	}
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if n.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,202 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func roundTripDocument(t *testing.T, spec []byte) (Document, *DocumentModel[v3high.Document]) {
+	doc, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{RoundTripFidelity: true})
+	require.NoError(t, err)
+	m, _ := doc.BuildV3Model()
+	require.NotNil(t, m)
+	return doc, m
+}
+
+func TestDocument_RoundTripFidelity_Corpus(t *testing.T) {
+	files, _ := filepath.Glob("test_specs/*")
+	checked := 0
+	for _, f := range files {
+		bs, _ := os.ReadFile(f)
+		if bs == nil {
+			continue // directory
+		}
+		doc, err := NewDocumentWithConfiguration(bs, &datamodel.DocumentConfiguration{RoundTripFidelity: true})
+		if err != nil || doc.GetSpecInfo().SpecType != utils.OpenApi3 {
+			continue
+		}
+		if m, _ := doc.BuildV3Model(); m == nil {
+			continue
+		}
+		out, err := doc.Render()
+		require.NoError(t, err, f)
+		assert.Equal(t, string(bs), string(out), f)
+		checked++
+	}
+	assert.Greater(t, checked, 15)
+}
+
+func TestDocument_RoundTripFidelity_Mutated(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Pets # the title
+  version: 1
+  x-unknown: [a, b]
+tags: [{name: one}, {name: two}]
+paths:
+  /pets:
+    get:
+      summary:  List all the pets
+      deprecated: false
+      description: |
+        Multi-line
+        description.
+  /dogs:
+    get:
+      summary: 'List dogs'
+`)
+	doc, m := roundTripDocument(t, spec)
+
+	m.Model.Info.Title = "Pets and dogs"
+	m.Model.Paths.PathItems.Delete("/dogs")
+	m.Model.Paths.PathItems.Set("/cats", &v3high.PathItem{Get: &v3high.Operation{Summary: "List cats"}})
+	m.Model.Paths.PathItems.GetOrZero("/pets").Get.Description = "Single line."
+
+	out, err := doc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, `openapi: 3.1.0
+info:
+  title: Pets and dogs # the title
+  version: 1
+  x-unknown: [a, b]
+tags: [{name: one}, {name: two}]
+paths:
+  /pets:
+    get:
+      summary: List all the pets
+      deprecated: false
+      description: |-
+        Single line.
+  /cats:
+    get:
+      summary: List cats
+`, string(out))
+}
+
+func TestDocument_RoundTripFidelity_Mutated_JSON(t *testing.T) {
+	spec := []byte(`{
+    "openapi": "3.1.0",
+    "info": {
+        "version": "1.0.0",
+        "title": "Pets"
+    }
+}`)
+	doc, m := roundTripDocument(t, spec)
+
+	m.Model.Info.Version = "2.0.0"
+	out, err := doc.Render()
+	require.NoError(t, err)
+	assert.Equal(t, `{
+    "openapi": "3.1.0",
+    "info": {
+        "version": "2.0.0",
+        "title": "Pets"
+    }
+}`, string(out))
+}
+
+func TestDocument_RoundTripFidelity_RenderAndReload(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+info:
+  title: Pets
+
+  version: 1.0.0 # keep me
+`)
+	doc, m := roundTripDocument(t, spec)
+
+	b, _, _, err := doc.RenderAndReload()
+	require.NoError(t, err)
+	assert.Equal(t, string(spec), string(b))
+
+	m.Model.Info.Title = "Dogs"
+	b, newDoc, _, err := doc.RenderAndReload()
+	require.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0\ninfo:\n  title: Dogs\n  version: 1.0.0 # keep me\n", string(b))
+	assert.True(t, newDoc.GetConfiguration().RoundTripFidelity)
+}
+
+func TestDocument_RoundTripFidelity_Invalidated(t *testing.T) {
+	doc, _ := roundTripDocument(t, []byte("openapi: 3.1.0\ninfo:\n  title: Pets\n"))
+	require.NoError(t, doc.InvalidateModel())
+	assert.Nil(t, doc.(*document).roundTripSnapshot)
+}
+
+func TestMergeRoundTrip(t *testing.T) {
+	parse := func(s string) *yaml.Node {
+		var n yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(s), &n))
+		return n.Content[0]
+	}
+	render := func(n *yaml.Node) string {
+		b, _ := yaml.Marshal(n)
+		return string(b)
+	}
+	original := parse(`a: [1, 2] # numbers
+b: {c: d}
+e: |
+  literal
+f: keep`)
+	snapshot := parse(`a: [1, 2]
+b: {c: d}
+e: literal`)
+
+	// sequences that change length are replaced.
+	current := parse(`a: [1, 2, 3]
+b: {c: d}
+e: literal`)
+	assert.Equal(t, "a: [1, 2, 3]\nb: {c: d}\ne: |\n    literal\nf: keep\n",
+		render(mergeRoundTrip(original, snapshot, current)))
+
+	// items in sequences of the same length are merged.
+	current = parse(`a: [1, 3]
+b: {c: d}
+e: literal`)
+	assert.Equal(t, "a: [1, 3] # numbers\nb: {c: d}\ne: |\n    literal\nf: keep\n",
+		render(mergeRoundTrip(original, snapshot, current)))
+
+	// a change of kind replaces the node, a literal is only kept if it's still a literal.
+	current = parse(`a: [1, 2]
+b: pizza
+e: "changed"`)
+	assert.Equal(t, "a: [1, 2] # numbers\nb: pizza\ne: \"changed\"\nf: keep\n",
+		render(mergeRoundTrip(original, snapshot, current)))
+
+	// keys the model moved around are written in their new order, keys it never rendered stay where they were.
+	current = parse(`e: literal
+b: {c: d}
+a: [1, 2]`)
+	assert.Equal(t, "e: |\n    literal\nf: keep\nb: {c: d}\na: [1, 2] # numbers\n",
+		render(mergeRoundTrip(original, snapshot, current)))
+
+	assert.Nil(t, mergeRoundTrip(original, snapshot, nil))
+	assert.Equal(t, "x: y\n", render(mergeRoundTrip(nil, snapshot, parse(`x: y`))))
+}
+
+func TestRenderModelNode(t *testing.T) {
+	m := &v3high.Document{Version: "3.1.0", Info: &base.Info{Title: "Pets"}}
+	n := renderModelNode(m)
+	require.NotNil(t, n)
+	assert.Equal(t, yaml.MappingNode, n.Kind)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// UpgradeDocument upgrades an OpenAPI 3.0 document to OpenAPI 3.1, and returns the upgraded document, alongside
+// every change that was made. The supplied document is not changed.
+//
+// The upgrade works on a copy of the original yaml nodes (see datamodel.UpgradeOpenAPI30Node), not the model, so
+// the upgraded specification (available from GetSpecInfo().SpecBytes) keeps the layout, key order, comments,
+// format (YAML or JSON) and indentation of the original. A document that is already OpenAPI 3.1 is returned as-is.
+func UpgradeDocument(doc Document) (Document, []*datamodel.UpgradeChange, error) {
+	if doc == nil {
+		return nil, nil, errors.New("unable to upgrade, no document supplied")
+	}
+	info := doc.GetSpecInfo()
+	if info == nil || info.RootNode == nil || len(info.RootNode.Content) == 0 {
+		return nil, nil, errors.New("unable to upgrade, the document has not been parsed")
+	}
+	if info.SpecType != utils.OpenApi3 {
+		return nil, nil, fmt.Errorf("unable to upgrade, only OpenAPI 3.0 documents can be upgraded, not '%s' documents",
+			info.SpecType)
+	}
+	root := utils.CloneYAMLNode(info.RootNode.Content[0])
+	changes, err := datamodel.UpgradeOpenAPI30Node(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(changes) == 0 {
+		return doc, nil, nil
+	}
+
+	var upgraded []byte
+	if d, ok := doc.(*document); ok {
+		upgraded, err = d.encodeNode(root)
+	} else {
+		upgraded, err = yaml.Marshal(root)
+	}
+	if err != nil {
+		return nil, changes, err
+	}
+	upgradedDoc, err := NewDocumentWithConfiguration(upgraded, doc.GetConfiguration())
+	return upgradedDoc, changes, err
+}
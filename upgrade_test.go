@@ -0,0 +1,54 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeDocument(t *testing.T) {
+	spec := `{
+  "openapi": "3.0.1",
+  "info": {"title": "Pets", "version": "1.0.0"},
+  "components": {
+    "schemas": {
+      "Pet": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string", "nullable": true, "example": "fluffy"}
+        }
+      }
+    }
+  }
+}`
+	doc, err := NewDocument([]byte(spec))
+	require.NoError(t, err)
+
+	upgraded, changes, err := UpgradeDocument(doc)
+	require.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.Equal(t, "3.0.1", doc.GetVersion())
+	assert.Equal(t, "3.1.0", upgraded.GetVersion())
+
+	bytes := string(*upgraded.GetSpecInfo().SpecBytes)
+	assert.Contains(t, bytes, `"openapi": "3.1.0"`)
+	assert.Contains(t, bytes, "\n  \"info\"")
+
+	model, err := upgraded.BuildV3Model()
+	require.NoError(t, err)
+	name := model.Model.Components.Schemas.GetOrZero("Pet").Schema().Properties.GetOrZero("name").Schema()
+	assert.Equal(t, []string{"string", "null"}, name.Type)
+	assert.Equal(t, "fluffy", name.Examples[0].Value)
+
+	same, changes, err := UpgradeDocument(upgraded)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, upgraded, same)
+
+	_, _, err = UpgradeDocument(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"slices"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// HashYAMLNode returns a canonical 64-bit hash of a yaml.Node and all of its children.
+//
+// The hash only considers the content of the tree; it is not affected by line or column numbers, comments,
+// quoting / flow styles, anchors or the order of keys in a mapping. Aliases are hashed as the node they point to.
+// This means two subtrees that describe the same data will always produce the same hash, regardless of where
+// they sit in a document or how they were written.
+//
+// The hash is deterministic across processes, so it can be stored and compared later. Circular node trees
+// are supported.
+func HashYAMLNode(n *yaml.Node) uint64 {
+	return hashYAMLNode(n, make(map[*yaml.Node]struct{}))
+}
+
+// yamlNodeHash hashes nodes to rule out trees that can't be equal, before they are walked.
+var yamlNodeHash = HashYAMLNode
+
+// YAMLNodesEqual determines if two yaml.Node trees contain the same content. Like HashYAMLNode, positions,
+// comments, styles and the order of keys in a mapping are ignored. Trees with different hashes are never equal, and
+// trees with the same hash are walked to confirm they are. Two nil nodes are considered equal. Identical pointers are
+// short-circuited without hashing.
+func YAMLNodesEqual(l, r *yaml.Node) bool {
+	return yamlNodesEqual(l, r, false)
+}
+
+// YAMLNodesEqualInOrder is the same as YAMLNodesEqual, except the order of keys in a mapping is significant, so
+// trees that only differ by the order of their keys are not equal.
+func YAMLNodesEqualInOrder(l, r *yaml.Node) bool {
+	return yamlNodesEqual(l, r, true)
+}
+
+func yamlNodesEqual(l, r *yaml.Node, ordered bool) bool {
+	if l == r {
+		return true
+	}
+	if l == nil || r == nil {
+		return false
+	}
+	if yamlNodeHash(l) != yamlNodeHash(r) {
+		return false
+	}
+	return yamlNodesMatch(l, r, ordered, make(map[[2]*yaml.Node]struct{}))
+}
+
+// yamlNodesMatch walks two trees, and checks the kind, tag and value of every node match. Aliases are compared as
+// the nodes they point to, and nodes that are already being compared are matched. Unless ordered is set, the pairs
+// of a mapping are matched by key, in any order.
+func yamlNodesMatch(l, r *yaml.Node, ordered bool, visiting map[[2]*yaml.Node]struct{}) bool {
+	for l != nil && l.Kind == yaml.AliasNode && l.Alias != nil {
+		l = l.Alias
+	}
+	for r != nil && r.Kind == yaml.AliasNode && r.Alias != nil {
+		r = r.Alias
+	}
+	if l == r {
+		return true
+	}
+	if l == nil || r == nil {
+		return false
+	}
+	pair := [2]*yaml.Node{l, r}
+	if _, ok := visiting[pair]; ok {
+		return true
+	}
+	visiting[pair] = struct{}{}
+	defer delete(visiting, pair)
+
+	if l.Kind != r.Kind || l.ShortTag() != r.ShortTag() || l.Value != r.Value || len(l.Content) != len(r.Content) {
+		return false
+	}
+	if l.Kind == yaml.MappingNode && !ordered {
+		return yamlMappingsMatch(l, r, visiting)
+	}
+	for i := range l.Content {
+		if !yamlNodesMatch(l.Content[i], r.Content[i], ordered, visiting) {
+			return false
+		}
+	}
+	return true
+}
+
+// yamlMappingsMatch checks every pair of a mapping matches a pair of the other mapping, in any order. Pairs in the
+// same position are tried first, as keys are rarely moved.
+func yamlMappingsMatch(l, r *yaml.Node, visiting map[[2]*yaml.Node]struct{}) bool {
+	matched := make([]bool, len(r.Content)/2)
+	pairMatches := func(i, j int) bool {
+		return !matched[j/2] && yamlNodesMatch(l.Content[i], r.Content[j], false, visiting) &&
+			yamlNodesMatch(l.Content[i+1], r.Content[j+1], false, visiting)
+	}
+	for i := 0; i+1 < len(l.Content); i += 2 {
+		found := pairMatches(i, i)
+		if found {
+			matched[i/2] = true
+			continue
+		}
+		for j := 0; j+1 < len(r.Content); j += 2 {
+			if pairMatches(i, j) {
+				matched[j/2], found = true, true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func hashYAMLNode(n *yaml.Node, visiting map[*yaml.Node]struct{}) uint64 {
+	h := fnv.New64a()
+	if n == nil {
+		return h.Sum64()
+	}
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	if _, ok := visiting[n]; ok {
+		h.Write([]byte("<<CIRCULAR>>"))
+		return h.Sum64()
+	}
+	visiting[n] = struct{}{}
+	defer delete(visiting, n)
+
+	h.Write([]byte{byte(n.Kind)})
+	switch n.Kind {
+	case yaml.ScalarNode:
+		h.Write([]byte(n.ShortTag()))
+		h.Write([]byte{0})
+		h.Write([]byte(n.Value))
+
+	case yaml.MappingNode:
+		// key order is not significant, so each pair is hashed on its own and the pairs are sorted.
+		pairs := make([]uint64, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			ph := fnv.New64a()
+			writeUint64(ph, hashYAMLNode(n.Content[i], visiting))
+			writeUint64(ph, hashYAMLNode(n.Content[i+1], visiting))
+			pairs = append(pairs, ph.Sum64())
+		}
+		slices.Sort(pairs)
+		for _, p := range pairs {
+			writeUint64(h, p)
+		}
+
+	default:
+		for _, c := range n.Content {
+			writeUint64(h, hashYAMLNode(c, visiting))
+		}
+	}
+	return h.Sum64()
+}
+
+func writeUint64(h hash.Hash64, v uint64) {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	h.Write(buf[:])
+}
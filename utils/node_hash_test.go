@@ -0,0 +1,109 @@
+// Copyright 2023-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.yaml.in/yaml/v4"
+)
+
+func parseNode(t *testing.T, s string) *yaml.Node {
+	var n yaml.Node
+	if err := yaml.Unmarshal([]byte(s), &n); err != nil {
+		t.Fatal(err)
+	}
+	return n.Content[0]
+}
+
+func TestHashYAMLNode_IgnoresPositionAndStyle(t *testing.T) {
+	a := parseNode(t, `name: pizza
+tags: [one, two] # a comment
+nested:
+  cheese: true`)
+	b := parseNode(t, `
+
+
+nested: {cheese: true}
+"name": 'pizza'
+tags:
+  - one
+  - two`)
+	assert.Equal(t, HashYAMLNode(a), HashYAMLNode(b))
+	assert.True(t, YAMLNodesEqual(a, b))
+}
+
+func TestHashYAMLNode_DetectsChanges(t *testing.T) {
+	a := parseNode(t, `tags: [one, two]`)
+	b := parseNode(t, `tags: [two, one]`)
+	c := parseNode(t, `tags: [one, "2"]`)
+	d := parseNode(t, `tags: [one, 2]`)
+	assert.NotEqual(t, HashYAMLNode(a), HashYAMLNode(b))
+	assert.NotEqual(t, HashYAMLNode(a), HashYAMLNode(c))
+	assert.NotEqual(t, HashYAMLNode(c), HashYAMLNode(d))
+}
+
+func TestHashYAMLNode_InferredTags(t *testing.T) {
+	parsed := parseNode(t, `hello`)
+	built := &yaml.Node{Kind: yaml.ScalarNode, Value: "hello"}
+	assert.Equal(t, HashYAMLNode(parsed), HashYAMLNode(built))
+}
+
+func TestHashYAMLNode_Alias(t *testing.T) {
+	a := parseNode(t, `base: &b {cheese: true}
+copy: *b`)
+	b := parseNode(t, `base: {cheese: true}
+copy: {cheese: true}`)
+	assert.Equal(t, HashYAMLNode(a), HashYAMLNode(b))
+}
+
+func TestHashYAMLNode_Circular(t *testing.T) {
+	m := &yaml.Node{Kind: yaml.MappingNode}
+	m.Content = []*yaml.Node{CreateStringNode("self"), m}
+	assert.NotPanics(t, func() {
+		assert.Equal(t, HashYAMLNode(m), HashYAMLNode(m))
+	})
+}
+
+func TestYAMLNodesEqual_Nil(t *testing.T) {
+	n := CreateStringNode("x")
+	assert.True(t, YAMLNodesEqual(nil, nil))
+	assert.True(t, YAMLNodesEqual(n, n))
+	assert.False(t, YAMLNodesEqual(n, nil))
+	assert.False(t, YAMLNodesEqual(nil, n))
+	assert.Equal(t, HashYAMLNode(nil), HashYAMLNode(nil))
+}
+
+func TestYAMLNodesEqual_KeyOrder(t *testing.T) {
+	a := parseNode(t, `{name: pizza, tags: [one, two]}`)
+	b := parseNode(t, `{tags: [one, two], name: pizza}`)
+	assert.True(t, YAMLNodesEqual(a, b))
+	assert.False(t, YAMLNodesEqualInOrder(a, b))
+	assert.True(t, YAMLNodesEqualInOrder(a, parseNode(t, "name: pizza\ntags:\n  - one\n  - two")))
+}
+
+func TestYAMLNodesEqual_SameHash(t *testing.T) {
+	// different trees that share a hash are still told apart.
+	defer func(h func(*yaml.Node) uint64) { yamlNodeHash = h }(yamlNodeHash)
+	yamlNodeHash = func(*yaml.Node) uint64 { return 1 }
+
+	a := parseNode(t, `{name: pizza, tags: [one, two]}`)
+	assert.False(t, YAMLNodesEqual(a, parseNode(t, `{name: pizza, tags: [two, one]}`)))
+	assert.False(t, YAMLNodesEqual(a, parseNode(t, `{name: burger, tags: [one, two]}`)))
+	assert.False(t, YAMLNodesEqual(a, parseNode(t, `{name: pizza, tags: [one, two], size: 12}`)))
+	assert.False(t, YAMLNodesEqual(parseNode(t, `{a: 1, b: 1}`), parseNode(t, `{a: 1, a: 1}`)))
+	assert.False(t, YAMLNodesEqual(parseNode(t, `1`), parseNode(t, `"1"`)))
+	assert.True(t, YAMLNodesEqual(a, parseNode(t, `{tags: [one, two], name: pizza}`)))
+	assert.False(t, YAMLNodesEqualInOrder(a, parseNode(t, `{tags: [one, two], name: pizza}`)))
+}
+
+func TestYAMLNodesEqual_Circular(t *testing.T) {
+	l := &yaml.Node{Kind: yaml.MappingNode}
+	l.Content = []*yaml.Node{CreateStringNode("self"), l}
+	r := &yaml.Node{Kind: yaml.MappingNode}
+	r.Content = []*yaml.Node{CreateStringNode("self"), r}
+	assert.True(t, YAMLNodesEqual(l, r))
+	assert.True(t, YAMLNodesEqualInOrder(l, r))
+}
@@ -81,3 +81,33 @@ func CreateFloatNode(str string) *yaml.Node {
 	}
 	return n
 }
+
+// CloneYAMLNode returns a deep copy of a yaml.Node and all of its children, including line and column numbers,
+// styles and comments. Alias targets are cloned along with the alias.
+func CloneYAMLNode(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	n := &yaml.Node{
+		Kind:        node.Kind,
+		Style:       node.Style,
+		Tag:         node.Tag,
+		Value:       node.Value,
+		Anchor:      node.Anchor,
+		HeadComment: node.HeadComment,
+		LineComment: node.LineComment,
+		FootComment: node.FootComment,
+		Line:        node.Line,
+		Column:      node.Column,
+	}
+	if node.Alias != nil {
+		n.Alias = CloneYAMLNode(node.Alias)
+	}
+	if node.Content != nil {
+		n.Content = make([]*yaml.Node, len(node.Content))
+		for i, c := range node.Content {
+			n.Content[i] = CloneYAMLNode(c)
+		}
+	}
+	return n
+}
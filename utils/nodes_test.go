@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.yaml.in/yaml/v4"
 )
 
 func TestCreateBoolNode(t *testing.T) {
@@ -60,3 +61,18 @@ func TestCreateYamlNode(t *testing.T) {
 	assert.Equal(t, "!!str", y.Tag) // Encode() sets appropriate tag
 	assert.Equal(t, "foo", y.Value)
 }
+
+func TestCloneYAMLNode(t *testing.T) {
+	var n yaml.Node
+	_ = yaml.Unmarshal([]byte(`a: &x [1, 2] # comment
+b: *x`), &n)
+	c := CloneYAMLNode(&n)
+	assert.True(t, YAMLNodesEqual(&n, c))
+	assert.NotSame(t, n.Content[0], c.Content[0])
+	assert.Equal(t, "# comment", c.Content[0].Content[1].LineComment)
+	assert.Equal(t, 1, c.Content[0].Content[1].Line)
+
+	c.Content[0].Content[1].Content[0].Value = "3"
+	assert.Equal(t, "1", n.Content[0].Content[1].Content[0].Value)
+	assert.Nil(t, CloneYAMLNode(nil))
+}
@@ -0,0 +1,70 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"context"
+	"errors"
+
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3low "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// ErrLazyPaths is returned when a document configured with LazyPaths is rendered, compared or patched. The model of
+// such a document holds no paths, so the result would be missing every path of the specification.
+var ErrLazyPaths = errors.New("the document is configured with LazyPaths, its model has no paths")
+
+// lazyPaths returns true if the model of an OpenAPI 3+ document is built without its paths.
+func lazyPaths(doc Document) bool {
+	config, info := doc.GetConfiguration(), doc.GetSpecInfo()
+	return config != nil && config.LazyPaths && info != nil && info.SpecType == utils.OpenApi3
+}
+
+// WalkV3Paths calls fn with every path (and its high-level PathItem) of an OpenAPI 3+ document, in the order they
+// appear in the specification. The model of the document is built first (if it has not been already).
+//
+// If the document was configured with LazyPaths, the model holds no paths. Instead, each path item is built
+// (low and high-level) when the walk reaches it, and is not kept by the document, so it can be released as soon as
+// fn returns, unless fn holds on to it. This keeps the memory needed to process every path of a huge specification
+// down to a single path item at a time. If the document was not configured with LazyPaths, the path items of the
+// model are walked instead.
+//
+// The walk stops if the context is cancelled, or fn returns an error, and the error is returned.
+func WalkV3Paths(ctx context.Context, doc Document,
+	fn func(path string, pathItem *v3high.PathItem) error,
+) error {
+	if doc == nil {
+		return errors.New("unable to walk paths, no document supplied")
+	}
+	m, err := doc.BuildV3ModelWithContext(ctx)
+	if m == nil {
+		return err
+	}
+	if m.Model.Paths != nil {
+		for path, pathItem := range m.Model.Paths.PathItems.FromOldest() {
+			if err = ctx.Err(); err != nil {
+				return err
+			}
+			if err = fn(path, pathItem); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	info := doc.GetSpecInfo()
+	if info == nil || info.RootNode == nil || len(info.RootNode.Content) == 0 {
+		return nil
+	}
+	_, _, paths := utils.FindKeyNodeFullTop(v3low.PathsLabel, info.RootNode.Content[0].Content)
+	if paths == nil {
+		return nil
+	}
+	return v3low.WalkPathItems(ctx, paths, m.Index,
+		func(key low.KeyReference[string], pathItem low.ValueReference[*v3low.PathItem]) error {
+			return fn(key.Value, v3high.NewPathItem(pathItem.Value))
+		})
+}
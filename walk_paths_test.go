@@ -0,0 +1,107 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3high "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func walkOperationIds(t *testing.T, doc Document) []string {
+	var ids []string
+	err := WalkV3Paths(context.Background(), doc, func(path string, pathItem *v3high.PathItem) error {
+		for _, op := range pathItem.GetOperations().FromOldest() {
+			ids = append(ids, path+" "+op.OperationId)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return ids
+}
+
+func TestWalkV3Paths_LazyPaths(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+
+	eager, err := NewDocument(spec)
+	require.NoError(t, err)
+	lazy, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{LazyPaths: true})
+	require.NoError(t, err)
+
+	m, errs := lazy.BuildV3Model()
+	require.NoError(t, errs)
+	assert.Nil(t, m.Model.Paths)
+	assert.NotNil(t, m.Model.Components)
+
+	expected := walkOperationIds(t, eager)
+	assert.NotEmpty(t, expected)
+	assert.Equal(t, expected, walkOperationIds(t, lazy))
+
+	// each walk builds new path items.
+	var first, second *v3high.PathItem
+	_ = WalkV3Paths(context.Background(), lazy, func(path string, pathItem *v3high.PathItem) error {
+		first = pathItem
+		return errors.New("stop")
+	})
+	_ = WalkV3Paths(context.Background(), lazy, func(path string, pathItem *v3high.PathItem) error {
+		second = pathItem
+		return errors.New("stop")
+	})
+	assert.NotSame(t, first, second)
+	firstYAML, _ := first.Render()
+	secondYAML, _ := second.Render()
+	assert.Equal(t, string(firstYAML), string(secondYAML))
+}
+
+func TestLazyPaths_Unsupported(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	eager, err := NewDocument(spec)
+	require.NoError(t, err)
+	lazy, err := NewDocumentWithConfiguration(spec, &datamodel.DocumentConfiguration{LazyPaths: true})
+	require.NoError(t, err)
+	_, err = lazy.BuildV3Model()
+	require.NoError(t, err)
+
+	// the model has no paths, so rendering, comparing or patching it would lose every path.
+	_, err = lazy.Render()
+	assert.ErrorIs(t, err, ErrLazyPaths)
+	_, err = CompareDocuments(eager, lazy)
+	assert.ErrorIs(t, err, ErrLazyPaths)
+	_, err = CompareDocuments(lazy, eager)
+	assert.ErrorIs(t, err, ErrLazyPaths)
+	err = lazy.ApplyPatch([]byte(`[{"op": "replace", "path": "/info/title", "value": "Pets"}]`))
+	assert.ErrorIs(t, err, ErrLazyPaths)
+}
+
+func TestWalkV3Paths_Errors(t *testing.T) {
+	spec, _ := os.ReadFile("test_specs/burgershop.openapi.yaml")
+	doc, err := NewDocument(spec)
+	require.NoError(t, err)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = WalkV3Paths(context.Background(), doc, func(path string, pathItem *v3high.PathItem) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = WalkV3Paths(ctx, doc, func(path string, pathItem *v3high.PathItem) error { return nil })
+	assert.ErrorIs(t, err, context.Canceled)
+
+	swagger, _ := os.ReadFile("test_specs/petstorev2.json")
+	v2Doc, err := NewDocument(swagger)
+	require.NoError(t, err)
+	assert.Error(t, WalkV3Paths(context.Background(), v2Doc, nil))
+	assert.Error(t, WalkV3Paths(context.Background(), nil, nil))
+}
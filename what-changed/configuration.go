@@ -0,0 +1,281 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// ComparisonConfiguration is used to tune how two documents are compared, without changing the behavior of any
+// other comparison.
+type ComparisonConfiguration struct {
+	// BreakingRules are merged over the active breaking rules (see model.SetActiveBreakingRulesConfig) for this
+	// comparison only. Only the rules that are set are overridden.
+	BreakingRules *model.BreakingRulesConfig
+
+	// BreakingRulesPreset is the name of a breaking rules preset (see model.BreakingRulesPreset), for example
+	// model.PresetLenient, which replaces the active breaking rules for this comparison only. BreakingRules are
+	// merged over the preset. Comparing documents with a preset that does not exist returns an error when using
+	// libopenapi.CompareDocumentsWithConfiguration(), otherwise the preset is ignored.
+	BreakingRulesPreset string
+
+	// IgnoreExtensions will drop every change made to extensions (x- properties) from the report.
+	IgnoreExtensions bool
+
+	// Perspective re-classifies changes that make values required or optional, depending on if they break the
+	// consumers or the providers of an API (see ConsumerPerspective and ProviderPerspective). The default
+	// perspective uses the breaking rules only.
+	Perspective Perspective
+
+	// IgnoreExamples will drop every change made to examples (example and examples properties, and example
+	// components) from the report. Examples are always compared by their content, so examples that have only
+	// been re-formatted are not reported either way (see model.ExampleValuesEqual).
+	IgnoreExamples bool
+
+	// Severity sets the severity of every change found, before the filter is called. When nil, the severity of a
+	// change is derived from the default mapping (see model.DefaultSeverityMapping).
+	Severity *model.SeverityMapping
+
+	// OrderedArrays selects the arrays (tags, servers, security requirements and enums) that are compared in order
+	// for this comparison only (see model.OrderedArrays). When nil, these arrays are compared as sets, so moving
+	// their items around is not reported. When an array is compared in order, moving its items around is
+	// reported as a single model.Reordered change.
+	OrderedArrays *model.OrderedArrays
+
+	// EffectiveSecurity compares the security of each operation after it inherits the security of the root of its
+	// document, for this comparison only (see model.ComparisonOptions). Removing a root security requirement is
+	// then reported on every operation that doesn't declare its own security, as well as at the root.
+	EffectiveSecurity bool
+
+	// DetectRenames reports components that were removed and added again with identical content under a different
+	// name as renamed, for this comparison only (see model.ComparisonOptions).
+	DetectRenames bool
+
+	// OnChange is called with every change as soon as it is found, while the documents are being compared (see
+	// model.ChangeStream). If it returns false, the comparison stops early and the changes found so far are
+	// returned, for example to stop as soon as the first breaking change is found:
+	//
+	//	OnChange: func(change *model.Change) bool { return !change.Breaking }
+	//
+	// Changes are streamed as classified by the breaking rules, before the perspective, severity, filter and
+	// snippets are applied.
+	OnChange func(change *model.Change) bool
+
+	// StreamOnly will only send changes to OnChange, and not keep them in the change models that are returned,
+	// so comparing very large documents does not build a large tree of changes. It has no effect without OnChange.
+	StreamOnly bool
+
+	// Concurrency is how many entries of the same map (paths, schemas, responses, etc.) are compared at the same
+	// time, for this comparison only (see model.ComparisonOptions). Large documents compare faster with a
+	// higher concurrency, and the changes found are the same, in the same order. When it is zero, the active
+	// concurrency is used.
+	Concurrency int
+
+	// Baseline drops every change that has been accepted by the baseline (see model.Baseline), so known and
+	// intentional changes are not reported again, for example to stop an accepted breaking change from failing
+	// every later build. Changes are matched by their ID (see model.ChangeID).
+	Baseline *model.Baseline
+
+	// Filter is called with every change found. If it returns false, the change is dropped from the report.
+	// model.OnlyBreaking() and model.AtLeast() can be used to keep breaking changes only, or changes with at
+	// least a given severity.
+	Filter func(change *model.Change) bool
+
+	// Snippets attaches an excerpt of the original and new documents to every change, around the line of the
+	// change (see model.AttachSnippets), so reports can show changes in context without loading and slicing the
+	// documents again.
+	Snippets bool
+
+	// SnippetContextLines is the number of lines included above and below the line of a change in a snippet. When
+	// zero, model.DefaultSnippetContextLines is used. When less than zero, snippets only include the line of the
+	// change.
+	SnippetContextLines int
+
+	// CompareResolvedSchemas will compare the resolved content of schemas that are references, rather than only
+	// the reference itself, so changes made to schemas in external documents are reported. Moving a schema inline
+	// or into components, or pointing a reference at an identical schema, is not a change. When the schema that
+	// is referenced is different, the changes made to the schema are reported instead. This is the same as
+	// setting UseSchemaQuickHash in the document configuration, and is only applied when comparing documents using
+	// libopenapi.CompareDocumentsWithConfiguration(), which builds new models with the option enabled. Low-level
+	// documents must be created with UseSchemaQuickHash enabled instead.
+	CompareResolvedSchemas bool
+}
+
+// CompareOpenAPIDocumentsWithConfiguration is the same as CompareOpenAPIDocuments, except the comparison is tuned
+// using the supplied configuration. The configuration can be nil.
+func CompareOpenAPIDocumentsWithConfiguration(original, updated *v3.Document,
+	configuration *ComparisonConfiguration,
+) *model.DocumentChanges {
+	return CompareOpenAPIDocumentsWithContext(context.Background(), original, updated, configuration)
+}
+
+// CompareSwaggerDocumentsWithConfiguration is the same as CompareSwaggerDocuments, except the comparison is tuned
+// using the supplied configuration. The configuration can be nil.
+func CompareSwaggerDocumentsWithConfiguration(original, updated *v2.Swagger,
+	configuration *ComparisonConfiguration,
+) *model.DocumentChanges {
+	return CompareSwaggerDocumentsWithContext(context.Background(), original, updated, configuration)
+}
+
+// compareWithConfiguration runs a comparison tuned by the configuration. indexes returns the indexes of the
+// original and updated documents, which are read to attach snippets. If the context is done by the time the
+// comparison returns, the incomplete changes are discarded.
+func compareWithConfiguration(ctx context.Context, configuration *ComparisonConfiguration,
+	compare func(ctx context.Context) *model.DocumentChanges, indexes func() (*index.SpecIndex, *index.SpecIndex),
+) *model.DocumentChanges {
+	if configuration == nil {
+		configuration = new(ComparisonConfiguration)
+	}
+	if configuration.OnChange != nil {
+		ctx = model.WithChangeStream(ctx, configuration.OnChange, configuration.StreamOnly)
+	}
+	ctx = model.WithComparisonOptions(ctx, configuration.comparisonOptions())
+	changes := compare(ctx)
+	if ctx.Err() != nil {
+		return nil
+	}
+	if changes != nil && configuration.Perspective != DefaultPerspective {
+		applyPerspective(changes, configuration.Perspective)
+		model.ExplainChanges(changes)
+	}
+	if changes != nil && configuration.Severity != nil {
+		model.ApplySeverityMapping(changes.GetAllChanges(), configuration.Severity)
+	}
+	if changes != nil && (configuration.IgnoreExtensions || configuration.IgnoreExamples || configuration.Filter != nil ||
+		configuration.Baseline != nil) {
+		filterChanges(reflect.ValueOf(changes), configuration, make(map[uintptr]struct{}))
+	}
+	if changes != nil && configuration.Snippets {
+		contextLines := configuration.SnippetContextLines
+		if contextLines == 0 {
+			contextLines = model.DefaultSnippetContextLines
+		}
+		original, updated := indexes()
+		model.AttachSnippets(changes.GetAllChanges(), model.IndexSnippetSource(original),
+			model.IndexSnippetSource(updated), contextLines)
+	}
+	return changes
+}
+
+var (
+	modelPackage         = reflect.TypeOf(model.DocumentChanges{}).PkgPath()
+	propertyChangesType  = reflect.TypeOf(model.PropertyChanges{})
+	extensionChangesType = reflect.TypeOf(&model.ExtensionChanges{})
+	exampleChangesType   = reflect.TypeOf(&model.ExampleChanges{})
+	examplesChangesType  = reflect.TypeOf(&model.ExamplesChanges{})
+	changeType           = reflect.TypeOf(model.Change{})
+)
+
+// comparisonOptions returns the options the models are compared with (see model.WithComparisonOptions).
+func (c *ComparisonConfiguration) comparisonOptions() *model.ComparisonOptions {
+	options := &model.ComparisonOptions{
+		Concurrency:       c.Concurrency,
+		OrderedArrays:     c.OrderedArrays,
+		EffectiveSecurity: c.EffectiveSecurity,
+		DetectRenames:     c.DetectRenames,
+	}
+	if c.BreakingRules != nil || c.BreakingRulesPreset != "" {
+		rules := new(model.BreakingRulesConfig)
+		if preset, err := model.BreakingRulesPreset(c.BreakingRulesPreset); err == nil {
+			rules.Merge(preset)
+		} else {
+			rules.Merge(model.GetActiveBreakingRulesConfig())
+		}
+		rules.Merge(c.BreakingRules)
+		options.BreakingRules = rules
+	}
+	return options
+}
+
+// ignores determines if every change of a type of changes is dropped from the report.
+func (c *ComparisonConfiguration) ignores(t reflect.Type) bool {
+	switch t {
+	case extensionChangesType:
+		return c.IgnoreExtensions
+	case exampleChangesType, examplesChangesType:
+		return c.IgnoreExamples
+	}
+	return false
+}
+
+// isExampleChange determines if a change was made to an example.
+func isExampleChange(c *model.Change) bool {
+	return c.Property == v3.ExampleLabel || c.Property == v3.ExamplesLabel
+}
+
+// filterChanges walks a tree of changes, dropping extension and example changes if they are ignored, changes
+// accepted by the baseline, and any changes rejected by the filter.
+func filterChanges(v reflect.Value, configuration *ComparisonConfiguration, seen map[uintptr]struct{}) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if configuration.ignores(v.Type()) {
+			if v.CanSet() {
+				v.Set(reflect.Zero(v.Type()))
+			} else if pc := v.Elem().FieldByName("PropertyChanges"); !pc.IsNil() {
+				pc.Interface().(*model.PropertyChanges).Changes = nil
+			}
+			return
+		}
+		if _, ok := seen[v.Pointer()]; ok {
+			return
+		}
+		seen[v.Pointer()] = struct{}{}
+		filterChanges(v.Elem(), configuration, seen)
+	case reflect.Struct:
+		if v.Type().PkgPath() != modelPackage || v.Type() == changeType {
+			return
+		}
+		if v.Type() == propertyChangesType {
+			pc := v.Addr().Interface().(*model.PropertyChanges)
+			if configuration.Filter != nil || configuration.IgnoreExamples || configuration.Baseline != nil {
+				kept := pc.Changes[:0]
+				for _, c := range pc.Changes {
+					if configuration.IgnoreExamples && isExampleChange(c) {
+						continue
+					}
+					if configuration.Baseline.Accepts(c) {
+						continue
+					}
+					if configuration.Filter == nil || configuration.Filter(c) {
+						kept = append(kept, c)
+					}
+				}
+				pc.Changes = kept
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				filterChanges(v.Field(i), configuration, seen)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			filterChanges(v.Index(i), configuration, seen)
+		}
+	case reflect.Map:
+		ignored := configuration.ignores(v.Type().Elem())
+		iter := v.MapRange()
+		for iter.Next() {
+			if ignored {
+				v.SetMapIndex(iter.Key(), reflect.Value{})
+				continue
+			}
+			filterChanges(iter.Value(), configuration, seen)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			filterChanges(v.Elem(), configuration, seen)
+		}
+	}
+}
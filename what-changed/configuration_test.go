@@ -0,0 +1,558 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func burgerShopDocuments() (*v3.Document, *v3.Document) {
+	original, _ := os.ReadFile("../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+	return origDoc, modDoc
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Nil(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, nil)
+	assert.Equal(t, 77, changes.TotalChanges())
+	assert.Equal(t, 19, changes.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithContext(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithContext(context.Background(), origDoc, modDoc, nil)
+	require.NotNil(t, changes)
+	assert.Equal(t, CompareOpenAPIDocuments(origDoc, modDoc).TotalChanges(), changes.TotalChanges())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Nil(t, CompareOpenAPIDocumentsWithContext(ctx, origDoc, modDoc, &ComparisonConfiguration{Snippets: true}))
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Filter(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Filter: func(change *model.Change) bool {
+			return change.Property != "description"
+		},
+	})
+	assert.Equal(t, 62, changes.TotalChanges())
+	for _, c := range changes.GetAllChanges() {
+		assert.NotEqual(t, "description", c.Property)
+	}
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Filter: func(change *model.Change) bool { return false },
+	})
+	assert.Equal(t, 0, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_IgnoreExtensions(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		IgnoreExtensions: true,
+	})
+	assert.Equal(t, 64, changes.TotalChanges())
+	for _, c := range changes.GetAllChanges() {
+		assert.False(t, strings.HasPrefix(c.Property, "x-"), c.Property)
+	}
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_IgnoreExamples(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          example: cat
+      responses:
+        '200':
+          description: pets
+          content:
+            application/json:
+              example:
+                name: fluffy
+                age: 2
+              examples:
+                cat:
+                  value: {name: fluffy}
+components:
+  schemas:
+    Pet:
+      type: object
+      example: {name: fluffy}`
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          example: dog
+      responses:
+        '200':
+          description: all the pets
+          content:
+            application/json:
+              example: {"age": 2.0, "name": "fluffy"}
+              examples:
+                cat:
+                  value: {name: whiskers}
+                dog:
+                  value: {name: rex}
+components:
+  schemas:
+    Pet:
+      type: object
+      example: {name: rex}`
+
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, _ := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		return doc
+	}
+	origDoc, modDoc := build(left), build(right)
+
+	// the re-formatted media type example is not a change.
+	changes := CompareOpenAPIDocuments(origDoc, modDoc)
+	assert.Equal(t, 5, changes.TotalChanges())
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		IgnoreExamples: true,
+	})
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, "description", changes.GetAllChanges()[0].Property)
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_BreakingRules(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	active := model.GetActiveBreakingRulesConfig()
+	notBreaking := false
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRules: &model.BreakingRulesConfig{
+			JSONSchemaDialect: &model.BreakingChangeRule{Modified: &notBreaking},
+		},
+	})
+	assert.Equal(t, 77, changes.TotalChanges())
+	assert.Equal(t, 18, changes.TotalBreakingChanges())
+
+	// the override only applies to the comparison.
+	assert.Same(t, active, model.GetActiveBreakingRulesConfig())
+	assert.Equal(t, 19, CompareOpenAPIDocuments(origDoc, modDoc).TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Concurrent(t *testing.T) {
+	notBreaking := false
+	config := &ComparisonConfiguration{
+		BreakingRules: &model.BreakingRulesConfig{
+			JSONSchemaDialect: &model.BreakingChangeRule{Modified: &notBreaking},
+		},
+		DetectRenames: true,
+	}
+
+	// comparisons with and without a configuration don't see each other's breaking rules, or renames.
+	var wg sync.WaitGroup
+	total := make([]int, 8)
+	breaking := make([]int, 8)
+	for i := range breaking {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			origDoc, modDoc := burgerShopDocuments()
+			var changes *model.DocumentChanges
+			if i%2 == 0 {
+				changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, config)
+			} else {
+				changes = CompareOpenAPIDocuments(origDoc, modDoc)
+			}
+			total[i], breaking[i] = changes.TotalChanges(), changes.TotalBreakingChanges()
+		}(i)
+	}
+	wg.Wait()
+	for i := range breaking {
+		if i%2 == 0 {
+			assert.Equal(t, 76, total[i])
+			assert.Equal(t, 18, breaking[i])
+		} else {
+			assert.Equal(t, 77, total[i])
+			assert.Equal(t, 19, breaking[i])
+		}
+	}
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_BreakingRulesOverrideDefaults(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, dog]
+      responses:
+        '200':
+          description: pets`
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list all the pets
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, fish]
+      responses:
+        '200':
+          description: pets`
+
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, _ := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		return doc
+	}
+	origDoc, modDoc := build(left), build(right)
+
+	// by default, only removing an enum value is breaking.
+	changes := CompareOpenAPIDocuments(origDoc, modDoc)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	// description changes are breaking for us, and enum values are free to come and go.
+	breaking, notBreaking := true, false
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRules: &model.BreakingRulesConfig{
+			Operation: &model.OperationRules{
+				Description: &model.BreakingChangeRule{Modified: &breaking},
+			},
+			Schema: &model.SchemaRules{
+				Enum: &model.BreakingChangeRule{Added: &notBreaking, Removed: &notBreaking},
+			},
+		},
+	})
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	for _, c := range changes.GetAllChanges() {
+		assert.Equal(t, c.Property == "description", c.Breaking, c.Property)
+	}
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_BreakingRulesPreset(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            format: uuid
+            enum: [cat, dog]
+      responses:
+        '200':
+          description: pets`
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            format: ulid
+            maxLength: 26
+            enum: [cat, dog, fish]
+      responses:
+        '200':
+          description: pets`
+
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, _ := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		return doc
+	}
+	origDoc, modDoc := build(left), build(right)
+	active := model.GetActiveBreakingRulesConfig()
+
+	// by default, only the format change is breaking.
+	changes := CompareOpenAPIDocuments(origDoc, modDoc)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRulesPreset: model.PresetStrictConsumer,
+	})
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 3, changes.TotalBreakingChanges())
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRulesPreset: model.PresetLenient,
+	})
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+
+	// overrides are merged over the preset.
+	breaking := true
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRulesPreset: model.PresetLenient,
+		BreakingRules: &model.BreakingRulesConfig{
+			Schema: &model.SchemaRules{MaxLength: &model.BreakingChangeRule{Added: &breaking}},
+		},
+	})
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	// the preset only applies to the comparison, and presets that don't exist are ignored.
+	assert.Same(t, active, model.GetActiveBreakingRulesConfig())
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		BreakingRulesPreset: "chaotic",
+	})
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_OnChange(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	streamed := 0
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		OnChange: func(change *model.Change) bool {
+			streamed++
+			return true
+		},
+		StreamOnly: true,
+	})
+	assert.GreaterOrEqual(t, streamed, 77)
+	assert.Less(t, changes.TotalChanges(), 77)
+
+	// stop as soon as the first breaking change is found.
+	var breaking []*model.Change
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		OnChange: func(change *model.Change) bool {
+			if change.Breaking {
+				breaking = append(breaking, change)
+			}
+			return !change.Breaking
+		},
+	})
+	assert.Len(t, breaking, 1)
+	assert.Less(t, changes.TotalChanges(), 77)
+	assert.Equal(t, 77, CompareOpenAPIDocuments(origDoc, modDoc).TotalChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Concurrency(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{Concurrency: 4})
+	assert.Equal(t, 77, changes.TotalChanges())
+	assert.Equal(t, 19, changes.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_OrderedArrays(t *testing.T) {
+	build := func(servers string) *v3.Document {
+		spec := "openapi: 3.1.0\ninfo:\n  title: t\n  version: 1.0.0\nservers:\n" + servers
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	origDoc := build("  - url: https://a.com\n  - url: https://b.com")
+	modDoc := build("  - url: https://b.com\n  - url: https://a.com")
+
+	// servers are compared as sets by default.
+	assert.Nil(t, CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{}))
+
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		OrderedArrays: &model.OrderedArrays{Servers: true},
+	})
+	require.NotNil(t, changes)
+	require.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, model.Reordered, changes.GetAllChanges()[0].ChangeType)
+
+	// the ordered arrays only apply to the comparison.
+	assert.Nil(t, CompareOpenAPIDocuments(origDoc, modDoc))
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_DetectRenames(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{DetectRenames: true})
+	assert.Equal(t, 76, changes.TotalChanges())
+
+	var renamed []*model.Change
+	for _, c := range changes.GetAllChanges() {
+		if c.ChangeType == model.ObjectRenamed {
+			renamed = append(renamed, c)
+		}
+	}
+	require.Len(t, renamed, 1)
+	assert.Equal(t, "DressingResponse", renamed[0].Original)
+	assert.Equal(t, "DressingResponse2", renamed[0].New)
+
+	// renames are only detected for the comparison.
+	assert.Equal(t, 77, CompareOpenAPIDocuments(origDoc, modDoc).TotalChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_EffectiveSecurity(t *testing.T) {
+	build := func(security string) *v3.Document {
+		spec := "openapi: 3.1.0\ninfo:\n  title: t\n  version: 1.0.0\nsecurity:\n" + security +
+			"\npaths:\n  /pets:\n    get:\n      responses:\n        \"200\":\n          description: OK"
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	origDoc := build("  - apiKey: []\n  - oauth: []")
+	modDoc := build("  - oauth: []")
+
+	// operations are compared as written by default.
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{})
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		EffectiveSecurity: true,
+	})
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Len(t, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.SecurityRequirementChanges, 1)
+
+	// effective security only applies to the comparison.
+	assert.Equal(t, 1, CompareOpenAPIDocuments(origDoc, modDoc).TotalChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Baseline(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocuments(origDoc, modDoc)
+
+	// accept every breaking change.
+	baseline := model.NewBaseline(changes)
+	kept := baseline.Accepted[:0]
+	for _, c := range changes.GetAllChanges() {
+		if c.Breaking {
+			kept = append(kept, &model.BaselineEntry{ID: c.ID})
+		}
+	}
+	baseline.Accepted = kept
+
+	origDoc, modDoc = burgerShopDocuments()
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{Baseline: baseline})
+	assert.Equal(t, 77-len(kept), changes.TotalChanges())
+	assert.Zero(t, changes.TotalBreakingChanges())
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Snippets(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{Snippets: true})
+	require.NotNil(t, changes)
+	for _, c := range changes.GetAllChanges() {
+		if c.Context.OriginalLine != nil {
+			require.NotNil(t, c.OriginalSnippet)
+			assert.LessOrEqual(t, len(c.OriginalSnippet.Lines), 2*model.DefaultSnippetContextLines+1)
+		}
+		if c.Context.NewLine != nil {
+			require.NotNil(t, c.NewSnippet)
+		}
+	}
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Snippets:            true,
+		SnippetContextLines: -1,
+	})
+	for _, c := range changes.GetAllChanges() {
+		if c.NewSnippet != nil {
+			assert.Len(t, c.NewSnippet.Lines, 1)
+		}
+	}
+
+	// snippets are not attached unless asked for.
+	for _, c := range CompareOpenAPIDocuments(origDoc, modDoc).GetAllChanges() {
+		assert.Nil(t, c.OriginalSnippet)
+		assert.Nil(t, c.NewSnippet)
+	}
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Severity(t *testing.T) {
+	origDoc, modDoc := burgerShopDocuments()
+	all := CompareOpenAPIDocuments(origDoc, modDoc).GetAllChanges()
+	critical := len(model.FilterChanges(all, model.AtLeast(model.SeverityCritical)))
+	assert.NotZero(t, critical)
+
+	changes := CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Filter: model.AtLeast(model.SeverityCritical),
+	})
+	assert.Equal(t, critical, changes.TotalChanges())
+
+	// make every change to a description critical, the mapping is applied before the filter.
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Severity: &model.SeverityMapping{
+			Breaking:   map[int]model.Severity{model.ObjectRemoved: model.SeverityCritical},
+			Properties: map[string]model.Severity{"description": model.SeverityCritical},
+		},
+		Filter: model.AtLeast(model.SeverityCritical),
+	})
+	assert.NotZero(t, changes.TotalChanges())
+	for _, c := range changes.GetAllChanges() {
+		assert.Equal(t, model.SeverityCritical, c.Severity)
+		assert.True(t, c.Property == "description" || (c.Breaking && c.ChangeType == model.ObjectRemoved), c.Property)
+	}
+
+	changes = CompareOpenAPIDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Filter: model.OnlyBreaking(),
+	})
+	assert.Equal(t, len(model.FilterChanges(all, model.OnlyBreaking())), changes.TotalChanges())
+}
+
+func TestCompareSwaggerDocumentsWithConfiguration(t *testing.T) {
+	original, _ := os.ReadFile("../test_specs/petstorev2-complete.yaml")
+	modified, _ := os.ReadFile("../test_specs/petstorev2-complete-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v2.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v2.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	changes := CompareSwaggerDocumentsWithConfiguration(origDoc, modDoc, &ComparisonConfiguration{
+		Filter: func(change *model.Change) bool { return change.Breaking },
+	})
+	assert.Equal(t, 27, changes.TotalChanges())
+	assert.Equal(t, 27, changes.TotalBreakingChanges())
+}
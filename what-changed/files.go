@@ -0,0 +1,263 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// FileChange describes a single source file of a multi-file document, and how its content changed between the
+// original and updated documents.
+type FileChange struct {
+	// Location is the path of the file, relative to the directory of the root document. Remote files use their
+	// full URL. Files are matched between the original and updated documents using this location, the root
+	// document is always matched to the other root document, regardless of its name.
+	Location string `json:"location" yaml:"location"`
+
+	// Root is true if the file is the root document.
+	Root bool `json:"root,omitempty" yaml:"root,omitempty"`
+
+	// OriginalLocation and UpdatedLocation are the absolute paths (or URLs) of the file in each document. One of
+	// them is empty if the file was added or removed.
+	OriginalLocation string `json:"originalLocation,omitempty" yaml:"originalLocation,omitempty"`
+	UpdatedLocation  string `json:"updatedLocation,omitempty" yaml:"updatedLocation,omitempty"`
+
+	// OriginalHash and UpdatedHash are canonical hashes of the content of the file, see utils.HashYAMLNode.
+	// Formatting, comments and the order of keys do not affect the hash.
+	OriginalHash uint64 `json:"originalHash,omitempty" yaml:"originalHash,omitempty"`
+	UpdatedHash  uint64 `json:"updatedHash,omitempty" yaml:"updatedHash,omitempty"`
+
+	// Changes are the changes from a full comparison that originate from this file, they are only set once
+	// FileChanges.AssignChanges has been called.
+	Changes []*model.Change `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// Added returns true if the file only exists in the updated document.
+func (f *FileChange) Added() bool {
+	return f.OriginalLocation == ""
+}
+
+// Removed returns true if the file only exists in the original document.
+func (f *FileChange) Removed() bool {
+	return f.UpdatedLocation == ""
+}
+
+// Modified returns true if the file exists in both documents, but the content is different.
+func (f *FileChange) Modified() bool {
+	return !f.Added() && !f.Removed() && f.OriginalHash != f.UpdatedHash
+}
+
+// Changed returns true if the file was added, removed or modified.
+func (f *FileChange) Changed() bool {
+	return f.Added() || f.Removed() || f.Modified()
+}
+
+// FileChanges holds every source file used by two multi-file documents, it is used to determine which files
+// have changed, without having to run a full comparison. If no files have changed, the documents are the same.
+type FileChanges struct {
+	// Files holds every file found in either document, the root document is first, and the rest are ordered
+	// by location.
+	Files []*FileChange `json:"files" yaml:"files"`
+
+	original *index.Rolodex
+	updated  *index.Rolodex
+}
+
+// HasChanges returns true if any file was added, removed or modified. If false, there is no need to run a full
+// comparison of the documents.
+func (f *FileChanges) HasChanges() bool {
+	for _, file := range f.Files {
+		if file.Changed() {
+			return true
+		}
+	}
+	return false
+}
+
+// ChangedFiles returns every file that was added, removed or modified.
+func (f *FileChanges) ChangedFiles() []*FileChange {
+	var changed []*FileChange
+	for _, file := range f.Files {
+		if file.Changed() {
+			changed = append(changed, file)
+		}
+	}
+	return changed
+}
+
+// GetFile returns the file with the supplied location, it can be relative (as used by Location), or the
+// absolute location of the file in either document. Returns nil if the file cannot be found.
+func (f *FileChanges) GetFile(location string) *FileChange {
+	if location == "" {
+		return nil
+	}
+	for _, file := range f.Files {
+		if file.Location == location || file.OriginalLocation == location || file.UpdatedLocation == location {
+			return file
+		}
+	}
+	return nil
+}
+
+// AssignChanges maps every change from a full comparison of the same two documents back to the file it came
+// from. The document location of each change is used if it is set, otherwise the origin of the object that changed
+// is looked up in the rolodex of each document. Any change that cannot be located is assigned to the root document.
+// Any changes previously assigned are replaced.
+//
+// Changes made inside referenced schemas are only found by a full comparison when the documents are built with
+// UseSchemaQuickHash enabled (see ComparisonConfiguration.CompareResolvedSchemas).
+func (f *FileChanges) AssignChanges(changes *model.DocumentChanges) {
+	var root *FileChange
+	for _, file := range f.Files {
+		file.Changes = nil
+		if file.Root {
+			root = file
+		}
+	}
+	if changes == nil {
+		return
+	}
+	for _, change := range changes.GetAllChanges() {
+		file := f.GetFile(f.changeLocation(change))
+		if file == nil {
+			file = root
+		}
+		if file != nil {
+			file.Changes = append(file.Changes, change)
+		}
+	}
+}
+
+// changeLocation returns the absolute location of the file a change came from, or an empty string if unknown.
+func (f *FileChanges) changeLocation(change *model.Change) string {
+	if change.Context != nil && change.Context.DocumentLocation != "" {
+		return change.Context.DocumentLocation
+	}
+	if location := objectLocation(f.updated, change.NewObject); location != "" {
+		return location
+	}
+	return objectLocation(f.original, change.OriginalObject)
+}
+
+// objectLocation uses the rolodex to find the file the root node of a low-level object came from.
+func objectLocation(rolodex *index.Rolodex, object any) string {
+	if rolodex == nil || rolodex.GetRootIndex() == nil {
+		return ""
+	}
+	if hr, ok := object.(interface{ GetRootNode() *yaml.Node }); ok && !reflect.ValueOf(hr).IsNil() {
+		if origin := rolodex.FindNodeOrigin(hr.GetRootNode()); origin != nil {
+			return origin.AbsoluteLocation
+		}
+	}
+	return ""
+}
+
+// CompareOpenAPIDocumentFiles compares the source files used by left (original) and right (updated) OpenAPI 3+
+// documents. See CompareRolodexFiles for details.
+func CompareOpenAPIDocumentFiles(original, updated *v3.Document) *FileChanges {
+	return CompareRolodexFiles(original.Rolodex, updated.Rolodex)
+}
+
+// CompareSwaggerDocumentFiles compares the source files used by left (original) and right (updated) Swagger
+// documents. See CompareRolodexFiles for details.
+func CompareSwaggerDocumentFiles(original, updated *v2.Swagger) *FileChanges {
+	return CompareRolodexFiles(original.Rolodex, updated.Rolodex)
+}
+
+// CompareRolodexFiles compares the content of every file indexed by two rolodexes, matching files by their
+// location relative to the root document. It's a cheap way for CI to find out which files contributed changes
+// (and if there are any changes at all), before running an expensive full comparison.
+func CompareRolodexFiles(original, updated *index.Rolodex) *FileChanges {
+	files := make(map[string]*FileChange)
+	for _, f := range rolodexFiles(original) {
+		files[f.key()] = &FileChange{
+			Location: f.location, Root: f.root, OriginalLocation: f.path, OriginalHash: f.hash,
+		}
+	}
+	for _, f := range rolodexFiles(updated) {
+		if fc, ok := files[f.key()]; ok {
+			fc.UpdatedLocation = f.path
+			fc.UpdatedHash = f.hash
+			continue
+		}
+		files[f.key()] = &FileChange{
+			Location: f.location, Root: f.root, UpdatedLocation: f.path, UpdatedHash: f.hash,
+		}
+	}
+
+	changes := &FileChanges{original: original, updated: updated}
+	for _, fc := range files {
+		changes.Files = append(changes.Files, fc)
+	}
+	sort.Slice(changes.Files, func(i, j int) bool {
+		if changes.Files[i].Root != changes.Files[j].Root {
+			return changes.Files[i].Root
+		}
+		return changes.Files[i].Location < changes.Files[j].Location
+	})
+	return changes
+}
+
+type rolodexFile struct {
+	location string
+	path     string
+	hash     uint64
+	root     bool
+}
+
+// key is used to match files between rolodexes, root documents always match, whatever they are called.
+func (f *rolodexFile) key() string {
+	if f.root {
+		return ""
+	}
+	return f.location
+}
+
+// rolodexFiles hashes the root document, and every file indexed by the rolodex.
+func rolodexFiles(rolodex *index.Rolodex) []*rolodexFile {
+	if rolodex == nil {
+		return nil
+	}
+	var files []*rolodexFile
+	seen := make(map[string]struct{})
+	rootIndex := rolodex.GetRootIndex()
+	rootDir := ""
+	if rootIndex != nil {
+		rootPath := rootIndex.GetSpecAbsolutePath()
+		rootDir = filepath.Dir(rootPath)
+		rootNode := rolodex.GetRootNode()
+		if rootNode == nil {
+			rootNode = rootIndex.GetRootNode()
+		}
+		files = append(files, &rolodexFile{
+			location: filepath.Base(rootPath), path: rootPath, hash: utils.HashYAMLNode(rootNode), root: true,
+		})
+		seen[rootPath] = struct{}{}
+	}
+	for _, idx := range rolodex.GetIndexes() {
+		path := idx.GetSpecAbsolutePath()
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+		location := path
+		if !strings.HasPrefix(path, "http") && rootDir != "" {
+			if rel, err := filepath.Rel(rootDir, path); err == nil {
+				location = filepath.ToSlash(rel)
+			}
+		}
+		files = append(files, &rolodexFile{location: location, path: path, hash: utils.HashYAMLNode(idx.GetRootNode())})
+	}
+	return files
+}
@@ -0,0 +1,138 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildMultiFileDocument(t *testing.T, files map[string]string) *v3.Document {
+	dir := t.TempDir()
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	spec, _ := os.ReadFile(filepath.Join(dir, "openapi.yaml"))
+	info, _ := datamodel.ExtractSpecInfo(spec)
+	config := datamodel.NewDocumentConfiguration()
+	config.AllowFileReferences = true
+	config.BasePath = dir
+	config.SpecFilePath = filepath.Join(dir, "openapi.yaml")
+	config.UseSchemaQuickHash = true
+	doc, err := v3.CreateDocumentFromConfig(info, config)
+	assert.NoError(t, err)
+	return doc
+}
+
+func TestCompareOpenAPIDocumentFiles(t *testing.T) {
+	root := `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'pet.yaml'
+  /toys:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: 'toy.yaml'`
+
+	original := buildMultiFileDocument(t, map[string]string{
+		"openapi.yaml": root,
+		"pet.yaml":     "type: object\ndescription: a pet",
+		"toy.yaml":     "type: object\ndescription: a toy",
+	})
+
+	// reformatting a file does not change it.
+	same := buildMultiFileDocument(t, map[string]string{
+		"openapi.yaml": root,
+		"pet.yaml":     "# a comment\ndescription: 'a pet'\ntype: object",
+		"toy.yaml":     "type: object\ndescription: a toy",
+	})
+	files := CompareOpenAPIDocumentFiles(original, same)
+	assert.Len(t, files.Files, 3)
+	assert.True(t, files.Files[0].Root)
+	assert.Equal(t, "openapi.yaml", files.Files[0].Location)
+	assert.Equal(t, "pet.yaml", files.Files[1].Location)
+	assert.Equal(t, "toy.yaml", files.Files[2].Location)
+	assert.False(t, files.HasChanges())
+	assert.Empty(t, files.ChangedFiles())
+
+	updated := buildMultiFileDocument(t, map[string]string{
+		"openapi.yaml": root,
+		"pet.yaml":     "type: object\ndescription: a very good pet",
+		"toy.yaml":     "type: object\ndescription: a toy",
+	})
+	files = CompareOpenAPIDocumentFiles(original, updated)
+	assert.True(t, files.HasChanges())
+	changed := files.ChangedFiles()
+	assert.Len(t, changed, 1)
+	assert.Equal(t, "pet.yaml", changed[0].Location)
+	assert.True(t, changed[0].Modified())
+	assert.False(t, changed[0].Added())
+	assert.False(t, changed[0].Removed())
+	assert.Same(t, changed[0], files.GetFile(changed[0].UpdatedLocation))
+	assert.Nil(t, files.GetFile("nope.yaml"))
+
+	files.AssignChanges(CompareOpenAPIDocuments(original, updated))
+	assert.Len(t, files.GetFile("pet.yaml").Changes, 1)
+	assert.Equal(t, "description", files.GetFile("pet.yaml").Changes[0].Property)
+	assert.Empty(t, files.GetFile("toy.yaml").Changes)
+	assert.Empty(t, files.GetFile("openapi.yaml").Changes)
+}
+
+func TestCompareRolodexFiles_AddedRemoved(t *testing.T) {
+	original := buildMultiFileDocument(t, map[string]string{
+		"openapi.yaml": `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'pet.yaml'`,
+		"pet.yaml": "type: object",
+	})
+	updated := buildMultiFileDocument(t, map[string]string{
+		"openapi.yaml": `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'cat.yaml'`,
+		"cat.yaml": "type: object",
+	})
+
+	files := CompareOpenAPIDocumentFiles(original, updated)
+	assert.Len(t, files.Files, 3)
+	assert.True(t, files.Files[0].Modified())
+	assert.Equal(t, "cat.yaml", files.Files[1].Location)
+	assert.True(t, files.Files[1].Added())
+	assert.Equal(t, "pet.yaml", files.Files[2].Location)
+	assert.True(t, files.Files[2].Removed())
+	assert.Len(t, files.ChangedFiles(), 3)
+
+	files.AssignChanges(nil)
+	assert.Empty(t, files.Files[0].Changes)
+	assert.Empty(t, CompareRolodexFiles(nil, nil).Files)
+}
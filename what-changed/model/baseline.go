@@ -0,0 +1,131 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ChangeID returns the deterministic ID of a change made at a location in a tree of changes (see LocateChanges).
+// The ID is a hash of the path of the location (see ChangePath), the property and the type of the change, so the
+// same change made to the same documents always has the same ID, regardless of line numbers, formatting or the
+// order the changes were found in.
+//
+// Objects that are added, removed or renamed are also identified by their name (the original or new value of the
+// change), as many objects can be added to or removed from the same property of the same location.
+func ChangeID(c *Change, location []string) string {
+	h := sha256.New()
+	h.Write([]byte(ChangePath(location)))
+	h.Write([]byte{'#'})
+	h.Write([]byte(c.Property))
+	h.Write([]byte{'#'})
+	h.Write([]byte(ChangeTypeText(c.ChangeType)))
+	switch c.ChangeType {
+	case ObjectAdded, ObjectRemoved, ObjectRenamed:
+		h.Write([]byte{'#'})
+		h.Write([]byte(c.Original))
+		h.Write([]byte{'#'})
+		h.Write([]byte(c.New))
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// AssignChangeIDs walks any tree of changes (see LocateChanges) and sets the ID of every change (see ChangeID). It's
+// called when documents are compared, and when changes are inverted.
+func AssignChangeIDs(changes any) {
+	for _, lc := range LocateChanges(changes) {
+		lc.Change.ID = ChangeID(lc.Change, lc.Location)
+	}
+}
+
+// Baseline is a list of changes that have been accepted, identified by their ID (see ChangeID), so known and
+// intentional changes (like a planned breaking change) can be suppressed from the changes found by later
+// comparisons of the same documents. Baselines are stored as YAML or JSON:
+//
+//	accepted:
+//	  - id: 5d1c2f0b9a7e3c41
+//	    path: $.paths.pathItems['/pets'].get
+//	    property: parameters
+//	    change: object_removed
+//	    reason: limit was deprecated in 1.2
+//
+// Only the ID is used to match changes, everything else describes the change for the readers of the baseline.
+type Baseline struct {
+	Accepted []*BaselineEntry `json:"accepted" yaml:"accepted"`
+}
+
+// BaselineEntry is a single change accepted by a Baseline.
+type BaselineEntry struct {
+	ID       string `json:"id" yaml:"id"`
+	Path     string `json:"path,omitempty" yaml:"path,omitempty"`
+	Property string `json:"property,omitempty" yaml:"property,omitempty"`
+	Change   string `json:"change,omitempty" yaml:"change,omitempty"`
+	Reason   string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// NewBaseline creates a Baseline that accepts every change of any tree of changes, for example to accept every
+// breaking change of a release:
+//
+//	baseline := model.NewBaseline(changes)
+//	bytes, _ := baseline.Marshal()
+//	os.WriteFile("baseline.yaml", bytes, 0o644)
+func NewBaseline(changes any) *Baseline {
+	b := &Baseline{Accepted: []*BaselineEntry{}}
+	seen := make(map[string]struct{})
+	for _, lc := range LocateChanges(changes) {
+		id := ChangeID(lc.Change, lc.Location)
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		b.Accepted = append(b.Accepted, &BaselineEntry{
+			ID:       id,
+			Path:     ChangePath(lc.Location),
+			Property: lc.Change.Property,
+			Change:   ChangeTypeText(lc.Change.ChangeType),
+		})
+	}
+	return b
+}
+
+// ParseBaseline reads a Baseline from YAML or JSON.
+func ParseBaseline(data []byte) (*Baseline, error) {
+	b := new(Baseline)
+	if err := yaml.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// LoadBaseline reads a Baseline from a YAML or JSON file.
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBaseline(data)
+}
+
+// Marshal serializes the Baseline as YAML.
+func (b *Baseline) Marshal() ([]byte, error) {
+	return yaml.Marshal(b)
+}
+
+// Accepts returns true if the change has been accepted by the baseline. Changes without an ID (see
+// AssignChangeIDs) are never accepted.
+func (b *Baseline) Accepts(c *Change) bool {
+	if b == nil || c == nil || c.ID == "" {
+		return false
+	}
+	for _, e := range b.Accepted {
+		if e != nil && e.ID == c.ID {
+			return true
+		}
+	}
+	return false
+}
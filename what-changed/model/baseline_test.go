@@ -0,0 +1,98 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignChangeIDs(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+
+	ids := make(map[string]*Change)
+	for _, lc := range LocateChanges(changes) {
+		require.Len(t, lc.Change.ID, 16)
+		assert.Equal(t, ChangeID(lc.Change, lc.Location), lc.Change.ID)
+		assert.NotContains(t, ids, lc.Change.ID, "duplicate id for %s", lc.Change.Property)
+		ids[lc.Change.ID] = lc.Change
+	}
+
+	// comparing the same documents again gives the same ids.
+	again := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	for _, c := range again.GetAllChanges() {
+		assert.Contains(t, ids, c.ID)
+	}
+
+	report := NewChangeReport(changes)
+	assert.Equal(t, report.Changes[0].ID, LocateChanges(changes)[0].Change.ID)
+}
+
+func TestChangeID(t *testing.T) {
+	location := []string{"paths", "pathItems", "/pets", "get"}
+	c := &Change{ChangeType: Modified, Property: "description", Original: "a", New: "b"}
+	id := ChangeID(c, location)
+
+	// modified values don't change the id.
+	assert.Equal(t, id, ChangeID(&Change{ChangeType: Modified, Property: "description", Original: "a", New: "c"},
+		location))
+	assert.NotEqual(t, id, ChangeID(&Change{ChangeType: Modified, Property: "summary"}, location))
+	assert.NotEqual(t, id, ChangeID(c, location[:3]))
+
+	// added objects are identified by their name.
+	added := &Change{ChangeType: ObjectAdded, Property: "parameters", New: "limit"}
+	assert.NotEqual(t, ChangeID(added, location),
+		ChangeID(&Change{ChangeType: ObjectAdded, Property: "parameters", New: "offset"}, location))
+}
+
+func TestInvertChanges_ChangeIDs(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+	InvertChanges(changes)
+	for _, lc := range LocateChanges(changes) {
+		assert.Equal(t, ChangeID(lc.Change, lc.Location), lc.Change.ID)
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+
+	baseline := NewBaseline(changes)
+	require.Len(t, baseline.Accepted, changes.TotalChanges())
+	for _, c := range changes.GetAllChanges() {
+		assert.True(t, baseline.Accepts(c))
+	}
+	assert.Equal(t, "$.paths", baseline.Accepted[0].Path)
+
+	bytes, err := baseline.Marshal()
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "baseline.yaml")
+	require.NoError(t, os.WriteFile(file, bytes, 0o644))
+
+	loaded, err := LoadBaseline(file)
+	require.NoError(t, err)
+	assert.Equal(t, baseline, loaded)
+
+	json, err := ParseBaseline([]byte(`{"accepted": [{"id": "` + baseline.Accepted[0].ID + `", "reason": "planned"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "planned", json.Accepted[0].Reason)
+	assert.Equal(t, 1, len(json.Accepted))
+
+	assert.False(t, baseline.Accepts(&Change{Property: "nope"}))
+	var none *Baseline
+	assert.False(t, none.Accepts(changes.GetAllChanges()[0]))
+}
+
+func TestBaseline_Errors(t *testing.T) {
+	_, err := LoadBaseline(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+	_, err = ParseBaseline([]byte("accepted: {nope"))
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 	"strings"
 	"sync"
@@ -73,6 +74,23 @@ func BreakingRemoved(component, property string) bool {
 	return IsBreakingChange(component, property, ChangeTypeRemoved)
 }
 
+// isBreakingChange is the same as IsBreakingChange, using the breaking rules of the comparison of the context.
+func isBreakingChange(ctx context.Context, component, property, changeType string) bool {
+	return breakingRules(ctx).IsBreaking(component, property, changeType)
+}
+
+func breakingAdded(ctx context.Context, component, property string) bool {
+	return isBreakingChange(ctx, component, property, ChangeTypeAdded)
+}
+
+func breakingModified(ctx context.Context, component, property string) bool {
+	return isBreakingChange(ctx, component, property, ChangeTypeModified)
+}
+
+func breakingRemoved(ctx context.Context, component, property string) bool {
+	return isBreakingChange(ctx, component, property, ChangeTypeRemoved)
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -164,6 +182,7 @@ func buildDefaultRules() *BreakingRulesConfig {
 		JSONSchemaDialect: rule(true, true, true),
 		Self:              rule(true, true, true),
 		Components:        rule(false, false, true),
+		Renamed:           rule(false, true, false),
 
 		Info: &InfoRules{
 			Title:          rule(false, false, false),
@@ -236,6 +255,7 @@ func buildDefaultRules() *BreakingRulesConfig {
 			Example:         rule(false, false, false),
 			Schema:          rule(true, false, true),
 			Items:           rule(true, false, true),
+			Enum:            rule(false, false, true),
 		},
 
 		RequestBody: &RequestBodyRules{
@@ -280,6 +300,7 @@ func buildDefaultRules() *BreakingRulesConfig {
 			Required:        rule(true, true, true),
 			Schema:          rule(true, false, true),
 			Items:           rule(true, false, true),
+			Enum:            rule(false, false, true),
 		},
 
 		Schemas: rule(true, false, true),
@@ -331,8 +352,13 @@ func buildDefaultRules() *BreakingRulesConfig {
 			Else:                  rule(true, false, true),
 			PropertyNames:         rule(true, false, true),
 			Contains:              rule(true, false, true),
+			MinContains:           rule(false, true, false),
+			MaxContains:           rule(false, true, false),
 			UnevaluatedItems:      rule(true, false, true),
 			UnevaluatedProperties: rule(true, true, true),
+			DependentSchemas:      rule(true, false, true),
+			PatternProperties:     rule(false, false, true),
+			Anchor:                rule(false, true, true),
 			DynamicAnchor:         rule(false, true, true), // $dynamicAnchor: modification/removal is breaking
 			DynamicRef:            rule(false, true, true), // $dynamicRef: modification/removal is breaking
 			Id:                    rule(true, true, true),  // $id: all changes are breaking (affects reference resolution)
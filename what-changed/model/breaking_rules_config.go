@@ -19,6 +19,7 @@ type BreakingRulesConfig struct {
 	JSONSchemaDialect   *BreakingChangeRule       `json:"jsonSchemaDialect,omitempty" yaml:"jsonSchemaDialect,omitempty"`
 	Self                *BreakingChangeRule       `json:"$self,omitempty" yaml:"$self,omitempty"`
 	Components          *BreakingChangeRule       `json:"components,omitempty" yaml:"components,omitempty"`
+	Renamed             *BreakingChangeRule       `json:"renamed,omitempty" yaml:"renamed,omitempty"`
 	Info                *InfoRules                `json:"info,omitempty" yaml:"info,omitempty"`
 	Contact             *ContactRules             `json:"contact,omitempty" yaml:"contact,omitempty"`
 	License             *LicenseRules             `json:"license,omitempty" yaml:"license,omitempty"`
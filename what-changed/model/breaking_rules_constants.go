@@ -31,6 +31,7 @@ const (
 	CompParameter           = "parameter"
 	CompPathItem            = "pathItem"
 	CompPaths               = "paths"
+	CompRenamed             = "renamed"
 	CompRequestBody         = "requestBody"
 	CompResponse            = "response"
 	CompResponses           = "responses"
@@ -56,6 +57,7 @@ const (
 	PropAllOf                = "allOf"
 	PropAllowEmptyValue      = "allowEmptyValue"
 	PropAllowReserved        = "allowReserved"
+	PropAnchor               = "$anchor"
 	PropAnyOf                = "anyOf"
 	PropAttribute            = "attribute"
 	PropAuthorizationCode    = "authorizationCode"
@@ -79,6 +81,7 @@ const (
 	PropDelete               = "delete"
 	PropDeprecated           = "deprecated"
 	PropDependentRequired    = "dependentRequired"
+	PropDependentSchemas     = "dependentSchemas"
 	PropDescription          = "description"
 	PropDevice               = "device"
 	PropDiscriminator        = "discriminator"
@@ -111,10 +114,12 @@ const (
 	PropKind                 = "kind"
 	PropLicense              = "license"
 	PropMapping              = "mapping"
+	PropMaxContains          = "maxContains"
 	PropMaxItems             = "maxItems"
 	PropMaxLength            = "maxLength"
 	PropMaxProperties        = "maxProperties"
 	PropMaximum              = "maximum"
+	PropMinContains          = "minContains"
 	PropMinItems             = "minItems"
 	PropMinLength            = "minLength"
 	PropMinProperties        = "minProperties"
@@ -137,6 +142,7 @@ const (
 	PropPatch                = "patch"
 	PropPath                 = "path"
 	PropPattern              = "pattern"
+	PropPatternProperties    = "patternProperties"
 	PropPost                 = "post"
 	PropPrefix               = "prefix"
 	PropPrefixItems          = "prefixItems"
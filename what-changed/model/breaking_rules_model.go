@@ -89,6 +89,7 @@ type ParameterRules struct {
 	Example         *BreakingChangeRule `json:"example,omitempty" yaml:"example,omitempty"`
 	Schema          *BreakingChangeRule `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Items           *BreakingChangeRule `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum            *BreakingChangeRule `json:"enum,omitempty" yaml:"enum,omitempty"`
 }
 
 // RequestBodyRules defines breaking rules for the Request Body object properties.
@@ -139,6 +140,7 @@ type HeaderRules struct {
 	Required        *BreakingChangeRule `json:"required,omitempty" yaml:"required,omitempty"`
 	Schema          *BreakingChangeRule `json:"schema,omitempty" yaml:"schema,omitempty"`
 	Items           *BreakingChangeRule `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum            *BreakingChangeRule `json:"enum,omitempty" yaml:"enum,omitempty"`
 }
 
 // SchemaRules defines breaking rules for the Schema object properties.
@@ -188,8 +190,13 @@ type SchemaRules struct {
 	Else                  *BreakingChangeRule `json:"else,omitempty" yaml:"else,omitempty"`
 	PropertyNames         *BreakingChangeRule `json:"propertyNames,omitempty" yaml:"propertyNames,omitempty"`
 	Contains              *BreakingChangeRule `json:"contains,omitempty" yaml:"contains,omitempty"`
+	MinContains           *BreakingChangeRule `json:"minContains,omitempty" yaml:"minContains,omitempty"`
+	MaxContains           *BreakingChangeRule `json:"maxContains,omitempty" yaml:"maxContains,omitempty"`
 	UnevaluatedItems      *BreakingChangeRule `json:"unevaluatedItems,omitempty" yaml:"unevaluatedItems,omitempty"`
 	UnevaluatedProperties *BreakingChangeRule `json:"unevaluatedProperties,omitempty" yaml:"unevaluatedProperties,omitempty"`
+	DependentSchemas      *BreakingChangeRule `json:"dependentSchemas,omitempty" yaml:"dependentSchemas,omitempty"`
+	PatternProperties     *BreakingChangeRule `json:"patternProperties,omitempty" yaml:"patternProperties,omitempty"`
+	Anchor                *BreakingChangeRule `json:"$anchor,omitempty" yaml:"$anchor,omitempty"`
 	DynamicAnchor         *BreakingChangeRule `json:"$dynamicAnchor,omitempty" yaml:"$dynamicAnchor,omitempty"`
 	DynamicRef            *BreakingChangeRule `json:"$dynamicRef,omitempty" yaml:"$dynamicRef,omitempty"`
 	Id                    *BreakingChangeRule `json:"$id,omitempty" yaml:"$id,omitempty"`
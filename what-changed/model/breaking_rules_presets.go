@@ -0,0 +1,238 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Names of the breaking rules presets (see BreakingRulesPreset).
+const (
+	// PresetStrictConsumer treats anything a strict client could notice as breaking. New constraints on schemas,
+	// new enum values, changes to required values and new security requirements are all breaking, as well as
+	// everything that is breaking by default.
+	PresetStrictConsumer = "strict-consumer"
+
+	// PresetLenient only treats changes that are certain to break clients as breaking, such as removing a path, an
+	// operation, a parameter or a property, or changing a type. Changes to constraints, formats, metadata and
+	// identifiers are not breaking.
+	PresetLenient = "lenient"
+
+	// PresetInternalAPI is for APIs with clients that are deployed along with the API, such as internal services.
+	// It is the same as PresetLenient, and also ignores changes to servers, security and operation IDs, which are
+	// managed by the platform rather than by the clients of the API.
+	PresetInternalAPI = "internal-api"
+)
+
+var breakingRulesPresets = map[string]func() *BreakingRulesConfig{
+	PresetStrictConsumer: strictConsumerRules,
+	PresetLenient:        lenientRules,
+	PresetInternalAPI:    internalAPIRules,
+}
+
+// BreakingRulesPresets returns the names of every breaking rules preset, in alphabetical order.
+func BreakingRulesPresets() []string {
+	names := make([]string, 0, len(breakingRulesPresets))
+	for name := range breakingRulesPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BreakingRulesPreset returns a complete breaking rules configuration for a named preset (PresetStrictConsumer,
+// PresetLenient or PresetInternalAPI), which is the default rules with the preset merged over them. The result
+// can be used with SetActiveBreakingRulesConfig, or merged with overrides of its own. Case is ignored, and an
+// error is returned if there is no preset with the name.
+func BreakingRulesPreset(name string) (*BreakingRulesConfig, error) {
+	preset, ok := breakingRulesPresets[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown breaking rules preset '%s', expected one of %s", name,
+			strings.Join(BreakingRulesPresets(), ", "))
+	}
+	config := new(BreakingRulesConfig)
+	config.Merge(GenerateDefaultBreakingRules())
+	config.Merge(preset())
+	return config, nil
+}
+
+func strictConsumerRules() *BreakingRulesConfig {
+	return &BreakingRulesConfig{
+		Security: rule(true, true, true),
+		Servers:  rule(false, true, true),
+		Operation: &OperationRules{
+			Deprecated: rule(true, false, false),
+			Security:   rule(true, true, true),
+		},
+		Parameter: &ParameterRules{
+			Required: rule(true, true, true),
+			Style:    rule(true, true, true),
+			Explode:  rule(true, true, true),
+			Enum:     rule(true, true, true),
+		},
+		Header: &HeaderRules{
+			Style:   rule(true, true, true),
+			Explode: rule(true, true, true),
+			Enum:    rule(true, true, true),
+		},
+		Schema: &SchemaRules{
+			Format:           rule(true, true, true),
+			Maximum:          rule(true, true, false),
+			Minimum:          rule(true, true, false),
+			ExclusiveMaximum: rule(true, true, false),
+			ExclusiveMinimum: rule(true, true, false),
+			MaxLength:        rule(true, true, false),
+			MinLength:        rule(true, true, false),
+			Pattern:          rule(true, true, false),
+			MaxItems:         rule(true, true, false),
+			MinItems:         rule(true, true, false),
+			MaxProperties:    rule(true, true, false),
+			MinProperties:    rule(true, true, false),
+			UniqueItems:      rule(true, true, false),
+			MultipleOf:       rule(true, true, false),
+			MinContains:      rule(true, true, false),
+			MaxContains:      rule(true, true, false),
+			Const:            rule(true, true, true),
+			Nullable:         rule(false, true, true),
+			ReadOnly:         rule(true, true, true),
+			WriteOnly:        rule(true, true, true),
+			Required:         rule(true, true, true),
+			Enum:             rule(true, true, true),
+		},
+		SecurityRequirement: &SecurityRequirementRules{
+			Schemes: rule(true, true, true),
+			Scopes:  rule(true, true, true),
+		},
+		ServerVariable: &ServerVariableRules{
+			Enum: rule(true, true, true),
+		},
+	}
+}
+
+func lenientRules() *BreakingRulesConfig {
+	return &BreakingRulesConfig{
+		OpenAPI:           rule(false, false, false),
+		JSONSchemaDialect: rule(false, false, false),
+		Self:              rule(false, false, false),
+		Renamed:           rule(false, false, false),
+		Tags:              rule(false, false, false),
+		Operation: &OperationRules{
+			Tags: rule(false, false, false),
+		},
+		Parameter: &ParameterRules{
+			AllowEmptyValue: rule(false, false, false),
+			AllowReserved:   rule(false, false, false),
+		},
+		Header: &HeaderRules{
+			AllowEmptyValue: rule(false, false, false),
+		},
+		Encoding: &EncodingRules{
+			Style:   rule(false, false, false),
+			Explode: rule(false, false, false),
+		},
+		Schema: &SchemaRules{
+			Format:           rule(false, false, false),
+			Maximum:          rule(false, false, false),
+			Minimum:          rule(false, false, false),
+			ExclusiveMaximum: rule(false, false, false),
+			ExclusiveMinimum: rule(false, false, false),
+			MaxLength:        rule(false, false, false),
+			MinLength:        rule(false, false, false),
+			Pattern:          rule(false, false, false),
+			MaxItems:         rule(false, false, false),
+			MinItems:         rule(false, false, false),
+			MaxProperties:    rule(false, false, false),
+			MinProperties:    rule(false, false, false),
+			UniqueItems:      rule(false, false, false),
+			MultipleOf:       rule(false, false, false),
+			MinContains:      rule(false, false, false),
+			MaxContains:      rule(false, false, false),
+			ContentEncoding:  rule(false, false, false),
+			ContentMediaType: rule(false, false, false),
+			Default:          rule(false, false, false),
+			Nullable:         rule(false, false, false),
+			ReadOnly:         rule(false, false, false),
+			WriteOnly:        rule(false, false, false),
+			Discriminator:    rule(false, false, false),
+			XML:              rule(false, false, false),
+			Anchor:           rule(false, false, false),
+			DynamicAnchor:    rule(false, false, false),
+			DynamicRef:       rule(false, false, false),
+			Id:               rule(false, false, false),
+			Vocabulary:       rule(false, false, false),
+			SchemaDialect:    rule(false, false, false),
+		},
+		Discriminator: &DiscriminatorRules{
+			DefaultMapping: rule(false, false, false),
+			Mapping:        rule(false, false, false),
+		},
+		XML: &XMLRules{
+			Name:      rule(false, false, false),
+			Namespace: rule(false, false, false),
+			Prefix:    rule(false, false, false),
+			Attribute: rule(false, false, false),
+			NodeType:  rule(false, false, false),
+			Wrapped:   rule(false, false, false),
+		},
+		Tag: &TagRules{
+			Name:   rule(false, false, false),
+			Parent: rule(false, false, false),
+		},
+		Link: &LinkRules{
+			OperationRef: rule(false, false, false),
+			OperationID:  rule(false, false, false),
+			RequestBody:  rule(false, false, false),
+			Server:       rule(false, false, false),
+			Parameters:   rule(false, false, false),
+		},
+	}
+}
+
+func internalAPIRules() *BreakingRulesConfig {
+	config := lenientRules()
+	config.Merge(&BreakingRulesConfig{
+		Servers:  rule(false, false, false),
+		Security: rule(false, false, false),
+		PathItem: &PathItemRules{
+			Servers: rule(false, false, false),
+		},
+		Operation: &OperationRules{
+			OperationID: rule(false, false, false),
+			Security:    rule(false, false, false),
+			Servers:     rule(false, false, false),
+		},
+		Server: &ServerRules{
+			Name: rule(false, false, false),
+			URL:  rule(false, false, false),
+		},
+		ServerVariable: &ServerVariableRules{
+			Enum:    rule(false, false, false),
+			Default: rule(false, false, false),
+		},
+		SecurityScheme: &SecuritySchemeRules{
+			Type:             rule(false, false, false),
+			Name:             rule(false, false, false),
+			In:               rule(false, false, false),
+			Scheme:           rule(false, false, false),
+			Flows:            rule(false, false, false),
+			Scopes:           rule(false, false, false),
+			Flow:             rule(false, false, false),
+			AuthorizationURL: rule(false, false, false),
+			TokenURL:         rule(false, false, false),
+		},
+		SecurityRequirement: &SecurityRequirementRules{
+			Schemes: rule(false, false, false),
+			Scopes:  rule(false, false, false),
+		},
+		OAuthFlow: &OAuthFlowRules{
+			AuthorizationURL: rule(false, false, false),
+			TokenURL:         rule(false, false, false),
+			RefreshURL:       rule(false, false, false),
+			Scopes:           rule(false, false, false),
+		},
+	})
+	return config
+}
@@ -0,0 +1,53 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakingRulesPresets(t *testing.T) {
+	assert.Equal(t, []string{PresetInternalAPI, PresetLenient, PresetStrictConsumer}, BreakingRulesPresets())
+}
+
+func TestBreakingRulesPreset(t *testing.T) {
+	defaults := GenerateDefaultBreakingRules()
+
+	strict, err := BreakingRulesPreset(PresetStrictConsumer)
+	require.NoError(t, err)
+	assert.True(t, strict.IsBreaking(CompSchema, PropMaxLength, ChangeTypeAdded))
+	assert.True(t, strict.IsBreaking(CompSchema, PropEnum, ChangeTypeAdded))
+	assert.True(t, strict.IsBreaking(CompSecurity, "", ChangeTypeAdded))
+	// rules the preset does not set are the defaults.
+	assert.True(t, strict.IsBreaking(CompPaths, PropPath, ChangeTypeRemoved))
+
+	lenient, err := BreakingRulesPreset(" Lenient ")
+	require.NoError(t, err)
+	assert.False(t, lenient.IsBreaking(CompSchema, PropFormat, ChangeTypeModified))
+	assert.False(t, lenient.IsBreaking(CompSchema, PropMaxLength, ChangeTypeModified))
+	assert.True(t, lenient.IsBreaking(CompSchema, PropType, ChangeTypeModified))
+	assert.True(t, lenient.IsBreaking(CompOperation, PropOperationID, ChangeTypeModified))
+
+	internal, err := BreakingRulesPreset(PresetInternalAPI)
+	require.NoError(t, err)
+	assert.False(t, internal.IsBreaking(CompSchema, PropFormat, ChangeTypeModified))
+	assert.False(t, internal.IsBreaking(CompOperation, PropOperationID, ChangeTypeModified))
+	assert.False(t, internal.IsBreaking(CompServer, PropURL, ChangeTypeModified))
+	assert.True(t, internal.IsBreaking(CompPathItem, PropGet, ChangeTypeRemoved))
+
+	// the defaults are not changed by the presets.
+	assert.False(t, defaults.IsBreaking(CompSchema, PropMaxLength, ChangeTypeAdded))
+	assert.True(t, defaults.IsBreaking(CompSchema, PropFormat, ChangeTypeModified))
+	assert.True(t, defaults.IsBreaking(CompOperation, PropOperationID, ChangeTypeModified))
+}
+
+func TestBreakingRulesPreset_Unknown(t *testing.T) {
+	config, err := BreakingRulesPreset("chaotic")
+	assert.Nil(t, config)
+	assert.EqualError(t, err,
+		"unknown breaking rules preset 'chaotic', expected one of internal-api, lenient, strict-consumer")
+}
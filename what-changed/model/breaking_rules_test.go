@@ -96,6 +96,29 @@ func TestDefaultBreakingRules_Operation(t *testing.T) {
 	assert.True(t, *config.Operation.RequestBody.Removed)
 }
 
+func TestDefaultBreakingRules_Enum(t *testing.T) {
+	config := GenerateDefaultBreakingRules()
+
+	// adding enum values to parameters and headers is not breaking, removing them is.
+	for _, r := range []*BreakingChangeRule{config.Parameter.Enum, config.Header.Enum} {
+		assert.False(t, *r.Added)
+		assert.False(t, *r.Modified)
+		assert.True(t, *r.Removed)
+	}
+	assert.True(t, BreakingRemoved(CompParameter, PropEnum))
+	assert.False(t, BreakingAdded(CompHeader, PropEnum))
+}
+
+func TestDefaultBreakingRules_Renamed(t *testing.T) {
+	config := GenerateDefaultBreakingRules()
+
+	// renaming a component is breaking, as anything referencing the old name can no longer find it.
+	assert.False(t, *config.Renamed.Added)
+	assert.True(t, *config.Renamed.Modified)
+	assert.False(t, *config.Renamed.Removed)
+	assert.True(t, BreakingModified(CompRenamed, ""))
+}
+
 func TestMerge_NilOverride(t *testing.T) {
 	ResetDefaultBreakingRules()
 	defer ResetDefaultBreakingRules()
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -63,6 +65,10 @@ func (c *CallbackChanges) TotalBreakingChanges() int {
 // CompareCallback will compare two Callback objects and return a pointer to CallbackChanges with all the things
 // that have changed between them. Handles nil inputs for added/removed callback scenarios.
 func CompareCallback(l, r *v3.Callback) *CallbackChanges {
+	return compareCallback(context.Background(), l, r)
+}
+
+func compareCallback(ctx context.Context, l, r *v3.Callback) *CallbackChanges {
 	cc := new(CallbackChanges)
 	var changes []*Change
 
@@ -75,12 +81,12 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 		expChanges := make(map[string]*PathItemChanges)
 		for k, v := range r.Expression.FromOldest() {
 			CreateChange(&changes, ObjectAdded, k.Value,
-				nil, v.GetValueNode(), BreakingAdded(CompOperation, PropCallbacks),
+				nil, v.GetValueNode(), breakingAdded(ctx, CompOperation, PropCallbacks),
 				nil, v.GetValue())
 		}
 		cc.ExpressionChanges = expChanges
-		cc.ExtensionChanges = CompareExtensions(nil, r.Extensions)
-		cc.PropertyChanges = NewPropertyChanges(changes)
+		cc.ExtensionChanges = compareExtensions(ctx, nil, r.Extensions)
+		cc.PropertyChanges = newPropertyChanges(ctx, changes)
 		if cc.TotalChanges() <= 0 {
 			return nil
 		}
@@ -92,12 +98,12 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 		expChanges := make(map[string]*PathItemChanges)
 		for k, v := range l.Expression.FromOldest() {
 			CreateChange(&changes, ObjectRemoved, k.Value,
-				v.GetValueNode(), nil, BreakingRemoved(CompOperation, PropCallbacks),
+				v.GetValueNode(), nil, breakingRemoved(ctx, CompOperation, PropCallbacks),
 				v.GetValue(), nil)
 		}
 		cc.ExpressionChanges = expChanges
-		cc.ExtensionChanges = CompareExtensions(l.Extensions, nil)
-		cc.PropertyChanges = NewPropertyChanges(changes)
+		cc.ExtensionChanges = compareExtensions(ctx, l.Extensions, nil)
+		cc.PropertyChanges = newPropertyChanges(ctx, changes)
 		if cc.TotalChanges() <= 0 {
 			return nil
 		}
@@ -128,7 +134,7 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 		rhash := rHashes[k]
 		if rhash == "" {
 			CreateChange(&changes, ObjectRemoved, k,
-				lValues[k].GetValueNode(), nil, BreakingRemoved(CompCallback, PropExpressions),
+				lValues[k].GetValueNode(), nil, breakingRemoved(ctx, CompCallback, PropExpressions),
 				lValues[k].GetValue(), nil)
 			continue
 		}
@@ -136,7 +142,7 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 			continue
 		}
 		// run comparison.
-		expChanges[k] = ComparePathItems(lValues[k].Value, rValues[k].Value)
+		expChanges[k] = comparePathItems(ctx, lValues[k].Value, rValues[k].Value)
 	}
 
 	// check right path item hashes
@@ -144,14 +150,15 @@ func CompareCallback(l, r *v3.Callback) *CallbackChanges {
 		lhash := lHashes[k]
 		if lhash == "" {
 			CreateChange(&changes, ObjectAdded, k,
-				nil, rValues[k].GetValueNode(), BreakingAdded(CompCallback, PropExpressions),
+				nil, rValues[k].GetValueNode(), breakingAdded(ctx, CompCallback, PropExpressions),
 				nil, rValues[k].GetValue())
 			continue
 		}
 	}
 	cc.ExpressionChanges = expChanges
-	cc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
-	cc.PropertyChanges = NewPropertyChanges(changes)
+	cc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
+	checkComparators(l, r, &changes)
+	cc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if cc.TotalChanges() <= 0 {
 		return nil
 	}
@@ -0,0 +1,273 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// ChangeReportVersion is the version of the JSON shape of a ChangeReport. It is only changed when the shape is, so
+// stored reports can always be read and compared.
+const ChangeReportVersion = "1.0"
+
+// ChangeReport is a flat list of every change made to an object (and to everything below it), each with the path of
+// the object that was changed, so reports are simple to store, and to compare over time. Reports are created from
+// any *Changes model with NewChangeReport, the models themselves keep their own JSON shape. A report is serialized as:
+//
+//	{
+//	  "version": "1.0",
+//	  "totalChanges": 2,
+//	  "breakingChanges": 1,
+//	  "changes": [
+//	    {
+//	      "id": "0c5b6f3e2a19d874",
+//	      "path": "$.paths.pathItems['/pets'].get.parameters.limit",
+//	      "property": "required",
+//	      "change": 1,
+//	      "changeText": "modified",
+//	      "original": "false",
+//	      "new": "true",
+//	      "breaking": true,
+//	      "severity": "error",
+//	      "originalLine": 12, "originalColumn": 11, "newLine": 14, "newColumn": 11
+//	    }
+//	  ]
+//	}
+//
+// The path is a JSON path through the tree of changes, made of the JSON names of the change models and the keys of
+// their maps. Lists of changes have no index, except for parameters, which are keyed by name. The totals count every
+// change listed.
+type ChangeReport struct {
+	Version         string               `json:"version"`
+	TotalChanges    int                  `json:"totalChanges"`
+	BreakingChanges int                  `json:"breakingChanges"`
+	Changes         []*ChangeReportEntry `json:"changes"`
+}
+
+// ChangeReportEntry is a single change of a ChangeReport.
+type ChangeReportEntry struct {
+	ID              string `json:"id,omitempty"`
+	Path            string `json:"path"`
+	Property        string `json:"property"`
+	ChangeType      int    `json:"change"`
+	ChangeText      string `json:"changeText"`
+	Original        string `json:"original,omitempty"`
+	New             string `json:"new,omitempty"`
+	OriginalEncoded string `json:"originalEncoded,omitempty"`
+	NewEncoded      string `json:"newEncoded,omitempty"`
+	Breaking        bool   `json:"breaking"`
+	Severity        string `json:"severity,omitempty"`
+	Reference       string `json:"reference,omitempty"`
+	Document        string `json:"document,omitempty"`
+	OriginalLine    *int   `json:"originalLine,omitempty"`
+	OriginalColumn  *int   `json:"originalColumn,omitempty"`
+	NewLine         *int   `json:"newLine,omitempty"`
+	NewColumn       *int   `json:"newColumn,omitempty"`
+}
+
+// NewChangeReport creates a ChangeReport for any *Changes model.
+func NewChangeReport(changes any) *ChangeReport {
+	report := &ChangeReport{Version: ChangeReportVersion, Changes: []*ChangeReportEntry{}}
+	for _, lc := range LocateChanges(changes) {
+		c := lc.Change
+		entry := &ChangeReportEntry{
+			ID:              c.ID,
+			Path:            ChangePath(lc.Location),
+			Property:        c.Property,
+			ChangeType:      c.ChangeType,
+			ChangeText:      ChangeTypeText(c.ChangeType),
+			Original:        c.Original,
+			New:             c.New,
+			OriginalEncoded: c.OriginalEncoded,
+			NewEncoded:      c.NewEncoded,
+			Breaking:        c.Breaking,
+			Severity:        c.GetSeverity().String(),
+			Reference:       c.Reference,
+		}
+		if c.Context != nil {
+			entry.Document = c.Context.DocumentLocation
+			entry.OriginalLine, entry.OriginalColumn = c.Context.OriginalLine, c.Context.OriginalColumn
+			entry.NewLine, entry.NewColumn = c.Context.NewLine, c.Context.NewColumn
+		}
+		report.Changes = append(report.Changes, entry)
+		if c.Breaking {
+			report.BreakingChanges++
+		}
+	}
+	report.TotalChanges = len(report.Changes)
+	return report
+}
+
+// LocatedChange is a change, and the location of the object it was made to in a tree of changes. The location is
+// made of the JSON names of the change models and the keys of their maps, for example 'paths', 'pathItems', '/pets',
+// 'get'.
+type LocatedChange struct {
+	Location []string
+	Change   *Change
+
+	// owner is the type of the change model holding the change, for example SchemaChanges.
+	owner reflect.Type
+}
+
+var (
+	modelPackage        = reflect.TypeOf(DocumentChanges{}).PkgPath()
+	propertyChangesType = reflect.TypeOf(PropertyChanges{})
+	changePointerType   = reflect.TypeOf(&Change{})
+	plainPathSegment    = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$-]*$`)
+)
+
+// LocateChanges walks any tree of changes and returns every change found, with its location. Changes are returned in
+// the order of the tree, with map keys sorted, so the result is always the same for the same changes.
+func LocateChanges(changes any) []*LocatedChange {
+	var located []*LocatedChange
+	walkChanges(reflect.ValueOf(changes), nil, nil, &located, make(map[uintptr]struct{}))
+	return located
+}
+
+// ChangePath returns the JSON path of a location in a tree of changes, using bracket notation for keys that are not
+// plain names, for example "$.paths.pathItems['/pets'].get".
+func ChangePath(location []string) string {
+	var b strings.Builder
+	b.WriteString("$")
+	for _, segment := range location {
+		if plainPathSegment.MatchString(segment) {
+			b.WriteString("." + segment)
+			continue
+		}
+		b.WriteString("['" + strings.ReplaceAll(segment, "'", "\\'") + "']")
+	}
+	return b.String()
+}
+
+func walkChanges(v reflect.Value, location []string, owner reflect.Type, located *[]*LocatedChange,
+	seen map[uintptr]struct{},
+) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.Type() == changePointerType {
+			*located = append(*located, &LocatedChange{Location: location, Change: v.Interface().(*Change), owner: owner})
+			return
+		}
+		if _, ok := seen[v.Pointer()]; ok {
+			return
+		}
+		seen[v.Pointer()] = struct{}{}
+		walkChanges(v.Elem(), location, owner, located, seen)
+	case reflect.Struct:
+		if v.Type().PkgPath() != modelPackage {
+			return
+		}
+		if v.Type() == propertyChangesType {
+			for _, c := range v.Addr().Interface().(*PropertyChanges).Changes {
+				if c != nil {
+					*located = append(*located, &LocatedChange{Location: location, Change: c, owner: owner})
+				}
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if field.Anonymous {
+				walkChanges(v.Field(i), location, v.Type(), located, seen)
+				continue
+			}
+			if name := jsonFieldName(field); name != "" {
+				walkChanges(v.Field(i), appendLocation(location, name), v.Type(), located, seen)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			item := v.Index(i)
+			// parameters are named, anything else in a list has no name of its own.
+			if name := changesName(item); name != "" {
+				walkChanges(item, appendLocation(location, name), owner, located, seen)
+				continue
+			}
+			walkChanges(item, location, owner, located, seen)
+		}
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		for _, key := range keys {
+			walkChanges(v.MapIndex(key), appendLocation(location, key.String()), owner, located, seen)
+		}
+	case reflect.Interface:
+		if !v.IsNil() {
+			walkChanges(v.Elem(), location, owner, located, seen)
+		}
+	}
+}
+
+// jsonFieldName returns the JSON name of a field, or an empty string if the field is not serialized.
+func jsonFieldName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return field.Name
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// changesName returns the value of the Name field of a change model, if it has one.
+func changesName(v reflect.Value) string {
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return ""
+	}
+	if name := v.FieldByName("Name"); name.IsValid() && name.Kind() == reflect.String {
+		return name.String()
+	}
+	return ""
+}
+
+// appendLocation returns a new location with a segment added, so locations never share their backing arrays.
+func appendLocation(location []string, segment string) []string {
+	l := make([]string, len(location), len(location)+1)
+	copy(l, location)
+	return append(l, segment)
+}
+
+// IsRequestLocation determines if a location in a tree of changes (see LocateChanges) is part of a request (true)
+// or a response (false). Requests sent by callbacks and webhooks are sent by the provider of an API, so they are
+// treated as responses, and the other way around. If the location is neither, or it isn't known (like a schema in
+// the components of a document), known is false.
+func IsRequestLocation(location []string) (request, known bool) {
+	inverted := false
+	for _, segment := range location {
+		switch segment {
+		case v3.SchemasLabel:
+			// anything below a schema is a property name.
+			return request != inverted, known
+		case v3.CallbacksLabel, v3.WebhooksLabel:
+			inverted = !inverted
+		case v3.ResponsesLabel:
+			request, known = false, true
+		case v3.RequestBodiesLabel, v3.ParametersLabel:
+			request, known = true, true
+		}
+	}
+	return request != inverted, known
+}
@@ -0,0 +1,177 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func compareReportDocuments(t *testing.T, left, right string) *DocumentChanges {
+	return compareReportDocumentsWithOptions(t, nil, left, right)
+}
+
+func compareReportDocumentsWithOptions(t *testing.T, options *ComparisonOptions, left, right string) *DocumentChanges {
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	return CompareDocumentsWithContext(WithComparisonOptions(context.Background(), options), build(left), build(right))
+}
+
+func TestNewChangeReport(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: false
+      responses:
+        '200':
+          description: pets`
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          required: true
+      responses:
+        '200':
+          description: all the pets`
+
+	changes := compareReportDocuments(t, left, right)
+	require.NotNil(t, changes)
+
+	b, err := json.Marshal(NewChangeReport(changes))
+	require.NoError(t, err)
+
+	var report ChangeReport
+	require.NoError(t, json.Unmarshal(b, &report))
+	assert.Equal(t, ChangeReportVersion, report.Version)
+	assert.Equal(t, 3, report.TotalChanges)
+	assert.Equal(t, 1, report.BreakingChanges)
+
+	paths := map[string]*ChangeReportEntry{}
+	for _, entry := range report.Changes {
+		paths[entry.Path+"#"+entry.Property] = entry
+	}
+	require.Contains(t, paths, "$.info#version")
+	required := paths["$.paths.pathItems['/pets'].get.parameters.limit#required"]
+	require.NotNil(t, required)
+	assert.Equal(t, Modified, required.ChangeType)
+	assert.Equal(t, "modified", required.ChangeText)
+	assert.Equal(t, "false", required.Original)
+	assert.Equal(t, "true", required.New)
+	assert.True(t, required.Breaking)
+	require.NotNil(t, required.OriginalLine)
+	assert.Equal(t, 11, *required.OriginalLine)
+	require.NotNil(t, required.NewColumn)
+	assert.Equal(t, 21, *required.NewColumn)
+	assert.Contains(t, paths, "$.paths.pathItems['/pets'].get.responses.response['200']#description")
+
+	// reports of nested change models list the changes below them, in the same shape.
+	b, err = json.Marshal(NewChangeReport(changes.PathsChanges.PathItemsChanges["/pets"].GetChanges))
+	require.NoError(t, err)
+	var operation ChangeReport
+	require.NoError(t, json.Unmarshal(b, &operation))
+	assert.Equal(t, 2, operation.TotalChanges)
+	assert.Equal(t, "$.parameters.limit", operation.Changes[0].Path)
+
+	// the same changes are always reported the same way.
+	first, _ := json.Marshal(NewChangeReport(changes))
+	again, _ := json.Marshal(NewChangeReport(changes))
+	assert.Equal(t, string(first), string(again))
+
+	// the change models keep their own shape.
+	b, err = json.Marshal(changes)
+	require.NoError(t, err)
+	var model map[string]any
+	require.NoError(t, json.Unmarshal(b, &model))
+	assert.Contains(t, model, "paths")
+	assert.NotContains(t, model, "version")
+}
+
+func TestChangeReport_Empty(t *testing.T) {
+	var changes *DocumentChanges
+	b, err := json.Marshal(NewChangeReport(changes))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"1.0","totalChanges":0,"breakingChanges":0,"changes":[]}`, string(b))
+
+	b, err = json.Marshal(NewChangeReport(&InfoChanges{PropertyChanges: NewPropertyChanges(nil)}))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"version":"1.0","totalChanges":0,"breakingChanges":0,"changes":[]}`, string(b))
+}
+
+func TestChangePath(t *testing.T) {
+	assert.Equal(t, "$", ChangePath(nil))
+	assert.Equal(t, "$.components.schemas.Pet", ChangePath([]string{"components", "schemas", "Pet"}))
+	assert.Equal(t, "$.content['application/json']['it\\'s']", ChangePath([]string{"content", "application/json", "it's"}))
+}
+
+func TestLocateChanges(t *testing.T) {
+	line := 1
+	added := &Change{ChangeType: PropertyAdded, Property: "x-pet", New: "dog", Context: &ChangeContext{NewLine: &line}}
+	changes := &MediaTypeChanges{
+		PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Property: "example"}}),
+		ExtensionChanges: &ExtensionChanges{
+			PropertyChanges: NewPropertyChanges([]*Change{added}),
+		},
+		EncodingChanges: map[string]*EncodingChanges{
+			"b": {PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Property: "style"}})},
+			"a": {PropertyChanges: NewPropertyChanges([]*Change{{ChangeType: Modified, Property: "style"}})},
+		},
+	}
+	located := LocateChanges(changes)
+	require.Len(t, located, 4)
+	assert.Empty(t, located[0].Location)
+	assert.Equal(t, []string{"extensions"}, located[1].Location)
+	assert.Same(t, added, located[1].Change)
+	assert.Equal(t, []string{"encoding", "a"}, located[2].Location)
+	assert.Equal(t, []string{"encoding", "b"}, located[3].Location)
+
+	assert.Empty(t, LocateChanges(nil))
+	assert.Empty(t, LocateChanges(low.NodeReference[string]{}))
+}
+
+func TestIsRequestLocation(t *testing.T) {
+	tests := []struct {
+		location []string
+		request  bool
+		known    bool
+	}{
+		{[]string{"paths", "pathItems", "/pets", "post", "requestBodies", "content", "application/json", "schemas"}, true, true},
+		{[]string{"paths", "pathItems", "/pets", "get", "parameters", "kind"}, true, true},
+		{[]string{"paths", "pathItems", "/pets", "get", "responses", "response", "200", "headers", "X-Rate"}, false, true},
+		{[]string{"paths", "pathItems", "/pets", "post", "callbacks", "adopted", "expressions", "{$url}", "post",
+			"requestBodies"}, false, true},
+		{[]string{"webhooks", "adopted", "post", "responses", "response", "200"}, true, true},
+		{[]string{"paths", "pathItems", "/pets", "get", "responses", "response", "200", "content", "application/json",
+			"schemas", "properties", "parameters"}, false, true},
+		{[]string{"components", "schemas", "Pet"}, false, false},
+	}
+	for _, tt := range tests {
+		request, known := IsRequestLocation(tt.location)
+		assert.Equal(t, tt.request, request, tt.location)
+		assert.Equal(t, tt.known, known, tt.location)
+	}
+}
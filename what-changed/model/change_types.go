@@ -26,6 +26,18 @@ const (
 
 	// PropertyRemoved means that a property of an object was removed
 	PropertyRemoved
+
+	// ObjectRenamed means that an object was removed, and added again with identical content under a different name.
+	// Renames are only detected when enabled (see ComparisonOptions).
+	ObjectRenamed
+
+	// ReferenceRepointed means that a reference ($ref) was changed to point somewhere else. ResolvedEqual determines
+	// if the new target is structurally identical to the original target.
+	ReferenceRepointed
+
+	// Reordered means that the items of an array were moved around. It's only reported for arrays that are
+	// compared in order (see ComparisonOptions).
+	Reordered
 )
 
 // WhatChanged is a summary object that contains a high level summary of everything changed.
@@ -97,9 +109,33 @@ type Change struct {
 	// Only populated for specific use cases (e.g., extension values that are objects/arrays).
 	NewEncoded string `json:"newEncoded,omitempty" yaml:"newEncoded,omitempty"`
 
+	// ID is the deterministic ID of the change, derived from where it was made (see ChangeID). It's set when
+	// documents are compared, and can be used to accept known changes (see Baseline).
+	ID string `json:"id,omitempty" yaml:"id,omitempty"`
+
 	// Breaking determines if the change is a breaking one or not.
 	Breaking bool `json:"breaking" yaml:"breaking"`
 
+	// Severity is how much the change matters. When not set, the severity is derived from the default
+	// SeverityMapping (see GetSeverity).
+	Severity Severity `json:"severity,omitempty" yaml:"severity,omitempty"`
+
+	// ResolvedEqual is only used by ReferenceRepointed changes, and determines if what the original and new
+	// references point to is structurally identical. When it is, the change is not breaking.
+	ResolvedEqual bool `json:"resolvedEqual,omitempty" yaml:"resolvedEqual,omitempty"`
+
+	// Explanation is a short, human-readable explanation of a breaking change, and what it means for the clients of
+	// an API. It's only set for breaking changes (see ExplainChange).
+	Explanation string `json:"explanation,omitempty" yaml:"explanation,omitempty"`
+
+	// OriginalSnippet is an excerpt of the original document around the original line of the change. It's only set
+	// once snippets are attached (see AttachSnippets).
+	OriginalSnippet *Snippet `json:"originalSnippet,omitempty" yaml:"originalSnippet,omitempty"`
+
+	// NewSnippet is an excerpt of the new document around the new line of the change. It's only set once snippets
+	// are attached (see AttachSnippets).
+	NewSnippet *Snippet `json:"newSnippet,omitempty" yaml:"newSnippet,omitempty"`
+
 	// OriginalObject represents the original object that was changed.
 	OriginalObject any `json:"-" yaml:"-"`
 
@@ -114,28 +150,55 @@ type Change struct {
 
 	// Reference is populated when the change is related to a $ref change.
 	Reference string `json:"reference,omitempty"`
+
+	// inverted holds the classification of the change before it was last inverted (see InvertChanges).
+	inverted *inversion
 }
 
-// MarshalJSON is a custom JSON marshaller for the Change object.
-func (c *Change) MarshalJSON() ([]byte, error) {
-	changeType := ""
-	switch c.ChangeType {
+// ChangeTypeText returns the name of a type of change, for example 'property_added'.
+func ChangeTypeText(changeType int) string {
+	switch changeType {
 	case Modified:
-		changeType = "modified"
+		return "modified"
 	case PropertyAdded:
-		changeType = "property_added"
+		return "property_added"
 	case ObjectAdded:
-		changeType = "object_added"
+		return "object_added"
 	case ObjectRemoved:
-		changeType = "object_removed"
+		return "object_removed"
 	case PropertyRemoved:
-		changeType = "property_removed"
+		return "property_removed"
+	case ObjectRenamed:
+		return "object_renamed"
+	case ReferenceRepointed:
+		return "reference_repointed"
+	case Reordered:
+		return "reordered"
 	}
+	return ""
+}
+
+// GetSeverity returns the severity of the change. If a severity has not been set, it's derived from the
+// default SeverityMapping.
+func (c *Change) GetSeverity() Severity {
+	if c.Severity != 0 {
+		return c.Severity
+	}
+	return defaultSeverityMapping.SeverityOf(c)
+}
+
+// MarshalJSON is a custom JSON marshaller for the Change object.
+func (c *Change) MarshalJSON() ([]byte, error) {
 	data := map[string]interface{}{
 		"change":     c.ChangeType,
-		"changeText": changeType,
+		"changeText": ChangeTypeText(c.ChangeType),
 		"property":   c.Property,
 		"breaking":   c.Breaking,
+		"severity":   c.GetSeverity().String(),
+	}
+
+	if c.ID != "" {
+		data["id"] = c.ID
 	}
 
 	if c.Original != "" {
@@ -154,9 +217,23 @@ func (c *Change) MarshalJSON() ([]byte, error) {
 		data["newEncoded"] = c.NewEncoded
 	}
 
+	if c.ChangeType == ReferenceRepointed {
+		data["resolvedEqual"] = c.ResolvedEqual
+	}
+
+	if c.Explanation != "" {
+		data["explanation"] = c.Explanation
+	}
+
 	if c.Context != nil {
 		data["context"] = c.Context
 	}
+	if c.OriginalSnippet != nil {
+		data["originalSnippet"] = c.OriginalSnippet
+	}
+	if c.NewSnippet != nil {
+		data["newSnippet"] = c.NewSnippet
+	}
 	if c.Type != "" {
 		data["type"] = c.Type
 	}
@@ -56,6 +56,27 @@ func TestChange_MarshalJSON(t *testing.T) {
 	assert.Equal(t, "property_removed", rebuilt["changeText"])
 	assert.Equal(t, float64(5), rebuilt["change"])
 
+	change = Change{
+		ChangeType: ReferenceRepointed,
+	}
+	rebuilt = rinseAndRepeat(&change)
+	assert.Equal(t, "reference_repointed", rebuilt["changeText"])
+	assert.Equal(t, float64(7), rebuilt["change"])
+	assert.Equal(t, false, rebuilt["resolvedEqual"])
+
+	change = Change{
+		ChangeType:    ReferenceRepointed,
+		ResolvedEqual: true,
+	}
+	rebuilt = rinseAndRepeat(&change)
+	assert.Equal(t, true, rebuilt["resolvedEqual"])
+
+	change = Change{
+		ChangeType: Modified,
+	}
+	rebuilt = rinseAndRepeat(&change)
+	assert.NotContains(t, rebuilt, "resolvedEqual")
+
 	change = Change{
 		Original: "gangster",
 	}
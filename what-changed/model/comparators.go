@@ -0,0 +1,90 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// ExtensionComparator compares the values of an extension (for example `x-rate-limit`), that exists in both the
+// left and right objects, and returns the changes made. CreateChange can be used to create the changes. The name
+// of the extension is always lower case.
+type ExtensionComparator func(name string, l, r *yaml.Node) []*Change
+
+var (
+	comparatorsLock      sync.RWMutex
+	comparators          = make(map[reflect.Type]func(l, r any) []*Change)
+	extensionComparators = make(map[string]ExtensionComparator)
+)
+
+// RegisterComparator registers a custom compare function for a type of low-level object (for example
+// base.Schema or v3.Operation), so an organization can encode its own semantics. The function is called with the
+// left and right objects whenever both exist and are compared, and the changes it returns are added to the
+// changes of the object, after the built-in comparison. Registering a function for a type replaces any function
+// already registered for it.
+//
+// Comparators are global, and are used by every comparison. They should be registered before comparing documents.
+func RegisterComparator[T any](compare func(l, r *T) []*Change) {
+	comparatorsLock.Lock()
+	defer comparatorsLock.Unlock()
+	comparators[reflect.TypeOf((*T)(nil))] = func(l, r any) []*Change {
+		return compare(l.(*T), r.(*T))
+	}
+}
+
+// UnregisterComparator removes the custom compare function registered for a type of low-level object.
+func UnregisterComparator[T any]() {
+	comparatorsLock.Lock()
+	defer comparatorsLock.Unlock()
+	delete(comparators, reflect.TypeOf((*T)(nil)))
+}
+
+// RegisterExtensionComparator registers a custom compare function for an extension (for example `x-rate-limit`),
+// which replaces the built-in comparison of its values. Extensions being added or removed are still reported as
+// normal. Registering a function for an extension replaces any function already registered for it.
+//
+// Comparators are global, and are used by every comparison. They should be registered before comparing documents.
+func RegisterExtensionComparator(name string, compare ExtensionComparator) {
+	comparatorsLock.Lock()
+	defer comparatorsLock.Unlock()
+	extensionComparators[strings.ToLower(name)] = compare
+}
+
+// UnregisterExtensionComparator removes the custom compare function registered for an extension.
+func UnregisterExtensionComparator(name string) {
+	comparatorsLock.Lock()
+	defer comparatorsLock.Unlock()
+	delete(extensionComparators, strings.ToLower(name))
+}
+
+// checkComparators calls the custom compare function registered for the type of the left and right objects, if
+// there is one, adding the changes it returns.
+func checkComparators(l, r any, changes *[]*Change) {
+	if l == nil || r == nil {
+		return
+	}
+	comparatorsLock.RLock()
+	compare := comparators[reflect.TypeOf(l)]
+	comparatorsLock.RUnlock()
+	if compare == nil || reflect.TypeOf(r) != reflect.TypeOf(l) ||
+		reflect.ValueOf(l).IsNil() || reflect.ValueOf(r).IsNil() {
+		return
+	}
+	if custom := compare(l, r); len(custom) > 0 {
+		changeMutex.Lock()
+		*changes = append(*changes, custom...)
+		changeMutex.Unlock()
+	}
+}
+
+// extensionComparator returns the custom compare function registered for an extension, if there is one.
+func extensionComparator(name string) ExtensionComparator {
+	comparatorsLock.RLock()
+	defer comparatorsLock.RUnlock()
+	return extensionComparators[name]
+}
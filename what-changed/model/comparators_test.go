@@ -0,0 +1,125 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+// requestsPerMinute reads a rate limit like `100/min` or `6000/hour`.
+func requestsPerMinute(limit string) int {
+	count, unit, _ := strings.Cut(limit, "/")
+	n, _ := strconv.Atoi(count)
+	if unit == "hour" {
+		return n / 60
+	}
+	return n
+}
+
+func TestRegisterExtensionComparator(t *testing.T) {
+	low.ClearHashCache()
+	RegisterExtensionComparator("X-Rate-Limit", func(name string, l, r *yaml.Node) []*Change {
+		var changes []*Change
+		if lLimit, rLimit := requestsPerMinute(l.Value), requestsPerMinute(r.Value); lLimit != rLimit {
+			CreateChange(&changes, Modified, name, l, r, rLimit < lLimit, l.Value, r.Value)
+		}
+		return changes
+	})
+	t.Cleanup(func() { UnregisterExtensionComparator("x-rate-limit") })
+
+	compare := func(left, right string) *ExtensionChanges {
+		var lNode, rNode yaml.Node
+		_ = yaml.Unmarshal([]byte(left), &lNode)
+		_ = yaml.Unmarshal([]byte(right), &rNode)
+		return CompareExtensions(low.ExtractExtensions(lNode.Content[0]), low.ExtractExtensions(rNode.Content[0]))
+	}
+
+	// the same limit, written differently.
+	assert.Nil(t, compare(`x-rate-limit: 100/min`, `x-rate-limit: 6000/hour`))
+
+	changes := compare(`x-rate-limit: 100/min`, `x-rate-limit: 50/min`)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	assert.Equal(t, "50/min", changes.Changes[0].New)
+
+	// additions and removals are reported as normal, as are other extensions.
+	changes = compare(`x-rate-limit: 100/min`, `x-burger: yes`)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+
+	UnregisterExtensionComparator("X-RATE-LIMIT")
+	assert.Equal(t, 1, compare(`x-rate-limit: 100/min`, `x-rate-limit: 6000/hour`).TotalChanges())
+}
+
+func TestRegisterComparator(t *testing.T) {
+	low.ClearHashCache()
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: string
+      format: uuid`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      type: string
+      format: UUID`
+
+	RegisterComparator(func(l, r *base.Schema) []*Change {
+		var changes []*Change
+		// formats are case-sensitive for us.
+		if l.Format.Value != r.Format.Value && strings.EqualFold(l.Format.Value, r.Format.Value) {
+			CreateChange(&changes, Modified, "formatCase", l.Format.ValueNode, r.Format.ValueNode, true,
+				l.Format.Value, r.Format.Value)
+		}
+		return changes
+	})
+	t.Cleanup(UnregisterComparator[base.Schema])
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	require.NotNil(t, changes)
+	properties := make(map[string]*Change)
+	for _, c := range changes.Changes {
+		properties[c.Property] = c
+	}
+	require.Len(t, properties, 2)
+	assert.Equal(t, "UUID", properties[v3.FormatLabel].New)
+	assert.True(t, properties["formatCase"].Breaking)
+
+	UnregisterComparator[base.Schema]()
+	low.ClearHashCache()
+	assert.Equal(t, 1, CompareSchemas(lSchemaProxy, rSchemaProxy).TotalChanges())
+}
+
+func TestCheckComparators(t *testing.T) {
+	RegisterComparator(func(l, r *v3.Server) []*Change {
+		return []*Change{{ChangeType: Modified, Property: "custom"}}
+	})
+	t.Cleanup(UnregisterComparator[v3.Server])
+
+	var changes []*Change
+	checkComparators(&v3.Server{}, nil, &changes)
+	checkComparators((*v3.Server)(nil), &v3.Server{}, &changes)
+	checkComparators(&v3.Server{}, &v3.ServerVariable{}, &changes)
+	checkComparators(&v3.ServerVariable{}, &v3.ServerVariable{}, &changes)
+	assert.Empty(t, changes)
+
+	checkComparators(&v3.Server{}, &v3.Server{}, &changes)
+	assert.Len(t, changes, 1)
+}
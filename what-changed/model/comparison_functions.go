@@ -4,8 +4,11 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -68,6 +71,15 @@ func checkLocation(ctx *ChangeContext, hs base.HasIndex) bool {
 func CreateChange(changes *[]*Change, changeType int, property string, leftValueNode, rightValueNode *yaml.Node,
 	breaking bool, originalObject, newObject any,
 ) *[]*Change {
+	recordChange(changes, newChange(changeType, property, leftValueNode, rightValueNode, breaking,
+		originalObject, newObject))
+	return changes
+}
+
+// newChange creates a Change from the left and right value nodes and objects.
+func newChange(changeType int, property string, leftValueNode, rightValueNode *yaml.Node,
+	breaking bool, originalObject, newObject any,
+) *Change {
 	// create a new context for the left and right nodes.
 	ctx := CreateContext(leftValueNode, rightValueNode)
 	c := &Change{
@@ -114,12 +126,7 @@ func CreateChange(changes *[]*Change, changeType int, property string, leftValue
 	// original and new objects
 	c.OriginalObject = originalObject
 	c.NewObject = newObject
-
-	// add the change to supplied changes slice
-	changeMutex.Lock()
-	*changes = append(*changes, c)
-	changeMutex.Unlock()
-	return changes
+	return c
 }
 
 // CreateChangeWithEncoding is like CreateChange but also populates the encoded fields for complex values.
@@ -128,9 +135,7 @@ func CreateChange(changes *[]*Change, changeType int, property string, leftValue
 func CreateChangeWithEncoding(changes *[]*Change, changeType int, property string, leftValueNode, rightValueNode *yaml.Node,
 	breaking bool, originalObject, newObject any,
 ) *[]*Change {
-	CreateChange(changes, changeType, property, leftValueNode, rightValueNode, breaking, originalObject, newObject)
-
-	c := (*changes)[len(*changes)-1]
+	c := newChange(changeType, property, leftValueNode, rightValueNode, breaking, originalObject, newObject)
 
 	// serialize complex values to YAML for extension rendering (avoid inflating memory for scalar values)
 	if leftValueNode != nil && (utils.IsNodeArray(leftValueNode) || utils.IsNodeMap(leftValueNode)) {
@@ -144,6 +149,7 @@ func CreateChangeWithEncoding(changes *[]*Change, changeType int, property strin
 		}
 	}
 
+	recordChange(changes, c)
 	return changes
 }
 
@@ -245,14 +251,19 @@ func CheckSpecificObjectAdded[T any](l, r map[string]*T, label string) bool {
 // When PropertyCheck has Component set, the configurable breaking rules system is used
 // to look up the correct breaking value for each change type (added, modified, removed).
 func CheckProperties(properties []*PropertyCheck) {
-	checkPropertiesInternal(properties, false)
+	checkProperties(context.Background(), properties)
+}
+
+// checkProperties is the same as CheckProperties, using the breaking rules of the comparison of the context.
+func checkProperties(ctx context.Context, properties []*PropertyCheck) {
+	checkPropertiesInternal(ctx, properties, false)
 }
 
 // checkPropertiesInternal is the shared implementation for CheckProperties and CheckPropertiesWithEncoding.
 // The withEncoding parameter controls whether to use encoding-aware functions for complex YAML values.
-func checkPropertiesInternal(properties []*PropertyCheck, withEncoding bool) {
+func checkPropertiesInternal(ctx context.Context, properties []*PropertyCheck, withEncoding bool) {
 	// cache config once outside the loop for performance (avoids repeated mutex operations)
-	config := GetActiveBreakingRulesConfig()
+	config := breakingRules(ctx)
 
 	for _, n := range properties {
 		var breakingAdded, breakingModified, breakingRemoved bool
@@ -293,7 +304,13 @@ func checkPropertiesInternal(properties []*PropertyCheck, withEncoding bool) {
 // CheckPropertiesWithEncoding is like CheckProperties but uses CreateChangeWithEncoding for complex values.
 // Use this for extensions where YAML serialization is needed.
 func CheckPropertiesWithEncoding(properties []*PropertyCheck) {
-	checkPropertiesInternal(properties, true)
+	checkPropertiesWithEncoding(context.Background(), properties)
+}
+
+// checkPropertiesWithEncoding is the same as CheckPropertiesWithEncoding, using the breaking rules of the
+// comparison of the context.
+func checkPropertiesWithEncoding(ctx context.Context, properties []*PropertyCheck) {
+	checkPropertiesInternal(ctx, properties, true)
 }
 
 // CheckPropertyAdditionOrRemovalWithEncoding checks for additions and removals with encoding.
@@ -437,13 +454,85 @@ func CheckForModification[T any](l, r *yaml.Node, label string, changes *[]*Chan
 	checkForModificationInternal(l, r, label, changes, breaking, orig, new, false)
 }
 
+// CheckForExampleModification is the same as CheckForModificationWithEncoding, except the values are compared as
+// example values (see ExampleValuesEqual), so an example that has only been re-formatted is not a change.
+func CheckForExampleModification[T any](l, r *yaml.Node, label string, changes *[]*Change, breaking bool, orig, new T) {
+	if l == nil || r == nil || ExampleValuesEqual(l, r) {
+		return
+	}
+	CreateChangeWithEncoding(changes, Modified, label, l, r, breaking, orig, new)
+}
+
+// ExampleValuesEqual compares two example values by their content rather than how they are written. The order of
+// the keys of an object does not matter, numbers are compared by value (so 1 and 1.0 are equal) and the style of
+// a value (quoted, flow or block) is ignored. The order of the values in an array still matters, as does the type of
+// a value, so a date and a string with the same text are not equal.
+func ExampleValuesEqual(l, r *yaml.Node) bool {
+	if l == nil || r == nil {
+		return l == r
+	}
+	return canonicalExample(l) == canonicalExample(r)
+}
+
+// canonicalExample renders an example value in a form that is the same for any two equal examples.
+func canonicalExample(n *yaml.Node) string {
+	if n == nil {
+		return ""
+	}
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) > 0 {
+			return canonicalExample(n.Content[0])
+		}
+		return "null"
+	case yaml.MappingNode:
+		entries := make([]string, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			entries = append(entries, canonicalExample(n.Content[i])+":"+canonicalExample(n.Content[i+1]))
+		}
+		sort.Strings(entries)
+		return "{" + strings.Join(entries, ",") + "}"
+	case yaml.SequenceNode:
+		values := make([]string, len(n.Content))
+		for i, c := range n.Content {
+			values[i] = canonicalExample(c)
+		}
+		return "[" + strings.Join(values, ",") + "]"
+	}
+	switch n.ShortTag() {
+	case "!!int", "!!float":
+		value := strings.ReplaceAll(n.Value, "_", "")
+		if i, err := strconv.ParseInt(value, 0, 64); err == nil {
+			value = strconv.FormatInt(i, 10)
+		}
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return strconv.FormatFloat(f, 'g', -1, 64)
+		}
+	case "!!bool":
+		return strings.ToLower(n.Value)
+	case "!!null":
+		return "null"
+	}
+	return n.ShortTag() + strconv.Quote(n.Value)
+}
+
 // CheckMapForChanges checks a left and right low level map for any additions, subtractions or modifications to
 // values. The compareFunc argument should reference the correct comparison function for the generic type.
 // Uses original hardcoded breaking behavior (removals breaking, additions non-breaking).
 func CheckMapForChanges[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string, compareFunc func(l, r T) R,
 ) map[string]R {
-	return checkMapForChangesInternal(expLeft, expRight, changes, label, compareFunc, true, false, true)
+	return checkMapForChanges(context.Background(), expLeft, expRight, changes, label, ignoreContext(compareFunc))
+}
+
+func checkMapForChanges[T any, R any](ctx context.Context,
+	expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(ctx context.Context, l, r T) R,
+) map[string]R {
+	return checkMapForChangesInternal(ctx, expLeft, expRight, changes, label, compareFunc, true, false, true)
 }
 
 // CheckMapForChangesWithRules checks a left and right low level map for any additions, subtractions or modifications
@@ -451,21 +540,29 @@ func CheckMapForChanges[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyR
 func CheckMapForChangesWithRules[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string, compareFunc func(l, r T) R, component, property string,
 ) map[string]R {
-	return checkMapForChangesInternal(expLeft, expRight, changes, label, compareFunc, true,
-		BreakingAdded(component, property), BreakingRemoved(component, property))
+	return checkMapForChangesWithRules(context.Background(), expLeft, expRight, changes, label,
+		ignoreContext(compareFunc), component, property)
+}
+
+func checkMapForChangesWithRules[T any, R any](ctx context.Context,
+	expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(ctx context.Context, l, r T) R, component, property string,
+) map[string]R {
+	return checkMapForChangesInternal(ctx, expLeft, expRight, changes, label, compareFunc, true,
+		breakingAdded(ctx, component, property), breakingRemoved(ctx, component, property))
 }
 
 // CheckMapForAdditionRemoval checks a left and right low level map for any additions or subtractions, but not modifications
 func CheckMapForAdditionRemoval[T any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string,
 ) any {
-	doNothing := func(l, r T) any {
+	doNothing := func(_ context.Context, l, r T) any {
 		return nil
 	}
 	// adding purely to make sure code is called for coverage.
 	var l, r T
-	doNothing(l, r)
-	return checkMapForChangesInternal(expLeft, expRight, changes, label, doNothing, false, false, true)
+	doNothing(context.Background(), l, r)
+	return checkMapForChangesInternal(context.Background(), expLeft, expRight, changes, label, doNothing, false, false, true)
 }
 
 // CheckMapForChangesWithComp checks a left and right low level map for any additions, subtractions or modifications to
@@ -475,7 +572,8 @@ func CheckMapForAdditionRemoval[T any](expLeft, expRight *orderedmap.Map[low.Key
 func CheckMapForChangesWithComp[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string, compareFunc func(l, r T) R, compare bool,
 ) map[string]R {
-	return checkMapForChangesInternal(expLeft, expRight, changes, label, compareFunc, compare, false, true)
+	return checkMapForChangesInternal(context.Background(), expLeft, expRight, changes, label,
+		ignoreContext(compareFunc), compare, false, true)
 }
 
 // CheckMapForChangesWithNilSupport checks a left and right low level map for any additions, subtractions or modifications.
@@ -485,208 +583,142 @@ func CheckMapForChangesWithComp[T any, R any](expLeft, expRight *orderedmap.Map[
 func CheckMapForChangesWithNilSupport[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
 	changes *[]*Change, label string, compareFunc func(l, r T) R,
 ) map[string]R {
-	return checkMapForChangesWithNilSupportInternal(expLeft, expRight, changes, label, compareFunc, false, true)
+	return checkMapForChangesWithNilSupport(context.Background(), expLeft, expRight, changes, label,
+		ignoreContext(compareFunc))
+}
+
+func checkMapForChangesWithNilSupport[T any, R any](ctx context.Context,
+	expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(ctx context.Context, l, r T) R,
+) map[string]R {
+	return checkMapForChangesWithNilSupportInternal(ctx, expLeft, expRight, changes, label, compareFunc, false, true)
+}
+
+// ignoreContext adapts a comparison function that is not given the context of the comparison it's part of.
+func ignoreContext[T any, R any](compareFunc func(l, r T) R) func(ctx context.Context, l, r T) R {
+	return func(_ context.Context, l, r T) R {
+		return compareFunc(l, r)
+	}
 }
 
 // checkMapForChangesWithNilSupportInternal is the core implementation that calls compareFunc with nil for added/removed items.
-func checkMapForChangesWithNilSupportInternal[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
-	changes *[]*Change, label string, compareFunc func(l, r T) R,
+func checkMapForChangesWithNilSupportInternal[T any, R any](ctx context.Context,
+	expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(ctx context.Context, l, r T) R,
 	breakingAdded, breakingRemoved bool,
 ) map[string]R {
 	var chLock sync.Mutex
 
-	lHashes := make(map[string]string)
-	rHashes := make(map[string]string)
-	lValues := make(map[string]low.ValueReference[T])
-	rValues := make(map[string]low.ValueReference[T])
+	lKeys, lHashes, lValues := hashMapEntries(ctx, expLeft)
+	rKeys, rHashes, rValues := hashMapEntries(ctx, expRight)
 
-	if expLeft != nil {
-		for k, v := range expLeft.FromOldest() {
-			lHashes[k.Value] = low.GenerateHashString(v.Value)
-			lValues[k.Value] = v
+	// entries that were removed or modified, in the order they appear on the left, then the entries that were
+	// added, in the order they appear on the right.
+	var checked []string
+	for _, k := range lKeys {
+		if rHashes[k] == EMPTY_STR || lHashes[k] != rHashes[k] {
+			checked = append(checked, k)
 		}
 	}
-
-	if expRight != nil {
-		for k, v := range expRight.FromOldest() {
-			rHashes[k.Value] = low.GenerateHashString(v.Value)
-			rValues[k.Value] = v
+	for _, k := range rKeys {
+		if lHashes[k] == EMPTY_STR {
+			checked = append(checked, k)
 		}
 	}
 
 	expChanges := make(map[string]R)
-
-	checkLeft := func(k string, doneChan chan struct{}, f, g map[string]string, p, h map[string]low.ValueReference[T]) {
-		rhash := g[k]
-		if rhash == EMPTY_STR {
-			// Item was removed - call compareFunc with nil/zero right side
-			chLock.Lock()
-			var zero T
-			ch := compareFunc(p[k].Value, zero)
-			if !reflect.ValueOf(&ch).Elem().IsZero() {
-				expChanges[k] = ch
-				var cr any = ch
-				pVal := p[k]
-				SetReferenceIfExists(&pVal, cr)
-			}
-			chLock.Unlock()
-			doneChan <- struct{}{}
-			return
+	compareEach(ctx, checked, func(k string) {
+		// a missing side is passed to compareFunc as nil (or zero).
+		ch := compareFunc(ctx, lValues[k].Value, rValues[k].Value)
+		pVal, ok := lValues[k]
+		if !ok {
+			pVal = rValues[k]
 		}
-		if f[k] == g[k] {
-			doneChan <- struct{}{}
-			return
-		}
-		// Item was modified
-		chLock.Lock()
-		ch := compareFunc(p[k].Value, h[k].Value)
 		if !reflect.ValueOf(&ch).Elem().IsZero() {
+			chLock.Lock()
 			expChanges[k] = ch
+			chLock.Unlock()
 			var cr any = ch
-			pVal := p[k]
 			SetReferenceIfExists(&pVal, cr)
 		}
-		chLock.Unlock()
-		doneChan <- struct{}{}
-	}
-
-	checkRight := func(k string, doneChan chan struct{}, f map[string]string, p map[string]low.ValueReference[T]) {
-		lhash := f[k]
-		if lhash == EMPTY_STR {
-			// Item was added - call compareFunc with nil/zero left side
-			chLock.Lock()
-			var zero T
-			ch := compareFunc(zero, p[k].Value)
-			if !reflect.ValueOf(&ch).Elem().IsZero() {
-				expChanges[k] = ch
-				var cr any = ch
-				pVal := p[k]
-				SetReferenceIfExists(&pVal, cr)
-			}
-			chLock.Unlock()
-		}
-		doneChan <- struct{}{}
-	}
-
-	doneChan := make(chan struct{})
-	count := 0
-
-	for k := range lHashes {
-		count++
-		go checkLeft(k, doneChan, lHashes, rHashes, lValues, rValues)
-	}
-
-	for k := range rHashes {
-		count++
-		go checkRight(k, doneChan, lHashes, rValues)
-	}
-
-	completed := 0
-	for completed < count {
-		<-doneChan
-		completed++
-	}
+	})
 	return expChanges
 }
 
 // checkMapForChangesInternal is the core implementation that checks a left and right low level map for any
 // additions, subtractions or modifications to values. The breakingAdded and breakingRemoved parameters control
 // whether additions and removals are marked as breaking changes.
-func checkMapForChangesInternal[T any, R any](expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
-	changes *[]*Change, label string, compareFunc func(l, r T) R, compare bool,
+func checkMapForChangesInternal[T any, R any](ctx context.Context,
+	expLeft, expRight *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change, label string, compareFunc func(ctx context.Context, l, r T) R, compare bool,
 	breakingAdded, breakingRemoved bool,
 ) map[string]R {
 	var chLock sync.Mutex
 
-	lHashes := make(map[string]string)
-	rHashes := make(map[string]string)
-	lValues := make(map[string]low.ValueReference[T])
-	rValues := make(map[string]low.ValueReference[T])
-
-	if expLeft != nil {
-		for k, v := range expLeft.FromOldest() {
-			lHashes[k.Value] = low.GenerateHashString(v.Value)
-			lValues[k.Value] = v
-		}
-	}
-
-	if expRight != nil {
-		for k, v := range expRight.FromOldest() {
-			rHashes[k.Value] = low.GenerateHashString(v.Value)
-			rValues[k.Value] = v
-		}
-	}
+	lKeys, lHashes, lValues := hashMapEntries(ctx, expLeft)
+	rKeys, rHashes, rValues := hashMapEntries(ctx, expRight)
 
-	expChanges := make(map[string]R)
-
-	checkLeft := func(k string, doneChan chan struct{}, f, g map[string]string, p, h map[string]low.ValueReference[T]) {
-		rhash := g[k]
-		if rhash == EMPTY_STR {
-			chLock.Lock()
-			if p[k].GetValueNode().Value == EMPTY_STR {
-				p[k].GetValueNode().Value = k
+	// removals are reported in the order the entries appear on the left, and additions in the order they appear
+	// on the right, so the changes are always in the same order.
+	var modified []string
+	for _, k := range lKeys {
+		if rHashes[k] == EMPTY_STR {
+			if lValues[k].GetValueNode().Value == EMPTY_STR {
+				lValues[k].GetValueNode().Value = k
 			}
 			CreateChange(changes, ObjectRemoved, label,
-				p[k].GetValueNode(), nil, breakingRemoved,
-				p[k].GetValue(), nil)
-			chLock.Unlock()
-			doneChan <- struct{}{}
-			return
+				lValues[k].GetValueNode(), nil, breakingRemoved,
+				lValues[k].GetValue(), nil)
+			continue
 		}
-		if f[k] == g[k] {
-			doneChan <- struct{}{}
-			return
+		if compare && lHashes[k] != rHashes[k] {
+			modified = append(modified, k)
 		}
-		if compare {
-			chLock.Lock()
-			ch := compareFunc(p[k].Value, h[k].Value)
-			// incorrect map results were being generated causing panics.
-			// https://github.com/pb33f/libopenapi/issues/61
-			if !reflect.ValueOf(&ch).Elem().IsZero() {
-				expChanges[k] = ch
-				var cr any = ch
-				pVal := p[k]
-				SetReferenceIfExists(&pVal, cr)
+	}
+	for _, k := range rKeys {
+		if lHashes[k] == EMPTY_STR {
+			if rValues[k].GetValueNode().Value == EMPTY_STR {
+				rValues[k].GetValueNode().Value = k
 			}
-			chLock.Unlock()
+			CreateChange(changes, ObjectAdded, label,
+				nil, rValues[k].GetValueNode(), breakingAdded,
+				nil, rValues[k].GetValue())
 		}
-		doneChan <- struct{}{}
 	}
 
-	checkRight := func(k string, doneChan chan struct{}, f map[string]string, p map[string]low.ValueReference[T]) {
-		lhash := f[k]
-		if lhash == EMPTY_STR {
+	expChanges := make(map[string]R)
+	compareEach(ctx, modified, func(k string) {
+		ch := compareFunc(ctx, lValues[k].Value, rValues[k].Value)
+		// incorrect map results were being generated causing panics.
+		// https://github.com/pb33f/libopenapi/issues/61
+		if !reflect.ValueOf(&ch).Elem().IsZero() {
 			chLock.Lock()
-			if p[k].GetValueNode().Value == EMPTY_STR {
-				p[k].GetValueNode().Value = k
-			}
-			CreateChange(changes, ObjectAdded, label,
-				nil, p[k].GetValueNode(), breakingAdded,
-				nil, p[k].GetValue())
+			expChanges[k] = ch
 			chLock.Unlock()
+			var cr any = ch
+			pVal := lValues[k]
+			SetReferenceIfExists(&pVal, cr)
 		}
-		doneChan <- struct{}{}
-	}
-
-	doneChan := make(chan struct{})
-	count := 0
-
-	for k := range lHashes {
-		count++
-		go checkLeft(k, doneChan, lHashes, rHashes, lValues, rValues)
-	}
-
-	for k := range rHashes {
-		count++
-		go checkRight(k, doneChan, lHashes, rValues)
-	}
+	})
+	return expChanges
+}
 
-	completed := 0
-	for completed < count {
-		<-doneChan
-		completed++
+// hashMapEntries returns the keys of a low level map in the order they appear, along with the hash and value of
+// every entry.
+func hashMapEntries[T any](
+	ctx context.Context, m *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+) ([]string, map[string]string, map[string]low.ValueReference[T]) {
+	var keys []string
+	hashes := make(map[string]string)
+	values := make(map[string]low.ValueReference[T])
+	if m != nil {
+		for k, v := range m.FromOldest() {
+			keys = append(keys, k.Value)
+			hashes[k.Value] = entryHash(ctx, v.Value, v.ValueNode)
+			values[k.Value] = v
+		}
 	}
-	return expChanges
+	return keys, hashes, values
 }
 
 // ExtractStringValueSliceChanges will compare two low level string slices for changes.
@@ -732,6 +764,14 @@ func ExtractStringValueSliceChanges(lParam, rParam []low.ValueReference[string],
 // using the configurable breaking rules system to determine breaking status.
 func ExtractStringValueSliceChangesWithRules(lParam, rParam []low.ValueReference[string],
 	changes *[]*Change, label string, component, property string,
+) {
+	extractStringValueSliceChangesWithRules(context.Background(), lParam, rParam, changes, label, component, property)
+}
+
+// extractStringValueSliceChangesWithRules is the same as ExtractStringValueSliceChangesWithRules, using the
+// breaking rules of the comparison of the context.
+func extractStringValueSliceChangesWithRules(ctx context.Context, lParam, rParam []low.ValueReference[string],
+	changes *[]*Change, label string, component, property string,
 ) {
 	lKeys := make([]string, len(lParam))
 	rKeys := make([]string, len(rParam))
@@ -750,7 +790,7 @@ func ExtractStringValueSliceChangesWithRules(lParam, rParam []low.ValueReference
 			CreateChange(changes, PropertyRemoved, label,
 				lValues[i].ValueNode,
 				nil,
-				BreakingRemoved(component, property),
+				breakingRemoved(ctx, component, property),
 				lValues[i].Value,
 				nil)
 		}
@@ -760,7 +800,7 @@ func ExtractStringValueSliceChangesWithRules(lParam, rParam []low.ValueReference
 			CreateChange(changes, PropertyAdded, label,
 				nil,
 				rValues[i].ValueNode,
-				BreakingAdded(component, property),
+				breakingAdded(ctx, component, property),
 				nil,
 				rValues[i].Value)
 		}
@@ -780,6 +820,31 @@ func toString(v any) string {
 // ExtractRawValueSliceChanges will compare two low level interface{} slices for changes.
 func ExtractRawValueSliceChanges[T any](lParam, rParam []low.ValueReference[T],
 	changes *[]*Change, label string, breaking bool,
+) {
+	extractRawValueSliceChanges(lParam, rParam, changes, label, breaking, false)
+}
+
+// ExtractRawValueSliceChangesWithRules compares two low level interface{} slices for changes,
+// using the configurable breaking rules system to determine breaking status.
+func ExtractRawValueSliceChangesWithRules[T any](lParam, rParam []low.ValueReference[T],
+	changes *[]*Change, label string, component, property string,
+) {
+	extractRawValueSliceChangesWithRules(context.Background(), lParam, rParam, changes, label, component, property)
+}
+
+// extractRawValueSliceChangesWithRules is the same as ExtractRawValueSliceChangesWithRules, using the breaking
+// rules of the comparison of the context.
+func extractRawValueSliceChangesWithRules[T any](ctx context.Context, lParam, rParam []low.ValueReference[T],
+	changes *[]*Change, label string, component, property string,
+) {
+	extractRawValueSliceChanges(lParam, rParam, changes, label,
+		breakingRemoved(ctx, component, property), breakingAdded(ctx, component, property))
+}
+
+// extractRawValueSliceChanges compares two low level interface{} slices for values that were removed or added,
+// which are breaking according to breakingRemoved and breakingAdded.
+func extractRawValueSliceChanges[T any](lParam, rParam []low.ValueReference[T],
+	changes *[]*Change, label string, breakingRemoved, breakingAdded bool,
 ) {
 	lKeys := make([]string, len(lParam))
 	rKeys := make([]string, len(rParam))
@@ -798,7 +863,7 @@ func ExtractRawValueSliceChanges[T any](lParam, rParam []low.ValueReference[T],
 			CreateChange(changes, PropertyRemoved, label,
 				lValues[i].ValueNode,
 				nil,
-				breaking,
+				breakingRemoved,
 				lValues[i].Value,
 				nil)
 		}
@@ -808,7 +873,7 @@ func ExtractRawValueSliceChanges[T any](lParam, rParam []low.ValueReference[T],
 			CreateChange(changes, PropertyAdded, label,
 				nil,
 				rValues[i].ValueNode,
-				false,
+				breakingAdded,
 				nil,
 				rValues[i].Value)
 		}
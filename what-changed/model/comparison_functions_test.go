@@ -940,3 +940,51 @@ func TestCheckPropertyAdditionOrRemovalWithEncoding(t *testing.T) {
 		assert.NotEmpty(t, changes[0].NewEncoded)
 	})
 }
+
+func TestExampleValuesEqual(t *testing.T) {
+	tests := []struct {
+		name  string
+		left  string
+		right string
+		equal bool
+	}{
+		{"key order", `{name: burger, price: 10}`, "price: 10\nname: burger", true},
+		{"numbers", `{price: 1}`, `{price: 1.0}`, true},
+		{"number bases", `0x10`, `16`, true},
+		{"quoting", `{name: burger}`, `{"name": 'burger'}`, true},
+		{"nested", "a:\n  b: [1, {c: d, e: 2.50}]", `{a: {b: [1.0, {e: 2.5, c: d}]}}`, true},
+		{"value", `{name: burger}`, `{name: pizza}`, false},
+		{"missing key", `{name: burger, price: 10}`, `{name: burger}`, false},
+		{"array order", `[1, 2]`, `[2, 1]`, false},
+		{"types", `{price: 1}`, `{price: "1"}`, false},
+		{"dates", `2022-12-29`, `"2022-12-29"`, false},
+		{"kinds", `[burger]`, `burger`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var lNode, rNode yaml.Node
+			_ = yaml.Unmarshal([]byte(tt.left), &lNode)
+			_ = yaml.Unmarshal([]byte(tt.right), &rNode)
+			assert.Equal(t, tt.equal, ExampleValuesEqual(lNode.Content[0], rNode.Content[0]))
+		})
+	}
+	assert.True(t, ExampleValuesEqual(nil, nil))
+	assert.False(t, ExampleValuesEqual(nil, &yaml.Node{Kind: yaml.ScalarNode, Value: "burger"}))
+}
+
+func TestCheckForExampleModification(t *testing.T) {
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(`{name: burger, price: 10}`), &lNode)
+	_ = yaml.Unmarshal([]byte("price: 10.0\nname: burger"), &rNode)
+
+	var changes []*Change
+	CheckForExampleModification(lNode.Content[0], rNode.Content[0], "example", &changes, false, "", "")
+	assert.Empty(t, changes)
+
+	_ = yaml.Unmarshal([]byte("price: 12\nname: burger"), &rNode)
+	CheckForExampleModification(lNode.Content[0], rNode.Content[0], "example", &changes, true, "", "")
+	assert.Len(t, changes, 1)
+	assert.Equal(t, Modified, changes[0].ChangeType)
+	assert.True(t, changes[0].Breaking)
+	assert.NotEmpty(t, changes[0].NewEncoded)
+}
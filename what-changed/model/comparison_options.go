@@ -0,0 +1,75 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+)
+
+// ComparisonOptions tune a single comparison, made with a context returned by WithComparisonOptions, without
+// changing the behavior of any other comparison, including comparisons running at the same time.
+type ComparisonOptions struct {
+	// BreakingRules classify the changes found, instead of the active breaking rules (see
+	// SetActiveBreakingRulesConfig). When nil, the active breaking rules are used.
+	BreakingRules *BreakingRulesConfig
+
+	// Concurrency is how many entries of the same map are compared at the same time, for example the paths of two
+	// documents, the schemas of their components, or the responses of an operation. When it is 1 or less (the
+	// default), entries are compared one at a time.
+	//
+	// The changes found are the same regardless of the concurrency. Changes made to an entry are keyed by its name,
+	// and entries that were added or removed are reported in the order they appear in each document.
+	Concurrency int
+
+	// OrderedArrays selects the arrays that are compared in order (see OrderedArrays). When nil, every array is
+	// compared as a set, which is the default.
+	OrderedArrays *OrderedArrays
+
+	// EffectiveSecurity compares the effective security of the operations of documents. By default, the security
+	// of each operation is compared as written, so changing the security at the root of a document is only reported
+	// once, at the root.
+	//
+	// When enabled, operations that don't declare their own security (in either document) are compared using the
+	// security they inherit from the root of their document, so removing a root security requirement is also
+	// reported on every operation that relies on it, and moving a requirement from the root onto every operation is
+	// not a change of those operations. An empty security array (security: []) is declared security, which is not
+	// inherited.
+	EffectiveSecurity bool
+
+	// DetectRenames reports components that were removed and added again under a different name as renamed (see
+	// ObjectRenamed). By default, they are reported as a removal and an addition.
+	//
+	// Only components with identical content are paired, as determined by their hash. A component that is renamed
+	// and changed at the same time is still reported as a removal and an addition.
+	DetectRenames bool
+}
+
+type comparisonOptionsKey struct{}
+
+var defaultComparisonOptions = new(ComparisonOptions)
+
+// WithComparisonOptions returns a copy of the context that tunes every comparison made with it (see
+// CompareDocumentsWithContext) using the options. The options must not be changed while they are in use.
+func WithComparisonOptions(ctx context.Context, options *ComparisonOptions) context.Context {
+	if options == nil {
+		options = defaultComparisonOptions
+	}
+	return context.WithValue(ctx, comparisonOptionsKey{}, options)
+}
+
+// comparisonOptions returns the options of the context (see WithComparisonOptions). It's never nil.
+func comparisonOptions(ctx context.Context) *ComparisonOptions {
+	if o, _ := ctx.Value(comparisonOptionsKey{}).(*ComparisonOptions); o != nil {
+		return o
+	}
+	return defaultComparisonOptions
+}
+
+// breakingRules returns the breaking rules used by the comparison of the context.
+func breakingRules(ctx context.Context) *BreakingRulesConfig {
+	if rules := comparisonOptions(ctx).BreakingRules; rules != nil {
+		return rules
+	}
+	return GetActiveBreakingRulesConfig()
+}
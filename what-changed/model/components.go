@@ -4,7 +4,9 @@
 package model
 
 import (
+	"context"
 	"reflect"
+	"sort"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
@@ -36,6 +38,11 @@ import (
 // An important note: Everything EXCEPT Schemas and SecuritySchemes is ONLY checked for additions or removals.
 // modifications are not checked, these checks occur in-place by implementing objects as they are autp-resolved
 // when the model is built.
+//
+// When renames are detected (see ComparisonOptions), a component that is removed and added again with identical
+// content under a different name (for example `Burger` renamed to `Hamburger`) is reported as a single
+// ObjectRenamed change, rather than a removal and an addition. Renames are breaking according to the `renamed`
+// breaking rule (modified).
 type ComponentsChanges struct {
 	*PropertyChanges
 	SchemaChanges         map[string]*SchemaChanges         `json:"schemas,omitempty" yaml:"schemas,omitempty"`
@@ -46,6 +53,13 @@ type ComponentsChanges struct {
 // CompareComponents will compare OpenAPI components for any changes. Accepts Swagger Definition objects
 // like ParameterDefinitions or Definitions etc.
 func CompareComponents(l, r any) *ComponentsChanges {
+	return compareComponents(context.Background(), l, r)
+}
+
+func compareComponents(ctx context.Context, l, r any) *ComponentsChanges {
+	if ComparisonAborted(ctx) {
+		return nil
+	}
 	var changes []*Change
 
 	cc := new(ComponentsChanges)
@@ -92,7 +106,7 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Schemas
 		}
-		cc.SchemaChanges = CheckMapForChanges(a, b, &changes, v2.DefinitionsLabel, CompareSchemas)
+		cc.SchemaChanges = checkMapForChanges(ctx, a, b, &changes, v2.DefinitionsLabel, compareSchemas)
 	}
 
 	// Swagger Security Definitions
@@ -107,8 +121,8 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		if rDef != nil {
 			b = rDef.Definitions
 		}
-		cc.SecuritySchemeChanges = CheckMapForChanges(a, b, &changes,
-			v3.SecurityDefinitionLabel, CompareSecuritySchemesV2)
+		cc.SecuritySchemeChanges = checkMapForChanges(ctx, a, b, &changes,
+			v3.SecurityDefinitionLabel, compareSecuritySchemesV2)
 	}
 
 	// OpenAPI Components
@@ -128,69 +142,71 @@ func CompareComponents(l, r any) *ComponentsChanges {
 		// run as fast as we can, thread all the things.
 		if !lComponents.Schemas.IsEmpty() || !rComponents.Schemas.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Schemas.Value, rComponents.Schemas.Value,
-				&changes, v3.SchemasLabel, CompareSchemas, doneChan)
+			go runComparison(ctx, lComponents.Schemas.Value, rComponents.Schemas.Value,
+				v3.SchemasLabel, compareSchemas, doneChan)
 		}
 
 		if !lComponents.Responses.IsEmpty() || !rComponents.Responses.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Responses.Value, rComponents.Responses.Value,
-				&changes, v3.ResponsesLabel, CompareResponseV3, doneChan)
+			go runComparison(ctx, lComponents.Responses.Value, rComponents.Responses.Value,
+				v3.ResponsesLabel, compareResponseV3, doneChan)
 		}
 
 		if !lComponents.Parameters.IsEmpty() || !rComponents.Parameters.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Parameters.Value, rComponents.Parameters.Value,
-				&changes, v3.ParametersLabel, CompareParametersV3, doneChan)
+			go runComparison(ctx, lComponents.Parameters.Value, rComponents.Parameters.Value,
+				v3.ParametersLabel, compareParametersV3, doneChan)
 		}
 
 		if !lComponents.Examples.IsEmpty() || !rComponents.Examples.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Examples.Value, rComponents.Examples.Value,
-				&changes, v3.ExamplesLabel, CompareExamples, doneChan)
+			go runComparison(ctx, lComponents.Examples.Value, rComponents.Examples.Value,
+				v3.ExamplesLabel, compareExamples, doneChan)
 		}
 
 		if !lComponents.RequestBodies.IsEmpty() || !rComponents.RequestBodies.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.RequestBodies.Value, rComponents.RequestBodies.Value,
-				&changes, v3.RequestBodiesLabel, CompareRequestBodies, doneChan)
+			go runComparison(ctx, lComponents.RequestBodies.Value, rComponents.RequestBodies.Value,
+				v3.RequestBodiesLabel, compareRequestBodies, doneChan)
 		}
 
 		if !lComponents.Headers.IsEmpty() || !rComponents.Headers.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Headers.Value, rComponents.Headers.Value,
-				&changes, v3.HeadersLabel, CompareHeadersV3, doneChan)
+			go runComparison(ctx, lComponents.Headers.Value, rComponents.Headers.Value,
+				v3.HeadersLabel, compareHeadersV3, doneChan)
 		}
 
 		if !lComponents.SecuritySchemes.IsEmpty() || !rComponents.SecuritySchemes.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.SecuritySchemes.Value, rComponents.SecuritySchemes.Value,
-				&changes, v3.SecuritySchemesLabel, CompareSecuritySchemesV3, doneChan)
+			go runComparison(ctx, lComponents.SecuritySchemes.Value, rComponents.SecuritySchemes.Value,
+				v3.SecuritySchemesLabel, compareSecuritySchemesV3, doneChan)
 		}
 
 		if !lComponents.Links.IsEmpty() || !rComponents.Links.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Links.Value, rComponents.Links.Value,
-				&changes, v3.LinksLabel, CompareLinks, doneChan)
+			go runComparison(ctx, lComponents.Links.Value, rComponents.Links.Value,
+				v3.LinksLabel, compareLinks, doneChan)
 		}
 
 		if !lComponents.Callbacks.IsEmpty() || !rComponents.Callbacks.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.Callbacks.Value, rComponents.Callbacks.Value,
-				&changes, v3.CallbacksLabel, CompareCallback, doneChan)
+			go runComparison(ctx, lComponents.Callbacks.Value, rComponents.Callbacks.Value,
+				v3.CallbacksLabel, compareCallback, doneChan)
 		}
 
 		if !lComponents.MediaTypes.IsEmpty() || !rComponents.MediaTypes.IsEmpty() {
 			comparisons++
-			go runComparison(lComponents.MediaTypes.Value, rComponents.MediaTypes.Value,
-				&changes, v3.MediaTypesLabel, CompareMediaTypes, doneChan)
+			go runComparison(ctx, lComponents.MediaTypes.Value, rComponents.MediaTypes.Value,
+				v3.MediaTypesLabel, compareMediaTypes, doneChan)
 		}
 
-		cc.ExtensionChanges = CompareExtensions(lComponents.Extensions, rComponents.Extensions)
+		cc.ExtensionChanges = compareExtensions(ctx, lComponents.Extensions, rComponents.Extensions)
 
+		found := make(map[string][]*Change)
 		completedComponents := 0
 		for completedComponents < comparisons {
 			res := <-doneChan
+			found[res.prop] = res.changes
 			switch res.prop {
 			case v3.SchemasLabel:
 				completedComponents++
@@ -203,37 +219,137 @@ func CompareComponents(l, r any) *ComponentsChanges {
 				completedComponents++
 			}
 		}
+		for _, label := range componentLabels {
+			changes = append(changes, found[label]...)
+		}
 	}
 
-	cc.PropertyChanges = NewPropertyChanges(changes)
+	if comparisonOptions(ctx).DetectRenames {
+		changes = checkForRenames(ctx, changes)
+	}
+	checkComparators(l, r, &changes)
+	cc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if cc.TotalChanges() <= 0 {
 		return nil
 	}
 	return cc
 }
 
+// componentLabels is the order the changes made to each type of component are reported in.
+var componentLabels = []string{
+	v3.SchemasLabel, v3.ResponsesLabel, v3.ParametersLabel, v3.ExamplesLabel, v3.RequestBodiesLabel,
+	v3.HeadersLabel, v3.SecuritySchemesLabel, v3.LinksLabel, v3.CallbacksLabel, v3.MediaTypesLabel,
+}
+
 type componentComparison struct {
-	prop   string
-	result any
+	prop    string
+	result  any
+	changes []*Change
 }
 
-// run a generic comparison in a thread which in turn splits checks into further threads.
-func runComparison[T any, R any](l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
-	changes *[]*Change, label string, compareFunc func(l, r T) R, doneChan chan componentComparison,
+// run a generic comparison in a thread which in turn splits checks into further threads. The changes found are
+// returned with the result, so they can be added to the changes of the components in a fixed order.
+func runComparison[T any, R any](ctx context.Context, l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	label string, compareFunc func(ctx context.Context, l, r T) R, doneChan chan componentComparison,
 ) {
+	var changes []*Change
 	// for schemas
 	if label == v3.SchemasLabel || label == v2.DefinitionsLabel || label == v3.SecuritySchemesLabel {
-		doneChan <- componentComparison{
-			prop:   label,
-			result: CheckMapForChanges(l, r, changes, label, compareFunc),
-		}
+		result := checkMapForChanges(ctx, l, r, &changes, label, compareFunc)
+		doneChan <- componentComparison{prop: label, result: result, changes: changes}
 		return
-	} else {
-		doneChan <- componentComparison{
-			prop:   label,
-			result: CheckMapForAdditionRemoval(l, r, changes, label),
+	}
+	result := CheckMapForAdditionRemoval(l, r, &changes, label)
+	doneChan <- componentComparison{prop: label, result: result, changes: changes}
+}
+
+// checkForRenames pairs up components that were removed with components that were added under a different name,
+// and have the same hash. Each pair is replaced with a single ObjectRenamed change. Pairs are made in the order
+// the components appear in each specification.
+func checkForRenames(ctx context.Context, changes []*Change) []*Change {
+	type candidate struct {
+		change *Change
+		hash   string
+	}
+	var removed, added []candidate
+	for _, c := range changes {
+		switch c.ChangeType {
+		case ObjectRemoved:
+			if h := low.GenerateHashString(c.OriginalObject); h != "" {
+				removed = append(removed, candidate{c, h})
+			}
+		case ObjectAdded:
+			if h := low.GenerateHashString(c.NewObject); h != "" {
+				added = append(added, candidate{c, h})
+			}
+		}
+	}
+	if len(removed) == 0 || len(added) == 0 {
+		return changes
+	}
+	line := func(c *Change, original bool) int {
+		switch {
+		case c.Context == nil:
+			return 0
+		case original && c.Context.OriginalLine != nil:
+			return *c.Context.OriginalLine
+		case !original && c.Context.NewLine != nil:
+			return *c.Context.NewLine
+		}
+		return 0
+	}
+	sort.SliceStable(removed, func(i, j int) bool {
+		return line(removed[i].change, true) < line(removed[j].change, true)
+	})
+	sort.SliceStable(added, func(i, j int) bool {
+		return line(added[i].change, false) < line(added[j].change, false)
+	})
+
+	renamed := make(map[*Change]*Change)
+	paired := make(map[*Change]bool)
+	for _, r := range removed {
+		for _, a := range added {
+			if paired[a.change] || a.hash != r.hash || a.change.Property != r.change.Property {
+				continue
+			}
+			var changeContext ChangeContext
+			if r.change.Context != nil {
+				changeContext = *r.change.Context
+			}
+			if a.change.Context != nil {
+				changeContext.NewLine, changeContext.NewColumn = a.change.Context.NewLine, a.change.Context.NewColumn
+				if changeContext.DocumentLocation == "" {
+					changeContext.DocumentLocation = a.change.Context.DocumentLocation
+				}
+			}
+			renamed[r.change] = &Change{
+				Context:        &changeContext,
+				ChangeType:     ObjectRenamed,
+				Property:       r.change.Property,
+				Original:       r.change.Original,
+				New:            a.change.New,
+				Breaking:       breakingModified(ctx, CompRenamed, ""),
+				OriginalObject: r.change.OriginalObject,
+				NewObject:      a.change.NewObject,
+			}
+			paired[a.change] = true
+			break
+		}
+	}
+	if len(renamed) == 0 {
+		return changes
+	}
+	result := make([]*Change, 0, len(changes)-len(renamed))
+	for _, c := range changes {
+		if paired[c] {
+			continue
+		}
+		if rc := renamed[c]; rc != nil {
+			c = rc
 		}
+		result = append(result, c)
 	}
+	return result
 }
 
 // GetAllChanges returns a slice of all changes made between Callback objects
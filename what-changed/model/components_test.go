@@ -8,10 +8,12 @@ import (
 	"testing"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.yaml.in/yaml/v4"
 )
 
@@ -1161,3 +1163,213 @@ mediaTypes:
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
 	assert.Equal(t, "ApplicationJSON", extChanges.Changes[0].Original)
 }
+
+var detectRenamesContext = WithComparisonOptions(context.Background(), &ComparisonOptions{DetectRenames: true})
+
+func TestCompareComponents_OpenAPI_Schemas_Renamed(t *testing.T) {
+	low.ClearHashCache()
+	left := `schemas:
+  Burger:
+    type: object
+    properties:
+      name:
+        type: string
+  Fries:
+    type: object
+    description: crispy`
+
+	right := `schemas:
+  Fries:
+    type: object
+    description: soggy
+  Hamburger:
+    type: object
+    properties:
+      name:
+        type: string`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Components
+	var rDoc v3.Components
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), rNode.Content[0], nil)
+
+	// compare.
+	extChanges := compareComponents(detectRenamesContext, &lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	assert.Len(t, extChanges.Changes, 1)
+	renamed := extChanges.Changes[0]
+	assert.Equal(t, ObjectRenamed, renamed.ChangeType)
+	assert.Equal(t, v3.SchemasLabel, renamed.Property)
+	assert.Equal(t, "Burger", renamed.Original)
+	assert.Equal(t, "Hamburger", renamed.New)
+	assert.Equal(t, 3, *renamed.Context.OriginalLine)
+	assert.Equal(t, 6, *renamed.Context.NewLine)
+	assert.True(t, renamed.Breaking)
+	assert.Equal(t, "object_renamed", ChangeTypeText(renamed.ChangeType))
+	assert.Equal(t, SeverityError, renamed.GetSeverity())
+	assert.Len(t, extChanges.SchemaChanges, 1)
+}
+
+func TestCompareComponents_OpenAPI_Schemas_RenamesNotDetected(t *testing.T) {
+	low.ClearHashCache()
+	left := `schemas:
+  Burger:
+    type: object`
+
+	right := `schemas:
+  Hamburger:
+    type: object`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Components
+	var rDoc v3.Components
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), rNode.Content[0], nil)
+
+	// renames are not detected by default.
+	extChanges := CompareComponents(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	for _, c := range extChanges.Changes {
+		assert.NotEqual(t, ObjectRenamed, c.ChangeType)
+	}
+}
+
+func TestCheckForRenames_NoContext(t *testing.T) {
+	contact := func(name string) *base.Contact {
+		return &base.Contact{Name: low.NodeReference[string]{Value: name}}
+	}
+	changes := []*Change{
+		{ChangeType: ObjectRemoved, Property: v3.SchemasLabel, Original: "Burger", OriginalObject: contact("a")},
+		{ChangeType: ObjectRemoved, Property: v3.SchemasLabel, Original: "Fries", OriginalObject: contact("b")},
+		{ChangeType: ObjectAdded, Property: v3.SchemasLabel, New: "Hamburger", NewObject: contact("a")},
+	}
+	renamed := checkForRenames(context.Background(), changes)
+	require.Len(t, renamed, 2)
+	assert.Equal(t, ObjectRenamed, renamed[0].ChangeType)
+	assert.Equal(t, "Hamburger", renamed[0].New)
+	assert.NotNil(t, renamed[0].Context)
+	assert.Equal(t, "Fries", renamed[1].Original)
+}
+
+func TestCompareComponents_OpenAPI_Schemas_RenamedAndModified(t *testing.T) {
+	low.ClearHashCache()
+	left := `schemas:
+  Burger:
+    type: object
+    description: tasty`
+
+	right := `schemas:
+  Hamburger:
+    type: object
+    description: very tasty`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Components
+	var rDoc v3.Components
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), rNode.Content[0], nil)
+
+	// the structure changed, so it's not a rename.
+	extChanges := compareComponents(detectRenamesContext, &lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
+	for _, c := range extChanges.Changes {
+		assert.NotEqual(t, ObjectRenamed, c.ChangeType)
+	}
+}
+
+func TestCompareComponents_OpenAPI_Renamed_CustomRules(t *testing.T) {
+	low.ClearHashCache()
+	left := `parameters:
+  limit:
+    name: limit
+    in: query
+  offset:
+    name: offset
+    in: query`
+
+	right := `parameters:
+  pageLimit:
+    name: limit
+    in: query
+  pageOffset:
+    name: offset
+    in: query`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.Components
+	var rDoc v3.Components
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), rNode.Content[0], nil)
+
+	config := new(BreakingRulesConfig)
+	config.Merge(GenerateDefaultBreakingRules())
+	config.Merge(&BreakingRulesConfig{Renamed: &BreakingChangeRule{Modified: boolPtr(false)}})
+	ctx := WithComparisonOptions(context.Background(), &ComparisonOptions{BreakingRules: config, DetectRenames: true})
+
+	extChanges := compareComponents(ctx, &lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+	renames := make(map[string]string)
+	for _, c := range extChanges.Changes {
+		assert.Equal(t, ObjectRenamed, c.ChangeType)
+		renames[c.Original] = c.New
+	}
+	assert.Equal(t, map[string]string{"limit": "pageLimit", "offset": "pageOffset"}, renames)
+}
+
+func TestCompareComponents_Swagger_Definitions_Renamed(t *testing.T) {
+	left := `Burger:
+ type: object
+ description: a burger`
+
+	right := `Hamburger:
+ type: object
+ description: a burger`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v2.Definitions
+	var rDoc v2.Definitions
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// compare.
+	extChanges := compareComponents(detectRenamesContext, &lDoc, &rDoc)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, ObjectRenamed, extChanges.Changes[0].ChangeType)
+	assert.Equal(t, v2.DefinitionsLabel, extChanges.Changes[0].Property)
+	assert.Equal(t, "Burger", extChanges.Changes[0].Original)
+	assert.Equal(t, "Hamburger", extChanges.Changes[0].New)
+}
@@ -0,0 +1,42 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"sync"
+)
+
+// compareEach calls compare with every key, running up to the concurrency of the comparison (see
+// ComparisonOptions) at the same time, and waits for every comparison to finish. Keys that have not been compared
+// yet are skipped if the comparison is aborted (see ComparisonAborted).
+func compareEach(ctx context.Context, keys []string, compare func(key string)) {
+	limit := comparisonOptions(ctx).Concurrency
+	if limit <= 1 || len(keys) <= 1 {
+		for _, k := range keys {
+			if ComparisonAborted(ctx) {
+				return
+			}
+			compare(k)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, limit)
+	for _, k := range keys {
+		if ComparisonAborted(ctx) {
+			break
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(key string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			compare(key)
+		}(k)
+	}
+	wg.Wait()
+}
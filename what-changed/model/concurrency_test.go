@@ -0,0 +1,104 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareEach(t *testing.T) {
+	ctx := WithComparisonOptions(context.Background(), &ComparisonOptions{Concurrency: 3})
+
+	var running, most atomic.Int64
+	var lock sync.Mutex
+	var compared []string
+	compareEach(ctx, []string{"a", "b", "c", "d", "e", "f", "g", "h"}, func(key string) {
+		n := running.Add(1)
+		for {
+			m := most.Load()
+			if n <= m || most.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		lock.Lock()
+		compared = append(compared, key)
+		lock.Unlock()
+		running.Add(-1)
+	})
+	assert.Len(t, compared, 8)
+	assert.LessOrEqual(t, most.Load(), int64(3))
+}
+
+func TestCompareEach_Aborted(t *testing.T) {
+	ctx := WithChangeStream(context.Background(), func(change *Change) bool { return false }, false)
+
+	var compared []string
+	compareEach(ctx, []string{"a", "b", "c"}, func(key string) {
+		compared = append(compared, key)
+		var changes []*Change
+		CreateChange(&changes, Modified, key, nil, nil, false, nil, nil)
+		newPropertyChanges(ctx, changes)
+	})
+	assert.Equal(t, []string{"a"}, compared)
+}
+
+func TestCompareEach_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var compared []string
+	compareEach(ctx, []string{"a", "b", "c"}, func(key string) {
+		compared = append(compared, key)
+		cancel()
+	})
+	assert.Equal(t, []string{"a"}, compared)
+}
+
+func TestCompareDocumentsWithContext_Cancelled(t *testing.T) {
+	original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	modified, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	infoOrig, _ := datamodel.ExtractSpecInfo(original)
+	infoMod, _ := datamodel.ExtractSpecInfo(modified)
+	origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+	modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	changes := CompareDocumentsWithContext(ctx, origDoc, modDoc)
+	require.NotNil(t, changes)
+	require.NotNil(t, changes.PathsChanges)
+	assert.Empty(t, changes.PathsChanges.PathItemsChanges)
+	assert.Nil(t, changes.ComponentsChanges)
+	assert.Greater(t, CompareDocuments(origDoc, modDoc).TotalChanges(), changes.TotalChanges())
+}
+
+func TestComparisonOptions_ConcurrencySameChanges(t *testing.T) {
+	compare := func(concurrency int) []byte {
+		original, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+		modified, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+		infoOrig, _ := datamodel.ExtractSpecInfo(original)
+		infoMod, _ := datamodel.ExtractSpecInfo(modified)
+		origDoc, _ := v3.CreateDocumentFromConfig(infoOrig, datamodel.NewDocumentConfiguration())
+		modDoc, _ := v3.CreateDocumentFromConfig(infoMod, datamodel.NewDocumentConfiguration())
+		ctx := WithComparisonOptions(context.Background(), &ComparisonOptions{Concurrency: concurrency})
+		data, err := json.Marshal(NewChangeReport(CompareDocumentsWithContext(ctx, origDoc, modDoc)))
+		require.NoError(t, err)
+		return data
+	}
+
+	expected := compare(0)
+	for i := 0; i < 5; i++ {
+		assert.JSONEq(t, string(expected), string(compare(8)))
+	}
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -41,6 +43,10 @@ func (c *ContactChanges) TotalBreakingChanges() int {
 // were any, a pointer to a ContactChanges object is returned, otherwise if nothing changed - the function
 // returns nil.
 func CompareContact(l, r *base.Contact) *ContactChanges {
+	return compareContact(context.Background(), l, r)
+}
+
+func compareContact(ctx context.Context, l, r *base.Contact) *ContactChanges {
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 3)
 
@@ -56,10 +62,11 @@ func CompareContact(l, r *base.Contact) *ContactChanges {
 			v3.EmailLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
+	checkComparators(l, r, &changes)
 	dc := new(ContactChanges)
-	dc.PropertyChanges = NewPropertyChanges(changes)
+	dc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 )
 
@@ -49,6 +51,10 @@ func (d *DiscriminatorChanges) TotalBreakingChanges() int {
 // CompareDiscriminator will check a left (original) and right (new) Discriminator object for changes
 // and will return a pointer to DiscriminatorChanges
 func CompareDiscriminator(l, r *base.Discriminator) *DiscriminatorChanges {
+	return compareDiscriminator(context.Background(), l, r)
+}
+
+func compareDiscriminator(ctx context.Context, l, r *base.Discriminator) *DiscriminatorChanges {
 	dc := new(DiscriminatorChanges)
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 2)
@@ -63,7 +69,7 @@ func CompareDiscriminator(l, r *base.Discriminator) *DiscriminatorChanges {
 			base.DefaultMappingLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	// flatten maps
 	lMap := FlattenLowLevelOrderedMap[string](l.Mapping.Value)
@@ -71,12 +77,12 @@ func CompareDiscriminator(l, r *base.Discriminator) *DiscriminatorChanges {
 
 	// check for removals, modifications and moves
 	for i := range lMap {
-		CheckForObjectAdditionOrRemoval[string](lMap, rMap, i, &mappingChanges, BreakingAdded(CompDiscriminator, PropMapping), BreakingRemoved(CompDiscriminator, PropMapping))
+		CheckForObjectAdditionOrRemoval[string](lMap, rMap, i, &mappingChanges, breakingAdded(ctx, CompDiscriminator, PropMapping), breakingRemoved(ctx, CompDiscriminator, PropMapping))
 		// if the existing tag exists, let's check it.
 		if rMap[i] != nil {
 			if lMap[i].Value != rMap[i].Value {
 				CreateChange(&mappingChanges, Modified, i, lMap[i].GetValueNode(),
-					rMap[i].GetValueNode(), BreakingModified(CompDiscriminator, PropMapping), lMap[i].GetValue(), rMap[i].GetValue())
+					rMap[i].GetValueNode(), breakingModified(ctx, CompDiscriminator, PropMapping), lMap[i].GetValue(), rMap[i].GetValue())
 			}
 		}
 	}
@@ -84,11 +90,12 @@ func CompareDiscriminator(l, r *base.Discriminator) *DiscriminatorChanges {
 	for i := range rMap {
 		if lMap[i] == nil {
 			CreateChange(&mappingChanges, ObjectAdded, i, nil,
-				rMap[i].GetValueNode(), BreakingAdded(CompDiscriminator, PropMapping), nil, rMap[i].GetValue())
+				rMap[i].GetValueNode(), breakingAdded(ctx, CompDiscriminator, PropMapping), nil, rMap[i].GetValue())
 		}
 	}
 
-	dc.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	dc.PropertyChanges = newPropertyChanges(ctx, changes)
 	dc.MappingChanges = mappingChanges
 	if dc.TotalChanges() <= 0 {
 		return nil
@@ -11,6 +11,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -145,6 +146,17 @@ func (d *DocumentChanges) TotalBreakingChanges() int {
 // CompareDocuments will compare any two OpenAPI documents (either Swagger or OpenAPI) and return a pointer to
 // DocumentChanges that outlines everything that was found to have changed.
 func CompareDocuments(l, r any) *DocumentChanges {
+	return CompareDocumentsWithContext(context.Background(), l, r)
+}
+
+// CompareDocumentsWithContext is the same as CompareDocuments, except the comparison stops as soon as the context
+// is cancelled or times out. The changes returned when it stops early are incomplete, so check the context error
+// before using them.
+func CompareDocumentsWithContext(ctx context.Context, l, r any) *DocumentChanges {
+	return compareDocuments(ctx, l, r)
+}
+
+func compareDocuments(ctx context.Context, l, r any) *DocumentChanges {
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -189,22 +201,28 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		}
 
 		// tags
-		dc.TagChanges = CompareTags(lDoc.Tags.Value, rDoc.Tags.Value)
+		dc.TagChanges = compareTags(ctx, lDoc.Tags.Value, rDoc.Tags.Value)
+		if tc := checkTagOrder(ctx, lDoc.Tags, rDoc.Tags); tc != nil {
+			dc.TagChanges = append(dc.TagChanges, tc)
+		}
 
 		// paths
 		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			dc.PathsChanges = comparePaths(ctx, lDoc.Paths.Value, rDoc.Paths.Value)
 		}
 
 		// external docs
-		compareDocumentExternalDocs(lDoc, rDoc, dc, &changes)
+		compareDocumentExternalDocs(ctx, lDoc, rDoc, dc, &changes)
 
 		// info
-		compareDocumentInfo(&lDoc.Info, &rDoc.Info, dc, &changes)
+		compareDocumentInfo(ctx, &lDoc.Info, &rDoc.Info, dc, &changes)
 
 		// security
 		if !lDoc.Security.IsEmpty() || !rDoc.Security.IsEmpty() {
-			checkSecurity(lDoc.Security, rDoc.Security, &changes, dc)
+			checkSecurity(ctx, lDoc.Security, rDoc.Security, &changes, dc)
+		}
+		if comparisonOptions(ctx).EffectiveSecurity {
+			compareEffectiveSecurity(ctx, lDoc.Security, rDoc.Security, lDoc.Paths.Value, rDoc.Paths.Value, dc)
 		}
 
 		// components / definitions
@@ -213,19 +231,19 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		// creating a new set of changes and then morphing them into a single changes object.
 		cc := new(ComponentsChanges)
 		cc.PropertyChanges = new(PropertyChanges)
-		if n := CompareComponents(lDoc.Definitions.Value, rDoc.Definitions.Value); n != nil {
+		if n := compareComponents(ctx, lDoc.Definitions.Value, rDoc.Definitions.Value); n != nil {
 			cc.SchemaChanges = n.SchemaChanges
 		}
-		if n := CompareComponents(lDoc.SecurityDefinitions.Value, rDoc.SecurityDefinitions.Value); n != nil {
+		if n := compareComponents(ctx, lDoc.SecurityDefinitions.Value, rDoc.SecurityDefinitions.Value); n != nil {
 			cc.SecuritySchemeChanges = n.SecuritySchemeChanges
 		}
-		if n := CompareComponents(lDoc.Parameters.Value, rDoc.Parameters.Value); n != nil {
+		if n := compareComponents(ctx, lDoc.Parameters.Value, rDoc.Parameters.Value); n != nil {
 			cc.PropertyChanges.Changes = append(cc.PropertyChanges.Changes, n.Changes...)
 		}
-		if n := CompareComponents(lDoc.Responses.Value, rDoc.Responses.Value); n != nil {
+		if n := compareComponents(ctx, lDoc.Responses.Value, rDoc.Responses.Value); n != nil {
 			cc.Changes = append(cc.Changes, n.Changes...)
 		}
-		dc.ExtensionChanges = CompareExtensions(lDoc.Extensions, rDoc.Extensions)
+		dc.ExtensionChanges = compareExtensions(ctx, lDoc.Extensions, rDoc.Extensions)
 		if cc.TotalChanges() > 0 {
 			dc.ComponentsChanges = cc
 		}
@@ -238,81 +256,90 @@ func CompareDocuments(l, r any) *DocumentChanges {
 		// version
 		addPropertyCheck(&props, lDoc.Version.ValueNode, rDoc.Version.ValueNode,
 			lDoc.Version.Value, rDoc.Version.Value, &changes, v3.OpenAPILabel,
-			BreakingModified(CompOpenAPI, ""), CompOpenAPI, "")
+			breakingModified(ctx, CompOpenAPI, ""), CompOpenAPI, "")
 
 		// schema dialect
 		addPropertyCheck(&props, lDoc.JsonSchemaDialect.ValueNode, rDoc.JsonSchemaDialect.ValueNode,
 			lDoc.JsonSchemaDialect.Value, rDoc.JsonSchemaDialect.Value, &changes, v3.JSONSchemaDialectLabel,
-			BreakingModified(CompJSONSchemaDialect, ""), CompJSONSchemaDialect, "")
+			breakingModified(ctx, CompJSONSchemaDialect, ""), CompJSONSchemaDialect, "")
 
 		// $self field (3.2+)
 		addPropertyCheck(&props, lDoc.Self.ValueNode, rDoc.Self.ValueNode,
 			lDoc.Self.Value, rDoc.Self.Value, &changes, v3.SelfLabel,
-			BreakingModified(CompSelf, ""), CompSelf, "")
+			breakingModified(ctx, CompSelf, ""), CompSelf, "")
 
 		// tags
-		dc.TagChanges = CompareTags(lDoc.Tags.Value, rDoc.Tags.Value)
+		dc.TagChanges = compareTags(ctx, lDoc.Tags.Value, rDoc.Tags.Value)
+		if tc := checkTagOrder(ctx, lDoc.Tags, rDoc.Tags); tc != nil {
+			dc.TagChanges = append(dc.TagChanges, tc)
+		}
 
 		// paths
 		if !lDoc.Paths.IsEmpty() || !rDoc.Paths.IsEmpty() {
-			dc.PathsChanges = ComparePaths(lDoc.Paths.Value, rDoc.Paths.Value)
+			dc.PathsChanges = comparePaths(ctx, lDoc.Paths.Value, rDoc.Paths.Value)
 		}
 
 		// external docs
-		compareDocumentExternalDocs(lDoc, rDoc, dc, &changes)
+		compareDocumentExternalDocs(ctx, lDoc, rDoc, dc, &changes)
 
 		// info
-		compareDocumentInfo(&lDoc.Info, &rDoc.Info, dc, &changes)
+		compareDocumentInfo(ctx, &lDoc.Info, &rDoc.Info, dc, &changes)
 
 		// security
 		if !lDoc.Security.IsEmpty() || !rDoc.Security.IsEmpty() {
-			checkSecurity(lDoc.Security, rDoc.Security, &changes, dc)
+			checkSecurity(ctx, lDoc.Security, rDoc.Security, &changes, dc)
+		}
+		if comparisonOptions(ctx).EffectiveSecurity {
+			compareEffectiveSecurity(ctx, lDoc.Security, rDoc.Security, lDoc.Paths.Value, rDoc.Paths.Value, dc)
 		}
 
 		// compare components.
 		if !lDoc.Components.IsEmpty() && !rDoc.Components.IsEmpty() {
-			if n := CompareComponents(lDoc.Components.Value, rDoc.Components.Value); n != nil {
+			if n := compareComponents(ctx, lDoc.Components.Value, rDoc.Components.Value); n != nil {
 				dc.ComponentsChanges = n
 			}
 		}
 		if !lDoc.Components.IsEmpty() && rDoc.Components.IsEmpty() {
 			CreateChange(&changes, PropertyRemoved, v3.ComponentsLabel,
-				lDoc.Components.ValueNode, nil, BreakingRemoved(CompComponents, ""), lDoc.Components.Value, nil)
+				lDoc.Components.ValueNode, nil, breakingRemoved(ctx, CompComponents, ""), lDoc.Components.Value, nil)
 		}
 		if lDoc.Components.IsEmpty() && !rDoc.Components.IsEmpty() {
 			CreateChange(&changes, PropertyAdded, v3.ComponentsLabel,
-				nil, rDoc.Components.ValueNode, BreakingAdded(CompComponents, ""), nil, lDoc.Components.Value)
+				nil, rDoc.Components.ValueNode, breakingAdded(ctx, CompComponents, ""), nil, lDoc.Components.Value)
 		}
 
 		// compare servers
-		if n := checkServers(lDoc.Servers, rDoc.Servers, CompServers, ""); n != nil {
+		if n := checkServers(ctx, lDoc.Servers, rDoc.Servers, CompServers, ""); n != nil {
 			dc.ServerChanges = n
 		}
 
 		// compare webhooks
-		dc.WebhookChanges = CheckMapForChanges(lDoc.Webhooks.Value, rDoc.Webhooks.Value, &changes,
-			v3.WebhooksLabel, ComparePathItemsV3)
+		dc.WebhookChanges = checkMapForChanges(ctx, lDoc.Webhooks.Value, rDoc.Webhooks.Value, &changes,
+			v3.WebhooksLabel, comparePathItemsV3)
 
 		// extensions
-		dc.ExtensionChanges = CompareExtensions(lDoc.Extensions, rDoc.Extensions)
+		dc.ExtensionChanges = compareExtensions(ctx, lDoc.Extensions, rDoc.Extensions)
 	}
 
-	CheckProperties(props)
-	dc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	dc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
+	ExplainChanges(dc)
+	AssignChangeIDs(dc)
 	base.SchemaQuickHashMap.Clear()
 	return dc
 }
 
-func compareDocumentExternalDocs(l, r low.HasExternalDocs, dc *DocumentChanges, changes *[]*Change) {
+func compareDocumentExternalDocs(ctx context.Context, l, r low.HasExternalDocs, dc *DocumentChanges, changes *[]*Change) {
 	// external docs
 	if !l.GetExternalDocs().IsEmpty() && !r.GetExternalDocs().IsEmpty() {
 		lExtDoc := l.GetExternalDocs().Value.(*base.ExternalDoc)
 		rExtDoc := r.GetExternalDocs().Value.(*base.ExternalDoc)
 		if !low.AreEqual(lExtDoc, rExtDoc) {
-			dc.ExternalDocChanges = CompareExternalDocs(lExtDoc, rExtDoc)
+			dc.ExternalDocChanges = compareExternalDocs(ctx, lExtDoc, rExtDoc)
 		}
 	}
 	if l.GetExternalDocs().IsEmpty() && !r.GetExternalDocs().IsEmpty() {
@@ -327,13 +354,13 @@ func compareDocumentExternalDocs(l, r low.HasExternalDocs, dc *DocumentChanges,
 	}
 }
 
-func compareDocumentInfo(l, r *low.NodeReference[*base.Info], dc *DocumentChanges, changes *[]*Change) {
+func compareDocumentInfo(ctx context.Context, l, r *low.NodeReference[*base.Info], dc *DocumentChanges, changes *[]*Change) {
 	// info
 	if !l.IsEmpty() && !r.IsEmpty() {
 		lInfo := l.Value
 		rInfo := r.Value
 		if !low.AreEqual(lInfo, rInfo) {
-			dc.InfoChanges = CompareInfo(lInfo, rInfo)
+			dc.InfoChanges = compareInfo(ctx, lInfo, rInfo)
 		}
 	}
 	if l.IsEmpty() && !r.IsEmpty() {
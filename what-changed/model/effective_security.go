@@ -0,0 +1,178 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/datamodel/low/base"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// securityRequirements is the security property of a document or an operation.
+type securityRequirements = low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]]
+
+// inheritsSecurity returns true if either operation inherits its security from the root of its document, when
+// comparing effective security (see ComparisonOptions). The security of these operations is compared by
+// CompareDocuments instead.
+func inheritsSecurity(ctx context.Context, l, r securityRequirements) bool {
+	return comparisonOptions(ctx).EffectiveSecurity && (l.IsEmpty() || r.IsEmpty())
+}
+
+// securedOperation is a Swagger or OpenAPI operation, with its own security.
+type securedOperation struct {
+	operation any
+	security  securityRequirements
+}
+
+// compareEffectiveSecurity compares the effective security of every operation found in both the left and right
+// paths, that inherits the root security of its document on at least one side. Changes are added to the operation
+// changes of the document, which are created if the operation has not changed otherwise.
+func compareEffectiveSecurity(ctx context.Context, lSecurity, rSecurity securityRequirements, lPaths, rPaths any, dc *DocumentChanges) {
+	lOps := pathOperations(lPaths)
+	rOps := pathOperations(rPaths)
+	for path, lMethods := range lOps {
+		rMethods, ok := rOps[path]
+		if !ok {
+			continue
+		}
+		for method, l := range lMethods {
+			r, ok := rMethods[method]
+			if !ok || !(l.security.IsEmpty() || r.security.IsEmpty()) {
+				continue
+			}
+			lEffective, rEffective := l.security, r.security
+			if lEffective.IsEmpty() {
+				lEffective = lSecurity
+			}
+			if rEffective.IsEmpty() {
+				rEffective = rSecurity
+			}
+			if lEffective.IsEmpty() && rEffective.IsEmpty() {
+				continue
+			}
+			sc := new(OperationChanges)
+			checkSecurity(ctx, lEffective, rEffective, nil, sc)
+			if len(sc.SecurityRequirementChanges) == 0 {
+				continue
+			}
+			oc := dc.operationChanges(path, method)
+			if oc.operationID == "" {
+				oc.operationID = operationID(r.operation)
+			}
+			if oc.operationID == "" {
+				oc.operationID = operationID(l.operation)
+			}
+			oc.SecurityRequirementChanges = sc.SecurityRequirementChanges
+		}
+	}
+}
+
+// operationChanges returns the changes made to an operation of a path (by method), creating the changes of the
+// paths, path item and operation if nothing has changed yet.
+func (d *DocumentChanges) operationChanges(path, method string) *OperationChanges {
+	if d.PathsChanges == nil {
+		d.PathsChanges = &PathsChanges{PropertyChanges: NewPropertyChanges(nil)}
+	}
+	if d.PathsChanges.PathItemsChanges == nil {
+		d.PathsChanges.PathItemsChanges = make(map[string]*PathItemChanges)
+	}
+	pc := d.PathsChanges.PathItemsChanges[path]
+	if pc == nil {
+		pc = &PathItemChanges{PropertyChanges: NewPropertyChanges(nil)}
+		d.PathsChanges.PathItemsChanges[path] = pc
+	}
+	var oc **OperationChanges
+	switch method {
+	case v3.GetLabel:
+		oc = &pc.GetChanges
+	case v3.PutLabel:
+		oc = &pc.PutChanges
+	case v3.PostLabel:
+		oc = &pc.PostChanges
+	case v3.DeleteLabel:
+		oc = &pc.DeleteChanges
+	case v3.OptionsLabel:
+		oc = &pc.OptionsChanges
+	case v3.HeadLabel:
+		oc = &pc.HeadChanges
+	case v3.PatchLabel:
+		oc = &pc.PatchChanges
+	case v3.TraceLabel:
+		oc = &pc.TraceChanges
+	case v3.QueryLabel:
+		oc = &pc.QueryChanges
+	default:
+		if pc.AdditionalOperationChanges == nil {
+			pc.AdditionalOperationChanges = make(map[string]*OperationChanges)
+		}
+		if pc.AdditionalOperationChanges[method] == nil {
+			pc.AdditionalOperationChanges[method] = &OperationChanges{PropertyChanges: NewPropertyChanges(nil)}
+		}
+		return pc.AdditionalOperationChanges[method]
+	}
+	if *oc == nil {
+		*oc = &OperationChanges{PropertyChanges: NewPropertyChanges(nil)}
+	}
+	return *oc
+}
+
+// pathOperations returns the operations of Swagger or OpenAPI paths, by path and then by method.
+func pathOperations(paths any) map[string]map[string]securedOperation {
+	ops := make(map[string]map[string]securedOperation)
+	switch p := paths.(type) {
+	case *v3.Paths:
+		if p == nil || p.PathItems == nil {
+			return ops
+		}
+		for k, v := range p.PathItems.FromOldest() {
+			pi := v.Value
+			if pi == nil {
+				continue
+			}
+			methods := make(map[string]securedOperation)
+			for method, op := range map[string]*v3.Operation{
+				v3.GetLabel: pi.Get.Value, v3.PutLabel: pi.Put.Value, v3.PostLabel: pi.Post.Value,
+				v3.DeleteLabel: pi.Delete.Value, v3.OptionsLabel: pi.Options.Value, v3.HeadLabel: pi.Head.Value,
+				v3.PatchLabel: pi.Patch.Value, v3.TraceLabel: pi.Trace.Value, v3.QueryLabel: pi.Query.Value,
+			} {
+				if op != nil {
+					methods[method] = securedOperation{operation: op, security: op.Security}
+				}
+			}
+			if pi.AdditionalOperations.Value != nil {
+				for method, op := range pi.AdditionalOperations.Value.FromOldest() {
+					if op.Value != nil {
+						methods[method.Value] = securedOperation{operation: op.Value, security: op.Value.Security}
+					}
+				}
+			}
+			ops[k.Value] = methods
+		}
+	case *v2.Paths:
+		if p == nil || p.PathItems == nil {
+			return ops
+		}
+		for k, v := range p.PathItems.FromOldest() {
+			pi := v.Value
+			if pi == nil {
+				continue
+			}
+			methods := make(map[string]securedOperation)
+			for method, op := range map[string]*v2.Operation{
+				v3.GetLabel: pi.Get.Value, v3.PutLabel: pi.Put.Value, v3.PostLabel: pi.Post.Value,
+				v3.DeleteLabel: pi.Delete.Value, v3.OptionsLabel: pi.Options.Value, v3.HeadLabel: pi.Head.Value,
+				v3.PatchLabel: pi.Patch.Value,
+			} {
+				if op != nil {
+					methods[method] = securedOperation{operation: op, security: op.Security}
+				}
+			}
+			ops[k.Value] = methods
+		}
+	}
+	return ops
+}
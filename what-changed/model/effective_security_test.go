@@ -0,0 +1,138 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var effectiveSecurityLeft = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+security:
+  - apiKey: []
+  - oauth: [read]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createPet
+      security:
+        - oauth: [write]
+      responses:
+        "201":
+          description: Created
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        "200":
+          description: OK`
+
+var effectiveSecurityRight = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+security:
+  - oauth: [read]
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createPet
+      security:
+        - oauth: [write]
+      responses:
+        "201":
+          description: Created
+  /health:
+    get:
+      operationId: health
+      security: []
+      responses:
+        "200":
+          description: OK`
+
+var effectiveSecurityOptions = &ComparisonOptions{EffectiveSecurity: true}
+
+func TestCompareDocuments_EffectiveSecurity_Disabled(t *testing.T) {
+	changes := compareReportDocuments(t, effectiveSecurityLeft, effectiveSecurityRight)
+	require.NotNil(t, changes)
+	assert.Len(t, changes.SecurityRequirementChanges, 1)
+	assert.Nil(t, changes.PathsChanges)
+	assert.Equal(t, 1, changes.TotalChanges())
+}
+
+func TestCompareDocuments_EffectiveSecurity(t *testing.T) {
+	changes := compareReportDocumentsWithOptions(t, effectiveSecurityOptions, effectiveSecurityLeft, effectiveSecurityRight)
+	require.NotNil(t, changes)
+	assert.Len(t, changes.SecurityRequirementChanges, 1)
+
+	// only the operation that inherits the root security has changed.
+	require.NotNil(t, changes.PathsChanges)
+	require.Len(t, changes.PathsChanges.PathItemsChanges, 1)
+	pets := changes.PathsChanges.PathItemsChanges["/pets"]
+	require.NotNil(t, pets)
+	assert.Nil(t, pets.PostChanges)
+	require.NotNil(t, pets.GetChanges)
+	require.Len(t, pets.GetChanges.SecurityRequirementChanges, 1)
+	c := pets.GetChanges.SecurityRequirementChanges[0].Changes[0]
+	assert.Equal(t, ObjectRemoved, c.ChangeType)
+	assert.Equal(t, "apiKey", c.Property)
+	assert.True(t, c.Breaking)
+
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+
+	summary := changes.EndpointSummary()
+	require.Len(t, summary.Modified, 1)
+	assert.Equal(t, "listPets", summary.Modified[0].OperationID)
+}
+
+func TestCompareDocuments_EffectiveSecurity_MovedToOperation(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+security:
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: OK`
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      security:
+        - apiKey: []
+      responses:
+        "200":
+          description: OK`
+
+	// the operation is still secured the same way, only the root security was removed.
+	changes := compareReportDocumentsWithOptions(t, effectiveSecurityOptions, left, right)
+	require.NotNil(t, changes)
+	assert.Len(t, changes.SecurityRequirementChanges, 1)
+	assert.Zero(t, changes.PathsChanges.TotalChanges())
+	assert.Equal(t, 1, changes.TotalChanges())
+}
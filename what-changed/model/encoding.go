@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
 
@@ -54,6 +56,10 @@ func (e *EncodingChanges) TotalBreakingChanges() int {
 // CompareEncoding returns a pointer to *EncodingChanges that contain all changes made between a left and right
 // set of Encoding objects.
 func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
+	return compareEncoding(context.Background(), l, r)
+}
+
+func compareEncoding(ctx context.Context, l, r *v3.Encoding) *EncodingChanges {
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 4)
 
@@ -73,12 +79,13 @@ func CompareEncoding(l, r *v3.Encoding) *EncodingChanges {
 	)
 
 	// check everything.
-	CheckProperties(props)
+	checkProperties(ctx, props)
 	ec := new(EncodingChanges)
 
 	// headers
-	ec.HeaderChanges = CheckMapForChanges(l.Headers.Value, r.Headers.Value, &changes, v3.HeadersLabel, CompareHeadersV3)
-	ec.PropertyChanges = NewPropertyChanges(changes)
+	ec.HeaderChanges = checkMapForChanges(ctx, l.Headers.Value, r.Headers.Value, &changes, v3.HeadersLabel, compareHeadersV3)
+	checkComparators(l, r, &changes)
+	ec.PropertyChanges = newPropertyChanges(ctx, changes)
 	if ec.TotalChanges() <= 0 {
 		return nil
 	}
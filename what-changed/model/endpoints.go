@@ -0,0 +1,247 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/orderedmap"
+)
+
+// Endpoint is an operation (a method of a path) that was added, removed or modified between two documents.
+type Endpoint struct {
+	Method      string `json:"method" yaml:"method"`
+	Path        string `json:"path" yaml:"path"`
+	OperationID string `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+
+	// TotalChanges is the number of changes made to the operation. An operation that was added or removed is a
+	// single change.
+	TotalChanges int `json:"total" yaml:"total"`
+
+	// BreakingChanges is the number of breaking changes made to the operation.
+	BreakingChanges int `json:"breaking" yaml:"breaking"`
+}
+
+// EndpointSummary lists the operations that were added, removed and modified between two documents, sorted by
+// path and then by method.
+type EndpointSummary struct {
+	Added    []*Endpoint `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed  []*Endpoint `json:"removed,omitempty" yaml:"removed,omitempty"`
+	Modified []*Endpoint `json:"modified,omitempty" yaml:"modified,omitempty"`
+}
+
+// TotalBreakingChanges returns the number of breaking changes made to every endpoint of the summary.
+func (s *EndpointSummary) TotalBreakingChanges() int {
+	if s == nil {
+		return 0
+	}
+	c := 0
+	for _, endpoints := range [][]*Endpoint{s.Added, s.Removed, s.Modified} {
+		for _, e := range endpoints {
+			c += e.BreakingChanges
+		}
+	}
+	return c
+}
+
+// endpointMethods are the methods of a path item, in the order endpoints are sorted.
+var endpointMethods = []string{
+	v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+	v3.TraceLabel, v3.QueryLabel,
+}
+
+// EndpointSummary returns the operations that were added, removed and modified, for example to answer which
+// endpoints a new version of an API adds or breaks, without walking the tree of changes. Adding or removing a
+// whole path adds or removes every operation of the path.
+//
+// Operations are modified when changes were made to the operation itself. Changes made to a path item that are
+// shared by its operations (such as the parameters of the path item) are not counted for each operation.
+func (d *DocumentChanges) EndpointSummary() *EndpointSummary {
+	summary := new(EndpointSummary)
+	if d == nil || d.PathsChanges == nil {
+		return summary
+	}
+	for _, c := range d.PathsChanges.Changes {
+		path := c.Property
+		switch c.ChangeType {
+		case ObjectAdded, PropertyAdded:
+			summary.Added = append(summary.Added, pathItemEndpoints(path, c.NewObject, c.Breaking)...)
+		case ObjectRemoved, PropertyRemoved:
+			summary.Removed = append(summary.Removed, pathItemEndpoints(path, c.OriginalObject, c.Breaking)...)
+		}
+	}
+	for path, pc := range d.PathsChanges.PathItemsChanges {
+		if pc == nil {
+			continue
+		}
+		if pc.PropertyChanges != nil {
+			for _, c := range pc.Changes {
+				added := c.ChangeType == ObjectAdded || c.ChangeType == PropertyAdded
+				removed := c.ChangeType == ObjectRemoved || c.ChangeType == PropertyRemoved
+				if !added && !removed {
+					continue
+				}
+				object := c.OriginalObject
+				if added {
+					object = c.NewObject
+				}
+				var endpoints []*Endpoint
+				switch {
+				case slices.Contains(endpointMethods, c.Property):
+					endpoints = []*Endpoint{newEndpoint(c.Property, path, object, c.Breaking)}
+				case c.Property == v3.AdditionalOperationsLabel:
+					endpoints = additionalEndpoints(path, object, c.Breaking)
+				}
+				if added {
+					summary.Added = append(summary.Added, endpoints...)
+				} else {
+					summary.Removed = append(summary.Removed, endpoints...)
+				}
+			}
+		}
+		for method, oc := range pc.operationChanges() {
+			if oc == nil || oc.TotalChanges() == 0 {
+				continue
+			}
+			summary.Modified = append(summary.Modified, &Endpoint{
+				Method:          method,
+				Path:            path,
+				OperationID:     oc.operationID,
+				TotalChanges:    oc.TotalChanges(),
+				BreakingChanges: oc.TotalBreakingChanges(),
+			})
+		}
+	}
+	for _, endpoints := range [][]*Endpoint{summary.Added, summary.Removed, summary.Modified} {
+		slices.SortFunc(endpoints, compareEndpoints)
+	}
+	return summary
+}
+
+// operationChanges returns the changes made to every operation of a path item, by method.
+func (p *PathItemChanges) operationChanges() map[string]*OperationChanges {
+	operations := map[string]*OperationChanges{
+		v3.GetLabel:     p.GetChanges,
+		v3.PutLabel:     p.PutChanges,
+		v3.PostLabel:    p.PostChanges,
+		v3.DeleteLabel:  p.DeleteChanges,
+		v3.OptionsLabel: p.OptionsChanges,
+		v3.HeadLabel:    p.HeadChanges,
+		v3.PatchLabel:   p.PatchChanges,
+		v3.TraceLabel:   p.TraceChanges,
+		v3.QueryLabel:   p.QueryChanges,
+	}
+	for method, oc := range p.AdditionalOperationChanges {
+		operations[method] = oc
+	}
+	return operations
+}
+
+// newEndpoint returns an endpoint that was added or removed, as a single change.
+func newEndpoint(method, path string, operation any, breaking bool) *Endpoint {
+	e := &Endpoint{Method: method, Path: path, OperationID: operationID(operation), TotalChanges: 1}
+	if breaking {
+		e.BreakingChanges = 1
+	}
+	if op, ok := operation.(*v3.Operation); ok && op != nil && !slices.Contains(endpointMethods, method) {
+		// additional operations are keyed by their method.
+		if op.KeyNode != nil {
+			e.Method = op.KeyNode.Value
+		}
+	}
+	return e
+}
+
+// pathItemEndpoints returns every operation of a Swagger or OpenAPI path item that was added or removed.
+func pathItemEndpoints(path string, pathItem any, breaking bool) []*Endpoint {
+	var endpoints []*Endpoint
+	switch p := pathItem.(type) {
+	case *v3.PathItem:
+		if p == nil {
+			return nil
+		}
+		for method, op := range map[string]*v3.Operation{
+			v3.GetLabel: p.Get.Value, v3.PutLabel: p.Put.Value, v3.PostLabel: p.Post.Value,
+			v3.DeleteLabel: p.Delete.Value, v3.OptionsLabel: p.Options.Value, v3.HeadLabel: p.Head.Value,
+			v3.PatchLabel: p.Patch.Value, v3.TraceLabel: p.Trace.Value, v3.QueryLabel: p.Query.Value,
+		} {
+			if op != nil {
+				endpoints = append(endpoints, newEndpoint(method, path, op, breaking))
+			}
+		}
+		endpoints = append(endpoints, additionalEndpoints(path, p.AdditionalOperations.Value, breaking)...)
+	case *v2.PathItem:
+		if p == nil {
+			return nil
+		}
+		for method, op := range map[string]*v2.Operation{
+			v3.GetLabel: p.Get.Value, v3.PutLabel: p.Put.Value, v3.PostLabel: p.Post.Value,
+			v3.DeleteLabel: p.Delete.Value, v3.OptionsLabel: p.Options.Value, v3.HeadLabel: p.Head.Value,
+			v3.PatchLabel: p.Patch.Value,
+		} {
+			if op != nil {
+				endpoints = append(endpoints, newEndpoint(method, path, op, breaking))
+			}
+		}
+	}
+	return endpoints
+}
+
+// additionalEndpoints returns the endpoints of the additional operations of a path item (OpenAPI 3.2+), which is
+// either a single operation, or every additional operation of the path item.
+func additionalEndpoints(path string, operations any, breaking bool) []*Endpoint {
+	switch ops := operations.(type) {
+	case *v3.Operation:
+		if ops != nil {
+			return []*Endpoint{newEndpoint("", path, ops, breaking)}
+		}
+	case *orderedmap.Map[low.KeyReference[string], low.NodeReference[*v3.Operation]]:
+		var endpoints []*Endpoint
+		for k, v := range ops.FromOldest() {
+			if v.Value != nil {
+				endpoints = append(endpoints, newEndpoint(k.Value, path, v.Value, breaking))
+			}
+		}
+		return endpoints
+	}
+	return nil
+}
+
+// operationID returns the operationId of a Swagger or OpenAPI operation.
+func operationID(operation any) string {
+	switch op := operation.(type) {
+	case *v3.Operation:
+		if op != nil {
+			return op.OperationId.Value
+		}
+	case *v2.Operation:
+		if op != nil {
+			return op.OperationId.Value
+		}
+	}
+	return ""
+}
+
+// compareEndpoints sorts endpoints by path, then by method, with additional operations last.
+func compareEndpoints(a, b *Endpoint) int {
+	if c := strings.Compare(a.Path, b.Path); c != 0 {
+		return c
+	}
+	ai, bi := methodIndex(a.Method), methodIndex(b.Method)
+	if ai != bi {
+		return ai - bi
+	}
+	return strings.Compare(a.Method, b.Method)
+}
+
+func methodIndex(method string) int {
+	if i := slices.Index(endpointMethods, method); i >= 0 {
+		return i
+	}
+	return len(endpointMethods)
+}
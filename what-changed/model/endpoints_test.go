@@ -0,0 +1,182 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var endpointsLeft = `openapi: 3.2.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: OK
+    delete:
+      operationId: deletePets
+      responses:
+        "204":
+          description: Deleted
+  /stores:
+    get:
+      operationId: listStores
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createStore
+      responses:
+        "201":
+          description: Created`
+
+var endpointsRight = `openapi: 3.2.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: OK
+    post:
+      operationId: createPet
+      responses:
+        "201":
+          description: Created
+    additionalOperations:
+      COPY:
+        operationId: copyPets
+        responses:
+          "200":
+            description: OK
+  /owners:
+    get:
+      operationId: listOwners
+      responses:
+        "200":
+          description: OK
+    put:
+      operationId: updateOwners
+      responses:
+        "200":
+          description: OK`
+
+func TestDocumentChanges_EndpointSummary(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+	summary := changes.EndpointSummary()
+
+	require.Len(t, summary.Added, 4)
+	assert.Equal(t, &Endpoint{Method: "get", Path: "/owners", OperationID: "listOwners", TotalChanges: 1},
+		summary.Added[0])
+	assert.Equal(t, "put", summary.Added[1].Method)
+	assert.Equal(t, "updateOwners", summary.Added[1].OperationID)
+	assert.Equal(t, &Endpoint{Method: "post", Path: "/pets", OperationID: "createPet", TotalChanges: 1},
+		summary.Added[2])
+	assert.Equal(t, &Endpoint{Method: "COPY", Path: "/pets", OperationID: "copyPets", TotalChanges: 1},
+		summary.Added[3])
+
+	require.Len(t, summary.Removed, 3)
+	assert.Equal(t, &Endpoint{Method: "delete", Path: "/pets", OperationID: "deletePets", TotalChanges: 1,
+		BreakingChanges: 1}, summary.Removed[0])
+	assert.Equal(t, "/stores", summary.Removed[1].Path)
+	assert.Equal(t, "get", summary.Removed[1].Method)
+	assert.Equal(t, "listStores", summary.Removed[1].OperationID)
+	assert.Equal(t, "post", summary.Removed[2].Method)
+	assert.Equal(t, "createStore", summary.Removed[2].OperationID)
+	assert.Equal(t, 1, summary.Removed[2].BreakingChanges)
+
+	require.Len(t, summary.Modified, 1)
+	get := summary.Modified[0]
+	assert.Equal(t, "get", get.Method)
+	assert.Equal(t, "/pets", get.Path)
+	assert.Equal(t, "listPets", get.OperationID)
+	assert.Equal(t, changes.PathsChanges.PathItemsChanges["/pets"].GetChanges.TotalChanges(), get.TotalChanges)
+	assert.Equal(t, 1, get.BreakingChanges)
+
+	assert.Equal(t, 4, summary.TotalBreakingChanges())
+}
+
+func TestDocumentChanges_EndpointSummary_Swagger(t *testing.T) {
+	left := `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: OK`
+	right := `swagger: "2.0"
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listAllPets
+      responses:
+        "200":
+          description: OK
+    patch:
+      operationId: patchPets
+      responses:
+        "200":
+          description: OK`
+
+	siLeft, _ := datamodel.ExtractSpecInfo([]byte(left))
+	siRight, _ := datamodel.ExtractSpecInfo([]byte(right))
+	lDoc, _ := v2.CreateDocumentFromConfig(siLeft, datamodel.NewDocumentConfiguration())
+	rDoc, _ := v2.CreateDocumentFromConfig(siRight, datamodel.NewDocumentConfiguration())
+
+	changes := CompareDocuments(lDoc, rDoc)
+	require.NotNil(t, changes)
+	summary := changes.EndpointSummary()
+	require.Len(t, summary.Added, 1)
+	assert.Equal(t, "patch", summary.Added[0].Method)
+	assert.Equal(t, "patchPets", summary.Added[0].OperationID)
+	require.Len(t, summary.Modified, 1)
+	assert.Equal(t, "listAllPets", summary.Modified[0].OperationID)
+	assert.Empty(t, summary.Removed)
+}
+
+func TestDocumentChanges_EndpointSummary_Inverted(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+	InvertChanges(changes)
+	summary := changes.EndpointSummary()
+	assert.Len(t, summary.Added, 3)
+	assert.Len(t, summary.Removed, 4)
+}
+
+func TestDocumentChanges_EndpointSummary_Empty(t *testing.T) {
+	var changes *DocumentChanges
+	assert.Equal(t, &EndpointSummary{}, changes.EndpointSummary())
+	assert.Zero(t, changes.EndpointSummary().TotalBreakingChanges())
+}
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"sort"
 
@@ -53,21 +54,25 @@ func (e *ExampleChanges) TotalBreakingChanges() int {
 // CompareExamples returns a pointer to ExampleChanges that contains all changes made between
 // left and right Example instances. If l is nil, the example was added. If r is nil, it was removed.
 func CompareExamples(l, r *base.Example) *ExampleChanges {
+	return compareExamples(context.Background(), l, r)
+}
+
+func compareExamples(ctx context.Context, l, r *base.Example) *ExampleChanges {
 	ec := new(ExampleChanges)
 	var changes []*Change
 
 	if l == nil {
 		// Example was added - use RootNode for proper line/column location
 		CreateChange(&changes, ObjectAdded, v3.ExampleLabel,
-			nil, r.RootNode, BreakingAdded(CompExample, PropValue), nil, r)
-		ec.PropertyChanges = NewPropertyChanges(changes)
+			nil, r.RootNode, breakingAdded(ctx, CompExample, PropValue), nil, r)
+		ec.PropertyChanges = newPropertyChanges(ctx, changes)
 		return ec
 	}
 	if r == nil {
 		// Example was removed - use RootNode for proper line/column location
 		CreateChange(&changes, ObjectRemoved, v3.ExampleLabel,
-			l.RootNode, nil, BreakingRemoved(CompExample, PropValue), l, nil)
-		ec.PropertyChanges = NewPropertyChanges(changes)
+			l.RootNode, nil, breakingRemoved(ctx, CompExample, PropValue), l, nil)
+		ec.PropertyChanges = newPropertyChanges(ctx, changes)
 		return ec
 	}
 
@@ -82,8 +87,10 @@ func CompareExamples(l, r *base.Example) *ExampleChanges {
 			v3.DescriptionLabel, &changes, l, r),
 	)
 
-	// Value
-	if utils.IsNodeMap(l.Value.ValueNode) && utils.IsNodeMap(r.Value.ValueNode) {
+	// Value, values that are written differently but are the same example are not a change.
+	switch {
+	case l.Value.ValueNode != nil && r.Value.ValueNode != nil && ExampleValuesEqual(l.Value.ValueNode, r.Value.ValueNode):
+	case utils.IsNodeMap(l.Value.ValueNode) && utils.IsNodeMap(r.Value.ValueNode):
 		lKeys := make([]string, len(l.Value.ValueNode.Content)/2)
 		rKeys := make([]string, len(r.Value.ValueNode.Content)/2)
 		z := 0
@@ -127,21 +134,21 @@ func CompareExamples(l, r *base.Example) *ExampleChanges {
 		for k := range lKeys {
 			if k < len(rKeys) && lKeys[k] != rKeys[k] {
 				CreateChangeWithEncoding(&changes, Modified, v3.ValueLabel,
-					l.Value.GetValueNode(), r.Value.GetValueNode(), BreakingModified(CompExample, PropValue), l.Value.GetValue(), r.Value.GetValue())
+					l.Value.GetValueNode(), r.Value.GetValueNode(), breakingModified(ctx, CompExample, PropValue), l.Value.GetValue(), r.Value.GetValue())
 				continue
 			}
 			if k >= len(rKeys) {
 				CreateChangeWithEncoding(&changes, PropertyRemoved, v3.ValueLabel,
-					l.Value.ValueNode, r.Value.ValueNode, BreakingRemoved(CompExample, PropValue), l.Value.Value, r.Value.Value)
+					l.Value.ValueNode, r.Value.ValueNode, breakingRemoved(ctx, CompExample, PropValue), l.Value.Value, r.Value.Value)
 			}
 		}
 		for k := range rKeys {
 			if k >= len(lKeys) {
 				CreateChangeWithEncoding(&changes, PropertyAdded, v3.ValueLabel,
-					l.Value.ValueNode, r.Value.ValueNode, BreakingAdded(CompExample, PropValue), l.Value.Value, r.Value.Value)
+					l.Value.ValueNode, r.Value.ValueNode, breakingAdded(ctx, CompExample, PropValue), l.Value.Value, r.Value.Value)
 			}
 		}
-	} else {
+	default:
 		props = append(props, NewPropertyCheck(CompExample, PropValue,
 			l.Value.ValueNode, r.Value.ValueNode,
 			v3.ValueLabel, &changes, l, r))
@@ -162,11 +169,12 @@ func CompareExamples(l, r *base.Example) *ExampleChanges {
 		base.SerializedValueLabel, &changes, l, r))
 
 	// check properties
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	// check extensions
-	ec.ExtensionChanges = CheckExtensions(l, r)
-	ec.PropertyChanges = NewPropertyChanges(changes)
+	ec.ExtensionChanges = checkExtensions(ctx, l, r)
+	checkComparators(l, r, &changes)
+	ec.PropertyChanges = newPropertyChanges(ctx, changes)
 	if ec.TotalChanges() <= 0 {
 		return nil
 	}
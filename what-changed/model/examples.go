@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	"go.yaml.in/yaml/v4"
@@ -38,18 +40,23 @@ func (a *ExamplesChanges) TotalBreakingChanges() int {
 // CompareExamplesV2 compares two Swagger Examples objects, returning a pointer to
 // ExamplesChanges if anything was found.
 func CompareExamplesV2(l, r *v2.Examples) *ExamplesChanges {
+	return compareExamplesV2(context.Background(), l, r)
+}
+
+func compareExamplesV2(ctx context.Context, l, r *v2.Examples) *ExamplesChanges {
 	lHashes := make(map[string]string)
 	rHashes := make(map[string]string)
 	lValues := make(map[string]low.ValueReference[*yaml.Node])
 	rValues := make(map[string]low.ValueReference[*yaml.Node])
 
+	// examples are hashed by their content, so re-formatted examples are not a change.
 	for k, v := range l.Values.FromOldest() {
-		lHashes[k.Value] = low.GenerateHashString(v.Value)
+		lHashes[k.Value] = low.GenerateHashString(canonicalExample(v.Value))
 		lValues[k.Value] = v
 	}
 
 	for k, v := range r.Values.FromOldest() {
-		rHashes[k.Value] = low.GenerateHashString(v.Value)
+		rHashes[k.Value] = low.GenerateHashString(canonicalExample(v.Value))
 		rValues[k.Value] = v
 	}
 	var changes []*Change
@@ -83,8 +90,9 @@ func CompareExamplesV2(l, r *v2.Examples) *ExamplesChanges {
 		}
 	}
 
+	checkComparators(l, r, &changes)
 	ex := new(ExamplesChanges)
-	ex.PropertyChanges = NewPropertyChanges(changes)
+	ex.PropertyChanges = newPropertyChanges(ctx, changes)
 	if ex.TotalChanges() <= 0 {
 		return nil
 	}
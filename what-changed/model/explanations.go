@@ -0,0 +1,228 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+)
+
+// singularLabels are the names used for a single value of a property that holds many.
+var singularLabels = map[string]string{
+	v3.PropertiesLabel: "property",
+	v3.ParametersLabel: "parameter",
+	v3.HeadersLabel:    "header",
+	v3.EnumLabel:       "enum value",
+	v3.ResponsesLabel:  "response",
+	v3.TagsLabel:       "tag",
+	v3.ServersLabel:    "server",
+	v3.SchemasLabel:    "schema",
+	v3.ExamplesLabel:   "example",
+	v3.CallbacksLabel:  "callback",
+	v3.LinksLabel:      "link",
+	v3.CodesLabel:      "response code",
+}
+
+// ExplainChanges walks any tree of changes (see LocateChanges) and sets the Explanation of every breaking change,
+// clearing the explanation of anything that is not breaking. It's called when documents are compared, and only
+// needs to be called again if the changes are re-classified.
+func ExplainChanges(changes any) {
+	for _, lc := range LocateChanges(changes) {
+		if lc.Change.Breaking {
+			lc.Change.Explanation = ExplainChange(lc.Change, lc.Location)
+		} else {
+			lc.Change.Explanation = ""
+		}
+	}
+}
+
+// ExplainChange returns a short, human-readable explanation of a change made at a location in a tree of changes
+// (see LocateChanges), describing what changed and what it means for the clients of an API, for example:
+//
+//	response property `id` changed type from `string` to `integer` — clients parsing this value will fail
+func ExplainChange(c *Change, location []string) string {
+	request, known := IsRequestLocation(location)
+	side := ""
+	if known {
+		side = "response"
+		if request {
+			side = "request"
+		}
+	}
+
+	subject, parent := explainSubject(location, side)
+	property := c.Property
+	added := c.ChangeType == PropertyAdded || c.ChangeType == ObjectAdded
+	value, verb, preposition := c.Original, "removed", "from"
+	if added {
+		value, verb, preposition = c.New, "added", "to"
+	}
+
+	var what string
+	switch {
+	case c.ChangeType == ObjectRenamed:
+		what = fmt.Sprintf("`%s` was renamed from `%s` to `%s`", property, c.Original, c.New)
+	case c.ChangeType == ReferenceRepointed:
+		target := "a different schema"
+		if c.ResolvedEqual {
+			target = "an identical schema"
+		}
+		what = fmt.Sprintf("reference was repointed from `%s` to `%s`, which is %s", c.Original, c.New, target)
+	case c.ChangeType == Reordered:
+		what = fmt.Sprintf("`%s` was reordered from `%s` to `%s`", property, c.Original, c.New)
+	case c.ChangeType == Modified && property == v3.TypeLabel && subject != "":
+		what = fmt.Sprintf("changed type from `%s` to `%s`", c.Original, c.New)
+	case c.ChangeType == Modified && c.Original != "" && c.New != "":
+		what = fmt.Sprintf("`%s` changed from `%s` to `%s`", property, c.Original, c.New)
+	case c.ChangeType == Modified:
+		what = fmt.Sprintf("`%s` was modified", property)
+	case property == v3.RequiredLabel && value != "":
+		// the property that became required (or optional) is the subject.
+		what = explainProperty(side, parent, value) + " is no longer required"
+		if added {
+			what = explainProperty(side, parent, value) + " became required"
+		}
+		subject = ""
+	case strings.HasPrefix(property, "x-") && c.ChangeType != Modified:
+		what = fmt.Sprintf("extension `%s` was %s", property, verb)
+		if subject != "" {
+			what += " " + preposition + " " + subject
+			subject = ""
+		}
+	case singularLabels[property] == "property" && value != "":
+		what = explainProperty(side, parent, value) + " was " + verb
+		subject = ""
+	case value != "" && value != property:
+		singular := singularLabels[property]
+		if singular == "" {
+			singular = property
+		}
+		what = fmt.Sprintf("%s `%s` was %s", singular, value, verb)
+		if subject != "" {
+			what += " " + preposition + " " + subject
+			subject = ""
+		}
+	default:
+		what = fmt.Sprintf("`%s` was %s", property, verb)
+	}
+
+	explanation := what
+	if subject != "" {
+		explanation = subject + " " + what
+	}
+	return explanation + " — " + explainConsequence(c, side)
+}
+
+// explainProperty describes a property of a schema, for example "response property `pet.id`".
+func explainProperty(side, parent, name string) string {
+	if parent != "" {
+		name = parent + "." + name
+	}
+	if side != "" {
+		return fmt.Sprintf("%s property `%s`", side, name)
+	}
+	return fmt.Sprintf("property `%s`", name)
+}
+
+// explainSubject describes the object changed at a location, for example "response property `id`". The name of
+// the property (if the object is a property of a schema) is also returned.
+func explainSubject(location []string, side string) (string, string) {
+	var property []string
+	var kind, name, method, path, code string
+	for i := 0; i < len(location); i++ {
+		segment := location[i]
+		next := ""
+		if i+1 < len(location) {
+			next = location[i+1]
+		}
+		switch {
+		case segment == v3.PropertiesLabel && next != "":
+			property = append(property, next)
+			i++
+		case (segment == v3.ParametersLabel || segment == v3.HeadersLabel) && next != "" && len(property) == 0:
+			kind, name = singularLabels[segment], next
+			i++
+		case segment == "response" && next != "":
+			code = next
+			i++
+		case segment == "pathItems" && next != "":
+			path, method = next, ""
+			i++
+		case path != "" && method == "" && isOperationLabel(segment):
+			method = segment
+		case segment == v3.ComponentsLabel && next == v3.SchemasLabel && i+2 < len(location):
+			kind, name = "schema", location[i+2]
+			i += 2
+		}
+	}
+	switch {
+	case len(property) > 0:
+		return explainProperty(side, "", strings.Join(property, ".")), strings.Join(property, ".")
+	case kind == "header" && side != "":
+		return fmt.Sprintf("%s header `%s`", side, name), ""
+	case kind != "":
+		return fmt.Sprintf("%s `%s`", kind, name), ""
+	case side == "request":
+		return "request body", ""
+	case side == "response" && code != "":
+		return fmt.Sprintf("response `%s`", code), ""
+	case side == "response":
+		return "response", ""
+	case method != "":
+		return fmt.Sprintf("operation `%s %s`", strings.ToUpper(method), path), ""
+	case path != "":
+		return fmt.Sprintf("path `%s`", path), ""
+	}
+	return "", ""
+}
+
+// isOperationLabel determines if a segment of a location is the method of an operation.
+func isOperationLabel(segment string) bool {
+	switch segment {
+	case v3.GetLabel, v3.PutLabel, v3.PostLabel, v3.DeleteLabel, v3.OptionsLabel, v3.HeadLabel, v3.PatchLabel,
+		v3.TraceLabel, v3.QueryLabel:
+		return true
+	}
+	return false
+}
+
+// explainConsequence describes what a breaking change means for the clients of an API.
+func explainConsequence(c *Change, side string) string {
+	added := c.ChangeType == PropertyAdded || c.ChangeType == ObjectAdded
+	removed := c.ChangeType == PropertyRemoved || c.ChangeType == ObjectRemoved
+	if c.ChangeType == Reordered {
+		return "clients relying on the previous order may fail"
+	}
+	switch side {
+	case "request":
+		switch {
+		case added && c.Property == v3.RequiredLabel:
+			return "requests that don't send it will be rejected"
+		case removed:
+			return "requests that still send it may be rejected"
+		}
+		return "requests that were valid before may now be rejected"
+	case "response":
+		switch {
+		case removed && c.Property == v3.RequiredLabel:
+			return "clients can no longer rely on it being returned"
+		case removed:
+			return "clients relying on it will no longer receive it"
+		case added:
+			return "clients may receive responses they don't expect"
+		case c.Property == v3.TypeLabel:
+			return "clients parsing this value will fail"
+		}
+		return "clients relying on the previous value may fail"
+	}
+	switch {
+	case removed:
+		return "clients relying on it will fail"
+	case added:
+		return "existing clients may not support it"
+	}
+	return "clients relying on the previous value may fail"
+}
@@ -0,0 +1,161 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainChange(t *testing.T) {
+	tests := []struct {
+		name     string
+		change   *Change
+		location []string
+		expected string
+	}{
+		{
+			name:     "response property type",
+			change:   &Change{ChangeType: Modified, Property: "type", Original: "string", New: "integer"},
+			location: []string{"paths", "pathItems", "/pets", "get", "responses", "response", "200", "content", "application/json", "schemas", "properties", "id"},
+			expected: "response property `id` changed type from `string` to `integer` — clients parsing this value will fail",
+		},
+		{
+			name:     "request property required",
+			change:   &Change{ChangeType: PropertyAdded, Property: "required", New: "name"},
+			location: []string{"paths", "pathItems", "/pets", "post", "requestBodies", "content", "application/json", "schemas"},
+			expected: "request property `name` became required — requests that don't send it will be rejected",
+		},
+		{
+			name:     "nested response property removed",
+			change:   &Change{ChangeType: ObjectRemoved, Property: "properties", Original: "name"},
+			location: []string{"paths", "pathItems", "/pets", "get", "responses", "response", "200", "content", "application/json", "schemas", "properties", "owner"},
+			expected: "response property `owner.name` was removed — clients relying on it will no longer receive it",
+		},
+		{
+			name:     "parameter removed",
+			change:   &Change{ChangeType: ObjectRemoved, Property: "parameters", Original: "limit"},
+			location: []string{"paths", "pathItems", "/pets", "get"},
+			expected: "parameter `limit` was removed from operation `GET /pets` — clients relying on it will fail",
+		},
+		{
+			name:     "parameter enum value removed",
+			change:   &Change{ChangeType: PropertyRemoved, Property: "enum", Original: "dog"},
+			location: []string{"paths", "pathItems", "/pets", "get", "parameters", "kind", "schemas"},
+			expected: "enum value `dog` was removed from parameter `kind` — requests that still send it may be rejected",
+		},
+		{
+			name:     "response code removed",
+			change:   &Change{ChangeType: ObjectRemoved, Property: "codes", Original: "404"},
+			location: []string{"paths", "pathItems", "/pets", "get", "responses"},
+			expected: "response code `404` was removed from response — clients relying on it will no longer receive it",
+		},
+		{
+			name:     "extension removed",
+			change:   &Change{ChangeType: ObjectRemoved, Property: "x-internal", Original: "true"},
+			location: []string{"paths", "pathItems", "/pets", "extensions"},
+			expected: "extension `x-internal` was removed from path `/pets` — clients relying on it will fail",
+		},
+		{
+			name:     "component schema type",
+			change:   &Change{ChangeType: Modified, Property: "type", Original: "object", New: "string"},
+			location: []string{"components", "schemas", "Pet"},
+			expected: "schema `Pet` changed type from `object` to `string` — clients relying on the previous value may fail",
+		},
+		{
+			name:     "reference repointed",
+			change:   &Change{ChangeType: ReferenceRepointed, Property: "$ref", Original: "#/components/schemas/Pet", New: "#/components/schemas/Toy"},
+			location: []string{"paths", "pathItems", "/pets", "get", "responses", "response", "200", "content", "application/json", "schemas"},
+			expected: "response `200` reference was repointed from `#/components/schemas/Pet` to `#/components/schemas/Toy`, which is a different schema — clients relying on the previous value may fail",
+		},
+		{
+			name:     "property without a subject",
+			change:   &Change{ChangeType: Modified, Property: "openapi", Original: "3.0.0", New: "3.1.0"},
+			expected: "`openapi` changed from `3.0.0` to `3.1.0` — clients relying on the previous value may fail",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExplainChange(tt.change, tt.location))
+		})
+	}
+}
+
+func TestExplainChanges(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+	changes := compareReportDocuments(t, left, right)
+	require.NotNil(t, changes)
+
+	located := LocateChanges(changes)
+	require.Len(t, located, 2)
+	breaking := 0
+	for _, lc := range located {
+		if lc.Change.Breaking {
+			breaking++
+			assert.Equal(t, "parameter `limit` was removed from operation `GET /pets` — clients relying on it will fail",
+				lc.Change.Explanation)
+		} else {
+			assert.Empty(t, lc.Change.Explanation)
+		}
+	}
+	assert.Equal(t, 1, breaking)
+
+	// re-classifying a change and explaining again clears the explanation.
+	for _, lc := range located {
+		lc.Change.Breaking = false
+	}
+	ExplainChanges(changes)
+	for _, lc := range located {
+		assert.Empty(t, lc.Change.Explanation)
+	}
+}
+
+func TestChange_MarshalJSON_Explanation(t *testing.T) {
+	c := &Change{ChangeType: ObjectRemoved, Property: "parameters", Original: "limit", Breaking: true}
+	c.Explanation = ExplainChange(c, []string{"paths", "pathItems", "/pets", "get"})
+
+	data, err := json.Marshal(c)
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, c.Explanation, decoded["explanation"])
+
+	c.Explanation = ""
+	data, err = json.Marshal(c)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "explanation")
+}
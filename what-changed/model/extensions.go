@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+	"fmt"
 	"strings"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -44,9 +46,17 @@ func (e *ExtensionChanges) TotalBreakingChanges() int {
 // anything. This function does not try and cast the value of an extension to perform checks, it
 // will perform a basic value check.
 //
-// A current limitation relates to extensions being objects and a property of the object changes,
-// there is currently no support for knowing anything changed - so it is ignored.
+// When the values of an extension are both objects, or both arrays, they are compared deeply, and a change is
+// recorded for every value that was added, removed or modified inside them. The property of each change is the
+// path to the value from the extension, for example `x-governance.owner.team` or `x-audiences[1]`. Arrays of
+// scalar values are compared as sets, so re-ordering values is not a change, other arrays are compared by index.
+//
+// Extensions with a custom compare function (see RegisterExtensionComparator) are compared using that function.
 func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]) *ExtensionChanges {
+	return compareExtensions(context.Background(), l, r)
+}
+
+func compareExtensions(ctx context.Context, l, r *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]) *ExtensionChanges {
 	// look at the original and then look through the new.
 	seenLeft := make(map[string]*low.ValueReference[*yaml.Node])
 	seenRight := make(map[string]*low.ValueReference[*yaml.Node])
@@ -64,6 +74,15 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 		CheckForObjectAdditionOrRemovalWithEncoding[*yaml.Node](seenLeft, seenRight, i, &changes, false, true)
 
 		if seenRight[i] != nil {
+			if compare := extensionComparator(i); compare != nil {
+				changes = append(changes, compare(i, seenLeft[i].ValueNode, seenRight[i].ValueNode)...)
+				continue
+			}
+			if l, r := seenLeft[i].ValueNode, seenRight[i].ValueNode; l != nil && r != nil &&
+				l.Kind == r.Kind && (l.Kind == yaml.MappingNode || l.Kind == yaml.SequenceNode) {
+				compareExtensionValues(i, l, r, &changes)
+				continue
+			}
 			var props []*PropertyCheck
 
 			props = append(props, &PropertyCheck{
@@ -77,7 +96,7 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 			})
 
 			// check properties with encoding for extensions
-			CheckPropertiesWithEncoding(props)
+			checkPropertiesWithEncoding(ctx, props)
 		}
 	}
 	for i := range seenRight {
@@ -86,16 +105,113 @@ func CompareExtensions(l, r *orderedmap.Map[low.KeyReference[string], low.ValueR
 		}
 	}
 	ex := new(ExtensionChanges)
-	ex.PropertyChanges = NewPropertyChanges(changes)
+	ex.PropertyChanges = newPropertyChanges(ctx, changes)
 	if ex.TotalChanges() <= 0 {
 		return nil
 	}
 	return ex
 }
 
+// compareExtensionValues deeply compares the values of an extension, recording a change for every value added,
+// removed or modified below the path.
+func compareExtensionValues(path string, l, r *yaml.Node, changes *[]*Change) {
+	for l.Kind == yaml.AliasNode && l.Alias != nil {
+		l = l.Alias
+	}
+	for r.Kind == yaml.AliasNode && r.Alias != nil {
+		r = r.Alias
+	}
+	switch {
+	case l.Kind == yaml.MappingNode && r.Kind == yaml.MappingNode:
+		lValues, rValues := make(map[string]*yaml.Node), make(map[string]*yaml.Node)
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			rValues[r.Content[i].Value] = r.Content[i+1]
+		}
+		for i := 0; i+1 < len(l.Content); i += 2 {
+			key, value := l.Content[i].Value, l.Content[i+1]
+			lValues[key] = value
+			if rValue, ok := rValues[key]; ok {
+				compareExtensionValues(path+extensionPathSegment(key), value, rValue, changes)
+				continue
+			}
+			CreateChangeWithEncoding(changes, PropertyRemoved, path+extensionPathSegment(key),
+				value, nil, false, value, nil)
+		}
+		for i := 0; i+1 < len(r.Content); i += 2 {
+			if key, value := r.Content[i].Value, r.Content[i+1]; lValues[key] == nil {
+				CreateChangeWithEncoding(changes, PropertyAdded, path+extensionPathSegment(key),
+					nil, value, false, nil, value)
+			}
+		}
+	case l.Kind == yaml.SequenceNode && r.Kind == yaml.SequenceNode && scalarSequence(l) && scalarSequence(r):
+		// values that are in both arrays are paired up, whatever their position.
+		unmatched := make(map[string][]int)
+		for i, n := range r.Content {
+			unmatched[n.Value] = append(unmatched[n.Value], i)
+		}
+		for i, n := range l.Content {
+			if remaining := unmatched[n.Value]; len(remaining) > 0 {
+				unmatched[n.Value] = remaining[1:]
+				continue
+			}
+			CreateChange(changes, PropertyRemoved, fmt.Sprintf("%s[%d]", path, i), n, nil, false, n, nil)
+		}
+		for i, n := range r.Content {
+			for _, j := range unmatched[n.Value] {
+				if j == i {
+					CreateChange(changes, PropertyAdded, fmt.Sprintf("%s[%d]", path, i), nil, n, false, nil, n)
+					break
+				}
+			}
+		}
+	case l.Kind == yaml.SequenceNode && r.Kind == yaml.SequenceNode:
+		for i := 0; i < len(l.Content) || i < len(r.Content); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(r.Content):
+				CreateChangeWithEncoding(changes, PropertyRemoved, itemPath, l.Content[i], nil, false, l.Content[i], nil)
+			case i >= len(l.Content):
+				CreateChangeWithEncoding(changes, PropertyAdded, itemPath, nil, r.Content[i], false, nil, r.Content[i])
+			default:
+				compareExtensionValues(itemPath, l.Content[i], r.Content[i], changes)
+			}
+		}
+	case l.Kind == yaml.ScalarNode && r.Kind == yaml.ScalarNode:
+		if l.Value != r.Value || l.Tag != r.Tag {
+			CreateChange(changes, Modified, path, l, r, false, l, r)
+		}
+	default:
+		if !low.CompareYAMLNodes(l, r) {
+			CreateChangeWithEncoding(changes, Modified, path, l, r, false, l, r)
+		}
+	}
+}
+
+// scalarSequence determines if every value of an array is a scalar.
+func scalarSequence(n *yaml.Node) bool {
+	for _, c := range n.Content {
+		if c.Kind != yaml.ScalarNode {
+			return false
+		}
+	}
+	return true
+}
+
+// extensionPathSegment renders a key of an extension value as a segment of a path.
+func extensionPathSegment(key string) string {
+	if plainPathSegment.MatchString(key) {
+		return "." + key
+	}
+	return "['" + strings.ReplaceAll(key, "'", "\\'") + "']"
+}
+
 // CheckExtensions is a helper method to un-pack a left and right model that contains extensions. Once unpacked
 // the extensions are compared and returns a pointer to ExtensionChanges. If nothing changed, nil is returned.
 func CheckExtensions[T low.HasExtensions[T]](l, r T) *ExtensionChanges {
+	return checkExtensions(context.Background(), l, r)
+}
+
+func checkExtensions[T low.HasExtensions[T]](ctx context.Context, l, r T) *ExtensionChanges {
 	var lExt, rExt *orderedmap.Map[low.KeyReference[string], low.ValueReference[*yaml.Node]]
 	if orderedmap.Len(l.GetExtensions()) > 0 {
 		lExt = l.GetExtensions()
@@ -103,5 +219,5 @@ func CheckExtensions[T low.HasExtensions[T]](l, r T) *ExtensionChanges {
 	if orderedmap.Len(r.GetExtensions()) > 0 {
 		rExt = r.GetExtensions()
 	}
-	return CompareExtensions(lExt, rExt)
+	return compareExtensions(ctx, lExt, rExt)
 }
@@ -105,3 +105,116 @@ func TestCompareExtensions_Identical(t *testing.T) {
 
 	assert.Nil(t, extChanges)
 }
+
+func compareExtensionsYAML(t *testing.T, left, right string) map[string]*Change {
+	low.ClearHashCache()
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	extChanges := CompareExtensions(low.ExtractExtensions(lNode.Content[0]), low.ExtractExtensions(rNode.Content[0]))
+	changes := make(map[string]*Change)
+	if extChanges == nil {
+		return changes
+	}
+	for _, c := range extChanges.Changes {
+		assert.False(t, c.Breaking)
+		changes[c.Property] = c
+	}
+	return changes
+}
+
+func TestCompareExtensions_DeepObject(t *testing.T) {
+	left := `x-governance:
+  owner:
+    team: burgers
+    slack: '#burgers'
+  tier: 1
+  a.b: keep
+  retired: true`
+
+	right := `x-governance:
+  owner:
+    team: pizza
+    slack: '#burgers'
+  tier: 1
+  a.b: changed
+  audience: public`
+
+	changes := compareExtensionsYAML(t, left, right)
+	assert.Len(t, changes, 4)
+
+	team := changes["x-governance.owner.team"]
+	assert.Equal(t, Modified, team.ChangeType)
+	assert.Equal(t, "burgers", team.Original)
+	assert.Equal(t, "pizza", team.New)
+	assert.Equal(t, 3, *team.Context.OriginalLine)
+	assert.Equal(t, 3, *team.Context.NewLine)
+
+	assert.Equal(t, Modified, changes["x-governance['a.b']"].ChangeType)
+	assert.Equal(t, PropertyRemoved, changes["x-governance.retired"].ChangeType)
+	assert.Equal(t, "true", changes["x-governance.retired"].Original)
+	assert.Equal(t, PropertyAdded, changes["x-governance.audience"].ChangeType)
+	assert.Equal(t, "public", changes["x-governance.audience"].New)
+}
+
+func TestCompareExtensions_DeepScalarArray(t *testing.T) {
+	left := `x-audiences: [internal, partner, public]`
+	right := `x-audiences: [public, internal, beta]`
+
+	changes := compareExtensionsYAML(t, left, right)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, PropertyRemoved, changes["x-audiences[1]"].ChangeType)
+	assert.Equal(t, "partner", changes["x-audiences[1]"].Original)
+	assert.Equal(t, PropertyAdded, changes["x-audiences[2]"].ChangeType)
+	assert.Equal(t, "beta", changes["x-audiences[2]"].New)
+
+	// re-ordering is not a change.
+	assert.Empty(t, compareExtensionsYAML(t, left, `x-audiences: [public, internal, partner]`))
+}
+
+func TestCompareExtensions_DeepObjectArray(t *testing.T) {
+	left := `x-reviewers:
+  - name: dave
+    role: owner
+  - name: quobix`
+
+	right := `x-reviewers:
+  - name: dave
+    role: reviewer
+  - name: quobix
+  - name: burger
+    role: cook`
+
+	changes := compareExtensionsYAML(t, left, right)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "owner", changes["x-reviewers[0].role"].Original)
+	assert.Equal(t, "reviewer", changes["x-reviewers[0].role"].New)
+
+	added := changes["x-reviewers[2]"]
+	assert.Equal(t, PropertyAdded, added.ChangeType)
+	assert.NotEmpty(t, added.NewEncoded)
+}
+
+func TestCompareExtensions_DeepMixedKinds(t *testing.T) {
+	left := `x-meta:
+  limits: [1, 2]
+  owner: dave`
+
+	right := `x-meta:
+  limits:
+    max: 2
+  owner:
+    name: dave`
+
+	changes := compareExtensionsYAML(t, left, right)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, Modified, changes["x-meta.limits"].ChangeType)
+	assert.Equal(t, Modified, changes["x-meta.owner"].ChangeType)
+	assert.Equal(t, "dave", changes["x-meta.owner"].Original)
+
+	// an extension changing from an object to a scalar is still a single change.
+	changes = compareExtensionsYAML(t, `x-meta: {owner: dave}`, `x-meta: dave`)
+	assert.Len(t, changes, 1)
+	assert.Equal(t, Modified, changes["x-meta"].ChangeType)
+}
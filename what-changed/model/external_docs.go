@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -55,6 +57,10 @@ func (e *ExternalDocChanges) TotalBreakingChanges() int {
 // nodes for any changes between them. If there are changes, then a pointer to ExternalDocChanges
 // is returned, otherwise if nothing changed - then nil is returned.
 func CompareExternalDocs(l, r *base.ExternalDoc) *ExternalDocChanges {
+	return compareExternalDocs(context.Background(), l, r)
+}
+
+func compareExternalDocs(ctx context.Context, l, r *base.ExternalDoc) *ExternalDocChanges {
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 2)
 
@@ -67,13 +73,14 @@ func CompareExternalDocs(l, r *base.ExternalDoc) *ExternalDocChanges {
 			v3.DescriptionLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
+	checkComparators(l, r, &changes)
 	dc := new(ExternalDocChanges)
-	dc.PropertyChanges = NewPropertyChanges(changes)
+	dc.PropertyChanges = newPropertyChanges(ctx, changes)
 
 	// check extensions
-	dc.ExtensionChanges = CheckExtensions(l, r)
+	dc.ExtensionChanges = checkExtensions(ctx, l, r)
 	if dc.TotalChanges() <= 0 {
 		return nil
 	}
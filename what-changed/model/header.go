@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -89,47 +90,47 @@ func (h *HeaderChanges) TotalBreakingChanges() int {
 }
 
 // shared header properties
-func addOpenAPIHeaderProperties(left, right low.OpenAPIHeader, changes *[]*Change) []*PropertyCheck {
+func addOpenAPIHeaderProperties(ctx context.Context, left, right low.OpenAPIHeader, changes *[]*Change) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	// style
 	addPropertyCheck(&props, left.GetStyle().ValueNode, right.GetStyle().ValueNode,
 		left.GetStyle(), right.GetStyle(), changes, v3.StyleLabel,
-		BreakingModified(CompHeader, PropStyle), CompHeader, PropStyle)
+		breakingModified(ctx, CompHeader, PropStyle), CompHeader, PropStyle)
 
 	// allow reserved
 	addPropertyCheck(&props, left.GetAllowReserved().ValueNode, right.GetAllowReserved().ValueNode,
 		left.GetAllowReserved(), right.GetAllowReserved(), changes, v3.AllowReservedLabel,
-		BreakingModified(CompHeader, PropAllowReserved), CompHeader, PropAllowReserved)
+		breakingModified(ctx, CompHeader, PropAllowReserved), CompHeader, PropAllowReserved)
 
 	// allow empty value
 	addPropertyCheck(&props, left.GetAllowEmptyValue().ValueNode, right.GetAllowEmptyValue().ValueNode,
 		left.GetAllowEmptyValue(), right.GetAllowEmptyValue(), changes, v3.AllowEmptyValueLabel,
-		BreakingModified(CompHeader, PropAllowEmptyValue), CompHeader, PropAllowEmptyValue)
+		breakingModified(ctx, CompHeader, PropAllowEmptyValue), CompHeader, PropAllowEmptyValue)
 
 	// explode
 	addPropertyCheck(&props, left.GetExplode().ValueNode, right.GetExplode().ValueNode,
 		left.GetExplode(), right.GetExplode(), changes, v3.ExplodeLabel,
-		BreakingModified(CompHeader, PropExplode), CompHeader, PropExplode)
+		breakingModified(ctx, CompHeader, PropExplode), CompHeader, PropExplode)
 
 	// example
 	CheckPropertyAdditionOrRemovalWithEncoding(left.GetExample().ValueNode, right.GetExample().ValueNode,
 		v3.ExampleLabel, changes,
-		BreakingAdded(CompHeader, PropExample) || BreakingRemoved(CompHeader, PropExample),
+		breakingAdded(ctx, CompHeader, PropExample) || breakingRemoved(ctx, CompHeader, PropExample),
 		left.GetExample(), right.GetExample())
-	CheckForModificationWithEncoding(left.GetExample().ValueNode, right.GetExample().ValueNode,
-		v3.ExampleLabel, changes, BreakingModified(CompHeader, PropExample),
+	CheckForExampleModification(left.GetExample().ValueNode, right.GetExample().ValueNode,
+		v3.ExampleLabel, changes, breakingModified(ctx, CompHeader, PropExample),
 		left.GetExample(), right.GetExample())
 
 	// deprecated
 	addPropertyCheck(&props, left.GetDeprecated().ValueNode, right.GetDeprecated().ValueNode,
 		left.GetDeprecated(), right.GetDeprecated(), changes, v3.DeprecatedLabel,
-		BreakingModified(CompHeader, PropDeprecated), CompHeader, PropDeprecated)
+		breakingModified(ctx, CompHeader, PropDeprecated), CompHeader, PropDeprecated)
 
 	// required
 	addPropertyCheck(&props, left.GetRequired().ValueNode, right.GetRequired().ValueNode,
 		left.GetRequired(), right.GetRequired(), changes, v3.RequiredLabel,
-		BreakingModified(CompHeader, PropRequired), CompHeader, PropRequired)
+		breakingModified(ctx, CompHeader, PropRequired), CompHeader, PropRequired)
 
 	return props
 }
@@ -198,13 +199,13 @@ func addSwaggerHeaderProperties(left, right low.SwaggerHeader, changes *[]*Chang
 }
 
 // common header properties
-func addCommonHeaderProperties(left, right low.HasDescription, changes *[]*Change) []*PropertyCheck {
+func addCommonHeaderProperties(ctx context.Context, left, right low.HasDescription, changes *[]*Change) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	// description
 	addPropertyCheck(&props, left.GetDescription().ValueNode, right.GetDescription().ValueNode,
 		left.GetDescription(), right.GetDescription(), changes, v3.DescriptionLabel,
-		BreakingModified(CompHeader, PropDescription), CompHeader, PropDescription)
+		breakingModified(ctx, CompHeader, PropDescription), CompHeader, PropDescription)
 
 	return props
 }
@@ -212,18 +213,30 @@ func addCommonHeaderProperties(left, right low.HasDescription, changes *[]*Chang
 // CompareHeadersV2 is a Swagger compatible, typed signature used for other generic functions. It simply
 // wraps CompareHeaders and provides nothing other that a typed interface.
 func CompareHeadersV2(l, r *v2.Header) *HeaderChanges {
-	return CompareHeaders(l, r)
+	return compareHeadersV2(context.Background(), l, r)
+}
+
+func compareHeadersV2(ctx context.Context, l, r *v2.Header) *HeaderChanges {
+	return compareHeaders(ctx, l, r)
 }
 
 // CompareHeadersV3 is an OpenAPI 3+ compatible, typed signature used for other generic functions. It simply
 // wraps CompareHeaders and provides nothing other that a typed interface.
 func CompareHeadersV3(l, r *v3.Header) *HeaderChanges {
-	return CompareHeaders(l, r)
+	return compareHeadersV3(context.Background(), l, r)
+}
+
+func compareHeadersV3(ctx context.Context, l, r *v3.Header) *HeaderChanges {
+	return compareHeaders(ctx, l, r)
 }
 
 // CompareHeaders will compare left and right Header objects (any version of Swagger or OpenAPI) and return
 // a pointer to HeaderChanges with anything that has changed, or nil if nothing changed.
 func CompareHeaders(l, r any) *HeaderChanges {
+	return compareHeaders(context.Background(), l, r)
+}
+
+func compareHeaders(ctx context.Context, l, r any) *HeaderChanges {
 	var changes []*Change
 	var props []*PropertyCheck
 	hc := new(HeaderChanges)
@@ -234,33 +247,38 @@ func CompareHeaders(l, r any) *HeaderChanges {
 		rHeader := r.(*v2.Header)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lHeader, rHeader) {
+		if areEqual(ctx, lHeader, rHeader) {
 			return nil
 		}
 
-		props = append(props, addCommonHeaderProperties(lHeader, rHeader, &changes)...)
+		props = append(props, addCommonHeaderProperties(ctx, lHeader, rHeader, &changes)...)
 		props = append(props, addSwaggerHeaderProperties(lHeader, rHeader, &changes)...)
 
 		// enum
 		if len(lHeader.Enum.Value) > 0 || len(rHeader.Enum.Value) > 0 {
-			ExtractRawValueSliceChanges(lHeader.Enum.Value, rHeader.Enum.Value, &changes, v3.EnumLabel, true)
+			extractRawValueSliceChangesWithRules(ctx, lHeader.Enum.Value, rHeader.Enum.Value, &changes, v3.EnumLabel,
+				CompHeader, PropEnum)
+			if orderedArrays(ctx).Enums {
+				checkReordered(valueKeys(lHeader.Enum.Value), valueKeys(rHeader.Enum.Value), v3.EnumLabel,
+					lHeader.Enum.ValueNode, rHeader.Enum.ValueNode, breakingModified(ctx, CompHeader, PropEnum), &changes)
+			}
 		}
 
 		// items
 		if !lHeader.Items.IsEmpty() && !rHeader.Items.IsEmpty() {
-			if !low.AreEqual(lHeader.Items.Value, rHeader.Items.Value) {
-				hc.ItemsChanges = CompareItems(lHeader.Items.Value, rHeader.Items.Value)
+			if !areEqual(ctx, lHeader.Items.Value, rHeader.Items.Value) {
+				hc.ItemsChanges = compareItems(ctx, lHeader.Items.Value, rHeader.Items.Value, CompHeader)
 			}
 		}
 		if lHeader.Items.IsEmpty() && !rHeader.Items.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.ItemsLabel, nil,
-				rHeader.Items.ValueNode, BreakingAdded(CompHeader, PropItems), nil, rHeader.Items.Value)
+				rHeader.Items.ValueNode, breakingAdded(ctx, CompHeader, PropItems), nil, rHeader.Items.Value)
 		}
 		if !lHeader.Items.IsEmpty() && rHeader.Items.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.SchemaLabel, lHeader.Items.ValueNode,
-				nil, BreakingRemoved(CompHeader, PropItems), lHeader.Items.Value, nil)
+				nil, breakingRemoved(ctx, CompHeader, PropItems), lHeader.Items.Value, nil)
 		}
-		hc.ExtensionChanges = CompareExtensions(lHeader.Extensions, rHeader.Extensions)
+		hc.ExtensionChanges = compareExtensions(ctx, lHeader.Extensions, rHeader.Extensions)
 	}
 
 	// handle OpenAPI
@@ -269,30 +287,31 @@ func CompareHeaders(l, r any) *HeaderChanges {
 		rHeader := r.(*v3.Header)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lHeader, rHeader) {
+		if areEqual(ctx, lHeader, rHeader) {
 			return nil
 		}
 
-		props = append(props, addCommonHeaderProperties(lHeader, rHeader, &changes)...)
-		props = append(props, addOpenAPIHeaderProperties(lHeader, rHeader, &changes)...)
+		props = append(props, addCommonHeaderProperties(ctx, lHeader, rHeader, &changes)...)
+		props = append(props, addOpenAPIHeaderProperties(ctx, lHeader, rHeader, &changes)...)
 
 		// header
 		if !lHeader.Schema.IsEmpty() || !rHeader.Schema.IsEmpty() {
-			hc.SchemaChanges = CompareSchemas(lHeader.Schema.Value, rHeader.Schema.Value)
+			hc.SchemaChanges = compareSchemas(ctx, lHeader.Schema.Value, rHeader.Schema.Value)
 		}
 
 		// examples
-		hc.ExamplesChanges = CheckMapForChanges(lHeader.Examples.Value, rHeader.Examples.Value,
-			&changes, v3.ExamplesLabel, CompareExamples)
+		hc.ExamplesChanges = checkMapForChanges(ctx, lHeader.Examples.Value, rHeader.Examples.Value,
+			&changes, v3.ExamplesLabel, compareExamples)
 
 		// content
-		hc.ContentChanges = CheckMapForChanges(lHeader.Content.Value, rHeader.Content.Value,
-			&changes, v3.ContentLabel, CompareMediaTypes)
+		hc.ContentChanges = checkMapForChanges(ctx, lHeader.Content.Value, rHeader.Content.Value,
+			&changes, v3.ContentLabel, compareMediaTypes)
 
-		hc.ExtensionChanges = CompareExtensions(lHeader.Extensions, rHeader.Extensions)
+		hc.ExtensionChanges = compareExtensions(ctx, lHeader.Extensions, rHeader.Extensions)
 
 	}
-	CheckProperties(props)
-	hc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	hc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return hc
 }
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -75,6 +77,10 @@ func (i *InfoChanges) TotalBreakingChanges() int {
 // will be returned in a pointer to InfoChanges, otherwise if nothing is found, then nil is
 // returned instead.
 func CompareInfo(l, r *base.Info) *InfoChanges {
+	return compareInfo(context.Background(), l, r)
+}
+
+func compareInfo(ctx context.Context, l, r *base.Info) *InfoChanges {
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 5)
 
@@ -97,42 +103,43 @@ func CompareInfo(l, r *base.Info) *InfoChanges {
 	)
 
 	// check properties
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	i := new(InfoChanges)
 
 	// compare contact.
 	if l.Contact.Value != nil && r.Contact.Value != nil {
-		i.ContactChanges = CompareContact(l.Contact.Value, r.Contact.Value)
+		i.ContactChanges = compareContact(ctx, l.Contact.Value, r.Contact.Value)
 	} else {
 		if l.Contact.Value == nil && r.Contact.Value != nil {
 			CreateChange(&changes, ObjectAdded, v3.ContactLabel,
-				nil, r.Contact.ValueNode, BreakingAdded(CompInfo, PropContact), nil, r.Contact.Value)
+				nil, r.Contact.ValueNode, breakingAdded(ctx, CompInfo, PropContact), nil, r.Contact.Value)
 		}
 		if l.Contact.Value != nil && r.Contact.Value == nil {
 			CreateChange(&changes, ObjectRemoved, v3.ContactLabel,
-				l.Contact.ValueNode, nil, BreakingRemoved(CompInfo, PropContact), l.Contact.Value, nil)
+				l.Contact.ValueNode, nil, breakingRemoved(ctx, CompInfo, PropContact), l.Contact.Value, nil)
 		}
 	}
 
 	// compare license.
 	if l.License.Value != nil && r.License.Value != nil {
-		i.LicenseChanges = CompareLicense(l.License.Value, r.License.Value)
+		i.LicenseChanges = compareLicense(ctx, l.License.Value, r.License.Value)
 	} else {
 		if l.License.Value == nil && r.License.Value != nil {
 			CreateChange(&changes, ObjectAdded, v3.LicenseLabel,
-				nil, r.License.ValueNode, BreakingAdded(CompInfo, PropLicense), nil, r.License.Value)
+				nil, r.License.ValueNode, breakingAdded(ctx, CompInfo, PropLicense), nil, r.License.Value)
 		}
 		if l.License.Value != nil && r.License.Value == nil {
 			CreateChange(&changes, ObjectRemoved, v3.LicenseLabel,
-				l.License.ValueNode, nil, BreakingRemoved(CompInfo, PropLicense), r.License.Value, nil)
+				l.License.ValueNode, nil, breakingRemoved(ctx, CompInfo, PropLicense), r.License.Value, nil)
 		}
 	}
 
 	// check extensions.
-	i.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	i.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 
-	i.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	i.PropertyChanges = newPropertyChanges(ctx, changes)
 	if i.TotalChanges() <= 0 {
 		return nil
 	}
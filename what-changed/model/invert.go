@@ -0,0 +1,112 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"strings"
+)
+
+// inversion is the classification of a change before it was inverted, so inverting it again restores it.
+type inversion struct {
+	breaking bool
+	severity Severity
+}
+
+// Invert swaps the original and new sides of every change made between two documents, so the changes describe
+// going from the new document back to the original (see InvertChanges). Inverting the changes again restores them.
+func (d *DocumentChanges) Invert() {
+	InvertChanges(d)
+}
+
+// InvertChanges walks any tree of changes (see LocateChanges) and swaps the original and new sides of every change
+// in place, so a single comparison can describe a change in both directions, for example what rolling back a
+// release would break. Values, objects and line numbers are swapped, and additions become removals (and the other
+// way around).
+//
+// Modifications, renames and repointed references are breaking in both directions, or neither. Additions and
+// removals are classified again using the active breaking rules (see GetActiveBreakingRulesConfig) of the model
+// holding the change. When there is no rule, a removal is breaking and an addition is not. Severities set when
+// comparing are cleared, and the explanations of breaking changes and the IDs of changes are written again. Inverting
+// the changes a second time restores the original classification.
+func InvertChanges(changes any) {
+	rules := GetActiveBreakingRulesConfig()
+	for _, lc := range LocateChanges(changes) {
+		invertChange(lc.Change, ruleComponent(lc.owner), rules)
+	}
+	ExplainChanges(changes)
+	AssignChangeIDs(changes)
+}
+
+func invertChange(c *Change, component string, rules *BreakingRulesConfig) {
+	switch c.ChangeType {
+	case PropertyAdded:
+		c.ChangeType = PropertyRemoved
+	case PropertyRemoved:
+		c.ChangeType = PropertyAdded
+	case ObjectAdded:
+		c.ChangeType = ObjectRemoved
+	case ObjectRemoved:
+		c.ChangeType = ObjectAdded
+	}
+	c.Original, c.New = c.New, c.Original
+	c.OriginalEncoded, c.NewEncoded = c.NewEncoded, c.OriginalEncoded
+	c.OriginalObject, c.NewObject = c.NewObject, c.OriginalObject
+	c.OriginalSnippet, c.NewSnippet = c.NewSnippet, c.OriginalSnippet
+	if ctx := c.Context; ctx != nil {
+		ctx.OriginalLine, ctx.NewLine = ctx.NewLine, ctx.OriginalLine
+		ctx.OriginalColumn, ctx.NewColumn = ctx.NewColumn, ctx.OriginalColumn
+	}
+
+	previous := &inversion{breaking: c.Breaking, severity: c.Severity}
+	if c.inverted != nil {
+		c.Breaking, c.Severity = c.inverted.breaking, c.inverted.severity
+	} else {
+		c.Breaking, c.Severity = invertedBreaking(c, component, rules), 0
+	}
+	c.inverted = previous
+}
+
+// invertedBreaking determines if a change that has been inverted is breaking.
+func invertedBreaking(c *Change, component string, rules *BreakingRulesConfig) bool {
+	var changeType string
+	switch c.ChangeType {
+	case PropertyAdded, ObjectAdded:
+		changeType = ChangeTypeAdded
+	case PropertyRemoved, ObjectRemoved:
+		changeType = ChangeTypeRemoved
+	default:
+		return c.Breaking
+	}
+	rule := rules.GetRule(component, c.Property)
+	if rule == nil {
+		// properties of a document (like jsonSchemaDialect) have rules of their own.
+		rule = rules.GetRule(c.Property, "")
+	}
+	if rule != nil {
+		if changeType == ChangeTypeAdded && rule.Added != nil {
+			return *rule.Added
+		}
+		if changeType == ChangeTypeRemoved && rule.Removed != nil {
+			return *rule.Removed
+		}
+	}
+	return changeType == ChangeTypeRemoved
+}
+
+// ruleComponent returns the name of the breaking rules component of a change model, for example 'schema' for
+// SchemaChanges, or an empty string if there are no rules for the model.
+func ruleComponent(owner reflect.Type) string {
+	if owner == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(owner.Name(), "Changes")
+	for i := 0; i < configType.NumField(); i++ {
+		component := jsonTagName(configType.Field(i))
+		if strings.EqualFold(component, name) || strings.EqualFold(component, name+"s") {
+			return component
+		}
+	}
+	return ""
+}
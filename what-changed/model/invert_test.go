@@ -0,0 +1,102 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentChanges_Invert(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.1
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+	changes := compareReportDocuments(t, left, right)
+	require.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+
+	changes.Invert()
+
+	located := LocateChanges(changes)
+	require.Len(t, located, 2)
+	for _, lc := range located {
+		switch lc.Change.Property {
+		case "version":
+			assert.Equal(t, Modified, lc.Change.ChangeType)
+			assert.Equal(t, "1.0.1", lc.Change.Original)
+			assert.Equal(t, "1.0.0", lc.Change.New)
+			assert.False(t, lc.Change.Breaking)
+		case "parameters":
+			assert.Equal(t, ObjectAdded, lc.Change.ChangeType)
+			assert.Equal(t, "limit", lc.Change.New)
+			assert.Empty(t, lc.Change.Original)
+			assert.NotNil(t, lc.Change.NewObject)
+			assert.Nil(t, lc.Change.OriginalObject)
+			assert.Empty(t, lc.Change.Explanation)
+		default:
+			t.Fatalf("unexpected change to %s", lc.Change.Property)
+		}
+	}
+
+	// inverting again restores the original changes.
+	changes.Invert()
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	for _, lc := range LocateChanges(changes) {
+		if lc.Change.Property == "parameters" {
+			assert.Equal(t, ObjectRemoved, lc.Change.ChangeType)
+			assert.Equal(t, "limit", lc.Change.Original)
+			assert.True(t, lc.Change.Breaking)
+			assert.Equal(t, "parameter `limit` was removed from operation `GET /pets` — clients relying on it will fail",
+				lc.Change.Explanation)
+		}
+	}
+}
+
+func TestInvertChanges_Classification(t *testing.T) {
+	schema := &SchemaChanges{PropertyChanges: NewPropertyChanges([]*Change{
+		{ChangeType: PropertyRemoved, Property: "enum", Original: "dog", Breaking: true},
+		{ChangeType: Modified, Property: "type", Original: "string", New: "integer", Breaking: true},
+	})}
+
+	InvertChanges(schema)
+
+	changes := schema.GetPropertyChanges()
+	assert.Equal(t, PropertyAdded, changes[0].ChangeType)
+	assert.Equal(t, "dog", changes[0].New)
+	assert.False(t, changes[0].Breaking)
+
+	assert.Equal(t, Modified, changes[1].ChangeType)
+	assert.Equal(t, "integer", changes[1].Original)
+	assert.Equal(t, "string", changes[1].New)
+	assert.True(t, changes[1].Breaking)
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -56,7 +58,15 @@ func (i *ItemsChanges) TotalBreakingChanges() int {
 //
 // It is worth nothing that Items can contain Items. This means recursion is possible and has the potential for
 // runaway code if not using the resolver's circular reference checking.
+//
+// Items that are added or removed are breaking according to the header rules. Items of a parameter are compared
+// by CompareParameters, using the parameter rules.
 func CompareItems(l, r *v2.Items) *ItemsChanges {
+	return compareItems(context.Background(), l, r, CompHeader)
+}
+
+// compareItems compares the items of a parameter or a header, using the breaking rules of the component.
+func compareItems(ctx context.Context, l, r *v2.Items, component string) *ItemsChanges {
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -64,27 +74,28 @@ func CompareItems(l, r *v2.Items) *ItemsChanges {
 
 	// header is identical to items, except for a description.
 	props = append(props, addSwaggerHeaderProperties(l, r, &changes)...)
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	if !l.Items.IsEmpty() && !r.Items.IsEmpty() {
 		// inline, check hashes, if they don't match, compare.
 		if l.Items.Value.Hash() != r.Items.Value.Hash() {
 			// compare.
-			ic.ItemsChanges = CompareItems(l.Items.Value, r.Items.Value)
+			ic.ItemsChanges = compareItems(ctx, l.Items.Value, r.Items.Value, component)
 		}
 	}
 	if l.Items.IsEmpty() && !r.Items.IsEmpty() {
 		// added items
 		CreateChange(&changes, PropertyAdded, v3.ItemsLabel,
-			nil, r.Items.GetValueNode(), true, nil, r.Items.GetValue())
+			nil, r.Items.GetValueNode(), breakingAdded(ctx, component, PropItems), nil, r.Items.GetValue())
 	}
 	if !l.Items.IsEmpty() && r.Items.IsEmpty() {
 		// removed items
 		CreateChange(&changes, PropertyRemoved, v3.ItemsLabel,
-			l.Items.GetValueNode(), nil, true, l.Items.GetValue(),
+			l.Items.GetValueNode(), nil, breakingRemoved(ctx, component, PropItems), l.Items.GetValue(),
 			nil)
 	}
-	ic.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	ic.PropertyChanges = newPropertyChanges(ctx, changes)
 	if ic.TotalChanges() <= 0 {
 		return nil
 	}
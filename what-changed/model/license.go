@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -56,6 +58,10 @@ func (l *LicenseChanges) TotalBreakingChanges() int {
 // were any, a pointer to a LicenseChanges object is returned, otherwise if nothing changed - the function
 // returns nil.
 func CompareLicense(l, r *base.License) *LicenseChanges {
+	return compareLicense(context.Background(), l, r)
+}
+
+func compareLicense(ctx context.Context, l, r *base.License) *LicenseChanges {
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 3)
 
@@ -71,11 +77,12 @@ func CompareLicense(l, r *base.License) *LicenseChanges {
 			v3.Identifier, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
+	checkComparators(l, r, &changes)
 	lc := new(LicenseChanges)
-	lc.PropertyChanges = NewPropertyChanges(changes)
-	lc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	lc.PropertyChanges = newPropertyChanges(ctx, changes)
+	lc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 	if lc.TotalChanges() <= 0 {
 		return nil
 	}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -58,7 +60,11 @@ func (l *LinkChanges) TotalBreakingChanges() int {
 // CompareLinks checks a left and right OpenAPI Link for any changes. If they are found, returns a pointer to
 // LinkChanges, and returns nil if nothing is found.
 func CompareLinks(l, r *v3.Link) *LinkChanges {
-	if low.AreEqual(l, r) {
+	return compareLinks(context.Background(), l, r)
+}
+
+func compareLinks(ctx context.Context, l, r *v3.Link) *LinkChanges {
+	if areEqual(ctx, l, r) {
 		return nil
 	}
 
@@ -80,24 +86,24 @@ func CompareLinks(l, r *v3.Link) *LinkChanges {
 			v3.DescriptionLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 	lc := new(LinkChanges)
-	lc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	lc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 
 	// server
 	if !l.Server.IsEmpty() && !r.Server.IsEmpty() {
-		if !low.AreEqual(l.Server.Value, r.Server.Value) {
-			lc.ServerChanges = CompareServers(l.Server.Value, r.Server.Value)
+		if !areEqual(ctx, l.Server.Value, r.Server.Value) {
+			lc.ServerChanges = compareServers(ctx, l.Server.Value, r.Server.Value)
 		}
 	}
 	if !l.Server.IsEmpty() && r.Server.IsEmpty() {
 		CreateChange(&changes, PropertyRemoved, v3.ServerLabel,
-			l.Server.ValueNode, nil, BreakingRemoved(CompLink, PropServer),
+			l.Server.ValueNode, nil, breakingRemoved(ctx, CompLink, PropServer),
 			l.Server.Value, nil)
 	}
 	if l.Server.IsEmpty() && !r.Server.IsEmpty() {
 		CreateChange(&changes, PropertyAdded, v3.ServerLabel,
-			nil, r.Server.ValueNode, BreakingAdded(CompLink, PropServer),
+			nil, r.Server.ValueNode, breakingAdded(ctx, CompLink, PropServer),
 			nil, r.Server.Value)
 	}
 
@@ -113,13 +119,13 @@ func CompareLinks(l, r *v3.Link) *LinkChanges {
 	for k := range lValues {
 		if _, ok := rValues[k]; !ok {
 			CreateChange(&changes, ObjectRemoved, v3.ParametersLabel,
-				lValues[k].ValueNode, nil, BreakingRemoved(CompLink, PropParameters),
+				lValues[k].ValueNode, nil, breakingRemoved(ctx, CompLink, PropParameters),
 				k, nil)
 			continue
 		}
 		if lValues[k].Value != rValues[k].Value {
 			CreateChange(&changes, Modified, v3.ParametersLabel,
-				lValues[k].ValueNode, rValues[k].ValueNode, BreakingModified(CompLink, PropParameters),
+				lValues[k].ValueNode, rValues[k].ValueNode, breakingModified(ctx, CompLink, PropParameters),
 				k, k)
 		}
 
@@ -127,11 +133,12 @@ func CompareLinks(l, r *v3.Link) *LinkChanges {
 	for k := range rValues {
 		if _, ok := lValues[k]; !ok {
 			CreateChange(&changes, ObjectAdded, v3.ParametersLabel,
-				nil, rValues[k].ValueNode, BreakingAdded(CompLink, PropParameters),
+				nil, rValues[k].ValueNode, breakingAdded(ctx, CompLink, PropParameters),
 				nil, k)
 		}
 	}
 
-	lc.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	lc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return lc
 }
@@ -4,7 +4,8 @@
 package model
 
 import (
-	"github.com/pb33f/libopenapi/datamodel/low"
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
 
@@ -106,65 +107,70 @@ func (m *MediaTypeChanges) TotalBreakingChanges() int {
 // CompareMediaTypes compares a left and a right MediaType object for any changes. If found, a pointer to a
 // MediaTypeChanges instance is returned; otherwise nothing is returned.
 func CompareMediaTypes(l, r *v3.MediaType) *MediaTypeChanges {
+	return compareMediaTypes(context.Background(), l, r)
+}
+
+func compareMediaTypes(ctx context.Context, l, r *v3.MediaType) *MediaTypeChanges {
 	var props []*PropertyCheck
 	var changes []*Change
 
 	mc := new(MediaTypeChanges)
 
-	if low.AreEqual(l, r) {
+	if areEqual(ctx, l, r) {
 		return nil
 	}
 
 	// Example
 	CheckPropertyAdditionOrRemovalWithEncoding(l.Example.ValueNode, r.Example.ValueNode,
 		v3.ExampleLabel, &changes,
-		BreakingAdded(CompMediaType, PropExample) || BreakingRemoved(CompMediaType, PropExample),
+		breakingAdded(ctx, CompMediaType, PropExample) || breakingRemoved(ctx, CompMediaType, PropExample),
 		l.Example.Value, r.Example.Value)
-	CheckForModificationWithEncoding(l.Example.ValueNode, r.Example.ValueNode,
-		v3.ExampleLabel, &changes, BreakingModified(CompMediaType, PropExample),
+	CheckForExampleModification(l.Example.ValueNode, r.Example.ValueNode,
+		v3.ExampleLabel, &changes, breakingModified(ctx, CompMediaType, PropExample),
 		l.Example.Value, r.Example.Value)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	// schema
 	if !l.Schema.IsEmpty() && !r.Schema.IsEmpty() {
-		mc.SchemaChanges = CompareSchemas(l.Schema.Value, r.Schema.Value)
+		mc.SchemaChanges = compareSchemas(ctx, l.Schema.Value, r.Schema.Value)
 	}
 	if !l.Schema.IsEmpty() && r.Schema.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.SchemaLabel, l.Schema.ValueNode,
-			nil, BreakingRemoved(CompMediaType, PropSchema), l.Schema.Value, nil)
+			nil, breakingRemoved(ctx, CompMediaType, PropSchema), l.Schema.Value, nil)
 	}
 	if l.Schema.IsEmpty() && !r.Schema.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.SchemaLabel, nil,
-			r.Schema.ValueNode, BreakingAdded(CompMediaType, PropSchema), nil, r.Schema.Value)
+			r.Schema.ValueNode, breakingAdded(ctx, CompMediaType, PropSchema), nil, r.Schema.Value)
 	}
 
 	// examples - use nil-aware version so added/removed examples appear in the map for tree rendering
-	mc.ExampleChanges = CheckMapForChangesWithNilSupport(l.Examples.Value, r.Examples.Value,
-		&changes, v3.ExamplesLabel, CompareExamples)
+	mc.ExampleChanges = checkMapForChangesWithNilSupport(ctx, l.Examples.Value, r.Examples.Value,
+		&changes, v3.ExamplesLabel, compareExamples)
 
 	// encoding
-	mc.EncodingChanges = CheckMapForChanges(l.Encoding.Value, r.Encoding.Value,
-		&changes, v3.EncodingLabel, CompareEncoding)
+	mc.EncodingChanges = checkMapForChanges(ctx, l.Encoding.Value, r.Encoding.Value,
+		&changes, v3.EncodingLabel, compareEncoding)
 
 	// itemSchema
 	if !l.ItemSchema.IsEmpty() && !r.ItemSchema.IsEmpty() {
-		mc.ItemSchemaChanges = CompareSchemas(l.ItemSchema.Value, r.ItemSchema.Value)
+		mc.ItemSchemaChanges = compareSchemas(ctx, l.ItemSchema.Value, r.ItemSchema.Value)
 	}
 	if !l.ItemSchema.IsEmpty() && r.ItemSchema.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.ItemSchemaLabel, l.ItemSchema.ValueNode,
-			nil, BreakingRemoved(CompMediaType, PropItemSchema), l.ItemSchema.Value, nil)
+			nil, breakingRemoved(ctx, CompMediaType, PropItemSchema), l.ItemSchema.Value, nil)
 	}
 	if l.ItemSchema.IsEmpty() && !r.ItemSchema.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.ItemSchemaLabel, nil,
-			r.ItemSchema.ValueNode, BreakingAdded(CompMediaType, PropItemSchema), nil, r.ItemSchema.Value)
+			r.ItemSchema.ValueNode, breakingAdded(ctx, CompMediaType, PropItemSchema), nil, r.ItemSchema.Value)
 	}
 
 	// itemEncoding
-	mc.ItemEncodingChanges = CheckMapForChanges(l.ItemEncoding.Value, r.ItemEncoding.Value,
-		&changes, v3.ItemEncodingLabel, CompareEncoding)
+	mc.ItemEncodingChanges = checkMapForChanges(ctx, l.ItemEncoding.Value, r.ItemEncoding.Value,
+		&changes, v3.ItemEncodingLabel, compareEncoding)
 
-	mc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
-	mc.PropertyChanges = NewPropertyChanges(changes)
+	mc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
+	checkComparators(l, r, &changes)
+	mc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return mc
 }
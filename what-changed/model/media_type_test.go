@@ -138,6 +138,33 @@ example:
 	assert.Equal(t, v3.ExampleLabel, extChanges.Changes[0].Property)
 }
 
+func TestCompareMediaTypes_Example_Reformatted(t *testing.T) {
+	left := `schema:
+  type: object
+example:
+  smoke: and a pancake?
+  pancakes: 2`
+
+	right := `schema:
+  type: object
+example: {"pancakes": 2.0, "smoke": "and a pancake?"}`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	// create low level objects
+	var lDoc v3.MediaType
+	var rDoc v3.MediaType
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// the same example, written differently.
+	assert.Equal(t, 0, CompareMediaTypes(&lDoc, &rDoc).TotalChanges())
+}
+
 func TestCompareMediaTypes_ExampleChangedToMap(t *testing.T) {
 	left := `schema:
   type: string`
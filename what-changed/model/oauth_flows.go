@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -98,6 +100,10 @@ func (o *OAuthFlowsChanges) TotalBreakingChanges() int {
 // CompareOAuthFlows compares a left and right OAuthFlows object. If changes are found a pointer to *OAuthFlowsChanges
 // is returned, otherwise nil is returned.
 func CompareOAuthFlows(l, r *v3.OAuthFlows) *OAuthFlowsChanges {
+	return compareOAuthFlows(context.Background(), l, r)
+}
+
+func compareOAuthFlows(ctx context.Context, l, r *v3.OAuthFlows) *OAuthFlowsChanges {
 	if low.AreEqual(l, r) {
 		return nil
 	}
@@ -107,81 +113,82 @@ func CompareOAuthFlows(l, r *v3.OAuthFlows) *OAuthFlowsChanges {
 
 	// client credentials
 	if !l.ClientCredentials.IsEmpty() && !r.ClientCredentials.IsEmpty() {
-		oa.ClientCredentialsChanges = CompareOAuthFlow(l.ClientCredentials.Value, r.ClientCredentials.Value)
+		oa.ClientCredentialsChanges = compareOAuthFlow(ctx, l.ClientCredentials.Value, r.ClientCredentials.Value)
 	}
 	if !l.ClientCredentials.IsEmpty() && r.ClientCredentials.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.ClientCredentialsLabel,
-			l.ClientCredentials.ValueNode, nil, BreakingRemoved(CompOAuthFlows, PropClientCredentials),
+			l.ClientCredentials.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlows, PropClientCredentials),
 			l.ClientCredentials.Value, nil)
 	}
 	if l.ClientCredentials.IsEmpty() && !r.ClientCredentials.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.ClientCredentialsLabel,
-			nil, r.ClientCredentials.ValueNode, BreakingAdded(CompOAuthFlows, PropClientCredentials),
+			nil, r.ClientCredentials.ValueNode, breakingAdded(ctx, CompOAuthFlows, PropClientCredentials),
 			nil, r.ClientCredentials.Value)
 	}
 
 	// implicit
 	if !l.Implicit.IsEmpty() && !r.Implicit.IsEmpty() {
-		oa.ImplicitChanges = CompareOAuthFlow(l.Implicit.Value, r.Implicit.Value)
+		oa.ImplicitChanges = compareOAuthFlow(ctx, l.Implicit.Value, r.Implicit.Value)
 	}
 	if !l.Implicit.IsEmpty() && r.Implicit.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.ImplicitLabel,
-			l.Implicit.ValueNode, nil, BreakingRemoved(CompOAuthFlows, PropImplicit),
+			l.Implicit.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlows, PropImplicit),
 			l.Implicit.Value, nil)
 	}
 	if l.Implicit.IsEmpty() && !r.Implicit.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.ImplicitLabel,
-			nil, r.Implicit.ValueNode, BreakingAdded(CompOAuthFlows, PropImplicit),
+			nil, r.Implicit.ValueNode, breakingAdded(ctx, CompOAuthFlows, PropImplicit),
 			nil, r.Implicit.Value)
 	}
 
 	// password
 	if !l.Password.IsEmpty() && !r.Password.IsEmpty() {
-		oa.PasswordChanges = CompareOAuthFlow(l.Password.Value, r.Password.Value)
+		oa.PasswordChanges = compareOAuthFlow(ctx, l.Password.Value, r.Password.Value)
 	}
 	if !l.Password.IsEmpty() && r.Password.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.PasswordLabel,
-			l.Password.ValueNode, nil, BreakingRemoved(CompOAuthFlows, PropPassword),
+			l.Password.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlows, PropPassword),
 			l.Password.Value, nil)
 	}
 	if l.Password.IsEmpty() && !r.Password.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.PasswordLabel,
-			nil, r.Password.ValueNode, BreakingAdded(CompOAuthFlows, PropPassword),
+			nil, r.Password.ValueNode, breakingAdded(ctx, CompOAuthFlows, PropPassword),
 			nil, r.Password.Value)
 	}
 
 	// auth code
 	if !l.AuthorizationCode.IsEmpty() && !r.AuthorizationCode.IsEmpty() {
-		oa.AuthorizationCodeChanges = CompareOAuthFlow(l.AuthorizationCode.Value, r.AuthorizationCode.Value)
+		oa.AuthorizationCodeChanges = compareOAuthFlow(ctx, l.AuthorizationCode.Value, r.AuthorizationCode.Value)
 	}
 	if !l.AuthorizationCode.IsEmpty() && r.AuthorizationCode.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.AuthorizationCodeLabel,
-			l.AuthorizationCode.ValueNode, nil, BreakingRemoved(CompOAuthFlows, PropAuthorizationCode),
+			l.AuthorizationCode.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlows, PropAuthorizationCode),
 			l.AuthorizationCode.Value, nil)
 	}
 	if l.AuthorizationCode.IsEmpty() && !r.AuthorizationCode.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.AuthorizationCodeLabel,
-			nil, r.AuthorizationCode.ValueNode, BreakingAdded(CompOAuthFlows, PropAuthorizationCode),
+			nil, r.AuthorizationCode.ValueNode, breakingAdded(ctx, CompOAuthFlows, PropAuthorizationCode),
 			nil, r.AuthorizationCode.Value)
 	}
 
 	// device flow (OpenAPI 3.2+)
 	if !l.Device.IsEmpty() && !r.Device.IsEmpty() {
-		oa.DeviceChanges = CompareOAuthFlow(l.Device.Value, r.Device.Value)
+		oa.DeviceChanges = compareOAuthFlow(ctx, l.Device.Value, r.Device.Value)
 	}
 	if !l.Device.IsEmpty() && r.Device.IsEmpty() {
 		CreateChange(&changes, ObjectRemoved, v3.DeviceLabel,
-			l.Device.ValueNode, nil, BreakingRemoved(CompOAuthFlows, PropDevice),
+			l.Device.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlows, PropDevice),
 			l.Device.Value, nil)
 	}
 	if l.Device.IsEmpty() && !r.Device.IsEmpty() {
 		CreateChange(&changes, ObjectAdded, v3.DeviceLabel,
-			nil, r.Device.ValueNode, BreakingAdded(CompOAuthFlows, PropDevice),
+			nil, r.Device.ValueNode, breakingAdded(ctx, CompOAuthFlows, PropDevice),
 			nil, r.Device.Value)
 	}
 
-	oa.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
-	oa.PropertyChanges = NewPropertyChanges(changes)
+	oa.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
+	checkComparators(l, r, &changes)
+	oa.PropertyChanges = newPropertyChanges(ctx, changes)
 	return oa
 }
 
@@ -224,6 +231,10 @@ func (o *OAuthFlowChanges) TotalBreakingChanges() int {
 // CompareOAuthFlow checks a left and a right OAuthFlow object for changes. If found, returns a pointer to
 // an OAuthFlowChanges instance, or nil if nothing is found.
 func CompareOAuthFlow(l, r *v3.OAuthFlow) *OAuthFlowChanges {
+	return compareOAuthFlow(context.Background(), l, r)
+}
+
+func compareOAuthFlow(ctx context.Context, l, r *v3.OAuthFlow) *OAuthFlowChanges {
 	if low.AreEqual(l, r) {
 		return nil
 	}
@@ -243,28 +254,29 @@ func CompareOAuthFlow(l, r *v3.OAuthFlow) *OAuthFlowChanges {
 			v3.RefreshUrlLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	for k, v := range l.Scopes.Value.FromOldest() {
 		if r != nil && r.FindScope(k.Value) == nil {
-			CreateChange(&changes, ObjectRemoved, v3.Scopes, v.ValueNode, nil, BreakingRemoved(CompOAuthFlow, PropScopes), k.Value, nil)
+			CreateChange(&changes, ObjectRemoved, v3.Scopes, v.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlow, PropScopes), k.Value, nil)
 			continue
 		}
 		if r != nil && r.FindScope(k.Value) != nil {
 			if v.Value != r.FindScope(k.Value).Value {
 				CreateChange(&changes, Modified, v3.Scopes,
-					v.ValueNode, r.FindScope(k.Value).ValueNode, BreakingModified(CompOAuthFlow, PropScopes),
+					v.ValueNode, r.FindScope(k.Value).ValueNode, breakingModified(ctx, CompOAuthFlow, PropScopes),
 					v.Value, r.FindScope(k.Value).Value)
 			}
 		}
 	}
 	for k, v := range r.Scopes.Value.FromOldest() {
 		if l != nil && l.FindScope(k.Value) == nil {
-			CreateChange(&changes, ObjectAdded, v3.Scopes, nil, v.ValueNode, BreakingAdded(CompOAuthFlow, PropScopes), nil, k.Value)
+			CreateChange(&changes, ObjectAdded, v3.Scopes, nil, v.ValueNode, breakingAdded(ctx, CompOAuthFlow, PropScopes), nil, k.Value)
 		}
 	}
+	checkComparators(l, r, &changes)
 	oa := new(OAuthFlowChanges)
-	oa.PropertyChanges = NewPropertyChanges(changes)
-	oa.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	oa.PropertyChanges = newPropertyChanges(ctx, changes)
+	oa.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 	return oa
 }
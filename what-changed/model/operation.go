@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 	"sort"
 	"strings"
@@ -28,6 +29,9 @@ type OperationChanges struct {
 	ServerChanges      []*ServerChanges            `json:"servers,omitempty" yaml:"servers,omitempty"`
 	ExtensionChanges   *ExtensionChanges           `json:"extensions,omitempty" yaml:"extensions,omitempty"`
 	CallbackChanges    map[string]*CallbackChanges `json:"callbacks,omitempty" yaml:"callbacks,omitempty"`
+
+	// operationID is the operationId of the right operation, or the left one if the right has none.
+	operationID string
 }
 
 // GetAllChanges returns a slice of all changes made between Operation objects
@@ -126,71 +130,75 @@ func (o *OperationChanges) TotalBreakingChanges() int {
 }
 
 // check for properties shared between operations objects.
-func addSharedOperationProperties(left, right low.SharedOperations, changes *[]*Change) []*PropertyCheck {
+func addSharedOperationProperties(ctx context.Context, left, right low.SharedOperations, changes *[]*Change) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	// tags
 	if len(left.GetTags().Value) > 0 || len(right.GetTags().Value) > 0 {
-		ExtractStringValueSliceChangesWithRules(left.GetTags().Value, right.GetTags().Value,
+		extractStringValueSliceChangesWithRules(ctx, left.GetTags().Value, right.GetTags().Value,
 			changes, v3.TagsLabel, CompOperation, PropTags)
+		if orderedArrays(ctx).Tags {
+			checkReordered(valueKeys(left.GetTags().Value), valueKeys(right.GetTags().Value), v3.TagsLabel,
+				left.GetTags().ValueNode, right.GetTags().ValueNode, breakingModified(ctx, CompOperation, PropTags), changes)
+		}
 	}
 
 	// summary
 	addPropertyCheck(&props, left.GetSummary().ValueNode, right.GetSummary().ValueNode,
 		left.GetSummary(), right.GetSummary(), changes, v3.SummaryLabel,
-		BreakingModified(CompOperation, PropSummary), CompOperation, PropSummary)
+		breakingModified(ctx, CompOperation, PropSummary), CompOperation, PropSummary)
 
 	// description
 	addPropertyCheck(&props, left.GetDescription().ValueNode, right.GetDescription().ValueNode,
 		left.GetDescription(), right.GetDescription(), changes, v3.DescriptionLabel,
-		BreakingModified(CompOperation, PropDescription), CompOperation, PropDescription)
+		breakingModified(ctx, CompOperation, PropDescription), CompOperation, PropDescription)
 
 	// deprecated
 	addPropertyCheck(&props, left.GetDeprecated().ValueNode, right.GetDeprecated().ValueNode,
 		left.GetDeprecated(), right.GetDeprecated(), changes, v3.DeprecatedLabel,
-		BreakingModified(CompOperation, PropDeprecated), CompOperation, PropDeprecated)
+		breakingModified(ctx, CompOperation, PropDeprecated), CompOperation, PropDeprecated)
 
 	// operation id
 	addPropertyCheck(&props, left.GetOperationId().ValueNode, right.GetOperationId().ValueNode,
 		left.GetOperationId(), right.GetOperationId(), changes, v3.OperationIdLabel,
-		BreakingModified(CompOperation, PropOperationID), CompOperation, PropOperationID)
+		breakingModified(ctx, CompOperation, PropOperationID), CompOperation, PropOperationID)
 
 	return props
 }
 
 // check shared objects
-func compareSharedOperationObjects(l, r low.SharedOperations, changes *[]*Change, opChanges *OperationChanges) {
+func compareSharedOperationObjects(ctx context.Context, l, r low.SharedOperations, changes *[]*Change, opChanges *OperationChanges) {
 	// external docs
 	if !l.GetExternalDocs().IsEmpty() && !r.GetExternalDocs().IsEmpty() {
 		lExtDoc := l.GetExternalDocs().Value.(*base.ExternalDoc)
 		rExtDoc := r.GetExternalDocs().Value.(*base.ExternalDoc)
 		if !low.AreEqual(lExtDoc, rExtDoc) {
-			opChanges.ExternalDocChanges = CompareExternalDocs(lExtDoc, rExtDoc)
+			opChanges.ExternalDocChanges = compareExternalDocs(ctx, lExtDoc, rExtDoc)
 		}
 	}
 	if l.GetExternalDocs().IsEmpty() && !r.GetExternalDocs().IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.ExternalDocsLabel,
-			nil, r.GetExternalDocs().ValueNode, BreakingAdded(CompOperation, PropExternalDocs), nil,
+			nil, r.GetExternalDocs().ValueNode, breakingAdded(ctx, CompOperation, PropExternalDocs), nil,
 			r.GetExternalDocs().Value)
 	}
 	if !l.GetExternalDocs().IsEmpty() && r.GetExternalDocs().IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.ExternalDocsLabel,
-			l.GetExternalDocs().ValueNode, nil, BreakingRemoved(CompOperation, PropExternalDocs), l.GetExternalDocs().Value,
+			l.GetExternalDocs().ValueNode, nil, breakingRemoved(ctx, CompOperation, PropExternalDocs), l.GetExternalDocs().Value,
 			nil)
 	}
 
 	// responses
 	if !l.GetResponses().IsEmpty() && !r.GetResponses().IsEmpty() {
-		opChanges.ResponsesChanges = CompareResponses(l.GetResponses().Value, r.GetResponses().Value)
+		opChanges.ResponsesChanges = compareResponses(ctx, l.GetResponses().Value, r.GetResponses().Value)
 	}
 	if l.GetResponses().IsEmpty() && !r.GetResponses().IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.ResponsesLabel,
-			nil, r.GetResponses().ValueNode, BreakingAdded(CompOperation, PropResponses), nil,
+			nil, r.GetResponses().ValueNode, breakingAdded(ctx, CompOperation, PropResponses), nil,
 			r.GetResponses().Value)
 	}
 	if !l.GetResponses().IsEmpty() && r.GetResponses().IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.ResponsesLabel,
-			l.GetResponses().ValueNode, nil, BreakingRemoved(CompOperation, PropResponses), l.GetResponses().Value,
+			l.GetResponses().ValueNode, nil, breakingRemoved(ctx, CompOperation, PropResponses), l.GetResponses().Value,
 			nil)
 	}
 }
@@ -198,6 +206,13 @@ func compareSharedOperationObjects(l, r low.SharedOperations, changes *[]*Change
 // CompareOperations compares a left and right Swagger or OpenAPI Operation object. If changes are found, returns
 // a pointer to an OperationChanges instance, or nil if nothing is found.
 func CompareOperations(l, r any) *OperationChanges {
+	return compareOperations(context.Background(), l, r)
+}
+
+func compareOperations(ctx context.Context, l, r any) *OperationChanges {
+	if ComparisonAborted(ctx) {
+		return nil
+	}
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -211,13 +226,13 @@ func CompareOperations(l, r any) *OperationChanges {
 		rOperation := r.(*v2.Operation)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lOperation, rOperation) {
+		if areEqual(ctx, lOperation, rOperation) {
 			return nil
 		}
 
-		props = append(props, addSharedOperationProperties(lOperation, rOperation, &changes)...)
+		props = append(props, addSharedOperationProperties(ctx, lOperation, rOperation, &changes)...)
 
-		compareSharedOperationObjects(lOperation, rOperation, &changes, oc)
+		compareSharedOperationObjects(ctx, lOperation, rOperation, &changes, oc)
 
 		// parameters
 		lParamsUntyped := lOperation.GetParameters()
@@ -245,8 +260,8 @@ func CompareOperations(l, r any) *OperationChanges {
 			var paramChanges []*ParameterChanges
 			for n := range lv {
 				if _, ok := rv[n]; ok {
-					if !low.AreEqual(lv[n], rv[n]) {
-						ch := CompareParameters(lv[n], rv[n])
+					if !areEqual(ctx, lv[n], rv[n]) {
+						ch := compareParameters(ctx, lv[n], rv[n])
 						if ch != nil {
 							// Preserve reference information if this parameter is a $ref
 							PreserveParameterReference(lRefs, rRefs, n, ch)
@@ -256,7 +271,7 @@ func CompareOperations(l, r any) *OperationChanges {
 					continue
 				}
 				CreateChange(&changes, ObjectRemoved, v3.ParametersLabel,
-					lv[n].Name.ValueNode, nil, BreakingRemoved(CompOperation, PropParameters), lv[n],
+					lv[n].Name.ValueNode, nil, breakingRemoved(ctx, CompOperation, PropParameters), lv[n],
 					nil)
 
 			}
@@ -271,7 +286,7 @@ func CompareOperations(l, r any) *OperationChanges {
 		}
 		if !lParamsUntyped.IsEmpty() && rParamsUntyped.IsEmpty() {
 			CreateChange(&changes, PropertyRemoved, v3.ParametersLabel,
-				lParamsUntyped.ValueNode, nil, true, lParamsUntyped.Value,
+				lParamsUntyped.ValueNode, nil, breakingRemoved(ctx, CompOperation, PropParameters), lParamsUntyped.Value,
 				nil)
 		}
 		if lParamsUntyped.IsEmpty() && !rParamsUntyped.IsEmpty() {
@@ -287,9 +302,10 @@ func CompareOperations(l, r any) *OperationChanges {
 				rParamsUntyped.Value)
 		}
 
-		// security
-		if !lOperation.Security.IsEmpty() || !rOperation.Security.IsEmpty() {
-			checkSecurity(lOperation.Security, rOperation.Security, &changes, oc)
+		// security, unless it's inherited (see ComparisonOptions)
+		if (!lOperation.Security.IsEmpty() || !rOperation.Security.IsEmpty()) &&
+			!inheritsSecurity(ctx, lOperation.Security, rOperation.Security) {
+			checkSecurity(ctx, lOperation.Security, rOperation.Security, &changes, oc)
 		}
 
 		// produces
@@ -310,7 +326,7 @@ func CompareOperations(l, r any) *OperationChanges {
 				&changes, v3.SchemesLabel, true)
 		}
 
-		oc.ExtensionChanges = CompareExtensions(lOperation.Extensions, rOperation.Extensions)
+		oc.ExtensionChanges = compareExtensions(ctx, lOperation.Extensions, rOperation.Extensions)
 	}
 
 	// OpenAPI
@@ -321,12 +337,12 @@ func CompareOperations(l, r any) *OperationChanges {
 		rOperation := r.(*v3.Operation)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lOperation, rOperation) {
+		if areEqual(ctx, lOperation, rOperation) {
 			return nil
 		}
 
-		props = append(props, addSharedOperationProperties(lOperation, rOperation, &changes)...)
-		compareSharedOperationObjects(lOperation, rOperation, &changes, oc)
+		props = append(props, addSharedOperationProperties(ctx, lOperation, rOperation, &changes)...)
+		compareSharedOperationObjects(ctx, lOperation, rOperation, &changes, oc)
 
 		// parameters
 		lParamsUntyped := lOperation.GetParameters()
@@ -354,8 +370,8 @@ func CompareOperations(l, r any) *OperationChanges {
 			var paramChanges []*ParameterChanges
 			for n := range lv {
 				if _, ok := rv[n]; ok {
-					if !low.AreEqual(lv[n], rv[n]) {
-						ch := CompareParameters(lv[n], rv[n])
+					if !areEqual(ctx, lv[n], rv[n]) {
+						ch := compareParameters(ctx, lv[n], rv[n])
 						if ch != nil {
 							// Preserve reference information if this parameter is a $ref
 							PreserveParameterReference(lRefs, rRefs, n, ch)
@@ -365,14 +381,14 @@ func CompareOperations(l, r any) *OperationChanges {
 					continue
 				}
 				CreateChange(&changes, ObjectRemoved, v3.ParametersLabel,
-					lv[n].Name.ValueNode, nil, BreakingRemoved(CompOperation, PropParameters), lv[n],
+					lv[n].Name.ValueNode, nil, breakingRemoved(ctx, CompOperation, PropParameters), lv[n],
 					nil)
 
 			}
 			for n := range rv {
 				if _, ok := lv[n]; !ok {
 					// Check configurable breaking rules first
-					breaking := BreakingAdded(CompOperation, PropParameters)
+					breaking := breakingAdded(ctx, CompOperation, PropParameters)
 					// If config doesn't say breaking, fall back to semantic check (required parameter)
 					if !breaking {
 						breaking = rv[n].Required.Value
@@ -386,13 +402,13 @@ func CompareOperations(l, r any) *OperationChanges {
 		}
 		if !lParamsUntyped.IsEmpty() && rParamsUntyped.IsEmpty() {
 			CreateChange(&changes, PropertyRemoved, v3.ParametersLabel,
-				lParamsUntyped.ValueNode, nil, BreakingRemoved(CompOperation, PropParameters), lParamsUntyped.Value,
+				lParamsUntyped.ValueNode, nil, breakingRemoved(ctx, CompOperation, PropParameters), lParamsUntyped.Value,
 				nil)
 		}
 		if lParamsUntyped.IsEmpty() && !rParamsUntyped.IsEmpty() {
 			rParams := rParamsUntyped.Value.([]low.ValueReference[*v3.Parameter])
 			// Check configurable breaking rules first
-			breaking := BreakingAdded(CompOperation, PropParameters)
+			breaking := breakingAdded(ctx, CompOperation, PropParameters)
 			// If config doesn't say breaking, fall back to semantic check (required parameter)
 			if !breaking {
 				for i := range rParams {
@@ -407,52 +423,60 @@ func CompareOperations(l, r any) *OperationChanges {
 				rParamsUntyped.Value)
 		}
 
-		// security
-		if !lOperation.Security.IsEmpty() || !rOperation.Security.IsEmpty() {
-			checkSecurity(lOperation.Security, rOperation.Security, &changes, oc)
+		// security, unless it's inherited (see ComparisonOptions)
+		if (!lOperation.Security.IsEmpty() || !rOperation.Security.IsEmpty()) &&
+			!inheritsSecurity(ctx, lOperation.Security, rOperation.Security) {
+			checkSecurity(ctx, lOperation.Security, rOperation.Security, &changes, oc)
 		}
 
 		// request body
 		if !lOperation.RequestBody.IsEmpty() && !rOperation.RequestBody.IsEmpty() {
-			if !low.AreEqual(lOperation.RequestBody.Value, rOperation.RequestBody.Value) {
-				oc.RequestBodyChanges = CompareRequestBodies(lOperation.RequestBody.Value, rOperation.RequestBody.Value)
+			if !areEqual(ctx, lOperation.RequestBody.Value, rOperation.RequestBody.Value) {
+				oc.RequestBodyChanges = compareRequestBodies(ctx, lOperation.RequestBody.Value, rOperation.RequestBody.Value)
 			}
 		}
 		if !lOperation.RequestBody.IsEmpty() && rOperation.RequestBody.IsEmpty() {
 			CreateChange(&changes, PropertyRemoved, v3.RequestBodyLabel,
-				lOperation.RequestBody.ValueNode, nil, BreakingRemoved(CompOperation, PropRequestBody), lOperation.RequestBody.Value,
+				lOperation.RequestBody.ValueNode, nil, breakingRemoved(ctx, CompOperation, PropRequestBody), lOperation.RequestBody.Value,
 				nil)
 		}
 		if lOperation.RequestBody.IsEmpty() && !rOperation.RequestBody.IsEmpty() {
 			CreateChange(&changes, PropertyAdded, v3.RequestBodyLabel,
-				nil, rOperation.RequestBody.ValueNode, BreakingAdded(CompOperation, PropRequestBody), nil,
+				nil, rOperation.RequestBody.ValueNode, breakingAdded(ctx, CompOperation, PropRequestBody), nil,
 				rOperation.RequestBody.Value)
 		}
 
 		// callbacks - use CheckMapForChangesWithNilSupport to properly populate CallbackChanges
 		// for added/removed callbacks, enabling proper tree hierarchy rendering
-		oc.CallbackChanges = CheckMapForChangesWithNilSupport(lOperation.Callbacks.Value, rOperation.Callbacks.Value,
-			&changes, v3.CallbacksLabel, CompareCallback)
+		oc.CallbackChanges = checkMapForChangesWithNilSupport(ctx, lOperation.Callbacks.Value, rOperation.Callbacks.Value,
+			&changes, v3.CallbacksLabel, compareCallback)
 
 		// servers
-		oc.ServerChanges = checkServers(lOperation.Servers, rOperation.Servers, CompOperation, PropServers)
-		oc.ExtensionChanges = CompareExtensions(lOperation.Extensions, rOperation.Extensions)
+		oc.ServerChanges = checkServers(ctx, lOperation.Servers, rOperation.Servers, CompOperation, PropServers)
+		oc.ExtensionChanges = compareExtensions(ctx, lOperation.Extensions, rOperation.Extensions)
 
 	}
-	CheckProperties(props)
-	oc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	oc.PropertyChanges = newPropertyChanges(ctx, changes)
+	oc.operationID = operationID(r)
+	if oc.operationID == "" {
+		oc.operationID = operationID(l)
+	}
 	return oc
 }
 
 // check servers property
 // component and property are used for breaking rules lookup (e.g., CompOperation/PropServers or CompServers/"")
-func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.Server]], component, property string) []*ServerChanges {
+func checkServers(ctx context.Context, lServers, rServers low.NodeReference[[]low.ValueReference[*v3.Server]], component, property string) []*ServerChanges {
 	var serverChanges []*ServerChanges
 
 	if !lServers.IsEmpty() && !rServers.IsEmpty() {
 
 		lv := make(map[string]low.ValueReference[*v3.Server], len(lServers.Value))
 		rv := make(map[string]low.ValueReference[*v3.Server], len(rServers.Value))
+		lKeys := make([]string, len(lServers.Value))
+		rKeys := make([]string, len(rServers.Value))
 
 		for i := range lServers.Value {
 			var s string
@@ -461,6 +485,7 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 			} else {
 				s = low.GenerateHashString(lServers.Value[i].Value)
 			}
+			lKeys[i] = s
 			lv[s] = lServers.Value[i]
 		}
 		for i := range rServers.Value {
@@ -470,6 +495,7 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 			} else {
 				s = low.GenerateHashString(rServers.Value[i].Value)
 			}
+			rKeys[i] = s
 			rv[s] = rServers.Value[i]
 		}
 
@@ -478,17 +504,17 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 			var changes []*Change
 
 			if _, ok := rv[k]; ok {
-				if !low.AreEqual(lv[k].Value, rv[k].Value) {
-					serverChanges = append(serverChanges, CompareServers(lv[k].Value, rv[k].Value))
+				if !areEqual(ctx, lv[k].Value, rv[k].Value) {
+					serverChanges = append(serverChanges, compareServers(ctx, lv[k].Value, rv[k].Value))
 				}
 				continue
 			}
 			lv[k].ValueNode.Value = lv[k].Value.URL.Value
 			CreateChange(&changes, ObjectRemoved, v3.ServersLabel,
-				lv[k].ValueNode, nil, BreakingRemoved(component, property), lv[k].Value,
+				lv[k].ValueNode, nil, breakingRemoved(ctx, component, property), lv[k].Value,
 				nil)
 			sc := new(ServerChanges)
-			sc.PropertyChanges = NewPropertyChanges(changes)
+			sc.PropertyChanges = newPropertyChanges(ctx, changes)
 			serverChanges = append(serverChanges, sc)
 
 		}
@@ -499,28 +525,37 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 				var changes []*Change
 				rv[k].ValueNode.Value = rv[k].Value.URL.Value
 				CreateChange(&changes, ObjectAdded, v3.ServersLabel,
-					nil, rv[k].ValueNode, BreakingAdded(component, property), nil,
+					nil, rv[k].ValueNode, breakingAdded(ctx, component, property), nil,
 					rv[k].Value)
 
 				sc := new(ServerChanges)
-				sc.PropertyChanges = NewPropertyChanges(changes)
+				sc.PropertyChanges = newPropertyChanges(ctx, changes)
 				serverChanges = append(serverChanges, sc)
 			}
 		}
+
+		if orderedArrays(ctx).Servers {
+			var changes []*Change
+			checkReordered(lKeys, rKeys, v3.ServersLabel, lServers.ValueNode, rServers.ValueNode,
+				breakingModified(ctx, component, property), &changes)
+			if len(changes) > 0 {
+				serverChanges = append(serverChanges, &ServerChanges{PropertyChanges: newPropertyChanges(ctx, changes)})
+			}
+		}
 	}
 	var changes []*Change
 	sc := new(ServerChanges)
 	if !lServers.IsEmpty() && rServers.IsEmpty() {
 		CreateChange(&changes, PropertyRemoved, v3.ServersLabel,
-			lServers.ValueNode, nil, BreakingRemoved(component, property), lServers.Value,
+			lServers.ValueNode, nil, breakingRemoved(ctx, component, property), lServers.Value,
 			nil)
 	}
 	if lServers.IsEmpty() && !rServers.IsEmpty() {
 		CreateChange(&changes, PropertyAdded, v3.ServersLabel,
-			nil, rServers.ValueNode, BreakingAdded(component, property), nil,
+			nil, rServers.ValueNode, breakingAdded(ctx, component, property), nil,
 			rServers.Value)
 	}
-	sc.PropertyChanges = NewPropertyChanges(changes)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if len(changes) > 0 {
 		serverChanges = append(serverChanges, sc)
 	}
@@ -531,18 +566,21 @@ func checkServers(lServers, rServers low.NodeReference[[]low.ValueReference[*v3.
 }
 
 // check security property.
-func checkSecurity(lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]],
+func checkSecurity(ctx context.Context, lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*base.SecurityRequirement]],
 	changes *[]*Change, oc any,
 ) {
 	lv := make(map[string]*base.SecurityRequirement, len(lSecurity.Value))
 	rv := make(map[string]*base.SecurityRequirement, len(rSecurity.Value))
 	lvn := make(map[string]*yaml.Node, len(lSecurity.Value))
 	rvn := make(map[string]*yaml.Node, len(rSecurity.Value))
+	lKeys := make([]string, len(lSecurity.Value))
+	rKeys := make([]string, len(rSecurity.Value))
 
 	for i := range lSecurity.Value {
 		keys := lSecurity.Value[i].Value.GetKeys()
 		sort.Strings(keys)
 		s := strings.Join(keys, "|")
+		lKeys[i] = s
 		lv[s] = lSecurity.Value[i].Value
 		lvn[s] = lSecurity.Value[i].ValueNode
 
@@ -551,26 +589,29 @@ func checkSecurity(lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*
 		keys := rSecurity.Value[i].Value.GetKeys()
 		sort.Strings(keys)
 		s := strings.Join(keys, "|")
+		rKeys[i] = s
 		rv[s] = rSecurity.Value[i].Value
 		rvn[s] = rSecurity.Value[i].ValueNode
 	}
 
 	// Determine breaking rules based on type (zero allocations using type switch)
-	var addedBreaking, removedBreaking bool
+	var addedBreaking, modifiedBreaking, removedBreaking bool
 	switch oc.(type) {
 	case *DocumentChanges:
-		addedBreaking = BreakingAdded(CompSecurity, "")
-		removedBreaking = BreakingRemoved(CompSecurity, "")
+		addedBreaking = breakingAdded(ctx, CompSecurity, "")
+		modifiedBreaking = breakingModified(ctx, CompSecurity, "")
+		removedBreaking = breakingRemoved(ctx, CompSecurity, "")
 	case *OperationChanges:
-		addedBreaking = BreakingAdded(CompOperation, PropSecurity)
-		removedBreaking = BreakingRemoved(CompOperation, PropSecurity)
+		addedBreaking = breakingAdded(ctx, CompOperation, PropSecurity)
+		modifiedBreaking = breakingModified(ctx, CompOperation, PropSecurity)
+		removedBreaking = breakingRemoved(ctx, CompOperation, PropSecurity)
 	}
 
 	var secChanges []*SecurityRequirementChanges
 	for n := range lv {
 		if _, ok := rv[n]; ok {
-			if !low.AreEqual(lv[n], rv[n]) {
-				ch := CompareSecurityRequirement(lv[n], rv[n])
+			if !areEqual(ctx, lv[n], rv[n]) {
+				ch := compareSecurityRequirement(ctx, lv[n], rv[n])
 				if ch != nil {
 					secChanges = append(secChanges, ch)
 				}
@@ -585,7 +626,7 @@ func checkSecurity(lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*
 		CreateChange(&reqChanges, ObjectRemoved, schemeNames,
 			lvn[n], nil, removedBreaking, lv[n], nil)
 		secChanges = append(secChanges, &SecurityRequirementChanges{
-			PropertyChanges: NewPropertyChanges(reqChanges),
+			PropertyChanges: newPropertyChanges(ctx, reqChanges),
 		})
 	}
 	for n := range rv {
@@ -598,7 +639,17 @@ func checkSecurity(lSecurity, rSecurity low.NodeReference[[]low.ValueReference[*
 			CreateChange(&reqChanges, ObjectAdded, schemeNames,
 				nil, rvn[n], addedBreaking, nil, rv[n])
 			secChanges = append(secChanges, &SecurityRequirementChanges{
-				PropertyChanges: NewPropertyChanges(reqChanges),
+				PropertyChanges: newPropertyChanges(ctx, reqChanges),
+			})
+		}
+	}
+	if orderedArrays(ctx).Security {
+		var reqChanges []*Change
+		checkReordered(lKeys, rKeys, v3.SecurityLabel, lSecurity.ValueNode, rSecurity.ValueNode, modifiedBreaking,
+			&reqChanges)
+		if len(reqChanges) > 0 {
+			secChanges = append(secChanges, &SecurityRequirementChanges{
+				PropertyChanges: newPropertyChanges(ctx, reqChanges),
 			})
 		}
 	}
@@ -0,0 +1,137 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/datamodel/low"
+	"github.com/pb33f/libopenapi/utils"
+	"go.yaml.in/yaml/v4"
+)
+
+// OrderedArrays selects the arrays that are compared in order. By default, tags, servers, security requirements
+// and enums are compared as sets, so moving their items around is not a change, and only items that were added or
+// removed are reported.
+//
+// When an array is compared in order, and the items found in both versions of it are not in the same order, a
+// single Reordered change is reported for the array, alongside any items that were added or removed. Whether a
+// reordered array is breaking is determined by the 'modified' breaking rule of the array.
+type OrderedArrays struct {
+	// Tags compares the tags of documents and operations in order.
+	Tags bool `json:"tags,omitempty" yaml:"tags,omitempty"`
+
+	// Servers compares the servers of documents, path items and operations in order. The first server is the
+	// default one, so moving servers around can change where clients send requests.
+	Servers bool `json:"servers,omitempty" yaml:"servers,omitempty"`
+
+	// Security compares the security requirements of documents and operations in order.
+	Security bool `json:"security,omitempty" yaml:"security,omitempty"`
+
+	// Enums compares the enum values of schemas, parameters, headers and server variables in order.
+	Enums bool `json:"enums,omitempty" yaml:"enums,omitempty"`
+}
+
+var unorderedArrays = new(OrderedArrays)
+
+// orderedArrays returns the arrays that are compared in order by the comparison of the context (see
+// ComparisonOptions). It's never nil.
+func orderedArrays(ctx context.Context) *OrderedArrays {
+	if arrays := comparisonOptions(ctx).OrderedArrays; arrays != nil {
+		return arrays
+	}
+	return unorderedArrays
+}
+
+// any returns true if at least one array is compared in order.
+func (o *OrderedArrays) any() bool {
+	return o.Tags || o.Servers || o.Security || o.Enums
+}
+
+// areEqual is the same as low.AreEqual, unless arrays are compared in order (see ComparisonOptions). Hashes ignore
+// the order of arrays, so objects with the same hash are only equal if their YAML is in the same order too.
+func areEqual(ctx context.Context, l, r low.Hashable) bool {
+	if !low.AreEqual(l, r) {
+		return false
+	}
+	if !orderedArrays(ctx).any() {
+		return true
+	}
+	return utils.YAMLNodesEqual(nodeOf(l), nodeOf(r))
+}
+
+// entryHash returns the hash of a map entry, which also includes the order of its YAML when arrays are compared
+// in order (see areEqual).
+func entryHash(ctx context.Context, value any, node *yaml.Node) string {
+	h := low.GenerateHashString(value)
+	if h == EMPTY_STR || !orderedArrays(ctx).any() {
+		return h
+	}
+	return h + "|" + strconv.FormatUint(utils.HashYAMLNode(node), 16)
+}
+
+// nodeOf returns the YAML node that an object was built from.
+func nodeOf(v any) *yaml.Node {
+	switch n := v.(type) {
+	case low.HasRootNode:
+		return n.GetRootNode()
+	case low.HasValueNodeUntyped:
+		return n.GetValueNode()
+	}
+	return nil
+}
+
+// checkReordered records a Reordered change if the items found in both the left and right arrays (identified by
+// their keys) are not in the same order. Items that were added or removed are not part of the order, as they are
+// reported on their own. The original and new values of the change are the keys of the items, in their order.
+func checkReordered(lKeys, rKeys []string, label string, lNode, rNode *yaml.Node, breaking bool,
+	changes *[]*Change,
+) {
+	lOrder := commonOrder(lKeys, rKeys)
+	rOrder := commonOrder(rKeys, lKeys)
+	if slices.Equal(lOrder, rOrder) {
+		return
+	}
+	c := newChange(Reordered, label, lNode, rNode, breaking, nil, nil)
+	c.Original = strings.Join(lOrder, ", ")
+	c.New = strings.Join(rOrder, ", ")
+	recordChange(changes, c)
+}
+
+// valueKeys returns the keys of the values of an array, in order. Scalar values are their own keys.
+func valueKeys[T any](values []low.ValueReference[T]) []string {
+	keys := make([]string, len(values))
+	for i := range values {
+		if n, ok := any(values[i].Value).(*yaml.Node); ok && n != nil && n.Kind == yaml.ScalarNode {
+			keys[i] = n.Value
+			continue
+		}
+		keys[i] = toString(values[i].Value)
+	}
+	return keys
+}
+
+// commonOrder returns the keys that are also in the other keys, in order, without duplicates.
+func commonOrder(keys, other []string) []string {
+	in := make(map[string]struct{}, len(other))
+	for _, k := range other {
+		in[k] = struct{}{}
+	}
+	seen := make(map[string]struct{}, len(keys))
+	var order []string
+	for _, k := range keys {
+		if _, ok := in[k]; !ok {
+			continue
+		}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		order = append(order, k)
+	}
+	return order
+}
@@ -0,0 +1,170 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var orderedLeft = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: stores
+servers:
+  - url: https://eu.pets.com
+  - url: https://us.pets.com
+security:
+  - apiKey: []
+  - oauth: [read]
+paths:
+  /pets:
+    get:
+      tags: [pets, stores]
+      parameters:
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [name, age]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: string
+                enum: [cat, dog, fish]`
+
+var orderedRight = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: stores
+  - name: pets
+servers:
+  - url: https://us.pets.com
+  - url: https://eu.pets.com
+security:
+  - oauth: [read]
+  - apiKey: []
+paths:
+  /pets:
+    get:
+      tags: [stores, pets]
+      parameters:
+        - name: sort
+          in: query
+          schema:
+            type: string
+            enum: [age, name]
+      responses:
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: string
+                enum: [dog, bird, cat]`
+
+func reorderedChanges(changes *DocumentChanges) map[string][]*Change {
+	reordered := make(map[string][]*Change)
+	if changes == nil {
+		return reordered
+	}
+	for _, c := range changes.GetAllChanges() {
+		if c.ChangeType == Reordered {
+			reordered[c.Property] = append(reordered[c.Property], c)
+		}
+	}
+	return reordered
+}
+
+func TestOrderedArrays_Default(t *testing.T) {
+	// arrays are compared as sets, so only the enum values that were added and removed are reported.
+	changes := compareReportDocuments(t, orderedLeft, orderedRight)
+	require.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Empty(t, reorderedChanges(changes))
+}
+
+func TestOrderedArrays(t *testing.T) {
+	options := &ComparisonOptions{OrderedArrays: &OrderedArrays{Tags: true, Servers: true, Security: true, Enums: true}}
+	changes := compareReportDocumentsWithOptions(t, options, orderedLeft, orderedRight)
+	require.NotNil(t, changes)
+	assert.Equal(t, 8, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges()) // only the enum value that was removed.
+
+	reordered := reorderedChanges(changes)
+	require.Len(t, reordered["tags"], 2)
+	require.Len(t, reordered["servers"], 1)
+	require.Len(t, reordered["security"], 1)
+	require.Len(t, reordered["enum"], 2)
+
+	assert.Equal(t, "https://eu.pets.com, https://us.pets.com", reordered["servers"][0].Original)
+	assert.Equal(t, "https://us.pets.com, https://eu.pets.com", reordered["servers"][0].New)
+	assert.Equal(t, "reordered", ChangeTypeText(reordered["servers"][0].ChangeType))
+	assert.Equal(t, SeverityInfo, reordered["servers"][0].GetSeverity())
+	require.NotNil(t, reordered["servers"][0].Context.OriginalLine)
+	assert.Equal(t, 9, *reordered["servers"][0].Context.OriginalLine)
+
+	// items that were added or removed are not part of the order.
+	var enums []string
+	for _, c := range reordered["enum"] {
+		enums = append(enums, c.Original+" -> "+c.New)
+	}
+	assert.ElementsMatch(t, []string{"name, age -> age, name", "cat, dog -> dog, cat"}, enums)
+}
+
+func TestOrderedArrays_Selected(t *testing.T) {
+	options := &ComparisonOptions{OrderedArrays: &OrderedArrays{Servers: true}}
+	changes := compareReportDocumentsWithOptions(t, options, orderedLeft, orderedRight)
+	require.NotNil(t, changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.Len(t, reorderedChanges(changes)["servers"], 1)
+}
+
+func TestOrderedArrays_BreakingRules(t *testing.T) {
+	rules := new(BreakingRulesConfig)
+	rules.Merge(GenerateDefaultBreakingRules())
+	rules.Merge(&BreakingRulesConfig{Servers: rule(false, true, true)})
+	options := &ComparisonOptions{BreakingRules: rules, OrderedArrays: &OrderedArrays{Servers: true}}
+
+	changes := compareReportDocumentsWithOptions(t, options, orderedLeft, orderedRight)
+	require.NotNil(t, changes)
+	servers := reorderedChanges(changes)["servers"]
+	require.Len(t, servers, 1)
+	assert.True(t, servers[0].Breaking)
+}
+
+func TestOrderedArrays_SameOrder(t *testing.T) {
+	options := &ComparisonOptions{OrderedArrays: &OrderedArrays{Tags: true, Servers: true, Security: true, Enums: true}}
+	assert.Nil(t, compareReportDocumentsWithOptions(t, options, orderedLeft, orderedLeft))
+}
+
+func TestOrderedArrays_Context(t *testing.T) {
+	assert.Equal(t, &OrderedArrays{}, orderedArrays(context.Background()))
+	ctx := WithComparisonOptions(context.Background(), &ComparisonOptions{OrderedArrays: &OrderedArrays{Enums: true}})
+	assert.True(t, orderedArrays(ctx).Enums)
+	assert.False(t, orderedArrays(WithComparisonOptions(context.Background(), nil)).Enums)
+}
+
+func TestCheckReordered(t *testing.T) {
+	var changes []*Change
+	checkReordered([]string{"a", "b", "c"}, []string{"d", "a", "c", "b"}, "tags", nil, nil, false, &changes)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "a, b, c", changes[0].Original)
+	assert.Equal(t, "a, c, b", changes[0].New)
+
+	changes = nil
+	checkReordered([]string{"a", "b", "c"}, []string{"a", "d", "c"}, "tags", nil, nil, false, &changes)
+	assert.Empty(t, changes)
+}
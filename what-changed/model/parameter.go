@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -110,33 +111,28 @@ func addPropertyCheck(props *[]*PropertyCheck,
 	})
 }
 
-func addOpenAPIParameterProperties(left, right low.OpenAPIParameter, changes *[]*Change) []*PropertyCheck {
+func addOpenAPIParameterProperties(ctx context.Context, left, right low.OpenAPIParameter, changes *[]*Change) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	// style
 	addPropertyCheck(&props, left.GetStyle().ValueNode, right.GetStyle().ValueNode,
 		left.GetStyle(), right.GetStyle(), changes, v3.StyleLabel,
-		BreakingModified(CompParameter, PropStyle), CompParameter, PropStyle)
+		breakingModified(ctx, CompParameter, PropStyle), CompParameter, PropStyle)
 
 	// allow reserved
 	addPropertyCheck(&props, left.GetAllowReserved().ValueNode, right.GetAllowReserved().ValueNode,
 		left.GetAllowReserved(), right.GetAllowReserved(), changes, v3.AllowReservedLabel,
-		BreakingModified(CompParameter, PropAllowReserved), CompParameter, PropAllowReserved)
+		breakingModified(ctx, CompParameter, PropAllowReserved), CompParameter, PropAllowReserved)
 
 	// explode
 	addPropertyCheck(&props, left.GetExplode().ValueNode, right.GetExplode().ValueNode,
 		left.GetExplode(), right.GetExplode(), changes, v3.ExplodeLabel,
-		BreakingModified(CompParameter, PropExplode), CompParameter, PropExplode)
+		breakingModified(ctx, CompParameter, PropExplode), CompParameter, PropExplode)
 
 	// deprecated
 	addPropertyCheck(&props, left.GetDeprecated().ValueNode, right.GetDeprecated().ValueNode,
 		left.GetDeprecated(), right.GetDeprecated(), changes, v3.DeprecatedLabel,
-		BreakingModified(CompParameter, PropDeprecated), CompParameter, PropDeprecated)
-
-	// example
-	addPropertyCheck(&props, left.GetExample().ValueNode, right.GetExample().ValueNode,
-		left.GetExample(), right.GetExample(), changes, v3.ExampleLabel,
-		BreakingModified(CompParameter, PropExample), CompParameter, PropExample)
+		breakingModified(ctx, CompParameter, PropDeprecated), CompParameter, PropDeprecated)
 
 	return props
 }
@@ -207,44 +203,52 @@ func addSwaggerParameterProperties(left, right low.SwaggerParameter, changes *[]
 	return props
 }
 
-func addCommonParameterProperties(left, right low.SharedParameters, changes *[]*Change) []*PropertyCheck {
+func addCommonParameterProperties(ctx context.Context, left, right low.SharedParameters, changes *[]*Change) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	addPropertyCheck(&props, left.GetName().ValueNode, right.GetName().ValueNode,
 		left.GetName(), right.GetName(), changes, v3.NameLabel,
-		BreakingModified(CompParameter, PropName), CompParameter, PropName)
+		breakingModified(ctx, CompParameter, PropName), CompParameter, PropName)
 
 	// in
 	addPropertyCheck(&props, left.GetIn().ValueNode, right.GetIn().ValueNode,
 		left.GetIn(), right.GetIn(), changes, v3.InLabel,
-		BreakingModified(CompParameter, PropIn), CompParameter, PropIn)
+		breakingModified(ctx, CompParameter, PropIn), CompParameter, PropIn)
 
 	// description
 	addPropertyCheck(&props, left.GetDescription().ValueNode, right.GetDescription().ValueNode,
 		left.GetDescription(), right.GetDescription(), changes, v3.DescriptionLabel,
-		BreakingModified(CompParameter, PropDescription), CompParameter, PropDescription)
+		breakingModified(ctx, CompParameter, PropDescription), CompParameter, PropDescription)
 
 	// required
 	addPropertyCheck(&props, left.GetRequired().ValueNode, right.GetRequired().ValueNode,
 		left.GetRequired(), right.GetRequired(), changes, v3.RequiredLabel,
-		BreakingModified(CompParameter, PropRequired), CompParameter, PropRequired)
+		breakingModified(ctx, CompParameter, PropRequired), CompParameter, PropRequired)
 
 	// allow empty value
 	addPropertyCheck(&props, left.GetAllowEmptyValue().ValueNode, right.GetAllowEmptyValue().ValueNode,
 		left.GetAllowEmptyValue(), right.GetAllowEmptyValue(), changes, v3.AllowEmptyValueLabel,
-		BreakingModified(CompParameter, PropAllowEmptyValue), CompParameter, PropAllowEmptyValue)
+		breakingModified(ctx, CompParameter, PropAllowEmptyValue), CompParameter, PropAllowEmptyValue)
 
 	return props
 }
 
 // CompareParametersV3 is an OpenAPI type safe proxy for CompareParameters
 func CompareParametersV3(l, r *v3.Parameter) *ParameterChanges {
-	return CompareParameters(l, r)
+	return compareParametersV3(context.Background(), l, r)
+}
+
+func compareParametersV3(ctx context.Context, l, r *v3.Parameter) *ParameterChanges {
+	return compareParameters(ctx, l, r)
 }
 
 // CompareParameters compares a left and right Swagger or OpenAPI Parameter object for any changes. If found returns
 // a pointer to ParameterChanges. If nothing is found, returns nil.
 func CompareParameters(l, r any) *ParameterChanges {
+	return compareParameters(context.Background(), l, r)
+}
+
+func compareParameters(ctx context.Context, l, r any) *ParameterChanges {
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -259,12 +263,12 @@ func CompareParameters(l, r any) *ParameterChanges {
 		pc.Name = lParam.Name.Value
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lParam, rParam) {
+		if areEqual(ctx, lParam, rParam) {
 			return nil
 		}
 
 		props = append(props, addSwaggerParameterProperties(lParam, rParam, &changes)...)
-		props = append(props, addCommonParameterProperties(lParam, rParam, &changes)...)
+		props = append(props, addCommonParameterProperties(ctx, lParam, rParam, &changes)...)
 
 		// extract schema
 		if lParam != nil {
@@ -279,23 +283,28 @@ func CompareParameters(l, r any) *ParameterChanges {
 		// items
 		if !lParam.Items.IsEmpty() && !rParam.Items.IsEmpty() {
 			if lParam.Items.Value.Hash() != rParam.Items.Value.Hash() {
-				pc.ItemsChanges = CompareItems(lParam.Items.Value, rParam.Items.Value)
+				pc.ItemsChanges = compareItems(ctx, lParam.Items.Value, rParam.Items.Value, CompParameter)
 			}
 		}
 		if lParam.Items.IsEmpty() && !rParam.Items.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.ItemsLabel,
-				nil, rParam.Items.ValueNode, BreakingAdded(CompParameter, PropItems), nil,
+				nil, rParam.Items.ValueNode, breakingAdded(ctx, CompParameter, PropItems), nil,
 				rParam.Items.Value)
 		}
 		if !lParam.Items.IsEmpty() && rParam.Items.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.ItemsLabel,
-				lParam.Items.ValueNode, nil, BreakingRemoved(CompParameter, PropItems), lParam.Items.Value,
+				lParam.Items.ValueNode, nil, breakingRemoved(ctx, CompParameter, PropItems), lParam.Items.Value,
 				nil)
 		}
 
 		// enum
 		if len(lParam.Enum.Value) > 0 || len(rParam.Enum.Value) > 0 {
-			ExtractRawValueSliceChanges(lParam.Enum.Value, rParam.Enum.Value, &changes, v3.EnumLabel, true)
+			extractRawValueSliceChangesWithRules(ctx, lParam.Enum.Value, rParam.Enum.Value, &changes, v3.EnumLabel,
+				CompParameter, PropEnum)
+			if orderedArrays(ctx).Enums {
+				checkReordered(valueKeys(lParam.Enum.Value), valueKeys(rParam.Enum.Value), v3.EnumLabel,
+					lParam.Enum.ValueNode, rParam.Enum.ValueNode, breakingModified(ctx, CompParameter, PropEnum), &changes)
+			}
 		}
 	}
 
@@ -307,12 +316,12 @@ func CompareParameters(l, r any) *ParameterChanges {
 		pc.Name = lParam.Name.Value
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lParam, rParam) {
+		if areEqual(ctx, lParam, rParam) {
 			return nil
 		}
 
-		props = append(props, addOpenAPIParameterProperties(lParam, rParam, &changes)...)
-		props = append(props, addCommonParameterProperties(lParam, rParam, &changes)...)
+		props = append(props, addOpenAPIParameterProperties(ctx, lParam, rParam, &changes)...)
+		props = append(props, addCommonParameterProperties(ctx, lParam, rParam, &changes)...)
 		if lParam != nil {
 			lext = lParam.Extensions
 			lSchema = lParam.Schema.Value
@@ -323,44 +332,47 @@ func CompareParameters(l, r any) *ParameterChanges {
 		}
 
 		// example
-		checkParameterExample(lParam.Example, rParam.Example, changes)
+		checkParameterExample(ctx, lParam.Example, rParam.Example, &changes)
 
 		// examples
-		pc.ExamplesChanges = CheckMapForChanges(lParam.Examples.Value, rParam.Examples.Value,
-			&changes, v3.ExamplesLabel, CompareExamples)
+		pc.ExamplesChanges = checkMapForChanges(ctx, lParam.Examples.Value, rParam.Examples.Value,
+			&changes, v3.ExamplesLabel, compareExamples)
 
 		// content
-		pc.ContentChanges = CheckMapForChanges(lParam.Content.Value, rParam.Content.Value,
-			&changes, v3.ContentLabel, CompareMediaTypes)
+		pc.ContentChanges = checkMapForChanges(ctx, lParam.Content.Value, rParam.Content.Value,
+			&changes, v3.ContentLabel, compareMediaTypes)
 	}
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	if lSchema != nil && rSchema != nil {
-		pc.SchemaChanges = CompareSchemas(lSchema, rSchema)
+		pc.SchemaChanges = compareSchemas(ctx, lSchema, rSchema)
 	}
 	if lSchema != nil && rSchema == nil {
 		CreateChange(&changes, ObjectRemoved, v3.SchemaLabel,
-			lSchema.GetValueNode(), nil, BreakingRemoved(CompParameter, PropSchema), lSchema,
+			lSchema.GetValueNode(), nil, breakingRemoved(ctx, CompParameter, PropSchema), lSchema,
 			nil)
 	}
 
 	if lSchema == nil && rSchema != nil {
 		CreateChange(&changes, ObjectAdded, v3.SchemaLabel,
-			nil, rSchema.GetValueNode(), BreakingAdded(CompParameter, PropSchema), nil,
+			nil, rSchema.GetValueNode(), breakingAdded(ctx, CompParameter, PropSchema), nil,
 			rSchema)
 	}
 
-	pc.PropertyChanges = NewPropertyChanges(changes)
-	pc.ExtensionChanges = CompareExtensions(lext, rext)
+	checkComparators(l, r, &changes)
+	pc.PropertyChanges = newPropertyChanges(ctx, changes)
+	pc.ExtensionChanges = compareExtensions(ctx, lext, rext)
 	return pc
 }
 
-func checkParameterExample(expLeft, expRight low.NodeReference[*yaml.Node], changes []*Change) {
-	CheckPropertyAdditionOrRemovalWithEncoding(expLeft.ValueNode, expRight.ValueNode,
-		v3.ExampleLabel, &changes,
-		BreakingAdded(CompParameter, PropExample) || BreakingRemoved(CompParameter, PropExample),
+func checkParameterExample(ctx context.Context, expLeft, expRight low.NodeReference[*yaml.Node], changes *[]*Change) {
+	CheckForRemovalWithEncoding(expLeft.ValueNode, expRight.ValueNode,
+		v3.ExampleLabel, changes, breakingRemoved(ctx, CompParameter, PropExample),
+		expLeft.Value, expRight.Value)
+	CheckForAdditionWithEncoding(expLeft.ValueNode, expRight.ValueNode,
+		v3.ExampleLabel, changes, breakingAdded(ctx, CompParameter, PropExample),
 		expLeft.Value, expRight.Value)
-	CheckForModificationWithEncoding(expLeft.ValueNode, expRight.ValueNode,
-		v3.ExampleLabel, &changes, BreakingModified(CompParameter, PropExample),
+	CheckForExampleModification(expLeft.ValueNode, expRight.ValueNode,
+		v3.ExampleLabel, changes, breakingModified(ctx, CompParameter, PropExample),
 		expLeft.Value, expRight.Value)
 }
@@ -620,6 +620,75 @@ func TestCompareParameters_V2_EnumChange(t *testing.T) {
 	assert.Equal(t, 1, extChanges.TotalBreakingChanges())
 }
 
+func TestCompareParameters_V2_EnumChange_CustomRules(t *testing.T) {
+	low.ClearHashCache()
+	notBreaking := false
+	config := new(BreakingRulesConfig)
+	config.Merge(GenerateDefaultBreakingRules())
+	config.Merge(&BreakingRulesConfig{
+		Parameter: &ParameterRules{Enum: &BreakingChangeRule{Removed: &notBreaking}},
+	})
+	SetActiveBreakingRulesConfig(config)
+	defer ResetActiveBreakingRulesConfig()
+
+	left := `enum:
+  - one`
+	right := `enum:
+  - two`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc v2.Parameter
+	var rDoc v2.Parameter
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// removing an enum value is no longer breaking.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.Equal(t, 2, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
+
+func TestCompareParameters_V2_ItemsRemoved_CustomRules(t *testing.T) {
+	low.ClearHashCache()
+	notBreaking := false
+	config := new(BreakingRulesConfig)
+	config.Merge(GenerateDefaultBreakingRules())
+	config.Merge(&BreakingRulesConfig{
+		Parameter: &ParameterRules{Items: &BreakingChangeRule{Removed: &notBreaking}},
+	})
+	SetActiveBreakingRulesConfig(config)
+	defer ResetActiveBreakingRulesConfig()
+
+	left := `items:
+  type: array
+  items:
+    type: string`
+	right := `items:
+  type: array`
+
+	var lNode, rNode yaml.Node
+	_ = yaml.Unmarshal([]byte(left), &lNode)
+	_ = yaml.Unmarshal([]byte(right), &rNode)
+
+	var lDoc v2.Parameter
+	var rDoc v2.Parameter
+	_ = low.BuildModel(lNode.Content[0], &lDoc)
+	_ = low.BuildModel(rNode.Content[0], &rDoc)
+	_ = lDoc.Build(context.Background(), nil, lNode.Content[0], nil)
+	_ = rDoc.Build(context.Background(), nil, rNode.Content[0], nil)
+
+	// the nested items belong to the parameter, so the parameter rules apply, not the header rules.
+	extChanges := CompareParameters(&lDoc, &rDoc)
+	assert.NotNil(t, extChanges.ItemsChanges)
+	assert.Equal(t, 1, extChanges.TotalChanges())
+	assert.Equal(t, 0, extChanges.TotalBreakingChanges())
+}
+
 func TestCompareParameters_V2_EnumEqual_Reorder(t *testing.T) {
 	// Clear hash cache to ensure deterministic results in concurrent test environments
 	low.ClearHashCache()
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -175,12 +176,23 @@ type opCheck struct {
 
 // ComparePathItemsV3 is an OpenAPI typesafe proxy method for ComparePathItems
 func ComparePathItemsV3(l, r *v3.PathItem) *PathItemChanges {
-	return ComparePathItems(l, r)
+	return comparePathItemsV3(context.Background(), l, r)
+}
+
+func comparePathItemsV3(ctx context.Context, l, r *v3.PathItem) *PathItemChanges {
+	return comparePathItems(ctx, l, r)
 }
 
 // ComparePathItems compare a left and right Swagger or OpenAPI PathItem object for changes. If found, returns
 // a pointer to PathItemChanges, or returns nil if nothing is found.
 func ComparePathItems(l, r any) *PathItemChanges {
+	return comparePathItems(context.Background(), l, r)
+}
+
+func comparePathItems(ctx context.Context, l, r any) *PathItemChanges {
+	if ComparisonAborted(ctx) {
+		return nil
+	}
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -194,11 +206,11 @@ func ComparePathItems(l, r any) *PathItemChanges {
 		rPath := r.(*v2.PathItem)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lPath, rPath) {
+		if areEqual(ctx, lPath, rPath) {
 			return nil
 		}
 
-		props = append(props, compareSwaggerPathItem(lPath, rPath, &changes, pc)...)
+		props = append(props, compareSwaggerPathItem(ctx, lPath, rPath, &changes, pc)...)
 	}
 
 	// OpenAPI
@@ -209,7 +221,7 @@ func ComparePathItems(l, r any) *PathItemChanges {
 		rPath := r.(*v3.PathItem)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lPath, rPath) {
+		if areEqual(ctx, lPath, rPath) {
 			return nil
 		}
 
@@ -223,15 +235,16 @@ func ComparePathItems(l, r any) *PathItemChanges {
 			lPath.Summary.ValueNode, rPath.Summary.ValueNode,
 			v3.SummaryLabel, &changes, lPath, rPath))
 
-		compareOpenAPIPathItem(lPath, rPath, &changes, pc)
+		compareOpenAPIPathItem(ctx, lPath, rPath, &changes, pc)
 	}
 
-	CheckProperties(props)
-	pc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	pc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return pc
 }
 
-func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *PathItemChanges) []*PropertyCheck {
+func compareSwaggerPathItem(ctx context.Context, lPath, rPath *v2.PathItem, changes *[]*Change, pc *PathItemChanges) []*PropertyCheck {
 	var props []*PropertyCheck
 
 	totalOps := 0
@@ -239,99 +252,99 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 	// get
 	if !lPath.Get.IsEmpty() && !rPath.Get.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Get.Value, rPath.Get.Value, opChan, v3.GetLabel)
+		go checkOperation(ctx, lPath.Get.Value, rPath.Get.Value, opChan, v3.GetLabel)
 	}
 	if !lPath.Get.IsEmpty() && rPath.Get.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.GetLabel,
-			lPath.Get.ValueNode, nil, BreakingRemoved(CompPathItem, PropGet), lPath.Get.Value, nil)
+			lPath.Get.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropGet), lPath.Get.Value, nil)
 	}
 	if lPath.Get.IsEmpty() && !rPath.Get.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.GetLabel,
-			nil, rPath.Get.ValueNode, BreakingAdded(CompPathItem, PropGet), nil, rPath.Get.Value)
+			nil, rPath.Get.ValueNode, breakingAdded(ctx, CompPathItem, PropGet), nil, rPath.Get.Value)
 	}
 
 	// put
 	if !lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Put.Value, rPath.Put.Value, opChan, v3.PutLabel)
+		go checkOperation(ctx, lPath.Put.Value, rPath.Put.Value, opChan, v3.PutLabel)
 	}
 	if !lPath.Put.IsEmpty() && rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PutLabel,
-			lPath.Put.ValueNode, nil, BreakingRemoved(CompPathItem, PropPut), lPath.Put.Value, nil)
+			lPath.Put.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPut), lPath.Put.Value, nil)
 	}
 	if lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PutLabel,
-			nil, rPath.Put.ValueNode, BreakingAdded(CompPathItem, PropPut), nil, lPath.Put.Value)
+			nil, rPath.Put.ValueNode, breakingAdded(ctx, CompPathItem, PropPut), nil, rPath.Put.Value)
 	}
 
 	// post
 	if !lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Post.Value, rPath.Post.Value, opChan, v3.PostLabel)
+		go checkOperation(ctx, lPath.Post.Value, rPath.Post.Value, opChan, v3.PostLabel)
 	}
 	if !lPath.Post.IsEmpty() && rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PostLabel,
-			lPath.Post.ValueNode, nil, BreakingRemoved(CompPathItem, PropPost), lPath.Post.Value, nil)
+			lPath.Post.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPost), lPath.Post.Value, nil)
 	}
 	if lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PostLabel,
-			nil, rPath.Post.ValueNode, BreakingAdded(CompPathItem, PropPost), nil, lPath.Post.Value)
+			nil, rPath.Post.ValueNode, breakingAdded(ctx, CompPathItem, PropPost), nil, rPath.Post.Value)
 	}
 
 	// delete
 	if !lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Delete.Value, rPath.Delete.Value, opChan, v3.DeleteLabel)
+		go checkOperation(ctx, lPath.Delete.Value, rPath.Delete.Value, opChan, v3.DeleteLabel)
 	}
 	if !lPath.Delete.IsEmpty() && rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.DeleteLabel,
-			lPath.Delete.ValueNode, nil, BreakingRemoved(CompPathItem, PropDelete), lPath.Delete.Value, nil)
+			lPath.Delete.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropDelete), lPath.Delete.Value, nil)
 	}
 	if lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.DeleteLabel,
-			nil, rPath.Delete.ValueNode, BreakingAdded(CompPathItem, PropDelete), nil, lPath.Delete.Value)
+			nil, rPath.Delete.ValueNode, breakingAdded(ctx, CompPathItem, PropDelete), nil, rPath.Delete.Value)
 	}
 
 	// options
 	if !lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Options.Value, rPath.Options.Value, opChan, v3.OptionsLabel)
+		go checkOperation(ctx, lPath.Options.Value, rPath.Options.Value, opChan, v3.OptionsLabel)
 	}
 	if !lPath.Options.IsEmpty() && rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.OptionsLabel,
-			lPath.Options.ValueNode, nil, BreakingRemoved(CompPathItem, PropOptions), lPath.Options.Value, nil)
+			lPath.Options.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropOptions), lPath.Options.Value, nil)
 	}
 	if lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.OptionsLabel,
-			nil, rPath.Options.ValueNode, BreakingAdded(CompPathItem, PropOptions), nil, lPath.Options.Value)
+			nil, rPath.Options.ValueNode, breakingAdded(ctx, CompPathItem, PropOptions), nil, rPath.Options.Value)
 	}
 
 	// head
 	if !lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Head.Value, rPath.Head.Value, opChan, v3.HeadLabel)
+		go checkOperation(ctx, lPath.Head.Value, rPath.Head.Value, opChan, v3.HeadLabel)
 	}
 	if !lPath.Head.IsEmpty() && rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.HeadLabel,
-			lPath.Head.ValueNode, nil, BreakingRemoved(CompPathItem, PropHead), lPath.Head.Value, nil)
+			lPath.Head.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropHead), lPath.Head.Value, nil)
 	}
 	if lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.HeadLabel,
-			nil, rPath.Head.ValueNode, BreakingAdded(CompPathItem, PropHead), nil, lPath.Head.Value)
+			nil, rPath.Head.ValueNode, breakingAdded(ctx, CompPathItem, PropHead), nil, rPath.Head.Value)
 	}
 
 	// patch
 	if !lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Patch.Value, rPath.Patch.Value, opChan, v3.PatchLabel)
+		go checkOperation(ctx, lPath.Patch.Value, rPath.Patch.Value, opChan, v3.PatchLabel)
 	}
 	if !lPath.Patch.IsEmpty() && rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PatchLabel,
-			lPath.Patch.ValueNode, nil, BreakingRemoved(CompPathItem, PropPatch), lPath.Patch.Value, nil)
+			lPath.Patch.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPatch), lPath.Patch.Value, nil)
 	}
 	if lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PatchLabel,
-			nil, rPath.Patch.ValueNode, BreakingAdded(CompPathItem, PropPatch), nil, lPath.Patch.Value)
+			nil, rPath.Patch.ValueNode, breakingAdded(ctx, CompPathItem, PropPatch), nil, rPath.Patch.Value)
 	}
 
 	// parameters
@@ -339,16 +352,16 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 		lParams := lPath.Parameters.Value
 		rParams := rPath.Parameters.Value
 		lp, rp := extractV2ParametersIntoInterface(lParams, rParams)
-		checkParameters(lp, rp, changes, pc)
+		checkParameters(ctx, lp, rp, changes, pc)
 	}
 	if !lPath.Parameters.IsEmpty() && rPath.Parameters.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.ParametersLabel,
-			lPath.Parameters.ValueNode, nil, BreakingRemoved(CompPathItem, PropParameters), lPath.Parameters.Value,
+			lPath.Parameters.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropParameters), lPath.Parameters.Value,
 			nil)
 	}
 	if lPath.Parameters.IsEmpty() && !rPath.Parameters.IsEmpty() {
 		// Check configurable breaking rules first
-		breaking := BreakingAdded(CompPathItem, PropParameters)
+		breaking := breakingAdded(ctx, CompPathItem, PropParameters)
 		// If config says not breaking, fall back to semantic check (required params are breaking)
 		if !breaking {
 			for i := range rPath.Parameters.Value {
@@ -387,7 +400,7 @@ func compareSwaggerPathItem(lPath, rPath *v2.PathItem, changes *[]*Change, pc *P
 		completedOperations++
 
 	}
-	pc.ExtensionChanges = CompareExtensions(lPath.Extensions, rPath.Extensions)
+	pc.ExtensionChanges = compareExtensions(ctx, lPath.Extensions, rPath.Extensions)
 	return props
 }
 
@@ -431,7 +444,7 @@ func extractV3ParametersIntoInterface(l, r []low.ValueReference[*v3.Parameter])
 	return lp, rp
 }
 
-func checkParameters(lParams, rParams []low.ValueReference[low.SharedParameters], changes *[]*Change, pc *PathItemChanges) {
+func checkParameters(ctx context.Context, lParams, rParams []low.ValueReference[low.SharedParameters], changes *[]*Change, pc *PathItemChanges) {
 	lv := make(map[string]low.SharedParameters, len(lParams))
 	rv := make(map[string]low.SharedParameters, len(rParams))
 	lRefs := make(map[string]*low.ValueReference[low.SharedParameters], len(lParams))
@@ -451,8 +464,8 @@ func checkParameters(lParams, rParams []low.ValueReference[low.SharedParameters]
 	var paramChanges []*ParameterChanges
 	for n := range lv {
 		if _, ok := rv[n]; ok {
-			if !low.AreEqual(lv[n], rv[n]) {
-				ch := CompareParameters(lv[n], rv[n])
+			if !areEqual(ctx, lv[n], rv[n]) {
+				ch := compareParameters(ctx, lv[n], rv[n])
 				if ch != nil {
 					// Preserve reference information if this parameter is a $ref
 					PreserveParameterReference(lRefs, rRefs, n, ch)
@@ -462,7 +475,7 @@ func checkParameters(lParams, rParams []low.ValueReference[low.SharedParameters]
 			continue
 		}
 		CreateChange(changes, ObjectRemoved, v3.ParametersLabel,
-			lv[n].GetName().ValueNode, nil, true, lv[n],
+			lv[n].GetName().ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropParameters), lv[n],
 			nil)
 
 	}
@@ -476,7 +489,7 @@ func checkParameters(lParams, rParams []low.ValueReference[low.SharedParameters]
 	pc.ParameterChanges = paramChanges
 }
 
-func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *PathItemChanges) {
+func compareOpenAPIPathItem(ctx context.Context, lPath, rPath *v3.PathItem, changes *[]*Change, pc *PathItemChanges) {
 	// var props []*PropertyCheck
 
 	totalOps := 0
@@ -485,137 +498,137 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 	// get
 	if !lPath.Get.IsEmpty() && !rPath.Get.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Get.Value, rPath.Get.Value, opChan, v3.GetLabel)
+		go checkOperation(ctx, lPath.Get.Value, rPath.Get.Value, opChan, v3.GetLabel)
 	}
 	if !lPath.Get.IsEmpty() && rPath.Get.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.GetLabel,
-			lPath.Get.ValueNode, nil, BreakingRemoved(CompPathItem, PropGet), lPath.Get.Value, nil)
+			lPath.Get.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropGet), lPath.Get.Value, nil)
 	}
 	if lPath.Get.IsEmpty() && !rPath.Get.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.GetLabel,
-			nil, rPath.Get.ValueNode, BreakingAdded(CompPathItem, PropGet), nil, lPath.Get.Value)
+			nil, rPath.Get.ValueNode, breakingAdded(ctx, CompPathItem, PropGet), nil, rPath.Get.Value)
 	}
 
 	// put
 	if !lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Put.Value, rPath.Put.Value, opChan, v3.PutLabel)
+		go checkOperation(ctx, lPath.Put.Value, rPath.Put.Value, opChan, v3.PutLabel)
 	}
 	if !lPath.Put.IsEmpty() && rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PutLabel,
-			lPath.Put.ValueNode, nil, BreakingRemoved(CompPathItem, PropPut), lPath.Put.Value, nil)
+			lPath.Put.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPut), lPath.Put.Value, nil)
 	}
 	if lPath.Put.IsEmpty() && !rPath.Put.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PutLabel,
-			nil, rPath.Put.ValueNode, BreakingAdded(CompPathItem, PropPut), nil, lPath.Put.Value)
+			nil, rPath.Put.ValueNode, breakingAdded(ctx, CompPathItem, PropPut), nil, rPath.Put.Value)
 	}
 
 	// post
 	if !lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Post.Value, rPath.Post.Value, opChan, v3.PostLabel)
+		go checkOperation(ctx, lPath.Post.Value, rPath.Post.Value, opChan, v3.PostLabel)
 	}
 	if !lPath.Post.IsEmpty() && rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PostLabel,
-			lPath.Post.ValueNode, nil, BreakingRemoved(CompPathItem, PropPost), lPath.Post.Value, nil)
+			lPath.Post.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPost), lPath.Post.Value, nil)
 	}
 	if lPath.Post.IsEmpty() && !rPath.Post.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PostLabel,
-			nil, rPath.Post.ValueNode, BreakingAdded(CompPathItem, PropPost), nil, lPath.Post.Value)
+			nil, rPath.Post.ValueNode, breakingAdded(ctx, CompPathItem, PropPost), nil, rPath.Post.Value)
 	}
 
 	// delete
 	if !lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Delete.Value, rPath.Delete.Value, opChan, v3.DeleteLabel)
+		go checkOperation(ctx, lPath.Delete.Value, rPath.Delete.Value, opChan, v3.DeleteLabel)
 	}
 	if !lPath.Delete.IsEmpty() && rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.DeleteLabel,
-			lPath.Delete.ValueNode, nil, BreakingRemoved(CompPathItem, PropDelete), lPath.Delete.Value, nil)
+			lPath.Delete.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropDelete), lPath.Delete.Value, nil)
 	}
 	if lPath.Delete.IsEmpty() && !rPath.Delete.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.DeleteLabel,
-			nil, rPath.Delete.ValueNode, BreakingAdded(CompPathItem, PropDelete), nil, lPath.Delete.Value)
+			nil, rPath.Delete.ValueNode, breakingAdded(ctx, CompPathItem, PropDelete), nil, rPath.Delete.Value)
 	}
 
 	// options
 	if !lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Options.Value, rPath.Options.Value, opChan, v3.OptionsLabel)
+		go checkOperation(ctx, lPath.Options.Value, rPath.Options.Value, opChan, v3.OptionsLabel)
 	}
 	if !lPath.Options.IsEmpty() && rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.OptionsLabel,
-			lPath.Options.ValueNode, nil, BreakingRemoved(CompPathItem, PropOptions), lPath.Options.Value, nil)
+			lPath.Options.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropOptions), lPath.Options.Value, nil)
 	}
 	if lPath.Options.IsEmpty() && !rPath.Options.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.OptionsLabel,
-			nil, rPath.Options.ValueNode, BreakingAdded(CompPathItem, PropOptions), nil, lPath.Options.Value)
+			nil, rPath.Options.ValueNode, breakingAdded(ctx, CompPathItem, PropOptions), nil, rPath.Options.Value)
 	}
 
 	// head
 	if !lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Head.Value, rPath.Head.Value, opChan, v3.HeadLabel)
+		go checkOperation(ctx, lPath.Head.Value, rPath.Head.Value, opChan, v3.HeadLabel)
 	}
 	if !lPath.Head.IsEmpty() && rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.HeadLabel,
-			lPath.Head.ValueNode, nil, BreakingRemoved(CompPathItem, PropHead), lPath.Head.Value, nil)
+			lPath.Head.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropHead), lPath.Head.Value, nil)
 	}
 	if lPath.Head.IsEmpty() && !rPath.Head.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.HeadLabel,
-			nil, rPath.Head.ValueNode, BreakingAdded(CompPathItem, PropHead), nil, lPath.Head.Value)
+			nil, rPath.Head.ValueNode, breakingAdded(ctx, CompPathItem, PropHead), nil, rPath.Head.Value)
 	}
 
 	// patch
 	if !lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Patch.Value, rPath.Patch.Value, opChan, v3.PatchLabel)
+		go checkOperation(ctx, lPath.Patch.Value, rPath.Patch.Value, opChan, v3.PatchLabel)
 	}
 	if !lPath.Patch.IsEmpty() && rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.PatchLabel,
-			lPath.Patch.ValueNode, nil, BreakingRemoved(CompPathItem, PropPatch), lPath.Patch.Value, nil)
+			lPath.Patch.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropPatch), lPath.Patch.Value, nil)
 	}
 	if lPath.Patch.IsEmpty() && !rPath.Patch.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.PatchLabel,
-			nil, rPath.Patch.ValueNode, BreakingAdded(CompPathItem, PropPatch), nil, lPath.Patch.Value)
+			nil, rPath.Patch.ValueNode, breakingAdded(ctx, CompPathItem, PropPatch), nil, rPath.Patch.Value)
 	}
 
 	// trace
 	if !lPath.Trace.IsEmpty() && !rPath.Trace.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Trace.Value, rPath.Trace.Value, opChan, v3.TraceLabel)
+		go checkOperation(ctx, lPath.Trace.Value, rPath.Trace.Value, opChan, v3.TraceLabel)
 	}
 	if !lPath.Trace.IsEmpty() && rPath.Trace.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.TraceLabel,
-			lPath.Trace.ValueNode, nil, BreakingRemoved(CompPathItem, PropTrace), lPath.Trace.Value, nil)
+			lPath.Trace.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropTrace), lPath.Trace.Value, nil)
 	}
 	if lPath.Trace.IsEmpty() && !rPath.Trace.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.TraceLabel,
-			nil, rPath.Trace.ValueNode, BreakingAdded(CompPathItem, PropTrace), nil, lPath.Trace.Value)
+			nil, rPath.Trace.ValueNode, breakingAdded(ctx, CompPathItem, PropTrace), nil, rPath.Trace.Value)
 	}
 
 	// query
 	if !lPath.Query.IsEmpty() && !rPath.Query.IsEmpty() {
 		totalOps++
-		go checkOperation(lPath.Query.Value, rPath.Query.Value, opChan, v3.QueryLabel)
+		go checkOperation(ctx, lPath.Query.Value, rPath.Query.Value, opChan, v3.QueryLabel)
 	}
 	if !lPath.Query.IsEmpty() && rPath.Query.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.QueryLabel,
-			lPath.Query.ValueNode, nil, BreakingRemoved(CompPathItem, PropQuery), lPath.Query.Value, nil)
+			lPath.Query.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropQuery), lPath.Query.Value, nil)
 	}
 	if lPath.Query.IsEmpty() && !rPath.Query.IsEmpty() {
 		CreateChange(changes, PropertyAdded, v3.QueryLabel,
-			nil, rPath.Query.ValueNode, BreakingAdded(CompPathItem, PropQuery), nil, rPath.Query.Value)
+			nil, rPath.Query.ValueNode, breakingAdded(ctx, CompPathItem, PropQuery), nil, rPath.Query.Value)
 	}
 
 	// additionalOperations (OpenAPI 3.2+)
 	if lPath.AdditionalOperations.Value != nil && rPath.AdditionalOperations.Value == nil {
 		CreateChange(changes, PropertyRemoved, v3.AdditionalOperationsLabel,
-			lPath.AdditionalOperations.ValueNode, nil, BreakingRemoved(CompPathItem, PropAdditionalOperations), lPath.AdditionalOperations.Value, nil)
+			lPath.AdditionalOperations.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropAdditionalOperations), lPath.AdditionalOperations.Value, nil)
 	}
 	if lPath.AdditionalOperations.Value == nil && rPath.AdditionalOperations.Value != nil {
 		CreateChange(changes, PropertyAdded, v3.AdditionalOperationsLabel,
-			nil, rPath.AdditionalOperations.ValueNode, BreakingAdded(CompPathItem, PropAdditionalOperations), nil, rPath.AdditionalOperations.Value)
+			nil, rPath.AdditionalOperations.ValueNode, breakingAdded(ctx, CompPathItem, PropAdditionalOperations), nil, rPath.AdditionalOperations.Value)
 	}
 	if lPath.AdditionalOperations.Value != nil && rPath.AdditionalOperations.Value != nil {
 
@@ -636,7 +649,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 					found = true
 					// compare the two operations
 					totalOps++
-					go checkOperation(lPath.AdditionalOperations.Value.GetOrZero(lKeys[j]).Value,
+					go checkOperation(ctx, lPath.AdditionalOperations.Value.GetOrZero(lKeys[j]).Value,
 						rPath.AdditionalOperations.Value.GetOrZero(rKeys[j]).Value, opChan, lKeys[i].Value)
 					break
 				}
@@ -644,7 +657,7 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 			// not found, was removed
 			if !found {
 				CreateChange(changes, PropertyRemoved, v3.AdditionalOperationsLabel,
-					lPath.AdditionalOperations.Value.GetOrZero(lKeys[i]).ValueNode, nil, BreakingRemoved(CompPathItem, PropAdditionalOperations),
+					lPath.AdditionalOperations.Value.GetOrZero(lKeys[i]).ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropAdditionalOperations),
 					lPath.AdditionalOperations.Value.GetOrZero(lKeys[i]).Value, nil)
 			}
 		}
@@ -662,31 +675,31 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 			// not found, was added
 			if !found {
 				CreateChange(changes, PropertyAdded, v3.AdditionalOperationsLabel,
-					nil, rPath.AdditionalOperations.Value.GetOrZero(rKeys[i]).ValueNode, BreakingAdded(CompPathItem, PropAdditionalOperations),
+					nil, rPath.AdditionalOperations.Value.GetOrZero(rKeys[i]).ValueNode, breakingAdded(ctx, CompPathItem, PropAdditionalOperations),
 					nil, rPath.AdditionalOperations.Value.GetOrZero(rKeys[i]).Value)
 			}
 		}
 	}
 
 	// servers
-	pc.ServerChanges = checkServers(lPath.Servers, rPath.Servers, CompPathItem, PropServers)
+	pc.ServerChanges = checkServers(ctx, lPath.Servers, rPath.Servers, CompPathItem, PropServers)
 
 	// parameters
 	if !lPath.Parameters.IsEmpty() && !rPath.Parameters.IsEmpty() {
 		lParams := lPath.Parameters.Value
 		rParams := rPath.Parameters.Value
 		lp, rp := extractV3ParametersIntoInterface(lParams, rParams)
-		checkParameters(lp, rp, changes, pc)
+		checkParameters(ctx, lp, rp, changes, pc)
 	}
 
 	if !lPath.Parameters.IsEmpty() && rPath.Parameters.IsEmpty() {
 		CreateChange(changes, PropertyRemoved, v3.ParametersLabel,
-			lPath.Parameters.ValueNode, nil, BreakingRemoved(CompPathItem, PropParameters), lPath.Parameters.Value,
+			lPath.Parameters.ValueNode, nil, breakingRemoved(ctx, CompPathItem, PropParameters), lPath.Parameters.Value,
 			nil)
 	}
 	if lPath.Parameters.IsEmpty() && !rPath.Parameters.IsEmpty() {
 		// Check configurable breaking rules first
-		breaking := BreakingAdded(CompPathItem, PropParameters)
+		breaking := breakingAdded(ctx, CompPathItem, PropParameters)
 		// If config says not breaking, fall back to semantic check (required params are breaking)
 		if !breaking {
 			for i := range rPath.Parameters.Value {
@@ -735,12 +748,12 @@ func compareOpenAPIPathItem(lPath, rPath *v3.PathItem, changes *[]*Change, pc *P
 		}
 		completedOperations++
 	}
-	pc.ExtensionChanges = CompareExtensions(lPath.Extensions, rPath.Extensions)
+	pc.ExtensionChanges = compareExtensions(ctx, lPath.Extensions, rPath.Extensions)
 }
 
-func checkOperation(l, r any, done chan opCheck, method string) {
+func checkOperation(ctx context.Context, l, r any, done chan opCheck, method string) {
 	done <- opCheck{
 		label:   method,
-		changes: CompareOperations(l, r),
+		changes: compareOperations(ctx, l, r),
 	}
 }
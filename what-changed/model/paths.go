@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 	"sync"
 
@@ -70,10 +71,13 @@ func (p *PathsChanges) TotalBreakingChanges() int {
 // ComparePaths compares a left and right Swagger or OpenAPI Paths Object for changes. If found, returns a pointer
 // to a PathsChanges instance. Returns nil if nothing is found.
 func ComparePaths(l, r any) *PathsChanges {
+	return comparePaths(context.Background(), l, r)
+}
+
+func comparePaths(ctx context.Context, l, r any) *PathsChanges {
 	var changes []*Change
 
 	pc := new(PathsChanges)
-	pathChanges := make(map[string]*PathItemChanges)
 
 	// Swagger
 	if reflect.TypeOf(&v2.Paths{}) == reflect.TypeOf(l) &&
@@ -83,65 +87,16 @@ func ComparePaths(l, r any) *PathsChanges {
 		rPath := r.(*v2.Paths)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lPath, rPath) {
+		if areEqual(ctx, lPath, rPath) {
 			return nil
 		}
 
-		lKeys := make(map[string]low.ValueReference[*v2.PathItem])
-		rKeys := make(map[string]low.ValueReference[*v2.PathItem])
-		for k, v := range lPath.PathItems.FromOldest() {
-			lKeys[k.Value] = v
-		}
-		for k, v := range rPath.PathItems.FromOldest() {
-			rKeys[k.Value] = v
-		}
-
-		// run every comparison in a thread.
-		var mLock sync.Mutex
-		compare := func(path string, _ map[string]*PathItemChanges, l, r *v2.PathItem, doneChan chan struct{}) {
-			if !low.AreEqual(l, r) {
-				mLock.Lock()
-				pathChanges[path] = ComparePathItems(l, r)
-				mLock.Unlock()
-			}
-			doneChan <- struct{}{}
-		}
-
-		doneChan := make(chan struct{})
-		pathsChecked := 0
-
-		for k := range lKeys {
-			if _, ok := rKeys[k]; ok {
-				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
-				pathsChecked++
-				continue
-			}
-			g, p := lPath.FindPathAndKey(k)
-			CreateChange(&changes, ObjectRemoved, k,
-				g.KeyNode, nil, BreakingRemoved(CompPaths, PropPath),
-				p.Value, nil)
-		}
-
-		for k := range rKeys {
-			if _, ok := lKeys[k]; !ok {
-				g, p := rPath.FindPathAndKey(k)
-				CreateChange(&changes, ObjectAdded, k,
-					nil, g.KeyNode, BreakingAdded(CompPaths, PropPath),
-					nil, p.Value)
-			}
-		}
-
-		// wait for the things to be done.
-		completedChecks := 0
-		for completedChecks < pathsChecked {
-			<-doneChan
-			completedChecks++
-		}
+		pathChanges := checkPathItems(ctx, lPath.PathItems, rPath.PathItems, &changes)
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
 
-		pc.ExtensionChanges = CompareExtensions(lPath.Extensions, rPath.Extensions)
+		pc.ExtensionChanges = compareExtensions(ctx, lPath.Extensions, rPath.Extensions)
 	}
 
 	// OpenAPI
@@ -152,64 +107,18 @@ func ComparePaths(l, r any) *PathsChanges {
 		rPath := r.(*v3.Paths)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lPath, rPath) {
+		if areEqual(ctx, lPath, rPath) {
 			return nil
 		}
 
-		lKeys := make(map[string]low.ValueReference[*v3.PathItem])
-		rKeys := make(map[string]low.ValueReference[*v3.PathItem])
-
-		if lPath != nil && lPath.PathItems != nil {
-			for k, v := range lPath.PathItems.FromOldest() {
-				lKeys[k.Value] = v
-			}
-		}
-		if rPath != nil && rPath.PathItems != nil {
-			for k, v := range rPath.PathItems.FromOldest() {
-				rKeys[k.Value] = v
-			}
-		}
-
-		// run every comparison in a thread.
-		var mLock sync.Mutex
-		compare := func(path string, _ map[string]*PathItemChanges, l, r *v3.PathItem, doneChan chan struct{}) {
-			if !low.AreEqual(l, r) {
-				mLock.Lock()
-				pathChanges[path] = ComparePathItems(l, r)
-				mLock.Unlock()
-			}
-			doneChan <- struct{}{}
-		}
-
-		doneChan := make(chan struct{})
-		pathsChecked := 0
-
-		for k := range lKeys {
-			if _, ok := rKeys[k]; ok {
-				go compare(k, pathChanges, lKeys[k].Value, rKeys[k].Value, doneChan)
-				pathsChecked++
-				continue
-			}
-			g, p := lPath.FindPathAndKey(k)
-			CreateChange(&changes, ObjectRemoved, k,
-				g.KeyNode, nil, BreakingRemoved(CompPaths, PropPath),
-				p.Value, nil)
-		}
-
-		for k := range rKeys {
-			if _, ok := lKeys[k]; !ok {
-				g, p := rPath.FindPathAndKey(k)
-				CreateChange(&changes, ObjectAdded, k,
-					nil, g.KeyNode, BreakingAdded(CompPaths, PropPath),
-					nil, p.Value)
-			}
+		var lItems, rItems *orderedmap.Map[low.KeyReference[string], low.ValueReference[*v3.PathItem]]
+		if lPath != nil {
+			lItems = lPath.PathItems
 		}
-		// wait for the things to be done.
-		completedChecks := 0
-		for completedChecks < pathsChecked {
-			<-doneChan
-			completedChecks++
+		if rPath != nil {
+			rItems = rPath.PathItems
 		}
+		pathChanges := checkPathItems(ctx, lItems, rItems, &changes)
 		if len(pathChanges) > 0 {
 			pc.PathItemsChanges = pathChanges
 		}
@@ -222,8 +131,58 @@ func ComparePaths(l, r any) *PathsChanges {
 			rExt = rPath.Extensions
 		}
 
-		pc.ExtensionChanges = CompareExtensions(lExt, rExt)
+		pc.ExtensionChanges = compareExtensions(ctx, lExt, rExt)
 	}
-	pc.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	pc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return pc
 }
+
+// checkPathItems compares the path items of two Paths objects, running up to the comparison concurrency (see
+// ComparisonOptions) at the same time. Paths that were removed are reported in the order they appear on the
+// left, followed by the paths that were added, in the order they appear on the right.
+func checkPathItems[T low.Hashable](ctx context.Context, lItems, rItems *orderedmap.Map[low.KeyReference[string], low.ValueReference[T]],
+	changes *[]*Change,
+) map[string]*PathItemChanges {
+	lKeys := make(map[string]*yaml.Node)
+	rKeys := make(map[string]*yaml.Node)
+	lValues := make(map[string]T)
+	rValues := make(map[string]T)
+	for k, v := range lItems.FromOldest() {
+		lKeys[k.Value], lValues[k.Value] = k.KeyNode, v.Value
+	}
+	for k, v := range rItems.FromOldest() {
+		rKeys[k.Value], rValues[k.Value] = k.KeyNode, v.Value
+	}
+
+	var common []string
+	for k := range lItems.KeysFromOldest() {
+		if _, ok := rKeys[k.Value]; ok {
+			common = append(common, k.Value)
+			continue
+		}
+		CreateChange(changes, ObjectRemoved, k.Value,
+			lKeys[k.Value], nil, breakingRemoved(ctx, CompPaths, PropPath),
+			lValues[k.Value], nil)
+	}
+	for k := range rItems.KeysFromOldest() {
+		if _, ok := lKeys[k.Value]; !ok {
+			CreateChange(changes, ObjectAdded, k.Value,
+				nil, rKeys[k.Value], breakingAdded(ctx, CompPaths, PropPath),
+				nil, rValues[k.Value])
+		}
+	}
+
+	var lock sync.Mutex
+	pathChanges := make(map[string]*PathItemChanges)
+	compareEach(ctx, common, func(path string) {
+		if areEqual(ctx, lValues[path], rValues[path]) {
+			return
+		}
+		changed := comparePathItems(ctx, lValues[path], rValues[path])
+		lock.Lock()
+		pathChanges[path] = changed
+		lock.Unlock()
+	})
+	return pathChanges
+}
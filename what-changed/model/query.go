@@ -0,0 +1,156 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ChangeQuery selects changes from any tree of changes (see QueryChanges). Every criteria that is set must match
+// for a change to be selected, and criteria that are not set match every change. Criteria that hold many values
+// match any of them.
+type ChangeQuery struct {
+	// ObjectTypes are the types of objects the changes were made to, for example CompSchema, CompParameter or
+	// CompOperation (see QueryResult.ObjectType).
+	ObjectTypes []string
+
+	// Properties are the labels of the properties that were changed, for example v3.TypeLabel or v3.RequiredLabel.
+	Properties []string
+
+	// ChangeTypes are the types of changes, for example Modified or ObjectRemoved.
+	ChangeTypes []int
+
+	// Breaking selects breaking changes when true, and changes that are not breaking when false.
+	Breaking *bool
+
+	// MinSeverity selects changes with this severity, or a higher one (see Change.GetSeverity).
+	MinSeverity Severity
+
+	// PointerPrefix selects changes made at, or below, a JSON Pointer into the tree of changes (see ChangePointer),
+	// for example "/paths/pathItems/~1pets" for every change made to the /pets path. Prefixes match whole segments,
+	// so "/paths/pathItems/~1pets" does not select changes made to /pets/{id}.
+	PointerPrefix string
+
+	// Filter is called with every change that matches everything else. If it returns false, the change is not
+	// selected.
+	Filter ChangeFilter
+}
+
+// QueryResult is a change selected by a ChangeQuery, with where it was made.
+type QueryResult struct {
+	Change *Change
+
+	// Location is the location of the object the change was made to (see LocateChanges).
+	Location []string
+
+	// Path is the JSON path of the location (see ChangePath).
+	Path string
+
+	// Pointer is the JSON Pointer of the location (see ChangePointer).
+	Pointer string
+
+	// ObjectType is the type of object the change was made to, for example CompSchema ('schema') or
+	// CompPathItem ('pathItem'). It's derived from the change model holding the change.
+	ObjectType string
+}
+
+// QueryChanges walks any tree of changes and returns the changes selected by the query as a flat list, in the
+// order of the tree (see LocateChanges). A nil query selects every change. For example, every breaking change made
+// to the schemas of the /pets path:
+//
+//	breaking := true
+//	results := model.QueryChanges(changes, &model.ChangeQuery{
+//		ObjectTypes:   []string{model.CompSchema},
+//		Breaking:      &breaking,
+//		PointerPrefix: "/paths/pathItems/~1pets",
+//	})
+func QueryChanges(changes any, query *ChangeQuery) []*QueryResult {
+	var results []*QueryResult
+	for _, lc := range LocateChanges(changes) {
+		r := &QueryResult{
+			Change:     lc.Change,
+			Location:   lc.Location,
+			Path:       ChangePath(lc.Location),
+			Pointer:    ChangePointer(lc.Location),
+			ObjectType: objectType(lc.owner),
+		}
+		if query.matches(r) {
+			results = append(results, r)
+		}
+	}
+	return results
+}
+
+// Changes returns the changes selected by the query, in the same order (see QueryChanges).
+func (q *ChangeQuery) Changes(changes any) []*Change {
+	var selected []*Change
+	for _, r := range QueryChanges(changes, q) {
+		selected = append(selected, r.Change)
+	}
+	return selected
+}
+
+func (q *ChangeQuery) matches(r *QueryResult) bool {
+	if q == nil {
+		return true
+	}
+	c := r.Change
+	if len(q.ObjectTypes) > 0 && !slices.Contains(q.ObjectTypes, r.ObjectType) {
+		return false
+	}
+	if len(q.Properties) > 0 && !slices.Contains(q.Properties, c.Property) {
+		return false
+	}
+	if len(q.ChangeTypes) > 0 && !slices.Contains(q.ChangeTypes, c.ChangeType) {
+		return false
+	}
+	if q.Breaking != nil && c.Breaking != *q.Breaking {
+		return false
+	}
+	if q.MinSeverity != 0 && c.GetSeverity() < q.MinSeverity {
+		return false
+	}
+	if !pointerHasPrefix(r.Pointer, q.PointerPrefix) {
+		return false
+	}
+	return q.Filter == nil || q.Filter(c)
+}
+
+// ChangePointer returns the JSON Pointer (RFC 6901) of a location in a tree of changes, for example
+// "/paths/pathItems/~1pets/get". The root of the tree is an empty pointer.
+func ChangePointer(location []string) string {
+	var b strings.Builder
+	for _, segment := range location {
+		b.WriteString("/")
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(segment, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
+
+// pointerHasPrefix returns true if the pointer is the prefix, or is below it.
+func pointerHasPrefix(pointer, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return prefix == "" || pointer == prefix || strings.HasPrefix(pointer, prefix+"/")
+}
+
+// objectType returns the type of object a change model holds the changes of, which is the component of its
+// breaking rules when it has some, for example 'schema' for SchemaChanges, or its name otherwise.
+func objectType(owner reflect.Type) string {
+	if component := ruleComponent(owner); component != "" {
+		return component
+	}
+	if owner == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(owner.Name(), "Changes")
+	if name == "" {
+		return ""
+	}
+	r, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToLower(r)) + name[size:]
+}
@@ -0,0 +1,66 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"testing"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryChanges(t *testing.T) {
+	changes := compareReportDocuments(t, endpointsLeft, endpointsRight)
+	require.NotNil(t, changes)
+
+	all := QueryChanges(changes, nil)
+	require.Len(t, all, changes.TotalChanges())
+	assert.Equal(t, "/paths", all[0].Pointer)
+	assert.Equal(t, "$.paths", all[0].Path)
+	assert.Equal(t, CompPaths, all[0].ObjectType)
+
+	breaking := true
+	results := QueryChanges(changes, &ChangeQuery{Breaking: &breaking})
+	require.Len(t, results, 3)
+	for _, r := range results {
+		assert.True(t, r.Change.Breaking)
+	}
+
+	notBreaking := false
+	assert.Len(t, QueryChanges(changes, &ChangeQuery{Breaking: &notBreaking}), 4)
+
+	results = QueryChanges(changes, &ChangeQuery{ObjectTypes: []string{CompSchema}})
+	require.Len(t, results, 1)
+	assert.Equal(t, v3.TypeLabel, results[0].Change.Property)
+	assert.Equal(t, "/paths/pathItems/~1pets/get/parameters/limit/schemas", results[0].Pointer)
+	assert.Equal(t, []string{"paths", "pathItems", "/pets", "get", "parameters", "limit", "schemas"},
+		results[0].Location)
+
+	results = QueryChanges(changes, &ChangeQuery{Properties: []string{v3.RequiredLabel, v3.TypeLabel}})
+	assert.Len(t, results, 2)
+
+	results = QueryChanges(changes, &ChangeQuery{ChangeTypes: []int{ObjectRemoved, PropertyRemoved}})
+	assert.Len(t, results, 2)
+
+	assert.Len(t, QueryChanges(changes, &ChangeQuery{MinSeverity: SeverityCritical}), 2)
+
+	results = QueryChanges(changes, &ChangeQuery{
+		PointerPrefix: "/paths/pathItems/~1pets/",
+		ObjectTypes:   []string{CompPathItem},
+		Filter:        OnlyBreaking(),
+	})
+	require.Len(t, results, 1)
+	assert.Equal(t, v3.DeleteLabel, results[0].Change.Property)
+
+	assert.Empty(t, QueryChanges(changes, &ChangeQuery{PointerPrefix: "/paths/pathItems/~1pe"}))
+	assert.Len(t, (&ChangeQuery{PointerPrefix: "/paths/pathItems/~1pets/get"}).Changes(changes), 2)
+	assert.Empty(t, QueryChanges(nil, nil))
+}
+
+func TestChangePointer(t *testing.T) {
+	assert.Equal(t, "", ChangePointer(nil))
+	assert.Equal(t, "/paths/pathItems/~1pets~1{id}/get", ChangePointer([]string{"paths", "pathItems", "/pets/{id}", "get"}))
+	assert.Equal(t, "/components/schemas/a~0b", ChangePointer([]string{"components", "schemas", "a~b"}))
+}
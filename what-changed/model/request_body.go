@@ -4,7 +4,8 @@
 package model
 
 import (
-	"github.com/pb33f/libopenapi/datamodel/low"
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
 
@@ -58,7 +59,11 @@ func (rb *RequestBodyChanges) TotalBreakingChanges() int {
 // CompareRequestBodies compares a left and right OpenAPI RequestBody object for changes. If found returns a pointer
 // to a RequestBodyChanges instance. Returns nil if nothing was found.
 func CompareRequestBodies(l, r *v3.RequestBody) *RequestBodyChanges {
-	if low.AreEqual(l, r) {
+	return compareRequestBodies(context.Background(), l, r)
+}
+
+func compareRequestBodies(ctx context.Context, l, r *v3.RequestBody) *RequestBodyChanges {
+	if areEqual(ctx, l, r) {
 		return nil
 	}
 
@@ -74,12 +79,13 @@ func CompareRequestBodies(l, r *v3.RequestBody) *RequestBodyChanges {
 			v3.RequiredLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	rbc := new(RequestBodyChanges)
-	rbc.ContentChanges = CheckMapForChanges(l.Content.Value, r.Content.Value,
-		&changes, v3.ContentLabel, CompareMediaTypes)
-	rbc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
-	rbc.PropertyChanges = NewPropertyChanges(changes)
+	rbc.ContentChanges = checkMapForChanges(ctx, l.Content.Value, r.Content.Value,
+		&changes, v3.ContentLabel, compareMediaTypes)
+	rbc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
+	checkComparators(l, r, &changes)
+	rbc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return rbc
 }
@@ -4,9 +4,9 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
-	"github.com/pb33f/libopenapi/datamodel/low"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
 
@@ -105,17 +105,29 @@ func (r *ResponseChanges) TotalBreakingChanges() int {
 
 // CompareResponseV2 is a Swagger type safe proxy for CompareResponse
 func CompareResponseV2(l, r *v2.Response) *ResponseChanges {
-	return CompareResponse(l, r)
+	return compareResponseV2(context.Background(), l, r)
+}
+
+func compareResponseV2(ctx context.Context, l, r *v2.Response) *ResponseChanges {
+	return compareResponse(ctx, l, r)
 }
 
 // CompareResponseV3 is an OpenAPI type safe proxy for CompareResponse
 func CompareResponseV3(l, r *v3.Response) *ResponseChanges {
-	return CompareResponse(l, r)
+	return compareResponseV3(context.Background(), l, r)
+}
+
+func compareResponseV3(ctx context.Context, l, r *v3.Response) *ResponseChanges {
+	return compareResponse(ctx, l, r)
 }
 
 // CompareResponse compares a left and right Swagger or OpenAPI Response object. If anything is found
 // a pointer to a ResponseChanges is returned, otherwise it returns nil.
 func CompareResponse(l, r any) *ResponseChanges {
+	return compareResponse(context.Background(), l, r)
+}
+
+func compareResponse(ctx context.Context, l, r any) *ResponseChanges {
 	var changes []*Change
 	var props []*PropertyCheck
 
@@ -127,7 +139,7 @@ func CompareResponse(l, r any) *ResponseChanges {
 		rResponse := r.(*v2.Response)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lResponse, rResponse) {
+		if areEqual(ctx, lResponse, rResponse) {
 			return nil
 		}
 
@@ -136,37 +148,37 @@ func CompareResponse(l, r any) *ResponseChanges {
 			lResponse.Description.Value, rResponse.Description.Value, &changes, v3.DescriptionLabel, false, CompResponse, PropDescription)
 
 		if !lResponse.Schema.IsEmpty() && !rResponse.Schema.IsEmpty() {
-			rc.SchemaChanges = CompareSchemas(lResponse.Schema.Value, rResponse.Schema.Value)
+			rc.SchemaChanges = compareSchemas(ctx, lResponse.Schema.Value, rResponse.Schema.Value)
 		}
 		if !lResponse.Schema.IsEmpty() && rResponse.Schema.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.SchemaLabel,
-				lResponse.Schema.ValueNode, nil, BreakingRemoved(CompResponse, PropSchema),
+				lResponse.Schema.ValueNode, nil, breakingRemoved(ctx, CompResponse, PropSchema),
 				lResponse.Schema.Value, nil)
 		}
 		if lResponse.Schema.IsEmpty() && !rResponse.Schema.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.SchemaLabel,
-				nil, rResponse.Schema.ValueNode, BreakingAdded(CompResponse, PropSchema),
+				nil, rResponse.Schema.ValueNode, breakingAdded(ctx, CompResponse, PropSchema),
 				nil, rResponse.Schema.Value)
 		}
 
-		rc.HeadersChanges = CheckMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
-			&changes, v3.HeadersLabel, CompareHeadersV2)
+		rc.HeadersChanges = checkMapForChanges(ctx, lResponse.Headers.Value, rResponse.Headers.Value,
+			&changes, v3.HeadersLabel, compareHeadersV2)
 
 		if !lResponse.Examples.IsEmpty() && !rResponse.Examples.IsEmpty() {
-			rc.ExamplesChanges = CompareExamplesV2(lResponse.Examples.Value, rResponse.Examples.Value)
+			rc.ExamplesChanges = compareExamplesV2(ctx, lResponse.Examples.Value, rResponse.Examples.Value)
 		}
 		if !lResponse.Examples.IsEmpty() && rResponse.Examples.IsEmpty() {
 			CreateChange(&changes, PropertyRemoved, v3.ExamplesLabel,
-				lResponse.Schema.ValueNode, nil, BreakingRemoved(CompResponse, PropExamples),
+				lResponse.Schema.ValueNode, nil, breakingRemoved(ctx, CompResponse, PropExamples),
 				lResponse.Schema.Value, nil)
 		}
 		if lResponse.Examples.IsEmpty() && !rResponse.Examples.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.ExamplesLabel,
-				nil, rResponse.Schema.ValueNode, BreakingAdded(CompResponse, PropExamples),
+				nil, rResponse.Schema.ValueNode, breakingAdded(ctx, CompResponse, PropExamples),
 				nil, lResponse.Schema.Value)
 		}
 
-		rc.ExtensionChanges = CompareExtensions(lResponse.Extensions, rResponse.Extensions)
+		rc.ExtensionChanges = compareExtensions(ctx, lResponse.Extensions, rResponse.Extensions)
 	}
 
 	if reflect.TypeOf(&v3.Response{}) == reflect.TypeOf(l) && reflect.TypeOf(&v3.Response{}) == reflect.TypeOf(r) {
@@ -175,33 +187,34 @@ func CompareResponse(l, r any) *ResponseChanges {
 		rResponse := r.(*v3.Response)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lResponse, rResponse) {
+		if areEqual(ctx, lResponse, rResponse) {
 			return nil
 		}
 
 		// summary (OpenAPI 3.2+)
 		addPropertyCheck(&props, lResponse.Summary.ValueNode, rResponse.Summary.ValueNode,
 			lResponse.Summary.Value, rResponse.Summary.Value, &changes, v3.SummaryLabel,
-			BreakingModified(CompResponse, PropSummary), CompResponse, PropSummary)
+			breakingModified(ctx, CompResponse, PropSummary), CompResponse, PropSummary)
 
 		// description
 		addPropertyCheck(&props, lResponse.Description.ValueNode, rResponse.Description.ValueNode,
 			lResponse.Description.Value, rResponse.Description.Value, &changes, v3.DescriptionLabel,
-			BreakingModified(CompResponse, PropDescription), CompResponse, PropDescription)
+			breakingModified(ctx, CompResponse, PropDescription), CompResponse, PropDescription)
 
-		rc.HeadersChanges = CheckMapForChanges(lResponse.Headers.Value, rResponse.Headers.Value,
-			&changes, v3.HeadersLabel, CompareHeadersV3)
+		rc.HeadersChanges = checkMapForChanges(ctx, lResponse.Headers.Value, rResponse.Headers.Value,
+			&changes, v3.HeadersLabel, compareHeadersV3)
 
-		rc.ContentChanges = CheckMapForChanges(lResponse.Content.Value, rResponse.Content.Value,
-			&changes, v3.ContentLabel, CompareMediaTypes)
+		rc.ContentChanges = checkMapForChanges(ctx, lResponse.Content.Value, rResponse.Content.Value,
+			&changes, v3.ContentLabel, compareMediaTypes)
 
-		rc.LinkChanges = CheckMapForChanges(lResponse.Links.Value, rResponse.Links.Value,
-			&changes, v3.LinksLabel, CompareLinks)
+		rc.LinkChanges = checkMapForChanges(ctx, lResponse.Links.Value, rResponse.Links.Value,
+			&changes, v3.LinksLabel, compareLinks)
 
-		rc.ExtensionChanges = CompareExtensions(lResponse.Extensions, rResponse.Extensions)
+		rc.ExtensionChanges = compareExtensions(ctx, lResponse.Extensions, rResponse.Extensions)
 	}
 
-	CheckProperties(props)
-	rc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	rc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return rc
 }
@@ -4,9 +4,9 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
-	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -72,6 +72,10 @@ func (r *ResponsesChanges) TotalBreakingChanges() int {
 // CompareResponses compares a left and right Swagger or OpenAPI Responses object for any changes. If found
 // returns a pointer to ResponsesChanges, or returns nil.
 func CompareResponses(l, r any) *ResponsesChanges {
+	return compareResponses(context.Background(), l, r)
+}
+
+func compareResponses(ctx context.Context, l, r any) *ResponsesChanges {
 	var changes []*Change
 
 	rc := new(ResponsesChanges)
@@ -84,28 +88,28 @@ func CompareResponses(l, r any) *ResponsesChanges {
 		rResponses := r.(*v2.Responses)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lResponses, rResponses) {
+		if areEqual(ctx, lResponses, rResponses) {
 			return nil
 		}
 
 		if !lResponses.Default.IsEmpty() && !rResponses.Default.IsEmpty() {
-			rc.DefaultChanges = CompareResponse(lResponses.Default.Value, rResponses.Default.Value)
+			rc.DefaultChanges = compareResponse(ctx, lResponses.Default.Value, rResponses.Default.Value)
 		}
 		if !lResponses.Default.IsEmpty() && rResponses.Default.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.DefaultLabel,
-				lResponses.Default.ValueNode, nil, BreakingRemoved(CompResponses, PropDefault),
+				lResponses.Default.ValueNode, nil, breakingRemoved(ctx, CompResponses, PropDefault),
 				lResponses.Default.Value, nil)
 		}
 		if lResponses.Default.IsEmpty() && !rResponses.Default.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.DefaultLabel,
-				nil, rResponses.Default.ValueNode, BreakingAdded(CompResponses, PropDefault),
+				nil, rResponses.Default.ValueNode, breakingAdded(ctx, CompResponses, PropDefault),
 				nil, lResponses.Default.Value)
 		}
 
-		rc.ResponseChanges = CheckMapForChangesWithRules(lResponses.Codes, rResponses.Codes,
-			&changes, v3.CodesLabel, CompareResponseV2, CompResponses, PropCodes)
+		rc.ResponseChanges = checkMapForChangesWithRules(ctx, lResponses.Codes, rResponses.Codes,
+			&changes, v3.CodesLabel, compareResponseV2, CompResponses, PropCodes)
 
-		rc.ExtensionChanges = CompareExtensions(lResponses.Extensions, rResponses.Extensions)
+		rc.ExtensionChanges = compareExtensions(ctx, lResponses.Extensions, rResponses.Extensions)
 	}
 
 	// openapi
@@ -116,31 +120,32 @@ func CompareResponses(l, r any) *ResponsesChanges {
 		rResponses := r.(*v3.Responses)
 
 		// perform hash check to avoid further processing
-		if low.AreEqual(lResponses, rResponses) {
+		if areEqual(ctx, lResponses, rResponses) {
 			return nil
 		}
 
 		if !lResponses.Default.IsEmpty() && !rResponses.Default.IsEmpty() {
-			rc.DefaultChanges = CompareResponse(lResponses.Default.Value, rResponses.Default.Value)
+			rc.DefaultChanges = compareResponse(ctx, lResponses.Default.Value, rResponses.Default.Value)
 		}
 		if !lResponses.Default.IsEmpty() && rResponses.Default.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.DefaultLabel,
-				lResponses.Default.ValueNode, nil, BreakingRemoved(CompResponses, PropDefault),
+				lResponses.Default.ValueNode, nil, breakingRemoved(ctx, CompResponses, PropDefault),
 				lResponses.Default.Value, nil)
 		}
 		if lResponses.Default.IsEmpty() && !rResponses.Default.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.DefaultLabel,
-				nil, rResponses.Default.ValueNode, BreakingAdded(CompResponses, PropDefault),
+				nil, rResponses.Default.ValueNode, breakingAdded(ctx, CompResponses, PropDefault),
 				nil, lResponses.Default.Value)
 		}
 
-		rc.ResponseChanges = CheckMapForChangesWithRules(lResponses.Codes, rResponses.Codes,
-			&changes, v3.CodesLabel, CompareResponseV3, CompResponses, PropCodes)
+		rc.ResponseChanges = checkMapForChangesWithRules(ctx, lResponses.Codes, rResponses.Codes,
+			&changes, v3.CodesLabel, compareResponseV3, CompResponses, PropCodes)
 
-		rc.ExtensionChanges = CompareExtensions(lResponses.Extensions, rResponses.Extensions)
+		rc.ExtensionChanges = compareExtensions(ctx, lResponses.Extensions, rResponses.Extensions)
 
 	}
 
-	rc.PropertyChanges = NewPropertyChanges(changes)
+	checkComparators(l, r, &changes)
+	rc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return rc
 }
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"slices"
 	"sort"
@@ -394,27 +395,54 @@ func (s *SchemaChanges) TotalBreakingChanges() int {
 	return t
 }
 
+// compareResolvedSchemas determines if both schemas belong to documents that compare the resolved content of
+// references (see datamodel.DocumentConfiguration.UseSchemaQuickHash).
+func compareResolvedSchemas(l, r *base.SchemaProxy) bool {
+	for _, sp := range []*base.SchemaProxy{l, r} {
+		idx := sp.GetIndex()
+		if idx == nil || idx.GetConfig() == nil || !idx.GetConfig().UseSchemaQuickHash {
+			return false
+		}
+	}
+	return true
+}
+
+// circular determines if either schema is part of a circular reference.
+func circular(l, r *base.SchemaProxy) bool {
+	return base.CheckSchemaProxyForCircularRefs(l) || base.CheckSchemaProxyForCircularRefs(r)
+}
+
 // CompareSchemas accepts a left and right SchemaProxy and checks for changes. If anything is found, returns
 // a pointer to SchemaChanges, otherwise returns nil
 func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
+	return compareSchemas(context.Background(), l, r)
+}
+
+func compareSchemas(ctx context.Context, l, r *base.SchemaProxy) *SchemaChanges {
+	if ComparisonAborted(ctx) {
+		return nil
+	}
 	sc := new(SchemaChanges)
 	var changes []*Change
 
 	// Added
 	if l == nil && r != nil {
 		CreateChange(&changes, ObjectAdded, v3.SchemaLabel,
-			nil, nil, BreakingAdded(CompSchemas, ""), nil, r)
-		sc.PropertyChanges = NewPropertyChanges(changes)
+			nil, nil, breakingAdded(ctx, CompSchemas, ""), nil, r)
+		sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	}
 
 	// Removed
 	if l != nil && r == nil {
 		CreateChange(&changes, ObjectRemoved, v3.SchemaLabel,
-			nil, nil, BreakingRemoved(CompSchemas, ""), l, nil)
-		sc.PropertyChanges = NewPropertyChanges(changes)
+			nil, nil, breakingRemoved(ctx, CompSchemas, ""), l, nil)
+		sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	}
 
 	if l != nil && r != nil {
+		// when comparing resolved schemas, a change of reference is only a change if what it points to changed, and
+		// then the changes made to the schema are reported, rather than the change of reference.
+		resolved := compareResolvedSchemas(l, r)
 
 		// if left proxy is a reference and right is a reference (we won't recurse into circular references here)
 		if l.IsReference() && r.IsReference() {
@@ -436,12 +464,22 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 
 				// continue on because the external references are the same and we need to check things going forward.
 
+			} else if resolved && !circular(l, r) {
+				// references are different, compare what they point to.
+				if l.Schema().Hash() == r.Schema().Hash() {
+					return nil
+				}
 			} else {
-				// references are different, that's all we care to know.
-				CreateChange(&changes, Modified, v3.RefLabel,
-					l.GetValueNode().Content[1], r.GetValueNode().Content[1], BreakingModified(CompSchema, PropRef), l.GetReference(),
-					r.GetReference())
-				sc.PropertyChanges = NewPropertyChanges(changes)
+				// references are different, report the reference was repointed, and if what it points to is the same.
+				var equal bool
+				if !circular(l, r) && l.Schema() != nil && r.Schema() != nil {
+					equal = l.Schema().Hash() == r.Schema().Hash()
+				}
+				CreateChange(&changes, ReferenceRepointed, v3.RefLabel,
+					l.GetValueNode().Content[1], r.GetValueNode().Content[1],
+					!equal && breakingModified(ctx, CompSchema, PropRef), l.GetReference(), r.GetReference())
+				changes[len(changes)-1].ResolvedEqual = equal
+				sc.PropertyChanges = newPropertyChanges(ctx, changes)
 
 				// check if this is a circular ref.
 				if base.CheckSchemaProxyForCircularRefs(l) || base.CheckSchemaProxyForCircularRefs(r) {
@@ -458,10 +496,10 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 			// https://github.com/pb33f/libopenapi/issues/218
 			lHash := l.Schema().Hash()
 			rHash := r.Schema().Hash()
-			if lHash != rHash {
+			if lHash != rHash && !(resolved && !circular(l, r)) {
 				CreateChange(&changes, Modified, v3.RefLabel,
-					l.GetValueNode(), r.GetValueNode().Content[1], BreakingModified(CompSchema, PropRef), l, r.GetReference())
-				sc.PropertyChanges = NewPropertyChanges(changes)
+					l.GetValueNode(), r.GetValueNode().Content[1], breakingModified(ctx, CompSchema, PropRef), l, r.GetReference())
+				sc.PropertyChanges = newPropertyChanges(ctx, changes)
 
 				// check if this is a circular ref.
 				if base.CheckSchemaProxyForCircularRefs(r) {
@@ -478,10 +516,10 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 			// https://github.com/pb33f/libopenapi/issues/218
 			lHash := l.Schema().Hash()
 			rHash := r.Schema().Hash()
-			if lHash != rHash {
+			if lHash != rHash && !(resolved && !circular(l, r)) {
 				CreateChange(&changes, Modified, v3.RefLabel,
-					l.GetValueNode().Content[1], r.GetValueNode(), BreakingModified(CompSchema, PropRef), l.GetReference(), r)
-				sc.PropertyChanges = NewPropertyChanges(changes)
+					l.GetValueNode().Content[1], r.GetValueNode(), breakingModified(ctx, CompSchema, PropRef), l.GetReference(), r)
+				sc.PropertyChanges = newPropertyChanges(ctx, changes)
 
 				// check if this is a circular ref.
 				if base.CheckSchemaProxyForCircularRefs(l) {
@@ -495,19 +533,22 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 		lSchema := l.Schema()
 		rSchema := r.Schema()
 
-		if low.AreEqual(lSchema, rSchema) {
+		if areEqual(ctx, lSchema, rSchema) {
 			// there is no point going on, we know nothing changed!
 			return nil
 		}
 
 		// check XML
-		checkSchemaXML(lSchema, rSchema, &changes, sc)
+		checkSchemaXML(ctx, lSchema, rSchema, &changes, sc)
 
 		// check examples
-		checkExamples(lSchema, rSchema, &changes)
+		checkExamples(ctx, lSchema, rSchema, &changes)
 
 		// check schema core properties for changes.
-		checkSchemaPropertyChanges(lSchema, rSchema, l, r, &changes, sc)
+		checkSchemaPropertyChanges(ctx, lSchema, rSchema, l, r, &changes, sc)
+
+		// check custom comparators.
+		checkComparators(lSchema, rSchema, &changes)
 
 		// now for the confusing part, there is also a schema's 'properties' property to parse.
 		// inception, eat your heart out.
@@ -532,10 +573,11 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 			rprefix = rSchema.PrefixItems.Value
 		}
 
-		props := checkMappedSchemaOfASchema(lProperties, rProperties, &changes)
+		props := checkMappedSchemaOfASchema(ctx, lProperties, rProperties, v3.PropertiesLabel, PropProperties, &changes)
 		sc.SchemaPropertyChanges = props
 
-		deps := checkMappedSchemaOfASchema(lDepSchemas, rDepSchemas, &changes)
+		deps := checkMappedSchemaOfASchema(ctx, lDepSchemas, rDepSchemas, v3.DependentSchemasLabel, PropDependentSchemas,
+			&changes)
 		sc.DependentSchemasChanges = deps
 
 		// Check dependent required changes
@@ -547,33 +589,34 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 			rDepRequired = rSchema.DependentRequired.Value
 		}
 
-		depRequiredChanges := checkDependentRequiredChanges(lDepRequired, rDepRequired)
+		depRequiredChanges := checkDependentRequiredChanges(ctx, lDepRequired, rDepRequired)
 		if len(depRequiredChanges) > 0 {
 			sc.DependentRequiredChanges = depRequiredChanges
 		}
 
-		patterns := checkMappedSchemaOfASchema(lPattProp, rPattProp, &changes)
+		patterns := checkMappedSchemaOfASchema(ctx, lPattProp, rPattProp, v3.PatternPropertiesLabel, PropPatternProperties,
+			&changes)
 		sc.PatternPropertiesChanges = patterns
 
 		var wg sync.WaitGroup
 		wg.Add(4)
 		go func() {
-			extractSchemaChanges(loneOf, roneOf, v3.OneOfLabel,
+			extractSchemaChanges(ctx, loneOf, roneOf, v3.OneOfLabel,
 				&sc.OneOfChanges, &changes)
 			wg.Done()
 		}()
 		go func() {
-			extractSchemaChanges(lallOf, rallOf, v3.AllOfLabel,
+			extractSchemaChanges(ctx, lallOf, rallOf, v3.AllOfLabel,
 				&sc.AllOfChanges, &changes)
 			wg.Done()
 		}()
 		go func() {
-			extractSchemaChanges(lanyOf, ranyOf, v3.AnyOfLabel,
+			extractSchemaChanges(ctx, lanyOf, ranyOf, v3.AnyOfLabel,
 				&sc.AnyOfChanges, &changes)
 			wg.Done()
 		}()
 		go func() {
-			extractSchemaChanges(lprefix, rprefix, v3.PrefixItemsLabel,
+			extractSchemaChanges(ctx, lprefix, rprefix, v3.PrefixItemsLabel,
 				&sc.PrefixItemsChanges, &changes)
 			wg.Done()
 		}()
@@ -582,7 +625,7 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 	}
 	// done
 	if changes != nil {
-		sc.PropertyChanges = NewPropertyChanges(changes)
+		sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	} else {
 		sc.PropertyChanges = NewPropertyChanges(nil)
 	}
@@ -592,32 +635,34 @@ func CompareSchemas(l, r *base.SchemaProxy) *SchemaChanges {
 	return nil
 }
 
-func checkSchemaXML(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change, sc *SchemaChanges) {
+func checkSchemaXML(ctx context.Context, lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change, sc *SchemaChanges) {
 	// XML removed
 	if lSchema == nil || rSchema == nil {
 		return
 	}
 	if lSchema.XML.Value != nil && rSchema.XML.Value == nil {
 		CreateChange(changes, ObjectRemoved, v3.XMLLabel,
-			lSchema.XML.GetValueNode(), nil, BreakingRemoved(CompSchema, PropXML), lSchema.XML.GetValue(), nil)
+			lSchema.XML.GetValueNode(), nil, breakingRemoved(ctx, CompSchema, PropXML), lSchema.XML.GetValue(), nil)
 	}
 	// XML added
 	if lSchema.XML.Value == nil && rSchema.XML.Value != nil {
 		CreateChange(changes, ObjectAdded, v3.XMLLabel,
-			nil, rSchema.XML.GetValueNode(), BreakingAdded(CompSchema, PropXML), nil, rSchema.XML.GetValue())
+			nil, rSchema.XML.GetValueNode(), breakingAdded(ctx, CompSchema, PropXML), nil, rSchema.XML.GetValue())
 	}
 
 	// compare XML
 	if lSchema.XML.Value != nil && rSchema.XML.Value != nil {
 		if !low.AreEqual(lSchema.XML.Value, rSchema.XML.Value) {
-			sc.XMLChanges = CompareXML(lSchema.XML.Value, rSchema.XML.Value)
+			sc.XMLChanges = compareXML(ctx, lSchema.XML.Value, rSchema.XML.Value)
 		}
 	}
 }
 
 func checkMappedSchemaOfASchema(
+	ctx context.Context,
 	lSchema,
 	rSchema *orderedmap.Map[low.KeyReference[string], low.ValueReference[*base.SchemaProxy]],
+	label, property string,
 	changes *[]*Change,
 ) map[string]*SchemaChanges {
 	var syncPropChanges sync.Map // concurrent-safe map
@@ -640,7 +685,7 @@ func checkMappedSchemaOfASchema(
 	}
 	sort.Strings(lProps)
 	sort.Strings(rProps)
-	buildProperty(lProps, rProps, lEntities, rEntities, &syncPropChanges, changes, rKeyNodes, lKeyNodes)
+	buildProperty(ctx, lProps, rProps, lEntities, rEntities, &syncPropChanges, changes, rKeyNodes, lKeyNodes, label, property)
 
 	// Convert the sync.Map into a regular map[string]*SchemaChanges.
 	propChanges := make(map[string]*SchemaChanges)
@@ -651,16 +696,16 @@ func checkMappedSchemaOfASchema(
 	return propChanges
 }
 
-func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*base.SchemaProxy,
-	propChanges *sync.Map, changes *[]*Change, rKeyNodes, lKeyNodes map[string]*yaml.Node,
+func buildProperty(ctx context.Context, lProps, rProps []string, lEntities, rEntities map[string]*base.SchemaProxy,
+	propChanges *sync.Map, changes *[]*Change, rKeyNodes, lKeyNodes map[string]*yaml.Node, label, property string,
 ) {
 	var wg sync.WaitGroup
 	checkProperty := func(key string, lp, rp *base.SchemaProxy) {
 		defer wg.Done()
-		if low.AreEqual(lp, rp) {
+		if areEqual(ctx, lp, rp) {
 			return
 		}
-		s := CompareSchemas(lp, rp)
+		s := compareSchemas(ctx, lp, rp)
 		propChanges.Store(key, s)
 	}
 
@@ -677,12 +722,12 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 			if lProps[w] != rProps[w] {
 				if !slices.Contains(lProps, rProps[w]) {
 					// new property added.
-					CreateChange(changes, ObjectAdded, v3.PropertiesLabel,
-						nil, rKeyNodes[rProps[w]], BreakingAdded(CompSchema, PropProperties), nil, rEntities[rProps[w]])
+					CreateChange(changes, ObjectAdded, label,
+						nil, rKeyNodes[rProps[w]], breakingAdded(ctx, CompSchema, property), nil, rEntities[rProps[w]])
 				}
 				if !slices.Contains(rProps, lProps[w]) {
-					CreateChange(changes, ObjectRemoved, v3.PropertiesLabel,
-						lKeyNodes[lProps[w]], nil, BreakingRemoved(CompSchema, PropProperties), lEntities[lProps[w]], nil)
+					CreateChange(changes, ObjectRemoved, label,
+						lKeyNodes[lProps[w]], nil, breakingRemoved(ctx, CompSchema, property), lEntities[lProps[w]], nil)
 				}
 				if slices.Contains(lProps, rProps[w]) {
 					h := slices.Index(lProps, rProps[w])
@@ -702,8 +747,8 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 				wg.Add(1)
 				go checkProperty(lProps[w], lEntities[lProps[w]], rEntities[lProps[w]])
 			} else {
-				CreateChange(changes, ObjectRemoved, v3.PropertiesLabel,
-					lKeyNodes[lProps[w]], nil, BreakingRemoved(CompSchema, PropProperties), lEntities[lProps[w]], nil)
+				CreateChange(changes, ObjectRemoved, label,
+					lKeyNodes[lProps[w]], nil, breakingRemoved(ctx, CompSchema, property), lEntities[lProps[w]], nil)
 			}
 		}
 		for w := range rProps {
@@ -711,8 +756,8 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 				wg.Add(1)
 				go checkProperty(rProps[w], lEntities[rProps[w]], rEntities[rProps[w]])
 			} else {
-				CreateChange(changes, ObjectAdded, v3.PropertiesLabel,
-					nil, rKeyNodes[rProps[w]], BreakingAdded(CompSchema, PropProperties), nil, rEntities[rProps[w]])
+				CreateChange(changes, ObjectAdded, label,
+					nil, rKeyNodes[rProps[w]], breakingAdded(ctx, CompSchema, property), nil, rEntities[rProps[w]])
 			}
 		}
 	}
@@ -724,8 +769,8 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 				wg.Add(1)
 				go checkProperty(propName, lEntities[propName], rEntities[propName])
 			} else {
-				CreateChange(changes, ObjectAdded, v3.PropertiesLabel,
-					nil, rKeyNodes[propName], BreakingAdded(CompSchema, PropProperties), nil, rEntities[propName])
+				CreateChange(changes, ObjectAdded, label,
+					nil, rKeyNodes[propName], breakingAdded(ctx, CompSchema, property), nil, rEntities[propName])
 			}
 		}
 		for _, propName := range lProps {
@@ -733,8 +778,8 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 				wg.Add(1)
 				go checkProperty(propName, lEntities[propName], rEntities[propName])
 			} else {
-				CreateChange(changes, ObjectRemoved, v3.PropertiesLabel,
-					nil, lKeyNodes[propName], BreakingRemoved(CompSchema, PropProperties), lEntities[propName], nil)
+				CreateChange(changes, ObjectRemoved, label,
+					nil, lKeyNodes[propName], breakingRemoved(ctx, CompSchema, property), lEntities[propName], nil)
 			}
 		}
 	}
@@ -744,6 +789,7 @@ func buildProperty(lProps, rProps []string, lEntities, rEntities map[string]*bas
 }
 
 func checkSchemaPropertyChanges(
+	ctx context.Context,
 	lSchema *base.Schema,
 	rSchema *base.Schema,
 	lProxy *base.SchemaProxy,
@@ -766,7 +812,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.SchemaDialectLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropSchemaDialect),
+		Breaking:  breakingModified(ctx, CompSchema, PropSchemaDialect),
 		Component: CompSchema,
 		Property:  PropSchemaDialect,
 		Original:  lSchema,
@@ -787,7 +833,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ExclusiveMaximumLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropExclusiveMaximum),
+		Breaking:  breakingModified(ctx, CompSchema, PropExclusiveMaximum),
 		Component: CompSchema,
 		Property:  PropExclusiveMaximum,
 		Original:  lSchema,
@@ -809,7 +855,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ExclusiveMinimumLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropExclusiveMinimum),
+		Breaking:  breakingModified(ctx, CompSchema, PropExclusiveMinimum),
 		Component: CompSchema,
 		Property:  PropExclusiveMinimum,
 		Original:  lSchema,
@@ -830,7 +876,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.TypeLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropType),
+		Breaking:  breakingModified(ctx, CompSchema, PropType),
 		Component: CompSchema,
 		Property:  PropType,
 		Original:  lSchema,
@@ -851,7 +897,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.TitleLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropTitle),
+		Breaking:  breakingModified(ctx, CompSchema, PropTitle),
 		Component: CompSchema,
 		Property:  PropTitle,
 		Original:  lSchema,
@@ -873,7 +919,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MultipleOfLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMultipleOf),
+		Breaking:  breakingModified(ctx, CompSchema, PropMultipleOf),
 		Component: CompSchema,
 		Property:  PropMultipleOf,
 		Original:  lSchema,
@@ -894,7 +940,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MaximumLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMaximum),
+		Breaking:  breakingModified(ctx, CompSchema, PropMaximum),
 		Component: CompSchema,
 		Property:  PropMaximum,
 		Original:  lSchema,
@@ -915,7 +961,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MinimumLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMinimum),
+		Breaking:  breakingModified(ctx, CompSchema, PropMinimum),
 		Component: CompSchema,
 		Property:  PropMinimum,
 		Original:  lSchema,
@@ -936,7 +982,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MaxLengthLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMaxLength),
+		Breaking:  breakingModified(ctx, CompSchema, PropMaxLength),
 		Component: CompSchema,
 		Property:  PropMaxLength,
 		Original:  lSchema,
@@ -957,7 +1003,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MinLengthLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMinLength),
+		Breaking:  breakingModified(ctx, CompSchema, PropMinLength),
 		Component: CompSchema,
 		Property:  PropMinLength,
 		Original:  lSchema,
@@ -978,7 +1024,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.PatternLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropPattern),
+		Breaking:  breakingModified(ctx, CompSchema, PropPattern),
 		Component: CompSchema,
 		Property:  PropPattern,
 		Original:  lSchema,
@@ -999,7 +1045,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.FormatLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropFormat),
+		Breaking:  breakingModified(ctx, CompSchema, PropFormat),
 		Component: CompSchema,
 		Property:  PropFormat,
 		Original:  lSchema,
@@ -1020,7 +1066,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MaxItemsLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMaxItems),
+		Breaking:  breakingModified(ctx, CompSchema, PropMaxItems),
 		Component: CompSchema,
 		Property:  PropMaxItems,
 		Original:  lSchema,
@@ -1041,7 +1087,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MinItemsLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMinItems),
+		Breaking:  breakingModified(ctx, CompSchema, PropMinItems),
 		Component: CompSchema,
 		Property:  PropMinItems,
 		Original:  lSchema,
@@ -1050,6 +1096,48 @@ func checkSchemaPropertyChanges(
 	lnv = nil
 	rnv = nil
 
+	if lSchema != nil && lSchema.MinContains.ValueNode != nil {
+		lnv = lSchema.MinContains.ValueNode
+	}
+	if rSchema != nil && rSchema.MinContains.ValueNode != nil {
+		rnv = rSchema.MinContains.ValueNode
+	}
+	// MinContains
+	props = append(props, &PropertyCheck{
+		LeftNode:  lnv,
+		RightNode: rnv,
+		Label:     v3.MinContainsLabel,
+		Changes:   changes,
+		Breaking:  breakingModified(ctx, CompSchema, PropMinContains),
+		Component: CompSchema,
+		Property:  PropMinContains,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+	lnv = nil
+	rnv = nil
+
+	if lSchema != nil && lSchema.MaxContains.ValueNode != nil {
+		lnv = lSchema.MaxContains.ValueNode
+	}
+	if rSchema != nil && rSchema.MaxContains.ValueNode != nil {
+		rnv = rSchema.MaxContains.ValueNode
+	}
+	// MaxContains
+	props = append(props, &PropertyCheck{
+		LeftNode:  lnv,
+		RightNode: rnv,
+		Label:     v3.MaxContainsLabel,
+		Changes:   changes,
+		Breaking:  breakingModified(ctx, CompSchema, PropMaxContains),
+		Component: CompSchema,
+		Property:  PropMaxContains,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+	lnv = nil
+	rnv = nil
+
 	if lSchema != nil && lSchema.MaxProperties.ValueNode != nil {
 		lnv = lSchema.MaxProperties.ValueNode
 	}
@@ -1062,7 +1150,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MaxPropertiesLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMaxProperties),
+		Breaking:  breakingModified(ctx, CompSchema, PropMaxProperties),
 		Component: CompSchema,
 		Property:  PropMaxProperties,
 		Original:  lSchema,
@@ -1084,7 +1172,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.MinPropertiesLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropMinProperties),
+		Breaking:  breakingModified(ctx, CompSchema, PropMinProperties),
 		Component: CompSchema,
 		Property:  PropMinProperties,
 		Original:  lSchema,
@@ -1105,7 +1193,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.UniqueItemsLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropUniqueItems),
+		Breaking:  breakingModified(ctx, CompSchema, PropUniqueItems),
 		Component: CompSchema,
 		Property:  PropUniqueItems,
 		Original:  lSchema,
@@ -1118,19 +1206,19 @@ func checkSchemaPropertyChanges(
 	// AdditionalProperties
 	if lSchema != nil && lSchema.AdditionalProperties.Value != nil && rSchema != nil && rSchema.AdditionalProperties.Value != nil {
 		if lSchema.AdditionalProperties.Value.IsA() && rSchema.AdditionalProperties.Value.IsA() {
-			if !low.AreEqual(lSchema.AdditionalProperties.Value.A, rSchema.AdditionalProperties.Value.A) {
-				sc.AdditionalPropertiesChanges = CompareSchemas(lSchema.AdditionalProperties.Value.A, rSchema.AdditionalProperties.Value.A)
+			if !areEqual(ctx, lSchema.AdditionalProperties.Value.A, rSchema.AdditionalProperties.Value.A) {
+				sc.AdditionalPropertiesChanges = compareSchemas(ctx, lSchema.AdditionalProperties.Value.A, rSchema.AdditionalProperties.Value.A)
 			}
 		} else {
 			if lSchema.AdditionalProperties.Value.IsB() && rSchema.AdditionalProperties.Value.IsB() {
 				if lSchema.AdditionalProperties.Value.B != rSchema.AdditionalProperties.Value.B {
 					CreateChange(changes, Modified, v3.AdditionalPropertiesLabel,
-						lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, BreakingModified(CompSchema, PropAdditionalProperties),
+						lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, breakingModified(ctx, CompSchema, PropAdditionalProperties),
 						lSchema.AdditionalProperties.Value.B, rSchema.AdditionalProperties.Value.B)
 				}
 			} else {
 				CreateChange(changes, Modified, v3.AdditionalPropertiesLabel,
-					lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, BreakingModified(CompSchema, PropAdditionalProperties),
+					lSchema.AdditionalProperties.ValueNode, rSchema.AdditionalProperties.ValueNode, breakingModified(ctx, CompSchema, PropAdditionalProperties),
 					lSchema.AdditionalProperties.Value.B, rSchema.AdditionalProperties.Value.B)
 			}
 		}
@@ -1139,12 +1227,12 @@ func checkSchemaPropertyChanges(
 	// added AdditionalProperties
 	if (lSchema == nil || lSchema.AdditionalProperties.Value == nil) && (rSchema != nil && rSchema.AdditionalProperties.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.AdditionalPropertiesLabel,
-			nil, rSchema.AdditionalProperties.ValueNode, BreakingAdded(CompSchema, PropAdditionalProperties), nil, rSchema.AdditionalProperties.Value)
+			nil, rSchema.AdditionalProperties.ValueNode, breakingAdded(ctx, CompSchema, PropAdditionalProperties), nil, rSchema.AdditionalProperties.Value)
 	}
 	// removed AdditionalProperties
 	if (lSchema != nil && lSchema.AdditionalProperties.Value != nil) && (rSchema == nil || rSchema.AdditionalProperties.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.AdditionalPropertiesLabel,
-			lSchema.AdditionalProperties.ValueNode, nil, BreakingRemoved(CompSchema, PropAdditionalProperties), lSchema.AdditionalProperties.Value, nil)
+			lSchema.AdditionalProperties.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropAdditionalProperties), lSchema.AdditionalProperties.Value, nil)
 	}
 
 	if lSchema != nil && lSchema.Description.ValueNode != nil {
@@ -1159,7 +1247,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.DescriptionLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropDescription),
+		Breaking:  breakingModified(ctx, CompSchema, PropDescription),
 		Component: CompSchema,
 		Property:  PropDescription,
 		Original:  lSchema,
@@ -1180,7 +1268,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ContentEncodingLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropContentEncoding),
+		Breaking:  breakingModified(ctx, CompSchema, PropContentEncoding),
 		Component: CompSchema,
 		Property:  PropContentEncoding,
 		Original:  lSchema,
@@ -1201,7 +1289,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ContentMediaType,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropContentMediaType),
+		Breaking:  breakingModified(ctx, CompSchema, PropContentMediaType),
 		Component: CompSchema,
 		Property:  PropContentMediaType,
 		Original:  lSchema,
@@ -1222,7 +1310,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.DefaultLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropDefault),
+		Breaking:  breakingModified(ctx, CompSchema, PropDefault),
 		Component: CompSchema,
 		Property:  PropDefault,
 		Original:  lSchema,
@@ -1243,7 +1331,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ConstLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropConst),
+		Breaking:  breakingModified(ctx, CompSchema, PropConst),
 		Component: CompSchema,
 		Property:  PropConst,
 		Original:  lSchema,
@@ -1264,7 +1352,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.NullableLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropNullable),
+		Breaking:  breakingModified(ctx, CompSchema, PropNullable),
 		Component: CompSchema,
 		Property:  PropNullable,
 		Original:  lSchema,
@@ -1285,7 +1373,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.ReadOnlyLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropReadOnly),
+		Breaking:  breakingModified(ctx, CompSchema, PropReadOnly),
 		Component: CompSchema,
 		Property:  PropReadOnly,
 		Original:  lSchema,
@@ -1306,7 +1394,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.WriteOnlyLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropWriteOnly),
+		Breaking:  breakingModified(ctx, CompSchema, PropWriteOnly),
 		Component: CompSchema,
 		Property:  PropWriteOnly,
 		Original:  lSchema,
@@ -1324,7 +1412,7 @@ func checkSchemaPropertyChanges(
 	// Example
 	CheckPropertyAdditionOrRemovalWithEncoding(lnv, rnv,
 		v3.ExampleLabel, changes, false, lSchema, rSchema)
-	CheckForModificationWithEncoding(lnv, rnv,
+	CheckForExampleModification(lnv, rnv,
 		v3.ExampleLabel, changes, false, lSchema, rSchema)
 	lnv = nil
 	rnv = nil
@@ -1341,7 +1429,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.DeprecatedLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropDeprecated),
+		Breaking:  breakingModified(ctx, CompSchema, PropDeprecated),
 		Component: CompSchema,
 		Property:  PropDeprecated,
 		Original:  lSchema,
@@ -1364,14 +1452,14 @@ func checkSchemaPropertyChanges(
 	for g := range k {
 		if _, ok := j[g]; !ok {
 			CreateChange(changes, PropertyAdded, v3.RequiredLabel,
-				nil, rSchema.Required.Value[k[g]].GetValueNode(), BreakingAdded(CompSchema, PropRequired), nil,
+				nil, rSchema.Required.Value[k[g]].GetValueNode(), breakingAdded(ctx, CompSchema, PropRequired), nil,
 				rSchema.Required.Value[k[g]].GetValue)
 		}
 	}
 	for g := range j {
 		if _, ok := k[g]; !ok {
 			CreateChange(changes, PropertyRemoved, v3.RequiredLabel,
-				lSchema.Required.Value[j[g]].GetValueNode(), nil, BreakingRemoved(CompSchema, PropRequired), lSchema.Required.Value[j[g]].GetValue,
+				lSchema.Required.Value[j[g]].GetValueNode(), nil, breakingRemoved(ctx, CompSchema, PropRequired), lSchema.Required.Value[j[g]].GetValue,
 				nil)
 		}
 	}
@@ -1392,168 +1480,172 @@ func checkSchemaPropertyChanges(
 	for g := range k {
 		if _, ok := j[g]; !ok {
 			CreateChange(changes, PropertyAdded, v3.EnumLabel,
-				nil, rSchema.Enum.Value[k[g]].GetValueNode(), BreakingAdded(CompSchema, PropEnum), nil,
+				nil, rSchema.Enum.Value[k[g]].GetValueNode(), breakingAdded(ctx, CompSchema, PropEnum), nil,
 				rSchema.Enum.Value[k[g]].GetValue)
 		}
 	}
 	for g := range j {
 		if _, ok := k[g]; !ok {
 			CreateChange(changes, PropertyRemoved, v3.EnumLabel,
-				lSchema.Enum.Value[j[g]].GetValueNode(), nil, BreakingRemoved(CompSchema, PropEnum), lSchema.Enum.Value[j[g]].GetValue,
+				lSchema.Enum.Value[j[g]].GetValueNode(), nil, breakingRemoved(ctx, CompSchema, PropEnum), lSchema.Enum.Value[j[g]].GetValue,
 				nil)
 		}
 	}
+	if lSchema != nil && rSchema != nil && orderedArrays(ctx).Enums {
+		checkReordered(valueKeys(lSchema.Enum.Value), valueKeys(rSchema.Enum.Value), v3.EnumLabel,
+			lSchema.Enum.ValueNode, rSchema.Enum.ValueNode, breakingModified(ctx, CompSchema, PropEnum), changes)
+	}
 
 	// Discriminator
 	if (lSchema != nil && lSchema.Discriminator.Value != nil) && (rSchema != nil && rSchema.Discriminator.Value != nil) {
 		// check if hash matches, if not then compare.
 		if lSchema.Discriminator.Value.Hash() != rSchema.Discriminator.Value.Hash() {
-			sc.DiscriminatorChanges = CompareDiscriminator(lSchema.Discriminator.Value, rSchema.Discriminator.Value)
+			sc.DiscriminatorChanges = compareDiscriminator(ctx, lSchema.Discriminator.Value, rSchema.Discriminator.Value)
 		}
 	}
 	// added Discriminator
 	if (lSchema == nil || lSchema.Discriminator.Value == nil) && (rSchema != nil && rSchema.Discriminator.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.DiscriminatorLabel,
-			nil, rSchema.Discriminator.ValueNode, BreakingAdded(CompSchema, PropDiscriminator), nil, rSchema.Discriminator.Value)
+			nil, rSchema.Discriminator.ValueNode, breakingAdded(ctx, CompSchema, PropDiscriminator), nil, rSchema.Discriminator.Value)
 	}
 	// removed Discriminator
 	if (lSchema != nil && lSchema.Discriminator.Value != nil) && (rSchema == nil || rSchema.Discriminator.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.DiscriminatorLabel,
-			lSchema.Discriminator.ValueNode, nil, BreakingRemoved(CompSchema, PropDiscriminator), lSchema.Discriminator.Value, nil)
+			lSchema.Discriminator.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropDiscriminator), lSchema.Discriminator.Value, nil)
 	}
 
 	// ExternalDocs
 	if (lSchema != nil && lSchema.ExternalDocs.Value != nil) && (rSchema != nil && rSchema.ExternalDocs.Value != nil) {
 		// check if hash matches, if not then compare.
 		if lSchema.ExternalDocs.Value.Hash() != rSchema.ExternalDocs.Value.Hash() {
-			sc.ExternalDocChanges = CompareExternalDocs(lSchema.ExternalDocs.Value, rSchema.ExternalDocs.Value)
+			sc.ExternalDocChanges = compareExternalDocs(ctx, lSchema.ExternalDocs.Value, rSchema.ExternalDocs.Value)
 		}
 	}
 	// added ExternalDocs
 	if (lSchema == nil || lSchema.ExternalDocs.Value == nil) && (rSchema != nil && rSchema.ExternalDocs.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.ExternalDocsLabel,
-			nil, rSchema.ExternalDocs.ValueNode, BreakingAdded(CompSchema, PropExternalDocs), nil, rSchema.ExternalDocs.Value)
+			nil, rSchema.ExternalDocs.ValueNode, breakingAdded(ctx, CompSchema, PropExternalDocs), nil, rSchema.ExternalDocs.Value)
 	}
 	// removed ExternalDocs
 	if (lSchema != nil && lSchema.ExternalDocs.Value != nil) && (rSchema == nil || rSchema.ExternalDocs.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.ExternalDocsLabel,
-			lSchema.ExternalDocs.ValueNode, nil, BreakingRemoved(CompSchema, PropExternalDocs), lSchema.ExternalDocs.Value, nil)
+			lSchema.ExternalDocs.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropExternalDocs), lSchema.ExternalDocs.Value, nil)
 	}
 
 	// 3.1 properties
 	// If
 	if (lSchema != nil && lSchema.If.Value != nil) && (rSchema != nil && rSchema.If.Value != nil) {
-		if !low.AreEqual(lSchema.If.Value, rSchema.If.Value) {
-			sc.IfChanges = CompareSchemas(lSchema.If.Value, rSchema.If.Value)
+		if !areEqual(ctx, lSchema.If.Value, rSchema.If.Value) {
+			sc.IfChanges = compareSchemas(ctx, lSchema.If.Value, rSchema.If.Value)
 		}
 	}
 	// added If
 	if (lSchema == nil || lSchema.If.Value == nil) && (rSchema != nil && rSchema.If.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.IfLabel,
-			nil, rSchema.If.ValueNode, BreakingAdded(CompSchema, PropIf), nil, rSchema.If.Value)
+			nil, rSchema.If.ValueNode, breakingAdded(ctx, CompSchema, PropIf), nil, rSchema.If.Value)
 	}
 	// removed If
 	if (lSchema != nil && lSchema.If.Value != nil) && (rSchema == nil || rSchema.If.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.IfLabel,
-			lSchema.If.ValueNode, nil, BreakingRemoved(CompSchema, PropIf), lSchema.If.Value, nil)
+			lSchema.If.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropIf), lSchema.If.Value, nil)
 	}
 	// Else
 	if (lSchema != nil && lSchema.Else.Value != nil) && (rSchema == nil || rSchema.Else.Value != nil) {
-		if !low.AreEqual(lSchema.Else.Value, rSchema.Else.Value) {
-			sc.ElseChanges = CompareSchemas(lSchema.Else.Value, rSchema.Else.Value)
+		if !areEqual(ctx, lSchema.Else.Value, rSchema.Else.Value) {
+			sc.ElseChanges = compareSchemas(ctx, lSchema.Else.Value, rSchema.Else.Value)
 		}
 	}
 	// added Else
 	if (lSchema == nil || lSchema.Else.Value == nil) && (rSchema != nil && rSchema.Else.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.ElseLabel,
-			nil, rSchema.Else.ValueNode, BreakingAdded(CompSchema, PropElse), nil, rSchema.Else.Value)
+			nil, rSchema.Else.ValueNode, breakingAdded(ctx, CompSchema, PropElse), nil, rSchema.Else.Value)
 	}
 	// removed Else
 	if (lSchema != nil && lSchema.Else.Value != nil) && (rSchema == nil || rSchema.Else.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.ElseLabel,
-			lSchema.Else.ValueNode, nil, BreakingRemoved(CompSchema, PropElse), lSchema.Else.Value, nil)
+			lSchema.Else.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropElse), lSchema.Else.Value, nil)
 	}
 	// Then
 	if (lSchema != nil && lSchema.Then.Value != nil) && (rSchema != nil && rSchema.Then.Value != nil) {
-		if !low.AreEqual(lSchema.Then.Value, rSchema.Then.Value) {
-			sc.ThenChanges = CompareSchemas(lSchema.Then.Value, rSchema.Then.Value)
+		if !areEqual(ctx, lSchema.Then.Value, rSchema.Then.Value) {
+			sc.ThenChanges = compareSchemas(ctx, lSchema.Then.Value, rSchema.Then.Value)
 		}
 	}
 	// added Then
 	if (lSchema == nil || lSchema.Then.Value == nil) && (rSchema != nil && rSchema.Then.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.ThenLabel,
-			nil, rSchema.Then.ValueNode, BreakingAdded(CompSchema, PropThen), nil, rSchema.Then.Value)
+			nil, rSchema.Then.ValueNode, breakingAdded(ctx, CompSchema, PropThen), nil, rSchema.Then.Value)
 	}
 	// removed Then
 	if (lSchema != nil && lSchema.Then.Value != nil) && (rSchema == nil || rSchema.Then.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.ThenLabel,
-			lSchema.Then.ValueNode, nil, BreakingRemoved(CompSchema, PropThen), lSchema.Then.Value, nil)
+			lSchema.Then.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropThen), lSchema.Then.Value, nil)
 	}
 	// PropertyNames
 	if (lSchema != nil && lSchema.PropertyNames.Value != nil) && (rSchema != nil && rSchema.PropertyNames.Value != nil) {
-		if !low.AreEqual(lSchema.PropertyNames.Value, rSchema.PropertyNames.Value) {
-			sc.PropertyNamesChanges = CompareSchemas(lSchema.PropertyNames.Value, rSchema.PropertyNames.Value)
+		if !areEqual(ctx, lSchema.PropertyNames.Value, rSchema.PropertyNames.Value) {
+			sc.PropertyNamesChanges = compareSchemas(ctx, lSchema.PropertyNames.Value, rSchema.PropertyNames.Value)
 		}
 	}
 	// added PropertyNames
 	if (lSchema == nil || lSchema.PropertyNames.Value == nil) && (rSchema != nil && rSchema.PropertyNames.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.PropertyNamesLabel,
-			nil, rSchema.PropertyNames.ValueNode, BreakingAdded(CompSchema, PropPropertyNames), nil, rSchema.PropertyNames.Value)
+			nil, rSchema.PropertyNames.ValueNode, breakingAdded(ctx, CompSchema, PropPropertyNames), nil, rSchema.PropertyNames.Value)
 	}
 	// removed PropertyNames
 	if (lSchema != nil && lSchema.PropertyNames.Value != nil) && (rSchema == nil || rSchema.PropertyNames.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.PropertyNamesLabel,
-			lSchema.PropertyNames.ValueNode, nil, BreakingRemoved(CompSchema, PropPropertyNames), lSchema.PropertyNames.Value, nil)
+			lSchema.PropertyNames.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropPropertyNames), lSchema.PropertyNames.Value, nil)
 	}
 	// Contains
 	if (lSchema != nil && lSchema.Contains.Value != nil) && (rSchema != nil && rSchema.Contains.Value != nil) {
-		if !low.AreEqual(lSchema.Contains.Value, rSchema.Contains.Value) {
-			sc.ContainsChanges = CompareSchemas(lSchema.Contains.Value, rSchema.Contains.Value)
+		if !areEqual(ctx, lSchema.Contains.Value, rSchema.Contains.Value) {
+			sc.ContainsChanges = compareSchemas(ctx, lSchema.Contains.Value, rSchema.Contains.Value)
 		}
 	}
 	// added Contains
 	if (lSchema == nil || lSchema.Contains.Value == nil) && (rSchema != nil && rSchema.Contains.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.ContainsLabel,
-			nil, rSchema.Contains.ValueNode, BreakingAdded(CompSchema, PropContains), nil, rSchema.Contains.Value)
+			nil, rSchema.Contains.ValueNode, breakingAdded(ctx, CompSchema, PropContains), nil, rSchema.Contains.Value)
 	}
 	// removed Contains
 	if (lSchema != nil && lSchema.Contains.Value != nil) && (rSchema == nil || rSchema.Contains.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.ContainsLabel,
-			lSchema.Contains.ValueNode, nil, BreakingRemoved(CompSchema, PropContains), lSchema.Contains.Value, nil)
+			lSchema.Contains.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropContains), lSchema.Contains.Value, nil)
 	}
 	// UnevaluatedItems
 	if (lSchema != nil && lSchema.UnevaluatedItems.Value != nil) && (rSchema != nil && rSchema.UnevaluatedItems.Value != nil) {
-		if !low.AreEqual(lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value) {
-			sc.UnevaluatedItemsChanges = CompareSchemas(lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value)
+		if !areEqual(ctx, lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value) {
+			sc.UnevaluatedItemsChanges = compareSchemas(ctx, lSchema.UnevaluatedItems.Value, rSchema.UnevaluatedItems.Value)
 		}
 	}
 	// added UnevaluatedItems
 	if (lSchema == nil || lSchema.UnevaluatedItems.Value == nil) && (rSchema != nil && rSchema.UnevaluatedItems.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.UnevaluatedItemsLabel,
-			nil, rSchema.UnevaluatedItems.ValueNode, BreakingAdded(CompSchema, PropUnevaluatedItems), nil, rSchema.UnevaluatedItems.Value)
+			nil, rSchema.UnevaluatedItems.ValueNode, breakingAdded(ctx, CompSchema, PropUnevaluatedItems), nil, rSchema.UnevaluatedItems.Value)
 	}
 	// removed UnevaluatedItems
 	if (lSchema != nil && lSchema.UnevaluatedItems.Value != nil) && (rSchema == nil || rSchema.UnevaluatedItems.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.UnevaluatedItemsLabel,
-			lSchema.UnevaluatedItems.ValueNode, nil, BreakingRemoved(CompSchema, PropUnevaluatedItems), lSchema.UnevaluatedItems.Value, nil)
+			lSchema.UnevaluatedItems.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropUnevaluatedItems), lSchema.UnevaluatedItems.Value, nil)
 	}
 
 	// UnevaluatedProperties
 	if (lSchema != nil && lSchema.UnevaluatedProperties.Value != nil) && (rSchema != nil && rSchema.UnevaluatedProperties.Value != nil) {
 		if lSchema.UnevaluatedProperties.Value.IsA() && rSchema.UnevaluatedProperties.Value.IsA() {
-			if !low.AreEqual(lSchema.UnevaluatedProperties.Value.A, rSchema.UnevaluatedProperties.Value.A) {
-				sc.UnevaluatedPropertiesChanges = CompareSchemas(lSchema.UnevaluatedProperties.Value.A, rSchema.UnevaluatedProperties.Value.A)
+			if !areEqual(ctx, lSchema.UnevaluatedProperties.Value.A, rSchema.UnevaluatedProperties.Value.A) {
+				sc.UnevaluatedPropertiesChanges = compareSchemas(ctx, lSchema.UnevaluatedProperties.Value.A, rSchema.UnevaluatedProperties.Value.A)
 			}
 		} else {
 			if lSchema.UnevaluatedProperties.Value.IsB() && rSchema.UnevaluatedProperties.Value.IsB() {
 				if lSchema.UnevaluatedProperties.Value.B != rSchema.UnevaluatedProperties.Value.B {
 					CreateChange(changes, Modified, v3.UnevaluatedPropertiesLabel,
-						lSchema.UnevaluatedProperties.ValueNode, rSchema.UnevaluatedProperties.ValueNode, BreakingModified(CompSchema, PropUnevaluatedProps),
+						lSchema.UnevaluatedProperties.ValueNode, rSchema.UnevaluatedProperties.ValueNode, breakingModified(ctx, CompSchema, PropUnevaluatedProps),
 						lSchema.UnevaluatedProperties.Value.B, rSchema.UnevaluatedProperties.Value.B)
 				}
 			} else {
 				CreateChange(changes, Modified, v3.UnevaluatedPropertiesLabel,
-					lSchema.UnevaluatedProperties.ValueNode, rSchema.UnevaluatedProperties.ValueNode, BreakingModified(CompSchema, PropUnevaluatedProps),
+					lSchema.UnevaluatedProperties.ValueNode, rSchema.UnevaluatedProperties.ValueNode, breakingModified(ctx, CompSchema, PropUnevaluatedProps),
 					lSchema.UnevaluatedProperties.Value.B, rSchema.UnevaluatedProperties.Value.B)
 			}
 		}
@@ -1562,56 +1654,78 @@ func checkSchemaPropertyChanges(
 	// added UnevaluatedProperties
 	if (lSchema == nil || lSchema.UnevaluatedProperties.Value == nil) && (rSchema != nil && rSchema.UnevaluatedProperties.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.UnevaluatedPropertiesLabel,
-			nil, rSchema.UnevaluatedProperties.ValueNode, BreakingAdded(CompSchema, PropUnevaluatedProps), nil, rSchema.UnevaluatedProperties.Value)
+			nil, rSchema.UnevaluatedProperties.ValueNode, breakingAdded(ctx, CompSchema, PropUnevaluatedProps), nil, rSchema.UnevaluatedProperties.Value)
 	}
 	// removed UnevaluatedProperties
 	if (lSchema != nil && lSchema.UnevaluatedProperties.Value != nil) && (rSchema == nil || rSchema.UnevaluatedProperties.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.UnevaluatedPropertiesLabel,
-			lSchema.UnevaluatedProperties.ValueNode, nil, BreakingRemoved(CompSchema, PropUnevaluatedProps), lSchema.UnevaluatedProperties.Value, nil)
+			lSchema.UnevaluatedProperties.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropUnevaluatedProps), lSchema.UnevaluatedProperties.Value, nil)
 	}
 
 	// Not
 	if (lSchema != nil && lSchema.Not.Value != nil) && (rSchema != nil && rSchema.Not.Value != nil) {
-		if !low.AreEqual(lSchema.Not.Value, rSchema.Not.Value) {
-			sc.NotChanges = CompareSchemas(lSchema.Not.Value, rSchema.Not.Value)
+		if !areEqual(ctx, lSchema.Not.Value, rSchema.Not.Value) {
+			sc.NotChanges = compareSchemas(ctx, lSchema.Not.Value, rSchema.Not.Value)
 		}
 	}
 	// added Not
 	if (lSchema == nil || lSchema.Not.Value == nil) && (rSchema != nil && rSchema.Not.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.NotLabel,
-			nil, rSchema.Not.ValueNode, BreakingAdded(CompSchema, PropNot), nil, rSchema.Not.Value)
+			nil, rSchema.Not.ValueNode, breakingAdded(ctx, CompSchema, PropNot), nil, rSchema.Not.Value)
 	}
 	// removed not
 	if (lSchema != nil && lSchema.Not.Value != nil) && (rSchema == nil || rSchema.Not.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.NotLabel,
-			lSchema.Not.ValueNode, nil, BreakingRemoved(CompSchema, PropNot), lSchema.Not.Value, nil)
+			lSchema.Not.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropNot), lSchema.Not.Value, nil)
 	}
 
 	// items
 	if (lSchema != nil && lSchema.Items.Value != nil) && (rSchema != nil && rSchema.Items.Value != nil) {
 		if lSchema.Items.Value.IsA() && rSchema.Items.Value.IsA() {
-			if !low.AreEqual(lSchema.Items.Value.A, rSchema.Items.Value.A) {
-				sc.ItemsChanges = CompareSchemas(lSchema.Items.Value.A, rSchema.Items.Value.A)
+			if !areEqual(ctx, lSchema.Items.Value.A, rSchema.Items.Value.A) {
+				sc.ItemsChanges = compareSchemas(ctx, lSchema.Items.Value.A, rSchema.Items.Value.A)
 			}
 		} else {
 			CreateChange(changes, Modified, v3.ItemsLabel,
-				lSchema.Items.ValueNode, rSchema.Items.ValueNode, BreakingModified(CompSchema, PropItems), lSchema.Items.Value.B, rSchema.Items.Value.B)
+				lSchema.Items.ValueNode, rSchema.Items.ValueNode, breakingModified(ctx, CompSchema, PropItems), lSchema.Items.Value.B, rSchema.Items.Value.B)
 		}
 	}
 	// added Items
 	if (lSchema == nil || lSchema.Items.Value == nil) && (rSchema != nil && rSchema.Items.Value != nil) {
 		CreateChange(changes, ObjectAdded, v3.ItemsLabel,
-			nil, rSchema.Items.ValueNode, BreakingAdded(CompSchema, PropItems), nil, rSchema.Items.Value)
+			nil, rSchema.Items.ValueNode, breakingAdded(ctx, CompSchema, PropItems), nil, rSchema.Items.Value)
 	}
 	// removed Items
 	if (lSchema != nil && lSchema.Items.Value != nil) && (rSchema == nil || rSchema.Items.Value == nil) {
 		CreateChange(changes, ObjectRemoved, v3.ItemsLabel,
-			lSchema.Items.ValueNode, nil, BreakingRemoved(CompSchema, PropItems), lSchema.Items.Value, nil)
+			lSchema.Items.ValueNode, nil, breakingRemoved(ctx, CompSchema, PropItems), lSchema.Items.Value, nil)
 	}
 
-	// $dynamicAnchor (JSON Schema 2020-12)
 	lnv = nil
 	rnv = nil
+
+	// $anchor (JSON Schema 2020-12)
+	if lSchema != nil && lSchema.Anchor.ValueNode != nil {
+		lnv = lSchema.Anchor.ValueNode
+	}
+	if rSchema != nil && rSchema.Anchor.ValueNode != nil {
+		rnv = rSchema.Anchor.ValueNode
+	}
+	props = append(props, &PropertyCheck{
+		LeftNode:  lnv,
+		RightNode: rnv,
+		Label:     v3.AnchorLabel,
+		Changes:   changes,
+		Breaking:  breakingModified(ctx, CompSchema, PropAnchor),
+		Component: CompSchema,
+		Property:  PropAnchor,
+		Original:  lSchema,
+		New:       rSchema,
+	})
+	lnv = nil
+	rnv = nil
+
+	// $dynamicAnchor (JSON Schema 2020-12)
 	if lSchema != nil && lSchema.DynamicAnchor.ValueNode != nil {
 		lnv = lSchema.DynamicAnchor.ValueNode
 	}
@@ -1623,7 +1737,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.DynamicAnchorLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropDynamicAnchor),
+		Breaking:  breakingModified(ctx, CompSchema, PropDynamicAnchor),
 		Component: CompSchema,
 		Property:  PropDynamicAnchor,
 		Original:  lSchema,
@@ -1644,7 +1758,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     v3.DynamicRefLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropDynamicRef),
+		Breaking:  breakingModified(ctx, CompSchema, PropDynamicRef),
 		Component: CompSchema,
 		Property:  PropDynamicRef,
 		Original:  lSchema,
@@ -1665,7 +1779,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     base.IdLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropId),
+		Breaking:  breakingModified(ctx, CompSchema, PropId),
 		Component: CompSchema,
 		Property:  PropId,
 		Original:  lSchema,
@@ -1686,7 +1800,7 @@ func checkSchemaPropertyChanges(
 		RightNode: rnv,
 		Label:     base.CommentLabel,
 		Changes:   changes,
-		Breaking:  BreakingModified(CompSchema, PropComment),
+		Breaking:  breakingModified(ctx, CompSchema, PropComment),
 		Component: CompSchema,
 		Property:  PropComment,
 		Original:  lSchema,
@@ -1697,18 +1811,18 @@ func checkSchemaPropertyChanges(
 
 	// contentSchema (JSON Schema 2020-12) - recursive schema comparison
 	if lSchema != nil && !lSchema.ContentSchema.IsEmpty() && rSchema != nil && !rSchema.ContentSchema.IsEmpty() {
-		sc.ContentSchemaChanges = CompareSchemas(lSchema.ContentSchema.Value, rSchema.ContentSchema.Value)
+		sc.ContentSchemaChanges = compareSchemas(ctx, lSchema.ContentSchema.Value, rSchema.ContentSchema.Value)
 	}
 	if lSchema != nil && !lSchema.ContentSchema.IsEmpty() && (rSchema == nil || rSchema.ContentSchema.IsEmpty()) {
 		CreateChange(changes, PropertyRemoved, base.ContentSchemaLabel,
 			lSchema.ContentSchema.ValueNode, nil,
-			BreakingRemoved(CompSchema, PropContentSchema),
+			breakingRemoved(ctx, CompSchema, PropContentSchema),
 			lSchema.ContentSchema.Value, nil)
 	}
 	if (lSchema == nil || lSchema.ContentSchema.IsEmpty()) && rSchema != nil && !rSchema.ContentSchema.IsEmpty() {
 		CreateChange(changes, PropertyAdded, base.ContentSchemaLabel,
 			nil, rSchema.ContentSchema.ValueNode,
-			BreakingAdded(CompSchema, PropContentSchema),
+			breakingAdded(ctx, CompSchema, PropContentSchema),
 			nil, rSchema.ContentSchema.Value)
 	}
 
@@ -1723,7 +1837,7 @@ func checkSchemaPropertyChanges(
 		rVocab = rSchema.Vocabulary.Value
 	}
 	if lVocab != nil || rVocab != nil {
-		sc.VocabularyChanges = checkVocabularyChanges(lVocab, rVocab)
+		sc.VocabularyChanges = checkVocabularyChanges(ctx, lVocab, rVocab)
 	}
 
 	// check extensions
@@ -1736,11 +1850,11 @@ func checkSchemaPropertyChanges(
 		rext = rSchema.Extensions
 	}
 	if lext != nil && rext != nil {
-		sc.ExtensionChanges = CompareExtensions(lext, rext)
+		sc.ExtensionChanges = compareExtensions(ctx, lext, rext)
 	}
 
 	// check core properties
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	// Post-process: Update context line numbers for Type changes to use schema KeyNode for better context
 	// This provides line where "schema:" is defined, not "type: value"
@@ -1765,7 +1879,7 @@ func checkSchemaPropertyChanges(
 	}
 }
 
-func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change) {
+func checkExamples(ctx context.Context, lSchema *base.Schema, rSchema *base.Schema, changes *[]*Change) {
 	if lSchema == nil && rSchema == nil {
 		return
 	}
@@ -1777,10 +1891,10 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 	lExampVal := make(map[string]any)
 	rExampVal := make(map[string]any)
 
-	// create keys by hashing values
+	// create keys by hashing the content of values, so re-formatted examples are not a change.
 	if lSchema != nil && lSchema.Examples.ValueNode != nil {
 		for i := range lSchema.Examples.ValueNode.Content {
-			key := low.GenerateHashString(lSchema.Examples.ValueNode.Content[i].Value)
+			key := low.GenerateHashString(canonicalExample(lSchema.Examples.ValueNode.Content[i]))
 			lExampKey = append(lExampKey, key)
 			lExampVal[key] = lSchema.Examples.ValueNode.Content[i].Value
 			lExampN[key] = lSchema.Examples.ValueNode.Content[i]
@@ -1789,7 +1903,7 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 	}
 	if rSchema != nil && rSchema.Examples.ValueNode != nil {
 		for i := range rSchema.Examples.ValueNode.Content {
-			key := low.GenerateHashString(rSchema.Examples.ValueNode.Content[i].Value)
+			key := low.GenerateHashString(canonicalExample(rSchema.Examples.ValueNode.Content[i]))
 			rExampKey = append(rExampKey, key)
 			rExampVal[key] = rSchema.Examples.ValueNode.Content[i].Value
 			rExampN[key] = rSchema.Examples.ValueNode.Content[i]
@@ -1801,7 +1915,7 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 		for i := range lExampKey {
 			if lExampKey[i] != rExampKey[i] {
 				CreateChangeWithEncoding(changes, Modified, v3.ExamplesLabel,
-					lExampN[lExampKey[i]], rExampN[rExampKey[i]], BreakingModified(CompSchema, PropExamples),
+					lExampN[lExampKey[i]], rExampN[rExampKey[i]], breakingModified(ctx, CompSchema, PropExamples),
 					lExampVal[lExampKey[i]], rExampVal[rExampKey[i]])
 			}
 		}
@@ -1811,12 +1925,12 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 		for i := range lExampKey {
 			if i < len(rExampKey) && lExampKey[i] != rExampKey[i] {
 				CreateChangeWithEncoding(changes, Modified, v3.ExamplesLabel,
-					lExampN[lExampKey[i]], rExampN[rExampKey[i]], BreakingModified(CompSchema, PropExamples),
+					lExampN[lExampKey[i]], rExampN[rExampKey[i]], breakingModified(ctx, CompSchema, PropExamples),
 					lExampVal[lExampKey[i]], rExampVal[rExampKey[i]])
 			}
 			if i >= len(rExampKey) {
 				CreateChangeWithEncoding(changes, ObjectRemoved, v3.ExamplesLabel,
-					lExampN[lExampKey[i]], nil, BreakingRemoved(CompSchema, PropExamples),
+					lExampN[lExampKey[i]], nil, breakingRemoved(ctx, CompSchema, PropExamples),
 					lExampVal[lExampKey[i]], nil)
 			}
 		}
@@ -1827,12 +1941,12 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 		for i := range rExampKey {
 			if i < len(lExampKey) && lExampKey[i] != rExampKey[i] {
 				CreateChangeWithEncoding(changes, Modified, v3.ExamplesLabel,
-					lExampN[lExampKey[i]], rExampN[rExampKey[i]], BreakingModified(CompSchema, PropExamples),
+					lExampN[lExampKey[i]], rExampN[rExampKey[i]], breakingModified(ctx, CompSchema, PropExamples),
 					lExampVal[lExampKey[i]], rExampVal[rExampKey[i]])
 			}
 			if i >= len(lExampKey) {
 				CreateChangeWithEncoding(changes, ObjectAdded, v3.ExamplesLabel,
-					nil, rExampN[rExampKey[i]], BreakingAdded(CompSchema, PropExamples),
+					nil, rExampN[rExampKey[i]], breakingAdded(ctx, CompSchema, PropExamples),
 					nil, rExampVal[rExampKey[i]])
 			}
 		}
@@ -1840,6 +1954,7 @@ func checkExamples(lSchema *base.Schema, rSchema *base.Schema, changes *[]*Chang
 }
 
 func extractSchemaChanges(
+	ctx context.Context,
 	lSchema []low.ValueReference[*base.SchemaProxy],
 	rSchema []low.ValueReference[*base.SchemaProxy],
 	label string,
@@ -1876,7 +1991,7 @@ func extractSchemaChanges(
 		for w := range lKeys {
 			// keys are different, which means there are changes.
 			if lKeys[w] != rKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, compareSchemas(ctx, lEntities[lKeys[w]], rEntities[rKeys[w]]))
 			}
 		}
 	}
@@ -1885,20 +2000,20 @@ func extractSchemaChanges(
 	if len(lKeys) > len(rKeys) {
 		for w := range lKeys {
 			if w < len(rKeys) && lKeys[w] != rKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, compareSchemas(ctx, lEntities[lKeys[w]], rEntities[rKeys[w]]))
 			}
 			if w >= len(rKeys) {
 				// determine breaking status based on label
 				breaking := true
 				switch label {
 				case v3.AllOfLabel:
-					breaking = BreakingRemoved(CompSchema, PropAllOf)
+					breaking = breakingRemoved(ctx, CompSchema, PropAllOf)
 				case v3.AnyOfLabel:
-					breaking = BreakingRemoved(CompSchema, PropAnyOf)
+					breaking = breakingRemoved(ctx, CompSchema, PropAnyOf)
 				case v3.OneOfLabel:
-					breaking = BreakingRemoved(CompSchema, PropOneOf)
+					breaking = breakingRemoved(ctx, CompSchema, PropOneOf)
 				case v3.PrefixItemsLabel:
-					breaking = BreakingRemoved(CompSchema, PropPrefixItems)
+					breaking = breakingRemoved(ctx, CompSchema, PropPrefixItems)
 				}
 				CreateChange(changes, ObjectRemoved, label,
 					lEntities[lKeys[w]].GetValueNode(), nil, breaking, lEntities[lKeys[w]], nil)
@@ -1910,20 +2025,20 @@ func extractSchemaChanges(
 	if len(rKeys) > len(lKeys) {
 		for w := range rKeys {
 			if w < len(lKeys) && rKeys[w] != lKeys[w] {
-				*sc = append(*sc, CompareSchemas(lEntities[lKeys[w]], rEntities[rKeys[w]]))
+				*sc = append(*sc, compareSchemas(ctx, lEntities[lKeys[w]], rEntities[rKeys[w]]))
 			}
 			if w >= len(lKeys) {
 				// determine breaking status based on label
 				breaking := false
 				switch label {
 				case v3.AllOfLabel:
-					breaking = BreakingAdded(CompSchema, PropAllOf)
+					breaking = breakingAdded(ctx, CompSchema, PropAllOf)
 				case v3.AnyOfLabel:
-					breaking = BreakingAdded(CompSchema, PropAnyOf)
+					breaking = breakingAdded(ctx, CompSchema, PropAnyOf)
 				case v3.OneOfLabel:
-					breaking = BreakingAdded(CompSchema, PropOneOf)
+					breaking = breakingAdded(ctx, CompSchema, PropOneOf)
 				case v3.PrefixItemsLabel:
-					breaking = BreakingAdded(CompSchema, PropPrefixItems)
+					breaking = breakingAdded(ctx, CompSchema, PropPrefixItems)
 				}
 				CreateChange(changes, ObjectAdded, label,
 					nil, rEntities[rKeys[w]].GetValueNode(), breaking, nil, rEntities[rKeys[w]])
@@ -1933,7 +2048,7 @@ func extractSchemaChanges(
 }
 
 // checkDependentRequiredChanges compares two DependentRequired maps and returns any changes found
-func checkDependentRequiredChanges(
+func checkDependentRequiredChanges(ctx context.Context,
 	left, right *orderedmap.Map[low.KeyReference[string], low.ValueReference[[]string]],
 ) []*Change {
 	// If both are nil, no changes
@@ -1967,13 +2082,13 @@ func checkDependentRequiredChanges(
 			if !slicesEqual(leftReqs, rightReqs) {
 				CreateChange(&changes, Modified, prop,
 					getNodeForProperty(left, prop), getNodeForProperty(right, prop),
-					BreakingModified(CompSchema, PropDependentRequired), leftReqs, rightReqs)
+					breakingModified(ctx, CompSchema, PropDependentRequired), leftReqs, rightReqs)
 			}
 		} else {
 			// Property added
 			CreateChange(&changes, PropertyAdded, prop,
 				nil, getNodeForProperty(right, prop),
-				BreakingAdded(CompSchema, PropDependentRequired), nil, rightReqs)
+				breakingAdded(ctx, CompSchema, PropDependentRequired), nil, rightReqs)
 		}
 	}
 
@@ -1982,7 +2097,7 @@ func checkDependentRequiredChanges(
 		if _, exists := rightMap[prop]; !exists {
 			CreateChange(&changes, PropertyRemoved, prop,
 				getNodeForProperty(left, prop), nil,
-				BreakingRemoved(CompSchema, PropDependentRequired), leftReqs, nil)
+				breakingRemoved(ctx, CompSchema, PropDependentRequired), leftReqs, nil)
 		}
 	}
 
@@ -2017,7 +2132,7 @@ func getNodeForProperty(depMap *orderedmap.Map[low.KeyReference[string], low.Val
 
 // checkVocabularyChanges compares $vocabulary maps and returns a list of changes.
 // the caller is responsible for appending the returned changes to their main changes slice.
-func checkVocabularyChanges(lVocab, rVocab *orderedmap.Map[low.KeyReference[string], low.ValueReference[bool]]) []*Change {
+func checkVocabularyChanges(ctx context.Context, lVocab, rVocab *orderedmap.Map[low.KeyReference[string], low.ValueReference[bool]]) []*Change {
 	if lVocab == nil && rVocab == nil {
 		return nil
 	}
@@ -2057,7 +2172,7 @@ func checkVocabularyChanges(lVocab, rVocab *orderedmap.Map[low.KeyReference[stri
 					ChangeType:     Modified,
 					Original:       fmt.Sprintf("%s=%v", uri, lVal),
 					New:            fmt.Sprintf("%s=%v", uri, rVal),
-					Breaking:       BreakingModified(CompSchema, PropVocabulary),
+					Breaking:       breakingModified(ctx, CompSchema, PropVocabulary),
 					OriginalObject: lVocabMap,
 					NewObject:      rVocabMap,
 				}
@@ -2072,7 +2187,7 @@ func checkVocabularyChanges(lVocab, rVocab *orderedmap.Map[low.KeyReference[stri
 				Property:       base.VocabularyLabel,
 				ChangeType:     PropertyRemoved,
 				Original:       uri,
-				Breaking:       BreakingRemoved(CompSchema, PropVocabulary),
+				Breaking:       breakingRemoved(ctx, CompSchema, PropVocabulary),
 				OriginalObject: lVocabMap,
 			}
 			if lVocabNodes[uri] != nil {
@@ -2090,7 +2205,7 @@ func checkVocabularyChanges(lVocab, rVocab *orderedmap.Map[low.KeyReference[stri
 				Property:   base.VocabularyLabel,
 				ChangeType: PropertyAdded,
 				New:        uri,
-				Breaking:   BreakingAdded(CompSchema, PropVocabulary),
+				Breaking:   breakingAdded(ctx, CompSchema, PropVocabulary),
 				NewObject:  rVocabMap,
 			}
 			if rVocabNodes[uri] != nil {
@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -261,8 +262,51 @@ components:
 	assert.NotNil(t, changes)
 	assert.Len(t, changes.Changes, 1)
 	assert.Len(t, changes.GetAllChanges(), 1)
-	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, ReferenceRepointed, changes.Changes[0].ChangeType)
+	assert.Equal(t, "#/components/schemas/Yo", changes.Changes[0].New)
+	assert.False(t, changes.Changes[0].ResolvedEqual)
+	// the default rules don't consider a change of reference breaking.
+	assert.False(t, changes.Changes[0].Breaking)
+}
+
+func TestCompareSchemas_RefRepointedToIdenticalSchema(t *testing.T) {
+	// Clear hash cache to ensure deterministic results in concurrent test environments
+	low.ClearHashCache()
+	left := `openapi: 3.0
+components:
+  schemas:
+    Woah:
+      type: int
+    Yo:
+      type: int
+    OK:
+      $ref: '#/components/schemas/Woah'`
+
+	right := `openapi: 3.0
+components:
+  schemas:
+    Woah:
+      type: int
+    Yo:
+      type: int
+    OK:
+      $ref: '#/components/schemas/Yo'`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Len(t, changes.Changes, 1)
+	assert.Equal(t, ReferenceRepointed, changes.Changes[0].ChangeType)
+	assert.Equal(t, "#/components/schemas/Woah", changes.Changes[0].Original)
 	assert.Equal(t, "#/components/schemas/Yo", changes.Changes[0].New)
+	assert.True(t, changes.Changes[0].ResolvedEqual)
+	assert.False(t, changes.Changes[0].Breaking)
+	assert.Equal(t, 0, changes.TotalBreakingChanges())
+	assert.Equal(t, SeverityInfo, changes.Changes[0].GetSeverity())
 }
 
 func TestCompareSchemas_RefToInline(t *testing.T) {
@@ -967,6 +1011,73 @@ components:
 	assert.Equal(t, 1, changes.PatternPropertiesChanges["schemaOne"].PropertyChanges.TotalChanges())
 }
 
+func TestCompareSchemas_DependentSchemas_AddedRemoved(t *testing.T) {
+	low.ClearHashCache()
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      dependentSchemas:
+        schemaOne:
+          type: string`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      dependentSchemas:
+        schemaTwo:
+          type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 2, changes.TotalBreakingChanges())
+	for _, c := range changes.Changes {
+		assert.Equal(t, v3.DependentSchemasLabel, c.Property)
+	}
+}
+
+func TestCompareSchemas_PatternProperties_AddedRemoved(t *testing.T) {
+	low.ClearHashCache()
+	left := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      patternProperties:
+        "^x-":
+          type: string`
+
+	right := `openapi: 3.1
+components:
+  schemas:
+    OK:
+      patternProperties:
+        "^y-":
+          type: string`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	for _, c := range changes.Changes {
+		assert.Equal(t, v3.PatternPropertiesLabel, c.Property)
+		if c.ChangeType == ObjectRemoved {
+			assert.True(t, c.Breaking)
+		}
+	}
+}
+
 func TestCompareSchemas_PropertyNames(t *testing.T) {
 	// Clear hash cache to ensure deterministic results in concurrent test environments
 	low.ClearHashCache()
@@ -2452,6 +2563,43 @@ components:
 	assert.Equal(t, "sausages", changes.Changes[0].Original)
 }
 
+func TestCompareSchemas_ExamplesObjects(t *testing.T) {
+	// Clear hash cache to ensure deterministic results in concurrent test environments
+	low.ClearHashCache()
+	left := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      title: nice
+      examples:
+        - name: sausages
+          price: 1
+        - name: eggs`
+
+	right := `openapi: 3.0
+components:
+  schemas:
+    OK:
+      title: nice
+      examples:
+        - {price: 1.0, name: sausages}
+        - name: beans`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	// extract left reference schema and non reference schema.
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("OK").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("OK").Value
+
+	// only the second example changed, the first was re-formatted.
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, v3.ExamplesLabel, changes.Changes[0].Property)
+	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, "name: beans\n", changes.Changes[0].NewEncoded)
+}
+
 func TestCompareSchemas_ExamplesAdd(t *testing.T) {
 	// Clear hash cache to ensure deterministic results in concurrent test environments
 	low.ClearHashCache()
@@ -3614,9 +3762,9 @@ components:
 func TestCompareSchemas_fireNilCheck(t *testing.T) {
 	// Clear hash cache to ensure deterministic results in concurrent test environments
 	low.ClearHashCache()
-	checkSchemaXML(nil, nil, nil, nil)
-	checkSchemaPropertyChanges(nil, nil, nil, nil, nil, nil)
-	checkExamples(nil, nil, nil)
+	checkSchemaXML(context.Background(), nil, nil, nil, nil)
+	checkSchemaPropertyChanges(context.Background(), nil, nil, nil, nil, nil, nil)
+	checkExamples(context.Background(), nil, nil, nil)
 }
 
 func TestCompareSchemas_TestProps(t *testing.T) {
@@ -4736,6 +4884,81 @@ components:
 	assert.Equal(t, "$dynamicAnchor", changes.Changes[0].Property)
 }
 
+func TestCompareSchemas_Anchor_Modified(t *testing.T) {
+	low.ClearHashCache()
+
+	left := `openapi: "3.1.0"
+components:
+  schemas:
+    TreeNode:
+      type: object
+      $anchor: nodeOld`
+
+	right := `openapi: "3.1.0"
+components:
+  schemas:
+    TreeNode:
+      type: object
+      $anchor: nodeNew`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("TreeNode").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("TreeNode").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 1, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges(), "Modifying $anchor should be breaking by default")
+	assert.Equal(t, Modified, changes.Changes[0].ChangeType)
+	assert.Equal(t, "$anchor", changes.Changes[0].Property)
+}
+
+func TestCompareSchemas_MinMaxContains(t *testing.T) {
+	low.ClearHashCache()
+
+	left := `openapi: "3.1.0"
+components:
+  schemas:
+    Tags:
+      type: array
+      contains:
+        type: string
+      minContains: 1
+      maxContains: 5`
+
+	right := `openapi: "3.1.0"
+components:
+  schemas:
+    Tags:
+      type: array
+      contains:
+        type: string
+      minContains: 2`
+
+	leftDoc, rightDoc := test_BuildDoc(left, right)
+
+	lSchemaProxy := leftDoc.Components.Value.FindSchema("Tags").Value
+	rSchemaProxy := rightDoc.Components.Value.FindSchema("Tags").Value
+
+	changes := CompareSchemas(lSchemaProxy, rSchemaProxy)
+	assert.NotNil(t, changes)
+	assert.Equal(t, 2, changes.TotalChanges())
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+	for _, c := range changes.Changes {
+		switch c.Property {
+		case v3.MinContainsLabel:
+			assert.Equal(t, Modified, c.ChangeType)
+			assert.True(t, c.Breaking)
+		case v3.MaxContainsLabel:
+			assert.Equal(t, PropertyRemoved, c.ChangeType)
+			assert.False(t, c.Breaking)
+		default:
+			t.Fatalf("unexpected change to %s", c.Property)
+		}
+	}
+}
+
 // TestCompareSchemas_DynamicAnchor_Removed tests removing $dynamicAnchor
 func TestCompareSchemas_DynamicAnchor_Removed(t *testing.T) {
 	low.ClearHashCache()
@@ -5764,7 +5987,7 @@ components:
 
 // TestCheckVocabularyChanges_BothNil tests the checkVocabularyChanges helper with both nil
 func TestCheckVocabularyChanges_BothNil(t *testing.T) {
-	changes := checkVocabularyChanges(nil, nil)
+	changes := checkVocabularyChanges(context.Background(), nil, nil)
 	assert.Nil(t, changes)
 }
 
@@ -5913,3 +6136,43 @@ components:
 	// TotalBreakingChanges should count the vocabulary change
 	assert.Equal(t, 1, changes.TotalBreakingChanges())
 }
+
+func TestCompareSchemas_RefRepointed_BreakingRule(t *testing.T) {
+	ResetActiveBreakingRulesConfig()
+	defer ResetActiveBreakingRulesConfig()
+	config := new(BreakingRulesConfig)
+	config.Merge(GenerateDefaultBreakingRules())
+	config.Merge(&BreakingRulesConfig{
+		Schema: &SchemaRules{Ref: &BreakingChangeRule{Modified: boolPtr(true)}},
+	})
+	SetActiveBreakingRulesConfig(config)
+
+	compare := func(target string) *SchemaChanges {
+		low.ClearHashCache()
+		spec := `openapi: 3.0
+components:
+  schemas:
+    Woah:
+      type: int
+    Same:
+      type: int
+    Different:
+      type: string
+    OK:
+      $ref: '#/components/schemas/%s'`
+		leftDoc, rightDoc := test_BuildDoc(fmt.Sprintf(spec, "Woah"), fmt.Sprintf(spec, target))
+		return CompareSchemas(leftDoc.Components.Value.FindSchema("OK").Value,
+			rightDoc.Components.Value.FindSchema("OK").Value)
+	}
+
+	// repointing a reference to a different schema follows the rules, an identical schema is never breaking.
+	different := compare("Different")
+	assert.NotNil(t, different)
+	assert.True(t, different.Changes[0].Breaking)
+	assert.False(t, different.Changes[0].ResolvedEqual)
+
+	same := compare("Same")
+	assert.NotNil(t, same)
+	assert.False(t, same.Changes[0].Breaking)
+	assert.True(t, same.Changes[0].ResolvedEqual)
+}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	v2 "github.com/pb33f/libopenapi/datamodel/low/v2"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -48,6 +50,10 @@ func (s *ScopesChanges) TotalBreakingChanges() int {
 // CompareScopes compares a left and right Swagger Scopes objects for changes. If anything is found, returns
 // a pointer to ScopesChanges, or returns nil if nothing is found.
 func CompareScopes(l, r *v2.Scopes) *ScopesChanges {
+	return compareScopes(context.Background(), l, r)
+}
+
+func compareScopes(ctx context.Context, l, r *v2.Scopes) *ScopesChanges {
 	if low.AreEqual(l, r) {
 		return nil
 	}
@@ -55,14 +61,14 @@ func CompareScopes(l, r *v2.Scopes) *ScopesChanges {
 	for k, v := range l.Values.FromOldest() {
 		if r != nil && r.FindScope(k.Value) == nil {
 			CreateChange(&changes, ObjectRemoved, v3.Scopes,
-				v.ValueNode, nil, BreakingRemoved(CompOAuthFlow, PropScopes),
+				v.ValueNode, nil, breakingRemoved(ctx, CompOAuthFlow, PropScopes),
 				k.Value, nil)
 			continue
 		}
 		if r != nil && r.FindScope(k.Value) != nil {
 			if v.Value != r.FindScope(k.Value).Value {
 				CreateChange(&changes, Modified, v3.Scopes,
-					v.ValueNode, r.FindScope(k.Value).ValueNode, BreakingModified(CompOAuthFlow, PropScopes),
+					v.ValueNode, r.FindScope(k.Value).ValueNode, breakingModified(ctx, CompOAuthFlow, PropScopes),
 					v.Value, r.FindScope(k.Value).Value)
 			}
 		}
@@ -70,13 +76,14 @@ func CompareScopes(l, r *v2.Scopes) *ScopesChanges {
 	for k, v := range r.Values.FromOldest() {
 		if l != nil && l.FindScope(k.Value) == nil {
 			CreateChange(&changes, ObjectAdded, v3.Scopes,
-				nil, v.ValueNode, BreakingAdded(CompOAuthFlow, PropScopes),
+				nil, v.ValueNode, breakingAdded(ctx, CompOAuthFlow, PropScopes),
 				nil, k.Value)
 		}
 	}
 
+	checkComparators(l, r, &changes)
 	sc := new(ScopesChanges)
-	sc.PropertyChanges = NewPropertyChanges(changes)
-	sc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
+	sc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 	return sc
 }
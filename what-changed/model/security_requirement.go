@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/orderedmap"
@@ -39,22 +41,27 @@ func (s *SecurityRequirementChanges) TotalBreakingChanges() int {
 // CompareSecurityRequirement compares left and right SecurityRequirement objects for changes. If anything
 // is found, then a pointer to SecurityRequirementChanges is returned, otherwise nil.
 func CompareSecurityRequirement(l, r *base.SecurityRequirement) *SecurityRequirementChanges {
+	return compareSecurityRequirement(context.Background(), l, r)
+}
+
+func compareSecurityRequirement(ctx context.Context, l, r *base.SecurityRequirement) *SecurityRequirementChanges {
 	var changes []*Change
 	sc := new(SecurityRequirementChanges)
 
 	if low.AreEqual(l, r) {
 		return nil
 	}
-	checkSecurityRequirement(l.Requirements.Value, r.Requirements.Value, &changes)
-	sc.PropertyChanges = NewPropertyChanges(changes)
+	checkSecurityRequirement(ctx, l.Requirements.Value, r.Requirements.Value, &changes)
+	checkComparators(l, r, &changes)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return sc
 }
 
-func removedSecurityRequirement(vn *yaml.Node, schemeName, scopeName string, changes *[]*Change) {
+func removedSecurityRequirement(ctx context.Context, vn *yaml.Node, schemeName, scopeName string, changes *[]*Change) {
 	property := schemeName
 	value := scopeName
 	var node *yaml.Node = vn
-	breaking := BreakingRemoved(CompSecurityRequirement, PropSchemes)
+	breaking := breakingRemoved(ctx, CompSecurityRequirement, PropSchemes)
 	if scopeName == "" {
 		// entire scheme was removed, use scheme name as value
 		value = schemeName
@@ -62,17 +69,17 @@ func removedSecurityRequirement(vn *yaml.Node, schemeName, scopeName string, cha
 		node = nil
 	} else {
 		// scope was removed
-		breaking = BreakingRemoved(CompSecurityRequirement, PropScopes)
+		breaking = breakingRemoved(ctx, CompSecurityRequirement, PropScopes)
 	}
 	CreateChange(changes, ObjectRemoved, property,
 		node, nil, breaking, value, nil)
 }
 
-func addedSecurityRequirement(vn *yaml.Node, schemeName, scopeName string, changes *[]*Change) {
+func addedSecurityRequirement(ctx context.Context, vn *yaml.Node, schemeName, scopeName string, changes *[]*Change) {
 	property := schemeName
 	value := scopeName
 	var node *yaml.Node = vn
-	breaking := BreakingAdded(CompSecurityRequirement, PropSchemes)
+	breaking := breakingAdded(ctx, CompSecurityRequirement, PropSchemes)
 	if scopeName == "" {
 		// entire scheme was added, use scheme name as value
 		value = schemeName
@@ -80,14 +87,14 @@ func addedSecurityRequirement(vn *yaml.Node, schemeName, scopeName string, chang
 		node = nil
 	} else {
 		// scope was added
-		breaking = BreakingAdded(CompSecurityRequirement, PropScopes)
+		breaking = breakingAdded(ctx, CompSecurityRequirement, PropScopes)
 	}
 	CreateChange(changes, ObjectAdded, property,
 		nil, node, breaking, nil, value)
 }
 
 // tricky to do this correctly, this is my solution.
-func checkSecurityRequirement(lSec, rSec *orderedmap.Map[low.KeyReference[string], low.ValueReference[[]low.ValueReference[string]]],
+func checkSecurityRequirement(ctx context.Context, lSec, rSec *orderedmap.Map[low.KeyReference[string], low.ValueReference[[]low.ValueReference[string]]],
 	changes *[]*Change,
 ) {
 	lKeys := make([]string, orderedmap.Len(lSec))
@@ -109,7 +116,7 @@ func checkSecurityRequirement(lSec, rSec *orderedmap.Map[low.KeyReference[string
 	for z = range lKeys {
 		if z < len(rKeys) {
 			if _, ok := rValues[lKeys[z]]; !ok {
-				removedSecurityRequirement(lValues[lKeys[z]].ValueNode, lKeys[z], "", changes)
+				removedSecurityRequirement(ctx, lValues[lKeys[z]].ValueNode, lKeys[z], "", changes)
 				continue
 			}
 
@@ -145,26 +152,26 @@ func checkSecurityRequirement(lSec, rSec *orderedmap.Map[low.KeyReference[string
 			for t = range lRoleKeys {
 				if t < len(rRoleKeys) {
 					if _, ok := rRoleValues[lRoleKeys[t]]; !ok {
-						removedSecurityRequirement(lRoleValues[lRoleKeys[t]].ValueNode, lKeys[z], lRoleKeys[t], changes)
+						removedSecurityRequirement(ctx, lRoleValues[lRoleKeys[t]].ValueNode, lKeys[z], lRoleKeys[t], changes)
 						continue
 					}
 				}
 				if t >= len(rRoleKeys) {
 					if _, ok := rRoleValues[lRoleKeys[t]]; !ok {
-						removedSecurityRequirement(lRoleValues[lRoleKeys[t]].ValueNode, lKeys[z], lRoleKeys[t], changes)
+						removedSecurityRequirement(ctx, lRoleValues[lRoleKeys[t]].ValueNode, lKeys[z], lRoleKeys[t], changes)
 					}
 				}
 			}
 			for t = range rRoleKeys {
 				if t < len(lRoleKeys) {
 					if _, ok := lRoleValues[rRoleKeys[t]]; !ok {
-						addedSecurityRequirement(rRoleValues[rRoleKeys[t]].ValueNode, rKeys[z], rRoleKeys[t], changes)
+						addedSecurityRequirement(ctx, rRoleValues[rRoleKeys[t]].ValueNode, rKeys[z], rRoleKeys[t], changes)
 						continue
 					}
 				}
 				if t >= len(lRoleKeys) {
 					if _, ok := lRoleValues[rRoleKeys[t]]; !ok {
-						addedSecurityRequirement(rRoleValues[rRoleKeys[t]].ValueNode, rKeys[z], rRoleKeys[t], changes)
+						addedSecurityRequirement(ctx, rRoleValues[rRoleKeys[t]].ValueNode, rKeys[z], rRoleKeys[t], changes)
 					}
 				}
 			}
@@ -172,20 +179,20 @@ func checkSecurityRequirement(lSec, rSec *orderedmap.Map[low.KeyReference[string
 		}
 		if z >= len(rKeys) {
 			if _, ok := rValues[lKeys[z]]; !ok {
-				removedSecurityRequirement(lValues[lKeys[z]].ValueNode, lKeys[z], "", changes)
+				removedSecurityRequirement(ctx, lValues[lKeys[z]].ValueNode, lKeys[z], "", changes)
 			}
 		}
 	}
 	for z = range rKeys {
 		if z < len(lKeys) {
 			if _, ok := lValues[rKeys[z]]; !ok {
-				addedSecurityRequirement(rValues[rKeys[z]].ValueNode, rKeys[z], "", changes)
+				addedSecurityRequirement(ctx, rValues[rKeys[z]].ValueNode, rKeys[z], "", changes)
 				continue
 			}
 		}
 		if z >= len(lKeys) {
 			if _, ok := lValues[rKeys[z]]; !ok {
-				addedSecurityRequirement(rValues[rKeys[z]].ValueNode, rKeys[z], "", changes)
+				addedSecurityRequirement(ctx, rValues[rKeys[z]].ValueNode, rKeys[z], "", changes)
 			}
 		}
 	}
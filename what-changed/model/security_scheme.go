@@ -4,6 +4,7 @@
 package model
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/pb33f/libopenapi/datamodel/low"
@@ -74,17 +75,29 @@ func (ss *SecuritySchemeChanges) TotalBreakingChanges() int {
 
 // CompareSecuritySchemesV2 is a Swagger type safe proxy for CompareSecuritySchemes
 func CompareSecuritySchemesV2(l, r *v2.SecurityScheme) *SecuritySchemeChanges {
-	return CompareSecuritySchemes(l, r)
+	return compareSecuritySchemesV2(context.Background(), l, r)
+}
+
+func compareSecuritySchemesV2(ctx context.Context, l, r *v2.SecurityScheme) *SecuritySchemeChanges {
+	return compareSecuritySchemes(ctx, l, r)
 }
 
 // CompareSecuritySchemesV3 is an OpenAPI type safe proxt for CompareSecuritySchemes
 func CompareSecuritySchemesV3(l, r *v3.SecurityScheme) *SecuritySchemeChanges {
-	return CompareSecuritySchemes(l, r)
+	return compareSecuritySchemesV3(context.Background(), l, r)
+}
+
+func compareSecuritySchemesV3(ctx context.Context, l, r *v3.SecurityScheme) *SecuritySchemeChanges {
+	return compareSecuritySchemes(ctx, l, r)
 }
 
 // CompareSecuritySchemes compares left and right Swagger or OpenAPI Security Scheme objects for changes.
 // If anything is found, returns a pointer to *SecuritySchemeChanges or nil if nothing is found.
 func CompareSecuritySchemes(l, r any) *SecuritySchemeChanges {
+	return compareSecuritySchemes(context.Background(), l, r)
+}
+
+func compareSecuritySchemes(ctx context.Context, l, r any) *SecuritySchemeChanges {
 	var props []*PropertyCheck
 	var changes []*Change
 
@@ -121,19 +134,19 @@ func CompareSecuritySchemes(l, r any) *SecuritySchemeChanges {
 
 		if !lSS.Scopes.IsEmpty() && !rSS.Scopes.IsEmpty() {
 			if !low.AreEqual(lSS.Scopes.Value, rSS.Scopes.Value) {
-				sc.ScopesChanges = CompareScopes(lSS.Scopes.Value, rSS.Scopes.Value)
+				sc.ScopesChanges = compareScopes(ctx, lSS.Scopes.Value, rSS.Scopes.Value)
 			}
 		}
 		if lSS.Scopes.IsEmpty() && !rSS.Scopes.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.ScopesLabel,
-				nil, rSS.Scopes.ValueNode, BreakingAdded(CompSecurityScheme, PropScopes), nil, rSS.Scopes.Value)
+				nil, rSS.Scopes.ValueNode, breakingAdded(ctx, CompSecurityScheme, PropScopes), nil, rSS.Scopes.Value)
 		}
 		if !lSS.Scopes.IsEmpty() && rSS.Scopes.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.ScopesLabel,
-				lSS.Scopes.ValueNode, nil, BreakingRemoved(CompSecurityScheme, PropScopes), lSS.Scopes.Value, nil)
+				lSS.Scopes.ValueNode, nil, breakingRemoved(ctx, CompSecurityScheme, PropScopes), lSS.Scopes.Value, nil)
 		}
 
-		sc.ExtensionChanges = CompareExtensions(lSS.Extensions, rSS.Extensions)
+		sc.ExtensionChanges = compareExtensions(ctx, lSS.Extensions, rSS.Extensions)
 	}
 
 	if reflect.TypeOf(&v3.SecurityScheme{}) == reflect.TypeOf(l) &&
@@ -147,57 +160,58 @@ func CompareSecuritySchemes(l, r any) *SecuritySchemeChanges {
 		}
 		addPropertyCheck(&props, lSS.Type.ValueNode, rSS.Type.ValueNode,
 			lSS.Type.Value, rSS.Type.Value, &changes, v3.TypeLabel,
-			BreakingModified(CompSecurityScheme, PropType), CompSecurityScheme, PropType)
+			breakingModified(ctx, CompSecurityScheme, PropType), CompSecurityScheme, PropType)
 
 		addPropertyCheck(&props, lSS.Description.ValueNode, rSS.Description.ValueNode,
 			lSS.Description.Value, rSS.Description.Value, &changes, v3.DescriptionLabel,
-			BreakingModified(CompSecurityScheme, PropDescription), CompSecurityScheme, PropDescription)
+			breakingModified(ctx, CompSecurityScheme, PropDescription), CompSecurityScheme, PropDescription)
 
 		addPropertyCheck(&props, lSS.Name.ValueNode, rSS.Name.ValueNode,
 			lSS.Name.Value, rSS.Name.Value, &changes, v3.NameLabel,
-			BreakingModified(CompSecurityScheme, PropName), CompSecurityScheme, PropName)
+			breakingModified(ctx, CompSecurityScheme, PropName), CompSecurityScheme, PropName)
 
 		addPropertyCheck(&props, lSS.In.ValueNode, rSS.In.ValueNode,
 			lSS.In.Value, rSS.In.Value, &changes, v3.InLabel,
-			BreakingModified(CompSecurityScheme, PropIn), CompSecurityScheme, PropIn)
+			breakingModified(ctx, CompSecurityScheme, PropIn), CompSecurityScheme, PropIn)
 
 		addPropertyCheck(&props, lSS.Scheme.ValueNode, rSS.Scheme.ValueNode,
 			lSS.Scheme.Value, rSS.Scheme.Value, &changes, v3.SchemeLabel,
-			BreakingModified(CompSecurityScheme, PropScheme), CompSecurityScheme, PropScheme)
+			breakingModified(ctx, CompSecurityScheme, PropScheme), CompSecurityScheme, PropScheme)
 
 		addPropertyCheck(&props, lSS.BearerFormat.ValueNode, rSS.BearerFormat.ValueNode,
 			lSS.BearerFormat.Value, rSS.BearerFormat.Value, &changes, v3.SchemeLabel,
-			BreakingModified(CompSecurityScheme, PropBearerFormat), CompSecurityScheme, PropBearerFormat)
+			breakingModified(ctx, CompSecurityScheme, PropBearerFormat), CompSecurityScheme, PropBearerFormat)
 
 		addPropertyCheck(&props, lSS.OpenIdConnectUrl.ValueNode, rSS.OpenIdConnectUrl.ValueNode,
 			lSS.OpenIdConnectUrl.Value, rSS.OpenIdConnectUrl.Value, &changes, v3.OpenIdConnectUrlLabel,
-			BreakingModified(CompSecurityScheme, PropOpenIDConnectURL), CompSecurityScheme, PropOpenIDConnectURL)
+			breakingModified(ctx, CompSecurityScheme, PropOpenIDConnectURL), CompSecurityScheme, PropOpenIDConnectURL)
 
 		// OpenAPI 3.2+ fields
 		addPropertyCheck(&props, lSS.OAuth2MetadataUrl.ValueNode, rSS.OAuth2MetadataUrl.ValueNode,
 			lSS.OAuth2MetadataUrl.Value, rSS.OAuth2MetadataUrl.Value, &changes, v3.OAuth2MetadataUrlLabel,
-			BreakingModified(CompSecurityScheme, PropOAuth2MetadataUrl), CompSecurityScheme, PropOAuth2MetadataUrl)
+			breakingModified(ctx, CompSecurityScheme, PropOAuth2MetadataUrl), CompSecurityScheme, PropOAuth2MetadataUrl)
 
 		addPropertyCheck(&props, lSS.Deprecated.ValueNode, rSS.Deprecated.ValueNode,
 			lSS.Deprecated.Value, rSS.Deprecated.Value, &changes, v3.DeprecatedLabel,
-			BreakingModified(CompSecurityScheme, PropDeprecated), CompSecurityScheme, PropDeprecated)
+			breakingModified(ctx, CompSecurityScheme, PropDeprecated), CompSecurityScheme, PropDeprecated)
 
 		if !lSS.Flows.IsEmpty() && !rSS.Flows.IsEmpty() {
 			if !low.AreEqual(lSS.Flows.Value, rSS.Flows.Value) {
-				sc.OAuthFlowChanges = CompareOAuthFlows(lSS.Flows.Value, rSS.Flows.Value)
+				sc.OAuthFlowChanges = compareOAuthFlows(ctx, lSS.Flows.Value, rSS.Flows.Value)
 			}
 		}
 		if lSS.Flows.IsEmpty() && !rSS.Flows.IsEmpty() {
 			CreateChange(&changes, ObjectAdded, v3.FlowsLabel,
-				nil, rSS.Flows.ValueNode, BreakingAdded(CompSecurityScheme, PropFlows), nil, rSS.Flows.Value)
+				nil, rSS.Flows.ValueNode, breakingAdded(ctx, CompSecurityScheme, PropFlows), nil, rSS.Flows.Value)
 		}
 		if !lSS.Flows.IsEmpty() && rSS.Flows.IsEmpty() {
 			CreateChange(&changes, ObjectRemoved, v3.FlowsLabel,
-				lSS.Flows.ValueNode, nil, BreakingRemoved(CompSecurityScheme, PropFlows), lSS.Flows.Value, nil)
+				lSS.Flows.ValueNode, nil, breakingRemoved(ctx, CompSecurityScheme, PropFlows), lSS.Flows.Value, nil)
 		}
-		sc.ExtensionChanges = CompareExtensions(lSS.Extensions, rSS.Extensions)
+		sc.ExtensionChanges = compareExtensions(ctx, lSS.Extensions, rSS.Extensions)
 	}
-	CheckProperties(props)
-	sc.PropertyChanges = NewPropertyChanges(changes)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return sc
 }
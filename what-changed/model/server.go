@@ -4,7 +4,8 @@
 package model
 
 import (
-	"github.com/pb33f/libopenapi/datamodel/low"
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
 
@@ -59,7 +60,11 @@ func (s *ServerChanges) TotalBreakingChanges() int {
 // CompareServers compares two OpenAPI Server objects for any changes. If anything is found, returns a pointer
 // to a ServerChanges instance, or returns nil if nothing is found.
 func CompareServers(l, r *v3.Server) *ServerChanges {
-	if low.AreEqual(l, r) {
+	return compareServers(context.Background(), l, r)
+}
+
+func compareServers(ctx context.Context, l, r *v3.Server) *ServerChanges {
+	if areEqual(ctx, l, r) {
 		return nil
 	}
 	var changes []*Change
@@ -77,13 +82,14 @@ func CompareServers(l, r *v3.Server) *ServerChanges {
 			v3.DescriptionLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
 	sc := new(ServerChanges)
-	sc.PropertyChanges = NewPropertyChanges(changes)
-	sc.ServerVariableChanges = CheckMapForChanges(l.Variables.Value, r.Variables.Value,
-		&changes, v3.VariablesLabel, CompareServerVariables)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
+	sc.ServerVariableChanges = checkMapForChanges(ctx, l.Variables.Value, r.Variables.Value,
+		&changes, v3.VariablesLabel, compareServerVariables)
 
-	sc.ExtensionChanges = CompareExtensions(l.Extensions, r.Extensions)
+	sc.ExtensionChanges = compareExtensions(ctx, l.Extensions, r.Extensions)
 	sc.Server = r
 	return sc
 }
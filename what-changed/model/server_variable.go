@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -24,7 +26,11 @@ func (s *ServerVariableChanges) GetAllChanges() []*Change {
 // CompareServerVariables compares a left and right OpenAPI ServerVariable object for changes.
 // If anything is found, returns a pointer to a ServerVariableChanges instance, otherwise returns nil.
 func CompareServerVariables(l, r *v3.ServerVariable) *ServerVariableChanges {
-	if low.AreEqual(l, r) {
+	return compareServerVariables(context.Background(), l, r)
+}
+
+func compareServerVariables(ctx context.Context, l, r *v3.ServerVariable) *ServerVariableChanges {
+	if areEqual(ctx, l, r) {
 		return nil
 	}
 
@@ -41,7 +47,7 @@ func CompareServerVariables(l, r *v3.ServerVariable) *ServerVariableChanges {
 	for k := range lValues {
 		if _, ok := rValues[k]; !ok {
 			CreateChange(&changes, ObjectRemoved, v3.EnumLabel,
-				lValues[k].ValueNode, nil, BreakingRemoved(CompServerVariable, PropEnum),
+				lValues[k].ValueNode, nil, breakingRemoved(ctx, CompServerVariable, PropEnum),
 				lValues[k].Value, nil)
 			continue
 		}
@@ -49,10 +55,22 @@ func CompareServerVariables(l, r *v3.ServerVariable) *ServerVariableChanges {
 	for k := range rValues {
 		if _, ok := lValues[k]; !ok {
 			CreateChange(&changes, ObjectAdded, v3.EnumLabel,
-				lValues[k].ValueNode, rValues[k].ValueNode, BreakingAdded(CompServerVariable, PropEnum),
+				lValues[k].ValueNode, rValues[k].ValueNode, breakingAdded(ctx, CompServerVariable, PropEnum),
 				lValues[k].Value, rValues[k].Value)
 		}
 	}
+	if orderedArrays(ctx).Enums {
+		lKeys := make([]string, len(l.Enum))
+		for i := range l.Enum {
+			lKeys[i] = l.Enum[i].Value
+		}
+		rKeys := make([]string, len(r.Enum))
+		for i := range r.Enum {
+			rKeys[i] = r.Enum[i].Value
+		}
+		checkReordered(lKeys, rKeys, v3.EnumLabel, l.RootNode, r.RootNode,
+			breakingModified(ctx, CompServerVariable, PropEnum), &changes)
+	}
 
 	props := make([]*PropertyCheck, 0, 2)
 	props = append(props,
@@ -64,8 +82,9 @@ func CompareServerVariables(l, r *v3.ServerVariable) *ServerVariableChanges {
 			v3.DescriptionLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
+	checkComparators(l, r, &changes)
 	sc := new(ServerVariableChanges)
-	sc.PropertyChanges = NewPropertyChanges(changes)
+	sc.PropertyChanges = newPropertyChanges(ctx, changes)
 	return sc
 }
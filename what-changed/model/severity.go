@@ -0,0 +1,179 @@
+// Copyright 2022-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is how much a change matters to consumers of a contract. Severities are ordered, from SeverityInfo
+// (the least severe) to SeverityCritical (the most severe), so they can be compared using < and >.
+type Severity int
+
+// Definitions of the severities a change can have.
+const (
+	// SeverityInfo means the change is informational only, for example a description was modified.
+	SeverityInfo Severity = iota + 1
+
+	// SeverityWarning means the change is not breaking, but consumers should know about it.
+	SeverityWarning
+
+	// SeverityError means the change is breaking.
+	SeverityError
+
+	// SeverityCritical means the change is breaking, and something consumers rely on has gone.
+	SeverityCritical
+)
+
+// String returns the name of the severity: info, warn, error or critical.
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warn"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	}
+	return ""
+}
+
+// MarshalText renders the severity using its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText reads a severity from its name (see ParseSeverity).
+func (s *Severity) UnmarshalText(text []byte) error {
+	severity, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = severity
+	return nil
+}
+
+// ParseSeverity returns the Severity with the supplied name (info, warn, error or critical). 'warning' is accepted
+// as well as 'warn', and case is ignored.
+func ParseSeverity(name string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "info":
+		return SeverityInfo, nil
+	case "warn", "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "critical":
+		return SeverityCritical, nil
+	}
+	return 0, fmt.Errorf("unknown severity '%s', expected one of info, warn, error or critical", name)
+}
+
+// SeverityMapping determines the severity of a change, from the type of change and if it's breaking or not.
+type SeverityMapping struct {
+	// Breaking maps each type of change (Modified, PropertyAdded, etc.) to a severity, when the change is breaking.
+	// Breaking changes with a type that is not mapped are SeverityError.
+	Breaking map[int]Severity `json:"breaking,omitempty" yaml:"breaking,omitempty"`
+
+	// NonBreaking maps each type of change to a severity, when the change is not breaking. Non-breaking changes
+	// with a type that is not mapped are SeverityInfo.
+	NonBreaking map[int]Severity `json:"nonBreaking,omitempty" yaml:"nonBreaking,omitempty"`
+
+	// Properties overrides the severity of every change made to a property with the same name, for example
+	// 'description' or 'operationId', regardless of the type of change or if it's breaking.
+	Properties map[string]Severity `json:"properties,omitempty" yaml:"properties,omitempty"`
+}
+
+// DefaultSeverityMapping returns the default severity mapping. Breaking removals are critical, all other breaking
+// changes are errors. Non-breaking removals and renames are warnings, and everything else is informational.
+func DefaultSeverityMapping() *SeverityMapping {
+	return &SeverityMapping{
+		Breaking: map[int]Severity{
+			Modified:           SeverityError,
+			PropertyAdded:      SeverityError,
+			ObjectAdded:        SeverityError,
+			PropertyRemoved:    SeverityCritical,
+			ObjectRemoved:      SeverityCritical,
+			ObjectRenamed:      SeverityError,
+			ReferenceRepointed: SeverityError,
+			Reordered:          SeverityError,
+		},
+		NonBreaking: map[int]Severity{
+			Modified:           SeverityInfo,
+			PropertyAdded:      SeverityInfo,
+			ObjectAdded:        SeverityInfo,
+			PropertyRemoved:    SeverityWarning,
+			ObjectRemoved:      SeverityWarning,
+			ObjectRenamed:      SeverityWarning,
+			ReferenceRepointed: SeverityInfo,
+			Reordered:          SeverityInfo,
+		},
+	}
+}
+
+var defaultSeverityMapping = DefaultSeverityMapping()
+
+// SeverityOf returns the severity of the change, according to the mapping.
+func (m *SeverityMapping) SeverityOf(change *Change) Severity {
+	if change == nil {
+		return 0
+	}
+	if m == nil {
+		m = defaultSeverityMapping
+	}
+	if severity, ok := m.Properties[change.Property]; ok {
+		return severity
+	}
+	if change.Breaking {
+		if severity, ok := m.Breaking[change.ChangeType]; ok {
+			return severity
+		}
+		return SeverityError
+	}
+	if severity, ok := m.NonBreaking[change.ChangeType]; ok {
+		return severity
+	}
+	return SeverityInfo
+}
+
+// ApplySeverityMapping sets the severity of every change, using the supplied mapping. If the mapping is nil, the
+// default mapping is used.
+func ApplySeverityMapping(changes []*Change, mapping *SeverityMapping) {
+	for _, c := range changes {
+		if c != nil {
+			c.Severity = mapping.SeverityOf(c)
+		}
+	}
+}
+
+// ChangeFilter decides if a change should be kept (true) or dropped (false).
+type ChangeFilter func(change *Change) bool
+
+// OnlyBreaking returns a ChangeFilter that keeps breaking changes only.
+func OnlyBreaking() ChangeFilter {
+	return func(change *Change) bool {
+		return change != nil && change.Breaking
+	}
+}
+
+// AtLeast returns a ChangeFilter that keeps changes with the supplied severity, or a higher one.
+func AtLeast(severity Severity) ChangeFilter {
+	return func(change *Change) bool {
+		return change != nil && change.GetSeverity() >= severity
+	}
+}
+
+// FilterChanges returns the changes kept by the filter, in the same order.
+func FilterChanges(changes []*Change, filter ChangeFilter) []*Change {
+	var kept []*Change
+	for _, c := range changes {
+		if filter(c) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
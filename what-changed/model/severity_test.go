@@ -0,0 +1,97 @@
+// Copyright 2022-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestSeverity_String(t *testing.T) {
+	assert.Equal(t, "info", SeverityInfo.String())
+	assert.Equal(t, "warn", SeverityWarning.String())
+	assert.Equal(t, "error", SeverityError.String())
+	assert.Equal(t, "critical", SeverityCritical.String())
+	assert.Empty(t, Severity(0).String())
+	assert.True(t, SeverityInfo < SeverityWarning && SeverityWarning < SeverityError && SeverityError < SeverityCritical)
+}
+
+func TestParseSeverity(t *testing.T) {
+	for name, expected := range map[string]Severity{
+		"info": SeverityInfo, "warn": SeverityWarning, "Warning": SeverityWarning,
+		"ERROR": SeverityError, " critical ": SeverityCritical,
+	} {
+		s, err := ParseSeverity(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, expected, s, name)
+	}
+	_, err := ParseSeverity("fatal")
+	assert.EqualError(t, err, "unknown severity 'fatal', expected one of info, warn, error or critical")
+}
+
+func TestSeverityMapping_YAML(t *testing.T) {
+	var mapping SeverityMapping
+	require.NoError(t, yaml.Unmarshal([]byte(`breaking:
+  1: critical
+nonBreaking:
+  2: warn
+properties:
+  description: error`), &mapping))
+	assert.Equal(t, SeverityCritical, mapping.Breaking[Modified])
+	assert.Equal(t, SeverityWarning, mapping.NonBreaking[PropertyAdded])
+	assert.Equal(t, SeverityError, mapping.Properties["description"])
+
+	assert.Error(t, yaml.Unmarshal([]byte(`properties: {description: fatal}`), &mapping))
+}
+
+func TestSeverityMapping_SeverityOf(t *testing.T) {
+	var defaults *SeverityMapping
+	assert.Equal(t, SeverityCritical, defaults.SeverityOf(&Change{ChangeType: ObjectRemoved, Breaking: true}))
+	assert.Equal(t, SeverityError, defaults.SeverityOf(&Change{ChangeType: Modified, Breaking: true}))
+	assert.Equal(t, SeverityWarning, defaults.SeverityOf(&Change{ChangeType: PropertyRemoved}))
+	assert.Equal(t, SeverityInfo, defaults.SeverityOf(&Change{ChangeType: PropertyAdded}))
+	assert.Equal(t, Severity(0), defaults.SeverityOf(nil))
+
+	mapping := &SeverityMapping{
+		Breaking:   map[int]Severity{Modified: SeverityCritical},
+		Properties: map[string]Severity{"description": SeverityError},
+	}
+	assert.Equal(t, SeverityCritical, mapping.SeverityOf(&Change{ChangeType: Modified, Breaking: true}))
+	assert.Equal(t, SeverityError, mapping.SeverityOf(&Change{ChangeType: ObjectRemoved, Breaking: true}))
+	assert.Equal(t, SeverityInfo, mapping.SeverityOf(&Change{ChangeType: ObjectRemoved}))
+	assert.Equal(t, SeverityError, mapping.SeverityOf(&Change{ChangeType: Modified, Property: "description"}))
+}
+
+func TestChange_GetSeverity(t *testing.T) {
+	c := &Change{ChangeType: PropertyRemoved, Property: "description"}
+	assert.Equal(t, SeverityWarning, c.GetSeverity())
+
+	ApplySeverityMapping([]*Change{c, nil}, &SeverityMapping{Properties: map[string]Severity{"description": SeverityInfo}})
+	assert.Equal(t, SeverityInfo, c.Severity)
+	assert.Equal(t, SeverityInfo, c.GetSeverity())
+
+	b, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"severity":"info"`)
+}
+
+func TestChangeFilters(t *testing.T) {
+	changes := []*Change{
+		{ChangeType: Modified, Property: "description"},
+		{ChangeType: PropertyRemoved, Property: "example"},
+		{ChangeType: Modified, Property: "type", Breaking: true},
+		{ChangeType: ObjectRemoved, Property: "get", Breaking: true},
+	}
+	assert.Equal(t, changes[2:], FilterChanges(changes, OnlyBreaking()))
+	assert.Equal(t, changes[1:], FilterChanges(changes, AtLeast(SeverityWarning)))
+	assert.Equal(t, changes[3:], FilterChanges(changes, AtLeast(SeverityCritical)))
+	assert.Len(t, FilterChanges(changes, AtLeast(SeverityInfo)), 4)
+	assert.Empty(t, FilterChanges(nil, OnlyBreaking()))
+	assert.False(t, OnlyBreaking()(nil))
+	assert.False(t, AtLeast(SeverityInfo)(nil))
+}
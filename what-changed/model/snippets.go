@@ -0,0 +1,118 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"strings"
+
+	"github.com/pb33f/libopenapi/index"
+)
+
+// DefaultSnippetContextLines is the number of lines shown above and below the line of a change in a snippet, when
+// snippets are attached by a comparison that does not set a number of its own.
+const DefaultSnippetContextLines = 3
+
+// Snippet is an excerpt of the YAML (or JSON) of a document, around the line of a change, so the change can be
+// shown in context without loading the document again.
+type Snippet struct {
+	// StartLine is the line number of the first line of the snippet.
+	StartLine int `json:"startLine" yaml:"startLine"`
+
+	// Line is the line number of the change.
+	Line int `json:"line" yaml:"line"`
+
+	// Lines are the lines of the snippet, starting at StartLine.
+	Lines []string `json:"lines" yaml:"lines"`
+}
+
+// String returns the lines of the snippet, joined by line breaks.
+func (s *Snippet) String() string {
+	if s == nil {
+		return ""
+	}
+	return strings.Join(s.Lines, "\n")
+}
+
+// SnippetSource returns the content of a document that was compared, given the location of the document recorded
+// by a change (see ChangeContext.DocumentLocation), which is empty for the root document. It returns nil if the
+// document is not known.
+type SnippetSource func(location string) []byte
+
+// IndexSnippetSource returns a SnippetSource that reads the root document of an index, and any other documents
+// that were loaded by its rolodex. The index of a low-level document is returned by its GetIndex() method.
+func IndexSnippetSource(idx *index.SpecIndex) SnippetSource {
+	return func(location string) []byte {
+		if idx == nil {
+			return nil
+		}
+		if location == "" || location == idx.GetSpecAbsolutePath() {
+			if cfg := idx.GetConfig(); cfg != nil && cfg.SpecInfo != nil && cfg.SpecInfo.SpecBytes != nil {
+				return *cfg.SpecInfo.SpecBytes
+			}
+			return nil
+		}
+		if r := idx.GetRolodex(); r != nil {
+			if f, err := r.Open(location); err == nil && f != nil {
+				return []byte(f.GetContent())
+			}
+		}
+		return nil
+	}
+}
+
+// AttachSnippets attaches a snippet of the original document (OriginalSnippet) and the new document (NewSnippet)
+// to every change that has a line number, with contextLines lines above and below the line of the change (so zero
+// only includes the line of the change). Either source can be nil, in which case snippets are not attached for
+// that side.
+func AttachSnippets(changes []*Change, original, updated SnippetSource, contextLines int) {
+	contextLines = max(contextLines, 0)
+	originalLines := newSnippetLines(original)
+	updatedLines := newSnippetLines(updated)
+	for _, c := range changes {
+		if c == nil || c.Context == nil {
+			continue
+		}
+		location := c.Context.DocumentLocation
+		if c.Context.OriginalLine != nil {
+			c.OriginalSnippet = originalLines.snippet(location, *c.Context.OriginalLine, contextLines)
+		}
+		if c.Context.NewLine != nil {
+			c.NewSnippet = updatedLines.snippet(location, *c.Context.NewLine, contextLines)
+		}
+	}
+}
+
+// snippetLines splits the documents of a source into lines, once per document.
+type snippetLines struct {
+	source    SnippetSource
+	documents map[string][]string
+}
+
+func newSnippetLines(source SnippetSource) *snippetLines {
+	return &snippetLines{source: source, documents: make(map[string][]string)}
+}
+
+// snippet returns the lines of a document around a line, or nil if the document or the line is not known.
+func (s *snippetLines) snippet(location string, line, contextLines int) *Snippet {
+	if s.source == nil || line < 1 {
+		return nil
+	}
+	lines, ok := s.documents[location]
+	if !ok {
+		if content := s.source(location); content != nil {
+			lines = strings.Split(strings.TrimRight(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"), "\n")
+		}
+		s.documents[location] = lines
+	}
+	if line > len(lines) {
+		return nil
+	}
+	start := max(line-contextLines, 1)
+	end := min(line+contextLines, len(lines))
+	return &Snippet{
+		StartLine: start,
+		Line:      line,
+		Lines:     append([]string(nil), lines[start-1:end]...),
+	}
+}
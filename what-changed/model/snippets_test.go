@@ -0,0 +1,89 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachSnippets(t *testing.T) {
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	left := build(streamLeft)
+	right := build(streamRight)
+	changes := CompareDocuments(left, right)
+	require.NotNil(t, changes)
+
+	all := changes.GetAllChanges()
+	AttachSnippets(all, IndexSnippetSource(left.GetIndex()), IndexSnippetSource(right.GetIndex()), 1)
+
+	var version, removed *Change
+	for _, c := range all {
+		switch {
+		case c.Property == "version":
+			version = c
+		case c.ChangeType == ObjectRemoved:
+			removed = c
+		}
+	}
+	require.NotNil(t, version)
+	require.NotNil(t, version.OriginalSnippet)
+	require.NotNil(t, version.NewSnippet)
+	assert.Equal(t, 3, version.OriginalSnippet.StartLine)
+	assert.Equal(t, 4, version.OriginalSnippet.Line)
+	assert.Equal(t, []string{"  title: Pets", "  version: 1.0.0", "paths:"}, version.OriginalSnippet.Lines)
+	assert.Equal(t, "  title: Pets\n  version: 1.0.1\npaths:", version.NewSnippet.String())
+
+	// a parameter that was removed only has an original snippet.
+	require.NotNil(t, removed)
+	require.NotNil(t, removed.OriginalSnippet)
+	assert.Nil(t, removed.NewSnippet)
+	assert.Contains(t, removed.OriginalSnippet.String(), "- name: limit")
+
+	out, err := json.Marshal(version)
+	require.NoError(t, err)
+	assert.Contains(t, string(out), `"newSnippet":{"startLine":3,"line":4,"lines":["  title: Pets","  version: 1.0.1","paths:"]}`)
+}
+
+func TestAttachSnippets_Bounds(t *testing.T) {
+	line := func(n int) *int { return &n }
+	source := func(location string) []byte {
+		if location != "" {
+			return nil
+		}
+		return []byte("a: 1\nb: 2\nc: 3\n")
+	}
+	changes := []*Change{
+		{Context: &ChangeContext{OriginalLine: line(1), NewLine: line(3)}},
+		{Context: &ChangeContext{OriginalLine: line(9)}},
+		{Context: &ChangeContext{OriginalLine: line(2), DocumentLocation: "/other.yaml"}},
+		{},
+	}
+	AttachSnippets(changes, source, nil, 5)
+
+	assert.Equal(t, &Snippet{StartLine: 1, Line: 1, Lines: []string{"a: 1", "b: 2", "c: 3"}}, changes[0].OriginalSnippet)
+	assert.Nil(t, changes[0].NewSnippet)
+	assert.Nil(t, changes[1].OriginalSnippet)
+	assert.Nil(t, changes[2].OriginalSnippet)
+
+	AttachSnippets(changes, nil, source, -1)
+	assert.Equal(t, &Snippet{StartLine: 3, Line: 3, Lines: []string{"c: 3"}}, changes[0].NewSnippet)
+}
+
+func TestInvertChanges_Snippets(t *testing.T) {
+	c := &Change{ChangeType: Modified, OriginalSnippet: &Snippet{Line: 1}, NewSnippet: &Snippet{Line: 2}}
+	InvertChanges(&PropertyChanges{Changes: []*Change{c}})
+	assert.Equal(t, 2, c.OriginalSnippet.Line)
+	assert.Equal(t, 1, c.NewSnippet.Line)
+}
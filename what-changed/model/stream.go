@@ -0,0 +1,80 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// ChangeStream is called with every change found while comparing documents, as soon as the object that changed
+// has been compared, rather than waiting for the whole tree of changes to be built. Return false to stop the
+// comparison early, for example as soon as the first breaking change is found. Calls are never made at the same
+// time, so a stream does not need to be safe for concurrent use.
+//
+// Changes are streamed as they are classified by the breaking rules of the comparison. Anything that re-classifies
+// or replaces changes once the comparison has finished (perspectives, severities and filters) is not seen by the
+// stream.
+type ChangeStream func(change *Change) bool
+
+type changeStream struct {
+	lock    sync.Mutex
+	stream  ChangeStream
+	discard bool
+	aborted atomic.Bool
+}
+
+type changeStreamKey struct{}
+
+// WithChangeStream returns a copy of the context that sends every change found by a comparison made with it (see
+// CompareDocumentsWithContext) to the stream. When discard is true, the changes are only sent to the stream and
+// are not kept by the change models, so the memory used to compare very large documents stays small.
+//
+// Once the stream returns false, every comparison made with the context stops, so each comparison should be
+// given a context of its own.
+func WithChangeStream(ctx context.Context, stream ChangeStream, discard bool) context.Context {
+	return context.WithValue(ctx, changeStreamKey{}, &changeStream{stream: stream, discard: discard})
+}
+
+// ComparisonAborted returns true if the context of a comparison is done, or its stream (see WithChangeStream)
+// returned false, asking for the comparison to stop. Comparisons check it as they go, and skip any work that
+// remains.
+func ComparisonAborted(ctx context.Context) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	s, _ := ctx.Value(changeStreamKey{}).(*changeStream)
+	return s != nil && s.aborted.Load()
+}
+
+// newPropertyChanges sends the changes found comparing an object to the stream of the context (if there is one),
+// and returns them as PropertyChanges, unless the stream discards changes.
+func newPropertyChanges(ctx context.Context, changes []*Change) *PropertyChanges {
+	s, _ := ctx.Value(changeStreamKey{}).(*changeStream)
+	if s == nil {
+		return NewPropertyChanges(changes)
+	}
+	s.lock.Lock()
+	for _, c := range changes {
+		if s.aborted.Load() {
+			break
+		}
+		if !s.stream(c) {
+			s.aborted.Store(true)
+		}
+	}
+	s.lock.Unlock()
+	if s.discard {
+		return NewPropertyChanges(nil)
+	}
+	return NewPropertyChanges(changes)
+}
+
+// recordChange adds a change to the changes.
+func recordChange(changes *[]*Change, c *Change) {
+	changeMutex.Lock()
+	*changes = append(*changes, c)
+	changeMutex.Unlock()
+}
@@ -0,0 +1,103 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var streamLeft = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+var streamRight = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.1
+paths:
+  /pets:
+    get:
+      description: list all the pets
+      parameters:
+        - name: offset
+          in: query
+      responses:
+        "200":
+          description: OK`
+
+func streamDocuments(t *testing.T, ctx context.Context, left, right string) *DocumentChanges {
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	return CompareDocumentsWithContext(ctx, build(left), build(right))
+}
+
+func TestWithChangeStream(t *testing.T) {
+	var streamed []*Change
+	ctx := WithChangeStream(context.Background(), func(change *Change) bool {
+		streamed = append(streamed, change)
+		return true
+	}, false)
+	changes := streamDocuments(t, ctx, streamLeft, streamRight)
+
+	require.NotNil(t, changes)
+	assert.Equal(t, 3, changes.TotalChanges())
+	assert.ElementsMatch(t, changes.GetAllChanges(), streamed)
+	assert.False(t, ComparisonAborted(ctx))
+
+	// comparisons made without the stream are not streamed.
+	compareReportDocuments(t, streamLeft, streamRight)
+	assert.Len(t, streamed, 3)
+}
+
+func TestWithChangeStream_Discard(t *testing.T) {
+	streamed := 0
+	ctx := WithChangeStream(context.Background(), func(change *Change) bool {
+		streamed++
+		return true
+	}, true)
+
+	changes := streamDocuments(t, ctx, streamLeft, streamRight)
+	assert.Equal(t, 3, streamed)
+	assert.Zero(t, changes.TotalChanges())
+}
+
+func TestWithChangeStream_Abort(t *testing.T) {
+	var streamed []*Change
+	ctx := WithChangeStream(context.Background(), func(change *Change) bool {
+		streamed = append(streamed, change)
+		return !change.Breaking
+	}, false)
+
+	changes := streamDocuments(t, ctx, streamLeft, streamRight)
+	assert.True(t, ComparisonAborted(ctx))
+	require.NotEmpty(t, streamed)
+	assert.True(t, streamed[len(streamed)-1].Breaking)
+	for _, c := range streamed[:len(streamed)-1] {
+		assert.False(t, c.Breaking)
+	}
+	assert.Equal(t, 1, changes.TotalBreakingChanges())
+}
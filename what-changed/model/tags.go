@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low"
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
@@ -56,6 +58,10 @@ func (t *TagChanges) TotalBreakingChanges() int {
 // any changes between them. If there are changes, a pointer to TagChanges is returned, if not then
 // nil is returned instead.
 func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
+	return compareTags(context.Background(), l, r)
+}
+
+func compareTags(ctx context.Context, l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 	var tagResults []*TagChanges
 
 	// look at the original and then look through the new.
@@ -77,7 +83,7 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 		tc := new(TagChanges)
 		var changes []*Change
 
-		CheckForObjectAdditionOrRemoval[*base.Tag](seenLeft, seenRight, i, &changes, BreakingAdded(CompTags, ""), BreakingRemoved(CompTags, ""))
+		CheckForObjectAdditionOrRemoval[*base.Tag](seenLeft, seenRight, i, &changes, breakingAdded(ctx, CompTags, ""), breakingRemoved(ctx, CompTags, ""))
 
 		// if the existing tag exists, let's check it.
 		if seenRight[i] != nil {
@@ -104,25 +110,25 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 			)
 
 			// check properties
-			CheckProperties(props)
+			checkProperties(ctx, props)
 
 			// compare external docs
 			if !seenLeft[i].Value.ExternalDocs.IsEmpty() && !seenRight[i].Value.ExternalDocs.IsEmpty() {
-				tc.ExternalDocs = CompareExternalDocs(seenLeft[i].Value.ExternalDocs.Value,
+				tc.ExternalDocs = compareExternalDocs(ctx, seenLeft[i].Value.ExternalDocs.Value,
 					seenRight[i].Value.ExternalDocs.Value)
 			}
 			if seenLeft[i].Value.ExternalDocs.IsEmpty() && !seenRight[i].Value.ExternalDocs.IsEmpty() {
 				CreateChange(&changes, ObjectAdded, v3.ExternalDocsLabel, nil, seenRight[i].GetValueNode(),
-					BreakingAdded(CompTag, PropExternalDocs), nil, seenRight[i].Value.ExternalDocs.Value)
+					breakingAdded(ctx, CompTag, PropExternalDocs), nil, seenRight[i].Value.ExternalDocs.Value)
 			}
 			if !seenLeft[i].Value.ExternalDocs.IsEmpty() && seenRight[i].Value.ExternalDocs.IsEmpty() {
 				CreateChange(&changes, ObjectRemoved, v3.ExternalDocsLabel, seenLeft[i].GetValueNode(), nil,
-					BreakingRemoved(CompTag, PropExternalDocs), seenLeft[i].Value.ExternalDocs.Value, nil)
+					breakingRemoved(ctx, CompTag, PropExternalDocs), seenLeft[i].Value.ExternalDocs.Value, nil)
 			}
 
 			// check extensions
-			tc.ExtensionChanges = CompareExtensions(seenLeft[i].Value.Extensions, seenRight[i].Value.Extensions)
-			tc.PropertyChanges = NewPropertyChanges(changes)
+			tc.ExtensionChanges = compareExtensions(ctx, seenLeft[i].Value.Extensions, seenRight[i].Value.Extensions)
+			tc.PropertyChanges = newPropertyChanges(ctx, changes)
 			if tc.TotalChanges() > 0 {
 				tagResults = append(tagResults, tc)
 			}
@@ -130,7 +136,7 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 		}
 
 		if len(changes) > 0 {
-			tc.PropertyChanges = NewPropertyChanges(changes)
+			tc.PropertyChanges = newPropertyChanges(ctx, changes)
 			tagResults = append(tagResults, tc)
 		}
 
@@ -141,12 +147,34 @@ func CompareTags(l, r []low.ValueReference[*base.Tag]) []*TagChanges {
 			var changes []*Change
 
 			CreateChange(&changes, ObjectAdded, i, nil, seenRight[i].GetValueNode(),
-				BreakingAdded(CompTags, ""), nil, seenRight[i].GetValue())
+				breakingAdded(ctx, CompTags, ""), nil, seenRight[i].GetValue())
 
-			tc.PropertyChanges = NewPropertyChanges(changes)
+			tc.PropertyChanges = newPropertyChanges(ctx, changes)
 			tagResults = append(tagResults, tc)
 
 		}
 	}
 	return tagResults
 }
+
+// checkTagOrder returns the tags of a document that were reordered, when tags are compared in order (see
+// ComparisonOptions). If they were not reordered, nil is returned.
+func checkTagOrder(ctx context.Context, l, r low.NodeReference[[]low.ValueReference[*base.Tag]]) *TagChanges {
+	if !orderedArrays(ctx).Tags {
+		return nil
+	}
+	lNames := make([]string, len(l.Value))
+	for i := range l.Value {
+		lNames[i] = l.Value[i].Value.Name.Value
+	}
+	rNames := make([]string, len(r.Value))
+	for i := range r.Value {
+		rNames[i] = r.Value[i].Value.Name.Value
+	}
+	var changes []*Change
+	checkReordered(lNames, rNames, v3.TagsLabel, l.ValueNode, r.ValueNode, breakingModified(ctx, CompTags, ""), &changes)
+	if len(changes) == 0 {
+		return nil
+	}
+	return &TagChanges{PropertyChanges: newPropertyChanges(ctx, changes)}
+}
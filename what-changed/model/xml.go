@@ -4,6 +4,8 @@
 package model
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/base"
 	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
 )
@@ -48,6 +50,10 @@ func (x *XMLChanges) TotalBreakingChanges() int {
 // any changes between them. If changes are found, the function returns a pointer to XMLChanges,
 // otherwise, if nothing changed - it will return nil
 func CompareXML(l, r *base.XML) *XMLChanges {
+	return compareXML(context.Background(), l, r)
+}
+
+func compareXML(ctx context.Context, l, r *base.XML) *XMLChanges {
 	xc := new(XMLChanges)
 	var changes []*Change
 	props := make([]*PropertyCheck, 0, 6)
@@ -73,11 +79,12 @@ func CompareXML(l, r *base.XML) *XMLChanges {
 			v3.WrappedLabel, &changes, l, r),
 	)
 
-	CheckProperties(props)
+	checkProperties(ctx, props)
 
 	// check extensions
-	xc.ExtensionChanges = CheckExtensions(l, r)
-	xc.PropertyChanges = NewPropertyChanges(changes)
+	xc.ExtensionChanges = checkExtensions(ctx, l, r)
+	checkComparators(l, r, &changes)
+	xc.PropertyChanges = newPropertyChanges(ctx, changes)
 	if xc.TotalChanges() <= 0 {
 		return nil
 	}
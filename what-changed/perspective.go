@@ -0,0 +1,69 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// Perspective is who changes are breaking for. Making a value required or optional breaks one side of an API but
+// not the other, depending on if the value is sent in a request, or returned in a response.
+type Perspective int
+
+const (
+	// DefaultPerspective classifies changes using the breaking rules only.
+	DefaultPerspective Perspective = iota
+
+	// ConsumerPerspective classifies changes for the clients of an API. Making a request value required, or a
+	// response value optional, is breaking. Making a request value optional, or a response value required, is not.
+	ConsumerPerspective
+
+	// ProviderPerspective classifies changes for the implementations of an API, which is the opposite of the
+	// consumer perspective. Making a request value optional, or a response value required, is breaking.
+	ProviderPerspective
+)
+
+// applyPerspective re-classifies the changes made to required values, depending on where they were made. Requests
+// sent by callbacks and webhooks are sent by the provider, so they are treated as responses (and the other way
+// around). Changes made to components are not re-classified, as it's not known where they are used.
+func applyPerspective(changes *model.DocumentChanges, perspective Perspective) {
+	if perspective != ConsumerPerspective && perspective != ProviderPerspective {
+		return
+	}
+	for _, lc := range model.LocateChanges(changes) {
+		c := lc.Change
+		if c.Property != v3.RequiredLabel {
+			continue
+		}
+		tightened, loosened := requiredChange(c)
+		if !tightened && !loosened {
+			continue
+		}
+		request, known := model.IsRequestLocation(lc.Location)
+		if !known {
+			continue
+		}
+		// for consumers, tightening a request or loosening a response is breaking.
+		breaking := tightened == request
+		if perspective == ProviderPerspective {
+			breaking = !breaking
+		}
+		c.Breaking = breaking
+	}
+}
+
+// requiredChange determines if a change to a required value made something required (tightened) or optional
+// (loosened). Schemas list the required properties, everything else uses a boolean.
+func requiredChange(c *model.Change) (tightened, loosened bool) {
+	switch c.ChangeType {
+	case model.PropertyAdded, model.ObjectAdded:
+		return c.New != "false", false
+	case model.PropertyRemoved, model.ObjectRemoved:
+		return false, c.Original != "false"
+	case model.Modified:
+		return c.New == "true", c.New == "false"
+	}
+	return false, false
+}
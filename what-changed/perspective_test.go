@@ -0,0 +1,129 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package what_changed
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const perspectiveLeft = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: kind
+          in: query
+          required: true
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+      responses:
+        '200':
+          description: a pet
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id, name]
+      callbacks:
+        adopted:
+          '{$request.body#/url}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      type: object
+              responses:
+                '200':
+                  description: thanks`
+
+const perspectiveRight = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    post:
+      parameters:
+        - name: kind
+          in: query
+          required: false
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name, age]
+      responses:
+        '200':
+          description: a pet
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+      callbacks:
+        adopted:
+          '{$request.body#/url}':
+            post:
+              requestBody:
+                content:
+                  application/json:
+                    schema:
+                      type: object
+                      required: [owner]
+              responses:
+                '200':
+                  description: thanks`
+
+// comparePerspective returns if the changes to required values are breaking, keyed by the changed value.
+func comparePerspective(t *testing.T, perspective Perspective) map[string]bool {
+	build := func(spec string) *v3.Document {
+		info, _ := datamodel.ExtractSpecInfo([]byte(spec))
+		doc, err := v3.CreateDocumentFromConfig(info, datamodel.NewDocumentConfiguration())
+		require.NoError(t, err)
+		return doc
+	}
+	changes := CompareOpenAPIDocumentsWithConfiguration(build(perspectiveLeft), build(perspectiveRight),
+		&ComparisonConfiguration{Perspective: perspective})
+	require.NotNil(t, changes)
+
+	breaking := make(map[string]bool)
+	for _, c := range changes.GetAllChanges() {
+		require.Equal(t, v3.RequiredLabel, c.Property)
+		value := c.New
+		if c.ChangeType == model.PropertyRemoved {
+			value = c.Original
+		}
+		breaking[value] = c.Breaking
+	}
+	require.Len(t, breaking, 4)
+	return breaking
+}
+
+func TestCompareOpenAPIDocumentsWithConfiguration_Perspective(t *testing.T) {
+	// the request parameter and response property became optional, the request and callback properties required.
+	// by default, all of them are breaking.
+	assert.Equal(t, map[string]bool{"false": true, "age": true, "name": true, "owner": true},
+		comparePerspective(t, DefaultPerspective))
+
+	assert.Equal(t, map[string]bool{"false": false, "age": true, "name": true, "owner": false},
+		comparePerspective(t, ConsumerPerspective))
+
+	assert.Equal(t, map[string]bool{"false": true, "age": false, "name": false, "owner": true},
+		comparePerspective(t, ProviderPerspective))
+}
@@ -0,0 +1,216 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// Changelog is the changes made to a document, grouped by the tags of the operations that changed (and then by
+// operation), so release notes can be written for each area of a product, rather than as one long list.
+type Changelog struct {
+	Total    int             `json:"total"`
+	Breaking int             `json:"breaking"`
+	Tags     []*ChangelogTag `json:"tags,omitempty"`
+
+	// Changes holds the changes that were not made to an operation, for example to components, or to the info of
+	// the document.
+	Changes []*ReportChange `json:"changes,omitempty"`
+}
+
+// ChangelogTag holds the operations that changed with a tag. Operations without any tags are grouped under a tag
+// without a name. An operation with more than one tag is listed under every one of them.
+type ChangelogTag struct {
+	Name       string                `json:"name"`
+	Total      int                   `json:"total"`
+	Breaking   int                   `json:"breaking"`
+	Operations []*ChangelogOperation `json:"operations"`
+}
+
+// ChangelogOperation holds the changes made to an operation. Webhook is true for the operations of a webhook, in
+// which case the Path is the name of the webhook.
+type ChangelogOperation struct {
+	Path     string          `json:"path"`
+	Method   string          `json:"method"`
+	Webhook  bool            `json:"webhook,omitempty"`
+	Tags     []string        `json:"tags,omitempty"`
+	Total    int             `json:"total"`
+	Breaking int             `json:"breaking"`
+	Changes  []*ReportChange `json:"changes"`
+}
+
+// CreateChangelog groups the changes made to a document by tag, and then by path and method. The original and
+// updated nodes are the root nodes of the specifications that were compared to create the changes (for example
+// SpecInfo.RootNode), and are used to look up the tags of each operation. The tags of the updated specification
+// are used, unless the operation was removed from it.
+//
+// Tags are listed in the order they are declared by the specification, followed by any tags that are not declared
+// (in alphabetical order), and then the untagged operations. Operations are ordered by path, and then method, and
+// breaking changes are listed first. Each change is only counted once by the totals of the changelog.
+func CreateChangelog(changes HasChanges, original, updated *yaml.Node) *Changelog {
+	changelog := new(Changelog)
+	if changes == nil || reflect.ValueOf(changes).IsNil() || changes.TotalChanges() == 0 {
+		return changelog
+	}
+
+	changed := make(map[string]*ChangelogOperation)
+	var ordered []*ChangelogOperation
+	for _, lc := range model.LocateChanges(changes) {
+		section, key, method, rest := documentLocation(lc.Location)
+		if section <= 1 && method == "" && len(rest) == 0 && slices.Contains(operations, lc.Change.Property) {
+			// operations that were added or removed are changes made to the path item, listed with the operation.
+			method = lc.Change.Property
+		}
+		change := newReportChange(lc, rest)
+		changelog.Total++
+		if change.Breaking {
+			changelog.Breaking++
+		}
+		if section > 1 || method == "" {
+			// changes that were not made to an operation are described from the root of the document.
+			change.Location = displayLocation(lc.Location)
+			changelog.Changes = append(changelog.Changes, change)
+			continue
+		}
+		id := key + " " + method
+		if section == 1 {
+			id = v3.WebhooksLabel + " " + id
+		}
+		op := changed[id]
+		if op == nil {
+			op = &ChangelogOperation{Path: key, Method: method, Webhook: section == 1}
+			op.Tags = operationTags(updated, op)
+			if op.Tags == nil {
+				op.Tags = operationTags(original, op)
+			}
+			changed[id] = op
+			ordered = append(ordered, op)
+		}
+		op.Total++
+		if change.Breaking {
+			op.Breaking++
+		}
+		op.Changes = append(op.Changes, change)
+	}
+
+	slices.SortStableFunc(ordered, func(a, b *ChangelogOperation) int {
+		switch {
+		case a.Webhook != b.Webhook && a.Webhook:
+			return 1
+		case a.Webhook != b.Webhook:
+			return -1
+		case a.Path != b.Path:
+			return strings.Compare(a.Path, b.Path)
+		}
+		return operationIndex(a.Method) - operationIndex(b.Method)
+	})
+
+	tags := make(map[string]*ChangelogTag)
+	for _, op := range ordered {
+		slices.SortStableFunc(op.Changes, breakingFirst)
+		names := op.Tags
+		if len(names) == 0 {
+			names = []string{""}
+		}
+		for _, name := range names {
+			tag := tags[name]
+			if tag == nil {
+				tag = &ChangelogTag{Name: name}
+				tags[name] = tag
+				changelog.Tags = append(changelog.Tags, tag)
+			}
+			tag.Total += op.Total
+			tag.Breaking += op.Breaking
+			tag.Operations = append(tag.Operations, op)
+		}
+	}
+	slices.SortStableFunc(changelog.Changes, breakingFirst)
+
+	declared := declaredTags(updated)
+	for _, name := range declaredTags(original) {
+		if !slices.Contains(declared, name) {
+			declared = append(declared, name)
+		}
+	}
+	tagIndex := func(name string) int {
+		if name == "" {
+			return len(declared) + 1
+		}
+		if i := slices.Index(declared, name); i >= 0 {
+			return i
+		}
+		return len(declared)
+	}
+	slices.SortStableFunc(changelog.Tags, func(a, b *ChangelogTag) int {
+		if i, j := tagIndex(a.Name), tagIndex(b.Name); i != j {
+			return i - j
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return changelog
+}
+
+// breakingFirst orders breaking changes before the changes that are not breaking.
+func breakingFirst(a, b *ReportChange) int {
+	switch {
+	case a.Breaking == b.Breaking:
+		return 0
+	case a.Breaking:
+		return -1
+	}
+	return 1
+}
+
+// operationTags returns the tags of an operation in a specification, or nil if the operation does not exist.
+func operationTags(root *yaml.Node, op *ChangelogOperation) []string {
+	if root == nil {
+		return nil
+	}
+	pointer := "/" + v3.PathsLabel + "/"
+	if op.Webhook {
+		pointer = "/" + v3.WebhooksLabel + "/"
+	}
+	pointer += strings.ReplaceAll(strings.ReplaceAll(op.Path, "~", "~0"), "/", "~1")
+	_, operation := pointerNode(root, pointer+"/"+op.Method)
+	if operation == nil {
+		_, operation = pointerNode(root, pointer+"/additionalOperations/"+op.Method)
+	}
+	if operation == nil {
+		return nil
+	}
+	_, tags := pointerNode(operation, "/"+v3.TagsLabel)
+	names := make([]string, 0)
+	if tags != nil && tags.Kind == yaml.SequenceNode {
+		for _, tag := range tags.Content {
+			if tag.Kind == yaml.ScalarNode && !slices.Contains(names, tag.Value) {
+				names = append(names, tag.Value)
+			}
+		}
+	}
+	return names
+}
+
+// declaredTags returns the names of the tags declared by a specification, in the order they are declared.
+func declaredTags(root *yaml.Node) []string {
+	if root == nil {
+		return nil
+	}
+	_, tags := pointerNode(root, "/"+v3.TagsLabel)
+	if tags == nil || tags.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var names []string
+	for _, tag := range tags.Content {
+		if _, name := pointerNode(tag, "/name"); name != nil && name.Kind == yaml.ScalarNode {
+			names = append(names, name.Value)
+		}
+	}
+	return names
+}
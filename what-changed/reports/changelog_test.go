@@ -0,0 +1,190 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+var changelogOriginal = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+  - name: toys
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      description: list pets
+      parameters:
+        - name: limit
+          in: query
+        - name: offset
+          in: query
+    post:
+      tags: [pets, toys]
+      description: add a pet
+  /toys:
+    get:
+      tags: [toys]
+      responses:
+        '200':
+          description: toys
+  /health:
+    get:
+      description: health`
+
+var changelogUpdated = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.1.0
+tags:
+  - name: pets
+  - name: toys
+paths:
+  /pets:
+    get:
+      tags: [pets]
+      description: list all the pets
+      parameters:
+        - name: offset
+          in: query
+    post:
+      tags: [pets, toys]
+      description: add a new pet
+  /toys:
+    get:
+      tags: [toys]
+      responses:
+        '200':
+          description: all the toys
+  /health:
+    get:
+      description: is it healthy?`
+
+func TestCreateChangelog(t *testing.T) {
+	original, err := libopenapi.NewDocument([]byte(changelogOriginal))
+	require.NoError(t, err)
+	updated, err := libopenapi.NewDocument([]byte(changelogUpdated))
+	require.NoError(t, err)
+	changes, err := libopenapi.CompareDocuments(original, updated)
+	require.NoError(t, err)
+
+	changelog := CreateChangelog(changes, original.GetSpecInfo().RootNode, updated.GetSpecInfo().RootNode)
+	assert.Equal(t, changes.TotalChanges(), changelog.Total)
+	assert.Equal(t, 1, changelog.Breaking)
+
+	require.Len(t, changelog.Tags, 3)
+	pets, toys, untagged := changelog.Tags[0], changelog.Tags[1], changelog.Tags[2]
+
+	assert.Equal(t, "pets", pets.Name)
+	assert.Equal(t, 3, pets.Total)
+	assert.Equal(t, 1, pets.Breaking)
+	require.Len(t, pets.Operations, 2)
+	assert.Equal(t, "/pets", pets.Operations[0].Path)
+	assert.Equal(t, "get", pets.Operations[0].Method)
+	assert.Equal(t, []string{"pets"}, pets.Operations[0].Tags)
+	assert.Equal(t, 2, pets.Operations[0].Total)
+	assert.Equal(t, 1, pets.Operations[0].Breaking)
+	assert.True(t, pets.Operations[0].Changes[0].Breaking)
+	assert.Equal(t, "post", pets.Operations[1].Method)
+
+	// operations with more than one tag are listed under every tag.
+	assert.Equal(t, "toys", toys.Name)
+	require.Len(t, toys.Operations, 2)
+	assert.Same(t, pets.Operations[1], toys.Operations[0])
+	assert.Equal(t, "/toys", toys.Operations[1].Path)
+	assert.Equal(t, "responses.200", toys.Operations[1].Changes[0].Location)
+	assert.Equal(t, 0, toys.Breaking)
+
+	assert.Empty(t, untagged.Name)
+	require.Len(t, untagged.Operations, 1)
+	assert.Equal(t, "/health", untagged.Operations[0].Path)
+
+	require.Len(t, changelog.Changes, 1)
+	assert.Equal(t, "info", changelog.Changes[0].Location)
+	assert.Equal(t, "version", changelog.Changes[0].Property)
+}
+
+func TestCreateChangelog_RemovedOperation(t *testing.T) {
+	var original, updated yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(changelogOriginal), &original))
+	require.NoError(t, yaml.Unmarshal([]byte(changelogUpdated), &updated))
+	// remove the post operation of /pets, leaving the get operation.
+	_, pathItem := pointerNode(&updated, "/paths/~1pets")
+	pathItem.Content = pathItem.Content[:2]
+
+	originalDoc, err := libopenapi.NewDocument([]byte(changelogOriginal))
+	require.NoError(t, err)
+	updatedSpec, err := yaml.Marshal(&updated)
+	require.NoError(t, err)
+	updatedDoc, err := libopenapi.NewDocument(updatedSpec)
+	require.NoError(t, err)
+	changes, err := libopenapi.CompareDocuments(originalDoc, updatedDoc)
+	require.NoError(t, err)
+
+	// the tags of a removed operation are looked up in the original specification.
+	changelog := CreateChangelog(changes, &original, &updated)
+	require.Len(t, changelog.Tags, 3)
+	toys := changelog.Tags[1]
+	assert.Equal(t, "toys", toys.Name)
+	require.Len(t, toys.Operations, 2)
+	removed := toys.Operations[0]
+	assert.Equal(t, "/pets", removed.Path)
+	assert.Equal(t, "post", removed.Method)
+	assert.Equal(t, []string{"pets", "toys"}, removed.Tags)
+	require.Len(t, removed.Changes, 1)
+	assert.True(t, removed.Changes[0].Breaking)
+	assert.Empty(t, removed.Changes[0].Location)
+}
+
+func TestCreateChangelog_BurgerShop(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	var original, updated yaml.Node
+	require.NoError(t, yaml.Unmarshal(burgerShopOriginal, &original))
+	require.NoError(t, yaml.Unmarshal(burgerShopUpdated, &updated))
+
+	changes := createDiff()
+	changelog := CreateChangelog(changes, &original, &updated)
+	report := CreateFlatReport(changes)
+	assert.Equal(t, report.Total, changelog.Total)
+	assert.Equal(t, report.Breaking, changelog.Breaking)
+
+	total, breaking := len(changelog.Changes), 0
+	for _, c := range changelog.Changes {
+		if c.Breaking {
+			breaking++
+		}
+	}
+	seen := make(map[*ChangelogOperation]bool)
+	for _, tag := range changelog.Tags {
+		for _, op := range tag.Operations {
+			if !seen[op] {
+				seen[op] = true
+				total += op.Total
+				breaking += op.Breaking
+			}
+		}
+	}
+	assert.Equal(t, changelog.Total, total)
+	assert.Equal(t, changelog.Breaking, breaking)
+	// tags are ordered the way the updated specification declares them.
+	assert.Equal(t, "HotDogs", changelog.Tags[0].Name)
+	assert.Equal(t, "Burgers", changelog.Tags[1].Name)
+}
+
+func TestCreateChangelog_NoChanges(t *testing.T) {
+	changelog := CreateChangelog(nil, nil, nil)
+	assert.Zero(t, changelog.Total)
+	assert.Empty(t, changelog.Tags)
+}
@@ -0,0 +1,245 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	v3 "github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// operations are the (JSON) names of the operation changes of a path item, in the order they are reported.
+var operations = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "query"}
+
+// FlatReport is a flattened tree of changes, grouped into sections and groups that are simple to render with a
+// template, without walking the nested change models.
+type FlatReport struct {
+	Total    int              `json:"total"`
+	Breaking int              `json:"breaking"`
+	Sections []*ReportSection `json:"sections,omitempty"`
+}
+
+// ReportSection is a top level section of a report, for example the paths, or the components of a document.
+type ReportSection struct {
+	Title    string         `json:"title"`
+	Total    int            `json:"total"`
+	Breaking int            `json:"breaking"`
+	Groups   []*ReportGroup `json:"groups"`
+}
+
+// ReportGroup is a group of changes made to the same object, for example a path, or a component. The changes made
+// to the operations of a path (or webhook) are grouped by operation.
+type ReportGroup struct {
+	Title      string             `json:"title"`
+	Key        string             `json:"key"`
+	Total      int                `json:"total"`
+	Breaking   int                `json:"breaking"`
+	Changes    []*ReportChange    `json:"changes,omitempty"`
+	Operations []*ReportOperation `json:"operations,omitempty"`
+}
+
+// ReportOperation holds the changes made to an operation of a path item.
+type ReportOperation struct {
+	Method   string          `json:"method"`
+	Total    int             `json:"total"`
+	Breaking int             `json:"breaking"`
+	Changes  []*ReportChange `json:"changes"`
+}
+
+// ReportChange is a single change of a report. The Location is relative to the group (or operation) holding the
+// change, and the Path is the full location of the change in the tree of changes.
+type ReportChange struct {
+	Location       string        `json:"location,omitempty"`
+	Path           []string      `json:"path,omitempty"`
+	ChangeType     int           `json:"change"`
+	ChangeText     string        `json:"changeText"`
+	Property       string        `json:"property"`
+	Original       string        `json:"original,omitempty"`
+	New            string        `json:"new,omitempty"`
+	Breaking       bool          `json:"breaking"`
+	Severity       string        `json:"severity,omitempty"`
+	OriginalLine   int           `json:"originalLine,omitempty"`
+	OriginalColumn int           `json:"originalColumn,omitempty"`
+	NewLine        int           `json:"newLine,omitempty"`
+	NewColumn      int           `json:"newColumn,omitempty"`
+	Change         *model.Change `json:"-"`
+}
+
+// CreateFlatReport flattens any tree of changes (a *model.DocumentChanges, or any other *Changes model) into a
+// report. The changes of a document are grouped by path (and operation), webhook, component and the rest of the
+// document, the changes of anything else are grouped by the property they were made under. Breaking changes are
+// listed first in every group, and the report is always the same for the same changes.
+//
+// The totals of a report count every change it lists, the breaking changes made to some extensions are not counted
+// by the TotalBreakingChanges of the models, so the totals can be higher.
+func CreateFlatReport(changes HasChanges) *FlatReport {
+	report := new(FlatReport)
+	if changes == nil || reflect.ValueOf(changes).IsNil() || changes.TotalChanges() == 0 {
+		return report
+	}
+
+	_, document := changes.(*model.DocumentChanges)
+	sections := []*ReportSection{{Title: "Changes"}}
+	if document {
+		sections = []*ReportSection{{Title: "Paths"}, {Title: "Webhooks"}, {Title: "Components"}, {Title: "Document"}}
+	}
+	groups := make([]map[string]*ReportGroup, len(sections))
+	for _, lc := range model.LocateChanges(changes) {
+		section, key, operation, rest := 0, "", "", lc.Location
+		if document {
+			section, key, operation, rest = documentLocation(lc.Location)
+		} else if len(rest) > 0 {
+			key, rest = rest[0], rest[1:]
+		}
+		if groups[section] == nil {
+			groups[section] = make(map[string]*ReportGroup)
+		}
+		group := groups[section][key]
+		if group == nil {
+			group = &ReportGroup{Title: key, Key: key}
+			if key == "" && document {
+				group.Title = "Document root"
+			} else if key == "" {
+				group.Title = "Root"
+			}
+			groups[section][key] = group
+			sections[section].Groups = append(sections[section].Groups, group)
+		}
+		group.add(operation, newReportChange(lc, rest))
+	}
+
+	for _, section := range sections {
+		if len(section.Groups) == 0 {
+			continue
+		}
+		for _, group := range section.Groups {
+			group.sort()
+			section.Total += group.Total
+			section.Breaking += group.Breaking
+		}
+		report.Total += section.Total
+		report.Breaking += section.Breaking
+		report.Sections = append(report.Sections, section)
+	}
+	return report
+}
+
+func newReportChange(lc *model.LocatedChange, rest []string) *ReportChange {
+	c := lc.Change
+	rc := &ReportChange{
+		Location:   displayLocation(rest),
+		Path:       lc.Location,
+		ChangeType: c.ChangeType,
+		ChangeText: model.ChangeTypeText(c.ChangeType),
+		Property:   c.Property,
+		Original:   c.Original,
+		New:        c.New,
+		Breaking:   c.Breaking,
+		Severity:   c.GetSeverity().String(),
+		Change:     c,
+	}
+	if ctx := c.Context; ctx != nil {
+		rc.OriginalLine, rc.OriginalColumn = deref(ctx.OriginalLine), deref(ctx.OriginalColumn)
+		rc.NewLine, rc.NewColumn = deref(ctx.NewLine), deref(ctx.NewColumn)
+	}
+	return rc
+}
+
+// add adds a change to the group, under an operation if there is one.
+func (g *ReportGroup) add(operation string, change *ReportChange) {
+	g.Total++
+	if change.Breaking {
+		g.Breaking++
+	}
+	if operation == "" {
+		g.Changes = append(g.Changes, change)
+		return
+	}
+	for _, op := range g.Operations {
+		if op.Method == operation {
+			op.add(change)
+			return
+		}
+	}
+	op := &ReportOperation{Method: operation}
+	op.add(change)
+	g.Operations = append(g.Operations, op)
+}
+
+func (o *ReportOperation) add(change *ReportChange) {
+	o.Total++
+	if change.Breaking {
+		o.Breaking++
+	}
+	o.Changes = append(o.Changes, change)
+}
+
+// sort orders the operations of a group the way they are written in a path item, and lists breaking changes first.
+func (g *ReportGroup) sort() {
+	slices.SortStableFunc(g.Changes, breakingFirst)
+	slices.SortStableFunc(g.Operations, func(a, b *ReportOperation) int {
+		return operationIndex(a.Method) - operationIndex(b.Method)
+	})
+	for _, op := range g.Operations {
+		slices.SortStableFunc(op.Changes, breakingFirst)
+	}
+}
+
+// documentLocation returns the section, group key and operation the change at a location of a document is
+// reported under, and the rest of its location.
+func documentLocation(location []string) (section int, key, operation string, rest []string) {
+	pathItem := func(l []string) (string, []string) {
+		if len(l) > 0 && slices.Contains(operations, l[0]) {
+			return l[0], l[1:]
+		}
+		if len(l) > 1 && l[0] == "additionalOperations" {
+			return l[1], l[2:]
+		}
+		return "", l
+	}
+	switch {
+	case len(location) >= 3 && location[0] == v3.PathsLabel && location[1] == "pathItems":
+		operation, rest = pathItem(location[3:])
+		return 0, location[2], operation, rest
+	case len(location) >= 2 && location[0] == v3.WebhooksLabel:
+		operation, rest = pathItem(location[2:])
+		return 1, location[1], operation, rest
+	case len(location) >= 3 && location[0] == v3.ComponentsLabel:
+		return 2, location[1] + "/" + location[2], "", location[3:]
+	case len(location) >= 1:
+		return 3, location[0], "", location[1:]
+	}
+	return 3, "", "", nil
+}
+
+func operationIndex(operation string) int {
+	if i := slices.Index(operations, operation); i >= 0 {
+		return i
+	}
+	return len(operations)
+}
+
+// displayLocation joins the segments of a location, the maps of paths and response codes are written as they are in
+// a document, for example 'responses.200' rather than 'responses.response.200'.
+func displayLocation(location []string) string {
+	segments := make([]string, 0, len(location))
+	for i, segment := range location {
+		if segment == "response" && i > 0 && location[i-1] == v3.ResponsesLabel ||
+			segment == "pathItems" && i > 0 && location[i-1] == v3.PathsLabel {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, ".")
+}
+
+func deref(i *int) int {
+	if i == nil {
+		return 0
+	}
+	return *i
+}
@@ -0,0 +1,92 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFlatReport(t *testing.T) {
+	changes := createDiff()
+	report := CreateFlatReport(changes)
+
+	assert.Equal(t, changes.TotalChanges(), report.Total)
+	assert.Equal(t, 24, report.Breaking)
+	require.Len(t, report.Sections, 4)
+
+	total, breaking := 0, 0
+	for _, section := range report.Sections {
+		total += section.Total
+		breaking += section.Breaking
+	}
+	assert.Equal(t, report.Total, total)
+	assert.Equal(t, report.Breaking, breaking)
+
+	paths := report.Sections[0]
+	assert.Equal(t, "Paths", paths.Title)
+	assert.Equal(t, "/burgers", paths.Groups[0].Key)
+	assert.Equal(t, "/burgers", paths.Groups[0].Title)
+
+	dressings := paths.Groups[2]
+	assert.Equal(t, "/burgers/{burgerId}/dressings", dressings.Key)
+	require.Len(t, dressings.Operations, 1)
+	get := dressings.Operations[0]
+	assert.Equal(t, "get", get.Method)
+	assert.Equal(t, 5, get.Breaking)
+	assert.Equal(t, get.Total, len(get.Changes))
+
+	in := get.Changes[1]
+	assert.Equal(t, "parameters.burgerId", in.Location)
+	assert.Equal(t, []string{"paths", "pathItems", "/burgers/{burgerId}/dressings", "get", "parameters", "burgerId"}, in.Path)
+	assert.Equal(t, "in", in.Property)
+	assert.Equal(t, "path", in.Original)
+	assert.Equal(t, "query", in.New)
+	assert.Equal(t, "modified", in.ChangeText)
+	assert.True(t, in.Breaking)
+	assert.NotZero(t, in.OriginalLine)
+	assert.NotZero(t, in.NewLine)
+	assert.Equal(t, "in", in.Change.Property)
+
+	// breaking changes are listed first.
+	for i := get.Breaking; i < len(get.Changes); i++ {
+		assert.False(t, get.Changes[i].Breaking)
+	}
+
+	assert.Equal(t, "Document root", report.Sections[3].Groups[0].Title)
+	assert.Equal(t, "schemas/Burger", report.Sections[2].Groups[0].Key)
+
+	// the report is simple to serialize.
+	b, err := json.Marshal(report)
+	require.NoError(t, err)
+	assert.Contains(t, string(b), `"method":"get"`)
+}
+
+func TestCreateFlatReport_Changes(t *testing.T) {
+	changes := createDiff()
+	operation := changes.PathsChanges.PathItemsChanges["/burgers/{burgerId}/dressings"].GetChanges
+	report := CreateFlatReport(operation)
+
+	assert.Equal(t, operation.TotalChanges(), report.Total)
+	require.Len(t, report.Sections, 1)
+	assert.Equal(t, "Changes", report.Sections[0].Title)
+
+	var keys []string
+	for _, group := range report.Sections[0].Groups {
+		keys = append(keys, group.Title)
+	}
+	assert.Equal(t, []string{"Root", "parameters", "responses"}, keys)
+	assert.Equal(t, "burgerId", report.Sections[0].Groups[1].Changes[0].Location)
+}
+
+func TestCreateFlatReport_NoChanges(t *testing.T) {
+	assert.Equal(t, &FlatReport{}, CreateFlatReport(nil))
+	var changes *model.DocumentChanges
+	assert.Equal(t, &FlatReport{}, CreateFlatReport(changes))
+	assert.Equal(t, &FlatReport{}, CreateFlatReport(&model.OperationChanges{PropertyChanges: model.NewPropertyChanges(nil)}))
+}
@@ -0,0 +1,87 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// htmlTemplate renders a FlatReport as a static, self-contained HTML page.
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"upper": strings.ToUpper,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{ .Title }}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2rem; color: #1f2328; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1rem; }
+th, td { border: 1px solid #d0d7de; padding: 0.3rem 0.6rem; text-align: left; vertical-align: top; }
+th { background: #f6f8fa; }
+td.value { font-family: monospace; white-space: pre-wrap; word-break: break-word; }
+tr.breaking { background: #ffebe9; }
+.badge { border-radius: 1rem; padding: 0.1rem 0.5rem; font-size: 0.8rem; background: #cf222e; color: #fff; }
+.summary { font-size: 1.1rem; }
+</style>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+{{- with .Report }}
+{{- if eq .Total 0 }}
+<p class="summary">No changes found.</p>
+{{- else }}
+<p class="summary"><strong>{{ .Total }}</strong> changes, <strong>{{ .Breaking }}</strong> breaking.</p>
+{{- range .Sections }}
+<h2>{{ .Title }} <small>({{ .Total }} changes, {{ .Breaking }} breaking)</small></h2>
+{{- range .Groups }}
+<h3>{{ if .Key }}<code>{{ .Title }}</code>{{ else }}{{ .Title }}{{ end }}</h3>
+{{- if .Changes }}{{ template "changes" .Changes }}{{ end }}
+{{- range .Operations }}
+<h4><code>{{ upper .Method }}</code></h4>
+{{- template "changes" .Changes }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+</body>
+</html>
+{{ define "changes" }}
+<table>
+<thead><tr><th>Change</th><th>Property</th><th>Location</th><th>Original</th><th>New</th></tr></thead>
+<tbody>
+{{- range . }}
+<tr{{ if .Breaking }} class="breaking"{{ end }}>
+<td>{{ .ChangeText }}{{ if .Breaking }} <span class="badge">breaking</span>{{ end }}</td>
+<td><code>{{ .Property }}</code></td>
+<td><code>{{ .Location }}</code></td>
+<td class="value">{{ .Original }}</td>
+<td class="value">{{ .New }}</td>
+</tr>
+{{- end }}
+</tbody>
+</table>
+{{- end }}`))
+
+// CreateHTMLReport renders any tree of changes as a static HTML report page, see CreateFlatReport.
+func CreateHTMLReport(changes HasChanges) (string, error) {
+	var b bytes.Buffer
+	if err := CreateFlatReport(changes).WriteHTML(&b, "API Changes"); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// WriteHTML renders the report as a static HTML page with a title. Every value is escaped, so the page is safe to
+// publish.
+func (r *FlatReport) WriteHTML(w io.Writer, title string) error {
+	return htmlTemplate.Execute(w, struct {
+		Title  string
+		Report *FlatReport
+	}{title, r})
+}
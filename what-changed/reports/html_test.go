@@ -0,0 +1,51 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateHTMLReport(t *testing.T) {
+	html, err := CreateHTMLReport(createDiff())
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<title>API Changes</title>")
+	assert.Contains(t, html, "<p class=\"summary\"><strong>77</strong> changes, <strong>24</strong> breaking.</p>")
+	assert.Contains(t, html, "<h2>Paths <small>(")
+	assert.Contains(t, html, "<h3><code>/burgers/{burgerId}/dressings</code></h3>")
+	assert.Contains(t, html, "<h4><code>GET</code></h4>")
+	assert.Contains(t, html, "<h3>Document root</h3>")
+	assert.Contains(t, html, "<tr class=\"breaking\">\n<td>modified <span class=\"badge\">breaking</span></td>\n"+
+		"<td><code>in</code></td>\n<td><code>parameters.burgerId</code></td>\n"+
+		"<td class=\"value\">path</td>\n<td class=\"value\">query</td>")
+}
+
+func TestFlatReport_WriteHTML_Escaped(t *testing.T) {
+	changes := &model.OperationChanges{PropertyChanges: model.NewPropertyChanges([]*model.Change{{
+		ChangeType: model.Modified,
+		Property:   "description",
+		Original:   "<script>alert('hi')</script>",
+		New:        "safe",
+	}})}
+
+	var b bytes.Buffer
+	require.NoError(t, CreateFlatReport(changes).WriteHTML(&b, "<Pets>"))
+	assert.Contains(t, b.String(), "<title>&lt;Pets&gt;</title>")
+	assert.Contains(t, b.String(), "&lt;script&gt;")
+	assert.NotContains(t, b.String(), "<script>")
+}
+
+func TestCreateHTMLReport_NoChanges(t *testing.T) {
+	html, err := CreateHTMLReport(nil)
+	require.NoError(t, err)
+	assert.Contains(t, html, "<p class=\"summary\">No changes found.</p>")
+}
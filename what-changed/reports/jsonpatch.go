@@ -0,0 +1,141 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/patch"
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// CreateJSONPatch converts a change report into a JSON Patch (RFC 6902) document, that transforms the original
+// specification into the updated one. The original and updated nodes are the root nodes of the specifications
+// that were compared to create the changes (for example SpecInfo.RootNode).
+//
+// Unlike libopenapi.GeneratePatch, which patches every difference between the two specifications, the patch only
+// contains the operations for the changes in the report. Changes dropped from the report (by a filter, or by
+// ignoring extensions) are not replayed. Changes made in other documents (those with a document location) and
+// changes without a line number cannot be placed in the root specification, and are left out.
+func CreateJSONPatch(changes HasChanges, original, updated *yaml.Node) ([]byte, error) {
+	operations, err := CreateJSONPatchOperations(changes, original, updated)
+	if err != nil {
+		return nil, err
+	}
+	return patch.Encode(operations)
+}
+
+// CreateJSONPatchOperations is the same as CreateJSONPatch, except the operations are returned rather than being
+// rendered, so they can be applied directly using patch.Apply.
+func CreateJSONPatchOperations(changes HasChanges, original, updated *yaml.Node) ([]*patch.Operation, error) {
+	if original == nil || updated == nil {
+		return nil, errors.New("unable to create a JSON patch, both the original and updated specifications are required")
+	}
+	originalLines, updatedLines := make(map[int]bool), make(map[int]bool)
+	for _, lc := range model.LocateChanges(changes) {
+		ctx := lc.Change.Context
+		if ctx == nil || ctx.DocumentLocation != "" {
+			continue
+		}
+		if ctx.OriginalLine != nil {
+			originalLines[*ctx.OriginalLine] = true
+		}
+		if ctx.NewLine != nil {
+			updatedLines[*ctx.NewLine] = true
+		}
+	}
+
+	operations := make([]*patch.Operation, 0)
+	for _, op := range patch.Diff(original, updated) {
+		reported := false
+		if op.Op == patch.OpRemove || op.Op == patch.OpReplace {
+			reported = spanContains(original, op.Path, originalLines)
+		}
+		if !reported && (op.Op == patch.OpAdd || op.Op == patch.OpReplace) {
+			reported = spanContains(updated, op.Path, updatedLines)
+		}
+		if reported {
+			operations = append(operations, op)
+		}
+	}
+	return operations, nil
+}
+
+// spanContains determines if any of the lines fall between the first line of the node at the pointer (including
+// its key) and the last line of everything below it.
+func spanContains(root *yaml.Node, pointer string, lines map[int]bool) bool {
+	if len(lines) == 0 {
+		return false
+	}
+	key, value := pointerNode(root, pointer)
+	if value == nil {
+		return false
+	}
+	start, end := value.Line, lastLine(value)
+	if key != nil {
+		start = key.Line
+	}
+	for line := range lines {
+		if line >= start && line <= end {
+			return true
+		}
+	}
+	return false
+}
+
+// pointerNode returns the node a JSON Pointer points to, and its key when the parent is a mapping.
+func pointerNode(root *yaml.Node, pointer string) (*yaml.Node, *yaml.Node) {
+	n := root
+	if n != nil && n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	var key *yaml.Node
+	if pointer == "" {
+		return nil, n
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		for n != nil && n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		if n == nil {
+			return nil, nil
+		}
+		key = nil
+		switch n.Kind {
+		case yaml.MappingNode:
+			var next *yaml.Node
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == token {
+					key, next = n.Content[i], n.Content[i+1]
+					break
+				}
+			}
+			n = next
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n.Content) {
+				return nil, nil
+			}
+			n = n.Content[i]
+		default:
+			return nil, nil
+		}
+	}
+	return key, n
+}
+
+// lastLine returns the last line used by a node and everything below it.
+func lastLine(n *yaml.Node) int {
+	line := n.Line
+	for _, c := range n.Content {
+		if l := lastLine(c); l > line {
+			line = l
+		}
+	}
+	return line
+}
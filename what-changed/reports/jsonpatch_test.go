@@ -0,0 +1,151 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/patch"
+	"github.com/pb33f/libopenapi/utils"
+	whatChanged "github.com/pb33f/libopenapi/what-changed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+var patchOriginal = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list pets
+      x-internal: true
+      parameters:
+        - name: limit
+          in: query
+      responses:
+        '200':
+          description: pets
+  /toys:
+    get:
+      responses:
+        '200':
+          description: toys`
+
+var patchUpdated = `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: list all the pets
+      x-internal: false
+      parameters:
+        - name: limit
+          in: query
+          required: true
+      responses:
+        '200':
+          description: pets`
+
+func comparePatchDocuments(t *testing.T, configuration *whatChanged.ComparisonConfiguration) (libopenapi.Document, libopenapi.Document, HasChanges) {
+	original, err := libopenapi.NewDocument([]byte(patchOriginal))
+	require.NoError(t, err)
+	updated, err := libopenapi.NewDocument([]byte(patchUpdated))
+	require.NoError(t, err)
+	changes, err := libopenapi.CompareDocumentsWithConfiguration(original, updated, configuration)
+	require.NoError(t, err)
+	return original, updated, changes
+}
+
+func TestCreateJSONPatch(t *testing.T) {
+	original, updated, changes := comparePatchDocuments(t, nil)
+
+	b, err := CreateJSONPatch(changes, original.GetSpecInfo().RootNode, updated.GetSpecInfo().RootNode)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+  {"op": "remove", "path": "/paths/~1toys"},
+  {"op": "replace", "path": "/paths/~1pets/get/description", "value": "list all the pets"},
+  {"op": "replace", "path": "/paths/~1pets/get/x-internal", "value": false},
+  {"op": "add", "path": "/paths/~1pets/get/parameters/0/required", "value": true}
+]`, string(b))
+
+	// replaying the patch turns the original specification into the updated one.
+	operations, err := patch.Decode(b)
+	require.NoError(t, err)
+	patched, err := patch.Apply(original.GetSpecInfo().RootNode, operations)
+	require.NoError(t, err)
+	assert.True(t, utils.YAMLNodesEqual(patched.Content[0], updated.GetSpecInfo().RootNode.Content[0]))
+}
+
+func TestCreateJSONPatch_FilteredReport(t *testing.T) {
+	original, updated, changes := comparePatchDocuments(t, &whatChanged.ComparisonConfiguration{IgnoreExtensions: true})
+
+	operations, err := CreateJSONPatchOperations(changes, original.GetSpecInfo().RootNode, updated.GetSpecInfo().RootNode)
+	require.NoError(t, err)
+	paths := make([]string, 0, len(operations))
+	for _, op := range operations {
+		paths = append(paths, op.Path)
+	}
+	assert.Equal(t, []string{"/paths/~1toys", "/paths/~1pets/get/description", "/paths/~1pets/get/parameters/0/required"}, paths)
+}
+
+func TestCreateJSONPatch_BurgerShop(t *testing.T) {
+	burgerShopOriginal, _ := os.ReadFile("../../test_specs/burgershop.openapi.yaml")
+	burgerShopUpdated, _ := os.ReadFile("../../test_specs/burgershop.openapi-modified.yaml")
+	var original, updated yaml.Node
+	require.NoError(t, yaml.Unmarshal(burgerShopOriginal, &original))
+	require.NoError(t, yaml.Unmarshal(burgerShopUpdated, &updated))
+
+	operations, err := CreateJSONPatchOperations(createDiff(), &original, &updated)
+	require.NoError(t, err)
+	assert.NotEmpty(t, operations)
+	assert.LessOrEqual(t, len(operations), len(patch.Diff(&original, &updated)))
+
+	b, err := CreateJSONPatch(createDiff(), &original, &updated)
+	require.NoError(t, err)
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Len(t, decoded, len(operations))
+}
+
+func TestCreateJSONPatch_NoChanges(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(patchOriginal), &root))
+
+	b, err := CreateJSONPatch(nil, &root, &root)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[]`, string(b))
+
+	_, err = CreateJSONPatch(nil, nil, &root)
+	assert.Error(t, err)
+}
+
+func TestPointerNode(t *testing.T) {
+	var root yaml.Node
+	require.NoError(t, yaml.Unmarshal([]byte(patchOriginal), &root))
+
+	key, value := pointerNode(&root, "/paths/~1pets/get/parameters/0/in")
+	require.NotNil(t, value)
+	assert.Equal(t, "in", key.Value)
+	assert.Equal(t, "query", value.Value)
+
+	key, value = pointerNode(&root, "/paths/~1pets/get/parameters/0")
+	assert.Nil(t, key)
+	assert.Equal(t, yaml.MappingNode, value.Kind)
+
+	_, value = pointerNode(&root, "")
+	assert.Equal(t, yaml.MappingNode, value.Kind)
+
+	for _, pointer := range []string{"/nope", "/paths/~1pets/get/parameters/5", "/info/title/deeper"} {
+		_, value = pointerNode(&root, pointer)
+		assert.Nil(t, value, pointer)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// maxMarkdownValue is the longest value (in characters) rendered in a changelog, longer values are cut short.
+const maxMarkdownValue = 80
+
+// CreateMarkdownReport renders the changes between two documents as a Markdown changelog, that can be posted
+// directly to a pull request. Changes are grouped by path (and operation), webhook, component and the rest of the
+// document, and breaking changes are highlighted and listed first in each group.
+func CreateMarkdownReport(changes *model.DocumentChanges) string {
+	return CreateFlatReport(changes).Markdown()
+}
+
+// Markdown renders the report as a Markdown changelog.
+func (r *FlatReport) Markdown() string {
+	var b strings.Builder
+	b.WriteString("# API Changes\n\n")
+	if r.Total == 0 {
+		b.WriteString("No changes found.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "**%d** %s, **%d** breaking.\n", r.Total, plural(r.Total, "change", "changes"), r.Breaking)
+
+	for _, section := range r.Sections {
+		fmt.Fprintf(&b, "\n## %s\n", section.Title)
+		for _, group := range section.Groups {
+			title := group.Title
+			if group.Key != "" {
+				title = markdownCode(group.Key)
+			}
+			fmt.Fprintf(&b, "\n### %s\n\n", title)
+			writeMarkdownChanges(&b, group.Changes)
+			for i, op := range group.Operations {
+				if len(group.Changes) > 0 || i > 0 {
+					b.WriteString("\n")
+				}
+				fmt.Fprintf(&b, "#### `%s`\n\n", strings.ToUpper(op.Method))
+				writeMarkdownChanges(&b, op.Changes)
+			}
+		}
+	}
+	return b.String()
+}
+
+// writeMarkdownChanges writes a list of changes.
+func writeMarkdownChanges(b *strings.Builder, changes []*ReportChange) {
+	for _, rc := range changes {
+		b.WriteString("- ")
+		if rc.Breaking {
+			b.WriteString("**Breaking:** ")
+		}
+		b.WriteString(describeChange(rc.Change))
+		if rc.Location != "" {
+			fmt.Fprintf(b, " in %s", markdownCode(rc.Location))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// describeChange describes a change in a short sentence, using Markdown.
+func describeChange(c *model.Change) string {
+	return describe(c, markdownCode, "_empty_")
+}
+
+// describe describes a change in a short sentence, rendering property names and values using code.
+func describe(c *model.Change, code func(string) string, empty string) string {
+	value := func(v string) string {
+		if v == "" {
+			return empty
+		}
+		return code(shortValue(v))
+	}
+	property := code(c.Property)
+	switch c.ChangeType {
+	case model.Modified:
+		if c.Original == "" && c.New == "" {
+			return property + " modified"
+		}
+		return fmt.Sprintf("%s changed from %s to %s", property, value(c.Original), value(c.New))
+	case model.PropertyAdded, model.ObjectAdded:
+		if c.New != "" && c.New != c.Property {
+			return fmt.Sprintf("%s added (%s)", property, value(c.New))
+		}
+		return property + " added"
+	case model.PropertyRemoved, model.ObjectRemoved:
+		if c.Original != "" && c.Original != c.Property {
+			return fmt.Sprintf("%s removed (%s)", property, value(c.Original))
+		}
+		return property + " removed"
+	case model.ObjectRenamed:
+		return fmt.Sprintf("%s renamed from %s to %s", property, value(c.Original), value(c.New))
+	case model.ReferenceRepointed:
+		target := "different target"
+		if c.ResolvedEqual {
+			target = "identical target"
+		}
+		return fmt.Sprintf("%s repointed from %s to %s (%s)", property, value(c.Original), value(c.New), target)
+	case model.Reordered:
+		return fmt.Sprintf("%s reordered from %s to %s", property, value(c.Original), value(c.New))
+	}
+	return property + " changed"
+}
+
+// shortValue returns a value on a single line, cut short if it is too long.
+func shortValue(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if utf8.RuneCountInString(value) > maxMarkdownValue {
+		value = string([]rune(value)[:maxMarkdownValue]) + "…"
+	}
+	return value
+}
+
+// markdownCode renders a code span, using a longer fence if the text contains backticks.
+func markdownCode(text string) string {
+	if !strings.Contains(text, "`") {
+		return "`" + text + "`"
+	}
+	return "`` " + text + " ``"
+}
+
+func plural(n int, one, many string) string {
+	if n == 1 {
+		return one
+	}
+	return many
+}
@@ -0,0 +1,66 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateMarkdownReport(t *testing.T) {
+	changes := createDiff()
+	report := CreateMarkdownReport(changes)
+
+	assert.True(t, strings.HasPrefix(report, "# API Changes\n\n**77** changes, **24** breaking.\n"))
+	assert.Contains(t, report, "\n## Paths\n\n### `/burgers`\n\n- `x-burger-meta` changed from `meaty` to `meaty pop` in `extensions`\n\n#### `POST`\n\n")
+	assert.Contains(t, report, "- **Breaking:** `operationId` changed from `createBurger` to `createBurgerChanged`\n")
+	assert.Contains(t, report, "- **Breaking:** `in` changed from `path` to `query` in `parameters.burgerId`\n")
+	assert.Contains(t, report, "- `codes` added (`201`) in `responses`\n")
+	assert.Contains(t, report, "in `responses.200.links.LocateBurger`")
+	assert.Contains(t, report, "\n## Webhooks\n\n### `someHook`\n\n#### `POST`\n")
+	assert.Contains(t, report, "\n### `schemas/Fries`\n\n- **Breaking:** `required` added (`seasoning`)\n")
+	assert.Contains(t, report, "\n### Document root\n\n- **Breaking:** `jsonSchemaDialect` changed")
+
+	// sections are rendered in order, and breaking changes are listed first in each group.
+	assert.Less(t, strings.Index(report, "## Paths"), strings.Index(report, "## Webhooks"))
+	assert.Less(t, strings.Index(report, "## Webhooks"), strings.Index(report, "## Components"))
+	assert.Less(t, strings.Index(report, "## Components"), strings.Index(report, "## Document"))
+	post := report[strings.Index(report, "#### `POST`"):]
+	assert.Less(t, strings.LastIndex(post[:strings.Index(post, "###")], "**Breaking:**"),
+		strings.Index(post, "- `tags` added"))
+
+	// the report is the same every time.
+	assert.Equal(t, report, CreateMarkdownReport(changes))
+}
+
+func TestCreateMarkdownReport_NoChanges(t *testing.T) {
+	assert.Equal(t, "# API Changes\n\nNo changes found.\n", CreateMarkdownReport(nil))
+	assert.Equal(t, "# API Changes\n\nNo changes found.\n",
+		CreateMarkdownReport(&model.DocumentChanges{PropertyChanges: model.NewPropertyChanges(nil)}))
+}
+
+func TestDescribeChange(t *testing.T) {
+	assert.Equal(t, "`description` changed from `a` to `b c`",
+		describeChange(&model.Change{ChangeType: model.Modified, Property: "description", Original: "a", New: "b\n  c"}))
+	assert.Equal(t, "`description` changed from _empty_ to `b`",
+		describeChange(&model.Change{ChangeType: model.Modified, Property: "description", New: "b"}))
+	assert.Equal(t, "`value` modified", describeChange(&model.Change{ChangeType: model.Modified, Property: "value"}))
+	assert.Equal(t, "`HotDogs` added", describeChange(&model.Change{ChangeType: model.ObjectAdded, Property: "HotDogs", New: "HotDogs"}))
+	assert.Equal(t, "`tags` removed", describeChange(&model.Change{ChangeType: model.PropertyRemoved, Property: "tags"}))
+	assert.Equal(t, "`schemas` renamed from `Burger` to `Hamburger`",
+		describeChange(&model.Change{ChangeType: model.ObjectRenamed, Property: "schemas", Original: "Burger", New: "Hamburger"}))
+	assert.Equal(t, "`$ref` repointed from `#/a` to `#/b` (identical target)",
+		describeChange(&model.Change{ChangeType: model.ReferenceRepointed, Property: "$ref", Original: "#/a", New: "#/b", ResolvedEqual: true}))
+	assert.Equal(t, "`$ref` repointed from `#/a` to `#/b` (different target)",
+		describeChange(&model.Change{ChangeType: model.ReferenceRepointed, Property: "$ref", Original: "#/a", New: "#/b"}))
+	assert.Equal(t, "`pattern` changed from `` a`b `` to `c`",
+		describeChange(&model.Change{ChangeType: model.Modified, Property: "pattern", Original: "a`b", New: "c"}))
+	assert.Equal(t, "`x` changed", describeChange(&model.Change{Property: "x"}))
+
+	long := describeChange(&model.Change{ChangeType: model.PropertyAdded, Property: "description", New: strings.Repeat("a", 100)})
+	assert.Equal(t, "`description` added (`"+strings.Repeat("a", maxMarkdownValue)+"…`)", long)
+}
@@ -0,0 +1,266 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// SARIF constants, for version 2.1.0 of the Static Analysis Results Interchange Format.
+const (
+	SARIFVersion  = "2.1.0"
+	SARIFSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	SARIFToolName = "libopenapi"
+	SARIFToolURI  = "https://pb33f.io/libopenapi/"
+)
+
+// SARIFLog is the root of a SARIF document.
+type SARIFLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*SARIFRun `json:"runs"`
+}
+
+// SARIFRun is a single run of a tool, holding the tool that created the results, and the results.
+type SARIFRun struct {
+	Tool    SARIFTool      `json:"tool"`
+	Results []*SARIFResult `json:"results"`
+}
+
+// SARIFTool describes the tool that created the results, and the rules the results are reported against.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver is the component of a tool that creates the results.
+type SARIFDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri,omitempty"`
+	Rules          []*SARIFRule `json:"rules"`
+}
+
+// SARIFRule is a rule results are reported against. There is a rule for each type of change, breaking or not.
+type SARIFRule struct {
+	ID                   string                 `json:"id"`
+	Name                 string                 `json:"name"`
+	ShortDescription     SARIFMessage           `json:"shortDescription"`
+	DefaultConfiguration SARIFRuleConfiguration `json:"defaultConfiguration"`
+	Properties           map[string]any         `json:"properties,omitempty"`
+}
+
+// SARIFRuleConfiguration holds the level of results reported against a rule, unless a result has its own level.
+type SARIFRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a message, as plain text and optionally Markdown.
+type SARIFMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+// SARIFResult is a single change, reported against the rule for its type of change.
+type SARIFResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             SARIFMessage      `json:"message"`
+	Locations           []*SARIFLocation  `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// SARIFLocation is where a result was found, in a file (physical) and in the tree of changes (logical).
+type SARIFLocation struct {
+	PhysicalLocation *SARIFPhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []*SARIFLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+// SARIFPhysicalLocation is a file, and a region of that file.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the URI of a file.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is a position in a file. Lines and columns start at 1.
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// SARIFLogicalLocation is the path of a change through the tree of changes (see model.ChangePath).
+type SARIFLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// CreateSARIFReport renders changes as a SARIF 2.1.0 document, so they can be uploaded to code scanning tools
+// (like GitHub or GitLab code scanning) and show up as annotations on the specification. See NewSARIFLog.
+func CreateSARIFReport(changes HasChanges, originalURI, updatedURI string) ([]byte, error) {
+	return json.MarshalIndent(NewSARIFLog(changes, originalURI, updatedURI), "", "  ")
+}
+
+// NewSARIFLog creates a SARIF log with a result for every change. Breaking changes are errors, and the level of
+// everything else follows the severity of the change: warnings for warn and notes for info.
+//
+// Changes are placed at the line and column they were made in the updated specification (updatedURI). Removals
+// only exist in the original specification, so they are placed in originalURI when it's set, or against the
+// whole of the updated specification otherwise. Changes made in other documents (found by the rolodex) are placed
+// in those documents. URIs are used as they are supplied, and should be relative to the root of the repository to
+// show up as annotations.
+func NewSARIFLog(changes HasChanges, originalURI, updatedURI string) *SARIFLog {
+	run := &SARIFRun{
+		Tool: SARIFTool{Driver: SARIFDriver{
+			Name:           SARIFToolName,
+			InformationURI: SARIFToolURI,
+			Rules:          []*SARIFRule{},
+		}},
+		Results: []*SARIFResult{},
+	}
+	log := &SARIFLog{Schema: SARIFSchema, Version: SARIFVersion, Runs: []*SARIFRun{run}}
+	if changes == nil || reflect.ValueOf(changes).IsNil() {
+		return log
+	}
+
+	located := model.LocateChanges(changes)
+	rules := make(map[string]*SARIFRule)
+	for _, lc := range located {
+		id, _ := sarifRule(lc.Change)
+		if rules[id] == nil {
+			rules[id] = newSARIFRule(lc.Change)
+		}
+	}
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	index := make(map[string]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, rules[id])
+	}
+
+	for _, lc := range located {
+		c := lc.Change
+		id, _ := sarifRule(c)
+		path := model.ChangePath(lc.Location)
+		result := &SARIFResult{
+			RuleID:    id,
+			RuleIndex: index[id],
+			Level:     sarifLevel(c),
+			Message: SARIFMessage{
+				Text:     describe(c, func(s string) string { return "'" + s + "'" }, "empty") + " in " + path,
+				Markdown: describeChange(c) + " in " + markdownCode(path),
+			},
+			PartialFingerprints: map[string]string{
+				"changePath/v1": path + "#" + c.Property + "#" + model.ChangeTypeText(c.ChangeType),
+				"changeId/v1":   model.ChangeID(c, lc.Location),
+			},
+			Properties: map[string]any{
+				"breaking": c.Breaking,
+				"severity": c.GetSeverity().String(),
+			},
+		}
+		location := &SARIFLocation{
+			LogicalLocations: []*SARIFLogicalLocation{{FullyQualifiedName: path, Kind: "member"}},
+		}
+		location.PhysicalLocation = sarifPhysicalLocation(c, originalURI, updatedURI)
+		result.Locations = []*SARIFLocation{location}
+		run.Results = append(run.Results, result)
+	}
+	return log
+}
+
+// sarifRule returns the ID and the name of the rule a change is reported against.
+func sarifRule(c *model.Change) (string, string) {
+	text := model.ChangeTypeText(c.ChangeType)
+	if text == "" {
+		text = "changed"
+	}
+	name := strings.ReplaceAll(text, "_", " ")
+	if c.Breaking {
+		return "breaking-" + strings.ReplaceAll(text, "_", "-"), "Breaking change: " + name
+	}
+	return "change-" + strings.ReplaceAll(text, "_", "-"), "Change: " + name
+}
+
+func newSARIFRule(c *model.Change) *SARIFRule {
+	id, name := sarifRule(c)
+	level := "note"
+	if c.Breaking {
+		level = "error"
+	}
+	return &SARIFRule{
+		ID:                   id,
+		Name:                 name,
+		ShortDescription:     SARIFMessage{Text: name},
+		DefaultConfiguration: SARIFRuleConfiguration{Level: level},
+		Properties:           map[string]any{"breaking": c.Breaking},
+	}
+}
+
+// sarifLevel returns the SARIF level of a change.
+func sarifLevel(c *model.Change) string {
+	if c.Breaking {
+		return "error"
+	}
+	switch c.GetSeverity() {
+	case model.SeverityError, model.SeverityCritical:
+		return "error"
+	case model.SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// sarifPhysicalLocation places a change in the updated specification, or the original one for removals.
+func sarifPhysicalLocation(c *model.Change, originalURI, updatedURI string) *SARIFPhysicalLocation {
+	uri, line, column := updatedURI, 0, 0
+	if ctx := c.Context; ctx != nil {
+		switch {
+		case ctx.NewLine != nil:
+			line, column = *ctx.NewLine, deref(ctx.NewColumn)
+		case ctx.OriginalLine != nil && originalURI != "":
+			uri, line, column = originalURI, *ctx.OriginalLine, deref(ctx.OriginalColumn)
+		}
+		if ctx.DocumentLocation != "" {
+			uri = sarifURI(ctx.DocumentLocation)
+			if line == 0 && ctx.OriginalLine != nil {
+				line, column = *ctx.OriginalLine, deref(ctx.OriginalColumn)
+			}
+		}
+	}
+	if uri == "" {
+		return nil
+	}
+	location := &SARIFPhysicalLocation{ArtifactLocation: SARIFArtifactLocation{URI: uri}}
+	if line > 0 {
+		location.Region = &SARIFRegion{StartLine: line, StartColumn: column}
+	}
+	return location
+}
+
+// sarifURI renders the location of a document as a URI.
+func sarifURI(location string) string {
+	if strings.Contains(location, "://") {
+		return location
+	}
+	location = filepath.ToSlash(location)
+	if strings.HasPrefix(location, "/") {
+		return "file://" + location
+	}
+	return location
+}
@@ -0,0 +1,101 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateSARIFReport(t *testing.T) {
+	_, _, changes := comparePatchDocuments(t, nil)
+
+	b, err := CreateSARIFReport(changes, "specs/old.yaml", "specs/openapi.yaml")
+	require.NoError(t, err)
+
+	var log SARIFLog
+	require.NoError(t, json.Unmarshal(b, &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Equal(t, SARIFSchema, log.Schema)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "libopenapi", run.Tool.Driver.Name)
+	require.Len(t, run.Results, changes.TotalChanges())
+
+	results := make(map[string]*SARIFResult)
+	for _, r := range run.Results {
+		results[r.RuleID+" "+r.Message.Text] = r
+		assert.Equal(t, r.RuleID, run.Tool.Driver.Rules[r.RuleIndex].ID)
+	}
+
+	removed := results["breaking-object-removed '/toys' removed in $.paths"]
+	require.NotNil(t, removed, results)
+	assert.Equal(t, "error", removed.Level)
+	require.NotNil(t, removed.Locations[0].PhysicalLocation)
+	assert.Equal(t, "specs/old.yaml", removed.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "$.paths", removed.Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	description := results["change-modified 'description' changed from 'list pets' to 'list all the pets' in $.paths.pathItems['/pets'].get"]
+	require.NotNil(t, description, results)
+	assert.Equal(t, "note", description.Level)
+	assert.Equal(t, "`description` changed from `list pets` to `list all the pets` in `$.paths.pathItems['/pets'].get`",
+		description.Message.Markdown)
+	location := description.Locations[0].PhysicalLocation
+	assert.Equal(t, "specs/openapi.yaml", location.ArtifactLocation.URI)
+	assert.Equal(t, 8, location.Region.StartLine)
+	assert.Equal(t, 20, location.Region.StartColumn)
+	assert.Equal(t, false, description.Properties["breaking"])
+	assert.Equal(t, "info", description.Properties["severity"])
+	assert.NotEmpty(t, description.PartialFingerprints["changePath/v1"])
+	assert.Len(t, description.PartialFingerprints["changeId/v1"], 16)
+
+	// rules are only listed once, in order.
+	ids := make([]string, 0, len(run.Tool.Driver.Rules))
+	for _, r := range run.Tool.Driver.Rules {
+		ids = append(ids, r.ID)
+	}
+	assert.IsIncreasing(t, ids)
+}
+
+func TestNewSARIFLog_Locations(t *testing.T) {
+	line, column := 4, 3
+	removed := &model.Change{ChangeType: model.PropertyRemoved, Property: "example",
+		Context: &model.ChangeContext{OriginalLine: &line, OriginalColumn: &column}}
+	external := &model.Change{ChangeType: model.Modified, Property: "type", Breaking: true,
+		Context: &model.ChangeContext{DocumentLocation: "/specs/schemas.yaml", NewLine: &line}}
+	changes := &model.MediaTypeChanges{PropertyChanges: model.NewPropertyChanges([]*model.Change{removed, external})}
+
+	run := NewSARIFLog(changes, "", "openapi.yaml").Runs[0]
+	require.Len(t, run.Results, 2)
+
+	// without the original specification, removals are placed against the updated one.
+	assert.Equal(t, "warning", run.Results[0].Level)
+	location := run.Results[0].Locations[0].PhysicalLocation
+	assert.Equal(t, "openapi.yaml", location.ArtifactLocation.URI)
+	assert.Nil(t, location.Region)
+
+	assert.Equal(t, "error", run.Results[1].Level)
+	location = run.Results[1].Locations[0].PhysicalLocation
+	assert.Equal(t, "file:///specs/schemas.yaml", location.ArtifactLocation.URI)
+	assert.Equal(t, 4, location.Region.StartLine)
+
+	run = NewSARIFLog(changes, "", "").Runs[0]
+	assert.Nil(t, run.Results[0].Locations[0].PhysicalLocation)
+}
+
+func TestCreateSARIFReport_NoChanges(t *testing.T) {
+	var changes *model.DocumentChanges
+	b, err := CreateSARIFReport(changes, "", "openapi.yaml")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "version": "2.1.0",
+  "runs": [{"tool": {"driver": {"name": "libopenapi", "informationUri": "https://pb33f.io/libopenapi/", "rules": []}},
+    "results": []}]
+}`, string(b))
+}
@@ -0,0 +1,216 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"math"
+	"reflect"
+	"slices"
+
+	"github.com/pb33f/libopenapi/what-changed/model"
+)
+
+// Categories of changes used to weight changes when scoring the stability of an API (see ScoreStability).
+const (
+	// ScoreBreakingResponseRemoval is a breaking removal from a response, for example a property clients read.
+	ScoreBreakingResponseRemoval = "breakingResponseRemoval"
+
+	// ScoreBreakingRemoval is any other breaking removal, for example a request parameter or a path.
+	ScoreBreakingRemoval = "breakingRemoval"
+
+	// ScoreBreaking is any other breaking change, for example a type that was modified.
+	ScoreBreaking = "breaking"
+
+	// ScoreNonBreaking is a change that is not breaking, and is not documentation.
+	ScoreNonBreaking = "nonBreaking"
+
+	// ScoreDocumentation is a change that is not breaking, made to documentation only, for example a description.
+	ScoreDocumentation = "documentation"
+)
+
+var scoreCategories = []string{
+	ScoreBreakingResponseRemoval, ScoreBreakingRemoval, ScoreBreaking, ScoreNonBreaking, ScoreDocumentation,
+}
+
+// ScoreWeights determines how much each category of change counts against the stability score of an API.
+type ScoreWeights struct {
+	BreakingResponseRemoval float64 `json:"breakingResponseRemoval" yaml:"breakingResponseRemoval"`
+	BreakingRemoval         float64 `json:"breakingRemoval" yaml:"breakingRemoval"`
+	Breaking                float64 `json:"breaking" yaml:"breaking"`
+	NonBreaking             float64 `json:"nonBreaking" yaml:"nonBreaking"`
+	Documentation           float64 `json:"documentation" yaml:"documentation"`
+
+	// DocumentationProperties are the properties that only document an API. Changes made to them that are not
+	// breaking are ScoreDocumentation changes.
+	DocumentationProperties []string `json:"documentationProperties,omitempty" yaml:"documentationProperties,omitempty"`
+
+	// Scale is the penalty that halves the score. The larger the scale, the more changes it takes to lower the
+	// score. If it's zero (or less), 100 is used.
+	Scale float64 `json:"scale" yaml:"scale"`
+}
+
+// DefaultScoreWeights returns the default weights. A breaking removal from a response is the heaviest change,
+// followed by other breaking removals and then every other breaking change. Changes that are not breaking barely
+// count, and documentation edits don't count at all.
+func DefaultScoreWeights() *ScoreWeights {
+	return &ScoreWeights{
+		BreakingResponseRemoval: 10,
+		BreakingRemoval:         6,
+		Breaking:                4,
+		NonBreaking:             0.5,
+		Documentation:           0,
+		DocumentationProperties: []string{
+			model.PropDescription, model.PropSummary, model.PropTitle, model.PropExample, model.PropExamples,
+			model.PropExternalDocs, model.PropComment, model.PropTermsOfService,
+		},
+		Scale: 100,
+	}
+}
+
+// StabilityScore is a single number that describes how stable an API is between two versions of a document, from
+// 100 (nothing that matters changed) down towards 0, with a breakdown of how the score was reached.
+type StabilityScore struct {
+	Score    float64 `json:"score"`
+	Penalty  float64 `json:"penalty"`
+	Total    int     `json:"total"`
+	Breaking int     `json:"breaking"`
+
+	// Categories break the penalty down by the category of each change, heaviest category first. Categories
+	// without any changes are not listed.
+	Categories []*ScoreCategory `json:"categories,omitempty"`
+
+	// Areas break the penalty down by the top level area of the document each change was made to, for example
+	// 'paths' or 'components', in alphabetical order.
+	Areas []*ScoreArea `json:"areas,omitempty"`
+}
+
+// ScoreCategory is the number of changes in a category, and the penalty they add up to.
+type ScoreCategory struct {
+	Category string  `json:"category"`
+	Changes  int     `json:"changes"`
+	Weight   float64 `json:"weight"`
+	Penalty  float64 `json:"penalty"`
+}
+
+// ScoreArea is the number of changes made to an area of a document, and the penalty they add up to.
+type ScoreArea struct {
+	Area     string  `json:"area"`
+	Changes  int     `json:"changes"`
+	Breaking int     `json:"breaking"`
+	Penalty  float64 `json:"penalty"`
+}
+
+// ScoreStability weights every change made to a document (or any other tree of changes), and turns the total
+// penalty into a stability score, so the churn of an API can be tracked over time using one number. If weights is
+// nil, the DefaultScoreWeights are used.
+//
+// The score is 100 * scale / (scale + penalty), so it's 100 when the penalty is zero, 50 when the penalty is equal
+// to the scale, and gets closer to zero (without reaching it) as the penalty grows.
+func ScoreStability(changes HasChanges, weights *ScoreWeights) *StabilityScore {
+	if weights == nil {
+		weights = DefaultScoreWeights()
+	}
+	score := &StabilityScore{Score: 100}
+	if changes == nil || reflect.ValueOf(changes).IsNil() || changes.TotalChanges() == 0 {
+		return score
+	}
+
+	categories := make(map[string]*ScoreCategory)
+	areas := make(map[string]*ScoreArea)
+	for _, lc := range model.LocateChanges(changes) {
+		category := weights.categoryOf(lc)
+		weight := weights.weightOf(category)
+		score.Total++
+		score.Penalty += weight
+
+		c := categories[category]
+		if c == nil {
+			c = &ScoreCategory{Category: category, Weight: weight}
+			categories[category] = c
+		}
+		c.Changes++
+		c.Penalty += weight
+
+		name := "document"
+		if len(lc.Location) > 0 {
+			name = lc.Location[0]
+		}
+		a := areas[name]
+		if a == nil {
+			a = &ScoreArea{Area: name}
+			areas[name] = a
+			score.Areas = append(score.Areas, a)
+		}
+		a.Changes++
+		a.Penalty += weight
+		if lc.Change.Breaking {
+			score.Breaking++
+			a.Breaking++
+		}
+	}
+
+	for _, category := range scoreCategories {
+		if c := categories[category]; c != nil {
+			score.Categories = append(score.Categories, c)
+		}
+	}
+	slices.SortStableFunc(score.Categories, func(a, b *ScoreCategory) int {
+		switch {
+		case a.Penalty > b.Penalty:
+			return -1
+		case a.Penalty < b.Penalty:
+			return 1
+		}
+		return 0
+	})
+	slices.SortFunc(score.Areas, func(a, b *ScoreArea) int {
+		if a.Area < b.Area {
+			return -1
+		}
+		if a.Area > b.Area {
+			return 1
+		}
+		return 0
+	})
+
+	scale := weights.Scale
+	if scale <= 0 {
+		scale = 100
+	}
+	score.Score = math.Round(100*scale/(scale+score.Penalty)*100) / 100
+	return score
+}
+
+// categoryOf returns the category of a located change.
+func (w *ScoreWeights) categoryOf(lc *model.LocatedChange) string {
+	c := lc.Change
+	removed := c.ChangeType == model.PropertyRemoved || c.ChangeType == model.ObjectRemoved
+	switch {
+	case c.Breaking && removed:
+		if request, known := model.IsRequestLocation(lc.Location); known && !request {
+			return ScoreBreakingResponseRemoval
+		}
+		return ScoreBreakingRemoval
+	case c.Breaking:
+		return ScoreBreaking
+	case slices.Contains(w.DocumentationProperties, c.Property):
+		return ScoreDocumentation
+	}
+	return ScoreNonBreaking
+}
+
+// weightOf returns the weight of a category of change.
+func (w *ScoreWeights) weightOf(category string) float64 {
+	switch category {
+	case ScoreBreakingResponseRemoval:
+		return w.BreakingResponseRemoval
+	case ScoreBreakingRemoval:
+		return w.BreakingRemoval
+	case ScoreBreaking:
+		return w.Breaking
+	case ScoreDocumentation:
+		return w.Documentation
+	}
+	return w.NonBreaking
+}
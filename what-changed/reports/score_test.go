@@ -0,0 +1,107 @@
+// Copyright 2023-2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package reports
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScoreStability(t *testing.T) {
+	original, err := libopenapi.NewDocument([]byte(changelogOriginal))
+	require.NoError(t, err)
+	updated, err := libopenapi.NewDocument([]byte(changelogUpdated))
+	require.NoError(t, err)
+	changes, err := libopenapi.CompareDocuments(original, updated)
+	require.NoError(t, err)
+
+	score := ScoreStability(changes, nil)
+	assert.Equal(t, changes.TotalChanges(), score.Total)
+	assert.Equal(t, 1, score.Breaking)
+	assert.Equal(t, 6.5, score.Penalty)
+	assert.Equal(t, 93.9, score.Score)
+
+	require.Len(t, score.Categories, 3)
+	assert.Equal(t, ScoreBreakingRemoval, score.Categories[0].Category)
+	assert.Equal(t, 1, score.Categories[0].Changes)
+	assert.Equal(t, ScoreNonBreaking, score.Categories[1].Category)
+	assert.Equal(t, 1, score.Categories[1].Changes)
+	assert.Equal(t, ScoreDocumentation, score.Categories[2].Category)
+	assert.Equal(t, 4, score.Categories[2].Changes)
+	assert.Zero(t, score.Categories[2].Penalty)
+
+	require.Len(t, score.Areas, 2)
+	assert.Equal(t, "info", score.Areas[0].Area)
+	assert.Equal(t, 0.5, score.Areas[0].Penalty)
+	assert.Equal(t, "paths", score.Areas[1].Area)
+	assert.Equal(t, 5, score.Areas[1].Changes)
+	assert.Equal(t, 1, score.Areas[1].Breaking)
+	assert.Equal(t, 6.0, score.Areas[1].Penalty)
+}
+
+func TestScoreStability_ResponseRemoval(t *testing.T) {
+	left := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string
+                  name:
+                    type: string`
+
+	right := `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: string`
+
+	original, err := libopenapi.NewDocument([]byte(left))
+	require.NoError(t, err)
+	updated, err := libopenapi.NewDocument([]byte(right))
+	require.NoError(t, err)
+	changes, err := libopenapi.CompareDocuments(original, updated)
+	require.NoError(t, err)
+
+	weights := DefaultScoreWeights()
+	weights.Scale = 10
+	score := ScoreStability(changes, weights)
+	assert.Equal(t, 1, score.Breaking)
+	require.Len(t, score.Categories, 1)
+	assert.Equal(t, ScoreBreakingResponseRemoval, score.Categories[0].Category)
+	assert.Equal(t, 10.0, score.Penalty)
+	assert.Equal(t, 50.0, score.Score)
+}
+
+func TestScoreStability_NoChanges(t *testing.T) {
+	score := ScoreStability(nil, nil)
+	assert.Equal(t, 100.0, score.Score)
+	assert.Zero(t, score.Total)
+	assert.Empty(t, score.Categories)
+}
@@ -16,8 +16,11 @@
 package what_changed
 
 import (
+	"context"
+
 	"github.com/pb33f/libopenapi/datamodel/low/v2"
 	"github.com/pb33f/libopenapi/datamodel/low/v3"
+	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/what-changed/model"
 )
 
@@ -28,9 +31,33 @@ func CompareOpenAPIDocuments(original, updated *v3.Document) *model.DocumentChan
 	return model.CompareDocuments(original, updated)
 }
 
+// CompareOpenAPIDocumentsWithContext is the same as CompareOpenAPIDocumentsWithConfiguration, except the comparison
+// stops as soon as the context is cancelled or times out, in which case nil is returned. The configuration can be nil.
+func CompareOpenAPIDocumentsWithContext(ctx context.Context, original, updated *v3.Document,
+	configuration *ComparisonConfiguration,
+) *model.DocumentChanges {
+	return compareWithConfiguration(ctx, configuration, func(ctx context.Context) *model.DocumentChanges {
+		return model.CompareDocumentsWithContext(ctx, original, updated)
+	}, func() (*index.SpecIndex, *index.SpecIndex) {
+		return original.Index, updated.Index
+	})
+}
+
 // CompareSwaggerDocuments will compare left (original) and a right (updated) Swagger documents and extract every change
 // made across the entire specification. The report outlines every property changes, everything that was added,
 // or removed and which of those changes were breaking.
 func CompareSwaggerDocuments(original, updated *v2.Swagger) *model.DocumentChanges {
 	return model.CompareDocuments(original, updated)
 }
+
+// CompareSwaggerDocumentsWithContext is the same as CompareSwaggerDocumentsWithConfiguration, except the comparison
+// stops as soon as the context is cancelled or times out, in which case nil is returned. The configuration can be nil.
+func CompareSwaggerDocumentsWithContext(ctx context.Context, original, updated *v2.Swagger,
+	configuration *ComparisonConfiguration,
+) *model.DocumentChanges {
+	return compareWithConfiguration(ctx, configuration, func(ctx context.Context) *model.DocumentChanges {
+		return model.CompareDocumentsWithContext(ctx, original, updated)
+	}, func() (*index.SpecIndex, *index.SpecIndex) {
+		return original.Index, updated.Index
+	})
+}
@@ -279,10 +279,12 @@ func TestCheckExplodedFileCheck(t *testing.T) {
 	assert.Equal(t, 1, changes.TotalChanges())
 	assert.Equal(t, 0, changes.TotalBreakingChanges())
 
+	// the references are resolved, so the change made to the schema they point to is reported.
 	allChanges := changes.GetAllChanges()
 	assert.Len(t, allChanges, 1)
-	assert.Equal(t, "b.yaml#/components/schemas/SchemaB", allChanges[0].OriginalObject)
-	assert.Equal(t, "b-alt.yaml#/components/schemas/SchemaB", allChanges[0].NewObject)
+	assert.Equal(t, "description", allChanges[0].Property)
+	assert.Equal(t, "object C", allChanges[0].Original)
+	assert.Equal(t, "object C, but this has a changed description", allChanges[0].New)
 
 }
 
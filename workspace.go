@@ -0,0 +1,254 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// Workspace opens many root documents against a single set of rolodex file systems. Registry style applications
+// that index hundreds of interrelated specifications can use a Workspace so every shared file is only loaded,
+// parsed and indexed once, no matter how many documents reference it.
+//
+// Every document opened by a workspace shares the same local file system (rooted at the BasePath of the
+// workspace configuration) and the same remote file system (if a BaseURL is configured, or remote references
+// are allowed). Because of this, the rolodex of each document sees every file that the workspace has loaded
+// so far.
+//
+// Documents are opened one at a time, and their models are built as they are opened, so OpenDocument is safe to
+// call from multiple goroutines. Models that are invalidated and re-built after being opened are no longer
+// guarded by the workspace, and should not be re-built while other documents are being opened.
+type Workspace struct {
+	config    *datamodel.DocumentConfiguration
+	basePath  string
+	localFS   *index.LocalFS
+	dirFS     fs.FS // read instead of the disk, if set.
+	remoteFS  *index.RemoteFS
+	documents map[string]Document
+	lock      sync.Mutex
+}
+
+// NewWorkspace creates a new Workspace from the supplied configuration. The BasePath of the configuration is the
+// root of the shared local file system, if it is not set, the current working directory is used. The LocalFS and
+// RemoteFS properties of the configuration are ignored, as the workspace supplies its own, unless LocalFS is a plain
+// fs.FS (like an embed.FS), which the shared local file system then reads files from, instead of the disk. The
+// configuration can be nil.
+func NewWorkspace(configuration *datamodel.DocumentConfiguration) (*Workspace, error) {
+	if configuration == nil {
+		configuration = datamodel.NewDocumentConfiguration()
+	}
+	basePath, err := filepath.Abs(configuration.BasePath)
+	if err != nil {
+		return nil, err
+	}
+
+	idxConfig := index.CreateClosedAPIIndexConfig()
+	idxConfig.BaseURL = configuration.BaseURL
+	idxConfig.BasePath = basePath
+	idxConfig.RemoteURLHandler = configuration.RemoteURLHandler
+	idxConfig.Logger = configuration.Logger
+	idxConfig.SubsystemLoggers = configuration.SubsystemLoggers
+	idxConfig.BuildEventHandler = configuration.BuildEventHandler
+	idxConfig.IndexingProgressHandler = configuration.IndexingProgressHandler
+	idxConfig.SlowRemoteFetchThreshold = configuration.SlowRemoteFetchThreshold
+	idxConfig.RemoteCacheDir = configuration.RemoteCacheDir
+	idxConfig.RemoteCacheTTL = configuration.RemoteCacheTTL
+	idxConfig.RemoteAuth = configuration.RemoteAuth
+	idxConfig.ObjectStores = configuration.ObjectStores
+	idxConfig.AllowGitReferences = configuration.AllowGitReferences
+	idxConfig.GitCacheDir = configuration.GitCacheDir
+	idxConfig.FileTransformers = configuration.FileTransformers
+	idxConfig.UseSchemaQuickHash = configuration.UseSchemaQuickHash
+	idxConfig.ExcludeExtensionRefs = configuration.ExcludeExtensionRefs
+	idxConfig.SkipRemoteReferences = configuration.SkipRemoteReferences
+	idxConfig.IgnoreArrayCircularReferences = configuration.IgnoreArrayCircularReferences
+	idxConfig.IgnorePolymorphicCircularReferences = configuration.IgnorePolymorphicCircularReferences
+	idxConfig.AllowUnknownExtensionContentDetection = configuration.AllowUnknownExtensionContentDetection
+	idxConfig.TransformSiblingRefs = configuration.TransformSiblingRefs
+	idxConfig.MergeReferencedProperties = configuration.MergeReferencedProperties
+	idxConfig.PropertyMergeStrategy = configuration.PropertyMergeStrategy
+	idxConfig.DeduplicateFilesByContent = configuration.DeduplicateFilesByContent
+	idxConfig.FileContentCacheSize = configuration.FileContentCacheSize
+	idxConfig.ExtractRefsSequentially = configuration.ExtractRefsSequentially
+	idxConfig.AvoidCircularReferenceCheck = true
+	idxConfig.AllowFileLookup = true
+
+	localFSConfig := &index.LocalFSConfig{
+		BaseDirectory: basePath,
+		IndexConfig:   idxConfig,
+		FileFilters:   configuration.FileFilter,
+	}
+	if _, ok := configuration.LocalFS.(index.RolodexFS); !ok && configuration.LocalFS != nil {
+		localFSConfig.DirFS = configuration.LocalFS
+		localFSConfig.LazyDirFS = true
+	}
+	localFS, err := index.NewLocalFSWithConfig(localFSConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workspace{
+		config:    configuration,
+		basePath:  basePath,
+		localFS:   localFS,
+		dirFS:     localFSConfig.DirFS,
+		documents: make(map[string]Document),
+	}
+	if configuration.BaseURL != nil || configuration.AllowRemoteReferences || len(configuration.ObjectStores) > 0 || configuration.AllowGitReferences {
+		idxConfig.AllowRemoteLookup = true
+		if w.remoteFS, err = index.NewRemoteFSWithConfig(idxConfig); err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// OpenDocument opens the root document at the supplied path (relative paths are relative to the BasePath of the
+// workspace), and builds its model. If the document has already been opened, the same Document is returned.
+//
+// Like BuildV3Model() and BuildV2Model(), the document is returned along with any errors that occurred when
+// building its model (such as circular references). If the model could not be built at all, no document is returned.
+func (w *Workspace) OpenDocument(path string) (Document, error) {
+	path = w.absolutePath(path)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if doc, ok := w.documents[path]; ok {
+		return doc, nil
+	}
+
+	spec, err := w.readFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := w.documentConfiguration()
+	config.BasePath = filepath.Dir(path)
+	config.SpecFilePath = filepath.Base(path)
+	return w.buildDocument(path, spec, config)
+}
+
+// OpenDocuments opens (and builds) every root document at the supplied paths, see OpenDocument, and returns them keyed
+// by their absolute path. Documents are opened in the order they are supplied, so files shared between them are
+// loaded and indexed by the first document that references them, and re-used by every document after it.
+//
+// Every document that could be opened is returned, along with the errors for those that could not (or that had
+// errors building their model).
+func (w *Workspace) OpenDocuments(paths ...string) (map[string]Document, error) {
+	documents := make(map[string]Document, len(paths))
+	var errs []error
+	for _, path := range paths {
+		doc, err := w.OpenDocument(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to open document '%s': %w", path, err))
+		}
+		if doc != nil {
+			documents[w.absolutePath(path)] = doc
+		}
+	}
+	return documents, errors.Join(errs...)
+}
+
+// documentConfiguration returns a copy of the workspace configuration, using the shared file systems.
+func (w *Workspace) documentConfiguration() *datamodel.DocumentConfiguration {
+	config := *w.config
+	config.LocalFS = w.localFS
+	config.RemoteFS = nil
+	if w.remoteFS != nil {
+		config.RemoteFS = w.remoteFS // avoid a typed nil, the rolodex checks for a nil interface.
+	}
+	return &config
+}
+
+// buildDocument creates a document and builds its model, adding it to the workspace under the supplied key.
+// The workspace must be locked.
+func (w *Workspace) buildDocument(key string, spec []byte, config *datamodel.DocumentConfiguration) (Document, error) {
+	doc, err := NewDocumentWithConfiguration(spec, config)
+	if err != nil {
+		return nil, err
+	}
+
+	var buildErr error
+	switch doc.GetSpecInfo().SpecType {
+	case utils.OpenApi3:
+		m, err := doc.BuildV3Model()
+		if m == nil {
+			return nil, err
+		}
+		buildErr = err
+	case utils.OpenApi2:
+		m, err := doc.BuildV2Model()
+		if m == nil {
+			return nil, err
+		}
+		buildErr = err
+	}
+	w.documents[key] = doc
+	return doc, buildErr
+}
+
+// GetDocuments returns every document opened by the workspace, keyed by the absolute path of the document (or the
+// URL of documents opened from a remote location, see OpenArazzoSources).
+func (w *Workspace) GetDocuments() map[string]Document {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	documents := make(map[string]Document, len(w.documents))
+	for k, v := range w.documents {
+		documents[k] = v
+	}
+	return documents
+}
+
+// GetLocalFS returns the local file system shared by every document in the workspace.
+func (w *Workspace) GetLocalFS() *index.LocalFS {
+	return w.localFS
+}
+
+// GetRemoteFS returns the remote file system shared by every document in the workspace, or nil if remote
+// references are not enabled.
+func (w *Workspace) GetRemoteFS() *index.RemoteFS {
+	return w.remoteFS
+}
+
+// CloseDocument removes a document from the workspace, so the next call to OpenDocument for the same path will
+// read, and build it again. Files that were loaded into the shared file systems on behalf of the document are kept.
+func (w *Workspace) CloseDocument(path string) error {
+	path = w.absolutePath(path)
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if _, ok := w.documents[path]; !ok {
+		return fmt.Errorf("unable to close document '%s', it has not been opened by the workspace", path)
+	}
+	delete(w.documents, path)
+	return nil
+}
+
+// readFile reads a root document from the disk, or from the fs.FS the workspace reads instead.
+func (w *Workspace) readFile(path string) ([]byte, error) {
+	if w.dirFS == nil {
+		return os.ReadFile(path)
+	}
+	rel, err := filepath.Rel(w.basePath, path)
+	if err != nil {
+		return nil, err
+	}
+	return fs.ReadFile(w.dirFS, filepath.ToSlash(rel))
+}
+
+// absolutePath resolves a document path relative to the BasePath of the workspace.
+func (w *Workspace) absolutePath(path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(w.basePath, path)
+	}
+	return filepath.Clean(path)
+}
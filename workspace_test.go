@@ -0,0 +1,204 @@
+// Copyright 2022-2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package libopenapi
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/pb33f/libopenapi/internal/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findRolodexIndex(rolodex *index.Rolodex, path string) *index.SpecIndex {
+	for _, idx := range rolodex.GetIndexes() {
+		if idx.GetSpecAbsolutePath() == path {
+			return idx
+		}
+	}
+	return nil
+}
+
+func TestWorkspace_OpenDocument_SharedFiles(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"shared.yaml": `components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`,
+		"pets.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'shared.yaml#/components/schemas/Pet'`,
+		"nested/shelters.yaml": `openapi: 3.1.0
+info:
+  title: Shelters
+  version: 1.0.0
+components:
+  schemas:
+    Resident:
+      $ref: '../shared.yaml#/components/schemas/Pet'`,
+		"swagger.yaml": `swagger: 2.0
+info:
+  title: Old Pets
+  version: 1.0.0
+definitions:
+  Pet:
+    $ref: 'shared.yaml#/components/schemas/Pet'`,
+	})
+
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: dir})
+	require.NoError(t, err)
+
+	pets, err := ws.OpenDocument("pets.yaml")
+	require.NoError(t, err)
+	shelters, err := ws.OpenDocument(filepath.Join(dir, "nested", "shelters.yaml"))
+	require.NoError(t, err)
+	swagger, err := ws.OpenDocument("swagger.yaml")
+	require.NoError(t, err)
+
+	petsModel, _ := pets.BuildV3Model()
+	sheltersModel, _ := shelters.BuildV3Model()
+	swaggerModel, _ := swagger.BuildV2Model()
+	assert.Equal(t, "object", petsModel.Model.Components.Schemas.GetOrZero("Pet").Schema().Type[0])
+	assert.Equal(t, "object", sheltersModel.Model.Components.Schemas.GetOrZero("Resident").Schema().Type[0])
+	assert.Equal(t, "object", swaggerModel.Model.Definitions.Definitions.GetOrZero("Pet").Schema().Type[0])
+
+	// the shared file was loaded and indexed once, and that index is used by every document.
+	shared := filepath.Join(dir, "shared.yaml")
+	assert.Len(t, ws.GetLocalFS().GetFiles(), 1)
+	sharedIndex := findRolodexIndex(pets.GetRolodex(), shared)
+	require.NotNil(t, sharedIndex)
+	assert.Same(t, sharedIndex, findRolodexIndex(shelters.GetRolodex(), shared))
+	assert.Same(t, sharedIndex, findRolodexIndex(swagger.GetRolodex(), shared))
+	assert.NotSame(t, pets.GetRolodex(), shelters.GetRolodex())
+	assert.Nil(t, ws.GetRemoteFS())
+	assert.Len(t, ws.GetDocuments(), 3)
+}
+
+func TestWorkspace_OpenDocument_Cached(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"pets.yaml": "openapi: 3.1.0\ninfo:\n  title: Pets\n  version: 1.0.0\n",
+	})
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: dir})
+	require.NoError(t, err)
+
+	first, err := ws.OpenDocument("pets.yaml")
+	require.NoError(t, err)
+	second, err := ws.OpenDocument(filepath.Join(dir, "pets.yaml"))
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	require.NoError(t, ws.CloseDocument("pets.yaml"))
+	assert.Empty(t, ws.GetDocuments())
+	third, err := ws.OpenDocument("pets.yaml")
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+
+	assert.Error(t, ws.CloseDocument("nope.yaml"))
+}
+
+func TestWorkspace_OpenDocument_Errors(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"bad.yaml":    "not: an openapi spec",
+		"broken.yaml": "openapi: 3.1.0\npaths: {",
+	})
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: dir})
+	require.NoError(t, err)
+
+	_, err = ws.OpenDocument("missing.yaml")
+	assert.Error(t, err)
+	_, err = ws.OpenDocument("broken.yaml")
+	assert.Error(t, err)
+	assert.Empty(t, ws.GetDocuments())
+}
+
+func TestWorkspace_OpenDocuments(t *testing.T) {
+	dir := testutil.WriteFiles(t, map[string]string{
+		"shared.yaml": "components:\n  schemas:\n    Pet:\n      type: object\n",
+		"pets.yaml": `openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: 'shared.yaml#/components/schemas/Pet'`,
+		"shelters.yaml": `openapi: 3.1.0
+info:
+  title: Shelters
+  version: 1.0.0
+components:
+  schemas:
+    Resident:
+      $ref: 'shared.yaml#/components/schemas/Pet'`,
+	})
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: dir})
+	require.NoError(t, err)
+
+	docs, err := ws.OpenDocuments("pets.yaml", "shelters.yaml", "missing.yaml")
+	assert.ErrorContains(t, err, "unable to open document 'missing.yaml'")
+	require.Len(t, docs, 2)
+
+	pets := docs[filepath.Join(dir, "pets.yaml")]
+	shelters := docs[filepath.Join(dir, "shelters.yaml")]
+	require.NotNil(t, pets)
+	require.NotNil(t, shelters)
+
+	// the shared file was only loaded once, by the first document.
+	shared := filepath.Join(dir, "shared.yaml")
+	assert.Len(t, ws.GetLocalFS().GetFiles(), 1)
+	assert.Same(t, findRolodexIndex(pets.GetRolodex(), shared), findRolodexIndex(shelters.GetRolodex(), shared))
+	assert.Equal(t, ws.GetDocuments(), docs)
+}
+
+func TestNewWorkspace_Defaults(t *testing.T) {
+	ws, err := NewWorkspace(nil)
+	require.NoError(t, err)
+	cwd, _ := os.Getwd()
+	assert.Equal(t, cwd, ws.basePath)
+	assert.Nil(t, ws.GetRemoteFS())
+
+	ws, err = NewWorkspace(&datamodel.DocumentConfiguration{AllowRemoteReferences: true})
+	require.NoError(t, err)
+	assert.NotNil(t, ws.GetRemoteFS())
+}
+
+func TestWorkspace_OpenDocument_PlainFS(t *testing.T) {
+	specs := fstest.MapFS{
+		"shared.yaml": {Data: []byte("components:\n  schemas:\n    Pet:\n      type: object")},
+		"nested/pets.yaml": {Data: []byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      $ref: '../shared.yaml#/components/schemas/Pet'`)},
+	}
+	base := filepath.Join(string(filepath.Separator), "embedded")
+	ws, err := NewWorkspace(&datamodel.DocumentConfiguration{BasePath: base, LocalFS: specs})
+	require.NoError(t, err)
+
+	pets, err := ws.OpenDocument("nested/pets.yaml")
+	require.NoError(t, err)
+	petsModel, _ := pets.BuildV3Model()
+	assert.Equal(t, "object", petsModel.Model.Components.Schemas.GetOrZero("Pet").Schema().Type[0])
+	assert.Len(t, ws.GetLocalFS().GetFiles(), 1)
+
+	_, err = ws.OpenDocument("missing.yaml")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+}